@@ -327,3 +327,258 @@ func (c *metricClient) RefreshWorkflowTasks(
 	}
 	return resp, err
 }
+
+func (c *metricClient) ResetWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ResetWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ResetWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientResetWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientResetWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.ResetWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientResetWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ExportWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ExportWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ExportWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientExportWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientExportWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.ExportWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientExportWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) ImportWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ImportWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ImportWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientImportWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientImportWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.ImportWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientImportWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DeleteNamespace(
+	ctx context.Context,
+	request *adminservice.DeleteNamespaceRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DeleteNamespaceResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDeleteNamespaceScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDeleteNamespaceScope, metrics.ClientLatency)
+	resp, err := c.client.DeleteNamespace(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDeleteNamespaceScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetReplicationStatus(
+	ctx context.Context,
+	request *adminservice.GetReplicationStatusRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetReplicationStatusResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientGetReplicationStatusScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientGetReplicationStatusScope, metrics.ClientLatency)
+	resp, err := c.client.GetReplicationStatus(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientGetReplicationStatusScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) AddOrUpdateRemoteCluster(
+	ctx context.Context,
+	request *adminservice.AddOrUpdateRemoteClusterRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.AddOrUpdateRemoteClusterResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientAddOrUpdateRemoteClusterScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientAddOrUpdateRemoteClusterScope, metrics.ClientLatency)
+	resp, err := c.client.AddOrUpdateRemoteCluster(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientAddOrUpdateRemoteClusterScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) RemoveRemoteCluster(
+	ctx context.Context,
+	request *adminservice.RemoveRemoteClusterRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RemoveRemoteClusterResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientRemoveRemoteClusterScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientRemoveRemoteClusterScope, metrics.ClientLatency)
+	resp, err := c.client.RemoveRemoteCluster(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientRemoveRemoteClusterScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) SetShardOwner(
+	ctx context.Context,
+	request *adminservice.SetShardOwnerRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.SetShardOwnerResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientSetShardOwnerScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientSetShardOwnerScope, metrics.ClientLatency)
+	resp, err := c.client.SetShardOwner(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientSetShardOwnerScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DrainHost(
+	ctx context.Context,
+	request *adminservice.DrainHostRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DrainHostResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDrainHostScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDrainHostScope, metrics.ClientLatency)
+	resp, err := c.client.DrainHost(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDrainHostScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetDynamicConfig(
+	ctx context.Context,
+	request *adminservice.GetDynamicConfigRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetDynamicConfigResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientGetDynamicConfigScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientGetDynamicConfigScope, metrics.ClientLatency)
+	resp, err := c.client.GetDynamicConfig(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientGetDynamicConfigScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) UpdateDynamicConfig(
+	ctx context.Context,
+	request *adminservice.UpdateDynamicConfigRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateDynamicConfigResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientUpdateDynamicConfigScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientUpdateDynamicConfigScope, metrics.ClientLatency)
+	resp, err := c.client.UpdateDynamicConfig(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientUpdateDynamicConfigScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DeleteDynamicConfig(
+	ctx context.Context,
+	request *adminservice.DeleteDynamicConfigRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DeleteDynamicConfigResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDeleteDynamicConfigScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDeleteDynamicConfigScope, metrics.ClientLatency)
+	resp, err := c.client.DeleteDynamicConfig(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDeleteDynamicConfigScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetDynamicConfigHistory(
+	ctx context.Context,
+	request *adminservice.GetDynamicConfigHistoryRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetDynamicConfigHistoryResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientGetDynamicConfigHistoryScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientGetDynamicConfigHistoryScope, metrics.ClientLatency)
+	resp, err := c.client.GetDynamicConfigHistory(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientGetDynamicConfigHistoryScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) RollbackDynamicConfig(
+	ctx context.Context,
+	request *adminservice.RollbackDynamicConfigRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RollbackDynamicConfigResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientRollbackDynamicConfigScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientRollbackDynamicConfigScope, metrics.ClientLatency)
+	resp, err := c.client.RollbackDynamicConfig(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientRollbackDynamicConfigScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetAuditLog(
+	ctx context.Context,
+	request *adminservice.GetAuditLogRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetAuditLogResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientGetAuditLogScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.AdminClientGetAuditLogScope, metrics.ClientLatency)
+	resp, err := c.client.GetAuditLog(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientGetAuditLogScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
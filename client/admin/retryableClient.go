@@ -301,3 +301,243 @@ func (c *retryableClient) RefreshWorkflowTasks(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) ResetWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ResetWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ResetWorkflowExecutionResponse, error) {
+
+	var resp *adminservice.ResetWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ResetWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ExportWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ExportWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ExportWorkflowExecutionResponse, error) {
+
+	var resp *adminservice.ExportWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ExportWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) ImportWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ImportWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.ImportWorkflowExecutionResponse, error) {
+
+	var resp *adminservice.ImportWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ImportWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DeleteNamespace(
+	ctx context.Context,
+	request *adminservice.DeleteNamespaceRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DeleteNamespaceResponse, error) {
+
+	var resp *adminservice.DeleteNamespaceResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DeleteNamespace(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetReplicationStatus(
+	ctx context.Context,
+	request *adminservice.GetReplicationStatusRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetReplicationStatusResponse, error) {
+
+	var resp *adminservice.GetReplicationStatusResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetReplicationStatus(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) AddOrUpdateRemoteCluster(
+	ctx context.Context,
+	request *adminservice.AddOrUpdateRemoteClusterRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.AddOrUpdateRemoteClusterResponse, error) {
+
+	var resp *adminservice.AddOrUpdateRemoteClusterResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.AddOrUpdateRemoteCluster(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) RemoveRemoteCluster(
+	ctx context.Context,
+	request *adminservice.RemoveRemoteClusterRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RemoveRemoteClusterResponse, error) {
+
+	var resp *adminservice.RemoveRemoteClusterResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.RemoveRemoteCluster(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) SetShardOwner(
+	ctx context.Context,
+	request *adminservice.SetShardOwnerRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.SetShardOwnerResponse, error) {
+
+	var resp *adminservice.SetShardOwnerResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.SetShardOwner(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DrainHost(
+	ctx context.Context,
+	request *adminservice.DrainHostRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DrainHostResponse, error) {
+
+	var resp *adminservice.DrainHostResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DrainHost(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetDynamicConfig(
+	ctx context.Context,
+	request *adminservice.GetDynamicConfigRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetDynamicConfigResponse, error) {
+
+	var resp *adminservice.GetDynamicConfigResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetDynamicConfig(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) UpdateDynamicConfig(
+	ctx context.Context,
+	request *adminservice.UpdateDynamicConfigRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.UpdateDynamicConfigResponse, error) {
+
+	var resp *adminservice.UpdateDynamicConfigResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.UpdateDynamicConfig(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DeleteDynamicConfig(
+	ctx context.Context,
+	request *adminservice.DeleteDynamicConfigRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.DeleteDynamicConfigResponse, error) {
+
+	var resp *adminservice.DeleteDynamicConfigResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DeleteDynamicConfig(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetDynamicConfigHistory(
+	ctx context.Context,
+	request *adminservice.GetDynamicConfigHistoryRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetDynamicConfigHistoryResponse, error) {
+
+	var resp *adminservice.GetDynamicConfigHistoryResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetDynamicConfigHistory(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) RollbackDynamicConfig(
+	ctx context.Context,
+	request *adminservice.RollbackDynamicConfigRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.RollbackDynamicConfigResponse, error) {
+
+	var resp *adminservice.RollbackDynamicConfigResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.RollbackDynamicConfig(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetAuditLog(
+	ctx context.Context,
+	request *adminservice.GetAuditLogRequest,
+	opts ...grpc.CallOption,
+) (*adminservice.GetAuditLogResponse, error) {
+
+	var resp *adminservice.GetAuditLogResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetAuditLog(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/client/matching"
 	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/service/config"
 )
 
 type (
@@ -58,9 +59,11 @@ type (
 		currentCluster        string
 		historyClient         history.Client
 		matchingClient        atomic.Value
+		clusterMetadata       cluster.Metadata
+		factory               Factory
+		remoteClientsLock     sync.RWMutex
 		remoteAdminClients    map[string]admin.Client
 		remoteFrontendClients map[string]frontend.Client
-		factory               Factory
 	}
 )
 
@@ -80,19 +83,7 @@ func NewClientBean(factory Factory, clusterMetadata cluster.Metadata) (Bean, err
 			continue
 		}
 
-		adminClient, err := factory.NewAdminClientWithTimeout(
-			info.RPCAddress,
-			admin.DefaultTimeout,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		remoteFrontendClient, err := factory.NewFrontendClientWithTimeout(
-			info.RPCAddress,
-			frontend.DefaultTimeout,
-			frontend.DefaultLongPollTimeout,
-		)
+		adminClient, remoteFrontendClient, err := createRemoteClients(factory, info)
 		if err != nil {
 			return nil, err
 		}
@@ -101,13 +92,16 @@ func NewClientBean(factory Factory, clusterMetadata cluster.Metadata) (Bean, err
 		remoteFrontendClients[clusterName] = remoteFrontendClient
 	}
 
-	return &clientBeanImpl{
+	bean := &clientBeanImpl{
 		currentCluster:        clusterMetadata.GetCurrentClusterName(),
+		clusterMetadata:       clusterMetadata,
 		factory:               factory,
 		historyClient:         historyClient,
 		remoteAdminClients:    remoteAdminClients,
 		remoteFrontendClients: remoteFrontendClients,
-	}, nil
+	}
+	clusterMetadata.RegisterMetadataChangeCallback(bean, bean.clusterMetadataChangeCallback)
+	return bean, nil
 }
 
 func (h *clientBeanImpl) GetHistoryClient() history.Client {
@@ -134,50 +128,80 @@ func (h *clientBeanImpl) SetMatchingClient(
 }
 
 func (h *clientBeanImpl) GetFrontendClient() frontend.Client {
+	h.remoteClientsLock.RLock()
+	defer h.remoteClientsLock.RUnlock()
+
 	return h.remoteFrontendClients[h.currentCluster]
 }
 
 func (h *clientBeanImpl) SetFrontendClient(
 	client frontend.Client,
 ) {
+	h.remoteClientsLock.Lock()
+	defer h.remoteClientsLock.Unlock()
+
 	h.remoteFrontendClients[h.currentCluster] = client
 }
 
 func (h *clientBeanImpl) GetRemoteAdminClient(cluster string) admin.Client {
+	h.remoteClientsLock.RLock()
 	client, ok := h.remoteAdminClients[cluster]
-	if !ok {
+	h.remoteClientsLock.RUnlock()
+	if ok {
+		return client
+	}
+
+	if err := h.lazyInitRemoteClients(cluster); err != nil {
 		panic(fmt.Sprintf(
 			"Unknown cluster name: %v with given cluster client map: %v.",
 			cluster,
 			h.remoteAdminClients,
 		))
 	}
-	return client
+
+	h.remoteClientsLock.RLock()
+	defer h.remoteClientsLock.RUnlock()
+	return h.remoteAdminClients[cluster]
 }
 
 func (h *clientBeanImpl) SetRemoteAdminClient(
 	cluster string,
 	client admin.Client,
 ) {
+	h.remoteClientsLock.Lock()
+	defer h.remoteClientsLock.Unlock()
+
 	h.remoteAdminClients[cluster] = client
 }
 
 func (h *clientBeanImpl) GetRemoteFrontendClient(cluster string) frontend.Client {
+	h.remoteClientsLock.RLock()
 	client, ok := h.remoteFrontendClients[cluster]
-	if !ok {
+	h.remoteClientsLock.RUnlock()
+	if ok {
+		return client
+	}
+
+	if err := h.lazyInitRemoteClients(cluster); err != nil {
 		panic(fmt.Sprintf(
 			"Unknown cluster name: %v with given cluster client map: %v.",
 			cluster,
 			h.remoteFrontendClients,
 		))
 	}
-	return client
+
+	h.remoteClientsLock.RLock()
+	defer h.remoteClientsLock.RUnlock()
+	return h.remoteFrontendClients[cluster]
 }
 
 func (h *clientBeanImpl) SetRemoteFrontendClient(
 	cluster string,
 	client frontend.Client,
 ) {
+	h.remoteClientsLock.Lock()
+	defer h.remoteClientsLock.Unlock()
+
 	h.remoteFrontendClients[cluster] = client
 }
 
@@ -194,3 +218,78 @@ func (h *clientBeanImpl) lazyInitMatchingClient(namespaceIDToName NamespaceIDToN
 	h.matchingClient.Store(client)
 	return client, nil
 }
+
+// lazyInitRemoteClients creates the clients of a cluster which was added after the bean was created
+func (h *clientBeanImpl) lazyInitRemoteClients(cluster string) error {
+	info, ok := h.clusterMetadata.GetAllClusterInfo()[cluster]
+	if !ok || !info.Enabled {
+		return fmt.Errorf("cluster %v is not an enabled cluster", cluster)
+	}
+
+	h.remoteClientsLock.Lock()
+	defer h.remoteClientsLock.Unlock()
+
+	if _, ok := h.remoteAdminClients[cluster]; ok {
+		return nil
+	}
+	adminClient, remoteFrontendClient, err := createRemoteClients(h.factory, info)
+	if err != nil {
+		return err
+	}
+	h.remoteAdminClients[cluster] = adminClient
+	h.remoteFrontendClients[cluster] = remoteFrontendClient
+	return nil
+}
+
+// clusterMetadataChangeCallback drops the clients of removed or disabled clusters and
+// recreates the clients of clusters whose address changed
+func (h *clientBeanImpl) clusterMetadataChangeCallback(
+	oldClusterMetadata map[string]*config.ClusterInformation,
+	newClusterMetadata map[string]*config.ClusterInformation,
+) {
+	h.remoteClientsLock.Lock()
+	defer h.remoteClientsLock.Unlock()
+
+	for clusterName, newInfo := range newClusterMetadata {
+		if clusterName == h.currentCluster {
+			continue
+		}
+		delete(h.remoteAdminClients, clusterName)
+		delete(h.remoteFrontendClients, clusterName)
+		if newInfo == nil || !newInfo.Enabled {
+			continue
+		}
+
+		adminClient, remoteFrontendClient, err := createRemoteClients(h.factory, *newInfo)
+		if err != nil {
+			// clients will be created on first use
+			continue
+		}
+		h.remoteAdminClients[clusterName] = adminClient
+		h.remoteFrontendClients[clusterName] = remoteFrontendClient
+	}
+}
+
+func createRemoteClients(
+	factory Factory,
+	info config.ClusterInformation,
+) (admin.Client, frontend.Client, error) {
+
+	adminClient, err := factory.NewAdminClientWithTimeout(
+		info.RPCAddress,
+		admin.DefaultTimeout,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	remoteFrontendClient, err := factory.NewFrontendClientWithTimeout(
+		info.RPCAddress,
+		frontend.DefaultTimeout,
+		frontend.DefaultLongPollTimeout,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return adminClient, remoteFrontendClient, nil
+}
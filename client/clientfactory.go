@@ -40,6 +40,7 @@ import (
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/membership"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/resharding"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
@@ -64,12 +65,12 @@ type (
 	NamespaceIDToNameFunc func(string) (string, error)
 
 	rpcClientFactory struct {
-		rpcFactory            common.RPCFactory
-		monitor               membership.Monitor
-		metricsClient         metrics.Client
-		dynConfig             *dynamicconfig.Collection
-		numberOfHistoryShards int
-		logger                log.Logger
+		rpcFactory         common.RPCFactory
+		monitor            membership.Monitor
+		metricsClient      metrics.Client
+		dynConfig          *dynamicconfig.Collection
+		historyShardLayout resharding.Layout
+		logger             log.Logger
 	}
 )
 
@@ -79,16 +80,16 @@ func NewRPCClientFactory(
 	monitor membership.Monitor,
 	metricsClient metrics.Client,
 	dc *dynamicconfig.Collection,
-	numberOfHistoryShards int,
+	historyShardLayout resharding.Layout,
 	logger log.Logger,
 ) Factory {
 	return &rpcClientFactory{
-		rpcFactory:            rpcFactory,
-		monitor:               monitor,
-		metricsClient:         metricsClient,
-		dynConfig:             dc,
-		numberOfHistoryShards: numberOfHistoryShards,
-		logger:                logger,
+		rpcFactory:         rpcFactory,
+		monitor:            monitor,
+		metricsClient:      metricsClient,
+		dynConfig:          dc,
+		historyShardLayout: historyShardLayout,
+		logger:             logger,
 	}
 }
 
@@ -123,7 +124,7 @@ func (cf *rpcClientFactory) NewHistoryClientWithTimeout(timeout time.Duration) (
 		return historyservice.NewHistoryServiceClient(connection), nil
 	}
 
-	client := history.NewClient(cf.numberOfHistoryShards, timeout, common.NewClientCache(keyResolver, clientProvider), cf.logger)
+	client := history.NewClient(cf.historyShardLayout, resolver, timeout, common.NewClientCache(keyResolver, clientProvider), cf.logger)
 	if cf.metricsClient != nil {
 		client = history.NewMetricClient(client, cf.metricsClient)
 	}
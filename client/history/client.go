@@ -26,6 +26,7 @@ package history
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -37,6 +38,8 @@ import (
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/resharding"
 )
 
 var _ Client = (*clientImpl)(nil)
@@ -47,7 +50,8 @@ const (
 )
 
 type clientImpl struct {
-	numberOfShards  int
+	shardLayout     resharding.Layout
+	resolver        membership.ServiceResolver
 	tokenSerializer common.TaskTokenSerializer
 	timeout         time.Duration
 	clients         common.ClientCache
@@ -56,13 +60,15 @@ type clientImpl struct {
 
 // NewClient creates a new history service gRPC client
 func NewClient(
-	numberOfShards int,
+	shardLayout resharding.Layout,
+	resolver membership.ServiceResolver,
 	timeout time.Duration,
 	clients common.ClientCache,
 	logger log.Logger,
 ) Client {
 	return &clientImpl{
-		numberOfShards:  numberOfShards,
+		shardLayout:     shardLayout,
+		resolver:        resolver,
 		tokenSerializer: common.NewProtoTaskTokenSerializer(),
 		timeout:         timeout,
 		clients:         clients,
@@ -142,6 +148,29 @@ func (c *clientImpl) PollMutableState(
 	return response, nil
 }
 
+func (c *clientImpl) WatchMutableState(
+	ctx context.Context,
+	request *historyservice.WatchMutableStateRequest,
+	opts ...grpc.CallOption) (historyservice.HistoryService_WatchMutableStateClient, error) {
+	client, err := c.getClientForWorkflowID(request.Execution.WorkflowId)
+	if err != nil {
+		return nil, err
+	}
+
+	var stream historyservice.HistoryService_WatchMutableStateClient
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		// the stream is open as long as the caller context, it has no timeout
+		stream, err = client.WatchMutableState(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
 func (c *clientImpl) DescribeHistoryHost(
 	ctx context.Context,
 	request *historyservice.DescribeHistoryHostRequest,
@@ -1031,17 +1060,189 @@ func (c *clientImpl) RefreshWorkflowTasks(
 	return response, nil
 }
 
+func (c *clientImpl) ImportWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.ImportWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ImportWorkflowExecutionResponse, error) {
+	client, err := c.getClientForWorkflowID(request.GetRequest().GetExport().GetExecution().GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.ImportWorkflowExecutionResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.ImportWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.DeleteWorkflowExecutionResponse, error) {
+	client, err := c.getClientForWorkflowID(request.GetExecution().GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.DeleteWorkflowExecutionResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.DeleteWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) GetReplicationStatus(
+	ctx context.Context,
+	request *historyservice.GetReplicationStatusRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.GetReplicationStatusResponse, error) {
+	shardIDs := request.GetShardIds()
+	if len(shardIDs) == 0 {
+		for shardID := 0; shardID < c.shardLayout.GetNumberOfShards(); shardID++ {
+			shardIDs = append(shardIDs, int32(shardID))
+		}
+	}
+
+	requestsByClient := make(map[historyservice.HistoryServiceClient]*historyservice.GetReplicationStatusRequest)
+	for _, shardID := range shardIDs {
+		client, err := c.getClientForShardID(int(shardID))
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := requestsByClient[client]; !ok {
+			requestsByClient[client] = &historyservice.GetReplicationStatusRequest{
+				RemoteClusters: request.RemoteClusters,
+			}
+		}
+
+		req := requestsByClient[client]
+		req.ShardIds = append(req.ShardIds, shardID)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(requestsByClient))
+	respChan := make(chan *historyservice.GetReplicationStatusResponse, len(requestsByClient))
+	errChan := make(chan error, len(requestsByClient))
+	for client, req := range requestsByClient {
+		go func(client historyservice.HistoryServiceClient, request *historyservice.GetReplicationStatusRequest) {
+			defer wg.Done()
+
+			ctx, cancel := c.createContext(ctx)
+			defer cancel()
+			resp, err := client.GetReplicationStatus(ctx, request, opts...)
+			if err != nil {
+				c.logger.Warn("Failed to get replication status from client", tag.Error(err))
+				errChan <- err
+				return
+			}
+			respChan <- resp
+		}(client, req)
+	}
+
+	wg.Wait()
+	close(respChan)
+	close(errChan)
+
+	response := &historyservice.GetReplicationStatusResponse{}
+	for resp := range respChan {
+		response.Shards = append(response.Shards, resp.Shards...)
+	}
+	if len(response.Shards) == 0 {
+		if err, ok := <-errChan; ok {
+			return nil, err
+		}
+	}
+	sort.Slice(response.Shards, func(i, j int) bool {
+		return response.Shards[i].GetShardId() < response.Shards[j].GetShardId()
+	})
+	return response, nil
+}
+
+func (c *clientImpl) SetShardOwner(
+	ctx context.Context,
+	request *historyservice.SetShardOwnerRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.SetShardOwnerResponse, error) {
+	client, err := c.getClientForShardID(int(request.GetShardId()))
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.SetShardOwnerResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.SetShardOwner(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) DrainHost(
+	ctx context.Context,
+	request *historyservice.DrainHostRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.DrainHostResponse, error) {
+	client, err := c.getClientForHostAddress(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	var response *historyservice.DrainHostResponse
+	op := func(ctx context.Context, client historyservice.HistoryServiceClient) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.DrainHost(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(parent, c.timeout)
 }
 
 func (c *clientImpl) getClientForWorkflowID(workflowID string) (historyservice.HistoryServiceClient, error) {
-	key := common.WorkflowIDToHistoryShard(workflowID, c.numberOfShards)
+	key := c.shardLayout.GetShardID(workflowID)
 	return c.getClientForShardID(key)
 }
 
 func (c *clientImpl) getClientForShardID(shardID int) (historyservice.HistoryServiceClient, error) {
-	client, err := c.clients.GetClientForKey(string(shardID))
+	host, err := resharding.LookupOwner(c.shardLayout, c.resolver, shardID)
+	if err != nil {
+		return nil, err
+	}
+	return c.getClientForHostAddress(host.GetAddress())
+}
+
+func (c *clientImpl) getClientForHostAddress(address string) (historyservice.HistoryServiceClient, error) {
+	client, err := c.clients.GetClientForClientKey(address)
 	if err != nil {
 		return nil, err
 	}
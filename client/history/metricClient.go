@@ -135,6 +135,21 @@ func (c *metricClient) PollMutableState(
 	return resp, err
 }
 
+func (c *metricClient) WatchMutableState(
+	context context.Context,
+	request *historyservice.WatchMutableStateRequest,
+	opts ...grpc.CallOption) (historyservice.HistoryService_WatchMutableStateClient, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientWatchMutableStateScope, metrics.ClientRequests)
+
+	stream, err := c.client.WatchMutableState(context, request, opts...)
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientWatchMutableStateScope, metrics.ClientFailures)
+	}
+
+	return stream, err
+}
+
 func (c *metricClient) ResetStickyTaskList(
 	context context.Context,
 	request *historyservice.ResetStickyTaskListRequest,
@@ -660,3 +675,88 @@ func (c *metricClient) RefreshWorkflowTasks(
 	}
 	return resp, err
 }
+
+func (c *metricClient) ImportWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.ImportWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ImportWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientImportWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientImportWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.ImportWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientImportWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.DeleteWorkflowExecutionResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.ClientLatency)
+	resp, err := c.client.DeleteWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientDeleteWorkflowExecutionScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) GetReplicationStatus(
+	ctx context.Context,
+	request *historyservice.GetReplicationStatusRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.GetReplicationStatusResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientGetReplicationStatusScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientGetReplicationStatusScope, metrics.ClientLatency)
+	resp, err := c.client.GetReplicationStatus(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientGetReplicationStatusScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) SetShardOwner(
+	ctx context.Context,
+	request *historyservice.SetShardOwnerRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.SetShardOwnerResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientSetShardOwnerScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientSetShardOwnerScope, metrics.ClientLatency)
+	resp, err := c.client.SetShardOwner(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientSetShardOwnerScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DrainHost(
+	ctx context.Context,
+	request *historyservice.DrainHostRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.DrainHostResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.HistoryClientDrainHostScope, metrics.ClientRequests)
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientDrainHostScope, metrics.ClientLatency)
+	resp, err := c.client.DrainHost(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientDrainHostScope, metrics.ClientFailures)
+	}
+	return resp, err
+}
@@ -162,6 +162,22 @@ func (c *retryableClient) PollMutableState(
 	return resp, err
 }
 
+func (c *retryableClient) WatchMutableState(
+	ctx context.Context,
+	request *historyservice.WatchMutableStateRequest,
+	opts ...grpc.CallOption) (historyservice.HistoryService_WatchMutableStateClient, error) {
+
+	var stream historyservice.HistoryService_WatchMutableStateClient
+	op := func() error {
+		var err error
+		stream, err = c.client.WatchMutableState(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return stream, err
+}
+
 func (c *retryableClient) ResetStickyTaskList(
 	ctx context.Context,
 	request *historyservice.ResetStickyTaskListRequest,
@@ -658,3 +674,88 @@ func (c *retryableClient) RefreshWorkflowTasks(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) ImportWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.ImportWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.ImportWorkflowExecutionResponse, error) {
+
+	var resp *historyservice.ImportWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ImportWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.DeleteWorkflowExecutionRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.DeleteWorkflowExecutionResponse, error) {
+
+	var resp *historyservice.DeleteWorkflowExecutionResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DeleteWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) GetReplicationStatus(
+	ctx context.Context,
+	request *historyservice.GetReplicationStatusRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.GetReplicationStatusResponse, error) {
+
+	var resp *historyservice.GetReplicationStatusResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.GetReplicationStatus(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) SetShardOwner(
+	ctx context.Context,
+	request *historyservice.SetShardOwnerRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.SetShardOwnerResponse, error) {
+
+	var resp *historyservice.SetShardOwnerResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.SetShardOwner(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DrainHost(
+	ctx context.Context,
+	request *historyservice.DrainHostRequest,
+	opts ...grpc.CallOption,
+) (*historyservice.DrainHostResponse, error) {
+
+	var resp *historyservice.DrainHostResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DrainHost(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
@@ -203,6 +203,16 @@ func (c *clientImpl) ListTaskListPartitions(ctx context.Context, request *matchi
 	return client.ListTaskListPartitions(ctx, request, opts...)
 }
 
+func (c *clientImpl) DrainHost(ctx context.Context, request *matchingservice.DrainHostRequest, opts ...grpc.CallOption) (*matchingservice.DrainHostResponse, error) {
+	client, err := c.getClientForHostAddress(request.GetHostAddress())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DrainHost(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	return context.WithTimeout(parent, c.timeout)
 }
@@ -218,3 +228,11 @@ func (c *clientImpl) getClientForTasklist(key string) (matchingservice.MatchingS
 	}
 	return client.(matchingservice.MatchingServiceClient), nil
 }
+
+func (c *clientImpl) getClientForHostAddress(address string) (matchingservice.MatchingServiceClient, error) {
+	client, err := c.clients.GetClientForClientKey(address)
+	if err != nil {
+		return nil, err
+	}
+	return client.(matchingservice.MatchingServiceClient), nil
+}
@@ -246,6 +246,24 @@ func (c *metricClient) ListTaskListPartitions(
 	return resp, err
 }
 
+func (c *metricClient) DrainHost(
+	ctx context.Context,
+	request *matchingservice.DrainHostRequest,
+	opts ...grpc.CallOption) (*matchingservice.DrainHostResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.MatchingClientDrainHostScope, metrics.ClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientDrainHostScope, metrics.ClientLatency)
+	resp, err := c.client.DrainHost(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientDrainHostScope, metrics.ClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) emitForwardedFromStats(scope int, forwardedFrom string, taskList *tasklistpb.TaskList) {
 	if taskList == nil {
 		return
@@ -193,3 +193,19 @@ func (c *retryableClient) ListTaskListPartitions(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) DrainHost(
+	ctx context.Context,
+	request *matchingservice.DrainHostRequest,
+	opts ...grpc.CallOption) (*matchingservice.DrainHostResponse, error) {
+
+	var resp *matchingservice.DrainHostResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DrainHost(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
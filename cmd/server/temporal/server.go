@@ -64,6 +64,8 @@ type (
 		cfg    *config.Config
 		doneC  chan struct{}
 		daemon common.Daemon
+		// dynamicConfig replaces the file based dynamic config client when set
+		dynamicConfig dynamicconfig.Client
 	}
 )
 
@@ -113,10 +115,14 @@ func (s *server) startService() common.Daemon {
 	params.Logger = loggerimpl.NewLogger(s.cfg.Log.NewZapLogger())
 	params.PersistenceConfig = s.cfg.Persistence
 
-	params.DynamicConfig, err = dynamicconfig.NewFileBasedClient(&s.cfg.DynamicConfigClient, params.Logger.WithTags(tag.Service(params.Name)), s.doneC)
-	if err != nil {
-		log.Printf("error creating file based dynamic config client, use no-op config client instead. error: %v", err)
-		params.DynamicConfig = dynamicconfig.NewNopClient()
+	if s.dynamicConfig != nil {
+		params.DynamicConfig = s.dynamicConfig
+	} else {
+		params.DynamicConfig, err = dynamicconfig.NewFileBasedClient(&s.cfg.DynamicConfigClient, params.Logger.WithTags(tag.Service(params.Name)), s.doneC)
+		if err != nil {
+			log.Printf("error creating file based dynamic config client, use no-op config client instead. error: %v", err)
+			params.DynamicConfig = dynamicconfig.NewNopClient()
+		}
 	}
 	dc := dynamicconfig.NewCollection(params.DynamicConfig, params.Logger)
 
@@ -133,6 +139,10 @@ func (s *server) startService() common.Daemon {
 
 	svcCfg := s.cfg.Services[s.name]
 	params.MetricScope = svcCfg.Metrics.NewScope(params.Logger)
+	params.Tracer, err = svcCfg.Tracing.NewTracer(params.Logger)
+	if err != nil {
+		log.Fatalf("error initializing tracer: %v", err)
+	}
 	params.RPCFactory = rpc.NewFactory(&svcCfg.RPC, params.Name, params.Logger, tlsFactory)
 
 	// Ringpop uses a different port to register handlers, this map is needed to resolve
@@ -156,8 +166,21 @@ func (s *server) startService() common.Daemon {
 		}
 
 	params.DCRedirectionPolicy = s.cfg.DCRedirectionPolicy
-
-	params.MetricsClient = metrics.NewClient(params.MetricScope, metrics.GetMetricsServiceIdx(params.Name, params.Logger))
+	params.AuditConfig = s.cfg.Audit
+	params.PayloadCodecConfig = s.cfg.PayloadCodec
+
+	params.MetricsClient = metrics.NewClientWithOptions(
+		params.MetricScope,
+		metrics.GetMetricsServiceIdx(params.Name, params.Logger),
+		metrics.ClientOptions{
+			HistogramTimers: svcCfg.Metrics.HistogramTimers(),
+			// dc is looked up on every use as it is replaced below once the persistence based dynamic config is set up
+			TagFilter: metrics.NewTagAllowlist(func() map[string]interface{} {
+				return dc.GetMapProperty(dynamicconfig.MetricsTagAllowlist, nil)()
+			}),
+			ExemplarReporter: metrics.DefaultExemplarStore,
+		},
+	)
 
 	clusterMetadata := s.cfg.ClusterMetadata
 
@@ -166,6 +189,22 @@ func (s *server) startService() common.Daemon {
 	// This is to keep this check hidden from independent downstream daemons and keep this in a single place.
 	immutableClusterMetadataInitialization(params.Logger, dc, &params.PersistenceConfig, &params.AbstractDatastoreFactory, &params.MetricsClient, clusterMetadata)
 
+	if s.cfg.PersistenceDynamicConfigClient != nil {
+		// Values changed through the admin API take precedence over the dynamic config file.
+		params.DynamicConfig = persistenceDynamicConfigInitialization(
+			params.Logger.WithTags(tag.Service(params.Name)),
+			dc,
+			s.cfg.PersistenceDynamicConfigClient,
+			params.DynamicConfig,
+			&params.PersistenceConfig,
+			&params.AbstractDatastoreFactory,
+			&params.MetricsClient,
+			clusterMetadata,
+			s.doneC,
+		)
+		dc = dynamicconfig.NewCollection(params.DynamicConfig, params.Logger)
+	}
+
 	params.ClusterMetadata = cluster.NewMetadata(
 		params.Logger,
 		dc.GetBoolProperty(dynamicconfig.EnableGlobalNamespace, clusterMetadata.EnableGlobalNamespace),
@@ -250,6 +289,7 @@ func (s *server) startService() common.Daemon {
 	params.PersistenceConfig.TransactionSizeLimit = dc.GetIntProperty(dynamicconfig.TransactionSizeLimit, common.DefaultTransactionSizeLimit)
 
 	params.Authorizer = authorization.NewNopAuthorizer()
+	params.TLSConfigProvider = tlsFactory
 
 	params.Logger.Info("Starting service " + s.name)
 
@@ -289,6 +329,7 @@ func immutableClusterMetadataInitialization(
 		*abstractDatastoreFactory,
 		clusterMetadata.CurrentClusterName,
 		*metricsClient,
+		nil,
 		logger,
 	)
 
@@ -342,6 +383,46 @@ func immutableClusterMetadataInitialization(
 	}
 }
 
+func persistenceDynamicConfigInitialization(
+	logger l.Logger,
+	dc *dynamicconfig.Collection,
+	clientConfig *dynamicconfig.PersistenceBasedClientConfig,
+	fallback dynamicconfig.Client,
+	persistenceConfig *config.Persistence,
+	abstractDatastoreFactory *persistenceClient.AbstractDataStoreFactory,
+	metricsClient *metrics.Client,
+	clusterMetadata *config.ClusterMetadata,
+	doneC chan struct{}) dynamicconfig.Client {
+
+	factory := persistenceClient.NewFactory(
+		persistenceConfig,
+		dc.GetIntProperty(dynamicconfig.HistoryPersistenceMaxQPS, 3000),
+		*abstractDatastoreFactory,
+		clusterMetadata.CurrentClusterName,
+		*metricsClient,
+		nil,
+		logger,
+	)
+
+	// The manager lives as long as the server, it is polled by the dynamic config client.
+	clusterMetadataManager, err := factory.NewClusterMetadataManager()
+	if err != nil {
+		log.Fatalf("Error initializing cluster metadata manager: %v", err)
+	}
+
+	client, err := dynamicconfig.NewPersistenceBasedClient(
+		clientConfig,
+		persistence.NewDynamicConfigValueStore(clusterMetadataManager),
+		fallback,
+		logger,
+		doneC,
+	)
+	if err != nil {
+		log.Fatalf("error creating persistence based dynamic config client: %v", err)
+	}
+	return client
+}
+
 func logImmutableMismatch(l l.Logger, key string, ignored interface{}, value interface{}) {
 	l.Error(
 		"Supplied configuration key/value mismatches persisted ImmutableClusterMetadata."+
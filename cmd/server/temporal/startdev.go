@@ -0,0 +1,302 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli"
+	"go.temporal.io/temporal-proto/serviceerror"
+	"go.temporal.io/temporal-proto/workflowservice"
+	sdkclient "go.temporal.io/temporal/client"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/backoff"
+	"github.com/temporalio/temporal/common/persistence/sql/sqlplugin/sqlite"
+	"github.com/temporalio/temporal/common/primitives"
+	"github.com/temporalio/temporal/common/service/config"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+	sqliteschema "github.com/temporalio/temporal/schema/sqlite"
+	"github.com/temporalio/temporal/tools/common/schema"
+	"github.com/temporalio/temporal/tools/sql"
+)
+
+const (
+	devClusterName  = "active"
+	devStoreName    = "sqlite-default"
+	devLocalhost    = "127.0.0.1"
+	devNumShards    = 4
+	devRetention    = 1
+	devStartTimeout = 30 * time.Second
+)
+
+// devServicePortOffsets are the offsets of the gRPC and membership ports of each service
+// from the frontend gRPC port, they match the ports of config/development.yaml
+var devServicePortOffsets = map[string]struct{ grpc, membership int }{
+	primitives.FrontendService: {grpc: 0, membership: -300},
+	primitives.HistoryService:  {grpc: 1, membership: -299},
+	primitives.MatchingService: {grpc: 2, membership: -298},
+	primitives.WorkerService:   {grpc: 6, membership: -294},
+}
+
+// devDynamicConfig are the dynamic config values of the dev server
+var devDynamicConfig = map[dynamicconfig.Key]interface{}{
+	dynamicconfig.MinRetentionDays:                   0,
+	dynamicconfig.AdvancedVisibilityWritingMode:      common.AdvancedVisibilityWritingModeOff,
+	dynamicconfig.MatchingNumTasklistReadPartitions:  1,
+	dynamicconfig.MatchingNumTasklistWritePartitions: 1,
+}
+
+// startDevHandler is the handler for the cli start-dev command, it starts all services
+// in this process on top of an embedded sqlite database
+func startDevHandler(c *cli.Context) {
+	cfg := newDevConfig(
+		strings.TrimSpace(c.String("db-filename")),
+		strings.TrimSpace(c.String("ip")),
+		c.Int("port"),
+		c.String("log-level"),
+	)
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("config validation failed: %v", err)
+	}
+
+	// The connection is kept for the life of the process, so an in memory database is not dropped
+	// when the stores of all services happen to be closed.
+	conn, err := setupDevSchema(cfg.Persistence.DataStores[devStoreName].SQL)
+	if err != nil {
+		log.Fatalf("sqlite schema setup failed: %v", err)
+	}
+
+	var daemons []common.Daemon
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGTERM, syscall.SIGINT)
+	for _, svc := range validServices {
+		server := newDevServer(svc, cfg)
+		daemons = append(daemons, server)
+		server.Start()
+	}
+
+	namespace := strings.TrimSpace(c.String("namespace"))
+	if err := registerDevNamespace(cfg.PublicClient.HostPort, namespace); err != nil {
+		log.Fatalf("failed to register namespace %v: %v", namespace, err)
+	}
+	log.Printf("Temporal dev server is ready; frontend=%v,namespace=%v\n", cfg.PublicClient.HostPort, namespace)
+
+	<-sigc
+	log.Println("Received shutdown signal, stopping dev server.")
+	for _, daemon := range daemons {
+		daemon.Stop()
+	}
+	conn.Close()
+	os.Exit(0)
+}
+
+// newDevServer returns a new instance of a daemon which runs
+// a temporal service with the dev server dynamic config
+func newDevServer(service string, cfg *config.Config) common.Daemon {
+	return &server{
+		cfg:           cfg,
+		name:          service,
+		doneC:         make(chan struct{}),
+		dynamicConfig: dynamicconfig.NewStaticClient(devDynamicConfig),
+	}
+}
+
+// newDevConfig returns the config of the dev server, all services listen on ports
+// derived from the frontend port and store their data in the given sqlite file
+func newDevConfig(dbFilename string, ip string, frontendPort int, logLevel string) *config.Config {
+	if dbFilename == "" {
+		dbFilename = sqlite.InMemoryDatabaseName
+	}
+	if ip == "" {
+		ip = devLocalhost
+	}
+	clientIP := ip
+	if clientIP == "0.0.0.0" {
+		clientIP = devLocalhost
+	}
+
+	services := make(map[string]config.Service, len(devServicePortOffsets))
+	for _, svc := range validServices {
+		offsets := devServicePortOffsets[svc]
+		rpc := config.RPC{
+			GRPCPort:       frontendPort + offsets.grpc,
+			MembershipPort: frontendPort + offsets.membership,
+		}
+		if ip == devLocalhost {
+			rpc.BindOnLocalHost = true
+		} else {
+			rpc.BindOnIP = ip
+		}
+		services[svc] = config.Service{RPC: rpc}
+	}
+
+	return &config.Config{
+		Global: config.Global{
+			Membership: config.Membership{
+				Name:             "temporal",
+				MaxJoinDuration:  devStartTimeout,
+				BroadcastAddress: clientIP,
+			},
+		},
+		Persistence: config.Persistence{
+			DefaultStore:     devStoreName,
+			VisibilityStore:  devStoreName,
+			NumHistoryShards: devNumShards,
+			DataStores: map[string]config.DataStore{
+				devStoreName: {
+					SQL: &config.SQL{
+						PluginName:   sqlite.PluginName,
+						DatabaseName: dbFilename,
+					},
+				},
+			},
+		},
+		Log: config.Logger{
+			Stdout: true,
+			Level:  logLevel,
+		},
+		ClusterMetadata: &config.ClusterMetadata{
+			EnableGlobalNamespace:    false,
+			FailoverVersionIncrement: 10,
+			MasterClusterName:        devClusterName,
+			CurrentClusterName:       devClusterName,
+			ClusterInformation: map[string]config.ClusterInformation{
+				devClusterName: {
+					Enabled:                true,
+					InitialFailoverVersion: 0,
+					RPCName:                "frontend",
+					RPCAddress:             fmt.Sprintf("%v:%v", clientIP, frontendPort),
+				},
+			},
+		},
+		DCRedirectionPolicy: config.DCRedirectionPolicy{
+			Policy: "noop",
+		},
+		Services: services,
+		Archival: config.Archival{
+			History: config.HistoryArchival{
+				Status: common.ArchivalDisabled,
+			},
+			Visibility: config.VisibilityArchival{
+				Status: common.ArchivalDisabled,
+			},
+		},
+		PublicClient: config.PublicClient{
+			HostPort: fmt.Sprintf("%v:%v", clientIP, frontendPort),
+		},
+		NamespaceDefaults: config.NamespaceDefaults{
+			Archival: config.ArchivalNamespaceDefaults{
+				History: config.HistoryArchivalNamespaceDefaults{
+					Status: common.ArchivalDisabled,
+				},
+				Visibility: config.VisibilityArchivalNamespaceDefaults{
+					Status: common.ArchivalDisabled,
+				},
+			},
+		},
+	}
+}
+
+// setupDevSchema creates the temporal and visibility tables in the dev server
+// database, unless the database was set up by a previous run
+func setupDevSchema(cfg *config.SQL) (*sql.Connection, error) {
+	conn, err := sql.NewConnection(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.ReadSchemaVersion(); err == nil {
+		return conn, nil
+	}
+
+	stmts, err := schema.ParseStatements(strings.NewReader(sqliteschema.TemporalSchema + sqliteschema.VisibilitySchema))
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := conn.CreateSchemaVersionTables(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	for _, stmt := range stmts {
+		if err := conn.Exec(stmt); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if err := conn.UpdateSchemaVersion(sqliteschema.Version, sqliteschema.Version); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := conn.WriteSchemaUpdateLog("0", sqliteschema.Version, "", "initial version"); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// registerDevNamespace registers the namespace through the frontend once it accepts requests,
+// a namespace which was registered by a previous run is left as is
+func registerDevNamespace(hostPort string, namespace string) error {
+	client, err := sdkclient.NewNamespaceClient(sdkclient.Options{HostPort: hostPort})
+	if err != nil {
+		return err
+	}
+	defer client.CloseConnection()
+
+	request := &workflowservice.RegisterNamespaceRequest{
+		Name:                                   namespace,
+		Description:                            "Default namespace of the dev server",
+		WorkflowExecutionRetentionPeriodInDays: devRetention,
+	}
+	policy := backoff.NewExponentialRetryPolicy(100 * time.Millisecond)
+	policy.SetMaximumInterval(time.Second)
+	policy.SetExpirationInterval(devStartTimeout)
+
+	return backoff.Retry(
+		func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			err := client.Register(ctx, request)
+			if _, ok := err.(*serviceerror.NamespaceAlreadyExists); ok {
+				return nil
+			}
+			return err
+		},
+		policy,
+		func(err error) bool {
+			_, ok := err.(*serviceerror.InvalidArgument)
+			return !ok
+		},
+	)
+}
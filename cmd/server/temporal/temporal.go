@@ -211,6 +211,40 @@ func BuildCLI() *cli.App {
 				startHandler(c)
 			},
 		},
+		{
+			Name:  "start-dev",
+			Usage: "start all temporal services in one process with an embedded sqlite database",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "db-filename, f",
+					Value: "",
+					Usage: "sqlite database file, the data is kept in memory when not set",
+				},
+				cli.StringFlag{
+					Name:  "namespace, n",
+					Value: "default",
+					Usage: "namespace which is registered on start",
+				},
+				cli.StringFlag{
+					Name:  "ip",
+					Value: devLocalhost,
+					Usage: "ip address the services listen on",
+				},
+				cli.IntFlag{
+					Name:  "port, p",
+					Value: 7233,
+					Usage: "frontend port, the ports of the other services are derived from it",
+				},
+				cli.StringFlag{
+					Name:  "log-level",
+					Value: "info",
+					Usage: "log level of the services",
+				},
+			},
+			Action: func(c *cli.Context) {
+				startDevHandler(c)
+			},
+		},
 	}
 
 	return app
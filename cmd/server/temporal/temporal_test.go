@@ -29,6 +29,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/persistence/sql/sqlplugin/sqlite"
+	"github.com/temporalio/temporal/common/primitives"
 )
 
 type TemporalSuite struct {
@@ -57,3 +60,23 @@ func (s *TemporalSuite) TestIsValidService() {
 func (s *TemporalSuite) TestPath() {
 	s.Equal("foo/bar", constructPath("foo", "bar"))
 }
+
+func (s *TemporalSuite) TestNewDevConfig() {
+	cfg := newDevConfig("", "", 7233, "info")
+	s.NoError(cfg.Validate())
+	s.Equal(sqlite.InMemoryDatabaseName, cfg.Persistence.DataStores[devStoreName].SQL.DatabaseName)
+	s.Equal("127.0.0.1:7233", cfg.PublicClient.HostPort)
+	s.Equal(7233, cfg.Services[primitives.FrontendService].RPC.GRPCPort)
+	s.Equal(6933, cfg.Services[primitives.FrontendService].RPC.MembershipPort)
+	s.Equal(7234, cfg.Services[primitives.HistoryService].RPC.GRPCPort)
+	s.Equal(7235, cfg.Services[primitives.MatchingService].RPC.GRPCPort)
+	s.Equal(7239, cfg.Services[primitives.WorkerService].RPC.GRPCPort)
+	s.True(cfg.Services[primitives.WorkerService].RPC.BindOnLocalHost)
+
+	cfg = newDevConfig("temporal.db", "0.0.0.0", 8233, "debug")
+	s.NoError(cfg.Validate())
+	s.Equal("temporal.db", cfg.Persistence.DataStores[devStoreName].SQL.DatabaseName)
+	s.Equal("127.0.0.1:8233", cfg.PublicClient.HostPort)
+	s.Equal("0.0.0.0", cfg.Services[primitives.HistoryService].RPC.BindOnIP)
+	s.Equal(8234, cfg.Services[primitives.HistoryService].RPC.GRPCPort)
+}
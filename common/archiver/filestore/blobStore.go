@@ -0,0 +1,105 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package filestore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/blobstore"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type (
+	blobStore struct {
+		fileMode os.FileMode
+		dirMode  os.FileMode
+	}
+)
+
+// NewBlobStore creates a new blobstore.BlobStore based on filestore,
+// the directory should be on a filesystem shared by all history hosts
+func NewBlobStore(
+	config *config.FilestoreArchiver,
+) (blobstore.BlobStore, error) {
+	fileMode, err := strconv.ParseUint(config.FileMode, 0, 32)
+	if err != nil {
+		return nil, errInvalidFileMode
+	}
+	dirMode, err := strconv.ParseUint(config.DirMode, 0, 32)
+	if err != nil {
+		return nil, errInvalidDirMode
+	}
+	return &blobStore{
+		fileMode: os.FileMode(fileMode),
+		dirMode:  os.FileMode(dirMode),
+	}, nil
+}
+
+func (s *blobStore) Put(_ context.Context, URI string, data []byte) error {
+	path, err := getBlobPath(URI)
+	if err != nil {
+		return err
+	}
+	if err := mkdirAll(filepath.Dir(path), s.dirMode); err != nil {
+		return err
+	}
+	return writeFile(path, data, s.fileMode)
+}
+
+func (s *blobStore) Get(_ context.Context, URI string) ([]byte, error) {
+	path, err := getBlobPath(URI)
+	if err != nil {
+		return nil, err
+	}
+	data, err := readFile(path)
+	if os.IsNotExist(err) {
+		return nil, blobstore.ErrBlobNotFound
+	}
+	return data, err
+}
+
+func (s *blobStore) Delete(_ context.Context, URI string) error {
+	path, err := getBlobPath(URI)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
+
+func getBlobPath(URI string) (string, error) {
+	u, err := archiver.NewURI(URI)
+	if err != nil {
+		return "", blobstore.ErrInvalidURI
+	}
+	path := filepath.Clean(u.Path())
+	if u.Scheme() != URIScheme || len(u.Hostname()) != 0 || !filepath.IsAbs(u.Path()) || path == "/" {
+		return "", blobstore.ErrInvalidURI
+	}
+	return path, nil
+}
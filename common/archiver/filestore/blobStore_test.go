@@ -0,0 +1,105 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package filestore
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/blobstore"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type blobStoreSuite struct {
+	*require.Assertions
+	suite.Suite
+
+	dir   string
+	store blobstore.BlobStore
+}
+
+func TestBlobStoreSuite(t *testing.T) {
+	suite.Run(t, new(blobStoreSuite))
+}
+
+func (s *blobStoreSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	dir, err := ioutil.TempDir("", "TestBlobStore")
+	s.NoError(err)
+	s.dir = dir
+	s.store, err = NewBlobStore(&config.FilestoreArchiver{
+		FileMode: testFileModeStr,
+		DirMode:  testDirModeStr,
+	})
+	s.NoError(err)
+}
+
+func (s *blobStoreSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *blobStoreSuite) TestNewBlobStore_InvalidMode() {
+	_, err := NewBlobStore(&config.FilestoreArchiver{
+		FileMode: "invalid",
+		DirMode:  testDirModeStr,
+	})
+	s.Equal(errInvalidFileMode, err)
+
+	_, err = NewBlobStore(&config.FilestoreArchiver{
+		FileMode: testFileModeStr,
+		DirMode:  "invalid",
+	})
+	s.Equal(errInvalidDirMode, err)
+}
+
+func (s *blobStoreSuite) TestPutGetDelete() {
+	executionURI := URIScheme + "://" + s.dir + "/payloads/namespace-id/run-id"
+	blobURI := executionURI + "/blob-id"
+
+	_, err := s.store.Get(context.Background(), blobURI)
+	s.Equal(blobstore.ErrBlobNotFound, err)
+
+	s.NoError(s.store.Put(context.Background(), blobURI, []byte("data")))
+	data, err := s.store.Get(context.Background(), blobURI)
+	s.NoError(err)
+	s.Equal([]byte("data"), data)
+
+	s.NoError(s.store.Delete(context.Background(), executionURI))
+	_, err = s.store.Get(context.Background(), blobURI)
+	s.Equal(blobstore.ErrBlobNotFound, err)
+}
+
+func (s *blobStoreSuite) TestInvalidURI() {
+	s.Equal(blobstore.ErrInvalidURI, s.store.Put(context.Background(), "file:///", []byte("data")))
+	s.Equal(blobstore.ErrInvalidURI, s.store.Delete(context.Background(), "file://relative/path"))
+	_, err := s.store.Get(context.Background(), "s3://bucket/blob")
+	s.Equal(blobstore.ErrInvalidURI, err)
+}
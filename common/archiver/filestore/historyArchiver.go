@@ -161,6 +161,11 @@ func (h *historyArchiver) Archive(
 			return err
 		}
 
+		if err := archiver.RehydrateHistoryBlob(ctx, h.container, URI, request, historyBlob); err != nil {
+			logger.Error(archiver.ArchiveTransientErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonReadHistory), tag.Error(err))
+			return err
+		}
+
 		if historyMutated(request, historyBlob.Body, historyBlob.Header.IsLast) {
 			logger.Error(archiver.ArchiveNonRetryableErrorMsg, tag.ArchivalArchiveFailReason(archiver.ErrReasonHistoryMutated))
 			return archiver.ErrHistoryMutated
@@ -0,0 +1,114 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gcloud
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"cloud.google.com/go/storage"
+
+	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/archiver/gcloud/connector"
+	"github.com/temporalio/temporal/common/blobstore"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type (
+	blobStore struct {
+		gcloudStorage connector.Client
+	}
+)
+
+// NewBlobStore creates a new blobstore.BlobStore based on google cloud storage,
+// blobs are stored in the bucket of the URI host under the object name of the URI path
+func NewBlobStore(
+	config *config.GstorageArchiver,
+) (blobstore.BlobStore, error) {
+	storage, err := connector.NewClient(context.Background(), config)
+	if err != nil {
+		return nil, err
+	}
+	return newBlobStore(storage), nil
+}
+
+func newBlobStore(storage connector.Client) *blobStore {
+	return &blobStore{
+		gcloudStorage: storage,
+	}
+}
+
+func (s *blobStore) Put(ctx context.Context, URI string, data []byte) error {
+	dirURI, fileName, err := parseBlobURI(URI)
+	if err != nil {
+		return err
+	}
+	return s.gcloudStorage.Upload(ctx, dirURI, fileName, data)
+}
+
+func (s *blobStore) Get(ctx context.Context, URI string) ([]byte, error) {
+	dirURI, fileName, err := parseBlobURI(URI)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.gcloudStorage.Get(ctx, dirURI, fileName)
+	if err == storage.ErrObjectNotExist {
+		return nil, blobstore.ErrBlobNotFound
+	}
+	return data, err
+}
+
+func (s *blobStore) Delete(ctx context.Context, URI string) error {
+	dirURI, fileName, err := parseBlobURI(URI)
+	if err != nil {
+		return err
+	}
+	if err := s.gcloudStorage.Delete(ctx, dirURI, fileName); err != nil {
+		return err
+	}
+	u, err := archiver.NewURI(strings.TrimRight(URI, "/"))
+	if err != nil {
+		return blobstore.ErrInvalidURI
+	}
+	return s.gcloudStorage.Delete(ctx, u, "")
+}
+
+// parseBlobURI splits the blob URI into the URI of its directory and its file name
+func parseBlobURI(URI string) (archiver.URI, string, error) {
+	u, err := archiver.NewURI(URI)
+	if err != nil {
+		return nil, "", blobstore.ErrInvalidURI
+	}
+	blobPath := path.Clean(u.Path())
+	if u.Scheme() != URIScheme || len(u.Hostname()) == 0 || !path.IsAbs(blobPath) || path.Dir(blobPath) == "/" {
+		return nil, "", blobstore.ErrInvalidURI
+	}
+	dirURI, err := archiver.NewURI(URIScheme + "://" + u.Hostname() + path.Dir(blobPath))
+	if err != nil {
+		return nil, "", blobstore.ErrInvalidURI
+	}
+	return dirURI, path.Base(blobPath), nil
+}
@@ -0,0 +1,99 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package gcloud
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/archiver/gcloud/connector/mocks"
+	"github.com/temporalio/temporal/common/blobstore"
+)
+
+type blobStoreSuite struct {
+	*require.Assertions
+	suite.Suite
+
+	storage *mocks.Client
+	store   *blobStore
+}
+
+func TestBlobStoreSuite(t *testing.T) {
+	suite.Run(t, new(blobStoreSuite))
+}
+
+func (s *blobStoreSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.storage = &mocks.Client{}
+	s.store = newBlobStore(s.storage)
+}
+
+func (s *blobStoreSuite) TearDownTest() {
+	s.storage.AssertExpectations(s.T())
+}
+
+func (s *blobStoreSuite) TestPutGet() {
+	dirURI := mock.MatchedBy(func(URI archiver.URI) bool {
+		return URI.String() == "gs://my-bucket-cad/payloads/namespace-id/run-id"
+	})
+	s.storage.On("Upload", mock.Anything, dirURI, "blob-id", []byte("data")).Return(nil).Once()
+	s.storage.On("Get", mock.Anything, dirURI, "blob-id").Return([]byte("data"), nil).Once()
+
+	s.NoError(s.store.Put(context.Background(), "gs://my-bucket-cad/payloads/namespace-id/run-id/blob-id", []byte("data")))
+	data, err := s.store.Get(context.Background(), "gs://my-bucket-cad/payloads/namespace-id/run-id/blob-id")
+	s.NoError(err)
+	s.Equal([]byte("data"), data)
+}
+
+func (s *blobStoreSuite) TestGet_NotFound() {
+	s.storage.On("Get", mock.Anything, mock.Anything, "blob-id").Return(nil, storage.ErrObjectNotExist).Once()
+
+	_, err := s.store.Get(context.Background(), "gs://my-bucket-cad/payloads/namespace-id/run-id/blob-id")
+	s.Equal(blobstore.ErrBlobNotFound, err)
+}
+
+func (s *blobStoreSuite) TestDelete() {
+	s.storage.On("Delete", mock.Anything, mock.MatchedBy(func(URI archiver.URI) bool {
+		return URI.String() == "gs://my-bucket-cad/payloads/namespace-id"
+	}), "run-id").Return(nil).Once()
+	s.storage.On("Delete", mock.Anything, mock.MatchedBy(func(URI archiver.URI) bool {
+		return URI.String() == "gs://my-bucket-cad/payloads/namespace-id/run-id"
+	}), "").Return(nil).Once()
+
+	s.NoError(s.store.Delete(context.Background(), "gs://my-bucket-cad/payloads/namespace-id/run-id"))
+}
+
+func (s *blobStoreSuite) TestInvalidURI() {
+	s.Equal(blobstore.ErrInvalidURI, s.store.Put(context.Background(), "gs://my-bucket-cad/blob-id", []byte("data")))
+	s.Equal(blobstore.ErrInvalidURI, s.store.Delete(context.Background(), "file:///payloads/namespace-id"))
+	_, err := s.store.Get(context.Background(), "gs:///payloads/blob-id")
+	s.Equal(blobstore.ErrInvalidURI, err)
+}
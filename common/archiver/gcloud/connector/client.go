@@ -63,6 +63,7 @@ type (
 		Query(ctx context.Context, URI archiver.URI, fileNamePrefix string) ([]string, error)
 		QueryWithFilters(ctx context.Context, URI archiver.URI, fileNamePrefix string, pageSize, offset int, filters []Precondition) ([]string, bool, int, error)
 		Exist(ctx context.Context, URI archiver.URI, fileName string) (bool, error)
+		Delete(ctx context.Context, URI archiver.URI, fileName string) error
 	}
 
 	storageWrapper struct {
@@ -142,6 +143,31 @@ func (s *storageWrapper) Get(ctx context.Context, URI archiver.URI, fileName str
 	return nil, err
 }
 
+// Delete removes a file, a missing file is not an error
+// If fileName is empty, then 'Delete' function will remove all the files under the given URI.
+func (s *storageWrapper) Delete(ctx context.Context, URI archiver.URI, fileName string) error {
+	bucket := s.client.Bucket(URI.Hostname())
+	if fileName != "" {
+		return deleteObject(ctx, bucket, formatSinkPath(URI.Path())+"/"+fileName)
+	}
+
+	it := bucket.Objects(ctx, &storage.Query{
+		Prefix: formatSinkPath(URI.Path()) + "/",
+	})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := deleteObject(ctx, bucket, attrs.Name); err != nil {
+			return err
+		}
+	}
+}
+
 // Query, retieves file names by provided storage query
 func (s *storageWrapper) Query(ctx context.Context, URI archiver.URI, fileNamePrefix string) (fileNames []string, err error) {
 	fileNames = make([]string, 0)
@@ -202,6 +228,13 @@ func (s *storageWrapper) QueryWithFilters(ctx context.Context, URI archiver.URI,
 
 }
 
+func deleteObject(ctx context.Context, bucket BucketHandleWrapper, name string) error {
+	if err := bucket.Object(name).Delete(ctx); err != nil && err != storage.ErrObjectNotExist {
+		return err
+	}
+	return nil
+}
+
 func isPageCompleted(pageSize, currentPosition int) bool {
 	return pageSize != 0 && currentPosition > 0 && pageSize <= currentPosition
 }
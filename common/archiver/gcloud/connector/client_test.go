@@ -219,6 +219,58 @@ func (s *clientSuite) TestGet() {
 	s.Require().NoError(err)
 }
 
+func (s *clientSuite) TestDelete() {
+	ctx := context.Background()
+	mockStorageClient := &mocks.GcloudStorageClient{}
+	mockBucketHandleClient := &mocks.BucketHandleWrapper{}
+	mockObjectHandler := &mocks.ObjectHandleWrapper{}
+	storageWrapper, _ := connector.NewClientWithParams(mockStorageClient)
+
+	mockStorageClient.On("Bucket", "my-bucket-cad").Return(mockBucketHandleClient).Times(1)
+	mockBucketHandleClient.On("Object", "temporal_archival/development/myfile.history").Return(mockObjectHandler).Times(1)
+	mockObjectHandler.On("Delete", ctx).Return(storage.ErrObjectNotExist).Times(1)
+
+	URI, err := archiver.NewURI("gs://my-bucket-cad/temporal_archival/development")
+	s.Require().NoError(err)
+	s.NoError(storageWrapper.Delete(ctx, URI, "myfile.history"))
+	mockObjectHandler.AssertExpectations(s.T())
+}
+
+func (s *clientSuite) TestDeleteAll() {
+	ctx := context.Background()
+	mockStorageClient := &mocks.GcloudStorageClient{}
+	mockBucketHandleClient := &mocks.BucketHandleWrapper{}
+	mockObjectHandler := &mocks.ObjectHandleWrapper{}
+	mockObjectIterator := &mocks.ObjectIteratorWrapper{}
+	storageWrapper, _ := connector.NewClientWithParams(mockStorageClient)
+
+	attr := new(storage.ObjectAttrs)
+	attr.Name = "temporal_archival/development/myfile.history"
+
+	mockStorageClient.On("Bucket", "my-bucket-cad").Return(mockBucketHandleClient).Times(1)
+	mockBucketHandleClient.On("Objects", ctx, &storage.Query{Prefix: "temporal_archival/development/"}).Return(mockObjectIterator).Times(1)
+	mockIterator := 0
+	mockObjectIterator.On("Next").Return(func() *storage.ObjectAttrs {
+		mockIterator++
+		if mockIterator == 1 {
+			return attr
+		}
+		return nil
+	}, func() error {
+		if mockIterator == 1 {
+			return nil
+		}
+		return iterator.Done
+	}).Times(2)
+	mockBucketHandleClient.On("Object", attr.Name).Return(mockObjectHandler).Times(1)
+	mockObjectHandler.On("Delete", ctx).Return(nil).Times(1)
+
+	URI, err := archiver.NewURI("gs://my-bucket-cad/temporal_archival/development")
+	s.Require().NoError(err)
+	s.NoError(storageWrapper.Delete(ctx, URI, ""))
+	mockObjectHandler.AssertExpectations(s.T())
+}
+
 func (s *clientSuite) TestWrongGoogleCredentialsPath() {
 	ctx := context.Background()
 	os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "/Wrong/path")
@@ -64,6 +64,20 @@ func (_m *ObjectHandleWrapper) Attrs(ctx context.Context) (*storage.ObjectAttrs,
 	return r0, r1
 }
 
+// Delete provides a mock function with given fields: ctx
+func (_m *ObjectHandleWrapper) Delete(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewReader provides a mock function with given fields: ctx
 func (_m *ObjectHandleWrapper) NewReader(ctx context.Context) (connector.ReaderWrapper, error) {
 	ret := _m.Called(ctx)
@@ -31,6 +31,7 @@ import (
 	executionpb "go.temporal.io/temporal-proto/execution"
 
 	archivergenpb "github.com/temporalio/temporal/.gen/proto/archiver"
+	"github.com/temporalio/temporal/common/blobstore"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/log"
@@ -74,6 +75,7 @@ type (
 		MetricsClient    metrics.Client
 		ClusterMetadata  cluster.Metadata
 		NamespaceCache   cache.NamespaceCache
+		PayloadOffloader blobstore.PayloadOffloader
 	}
 
 	// HistoryArchiver is used to archive history and read archived history
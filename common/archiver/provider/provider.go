@@ -33,6 +33,7 @@ import (
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/filestore"
 	"github.com/temporalio/temporal/common/archiver/s3store"
+	"github.com/temporalio/temporal/common/blobstore"
 	"github.com/temporalio/temporal/common/service/config"
 )
 
@@ -52,7 +53,10 @@ var (
 type (
 	// ArchiverProvider returns history or visibility archiver based on the scheme and serviceName.
 	// The archiver for each combination of scheme and serviceName will be created only once and cached.
+	// It also provides the blob store of each history archiver backend, which is used for payload offloading.
 	ArchiverProvider interface {
+		blobstore.Provider
+
 		RegisterBootstrapContainer(
 			serviceName string,
 			historyContainer *archiver.HistoryBootstrapContainer,
@@ -75,6 +79,9 @@ type (
 		// Key for the archiver is scheme + serviceName
 		historyArchivers    map[string]archiver.HistoryArchiver
 		visibilityArchivers map[string]archiver.VisibilityArchiver
+
+		// Key for the blob store is just scheme
+		blobStores map[string]blobstore.BlobStore
 	}
 )
 
@@ -90,6 +97,7 @@ func NewArchiverProvider(
 		visibilityContainers:      make(map[string]*archiver.VisibilityBootstrapContainer),
 		historyArchivers:          make(map[string]archiver.HistoryArchiver),
 		visibilityArchivers:       make(map[string]archiver.VisibilityArchiver),
+		blobStores:                make(map[string]blobstore.BlobStore),
 	}
 }
 
@@ -223,6 +231,52 @@ func (p *archiverProvider) GetVisibilityArchiver(scheme, serviceName string) (ar
 
 }
 
+// GetBlobStore returns the blob store for the scheme, the blob store is created
+// with the config of the history archiver of the same scheme
+func (p *archiverProvider) GetBlobStore(scheme string) (blobStore blobstore.BlobStore, err error) {
+	p.RLock()
+	if blobStore, ok := p.blobStores[scheme]; ok {
+		p.RUnlock()
+		return blobStore, nil
+	}
+	p.RUnlock()
+
+	if p.historyArchiverConfigs == nil {
+		return nil, ErrArchiverConfigNotFound
+	}
+
+	switch scheme {
+	case filestore.URIScheme:
+		if p.historyArchiverConfigs.Filestore == nil {
+			return nil, ErrArchiverConfigNotFound
+		}
+		blobStore, err = filestore.NewBlobStore(p.historyArchiverConfigs.Filestore)
+	case gcloud.URIScheme:
+		if p.historyArchiverConfigs.Gstorage == nil {
+			return nil, ErrArchiverConfigNotFound
+		}
+		blobStore, err = gcloud.NewBlobStore(p.historyArchiverConfigs.Gstorage)
+	case s3store.URIScheme:
+		if p.historyArchiverConfigs.S3store == nil {
+			return nil, ErrArchiverConfigNotFound
+		}
+		blobStore, err = s3store.NewBlobStore(p.historyArchiverConfigs.S3store)
+	default:
+		return nil, ErrUnknownScheme
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if existingBlobStore, ok := p.blobStores[scheme]; ok {
+		return existingBlobStore, nil
+	}
+	p.blobStores[scheme] = blobStore
+	return blobStore, nil
+}
+
 func (p *archiverProvider) getArchiverKey(scheme, serviceName string) string {
 	return scheme + ":" + serviceName
 }
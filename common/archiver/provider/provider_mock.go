@@ -30,6 +30,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/blobstore"
 )
 
 // MockArchiverProvider is an autogenerated mock type for the ArchiverProvider type
@@ -37,6 +38,29 @@ type MockArchiverProvider struct {
 	mock.Mock
 }
 
+// GetBlobStore provides a mock function with given fields: scheme
+func (_m *MockArchiverProvider) GetBlobStore(scheme string) (blobstore.BlobStore, error) {
+	ret := _m.Called(scheme)
+
+	var r0 blobstore.BlobStore
+	if rf, ok := ret.Get(0).(func(string) blobstore.BlobStore); ok {
+		r0 = rf(scheme)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(blobstore.BlobStore)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(scheme)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetHistoryArchiver provides a mock function with given fields: scheme, serviceName
 func (_m *MockArchiverProvider) GetHistoryArchiver(scheme string, serviceName string) (archiver.HistoryArchiver, error) {
 	ret := _m.Called(scheme, serviceName)
@@ -0,0 +1,149 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package s3store
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/common/archiver"
+	"github.com/temporalio/temporal/common/blobstore"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type (
+	blobStore struct {
+		s3cli s3iface.S3API
+	}
+)
+
+// NewBlobStore creates a new blobstore.BlobStore based on s3,
+// blobs are stored in the bucket of the URI host under the key of the URI path
+func NewBlobStore(
+	config *config.S3Archiver,
+) (blobstore.BlobStore, error) {
+	if len(config.Region) == 0 {
+		return nil, errEmptyAwsRegion
+	}
+	s3Config := &aws.Config{
+		Endpoint:         config.Endpoint,
+		Region:           aws.String(config.Region),
+		S3ForcePathStyle: aws.Bool(config.S3ForcePathStyle),
+	}
+	sess, err := session.NewSession(s3Config)
+	if err != nil {
+		return nil, err
+	}
+	return newBlobStore(s3.New(sess)), nil
+}
+
+func newBlobStore(s3cli s3iface.S3API) *blobStore {
+	return &blobStore{
+		s3cli: s3cli,
+	}
+}
+
+func (s *blobStore) Put(ctx context.Context, URI string, data []byte) error {
+	u, key, err := parseBlobURI(URI)
+	if err != nil {
+		return err
+	}
+	return upload(ctx, s.s3cli, u, key, data)
+}
+
+func (s *blobStore) Get(ctx context.Context, URI string) ([]byte, error) {
+	u, key, err := parseBlobURI(URI)
+	if err != nil {
+		return nil, err
+	}
+	data, err := download(ctx, s.s3cli, u, key)
+	if _, ok := err.(*serviceerror.NotFound); ok {
+		return nil, blobstore.ErrBlobNotFound
+	}
+	return data, err
+}
+
+func (s *blobStore) Delete(ctx context.Context, URI string) error {
+	u, key, err := parseBlobURI(URI)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := ensureContextTimeout(ctx)
+	defer cancel()
+
+	if _, err := s.s3cli.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(u.Hostname()),
+		Key:    aws.String(key),
+	}); err != nil {
+		return err
+	}
+
+	// s3 has no directories, delete every key under the URI page by page
+	var continuationToken *string
+	for {
+		results, err := s.s3cli.ListObjectsV2WithContext(ctx, &s3.ListObjectsV2Input{
+			Bucket:            aws.String(u.Hostname()),
+			Prefix:            aws.String(key + "/"),
+			ContinuationToken: continuationToken,
+		})
+		if err != nil {
+			return err
+		}
+		if len(results.Contents) != 0 {
+			objects := make([]*s3.ObjectIdentifier, 0, len(results.Contents))
+			for _, object := range results.Contents {
+				objects = append(objects, &s3.ObjectIdentifier{Key: object.Key})
+			}
+			if _, err := s.s3cli.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+				Bucket: aws.String(u.Hostname()),
+				Delete: &s3.Delete{Objects: objects, Quiet: aws.Bool(true)},
+			}); err != nil {
+				return err
+			}
+		}
+		if !aws.BoolValue(results.IsTruncated) {
+			return nil
+		}
+		continuationToken = results.NextContinuationToken
+	}
+}
+
+func parseBlobURI(URI string) (archiver.URI, string, error) {
+	u, err := archiver.NewURI(URI)
+	if err != nil {
+		return nil, "", blobstore.ErrInvalidURI
+	}
+	key := strings.Trim(u.Path(), "/")
+	if u.Scheme() != URIScheme || len(u.Hostname()) == 0 || len(key) == 0 {
+		return nil, "", blobstore.ErrInvalidURI
+	}
+	return u, key, nil
+}
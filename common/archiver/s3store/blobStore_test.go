@@ -0,0 +1,119 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package s3store
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/archiver/s3store/mocks"
+	"github.com/temporalio/temporal/common/blobstore"
+)
+
+type blobStoreSuite struct {
+	*require.Assertions
+	suite.Suite
+
+	s3cli *mocks.S3API
+	store *blobStore
+}
+
+func TestBlobStoreSuite(t *testing.T) {
+	suite.Run(t, new(blobStoreSuite))
+}
+
+func (s *blobStoreSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.s3cli = &mocks.S3API{}
+	s.store = newBlobStore(s.s3cli)
+}
+
+func (s *blobStoreSuite) TearDownTest() {
+	s.s3cli.AssertExpectations(s.T())
+}
+
+func (s *blobStoreSuite) TestPutGet() {
+	s.s3cli.On("PutObjectWithContext", mock.Anything, mock.MatchedBy(func(input *s3.PutObjectInput) bool {
+		return *input.Bucket == testBucket && *input.Key == "payloads/namespace-id/run-id/blob-id"
+	})).Return(&s3.PutObjectOutput{}, nil).Once()
+	s.s3cli.On("GetObjectWithContext", mock.Anything, mock.MatchedBy(func(input *s3.GetObjectInput) bool {
+		return *input.Bucket == testBucket && *input.Key == "payloads/namespace-id/run-id/blob-id"
+	})).Return(&s3.GetObjectOutput{
+		Body: ioutil.NopCloser(bytes.NewReader([]byte("data"))),
+	}, nil).Once()
+
+	s.NoError(s.store.Put(context.Background(), testBucketURI+"/payloads/namespace-id/run-id/blob-id", []byte("data")))
+	data, err := s.store.Get(context.Background(), testBucketURI+"/payloads/namespace-id/run-id/blob-id")
+	s.NoError(err)
+	s.Equal([]byte("data"), data)
+}
+
+func (s *blobStoreSuite) TestGet_NotFound() {
+	s.s3cli.On("GetObjectWithContext", mock.Anything, mock.Anything).
+		Return(nil, awserr.New(s3.ErrCodeNoSuchKey, "", nil)).Once()
+
+	_, err := s.store.Get(context.Background(), testBucketURI+"/payloads/namespace-id/run-id/blob-id")
+	s.Equal(blobstore.ErrBlobNotFound, err)
+}
+
+func (s *blobStoreSuite) TestDelete() {
+	s.s3cli.On("DeleteObjectWithContext", mock.Anything, mock.MatchedBy(func(input *s3.DeleteObjectInput) bool {
+		return *input.Key == "payloads/namespace-id/run-id"
+	})).Return(&s3.DeleteObjectOutput{}, nil).Once()
+	s.s3cli.On("ListObjectsV2WithContext", mock.Anything, mock.MatchedBy(func(input *s3.ListObjectsV2Input) bool {
+		return *input.Prefix == "payloads/namespace-id/run-id/" && input.ContinuationToken == nil
+	})).Return(&s3.ListObjectsV2Output{
+		Contents:              []*s3.Object{{Key: aws.String("payloads/namespace-id/run-id/blob-1")}},
+		IsTruncated:           aws.Bool(true),
+		NextContinuationToken: aws.String("next"),
+	}, nil).Once()
+	s.s3cli.On("ListObjectsV2WithContext", mock.Anything, mock.MatchedBy(func(input *s3.ListObjectsV2Input) bool {
+		return input.ContinuationToken != nil && *input.ContinuationToken == "next"
+	})).Return(&s3.ListObjectsV2Output{
+		Contents:    []*s3.Object{{Key: aws.String("payloads/namespace-id/run-id/blob-2")}},
+		IsTruncated: aws.Bool(false),
+	}, nil).Once()
+	s.s3cli.On("DeleteObjectsWithContext", mock.Anything, mock.MatchedBy(func(input *s3.DeleteObjectsInput) bool {
+		return len(input.Delete.Objects) == 1
+	})).Return(&s3.DeleteObjectsOutput{}, nil).Twice()
+
+	s.NoError(s.store.Delete(context.Background(), testBucketURI+"/payloads/namespace-id/run-id"))
+}
+
+func (s *blobStoreSuite) TestInvalidURI() {
+	s.Equal(blobstore.ErrInvalidURI, s.store.Put(context.Background(), testBucketURI, []byte("data")))
+	s.Equal(blobstore.ErrInvalidURI, s.store.Delete(context.Background(), "file:///payloads"))
+	_, err := s.store.Get(context.Background(), "s3:///payloads")
+	s.Equal(blobstore.ErrInvalidURI, err)
+}
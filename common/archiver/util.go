@@ -25,6 +25,7 @@
 package archiver
 
 import (
+	"context"
 	"errors"
 
 	commonpb "go.temporal.io/temporal-proto/common"
@@ -47,6 +48,16 @@ var (
 	errEmptyQuery            = errors.New("Query string is empty")
 )
 
+// RehydrateHistoryBlob replaces the blob references in the history blob with the offloaded payloads,
+// so that archived history does not depend on the payloads which are deleted with the workflow.
+// Payloads are offloaded under the history archival URI of the namespace.
+func RehydrateHistoryBlob(ctx context.Context, container *HistoryBootstrapContainer, URI URI, request *ArchiveHistoryRequest, historyBlob *archivergenpb.HistoryBlob) error {
+	if container.PayloadOffloader == nil {
+		return nil
+	}
+	return container.PayloadOffloader.Rehydrate(ctx, URI.String(), request.NamespaceID, request.RunID, historyBlob.Body)
+}
+
 // TagLoggerWithArchiveHistoryRequestAndURI tags logger with fields in the archive history request and the URI
 func TagLoggerWithArchiveHistoryRequestAndURI(logger log.Logger, request *ArchiveHistoryRequest, URI string) log.Logger {
 	return logger.WithTags(
@@ -0,0 +1,156 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common/codec"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+const (
+	defaultFileMaxSizeMB  = 100
+	defaultFileMaxBackups = 10
+	bytesPerMB            = 1024 * 1024
+)
+
+type (
+	// fileSink writes audit records as JSON lines to a local file, which is rotated
+	// once it reaches the max size. Rotated files are named <path>.1 (newest) to <path>.<maxBackups>.
+	fileSink struct {
+		sync.Mutex
+		path       string
+		maxSize    int64
+		maxBackups int
+		encoder    *codec.JSONPBEncoder
+
+		file *os.File
+		size int64
+	}
+)
+
+var _ Sink = (*fileSink)(nil)
+
+// NewFileSink creates a sink which writes audit records to a rotating local file
+func NewFileSink(
+	cfg *config.AuditFile,
+) (Sink, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("audit file path is not set")
+	}
+
+	maxSizeMB := cfg.MaxSizeMB
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultFileMaxSizeMB
+	}
+	maxBackups := cfg.MaxBackups
+	if maxBackups <= 0 {
+		maxBackups = defaultFileMaxBackups
+	}
+
+	sink := &fileSink{
+		path:       cfg.Path,
+		maxSize:    int64(maxSizeMB) * bytesPerMB,
+		maxBackups: maxBackups,
+		encoder:    codec.NewJSONPBEncoder(),
+	}
+	if err := sink.open(); err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+func (s *fileSink) Write(
+	record *persistenceblobs.AuditRecord,
+) error {
+	line, err := s.encoder.Encode(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %v", err)
+	}
+	line = append(line, '\n')
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	return err
+}
+
+func (s *fileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0640)
+	if err != nil {
+		return fmt.Errorf("failed to open audit file: %v", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to open audit file: %v", err)
+	}
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *fileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to rotate audit file: %v", err)
+	}
+
+	err := s.renameBackups()
+	// the active file is reopened even if renaming failed, so that later writes can retry the rotation
+	if openErr := s.open(); openErr != nil {
+		return openErr
+	}
+	return err
+}
+
+func (s *fileSink) renameBackups() error {
+	for i := s.maxBackups - 1; i > 0; i-- {
+		err := os.Rename(s.backupPath(i), s.backupPath(i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to rotate audit file: %v", err)
+		}
+	}
+	if err := os.Rename(s.path, s.backupPath(1)); err != nil {
+		return fmt.Errorf("failed to rotate audit file: %v", err)
+	}
+	return nil
+}
+
+func (s *fileSink) backupPath(
+	index int,
+) string {
+	return fmt.Sprintf("%v.%v", s.path, index)
+}
@@ -0,0 +1,176 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+const (
+	// SinkLog writes audit records to the service log
+	SinkLog = "log"
+	// SinkFile writes audit records to a rotating local file
+	SinkFile = "file"
+	// SinkPersistence writes audit records to the default store, where they can be read through the admin API
+	SinkPersistence = "persistence"
+
+	// OutcomeSuccess is the outcome of a call which succeeded
+	OutcomeSuccess = "Success"
+	// OutcomeFailure is the outcome of a call which failed
+	OutcomeFailure = "Failure"
+
+	maxReasonLength = 1024
+)
+
+type (
+	// Sink is a destination of audit records
+	Sink interface {
+		Write(record *persistenceblobs.AuditRecord) error
+	}
+
+	logSink struct {
+		logger log.Logger
+	}
+
+	persistenceSink struct {
+		queue persistence.AuditLogQueue
+	}
+)
+
+var _ Sink = (*logSink)(nil)
+var _ Sink = (*persistenceSink)(nil)
+
+// NewSinks creates the sinks listed in the audit config
+func NewSinks(
+	cfg *config.Audit,
+	queue persistence.AuditLogQueue,
+	logger log.Logger,
+) ([]Sink, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	var sinks []Sink
+	for _, name := range cfg.Sinks {
+		switch name {
+		case SinkLog:
+			sinks = append(sinks, NewLogSink(logger))
+		case SinkFile:
+			if cfg.File == nil {
+				return nil, errors.New("audit file sink requires file config")
+			}
+			sink, err := NewFileSink(cfg.File)
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		case SinkPersistence:
+			sinks = append(sinks, NewPersistenceSink(queue))
+		default:
+			return nil, fmt.Errorf("unknown audit sink %v", name)
+		}
+	}
+	return sinks, nil
+}
+
+// NewLogSink creates a sink which writes audit records to the logger
+func NewLogSink(
+	logger log.Logger,
+) Sink {
+	return &logSink{
+		logger: logger,
+	}
+}
+
+// NewPersistenceSink creates a sink which appends audit records to the audit log queue
+func NewPersistenceSink(
+	queue persistence.AuditLogQueue,
+) Sink {
+	return &persistenceSink{
+		queue: queue,
+	}
+}
+
+func (s *logSink) Write(
+	record *persistenceblobs.AuditRecord,
+) error {
+	tags := []tag.Tag{
+		tag.TimestampProto(record.GetTime()),
+		tag.AuditActor(record.GetActor()),
+		tag.AuditIdentity(record.GetIdentity()),
+		tag.AuditAPI(record.GetApi()),
+		tag.WorkflowNamespace(record.GetNamespace()),
+		tag.WorkflowID(record.GetWorkflowId()),
+		tag.WorkflowRunID(record.GetRunId()),
+		tag.AuditRequestID(record.GetRequestId()),
+		tag.AuditOutcome(record.GetOutcome()),
+		tag.AuditReason(record.GetReason()),
+	}
+	if record.GetError() != "" {
+		tags = append(tags, tag.Error(errors.New(record.GetError())))
+	}
+	s.logger.Info("Audit record", tags...)
+	return nil
+}
+
+func (s *persistenceSink) Write(
+	record *persistenceblobs.AuditRecord,
+) error {
+	return s.queue.Append(record)
+}
+
+// SanitizeReason strips control characters from the reason given by a caller
+// and truncates it, so that it is safe to write to line based sinks
+func SanitizeReason(
+	reason string,
+) string {
+	reason = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, reason)
+	reason = strings.TrimSpace(reason)
+
+	if len(reason) > maxReasonLength {
+		// cut on a rune boundary
+		cut := maxReasonLength
+		for cut > 0 && !utf8.RuneStart(reason[cut]) {
+			cut--
+		}
+		reason = reason[:cut]
+	}
+	return reason
+}
@@ -0,0 +1,132 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type (
+	sinkSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		dir string
+	}
+)
+
+func TestSinkSuite(t *testing.T) {
+	s := new(sinkSuite)
+	suite.Run(t, s)
+}
+
+func (s *sinkSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	var err error
+	s.dir, err = ioutil.TempDir("", "TestAuditSink")
+	s.NoError(err)
+}
+
+func (s *sinkSuite) TearDownTest() {
+	s.NoError(os.RemoveAll(s.dir))
+}
+
+func (s *sinkSuite) TestNewSinks() {
+	sinks, err := NewSinks(nil, nil, log.NewNoop())
+	s.NoError(err)
+	s.Empty(sinks)
+
+	sinks, err = NewSinks(&config.Audit{Sinks: []string{SinkLog, SinkPersistence}}, nil, log.NewNoop())
+	s.NoError(err)
+	s.Len(sinks, 2)
+
+	_, err = NewSinks(&config.Audit{Sinks: []string{SinkFile}}, nil, log.NewNoop())
+	s.Error(err)
+
+	_, err = NewSinks(&config.Audit{Sinks: []string{"some random sink"}}, nil, log.NewNoop())
+	s.Error(err)
+}
+
+func (s *sinkSuite) TestFileSink_Rotate() {
+	path := filepath.Join(s.dir, "audit.log")
+	sink, err := NewFileSink(&config.AuditFile{Path: path, MaxBackups: 2})
+	s.NoError(err)
+	sink.(*fileSink).maxSize = 100
+
+	for _, workflowID := range []string{"wid-1", "wid-2", "wid-3", "wid-4"} {
+		s.NoError(sink.Write(&persistenceblobs.AuditRecord{
+			Api:        "WorkflowService.TerminateWorkflowExecution",
+			WorkflowId: workflowID,
+		}))
+	}
+
+	s.assertFileContains(path, "wid-4")
+	s.assertFileContains(path+".1", "wid-3")
+	s.assertFileContains(path+".2", "wid-2")
+	_, err = os.Stat(path + ".3")
+	s.True(os.IsNotExist(err))
+}
+
+func (s *sinkSuite) TestFileSink_Append() {
+	path := filepath.Join(s.dir, "audit.log")
+	sink, err := NewFileSink(&config.AuditFile{Path: path})
+	s.NoError(err)
+	s.NoError(sink.Write(&persistenceblobs.AuditRecord{WorkflowId: "wid-1"}))
+
+	sink, err = NewFileSink(&config.AuditFile{Path: path})
+	s.NoError(err)
+	s.NoError(sink.Write(&persistenceblobs.AuditRecord{WorkflowId: "wid-2"}))
+
+	content, err := ioutil.ReadFile(path)
+	s.NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	s.Len(lines, 2)
+	s.Contains(lines[0], "wid-1")
+	s.Contains(lines[1], "wid-2")
+}
+
+func (s *sinkSuite) TestSanitizeReason() {
+	s.Equal("stuck workflow  fake log line", SanitizeReason(" stuck workflow\n\tfake log line\r\n"))
+	s.Len(SanitizeReason(strings.Repeat("a", 2*maxReasonLength)), maxReasonLength)
+	s.Equal(strings.Repeat("a", maxReasonLength-1), SanitizeReason(strings.Repeat("a", maxReasonLength-1)+"é"))
+}
+
+func (s *sinkSuite) assertFileContains(path string, substr string) {
+	content, err := ioutil.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(content), substr)
+}
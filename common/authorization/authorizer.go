@@ -35,6 +35,12 @@ const (
 	DecisionAllow
 )
 
+const (
+	// PermissionDecodePayloads is the permission to receive the decoded payloads of a namespace
+	// from the frontend when the payloads are encrypted by the payload codec
+	PermissionDecodePayloads = "DecodePayloads"
+)
+
 type (
 	// Attributes is input for authority to make decision.
 	// It can be extended in future if required auth on resources like WorkflowType and TaskList
@@ -42,6 +48,22 @@ type (
 		Actor     string
 		APIName   string
 		Namespace string
+		// Permission is set instead of APIName when the decision is about a permission which is not an API,
+		// such as PermissionDecodePayloads. Permissions are never granted unless explicitly allowed.
+		Permission string
+		// TLSIdentity is the identity of the caller authenticated by its client certificate,
+		// it is nil when the caller did not present a verified certificate
+		TLSIdentity *TLSIdentity
+	}
+
+	// TLSIdentity is the identity of a caller authenticated with mutual TLS
+	TLSIdentity struct {
+		// Subject is the subject of the client certificate
+		Subject string
+		// Issuer is the issuer of the client certificate
+		Issuer string
+		// Namespaces are the namespaces bound to the CAs which verified the client certificate
+		Namespaces []string
 	}
 
 	// Result is result from authority.
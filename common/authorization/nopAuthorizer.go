@@ -28,7 +28,8 @@ import "context"
 
 type nopAuthority struct{}
 
-// NewNopAuthorizer creates a no-op authority
+// NewNopAuthorizer creates a no-op authority, it allows every API but denies the permissions
+// which must be explicitly granted
 func NewNopAuthorizer() Authorizer {
 	return &nopAuthority{}
 }
@@ -37,5 +38,8 @@ func (a *nopAuthority) Authorize(
 	ctx context.Context,
 	attributes *Attributes,
 ) (Result, error) {
+	if len(attributes.Permission) != 0 {
+		return Result{Decision: DecisionDeny}, nil
+	}
 	return Result{Decision: DecisionAllow}, nil
 }
@@ -0,0 +1,57 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package blobstore
+
+import (
+	"context"
+	"errors"
+)
+
+type (
+	// BlobStore stores blobs identified by URI, it is used for offloading large payloads out of history
+	BlobStore interface {
+		// Put writes the blob to the URI, overwriting any existing blob
+		Put(ctx context.Context, URI string, data []byte) error
+		// Get reads the blob at the URI
+		Get(ctx context.Context, URI string) ([]byte, error)
+		// Delete removes the blob at the URI, or all blobs under the URI
+		Delete(ctx context.Context, URI string) error
+	}
+
+	// Provider returns the blob store for a URI scheme,
+	// the blob stores are implemented by the archiver backends
+	Provider interface {
+		GetBlobStore(scheme string) (BlobStore, error)
+	}
+)
+
+var (
+	// ErrInvalidURI is the error for invalid blob store URI
+	ErrInvalidURI = errors.New("invalid blob store URI")
+	// ErrBlobNotFound is the error for blob which does not exist
+	ErrBlobNotFound = errors.New("blob not found")
+	// ErrInvalidBlobReference is the error for blob reference which does not point to a blob offloaded for the execution
+	ErrInvalidBlobReference = errors.New("invalid blob reference")
+)
@@ -0,0 +1,231 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package blobstore
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/pborman/uuid"
+	commonpb "go.temporal.io/temporal-proto/common"
+
+	"github.com/temporalio/temporal/common/payload"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/persistence/serialization"
+)
+
+const (
+	// MetadataEncodingBlobReference is the payload encoding of a payload which is offloaded to a blob store,
+	// the payload data is stored as a blob and the payload itself only keeps the blob URI
+	MetadataEncodingBlobReference = "binary/blob-reference"
+
+	metadataEncoding = "encoding"
+	metadataBlobURI  = "blobURI"
+
+	// payloadsDirectory keeps the offloaded payloads apart from the archived histories
+	// when the store URI is also used for history archival
+	payloadsDirectory = "payloads"
+)
+
+type (
+	// PayloadOffloader offloads large payloads of a message to a blob store and rehydrates them
+	PayloadOffloader interface {
+		// Offload replaces all payloads of the message larger than the size threshold with blob references,
+		// blobs are written under the payloads directory of the store URI
+		Offload(ctx context.Context, storeURI string, namespaceID string, runID string, sizeThreshold int, message interface{}) error
+		// Rehydrate replaces all blob references of the message with the original payloads,
+		// only blobs offloaded for the execution under the store URI are read
+		Rehydrate(ctx context.Context, storeURI string, namespaceID string, runID string, message interface{}) error
+		// DeleteExecutionBlobs removes all blobs offloaded for the execution
+		DeleteExecutionBlobs(ctx context.Context, storeURI string, namespaceID string, runID string) error
+	}
+
+	payloadOffloaderImpl struct {
+		provider Provider
+	}
+)
+
+// NewPayloadOffloader returns a new PayloadOffloader
+func NewPayloadOffloader(provider Provider) PayloadOffloader {
+	return &payloadOffloaderImpl{
+		provider: provider,
+	}
+}
+
+// IsBlobReference returns true if the payload is a reference to a blob in blob store
+func IsBlobReference(p *commonpb.Payload) bool {
+	return string(p.GetMetadata()[metadataEncoding]) == MetadataEncodingBlobReference
+}
+
+// HasBlobReference returns true if any payload of the message is a reference to a blob in blob store
+func HasBlobReference(message interface{}) bool {
+	found := false
+	_ = payload.Visit(message, func(p *commonpb.Payload) error {
+		found = found || IsBlobReference(p)
+		return nil
+	})
+	return found
+}
+
+// RehydrateEventBlob rehydrates a serialized history event batch,
+// the batch is only re-encoded when it references offloaded payloads
+func RehydrateEventBlob(
+	ctx context.Context,
+	offloader PayloadOffloader,
+	serializer persistence.PayloadSerializer,
+	storeURI string,
+	namespaceID string,
+	runID string,
+	blob *serialization.DataBlob,
+) (*serialization.DataBlob, error) {
+
+	events, err := serializer.DeserializeBatchEvents(blob)
+	if err != nil {
+		return nil, err
+	}
+	if !HasBlobReference(events) {
+		return blob, nil
+	}
+	if err := offloader.Rehydrate(ctx, storeURI, namespaceID, runID, events); err != nil {
+		return nil, err
+	}
+	return serializer.SerializeBatchEvents(events, blob.Encoding)
+}
+
+func (o *payloadOffloaderImpl) Offload(
+	ctx context.Context,
+	storeURI string,
+	namespaceID string,
+	runID string,
+	sizeThreshold int,
+	message interface{},
+) error {
+
+	if sizeThreshold <= 0 || len(storeURI) == 0 {
+		return nil
+	}
+	store, err := o.getBlobStore(storeURI)
+	if err != nil {
+		return err
+	}
+	executionURI := getExecutionURI(storeURI, namespaceID, runID)
+
+	return payload.Visit(message, func(p *commonpb.Payload) error {
+		if IsBlobReference(p) || p.Size() <= sizeThreshold {
+			return nil
+		}
+
+		data, err := p.Marshal()
+		if err != nil {
+			return err
+		}
+		blobURI := executionURI + "/" + uuid.New()
+		if err := store.Put(ctx, blobURI, data); err != nil {
+			return err
+		}
+		*p = commonpb.Payload{
+			Metadata: map[string][]byte{
+				metadataEncoding: []byte(MetadataEncodingBlobReference),
+				metadataBlobURI:  []byte(blobURI),
+			},
+		}
+		return nil
+	})
+}
+
+func (o *payloadOffloaderImpl) Rehydrate(
+	ctx context.Context,
+	storeURI string,
+	namespaceID string,
+	runID string,
+	message interface{},
+) error {
+
+	executionURI := getExecutionURI(storeURI, namespaceID, runID)
+	return payload.Visit(message, func(p *commonpb.Payload) error {
+		if !IsBlobReference(p) {
+			return nil
+		}
+
+		// the blob URI is part of the payload, never read a blob which was not offloaded for the execution
+		blobURI := string(p.GetMetadata()[metadataBlobURI])
+		if len(storeURI) == 0 || !isExecutionBlobURI(executionURI, blobURI) {
+			return ErrInvalidBlobReference
+		}
+		store, err := o.getBlobStore(blobURI)
+		if err != nil {
+			return err
+		}
+		data, err := store.Get(ctx, blobURI)
+		if err != nil {
+			return err
+		}
+		rehydrated := commonpb.Payload{}
+		if err := rehydrated.Unmarshal(data); err != nil {
+			return err
+		}
+		*p = rehydrated
+		return nil
+	})
+}
+
+func (o *payloadOffloaderImpl) DeleteExecutionBlobs(
+	ctx context.Context,
+	storeURI string,
+	namespaceID string,
+	runID string,
+) error {
+
+	if len(storeURI) == 0 {
+		return nil
+	}
+	store, err := o.getBlobStore(storeURI)
+	if err != nil {
+		return err
+	}
+	return store.Delete(ctx, getExecutionURI(storeURI, namespaceID, runID))
+}
+
+func (o *payloadOffloaderImpl) getBlobStore(URI string) (BlobStore, error) {
+	u, err := url.ParseRequestURI(URI)
+	if err != nil {
+		return nil, ErrInvalidURI
+	}
+	return o.provider.GetBlobStore(u.Scheme)
+}
+
+func getExecutionURI(storeURI string, namespaceID string, runID string) string {
+	return strings.TrimRight(storeURI, "/") + "/" + payloadsDirectory + "/" + namespaceID + "/" + runID
+}
+
+// isExecutionBlobURI returns true if the blob URI names a blob written by Offload directly under the execution URI
+func isExecutionBlobURI(executionURI string, blobURI string) bool {
+	prefix := executionURI + "/"
+	if !strings.HasPrefix(blobURI, prefix) {
+		return false
+	}
+	return uuid.Parse(strings.TrimPrefix(blobURI, prefix)) != nil
+}
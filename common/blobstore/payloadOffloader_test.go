@@ -0,0 +1,274 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	payloadOffloaderSuite struct {
+		*require.Assertions
+		suite.Suite
+
+		storeURI   string
+		offloader  PayloadOffloader
+		store      *memoryStore
+		smallInput *commonpb.Payload
+		largeInput *commonpb.Payload
+	}
+
+	memoryStore struct {
+		sync.Mutex
+		blobs map[string][]byte
+	}
+
+	memoryProvider struct {
+		store *memoryStore
+	}
+)
+
+var errUnknownScheme = errors.New("unknown scheme")
+
+func TestPayloadOffloaderSuite(t *testing.T) {
+	suite.Run(t, new(payloadOffloaderSuite))
+}
+
+func (s *payloadOffloaderSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.storeURI = "mem://bucket/archival"
+	s.store = &memoryStore{blobs: make(map[string][]byte)}
+	s.offloader = NewPayloadOffloader(&memoryProvider{store: s.store})
+
+	s.smallInput = &commonpb.Payload{
+		Metadata: map[string][]byte{metadataEncoding: []byte("json/plain")},
+		Data:     []byte(`"small"`),
+	}
+	s.largeInput = &commonpb.Payload{
+		Metadata: map[string][]byte{metadataEncoding: []byte("json/plain")},
+		Data:     []byte(`"` + strings.Repeat("large", 100) + `"`),
+	}
+}
+
+func (s *payloadOffloaderSuite) newEvent() *eventpb.HistoryEvent {
+	return &eventpb.HistoryEvent{
+		EventId:   5,
+		EventType: eventpb.EventType_ActivityTaskScheduled,
+		Attributes: &eventpb.HistoryEvent_ActivityTaskScheduledEventAttributes{
+			ActivityTaskScheduledEventAttributes: &eventpb.ActivityTaskScheduledEventAttributes{
+				ActivityId: "activity-id",
+				Input: &commonpb.Payloads{
+					Payloads: []*commonpb.Payload{
+						copyPayload(s.smallInput),
+						copyPayload(s.largeInput),
+					},
+				},
+				Header: &commonpb.Header{
+					Fields: map[string]*commonpb.Payload{
+						"large-header": copyPayload(s.largeInput),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *payloadOffloaderSuite) TestOffloadAndRehydrate() {
+	event := s.newEvent()
+	events := []*eventpb.HistoryEvent{event}
+
+	err := s.offloader.Offload(context.Background(), s.storeURI, "namespace-id", "run-id", 100, events)
+	s.NoError(err)
+
+	attributes := event.GetActivityTaskScheduledEventAttributes()
+	s.Equal(s.smallInput, attributes.Input.Payloads[0])
+	s.True(IsBlobReference(attributes.Input.Payloads[1]))
+	s.True(IsBlobReference(attributes.Header.Fields["large-header"]))
+	s.True(strings.HasPrefix(
+		string(attributes.Input.Payloads[1].Metadata[metadataBlobURI]),
+		s.storeURI+"/payloads/namespace-id/run-id/",
+	))
+
+	// offloading again should not offload blob references
+	blobURI := string(attributes.Input.Payloads[1].Metadata[metadataBlobURI])
+	err = s.offloader.Offload(context.Background(), s.storeURI, "namespace-id", "run-id", 10, events)
+	s.NoError(err)
+	s.Equal(blobURI, string(attributes.Input.Payloads[1].Metadata[metadataBlobURI]))
+
+	err = s.offloader.Rehydrate(context.Background(), s.storeURI, "namespace-id", "run-id", events)
+	s.NoError(err)
+	s.Equal(s.newEvent(), event)
+}
+
+func (s *payloadOffloaderSuite) TestOffload_Disabled() {
+	event := s.newEvent()
+
+	err := s.offloader.Offload(context.Background(), s.storeURI, "namespace-id", "run-id", 0, event)
+	s.NoError(err)
+	s.Equal(s.newEvent(), event)
+
+	err = s.offloader.Offload(context.Background(), "", "namespace-id", "run-id", 100, event)
+	s.NoError(err)
+	s.Equal(s.newEvent(), event)
+}
+
+func (s *payloadOffloaderSuite) TestOffload_UnknownScheme() {
+	err := s.offloader.Offload(context.Background(), "unknown:///blobs", "namespace-id", "run-id", 100, s.newEvent())
+	s.Equal(errUnknownScheme, err)
+
+	err = s.offloader.Offload(context.Background(), "blobs", "namespace-id", "run-id", 100, s.newEvent())
+	s.Equal(ErrInvalidURI, err)
+}
+
+func (s *payloadOffloaderSuite) TestDeleteExecutionBlobs() {
+	event := s.newEvent()
+	err := s.offloader.Offload(context.Background(), s.storeURI, "namespace-id", "run-id", 100, event)
+	s.NoError(err)
+
+	err = s.offloader.DeleteExecutionBlobs(context.Background(), s.storeURI, "namespace-id", "run-id")
+	s.NoError(err)
+
+	blobURI := string(event.GetActivityTaskScheduledEventAttributes().Input.Payloads[1].Metadata[metadataBlobURI])
+	_, err = s.store.Get(context.Background(), blobURI)
+	s.Equal(ErrBlobNotFound, err)
+
+	err = s.offloader.Rehydrate(context.Background(), s.storeURI, "namespace-id", "run-id", event)
+	s.Equal(ErrBlobNotFound, err)
+}
+
+func (s *payloadOffloaderSuite) TestRehydrate_InvalidBlobReference() {
+	event := s.newEvent()
+	err := s.offloader.Offload(context.Background(), s.storeURI, "namespace-id", "run-id", 100, event)
+	s.NoError(err)
+	blobURI := string(event.GetActivityTaskScheduledEventAttributes().Input.Payloads[1].Metadata[metadataBlobURI])
+
+	// blobs of other executions and namespaces are never read
+	err = s.offloader.Rehydrate(context.Background(), s.storeURI, "namespace-id", "other-run-id", event)
+	s.Equal(ErrInvalidBlobReference, err)
+	err = s.offloader.Rehydrate(context.Background(), s.storeURI, "other-namespace-id", "run-id", event)
+	s.Equal(ErrInvalidBlobReference, err)
+	err = s.offloader.Rehydrate(context.Background(), "", "namespace-id", "run-id", event)
+	s.Equal(ErrInvalidBlobReference, err)
+
+	for _, forged := range []string{
+		"file:///etc/passwd",
+		s.storeURI + "/payloads/namespace-id/run-id/../../other-namespace-id/run-id/" + uuid.New(),
+		s.storeURI + "/payloads/namespace-id/run-id/" + uuid.New() + "/blob",
+		strings.TrimSuffix(blobURI, "/"+path.Base(blobURI)),
+	} {
+		event := s.newEvent()
+		event.GetActivityTaskScheduledEventAttributes().Input.Payloads[1] = &commonpb.Payload{
+			Metadata: map[string][]byte{
+				metadataEncoding: []byte(MetadataEncodingBlobReference),
+				metadataBlobURI:  []byte(forged),
+			},
+		}
+		err = s.offloader.Rehydrate(context.Background(), s.storeURI, "namespace-id", "run-id", event)
+		s.Equal(ErrInvalidBlobReference, err, forged)
+	}
+}
+
+func (s *payloadOffloaderSuite) TestRehydrateEventBlob() {
+	serializer := persistence.NewPayloadSerializer()
+	events := []*eventpb.HistoryEvent{s.newEvent()}
+	blob, err := serializer.SerializeBatchEvents(events, common.EncodingTypeProto3)
+	s.NoError(err)
+
+	// a batch without blob references is returned as is
+	rehydrated, err := RehydrateEventBlob(context.Background(), s.offloader, serializer, s.storeURI, "namespace-id", "run-id", blob)
+	s.NoError(err)
+	s.True(blob == rehydrated)
+
+	err = s.offloader.Offload(context.Background(), s.storeURI, "namespace-id", "run-id", 100, events)
+	s.NoError(err)
+	s.True(HasBlobReference(events))
+	blob, err = serializer.SerializeBatchEvents(events, common.EncodingTypeProto3)
+	s.NoError(err)
+
+	rehydrated, err = RehydrateEventBlob(context.Background(), s.offloader, serializer, s.storeURI, "namespace-id", "run-id", blob)
+	s.NoError(err)
+	rehydratedEvents, err := serializer.DeserializeBatchEvents(rehydrated)
+	s.NoError(err)
+	s.Equal([]*eventpb.HistoryEvent{s.newEvent()}, rehydratedEvents)
+	s.False(HasBlobReference(rehydratedEvents))
+}
+
+func copyPayload(p *commonpb.Payload) *commonpb.Payload {
+	metadata := make(map[string][]byte, len(p.Metadata))
+	for k, v := range p.Metadata {
+		metadata[k] = v
+	}
+	return &commonpb.Payload{Metadata: metadata, Data: p.Data}
+}
+
+func (p *memoryProvider) GetBlobStore(scheme string) (BlobStore, error) {
+	if scheme != "mem" {
+		return nil, errUnknownScheme
+	}
+	return p.store, nil
+}
+
+func (m *memoryStore) Put(_ context.Context, URI string, data []byte) error {
+	m.Lock()
+	defer m.Unlock()
+	m.blobs[URI] = data
+	return nil
+}
+
+func (m *memoryStore) Get(_ context.Context, URI string) ([]byte, error) {
+	m.Lock()
+	defer m.Unlock()
+	data, ok := m.blobs[URI]
+	if !ok {
+		return nil, ErrBlobNotFound
+	}
+	return data, nil
+}
+
+func (m *memoryStore) Delete(_ context.Context, URI string) error {
+	m.Lock()
+	defer m.Unlock()
+	for blobURI := range m.blobs {
+		if blobURI == URI || strings.HasPrefix(blobURI, URI+"/") {
+			delete(m.blobs, blobURI)
+		}
+	}
+	return nil
+}
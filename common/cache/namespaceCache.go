@@ -27,6 +27,7 @@
 package cache
 
 import (
+	"fmt"
 	"hash/fnv"
 	"sort"
 	"strconv"
@@ -137,6 +138,7 @@ type (
 		isGlobalNamespace           bool
 		failoverNotificationVersion int64
 		notificationVersion         int64
+		handover                    *persistenceblobs.NamespaceHandover
 		initialized                 bool
 	}
 )
@@ -544,6 +546,7 @@ func (c *namespaceCache) updateIDToNamespaceCache(
 	entry.isGlobalNamespace = record.isGlobalNamespace
 	entry.failoverNotificationVersion = record.failoverNotificationVersion
 	entry.notificationVersion = record.notificationVersion
+	entry.handover = record.handover
 	entry.initialized = record.initialized
 
 	nextNamespace := entry.duplicate()
@@ -672,6 +675,7 @@ func (c *namespaceCache) buildEntryFromRecord(
 	newEntry.isGlobalNamespace = record.IsGlobalNamespace
 	newEntry.failoverNotificationVersion = record.Namespace.FailoverNotificationVersion
 	newEntry.notificationVersion = record.NotificationVersion
+	newEntry.handover = record.Namespace.Handover
 	newEntry.initialized = true
 	return newEntry
 }
@@ -695,6 +699,9 @@ func (entry *NamespaceCacheEntry) duplicate() *NamespaceCacheEntry {
 	result.isGlobalNamespace = entry.isGlobalNamespace
 	result.failoverNotificationVersion = entry.failoverNotificationVersion
 	result.notificationVersion = entry.notificationVersion
+	if entry.handover != nil {
+		result.handover = proto.Clone(entry.handover).(*persistenceblobs.NamespaceHandover)
+	}
 	result.initialized = entry.initialized
 	return result
 }
@@ -739,6 +746,16 @@ func (entry *NamespaceCacheEntry) GetNotificationVersion() int64 {
 	return entry.notificationVersion
 }
 
+// GetHandover return the graceful failover handover of the namespace, nil if the namespace is not in handover
+func (entry *NamespaceCacheEntry) GetHandover() *persistenceblobs.NamespaceHandover {
+	return entry.handover
+}
+
+// IsNamespaceInHandover return whether the namespace is handing over its active role to another cluster
+func (entry *NamespaceCacheEntry) IsNamespaceInHandover() bool {
+	return entry.handover != nil
+}
+
 // IsNamespaceActive return whether the namespace is active, i.e. non global namespace or global namespace which active cluster is the current cluster
 func (entry *NamespaceCacheEntry) IsNamespaceActive() bool {
 	if !entry.isGlobalNamespace {
@@ -771,6 +788,18 @@ func (entry *NamespaceCacheEntry) GetNamespaceNotActiveErr() error {
 	)
 }
 
+// GetNamespaceHandoverErr return a retryable err if the namespace is active and in handover, nil otherwise
+func (entry *NamespaceCacheEntry) GetNamespaceHandoverErr() error {
+	if !entry.IsNamespaceActive() || !entry.IsNamespaceInHandover() {
+		return nil
+	}
+	return serviceerror.NewUnavailable(fmt.Sprintf(
+		"Namespace %v is in handover to cluster %v, retry later.",
+		entry.info.Name,
+		entry.handover.TargetClusterName,
+	))
+}
+
 // Len return length
 func (t NamespaceCacheEntries) Len() int {
 	return len(t)
@@ -28,11 +28,13 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally"
 	namespacepb "go.temporal.io/temporal-proto/namespace"
+	"go.temporal.io/temporal-proto/serviceerror"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common/cluster"
@@ -672,3 +674,34 @@ func Test_IsSampledForLongerRetention(t *testing.T) {
 	d.info.Data[SampleRateKey] = "invalid-value"
 	require.False(t, d.IsSampledForLongerRetention(wid))
 }
+
+func Test_GetNamespaceHandoverErr(t *testing.T) {
+	d := &NamespaceCacheEntry{
+		clusterMetadata: cluster.GetTestClusterMetadata(true, true),
+		info:            &persistenceblobs.NamespaceInfo{Name: "some random namespace"},
+		config: &persistenceblobs.NamespaceConfig{
+			BadBinaries: &namespacepb.BadBinaries{
+				Binaries: map[string]*namespacepb.BadBinaryInfo{},
+			},
+		},
+		isGlobalNamespace: true,
+		replicationConfig: &persistenceblobs.NamespaceReplicationConfig{
+			ActiveClusterName: cluster.TestCurrentClusterName,
+			Clusters:          []string{cluster.TestCurrentClusterName, cluster.TestAlternativeClusterName},
+		},
+	}
+	require.False(t, d.IsNamespaceInHandover())
+	require.NoError(t, d.GetNamespaceHandoverErr())
+
+	d.handover = &persistenceblobs.NamespaceHandover{
+		TargetClusterName: cluster.TestAlternativeClusterName,
+		HandoverVersion:   1,
+	}
+	require.True(t, d.IsNamespaceInHandover())
+	require.IsType(t, &serviceerror.Unavailable{}, d.GetNamespaceHandoverErr())
+	require.True(t, proto.Equal(d.handover, d.duplicate().GetHandover()))
+
+	// the standby cluster keeps rejecting with namespace not active
+	d.replicationConfig.ActiveClusterName = cluster.TestAlternativeClusterName
+	require.NoError(t, d.GetNamespaceHandoverErr())
+}
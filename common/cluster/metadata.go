@@ -28,6 +28,7 @@ package cluster
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
@@ -57,8 +58,18 @@ type (
 		ClusterNameForFailoverVersion(failoverVersion int64) string
 		// GetReplicationConsumerConfig returns the config for replication task consumer.
 		GetReplicationConsumerConfig() *config.ReplicationConsumerConfig
+		// UpdateRemoteClusters applies the dynamically managed remote clusters on top of the static cluster information
+		UpdateRemoteClusters(remoteClusters map[string]config.ClusterInformation) error
+		// RegisterMetadataChangeCallback registers a callback which is invoked when clusters are added, updated or removed
+		RegisterMetadataChangeCallback(callbackID interface{}, cb CallbackFn)
+		// UnRegisterMetadataChangeCallback removes the callback registered with callbackID
+		UnRegisterMetadataChangeCallback(callbackID interface{})
 	}
 
+	// CallbackFn is invoked with the old and the new information of the clusters which changed,
+	// nil old information means the cluster was added, nil new information means the cluster was removed
+	CallbackFn func(oldClusterMetadata map[string]*config.ClusterInformation, newClusterMetadata map[string]*config.ClusterInformation)
+
 	metadataImpl struct {
 		logger log.Logger
 		// EnableGlobalNamespace whether the global namespace is enabled,
@@ -71,10 +82,18 @@ type (
 		masterClusterName string
 		// currentClusterName is the name of the current cluster
 		currentClusterName string
+		// staticClusterInfo contains the cluster name -> corresponding information from static config
+		staticClusterInfo map[string]config.ClusterInformation
+
+		clusterLock sync.RWMutex
 		// clusterInfo contains all cluster name -> corresponding information
 		clusterInfo map[string]config.ClusterInformation
-		// versionToClusterName contains all initial version -> corresponding cluster name
+		// versionToClusterName contains all initial version -> corresponding cluster name,
+		// versions of removed clusters are kept so existing history can still be resolved
 		versionToClusterName map[int64]string
+
+		callbackLock           sync.Mutex
+		clusterChangeCallbacks map[interface{}]CallbackFn
 		//replicationConsumer returns the config for replication task consumer.
 		replicationConsumer *config.ReplicationConsumerConfig
 	}
@@ -137,8 +156,10 @@ func NewMetadata(
 		failoverVersionIncrement: failoverVersionIncrement,
 		masterClusterName:        masterClusterName,
 		currentClusterName:       currentClusterName,
+		staticClusterInfo:        clusterInfo,
 		clusterInfo:              clusterInfo,
 		versionToClusterName:     versionToClusterName,
+		clusterChangeCallbacks:   make(map[interface{}]CallbackFn),
 	}
 }
 
@@ -150,7 +171,9 @@ func (metadata *metadataImpl) IsGlobalNamespaceEnabled() bool {
 
 // GetNextFailoverVersion return the next failover version based on input
 func (metadata *metadataImpl) GetNextFailoverVersion(cluster string, currentFailoverVersion int64) int64 {
+	metadata.clusterLock.RLock()
 	info, ok := metadata.clusterInfo[cluster]
+	metadata.clusterLock.RUnlock()
 	if !ok {
 		panic(fmt.Sprintf(
 			"Unknown cluster name: %v with given cluster initial failover version map: %v.",
@@ -186,7 +209,14 @@ func (metadata *metadataImpl) GetCurrentClusterName() string {
 
 // GetAllClusterInfo return the all cluster name -> corresponding information
 func (metadata *metadataImpl) GetAllClusterInfo() map[string]config.ClusterInformation {
-	return metadata.clusterInfo
+	metadata.clusterLock.RLock()
+	defer metadata.clusterLock.RUnlock()
+
+	clusterInfo := make(map[string]config.ClusterInformation, len(metadata.clusterInfo))
+	for clusterName, info := range metadata.clusterInfo {
+		clusterInfo[clusterName] = info
+	}
+	return clusterInfo
 }
 
 // ClusterNameForFailoverVersion return the corresponding cluster name for a given failover version
@@ -196,7 +226,9 @@ func (metadata *metadataImpl) ClusterNameForFailoverVersion(failoverVersion int6
 	}
 
 	initialFailoverVersion := failoverVersion % metadata.failoverVersionIncrement
+	metadata.clusterLock.RLock()
 	clusterName, ok := metadata.versionToClusterName[initialFailoverVersion]
+	metadata.clusterLock.RUnlock()
 	if !ok {
 		panic(fmt.Sprintf(
 			"Unknown initial failover version %v with given cluster initial failover version map: %v and failover version increment %v.",
@@ -215,3 +247,115 @@ func (metadata *metadataImpl) GetReplicationConsumerConfig() *config.Replication
 
 	return metadata.replicationConsumer
 }
+
+// UpdateRemoteClusters applies the dynamically managed remote clusters on top of the static cluster information
+// and notifies the registered callbacks about the clusters which changed
+func (metadata *metadataImpl) UpdateRemoteClusters(remoteClusters map[string]config.ClusterInformation) error {
+	clusterInfo := make(map[string]config.ClusterInformation, len(metadata.staticClusterInfo)+len(remoteClusters))
+	for clusterName, info := range metadata.staticClusterInfo {
+		clusterInfo[clusterName] = info
+	}
+	for clusterName, info := range remoteClusters {
+		if err := metadata.validateRemoteCluster(clusterName, info); err != nil {
+			return err
+		}
+		clusterInfo[clusterName] = info
+	}
+
+	initialVersions := make(map[int64]string, len(clusterInfo))
+	for clusterName, info := range clusterInfo {
+		if existing, ok := initialVersions[info.InitialFailoverVersion]; ok {
+			return fmt.Errorf("clusters %v and %v have the same initial failover version %v", existing, clusterName, info.InitialFailoverVersion)
+		}
+		initialVersions[info.InitialFailoverVersion] = clusterName
+	}
+
+	metadata.clusterLock.Lock()
+	for initialVersion, clusterName := range initialVersions {
+		if existing, ok := metadata.versionToClusterName[initialVersion]; ok && existing != clusterName {
+			metadata.clusterLock.Unlock()
+			return fmt.Errorf("initial failover version %v of cluster %v is already used by cluster %v", initialVersion, clusterName, existing)
+		}
+	}
+
+	oldClusterMetadata := make(map[string]*config.ClusterInformation)
+	newClusterMetadata := make(map[string]*config.ClusterInformation)
+	for clusterName, oldInfo := range metadata.clusterInfo {
+		oldInfo := oldInfo
+		newInfo, ok := clusterInfo[clusterName]
+		if !ok {
+			oldClusterMetadata[clusterName] = &oldInfo
+			newClusterMetadata[clusterName] = nil
+		} else if newInfo != oldInfo {
+			oldClusterMetadata[clusterName] = &oldInfo
+			newClusterMetadata[clusterName] = &newInfo
+		}
+	}
+	for clusterName, newInfo := range clusterInfo {
+		newInfo := newInfo
+		if _, ok := metadata.clusterInfo[clusterName]; !ok {
+			oldClusterMetadata[clusterName] = nil
+			newClusterMetadata[clusterName] = &newInfo
+		}
+	}
+
+	for initialVersion, clusterName := range initialVersions {
+		metadata.versionToClusterName[initialVersion] = clusterName
+	}
+	metadata.clusterInfo = clusterInfo
+	metadata.clusterLock.Unlock()
+
+	if len(newClusterMetadata) == 0 {
+		return nil
+	}
+
+	metadata.logger.Info(fmt.Sprintf("Remote clusters updated: %v.", clusterInfo))
+	metadata.callbackLock.Lock()
+	callbacks := make([]CallbackFn, 0, len(metadata.clusterChangeCallbacks))
+	for _, cb := range metadata.clusterChangeCallbacks {
+		callbacks = append(callbacks, cb)
+	}
+	metadata.callbackLock.Unlock()
+
+	for _, cb := range callbacks {
+		cb(oldClusterMetadata, newClusterMetadata)
+	}
+	return nil
+}
+
+// RegisterMetadataChangeCallback registers a callback which is invoked when clusters are added, updated or removed
+func (metadata *metadataImpl) RegisterMetadataChangeCallback(callbackID interface{}, cb CallbackFn) {
+	metadata.callbackLock.Lock()
+	defer metadata.callbackLock.Unlock()
+
+	metadata.clusterChangeCallbacks[callbackID] = cb
+}
+
+// UnRegisterMetadataChangeCallback removes the callback registered with callbackID
+func (metadata *metadataImpl) UnRegisterMetadataChangeCallback(callbackID interface{}) {
+	metadata.callbackLock.Lock()
+	defer metadata.callbackLock.Unlock()
+
+	delete(metadata.clusterChangeCallbacks, callbackID)
+}
+
+func (metadata *metadataImpl) validateRemoteCluster(clusterName string, info config.ClusterInformation) error {
+	if len(clusterName) == 0 {
+		return fmt.Errorf("remote cluster name is empty")
+	}
+	if clusterName == metadata.currentClusterName {
+		return fmt.Errorf("remote cluster %v is the current cluster", clusterName)
+	}
+	if metadata.failoverVersionIncrement <= info.InitialFailoverVersion || info.InitialFailoverVersion < 0 {
+		return fmt.Errorf(
+			"initial failover version %v of cluster %v is not within [0, %v)",
+			info.InitialFailoverVersion,
+			clusterName,
+			metadata.failoverVersionIncrement,
+		)
+	}
+	if info.Enabled && len(info.RPCAddress) == 0 {
+		return fmt.Errorf("cluster %v: rpc address is empty", clusterName)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cluster
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/config"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	// MetadataRefresher periodically reloads the remote clusters managed through the admin APIs
+	// from persistence and applies them to the cluster metadata
+	MetadataRefresher struct {
+		status                 int32
+		metadata               Metadata
+		clusterMetadataManager persistence.ClusterMetadataManager
+		refreshInterval        dynamicconfig.DurationPropertyFn
+		logger                 log.Logger
+		shutdownChan           chan struct{}
+	}
+)
+
+// NewMetadataRefresher creates a new instance of MetadataRefresher
+func NewMetadataRefresher(
+	metadata Metadata,
+	clusterMetadataManager persistence.ClusterMetadataManager,
+	refreshInterval dynamicconfig.DurationPropertyFn,
+	logger log.Logger,
+) *MetadataRefresher {
+
+	return &MetadataRefresher{
+		status:                 common.DaemonStatusInitialized,
+		metadata:               metadata,
+		clusterMetadataManager: clusterMetadataManager,
+		refreshInterval:        refreshInterval,
+		logger:                 logger,
+		shutdownChan:           make(chan struct{}),
+	}
+}
+
+// Start loads the remote clusters once and starts the background refresh
+func (r *MetadataRefresher) Start() {
+	if !atomic.CompareAndSwapInt32(&r.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+
+	if err := r.refresh(); err != nil {
+		r.logger.Error("Error loading remote clusters.", tag.Error(err))
+	}
+	go r.refreshLoop()
+}
+
+// Stop stops the background refresh
+func (r *MetadataRefresher) Stop() {
+	if !atomic.CompareAndSwapInt32(&r.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+
+	close(r.shutdownChan)
+}
+
+func (r *MetadataRefresher) refreshLoop() {
+	timer := time.NewTimer(r.refreshInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-r.shutdownChan:
+			return
+		case <-timer.C:
+			if err := r.refresh(); err != nil {
+				r.logger.Error("Error refreshing remote clusters.", tag.Error(err))
+			}
+			timer.Reset(r.refreshInterval())
+		}
+	}
+}
+
+func (r *MetadataRefresher) refresh() error {
+	resp, err := r.clusterMetadataManager.ListRemoteClusters()
+	if err != nil {
+		return err
+	}
+
+	remoteClusters := make(map[string]config.ClusterInformation, len(resp.RemoteClusters))
+	for _, info := range resp.RemoteClusters {
+		remoteClusters[info.GetClusterName()] = config.ClusterInformation{
+			Enabled:                info.GetEnabled(),
+			InitialFailoverVersion: info.GetInitialFailoverVersion(),
+			RPCAddress:             info.GetRpcAddress(),
+		}
+	}
+	return r.metadata.UpdateRemoteClusters(remoteClusters)
+}
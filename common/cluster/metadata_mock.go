@@ -182,3 +182,41 @@ func (mr *MockMetadataMockRecorder) GetReplicationConsumerConfig() *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplicationConsumerConfig", reflect.TypeOf((*MockMetadata)(nil).GetReplicationConsumerConfig))
 }
+
+// UpdateRemoteClusters mocks base method.
+func (m *MockMetadata) UpdateRemoteClusters(remoteClusters map[string]config.ClusterInformation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRemoteClusters", remoteClusters)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRemoteClusters indicates an expected call of UpdateRemoteClusters.
+func (mr *MockMetadataMockRecorder) UpdateRemoteClusters(remoteClusters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRemoteClusters", reflect.TypeOf((*MockMetadata)(nil).UpdateRemoteClusters), remoteClusters)
+}
+
+// RegisterMetadataChangeCallback mocks base method.
+func (m *MockMetadata) RegisterMetadataChangeCallback(callbackID interface{}, cb CallbackFn) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterMetadataChangeCallback", callbackID, cb)
+}
+
+// RegisterMetadataChangeCallback indicates an expected call of RegisterMetadataChangeCallback.
+func (mr *MockMetadataMockRecorder) RegisterMetadataChangeCallback(callbackID, cb interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterMetadataChangeCallback", reflect.TypeOf((*MockMetadata)(nil).RegisterMetadataChangeCallback), callbackID, cb)
+}
+
+// UnRegisterMetadataChangeCallback mocks base method.
+func (m *MockMetadata) UnRegisterMetadataChangeCallback(callbackID interface{}) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UnRegisterMetadataChangeCallback", callbackID)
+}
+
+// UnRegisterMetadataChangeCallback indicates an expected call of UnRegisterMetadataChangeCallback.
+func (mr *MockMetadataMockRecorder) UnRegisterMetadataChangeCallback(callbackID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnRegisterMetadataChangeCallback", reflect.TypeOf((*MockMetadata)(nil).UnRegisterMetadataChangeCallback), callbackID)
+}
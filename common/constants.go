@@ -70,6 +70,11 @@ const (
 	EncodingTypeUnknown EncodingType = "unknow"
 	EncodingTypeEmpty   EncodingType = ""
 	EncodingTypeProto3  EncodingType = "proto3"
+
+	// EncodingTypeProto3Snappy is proto3 compressed with snappy, only used for history event blobs
+	EncodingTypeProto3Snappy EncodingType = "proto3-snappy"
+	// EncodingTypeProto3Zstd is proto3 compressed with zstd, only used for history event blobs
+	EncodingTypeProto3Zstd EncodingType = "proto3-zstd"
 )
 
 func (e EncodingType) String() string {
@@ -91,6 +96,11 @@ const (
 	ReadDLQMessagesPageSize = 1000
 )
 
+const (
+	// WorkflowExecutionHistoryExportFormatVersion is the current format version of exported workflow histories
+	WorkflowExecutionHistoryExportFormatVersion = 1
+)
+
 const (
 	// VisibilityAppName is used to find kafka topics and ES indexName for visibility
 	VisibilityAppName = "visibility"
@@ -139,6 +149,13 @@ const (
 	ArchivalPaused = "paused"
 )
 
+const (
+	// ResetReapplyTypeSignal reapplies the signals received after the reset point
+	ResetReapplyTypeSignal = "Signal"
+	// ResetReapplyTypeNone reapplies no events after the reset point
+	ResetReapplyTypeNone = "None"
+)
+
 // enum for dynamic config AdvancedVisibilityWritingMode
 const (
 	// AdvancedVisibilityWritingModeOff means do not write to advanced visibility store
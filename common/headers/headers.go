@@ -41,6 +41,18 @@ const (
 
 	// ClientImplHeaderName refers to the name of the gRPC metadata header that contains the client implementation.
 	ClientImplHeaderName = "temporal-client-name"
+
+	// CompletionCallbackURLHeaderName refers to the name of the gRPC metadata header that contains the URLs
+	// which are notified when a workflow started by the request completes. The header can be set multiple times.
+	CompletionCallbackURLHeaderName = "temporal-completion-callback-url"
+
+	// CompletionCallbackStateHeaderName refers to the name of the gRPC metadata header that contains the
+	// JSON encoded state of a workflow completion callback, one value per callback.
+	CompletionCallbackStateHeaderName = "temporal-completion-callback-state"
+
+	// TraceParentHeaderName refers to the name of the gRPC metadata header that carries the span context
+	// of the caller, in the W3C trace context format "00-<trace-id>-<span-id>-<flags>".
+	TraceParentHeaderName = "traceparent"
 )
 
 var (
@@ -50,6 +62,12 @@ var (
 		ClientImplHeaderName:           GoSDK,
 	})
 
+	// requestOptionHeaders carry request options which have no field in the public API requests,
+	// they are forwarded with the request when it is redirected to another cluster
+	requestOptionHeaders = []string{
+		CompletionCallbackURLHeaderName,
+	}
+
 	cliVersionHeaders = metadata.New(map[string]string{
 		ClientVersionHeaderName:        SupportedCLIVersion,
 		ClientFeatureVersionHeaderName: BaseFeaturesFeatureVersion,
@@ -71,6 +89,14 @@ func GetValues(ctx context.Context, headerNames ...string) []string {
 	return headerValues
 }
 
+// GetAllValues returns all values of the passed header name.
+func GetAllValues(ctx context.Context, headerName string) []string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		return md.Get(headerName)
+	}
+	return nil
+}
+
 // PropagateVersions propagates version headers from incoming context to outgoing context.
 // It copies all version headers to outgoing context only if they are exist in incoming context
 // and doesn't exist in outgoing context already.
@@ -99,6 +125,29 @@ func PropagateVersions(ctx context.Context) context.Context {
 	return ctx
 }
 
+// PropagateRequestOptions propagates request option headers from incoming context to outgoing context,
+// unless they are already set in outgoing context.
+func PropagateRequestOptions(ctx context.Context) context.Context {
+	mdIncoming, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	mdOutgoing, _ := metadata.FromOutgoingContext(ctx)
+	var headersToAppend []string
+	for _, headerName := range requestOptionHeaders {
+		if len(mdOutgoing.Get(headerName)) > 0 {
+			continue
+		}
+		for _, value := range mdIncoming.Get(headerName) {
+			headersToAppend = append(headersToAppend, headerName, value)
+		}
+	}
+	if headersToAppend != nil {
+		ctx = metadata.AppendToOutgoingContext(ctx, headersToAppend...)
+	}
+	return ctx
+}
+
 // SetVersions sets headers for internal communications.
 func SetVersions(ctx context.Context) context.Context {
 	return metadata.NewOutgoingContext(ctx, versionHeaders)
@@ -127,3 +127,34 @@ func (s *HeadersSuite) TestPropagateHeaders_EmptyIncomingContext() {
 	s.Equal("21.04.16", md.Get(ClientFeatureVersionHeaderName)[0])
 	s.Equal("28.08.14", md.Get(ClientImplHeaderName)[0])
 }
+
+func (s *HeadersSuite) TestGetAllValues() {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		CompletionCallbackURLHeaderName, "http://localhost:8080/a",
+		CompletionCallbackURLHeaderName, "http://localhost:8080/b",
+	))
+
+	s.Equal([]string{"http://localhost:8080/a", "http://localhost:8080/b"}, GetAllValues(ctx, CompletionCallbackURLHeaderName))
+	s.Empty(GetAllValues(ctx, ClientImplHeaderName))
+	s.Empty(GetAllValues(context.Background(), CompletionCallbackURLHeaderName))
+}
+
+func (s *HeadersSuite) TestPropagateRequestOptions() {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		CompletionCallbackURLHeaderName, "http://localhost:8080/a",
+		CompletionCallbackURLHeaderName, "http://localhost:8080/b",
+		ClientImplHeaderName, "28.08.14",
+	))
+
+	ctx = PropagateRequestOptions(ctx)
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	s.True(ok)
+	s.Equal([]string{"http://localhost:8080/a", "http://localhost:8080/b"}, md.Get(CompletionCallbackURLHeaderName))
+	s.Empty(md.Get(ClientImplHeaderName))
+
+	// values already set in outgoing context are kept
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs(CompletionCallbackURLHeaderName, "http://localhost:8080/c"))
+	md, _ = metadata.FromOutgoingContext(PropagateRequestOptions(ctx))
+	s.Equal([]string{"http://localhost:8080/c"}, md.Get(CompletionCallbackURLHeaderName))
+}
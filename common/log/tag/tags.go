@@ -842,3 +842,65 @@ func QueryLevel(s time.Time) Tag {
 func TaskListInfo(s interface{}) Tag {
 	return newObjectTag("task-list-info", s)
 }
+
+// TraceID returns tag for the trace ID of a span
+func TraceID(traceID string) Tag {
+	return newStringTag("trace-id", traceID)
+}
+
+// SpanID returns tag for the ID of a span
+func SpanID(spanID string) Tag {
+	return newStringTag("span-id", spanID)
+}
+
+// ParentSpanID returns tag for the ID of the parent of a span
+func ParentSpanID(spanID string) Tag {
+	return newStringTag("parent-span-id", spanID)
+}
+
+// SpanName returns tag for the name of a span
+func SpanName(name string) Tag {
+	return newStringTag("span-name", name)
+}
+
+// SpanAttributes returns tag for the attributes of a span
+func SpanAttributes(attributes interface{}) Tag {
+	return newObjectTag("span-attributes", attributes)
+}
+
+// SpanDuration returns tag for the duration of a span
+func SpanDuration(duration time.Duration) Tag {
+	return newDurationTag("span-duration", duration)
+}
+
+///////////////////  Audit tags defined here: audit- ///////////////////
+
+// AuditActor returns tag for the actor of an audited call
+func AuditActor(actor string) Tag {
+	return newStringTag("audit-actor", actor)
+}
+
+// AuditIdentity returns tag for the identity reported by the caller of an audited call
+func AuditIdentity(identity string) Tag {
+	return newStringTag("audit-identity", identity)
+}
+
+// AuditAPI returns tag for the API of an audited call
+func AuditAPI(api string) Tag {
+	return newStringTag("audit-api", api)
+}
+
+// AuditRequestID returns tag for the request ID of an audited call
+func AuditRequestID(requestID string) Tag {
+	return newStringTag("audit-request-id", requestID)
+}
+
+// AuditOutcome returns tag for the outcome of an audited call
+func AuditOutcome(outcome string) Tag {
+	return newStringTag("audit-outcome", outcome)
+}
+
+// AuditReason returns tag for the reason given for an audited call
+func AuditReason(reason string) Tag {
+	return newStringTag("audit-reason", reason)
+}
@@ -126,6 +126,7 @@ var (
 	ComponentWorker                   = component("worker")
 	ComponentServiceResolver          = component("service-resolver")
 	ComponentMetadataInitializer      = component("metadata-initializer")
+	ComponentNamespaceDeletion        = component("namespace-deletion")
 )
 
 // Pre-defined values for TagSysLifecycle
@@ -65,6 +65,10 @@ type (
 		// called, other members will discover that this node is no longer part of the
 		// ring. This primitive is useful to carry out graceful host shutdown during deployments.
 		EvictSelf() error
+		// SetDraining marks this member as draining (or clears the mark). Draining members
+		// stay in the gossip cluster but are excluded from every service ring, so their keys
+		// move to other hosts without the process having to exit.
+		SetDraining(draining bool) error
 		Lookup(service string, key string) (*HostInfo, error)
 		GetResolver(service string) (ServiceResolver, error)
 		// AddListener adds a listener for this service.
@@ -109,6 +109,20 @@ func (mr *MockMonitorMockRecorder) EvictSelf() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvictSelf", reflect.TypeOf((*MockMonitor)(nil).EvictSelf))
 }
 
+// SetDraining mocks base method.
+func (m *MockMonitor) SetDraining(draining bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDraining", draining)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDraining indicates an expected call of SetDraining.
+func (mr *MockMonitorMockRecorder) SetDraining(draining interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDraining", reflect.TypeOf((*MockMonitor)(nil).SetDraining), draining)
+}
+
 // Lookup mocks base method.
 func (m *MockMonitor) Lookup(service, key string) (*HostInfo, error) {
 	m.ctrl.T.Helper()
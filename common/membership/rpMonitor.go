@@ -325,6 +325,18 @@ func (rpo *ringpopMonitor) EvictSelf() error {
 	return rpo.rp.SelfEvict()
 }
 
+func (rpo *ringpopMonitor) SetDraining(draining bool) error {
+	labels, err := rpo.rp.Labels()
+	if err != nil {
+		return err
+	}
+	if draining {
+		return labels.Set(DrainingKey, drainingLabelValue)
+	}
+	_, err = labels.Remove(DrainingKey)
+	return err
+}
+
 func (rpo *ringpopMonitor) GetResolver(service string) (ServiceResolver, error) {
 	ring, found := rpo.rings[service]
 	if !found {
@@ -32,6 +32,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"github.com/uber/ringpop-go/swim"
 
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 )
@@ -103,6 +104,12 @@ func (s *RpoSuite) TestCompareMembers() {
 	s.testCompareMembers([]string{"a", "b"}, []string{"a", "b"}, false)
 }
 
+func (s *RpoSuite) TestMemberNotDraining() {
+	s.True(memberNotDraining(swim.Member{Labels: nil}))
+	s.True(memberNotDraining(swim.Member{Labels: swim.LabelMap{RoleKey: primitives.HistoryService}}))
+	s.False(memberNotDraining(swim.Member{Labels: swim.LabelMap{RoleKey: primitives.HistoryService, DrainingKey: drainingLabelValue}}))
+}
+
 func (s *RpoSuite) testCompareMembers(curr []string, new []string, hasDiff bool) {
 	resolver := &ringpopServiceResolver{}
 	currMembers := make(map[string]struct{}, len(curr))
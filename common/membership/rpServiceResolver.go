@@ -47,7 +47,11 @@ import (
 const (
 	// RoleKey label is set by every single service as soon as it bootstraps its
 	// ringpop instance. The data for this key is the service name
-	RoleKey                = "serviceName"
+	RoleKey = "serviceName"
+	// DrainingKey label is set by a host that is being drained. Members carrying
+	// this label are left out of the service rings while staying in the cluster.
+	DrainingKey            = "draining"
+	drainingLabelValue     = "true"
 	minRefreshInternal     = time.Second * 4
 	defaultRefreshInterval = time.Second * 10
 	replicaPoints          = 100
@@ -112,7 +116,7 @@ func (r *ringpopServiceResolver) Start() {
 	}
 
 	r.rp.AddListener(r)
-	if err := r.refresh(); err != nil {
+	if _, err := r.refresh(); err != nil {
 		r.logger.Fatal("unable to start ring pop service resolver", tag.Error(err))
 	}
 
@@ -217,38 +221,55 @@ func (r *ringpopServiceResolver) HandleEvent(
 		// Note that we receive events asynchronously, possibly out of order.
 		// We cannot rely on the content of the event, rather we load everything
 		// from ringpop when we get a notification that something changed.
-		if err := r.refresh(); err != nil {
+		if _, err := r.refresh(); err != nil {
 			r.logger.Error("error refreshing ring when receiving a ring changed event", tag.Error(err))
 		}
 		r.emitEvent(e)
 	}
 }
 
-func (r *ringpopServiceResolver) refresh() error {
+func (r *ringpopServiceResolver) refresh() (*ChangedEvent, error) {
 	r.refreshLock.Lock()
 	defer r.refreshLock.Unlock()
 	return r.refreshNoLock()
 }
 
-func (r *ringpopServiceResolver) refreshWithBackoff() error {
+func (r *ringpopServiceResolver) refreshWithBackoff() (*ChangedEvent, error) {
 	r.refreshLock.Lock()
 	defer r.refreshLock.Unlock()
 	if r.lastRefreshTime.After(time.Now().Add(-minRefreshInternal)) {
 		// refresh too frequently
-		return nil
+		return nil, nil
 	}
 	return r.refreshNoLock()
 }
 
-func (r *ringpopServiceResolver) refreshNoLock() error {
-	addrs, err := r.rp.GetReachableMembers(swim.MemberWithLabelAndValue(RoleKey, r.service))
+// refreshNoLock rebuilds the ring from the reachable members and returns the
+// membership delta, or nil if nothing changed
+func (r *ringpopServiceResolver) refreshNoLock() (*ChangedEvent, error) {
+	addrs, err := r.rp.GetReachableMembers(
+		swim.MemberWithLabelAndValue(RoleKey, r.service),
+		memberNotDraining,
+	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	newMembersMap, changed := r.compareMembers(addrs)
 	if !changed {
-		return nil
+		return nil, nil
+	}
+
+	event := &ChangedEvent{}
+	for addr := range newMembersMap {
+		if _, ok := r.membersMap[addr]; !ok {
+			event.HostsAdded = append(event.HostsAdded, NewHostInfo(addr, r.getLabelsMap()))
+		}
+	}
+	for addr := range r.membersMap {
+		if _, ok := newMembersMap[addr]; !ok {
+			event.HostsRemoved = append(event.HostsRemoved, NewHostInfo(addr, r.getLabelsMap()))
+		}
 	}
 
 	ring := newHashRing()
@@ -261,7 +282,12 @@ func (r *ringpopServiceResolver) refreshNoLock() error {
 	r.lastRefreshTime = time.Now()
 	r.ringValue.Store(ring)
 	r.logger.Info("Current reachable members", tag.Addresses(addrs))
-	return nil
+	return event, nil
+}
+
+// memberNotDraining filters out members that are being drained, see DrainingKey
+func memberNotDraining(member swim.Member) bool {
+	return member.Labels[DrainingKey] != drainingLabelValue
 }
 
 func (r *ringpopServiceResolver) emitEvent(
@@ -279,6 +305,12 @@ func (r *ringpopServiceResolver) emitEvent(
 	for _, addr := range rpEvent.ServersUpdated {
 		event.HostsUpdated = append(event.HostsUpdated, NewHostInfo(addr, r.getLabelsMap()))
 	}
+	r.notifyListeners(event)
+}
+
+func (r *ringpopServiceResolver) notifyListeners(
+	event *ChangedEvent,
+) {
 
 	// Notify listeners
 	r.listenerLock.RLock()
@@ -304,17 +336,27 @@ func (r *ringpopServiceResolver) refreshRingWorker() {
 		case <-r.shutdownCh:
 			return
 		case <-r.refreshChan:
-			if err := r.refreshWithBackoff(); err != nil {
-				r.logger.Error("error periodically refreshing ring", tag.Error(err))
-			}
+			r.refreshAndNotify()
 		case <-refreshTicker.C:
-			if err := r.refreshWithBackoff(); err != nil {
-				r.logger.Error("error periodically refreshing ring", tag.Error(err))
-			}
+			r.refreshAndNotify()
 		}
 	}
 }
 
+// refreshAndNotify refreshes the ring and notifies listeners of any change found.
+// Label changes such as a host starting to drain do not produce ringpop ring
+// changed events, so this is how listeners learn about them.
+func (r *ringpopServiceResolver) refreshAndNotify() {
+	event, err := r.refreshWithBackoff()
+	if err != nil {
+		r.logger.Error("error periodically refreshing ring", tag.Error(err))
+		return
+	}
+	if event != nil {
+		r.notifyListeners(event)
+	}
+}
+
 func (r *ringpopServiceResolver) ring() *hashring.HashRing {
 	return r.ringValue.Load().(*hashring.HashRing)
 }
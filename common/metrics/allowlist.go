@@ -0,0 +1,92 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+type (
+	// TagFilter decides whether the value of a high cardinality tag is emitted by the
+	// scope of an operation. Values of filtered out tags are replaced with a constant.
+	TagFilter interface {
+		Allow(operation string, tagKey string) bool
+	}
+
+	// TagAllowlistFn returns the tag allowlist, it maps a tag key to the list of
+	// operations which emit the tag value. An operation of "*" matches all operations
+	// and tags which are not in the allowlist are emitted by all operations.
+	TagAllowlistFn func() map[string]interface{}
+
+	tagAllowlist struct {
+		allowlist TagAllowlistFn
+	}
+
+	allowAllTagFilter struct{}
+)
+
+const allOperations = "*"
+
+// filterableTags are the tags with unbounded cardinality which can be filtered out
+var filterableTags = map[string]struct{}{
+	namespace:    {},
+	taskList:     {},
+	workflowType: {},
+}
+
+// NewTagAllowlist returns a TagFilter backed by the given allowlist. The allowlist is
+// evaluated on every use so it can be backed by dynamic config.
+func NewTagAllowlist(allowlist TagAllowlistFn) TagFilter {
+	return &tagAllowlist{
+		allowlist: allowlist,
+	}
+}
+
+// Allow returns true if the operation emits the value of the tag
+func (t *tagAllowlist) Allow(operation string, tagKey string) bool {
+	if _, ok := filterableTags[tagKey]; !ok {
+		return true
+	}
+	operations, ok := t.allowlist()[tagKey]
+	if !ok {
+		return true
+	}
+	switch operations := operations.(type) {
+	case []interface{}:
+		for _, op := range operations {
+			if op == operation || op == allOperations {
+				return true
+			}
+		}
+	case []string:
+		for _, op := range operations {
+			if op == operation || op == allOperations {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Allow returns true for all tags
+func (t *allowAllTagFilter) Allow(_ string, _ string) bool {
+	return true
+}
@@ -34,26 +34,49 @@ import (
 	"github.com/temporalio/temporal/common/primitives"
 )
 
-// ClientImpl is used for reporting metrics by various Temporal services
-type ClientImpl struct {
-	//parentReporter is the parent scope for the metrics
-	parentScope tally.Scope
-	childScopes map[int]tally.Scope
-	metricDefs  map[int]metricDefinition
-	serviceIdx  ServiceIdx
-}
+type (
+	// ClientImpl is used for reporting metrics by various Temporal services
+	ClientImpl struct {
+		//parentReporter is the parent scope for the metrics
+		parentScope tally.Scope
+		childScopes map[int]tally.Scope
+		scopeTags   map[int]map[string]string
+		metricDefs  map[int]metricDefinition
+		serviceIdx  ServiceIdx
+		options     *ClientOptions
+	}
+
+	// ClientOptions holds the optional settings of a Client
+	ClientOptions struct {
+		// HistogramTimers emits the timers which define buckets as histograms with these buckets
+		HistogramTimers bool
+		// TagFilter filters the high cardinality tags of the scopes, all tags are emitted if nil
+		TagFilter TagFilter
+		// ExemplarReporter receives the trace exemplars of the timers which define buckets,
+		// exemplars are not recorded if nil
+		ExemplarReporter ExemplarReporter
+	}
+)
 
 // NewClient creates and returns a new instance of
 // Client implementation
 // reporter holds the common tags for the service
 // serviceIdx indicates the service type in (InputhostIndex, ... StorageIndex)
 func NewClient(scope tally.Scope, serviceIdx ServiceIdx) Client {
+	return NewClientWithOptions(scope, serviceIdx, ClientOptions{})
+}
+
+// NewClientWithOptions creates and returns a new instance of
+// Client implementation with the given options
+func NewClientWithOptions(scope tally.Scope, serviceIdx ServiceIdx, options ClientOptions) Client {
 	totalScopes := len(ScopeDefs[Common]) + len(ScopeDefs[serviceIdx])
 	metricsClient := &ClientImpl{
 		parentScope: scope,
 		childScopes: make(map[int]tally.Scope, totalScopes),
+		scopeTags:   make(map[int]map[string]string, totalScopes),
 		metricDefs:  getMetricDefs(serviceIdx),
 		serviceIdx:  serviceIdx,
+		options:     newClientOptions(options),
 	}
 
 	for idx, def := range ScopeDefs[Common] {
@@ -62,6 +85,7 @@ func NewClient(scope tally.Scope, serviceIdx ServiceIdx) Client {
 		}
 		mergeMapToRight(def.tags, scopeTags)
 		metricsClient.childScopes[idx] = scope.Tagged(scopeTags)
+		metricsClient.scopeTags[idx] = scopeTags
 	}
 
 	for idx, def := range ScopeDefs[serviceIdx] {
@@ -70,6 +94,7 @@ func NewClient(scope tally.Scope, serviceIdx ServiceIdx) Client {
 		}
 		mergeMapToRight(def.tags, scopeTags)
 		metricsClient.childScopes[idx] = scope.Tagged(scopeTags)
+		metricsClient.scopeTags[idx] = scopeTags
 	}
 
 	return metricsClient
@@ -113,7 +138,15 @@ func (m *ClientImpl) UpdateGauge(scopeIdx int, gaugeIdx int, value float64) {
 // information to the metrics emitted
 func (m *ClientImpl) Scope(scopeIdx int, tags ...Tag) Scope {
 	scope := m.childScopes[scopeIdx]
-	return newMetricsScope(scope, scope, m.metricDefs, false).Tagged(tags...)
+	scopeTags := m.scopeTags[scopeIdx]
+	return newMetricsScope(scope, scope, m.metricDefs, false, scopeTags[OperationTagName], scopeTags, m.options).Tagged(tags...)
+}
+
+func newClientOptions(options ClientOptions) *ClientOptions {
+	if options.TagFilter == nil {
+		options.TagFilter = &allowAllTagFilter{}
+	}
+	return &options
 }
 
 func getMetricDefs(serviceIdx ServiceIdx) map[int]metricDefinition {
@@ -24,7 +24,11 @@
 
 package metrics
 
-import "github.com/uber-go/tally"
+import (
+	"time"
+
+	"github.com/uber-go/tally"
+)
 
 // types used/defined by the package
 type (
@@ -174,6 +178,18 @@ const (
 	PersistenceDeleteReplicationTaskFromDLQScope
 	// PersistenceRangeDeleteReplicationTaskFromDLQScope tracks PersistenceRangeDeleteReplicationTaskFromDLQScope calls made by service to persistence layer
 	PersistenceRangeDeleteReplicationTaskFromDLQScope
+	// PersistencePutTransferTaskToDLQScope tracks PersistencePutTransferTaskToDLQScope calls made by service to persistence layer
+	PersistencePutTransferTaskToDLQScope
+	// PersistenceGetTransferTasksFromDLQScope tracks PersistenceGetTransferTasksFromDLQScope calls made by service to persistence layer
+	PersistenceGetTransferTasksFromDLQScope
+	// PersistenceRangeDeleteTransferTaskFromDLQScope tracks PersistenceRangeDeleteTransferTaskFromDLQScope calls made by service to persistence layer
+	PersistenceRangeDeleteTransferTaskFromDLQScope
+	// PersistencePutTimerTaskToDLQScope tracks PersistencePutTimerTaskToDLQScope calls made by service to persistence layer
+	PersistencePutTimerTaskToDLQScope
+	// PersistenceGetTimerTasksFromDLQScope tracks PersistenceGetTimerTasksFromDLQScope calls made by service to persistence layer
+	PersistenceGetTimerTasksFromDLQScope
+	// PersistenceRangeDeleteTimerTaskFromDLQScope tracks PersistenceRangeDeleteTimerTaskFromDLQScope calls made by service to persistence layer
+	PersistenceRangeDeleteTimerTaskFromDLQScope
 	// PersistenceGetTimerTaskScope tracks GetTimerTask calls made by service to persistence layer
 	PersistenceGetTimerTaskScope
 	// PersistenceGetTimerIndexTasksScope tracks GetTimerIndexTasks calls made by service to persistence layer
@@ -276,6 +292,18 @@ const (
 	PersistenceInitImmutableClusterMetadataScope
 	// PersistenceGetImmutableClusterMetadataScope tracks GetImmutableClusterMetadata calls made by service to persistence layer
 	PersistenceGetImmutableClusterMetadataScope
+	// PersistenceUpsertRemoteClusterScope tracks UpsertRemoteCluster calls made by service to persistence layer
+	PersistenceUpsertRemoteClusterScope
+	// PersistenceListRemoteClustersScope tracks ListRemoteClusters calls made by service to persistence layer
+	PersistenceListRemoteClustersScope
+	// PersistenceDeleteRemoteClusterScope tracks DeleteRemoteCluster calls made by service to persistence layer
+	PersistenceDeleteRemoteClusterScope
+	// PersistenceUpdateDynamicConfigScope tracks UpdateDynamicConfig calls made by service to persistence layer
+	PersistenceUpdateDynamicConfigScope
+	// PersistenceListDynamicConfigValuesScope tracks ListDynamicConfigValues calls made by service to persistence layer
+	PersistenceListDynamicConfigValuesScope
+	// PersistenceGetDynamicConfigHistoryScope tracks GetDynamicConfigHistory calls made by service to persistence layer
+	PersistenceGetDynamicConfigHistoryScope
 	// PersistenceUpsertClusterMembershipScope tracks UpsertClusterMembership calls made by service to persistence layer
 	PersistenceUpsertClusterMembershipScope
 	// PersistencePruneClusterMembershipScope tracks PruneClusterMembership calls made by service to persistence layer
@@ -300,6 +328,8 @@ const (
 	HistoryClientGetMutableStateScope
 	// HistoryClientPollMutableStateScope tracks RPC calls to history service
 	HistoryClientPollMutableStateScope
+	// HistoryClientWatchMutableStateScope tracks RPC calls to history service
+	HistoryClientWatchMutableStateScope
 	// HistoryClientResetStickyTaskListScope tracks RPC calls to history service
 	HistoryClientResetStickyTaskListScope
 	// HistoryClientDescribeWorkflowExecutionScope tracks RPC calls to history service
@@ -350,6 +380,16 @@ const (
 	HistoryClientMergeDLQMessagesScope
 	// HistoryClientRefreshWorkflowTasksScope tracks RPC calls to history service
 	HistoryClientRefreshWorkflowTasksScope
+	// HistoryClientImportWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientImportWorkflowExecutionScope
+	// HistoryClientDeleteWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientDeleteWorkflowExecutionScope
+	// HistoryClientGetReplicationStatusScope tracks RPC calls to history service
+	HistoryClientGetReplicationStatusScope
+	// HistoryClientSetShardOwnerScope tracks RPC calls to history service
+	HistoryClientSetShardOwnerScope
+	// HistoryClientDrainHostScope tracks RPC calls to history service
+	HistoryClientDrainHostScope
 	// MatchingClientPollForDecisionTaskScope tracks RPC calls to matching service
 	MatchingClientPollForDecisionTaskScope
 	// MatchingClientPollForActivityTaskScope tracks RPC calls to matching service
@@ -368,6 +408,8 @@ const (
 	MatchingClientDescribeTaskListScope
 	// MatchingClientListTaskListPartitionsScope tracks RPC calls to matching service
 	MatchingClientListTaskListPartitionsScope
+	// MatchingClientDrainHostScope tracks RPC calls to matching service
+	MatchingClientDrainHostScope
 	// FrontendClientDeprecateNamespaceScope tracks RPC calls to frontend service
 	FrontendClientDeprecateNamespaceScope
 	// FrontendClientDescribeNamespaceScope tracks RPC calls to frontend service
@@ -478,6 +520,36 @@ const (
 	AdminClientMergeDLQMessagesScope
 	// AdminClientRefreshWorkflowTasksScope tracks RPC calls to admin service
 	AdminClientRefreshWorkflowTasksScope
+	// AdminClientResetWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientResetWorkflowExecutionScope
+	// AdminClientExportWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientExportWorkflowExecutionScope
+	// AdminClientImportWorkflowExecutionScope tracks RPC calls to admin service
+	AdminClientImportWorkflowExecutionScope
+	// AdminClientDeleteNamespaceScope tracks RPC calls to admin service
+	AdminClientDeleteNamespaceScope
+	// AdminClientGetReplicationStatusScope tracks RPC calls to admin service
+	AdminClientGetReplicationStatusScope
+	// AdminClientAddOrUpdateRemoteClusterScope tracks RPC calls to admin service
+	AdminClientAddOrUpdateRemoteClusterScope
+	// AdminClientRemoveRemoteClusterScope tracks RPC calls to admin service
+	AdminClientRemoveRemoteClusterScope
+	// AdminClientSetShardOwnerScope tracks RPC calls to admin service
+	AdminClientSetShardOwnerScope
+	// AdminClientDrainHostScope tracks RPC calls to admin service
+	AdminClientDrainHostScope
+	// AdminClientGetDynamicConfigScope tracks RPC calls to admin service
+	AdminClientGetDynamicConfigScope
+	// AdminClientUpdateDynamicConfigScope tracks RPC calls to admin service
+	AdminClientUpdateDynamicConfigScope
+	// AdminClientDeleteDynamicConfigScope tracks RPC calls to admin service
+	AdminClientDeleteDynamicConfigScope
+	// AdminClientGetDynamicConfigHistoryScope tracks RPC calls to admin service
+	AdminClientGetDynamicConfigHistoryScope
+	// AdminClientRollbackDynamicConfigScope tracks RPC calls to admin service
+	AdminClientRollbackDynamicConfigScope
+	// AdminClientGetAuditLogScope tracks RPC calls to admin service
+	AdminClientGetAuditLogScope
 	// DCRedirectionDeprecateNamespaceScope tracks RPC calls for dc redirection
 	DCRedirectionDeprecateNamespaceScope
 	// DCRedirectionDescribeNamespaceScope tracks RPC calls for dc redirection
@@ -556,6 +628,8 @@ const (
 	DCRedirectionUpdateNamespaceScope
 	// DCRedirectionListTaskListPartitionsScope tracks RPC calls for dc redirection
 	DCRedirectionListTaskListPartitionsScope
+	// DCRedirectionStreamWorkflowExecutionHistoryScope tracks RPC calls for dc redirection
+	DCRedirectionStreamWorkflowExecutionHistoryScope
 
 	// MessagingPublishScope tracks Publish calls made by service to messaging layer
 	MessagingClientPublishScope
@@ -679,6 +753,8 @@ const (
 	AdminReapplyEventsScope
 	// AdminRefreshWorkflowTasksScope is the metric scope for admin.RefreshWorkflowTasks
 	AdminRefreshWorkflowTasksScope
+	// AdminResetWorkflowExecutionScope is the metric scope for admin.ResetWorkflowExecution
+	AdminResetWorkflowExecutionScope
 	// AdminRemoveTaskScope is the metric scope for admin.AdminRemoveTaskScope
 	AdminRemoveTaskScope
 	//AdminCloseShardTaskScope is the metric scope for admin.AdminRemoveTaskScope
@@ -689,6 +765,34 @@ const (
 	AdminPurgeDLQMessagesScope
 	//AdminMergeDLQMessagesScope is the metric scope for admin.AdminMergeDLQMessagesScope
 	AdminMergeDLQMessagesScope
+	// AdminExportWorkflowExecutionScope is the metric scope for admin.ExportWorkflowExecution
+	AdminExportWorkflowExecutionScope
+	// AdminImportWorkflowExecutionScope is the metric scope for admin.ImportWorkflowExecution
+	AdminImportWorkflowExecutionScope
+	// AdminDeleteNamespaceScope is the metric scope for admin.DeleteNamespace
+	AdminDeleteNamespaceScope
+	// AdminGetReplicationStatusScope is the metric scope for admin.GetReplicationStatus
+	AdminGetReplicationStatusScope
+	// AdminAddOrUpdateRemoteClusterScope is the metric scope for admin.AddOrUpdateRemoteCluster
+	AdminAddOrUpdateRemoteClusterScope
+	// AdminRemoveRemoteClusterScope is the metric scope for admin.RemoveRemoteCluster
+	AdminRemoveRemoteClusterScope
+	// AdminSetShardOwnerScope is the metric scope for admin.SetShardOwner
+	AdminSetShardOwnerScope
+	// AdminDrainHostScope is the metric scope for admin.DrainHost
+	AdminDrainHostScope
+	// AdminGetDynamicConfigScope is the metric scope for admin.GetDynamicConfig
+	AdminGetDynamicConfigScope
+	// AdminUpdateDynamicConfigScope is the metric scope for admin.UpdateDynamicConfig
+	AdminUpdateDynamicConfigScope
+	// AdminDeleteDynamicConfigScope is the metric scope for admin.DeleteDynamicConfig
+	AdminDeleteDynamicConfigScope
+	// AdminGetDynamicConfigHistoryScope is the metric scope for admin.GetDynamicConfigHistory
+	AdminGetDynamicConfigHistoryScope
+	// AdminRollbackDynamicConfigScope is the metric scope for admin.RollbackDynamicConfig
+	AdminRollbackDynamicConfigScope
+	// AdminGetAuditLogScope is the metric scope for admin.GetAuditLog
+	AdminGetAuditLogScope
 
 	NumAdminScopes
 )
@@ -773,6 +877,10 @@ const (
 	FrontendResetWorkflowExecutionScope
 	// FrontendGetSearchAttributesScope is the metric scope for frontend.GetSearchAttributes
 	FrontendGetSearchAttributesScope
+	// FrontendStreamWorkflowExecutionHistoryScope is the metric scope for frontend.StreamWorkflowExecutionHistory
+	FrontendStreamWorkflowExecutionHistoryScope
+	// FrontendRateLimitScope is the metric scope for the frontend rate limiting of all APIs
+	FrontendRateLimitScope
 
 	NumFrontendScopes
 )
@@ -797,6 +905,8 @@ const (
 	HistoryGetMutableStateScope
 	// HistoryPollMutableStateScope tracks PollMutableStateScope API calls received by service
 	HistoryPollMutableStateScope
+	// HistoryWatchMutableStateScope tracks WatchMutableState API calls received by service
+	HistoryWatchMutableStateScope
 	// HistoryResetStickyTaskListScope tracks ResetStickyTaskListScope API calls received by service
 	HistoryResetStickyTaskListScope
 	// HistoryDescribeWorkflowExecutionScope tracks DescribeWorkflowExecution API calls received by service
@@ -847,6 +957,16 @@ const (
 	HistoryReapplyEventsScope
 	// HistoryRefreshWorkflowTasksScope is the scope used by refresh workflow tasks API
 	HistoryRefreshWorkflowTasksScope
+	// HistoryImportWorkflowExecutionScope tracks ImportWorkflowExecution API calls received by service
+	HistoryImportWorkflowExecutionScope
+	// HistoryDeleteWorkflowExecutionScope tracks DeleteWorkflowExecution API calls received by service
+	HistoryDeleteWorkflowExecutionScope
+	// HistoryGetReplicationStatusScope tracks GetReplicationStatus API calls received by service
+	HistoryGetReplicationStatusScope
+	// HistorySetShardOwnerScope tracks SetShardOwner API calls received by service
+	HistorySetShardOwnerScope
+	// HistoryDrainHostScope tracks DrainHost API calls received by service
+	HistoryDrainHostScope
 	// TaskPriorityAssignerScope is the scope used by all metric emitted by task priority assigner
 	TaskPriorityAssignerScope
 	// TransferQueueProcessorScope is the scope used by all metric emitted by transfer queue processor
@@ -873,6 +993,8 @@ const (
 	TransferActiveTaskResetWorkflowScope
 	// TransferActiveTaskUpsertWorkflowSearchAttributesScope is the scope used for upsert search attributes processing by transfer queue processor
 	TransferActiveTaskUpsertWorkflowSearchAttributesScope
+	// TransferActiveTaskWorkflowCompletionCallbackScope is the scope used for workflow completion callback processing by transfer queue processor
+	TransferActiveTaskWorkflowCompletionCallbackScope
 	// TransferStandbyTaskResetWorkflowScope is the scope used for record workflow started task processing by transfer queue processor
 	TransferStandbyTaskResetWorkflowScope
 	// TransferStandbyTaskActivityScope is the scope used for activity task processing by transfer queue processor
@@ -891,6 +1013,8 @@ const (
 	TransferStandbyTaskRecordWorkflowStartedScope
 	// TransferStandbyTaskUpsertWorkflowSearchAttributesScope is the scope used for upsert search attributes processing by transfer queue processor
 	TransferStandbyTaskUpsertWorkflowSearchAttributesScope
+	// TransferStandbyTaskWorkflowCompletionCallbackScope is the scope used for workflow completion callback processing by transfer queue processor
+	TransferStandbyTaskWorkflowCompletionCallbackScope
 	// TimerQueueProcessorScope is the scope used by all metric emitted by timer queue processor
 	TimerQueueProcessorScope
 	// TimerActiveQueueProcessorScope is the scope used by all metric emitted by timer queue processor
@@ -1005,6 +1129,8 @@ const (
 	MatchingDescribeTaskListScope
 	// MatchingListTaskListPartitionsScope tracks ListTaskListPartitions API calls received by service
 	MatchingListTaskListPartitionsScope
+	// MatchingDrainHostScope tracks DrainHost API calls received by service
+	MatchingDrainHostScope
 
 	NumMatchingScopes
 )
@@ -1051,6 +1177,8 @@ const (
 	HistoryScavengerScope
 	// ParentClosePolicyProcessorScope is scope used by all metrics emitted by worker.ParentClosePolicyProcessor
 	ParentClosePolicyProcessorScope
+	// NamespaceDeletionScope is scope used by all metrics emitted by worker.namespacedeletion module
+	NamespaceDeletionScope
 
 	NumWorkerScopes
 )
@@ -1081,6 +1209,12 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceGetReplicationTasksFromDLQScope:               {operation: "GetReplicationTasksFromDLQ"},
 		PersistenceDeleteReplicationTaskFromDLQScope:             {operation: "DeleteReplicationTaskFromDLQ"},
 		PersistenceRangeDeleteReplicationTaskFromDLQScope:        {operation: "RangeDeleteReplicationTaskFromDLQ"},
+		PersistencePutTransferTaskToDLQScope:                     {operation: "PutTransferTaskToDLQ"},
+		PersistenceGetTransferTasksFromDLQScope:                  {operation: "GetTransferTasksFromDLQ"},
+		PersistenceRangeDeleteTransferTaskFromDLQScope:           {operation: "RangeDeleteTransferTaskFromDLQ"},
+		PersistencePutTimerTaskToDLQScope:                        {operation: "PutTimerTaskToDLQ"},
+		PersistenceGetTimerTasksFromDLQScope:                     {operation: "GetTimerTasksFromDLQ"},
+		PersistenceRangeDeleteTimerTaskFromDLQScope:              {operation: "RangeDeleteTimerTaskFromDLQ"},
 		PersistenceGetTimerTaskScope:                             {operation: "GetTimerTask"},
 		PersistenceGetTimerIndexTasksScope:                       {operation: "GetTimerIndexTasks"},
 		PersistenceCompleteTimerTaskScope:                        {operation: "CompleteTimerTask"},
@@ -1140,6 +1274,12 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceNamespaceReplicationQueueScope:                {operation: "NamespaceReplicationQueue"},
 		PersistenceInitImmutableClusterMetadataScope:             {operation: "InitializeImmutableClusterMetadata"},
 		PersistenceGetImmutableClusterMetadataScope:              {operation: "GetImmutableClusterMetadata"},
+		PersistenceUpsertRemoteClusterScope:                      {operation: "UpsertRemoteCluster"},
+		PersistenceListRemoteClustersScope:                       {operation: "ListRemoteClusters"},
+		PersistenceDeleteRemoteClusterScope:                      {operation: "DeleteRemoteCluster"},
+		PersistenceUpdateDynamicConfigScope:                      {operation: "UpdateDynamicConfig"},
+		PersistenceListDynamicConfigValuesScope:                  {operation: "ListDynamicConfigValues"},
+		PersistenceGetDynamicConfigHistoryScope:                  {operation: "GetDynamicConfigHistory"},
 		PersistencePruneClusterMembershipScope:                   {operation: "PruneClusterMembership"},
 		PersistenceGetClusterMembersScope:                        {operation: "GetClusterMembership"},
 		PersistenceUpsertClusterMembershipScope:                  {operation: "UpsertClusterMembership"},
@@ -1155,6 +1295,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientRespondActivityTaskCanceledScope:         {operation: "HistoryClientRespondActivityTaskCanceled", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientGetMutableStateScope:                     {operation: "HistoryClientGetMutableState", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientPollMutableStateScope:                    {operation: "HistoryClientPollMutableState", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientWatchMutableStateScope:                   {operation: "HistoryClientWatchMutableState", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientResetStickyTaskListScope:                 {operation: "HistoryClientResetStickyTaskListScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientDescribeWorkflowExecutionScope:           {operation: "HistoryClientDescribeWorkflowExecution", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRecordDecisionTaskStartedScope:           {operation: "HistoryClientRecordDecisionTaskStarted", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
@@ -1180,6 +1321,11 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientPurgeDLQMessagesScope:                    {operation: "HistoryClientPurgeDLQMessagesScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientMergeDLQMessagesScope:                    {operation: "HistoryClientMergeDLQMessagesScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRefreshWorkflowTasksScope:                {operation: "HistoryClientRefreshWorkflowTasksScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientImportWorkflowExecutionScope:             {operation: "HistoryClientImportWorkflowExecutionScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientDeleteWorkflowExecutionScope:             {operation: "HistoryClientDeleteWorkflowExecutionScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientGetReplicationStatusScope:                {operation: "HistoryClientGetReplicationStatusScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientSetShardOwnerScope:                       {operation: "HistoryClientSetShardOwnerScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientDrainHostScope:                           {operation: "HistoryClientDrainHostScope", tags: map[string]string{ServiceRoleTagName: HistoryRoleTagValue}},
 		MatchingClientPollForDecisionTaskScope:                {operation: "MatchingClientPollForDecisionTask", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientPollForActivityTaskScope:                {operation: "MatchingClientPollForActivityTask", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientAddActivityTaskScope:                    {operation: "MatchingClientAddActivityTask", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
@@ -1189,6 +1335,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingClientCancelOutstandingPollScope:              {operation: "MatchingClientCancelOutstandingPoll", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientDescribeTaskListScope:                   {operation: "MatchingClientDescribeTaskList", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientListTaskListPartitionsScope:             {operation: "MatchingClientListTaskListPartitions", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientDrainHostScope:                          {operation: "MatchingClientDrainHost", tags: map[string]string{ServiceRoleTagName: MatchingRoleTagValue}},
 		FrontendClientDeprecateNamespaceScope:                 {operation: "FrontendClientDeprecateNamespace", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeNamespaceScope:                  {operation: "FrontendClientDescribeNamespace", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeTaskListScope:                   {operation: "FrontendClientDescribeTaskList", tags: map[string]string{ServiceRoleTagName: FrontendRoleTagValue}},
@@ -1240,10 +1387,25 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminClientGetWorkflowExecutionRawHistoryV2Scope:      {operation: "AdminClientGetWorkflowExecutionRawHistoryV2", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientDescribeClusterScope:                       {operation: "AdminClientDescribeCluster", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientRefreshWorkflowTasksScope:                  {operation: "AdminClientRefreshWorkflowTasks", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientResetWorkflowExecutionScope:                {operation: "AdminClientResetWorkflowExecution", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientCloseShardScope:                            {operation: "AdminClientCloseShard", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientReadDLQMessagesScope:                       {operation: "AdminClientReadDLQMessages", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientPurgeDLQMessagesScope:                      {operation: "AdminClientPurgeDLQMessages", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		AdminClientMergeDLQMessagesScope:                      {operation: "AdminClientMergeDLQMessages", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientExportWorkflowExecutionScope:               {operation: "AdminClientExportWorkflowExecution", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientImportWorkflowExecutionScope:               {operation: "AdminClientImportWorkflowExecution", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientDeleteNamespaceScope:                       {operation: "AdminClientDeleteNamespace", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientGetReplicationStatusScope:                  {operation: "AdminClientGetReplicationStatus", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientAddOrUpdateRemoteClusterScope:              {operation: "AdminClientAddOrUpdateRemoteCluster", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientRemoveRemoteClusterScope:                   {operation: "AdminClientRemoveRemoteCluster", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientSetShardOwnerScope:                         {operation: "AdminClientSetShardOwner", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientDrainHostScope:                             {operation: "AdminClientDrainHost", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientGetDynamicConfigScope:                      {operation: "AdminClientGetDynamicConfig", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientUpdateDynamicConfigScope:                   {operation: "AdminClientUpdateDynamicConfig", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientDeleteDynamicConfigScope:                   {operation: "AdminClientDeleteDynamicConfig", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientGetDynamicConfigHistoryScope:               {operation: "AdminClientGetDynamicConfigHistory", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientRollbackDynamicConfigScope:                 {operation: "AdminClientRollbackDynamicConfig", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
+		AdminClientGetAuditLogScope:                           {operation: "AdminClientGetAuditLog", tags: map[string]string{ServiceRoleTagName: AdminRoleTagValue}},
 		DCRedirectionDeprecateNamespaceScope:                  {operation: "DCRedirectionDeprecateNamespace", tags: map[string]string{ServiceRoleTagName: DCRedirectionRoleTagValue}},
 		DCRedirectionDescribeNamespaceScope:                   {operation: "DCRedirectionDescribeNamespace", tags: map[string]string{ServiceRoleTagName: DCRedirectionRoleTagValue}},
 		DCRedirectionDescribeTaskListScope:                    {operation: "DCRedirectionDescribeTaskList", tags: map[string]string{ServiceRoleTagName: DCRedirectionRoleTagValue}},
@@ -1283,6 +1445,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		DCRedirectionTerminateWorkflowExecutionScope:          {operation: "DCRedirectionTerminateWorkflowExecution", tags: map[string]string{ServiceRoleTagName: DCRedirectionRoleTagValue}},
 		DCRedirectionUpdateNamespaceScope:                     {operation: "DCRedirectionUpdateNamespace", tags: map[string]string{ServiceRoleTagName: DCRedirectionRoleTagValue}},
 		DCRedirectionListTaskListPartitionsScope:              {operation: "DCRedirectionListTaskListPartitions", tags: map[string]string{ServiceRoleTagName: DCRedirectionRoleTagValue}},
+		DCRedirectionStreamWorkflowExecutionHistoryScope:      {operation: "DCRedirectionStreamWorkflowExecutionHistory", tags: map[string]string{ServiceRoleTagName: DCRedirectionRoleTagValue}},
 
 		MessagingClientPublishScope:      {operation: "MessagingClientPublish"},
 		MessagingClientPublishBatchScope: {operation: "MessagingClientPublishBatch"},
@@ -1341,6 +1504,21 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminGetDLQReplicationMessagesScope:        {operation: "AdminGetDLQReplicationMessages"},
 		AdminReapplyEventsScope:                    {operation: "ReapplyEvents"},
 		AdminRefreshWorkflowTasksScope:             {operation: "RefreshWorkflowTasks"},
+		AdminResetWorkflowExecutionScope:           {operation: "AdminResetWorkflowExecution"},
+		AdminExportWorkflowExecutionScope:          {operation: "AdminExportWorkflowExecution"},
+		AdminImportWorkflowExecutionScope:          {operation: "AdminImportWorkflowExecution"},
+		AdminDeleteNamespaceScope:                  {operation: "AdminDeleteNamespace"},
+		AdminGetReplicationStatusScope:             {operation: "AdminGetReplicationStatus"},
+		AdminAddOrUpdateRemoteClusterScope:         {operation: "AdminAddOrUpdateRemoteCluster"},
+		AdminRemoveRemoteClusterScope:              {operation: "AdminRemoveRemoteCluster"},
+		AdminSetShardOwnerScope:                    {operation: "AdminSetShardOwner"},
+		AdminDrainHostScope:                        {operation: "AdminDrainHost"},
+		AdminGetDynamicConfigScope:                 {operation: "AdminGetDynamicConfig"},
+		AdminUpdateDynamicConfigScope:              {operation: "AdminUpdateDynamicConfig"},
+		AdminDeleteDynamicConfigScope:              {operation: "AdminDeleteDynamicConfig"},
+		AdminGetDynamicConfigHistoryScope:          {operation: "AdminGetDynamicConfigHistory"},
+		AdminRollbackDynamicConfigScope:            {operation: "AdminRollbackDynamicConfig"},
+		AdminGetAuditLogScope:                      {operation: "AdminGetAuditLog"},
 
 		FrontendStartWorkflowExecutionScope:             {operation: "StartWorkflowExecution"},
 		FrontendPollForDecisionTaskScope:                {operation: "PollForDecisionTask"},
@@ -1381,6 +1559,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendDescribeTaskListScope:                   {operation: "DescribeTaskList"},
 		FrontendResetStickyTaskListScope:                {operation: "ResetStickyTaskList"},
 		FrontendGetSearchAttributesScope:                {operation: "GetSearchAttributes"},
+		FrontendStreamWorkflowExecutionHistoryScope:     {operation: "StreamWorkflowExecutionHistory"},
+		FrontendRateLimitScope:                          {operation: "RateLimit"},
 	},
 	// History Scope Names
 	History: {
@@ -1393,6 +1573,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryRespondActivityTaskCanceledScope:                {operation: "RespondActivityTaskCanceled"},
 		HistoryGetMutableStateScope:                            {operation: "GetMutableState"},
 		HistoryPollMutableStateScope:                           {operation: "PollMutableState"},
+		HistoryWatchMutableStateScope:                          {operation: "WatchMutableState"},
 		HistoryResetStickyTaskListScope:                        {operation: "ResetStickyTaskListScope"},
 		HistoryDescribeWorkflowExecutionScope:                  {operation: "DescribeWorkflowExecution"},
 		HistoryRecordDecisionTaskStartedScope:                  {operation: "RecordDecisionTaskStarted"},
@@ -1421,6 +1602,11 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryShardControllerScope:                            {operation: "ShardController"},
 		HistoryReapplyEventsScope:                              {operation: "EventReapplication"},
 		HistoryRefreshWorkflowTasksScope:                       {operation: "RefreshWorkflowTasks"},
+		HistoryImportWorkflowExecutionScope:                    {operation: "ImportWorkflowExecution"},
+		HistoryDeleteWorkflowExecutionScope:                    {operation: "DeleteWorkflowExecution"},
+		HistoryGetReplicationStatusScope:                       {operation: "GetReplicationStatus"},
+		HistorySetShardOwnerScope:                              {operation: "SetShardOwner"},
+		HistoryDrainHostScope:                                  {operation: "DrainHost"},
 		TaskPriorityAssignerScope:                              {operation: "TaskPriorityAssigner"},
 		TransferQueueProcessorScope:                            {operation: "TransferQueueProcessor"},
 		TransferActiveQueueProcessorScope:                      {operation: "TransferActiveQueueProcessor"},
@@ -1434,6 +1620,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TransferActiveTaskRecordWorkflowStartedScope:           {operation: "TransferActiveTaskRecordWorkflowStarted"},
 		TransferActiveTaskResetWorkflowScope:                   {operation: "TransferActiveTaskResetWorkflow"},
 		TransferActiveTaskUpsertWorkflowSearchAttributesScope:  {operation: "TransferActiveTaskUpsertWorkflowSearchAttributes"},
+		TransferActiveTaskWorkflowCompletionCallbackScope:      {operation: "TransferActiveTaskWorkflowCompletionCallback"},
 		TransferStandbyTaskActivityScope:                       {operation: "TransferStandbyTaskActivity"},
 		TransferStandbyTaskDecisionScope:                       {operation: "TransferStandbyTaskDecision"},
 		TransferStandbyTaskCloseExecutionScope:                 {operation: "TransferStandbyTaskCloseExecution"},
@@ -1443,6 +1630,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		TransferStandbyTaskRecordWorkflowStartedScope:          {operation: "TransferStandbyTaskRecordWorkflowStarted"},
 		TransferStandbyTaskResetWorkflowScope:                  {operation: "TransferStandbyTaskResetWorkflow"},
 		TransferStandbyTaskUpsertWorkflowSearchAttributesScope: {operation: "TransferStandbyTaskUpsertWorkflowSearchAttributes"},
+		TransferStandbyTaskWorkflowCompletionCallbackScope:     {operation: "TransferStandbyTaskWorkflowCompletionCallback"},
 		TimerQueueProcessorScope:                               {operation: "TimerQueueProcessor"},
 		TimerActiveQueueProcessorScope:                         {operation: "TimerActiveQueueProcessor"},
 		TimerStandbyQueueProcessorScope:                        {operation: "TimerStandbyQueueProcessor"},
@@ -1497,6 +1685,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingCancelOutstandingPollScope:     {operation: "CancelOutstandingPoll"},
 		MatchingDescribeTaskListScope:          {operation: "DescribeTaskList"},
 		MatchingListTaskListPartitionsScope:    {operation: "ListTaskListPartitions"},
+		MatchingDrainHostScope:                 {operation: "DrainHost"},
 	},
 	// Worker Scope Names
 	Worker: {
@@ -1520,6 +1709,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryScavengerScope:                  {operation: "historyscavenger"},
 		BatcherScope:                           {operation: "batcher"},
 		ParentClosePolicyProcessorScope:        {operation: "ParentClosePolicyProcessor"},
+		NamespaceDeletionScope:                 {operation: "namespacedeletion"},
 	},
 }
 
@@ -1559,6 +1749,10 @@ const (
 	PersistenceErrNamespaceAlreadyExistsCounter
 	PersistenceErrBadRequestCounter
 	PersistenceSampledCounter
+	PersistenceHistoryBlobSize
+	PersistenceHistoryBlobEncodedSize
+	PersistenceHistoryBlobBytes
+	PersistenceHistoryBlobEncodedBytes
 
 	ClientRequests
 	ClientFailures
@@ -1662,6 +1856,7 @@ const (
 	TaskStandbyRetryCounter
 	TaskNotActiveCounter
 	TaskLimitExceededCounter
+	TaskMovedToDLQCounter
 	TaskBatchCompleteCounter
 	TaskProcessingLatency
 	TaskQueueLatency
@@ -1707,6 +1902,12 @@ const (
 	ShardItemCreatedCounter
 	ShardItemRemovedCounter
 	ShardItemAcquisitionLatency
+	ShardSplitCompletedCounter
+	ShardSplitFailedCounter
+	ShardSplitLatency
+	ShardSplitExecutionsMovedCounter
+	ShardRebalanceMovedCounter
+	ShardRebalanceFailedCounter
 	ShardInfoReplicationPendingTasksTimer
 	ShardInfoTransferActivePendingTasksTimer
 	ShardInfoTransferStandbyPendingTasksTimer
@@ -1796,6 +1997,10 @@ const (
 	ReplicationDLQFailed
 	ReplicationDLQMaxLevelGauge
 	ReplicationDLQAckLevelGauge
+	ReplicationDLQSizeGauge
+	ReplicationAckLevelGauge
+	ReplicationAckLevelLagGauge
+	ReplicationEstimatedLagGauge
 	GetReplicationMessagesForShardLatency
 	GetDLQReplicationMessagesLatency
 	EventReapplySkippedCount
@@ -1820,6 +2025,9 @@ const (
 	ReplicationTaskCleanupFailure
 	MutableStateChecksumMismatch
 	MutableStateChecksumInvalidated
+	CompletionCallbackDeliverySuccessCount
+	CompletionCallbackDeliveryFailureCount
+	CompletionCallbackDeadLetterCount
 
 	NumHistoryMetrics
 )
@@ -1914,17 +2122,74 @@ const (
 	ParentClosePolicyProcessorSuccess
 	ParentClosePolicyProcessorFailures
 	NamespaceReplicationEnqueueDLQCount
+	NamespaceDeletionExecutionsDeletedCount
+	NamespaceDeletionFailures
 
 	NumWorkerMetrics
 )
 
+// Histogram buckets of the latency metrics, they are used when timers are emitted as histograms.
+// The bounds are tuned to the latency SLOs of the corresponding operations.
+var (
+	// ServiceLatencyBuckets are the buckets of the service and client request latencies
+	ServiceLatencyBuckets = tally.DurationBuckets{
+		time.Millisecond,
+		2 * time.Millisecond,
+		5 * time.Millisecond,
+		10 * time.Millisecond,
+		25 * time.Millisecond,
+		50 * time.Millisecond,
+		100 * time.Millisecond,
+		250 * time.Millisecond,
+		500 * time.Millisecond,
+		time.Second,
+		2500 * time.Millisecond,
+		5 * time.Second,
+		10 * time.Second,
+		// long polls are held for up to a minute
+		30 * time.Second,
+		time.Minute,
+	}
+	// PersistenceLatencyBuckets are the buckets of the persistence and visibility store latencies
+	PersistenceLatencyBuckets = tally.DurationBuckets{
+		500 * time.Microsecond,
+		time.Millisecond,
+		2 * time.Millisecond,
+		5 * time.Millisecond,
+		10 * time.Millisecond,
+		25 * time.Millisecond,
+		50 * time.Millisecond,
+		100 * time.Millisecond,
+		250 * time.Millisecond,
+		500 * time.Millisecond,
+		time.Second,
+		5 * time.Second,
+	}
+	// TaskLatencyBuckets are the buckets of the task processing and matching latencies
+	TaskLatencyBuckets = tally.DurationBuckets{
+		5 * time.Millisecond,
+		10 * time.Millisecond,
+		25 * time.Millisecond,
+		50 * time.Millisecond,
+		100 * time.Millisecond,
+		250 * time.Millisecond,
+		500 * time.Millisecond,
+		time.Second,
+		5 * time.Second,
+		10 * time.Second,
+		time.Minute,
+		5 * time.Minute,
+		time.Hour,
+	}
+)
+
 // MetricDefs record the metrics for all services
 var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 	Common: {
 		ServiceRequests:                                     {metricName: "service_requests", metricType: Counter},
 		ServiceFailures:                                     {metricName: "service_errors", metricType: Counter},
 		ServiceCriticalFailures:                             {metricName: "service_errors_critical", metricType: Counter},
-		ServiceLatency:                                      {metricName: "service_latency", metricType: Timer},
+		ServiceLatency:                                      {metricName: "service_latency", metricType: Timer, buckets: ServiceLatencyBuckets},
 		ServiceErrInvalidArgumentCounter:                    {metricName: "service_errors_invalid_argument", metricType: Counter},
 		ServiceErrNamespaceNotActiveCounter:                 {metricName: "service_errors_namespace_not_active", metricType: Counter},
 		ServiceErrResourceExhaustedCounter:                  {metricName: "service_errors_resource_exhausted", metricType: Counter},
@@ -1943,7 +2208,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ServiceErrAuthorizeFailedCounter:                    {metricName: "service_errors_authorize_failed", metricType: Counter},
 		PersistenceRequests:                                 {metricName: "persistence_requests", metricType: Counter},
 		PersistenceFailures:                                 {metricName: "persistence_errors", metricType: Counter},
-		PersistenceLatency:                                  {metricName: "persistence_latency", metricType: Timer},
+		PersistenceLatency:                                  {metricName: "persistence_latency", metricType: Timer, buckets: PersistenceLatencyBuckets},
 		PersistenceErrShardExistsCounter:                    {metricName: "persistence_errors_shard_exists", metricType: Counter},
 		PersistenceErrShardOwnershipLostCounter:             {metricName: "persistence_errors_shard_ownership_lost", metricType: Counter},
 		PersistenceErrConditionFailedCounter:                {metricName: "persistence_errors_condition_failed", metricType: Counter},
@@ -1955,9 +2220,13 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		PersistenceErrNamespaceAlreadyExistsCounter:         {metricName: "persistence_errors_namespace_already_exists", metricType: Counter},
 		PersistenceErrBadRequestCounter:                     {metricName: "persistence_errors_bad_request", metricType: Counter},
 		PersistenceSampledCounter:                           {metricName: "persistence_sampled", metricType: Counter},
+		PersistenceHistoryBlobSize:                          {metricName: "persistence_history_blob_size", metricType: Timer},
+		PersistenceHistoryBlobEncodedSize:                   {metricName: "persistence_history_blob_encoded_size", metricType: Timer},
+		PersistenceHistoryBlobBytes:                         {metricName: "persistence_history_blob_bytes", metricType: Counter},
+		PersistenceHistoryBlobEncodedBytes:                  {metricName: "persistence_history_blob_encoded_bytes", metricType: Counter},
 		ClientRequests:                                      {metricName: "client_requests", metricType: Counter},
 		ClientFailures:                                      {metricName: "client_errors", metricType: Counter},
-		ClientLatency:                                       {metricName: "client_latency", metricType: Timer},
+		ClientLatency:                                       {metricName: "client_latency", metricType: Timer, buckets: ServiceLatencyBuckets},
 		ClientRedirectionRequests:                           {metricName: "client_redirection_requests", metricType: Counter},
 		ClientRedirectionFailures:                           {metricName: "client_redirection_errors", metricType: Counter},
 		ClientRedirectionLatency:                            {metricName: "client_redirection_latency", metricType: Timer},
@@ -1970,7 +2239,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ArchivalConfigFailures:                              {metricName: "archivalconfig_failures", metricType: Counter},
 		ElasticsearchRequests:                               {metricName: "elasticsearch_requests", metricType: Counter},
 		ElasticsearchFailures:                               {metricName: "elasticsearch_errors", metricType: Counter},
-		ElasticsearchLatency:                                {metricName: "elasticsearch_latency", metricType: Timer},
+		ElasticsearchLatency:                                {metricName: "elasticsearch_latency", metricType: Timer, buckets: PersistenceLatencyBuckets},
 		ElasticsearchErrBadRequestCounter:                   {metricName: "elasticsearch_errors_bad_request", metricType: Counter},
 		ElasticsearchErrBusyCounter:                         {metricName: "elasticsearch_errors_busy", metricType: Counter},
 		SequentialTaskSubmitRequest:                         {metricName: "sequentialtask_submit_request", metricType: Counter},
@@ -2018,7 +2287,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 			metricName: "service_errors_per_tl", metricRollupName: "service_errors", metricType: Counter,
 		},
 		ServiceLatencyPerTaskList: {
-			metricName: "service_latency_per_tl", metricRollupName: "service_latency", metricType: Timer,
+			metricName: "service_latency_per_tl", metricRollupName: "service_latency", metricType: Timer, buckets: ServiceLatencyBuckets,
 		},
 		ServiceErrInvalidArgumentPerTaskListCounter: {
 			metricName: "service_errors_invalid_argument_per_tl", metricRollupName: "service_errors_invalid_argument", metricType: Counter,
@@ -2071,15 +2340,16 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 	},
 	History: {
 		TaskRequests:                                      {metricName: "task_requests", metricType: Counter},
-		TaskLatency:                                       {metricName: "task_latency", metricType: Timer},
+		TaskLatency:                                       {metricName: "task_latency", metricType: Timer, buckets: TaskLatencyBuckets},
 		TaskAttemptTimer:                                  {metricName: "task_attempt", metricType: Timer},
 		TaskFailures:                                      {metricName: "task_errors", metricType: Counter},
 		TaskDiscarded:                                     {metricName: "task_errors_discarded", metricType: Counter},
 		TaskStandbyRetryCounter:                           {metricName: "task_errors_standby_retry_counter", metricType: Counter},
 		TaskNotActiveCounter:                              {metricName: "task_errors_not_active_counter", metricType: Counter},
 		TaskLimitExceededCounter:                          {metricName: "task_errors_limit_exceeded_counter", metricType: Counter},
-		TaskProcessingLatency:                             {metricName: "task_latency_processing", metricType: Timer},
-		TaskQueueLatency:                                  {metricName: "task_latency_queue", metricType: Timer},
+		TaskMovedToDLQCounter:                             {metricName: "task_errors_moved_to_dlq_counter", metricType: Counter},
+		TaskProcessingLatency:                             {metricName: "task_latency_processing", metricType: Timer, buckets: TaskLatencyBuckets},
+		TaskQueueLatency:                                  {metricName: "task_latency_queue", metricType: Timer, buckets: TaskLatencyBuckets},
 		TaskBatchCompleteCounter:                          {metricName: "task_batch_complete_counter", metricType: Counter},
 		TaskRedispatchQueuePendingTasksTimer:              {metricName: "task_redispatch_queue_pending_tasks", metricType: Timer},
 		TransferTaskThrottledCounter:                      {metricName: "transfer_task_throttled_counter", metricType: Counter},
@@ -2121,6 +2391,12 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ShardItemCreatedCounter:                           {metricName: "sharditem_created_count", metricType: Counter},
 		ShardItemRemovedCounter:                           {metricName: "sharditem_removed_count", metricType: Counter},
 		ShardItemAcquisitionLatency:                       {metricName: "sharditem_acquisition_latency", metricType: Timer},
+		ShardSplitCompletedCounter:                        {metricName: "shard_split_completed_count", metricType: Counter},
+		ShardSplitFailedCounter:                           {metricName: "shard_split_failed_count", metricType: Counter},
+		ShardSplitLatency:                                 {metricName: "shard_split_latency", metricType: Timer},
+		ShardSplitExecutionsMovedCounter:                  {metricName: "shard_split_executions_moved_count", metricType: Counter},
+		ShardRebalanceMovedCounter:                        {metricName: "shard_rebalance_moved_count", metricType: Counter},
+		ShardRebalanceFailedCounter:                       {metricName: "shard_rebalance_failed_count", metricType: Counter},
 		ShardInfoReplicationPendingTasksTimer:             {metricName: "shardinfo_replication_pending_task", metricType: Timer},
 		ShardInfoTransferActivePendingTasksTimer:          {metricName: "shardinfo_transfer_active_pending_task", metricType: Timer},
 		ShardInfoTransferStandbyPendingTasksTimer:         {metricName: "shardinfo_transfer_standby_pending_task", metricType: Timer},
@@ -2210,6 +2486,10 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ReplicationDLQFailed:                              {metricName: "replication_dlq_enqueue_failed", metricType: Counter},
 		ReplicationDLQMaxLevelGauge:                       {metricName: "replication_dlq_max_level", metricType: Gauge},
 		ReplicationDLQAckLevelGauge:                       {metricName: "replication_dlq_ack_level", metricType: Gauge},
+		ReplicationDLQSizeGauge:                           {metricName: "replication_dlq_size", metricType: Gauge},
+		ReplicationAckLevelGauge:                          {metricName: "replication_ack_level", metricType: Gauge},
+		ReplicationAckLevelLagGauge:                       {metricName: "replication_ack_level_lag", metricType: Gauge},
+		ReplicationEstimatedLagGauge:                      {metricName: "replication_estimated_lag_seconds", metricType: Gauge},
 		GetReplicationMessagesForShardLatency:             {metricName: "get_replication_messages_for_shard", metricType: Timer},
 		GetDLQReplicationMessagesLatency:                  {metricName: "get_dlq_replication_messages", metricType: Timer},
 		EventReapplySkippedCount:                          {metricName: "event_reapply_skipped_count", metricType: Counter},
@@ -2234,6 +2514,9 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ReplicationTaskCleanupFailure:                     {metricName: "replication_task_cleanup_failed", metricType: Counter},
 		MutableStateChecksumMismatch:                      {metricName: "mutable_state_checksum_mismatch", metricType: Counter},
 		MutableStateChecksumInvalidated:                   {metricName: "mutable_state_checksum_invalidated", metricType: Counter},
+		CompletionCallbackDeliverySuccessCount:            {metricName: "completion_callback_delivery_success", metricType: Counter},
+		CompletionCallbackDeliveryFailureCount:            {metricName: "completion_callback_delivery_failure", metricType: Counter},
+		CompletionCallbackDeadLetterCount:                 {metricName: "completion_callback_dead_letter", metricType: Counter},
 	},
 	Matching: {
 		PollSuccessPerTaskListCounter:            {metricName: "poll_success_per_tl", metricRollupName: "poll_success"},
@@ -2253,8 +2536,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ForwardQueryErrorsPerTaskList:            {metricName: "forward_query_errors_per_tl", metricRollupName: "forward_query_errors"},
 		ForwardPollCallsPerTaskList:              {metricName: "forward_poll_calls_per_tl", metricRollupName: "forward_poll_calls"},
 		ForwardPollErrorsPerTaskList:             {metricName: "forward_poll_errors_per_tl", metricRollupName: "forward_poll_errors"},
-		SyncMatchLatencyPerTaskList:              {metricName: "syncmatch_latency_per_tl", metricRollupName: "syncmatch_latency", metricType: Timer},
-		AsyncMatchLatencyPerTaskList:             {metricName: "asyncmatch_latency_per_tl", metricRollupName: "asyncmatch_latency", metricType: Timer},
+		SyncMatchLatencyPerTaskList:              {metricName: "syncmatch_latency_per_tl", metricRollupName: "syncmatch_latency", metricType: Timer, buckets: TaskLatencyBuckets},
+		AsyncMatchLatencyPerTaskList:             {metricName: "asyncmatch_latency_per_tl", metricRollupName: "asyncmatch_latency", metricType: Timer, buckets: TaskLatencyBuckets},
 		ForwardTaskLatencyPerTaskList:            {metricName: "forward_task_latency_per_tl", metricRollupName: "forward_task_latency"},
 		ForwardQueryLatencyPerTaskList:           {metricName: "forward_query_latency_per_tl", metricRollupName: "forward_query_latency"},
 		ForwardPollLatencyPerTaskList:            {metricName: "forward_poll_latency_per_tl", metricRollupName: "forward_poll_latency"},
@@ -2320,6 +2603,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ParentClosePolicyProcessorSuccess:             {metricName: "parent_close_policy_processor_requests", metricType: Counter},
 		ParentClosePolicyProcessorFailures:            {metricName: "parent_close_policy_processor_errors", metricType: Counter},
 		NamespaceReplicationEnqueueDLQCount:           {metricName: "namespace_replication_dlq_enqueue_requests", metricType: Counter},
+		NamespaceDeletionExecutionsDeletedCount:       {metricName: "namespace_deletion_executions_deleted", metricType: Counter},
+		NamespaceDeletionFailures:                     {metricName: "namespace_deletion_errors", metricType: Counter},
 	},
 }
 
@@ -0,0 +1,192 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uber-go/tally"
+)
+
+type (
+	// Exemplar links an observation of a histogram bucket to the trace which produced it
+	Exemplar struct {
+		Metric     string            `json:"metric"`
+		Tags       map[string]string `json:"tags"`
+		UpperBound string            `json:"le"`
+		Value      float64           `json:"value"`
+		TraceID    string            `json:"traceId"`
+		Timestamp  time.Time         `json:"timestamp"`
+	}
+
+	// ExemplarReporter receives exemplars of histogram observations
+	ExemplarReporter interface {
+		ReportExemplar(exemplar *Exemplar)
+	}
+
+	// ExemplarStore keeps the latest exemplar of every histogram bucket, it is served
+	// over http as the prometheus client in use is not able to expose exemplars
+	ExemplarStore struct {
+		sync.RWMutex
+		maxSize   int
+		exemplars map[string]*Exemplar
+	}
+
+	// exemplarTimer is a tally.Timer which reports the observations as exemplars
+	exemplarTimer struct {
+		reporter ExemplarReporter
+		metric   string
+		tags     map[string]string
+		buckets  tally.Buckets
+		traceID  string
+	}
+)
+
+const (
+	// ExemplarsHandlerPath is the http path exemplars are served at
+	ExemplarsHandlerPath = "/debug/exemplars"
+
+	defaultExemplarStoreSize = 10000
+)
+
+var _ ExemplarReporter = (*ExemplarStore)(nil)
+var _ tally.Timer = (*exemplarTimer)(nil)
+
+// DefaultExemplarStore is the exemplar store shared by all services of the process
+var DefaultExemplarStore = NewExemplarStore(defaultExemplarStoreSize)
+
+// NewExemplarStore creates a store which keeps at most maxSize exemplars,
+// exemplars of new buckets are dropped once the store is full
+func NewExemplarStore(maxSize int) *ExemplarStore {
+	return &ExemplarStore{
+		maxSize:   maxSize,
+		exemplars: make(map[string]*Exemplar),
+	}
+}
+
+// ReportExemplar replaces the exemplar of the bucket the observation falls into
+func (s *ExemplarStore) ReportExemplar(exemplar *Exemplar) {
+	key := exemplarKey(exemplar)
+	s.Lock()
+	defer s.Unlock()
+	if _, ok := s.exemplars[key]; !ok && len(s.exemplars) >= s.maxSize {
+		return
+	}
+	s.exemplars[key] = exemplar
+}
+
+// Exemplars returns the exemplars in the store ordered by metric, tags and bucket
+func (s *ExemplarStore) Exemplars() []*Exemplar {
+	s.RLock()
+	keys := make([]string, 0, len(s.exemplars))
+	for key := range s.exemplars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]*Exemplar, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, s.exemplars[key])
+	}
+	s.RUnlock()
+	return result
+}
+
+// ServeHTTP writes the exemplars in the store as json
+func (s *ExemplarStore) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.Exemplars())
+}
+
+func exemplarKey(exemplar *Exemplar) string {
+	tagKeys := make([]string, 0, len(exemplar.Tags))
+	for key := range exemplar.Tags {
+		tagKeys = append(tagKeys, key)
+	}
+	sort.Strings(tagKeys)
+
+	var builder strings.Builder
+	builder.WriteString(exemplar.Metric)
+	for _, key := range tagKeys {
+		builder.WriteString(",")
+		builder.WriteString(key)
+		builder.WriteString("=")
+		builder.WriteString(exemplar.Tags[key])
+	}
+	builder.WriteString(",le=")
+	builder.WriteString(exemplar.UpperBound)
+	return builder.String()
+}
+
+func newExemplarTimer(
+	reporter ExemplarReporter,
+	metric string,
+	tags map[string]string,
+	buckets tally.Buckets,
+	traceID string,
+) *exemplarTimer {
+	return &exemplarTimer{
+		reporter: reporter,
+		metric:   metric,
+		tags:     tags,
+		buckets:  buckets,
+		traceID:  traceID,
+	}
+}
+
+// Record reports the duration as an exemplar of the bucket it falls into
+func (t *exemplarTimer) Record(d time.Duration) {
+	// the bucket bounds are formatted the same way as the prometheus le label
+	le := "+Inf"
+	for _, bound := range t.buckets.AsDurations() {
+		if d <= bound {
+			le = strconv.FormatFloat(bound.Seconds(), 'f', -1, 64)
+			break
+		}
+	}
+	t.reporter.ReportExemplar(&Exemplar{
+		Metric:     t.metric,
+		Tags:       t.tags,
+		UpperBound: le,
+		Value:      d.Seconds(),
+		TraceID:    t.traceID,
+		Timestamp:  time.Now(),
+	})
+}
+
+// Start returns a stopwatch which reports an exemplar when stopped
+func (t *exemplarTimer) Start() tally.Stopwatch {
+	return tally.NewStopwatch(time.Now(), t)
+}
+
+// RecordStopwatch reports the time elapsed since the stopwatch start
+func (t *exemplarTimer) RecordStopwatch(stopwatchStart time.Time) {
+	t.Record(time.Since(stopwatchStart))
+}
@@ -25,6 +25,7 @@
 package metrics
 
 import (
+	"context"
 	"time"
 
 	"github.com/uber-go/tally"
@@ -59,6 +60,9 @@ type (
 		// StartTimer starts a timer for the given metric name.
 		// Time will be recorded when stopwatch is stopped.
 		StartTimer(timer int) Stopwatch
+		// StartTimerWithContext starts a timer for the given metric name. Time will be recorded
+		// when stopwatch is stopped, along with the trace of the span in ctx as an exemplar.
+		StartTimerWithContext(ctx context.Context, timer int) Stopwatch
 		// RecordTimer starts a timer for the given metric name
 		RecordTimer(timer int, d time.Duration)
 		// RecordHistogramDuration records a histogram duration value for the given
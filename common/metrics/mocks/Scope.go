@@ -27,6 +27,8 @@
 package mocks
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 	metrics "github.com/temporalio/temporal/common/metrics"
 
@@ -77,6 +79,20 @@ func (_m *Scope) StartTimer(timer int) metrics.Stopwatch {
 	return r0
 }
 
+// StartTimerWithContext provides a mock function with given fields: ctx, timer
+func (_m *Scope) StartTimerWithContext(ctx context.Context, timer int) metrics.Stopwatch {
+	ret := _m.Called(ctx, timer)
+
+	var r0 metrics.Stopwatch
+	if rf, ok := ret.Get(0).(func(context.Context, int) metrics.Stopwatch); ok {
+		r0 = rf(ctx, timer)
+	} else {
+		r0 = ret.Get(0).(metrics.Stopwatch)
+	}
+
+	return r0
+}
+
 // Tagged provides a mock function with given fields: tags
 func (_m *Scope) Tagged(tags ...metrics.Tag) metrics.Scope {
 	_va := make([]interface{}, len(tags))
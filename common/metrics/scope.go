@@ -25,29 +25,48 @@
 package metrics
 
 import (
+	"context"
 	"time"
 
 	"github.com/uber-go/tally"
+
+	"github.com/temporalio/temporal/common/tracing"
 )
 
-type metricsScope struct {
-	scope             tally.Scope
-	rootScope         tally.Scope
-	defs              map[int]metricDefinition
-	isNamespaceTagged bool
-}
+type (
+	metricsScope struct {
+		scope             tally.Scope
+		rootScope         tally.Scope
+		defs              map[int]metricDefinition
+		isNamespaceTagged bool
+		operation         string
+		tags              map[string]string
+		options           *ClientOptions
+	}
+
+	// histogramTimer records the durations of a timer in a histogram
+	histogramTimer struct {
+		tally.Histogram
+	}
+)
 
 func newMetricsScope(
 	rootScope tally.Scope,
 	scope tally.Scope,
 	defs map[int]metricDefinition,
 	isNamespace bool,
+	operation string,
+	tags map[string]string,
+	options *ClientOptions,
 ) Scope {
 	return &metricsScope{
 		scope:             scope,
 		rootScope:         rootScope,
 		defs:              defs,
 		isNamespaceTagged: isNamespace,
+		operation:         operation,
+		tags:              tags,
+		options:           options,
 	}
 }
 
@@ -57,6 +76,8 @@ func NoopScope(serviceIdx ServiceIdx) Scope {
 		scope:     tally.NoopScope,
 		rootScope: tally.NoopScope,
 		defs:      getMetricDefs(serviceIdx),
+		tags:      map[string]string{},
+		options:   newClientOptions(ClientOptions{}),
 	}
 }
 
@@ -81,27 +102,20 @@ func (m *metricsScope) UpdateGauge(id int, value float64) {
 }
 
 func (m *metricsScope) StartTimer(id int) Stopwatch {
-	def := m.defs[id]
-	timer := m.scope.Timer(def.metricName.String())
-	switch {
-	case !def.metricRollupName.Empty():
-		return NewStopwatch(timer, m.rootScope.Timer(def.metricRollupName.String()))
-	case m.isNamespaceTagged:
-		timerAll := m.scope.Tagged(map[string]string{namespace: namespaceAllValue}).Timer(def.metricName.String())
-		return NewStopwatch(timer, timerAll)
-	default:
-		return NewStopwatch(timer)
+	return NewStopwatch(m.timers(id)...)
+}
+
+func (m *metricsScope) StartTimerWithContext(ctx context.Context, id int) Stopwatch {
+	timers := m.timers(id)
+	if exemplarTimer := m.exemplarTimer(ctx, id); exemplarTimer != nil {
+		timers = append(timers, exemplarTimer)
 	}
+	return NewStopwatch(timers...)
 }
 
 func (m *metricsScope) RecordTimer(id int, d time.Duration) {
-	def := m.defs[id]
-	m.scope.Timer(def.metricName.String()).Record(d)
-	switch {
-	case !def.metricRollupName.Empty():
-		m.rootScope.Timer(def.metricRollupName.String()).Record(d)
-	case m.isNamespaceTagged:
-		m.scope.Tagged(map[string]string{namespace: namespaceAllValue}).Timer(def.metricName.String()).Record(d)
+	for _, timer := range m.timers(id) {
+		timer.Record(d)
 	}
 }
 
@@ -125,12 +139,57 @@ func (m *metricsScope) Tagged(tags ...Tag) Scope {
 	namespaceTagged := false
 	tagMap := make(map[string]string, len(tags))
 	for _, tag := range tags {
-		if isNamespaceTagged(tag) {
+		switch {
+		case !m.options.TagFilter.Allow(m.operation, tag.Key()):
+			tagMap[tag.Key()] = excludedValue
+		case isNamespaceTagged(tag):
 			namespaceTagged = true
+			tagMap[tag.Key()] = tag.Value()
+		default:
+			tagMap[tag.Key()] = tag.Value()
 		}
-		tagMap[tag.Key()] = tag.Value()
 	}
-	return newMetricsScope(m.rootScope, m.scope.Tagged(tagMap), m.defs, namespaceTagged)
+	allTags := make(map[string]string, len(m.tags)+len(tagMap))
+	mergeMapToRight(m.tags, allTags)
+	mergeMapToRight(tagMap, allTags)
+	return newMetricsScope(m.rootScope, m.scope.Tagged(tagMap), m.defs, namespaceTagged, m.operation, allTags, m.options)
+}
+
+// timers returns the timers a duration of the metric is recorded to
+func (m *metricsScope) timers(id int) []tally.Timer {
+	def := m.defs[id]
+	timer := m.timer(m.scope, def.metricName.String(), def.buckets)
+	switch {
+	case !def.metricRollupName.Empty():
+		return []tally.Timer{timer, m.timer(m.rootScope, def.metricRollupName.String(), def.buckets)}
+	case m.isNamespaceTagged:
+		scopeAll := m.scope.Tagged(map[string]string{namespace: namespaceAllValue})
+		return []tally.Timer{timer, m.timer(scopeAll, def.metricName.String(), def.buckets)}
+	default:
+		return []tally.Timer{timer}
+	}
+}
+
+// timer returns a histogram backed timer if the metric defines buckets and timers are emitted as histograms
+func (m *metricsScope) timer(scope tally.Scope, name string, buckets tally.Buckets) tally.Timer {
+	if m.options.HistogramTimers && buckets != nil {
+		return histogramTimer{scope.Histogram(name, buckets)}
+	}
+	return scope.Timer(name)
+}
+
+// exemplarTimer returns a timer reporting the trace of the span in ctx as an exemplar,
+// it returns nil if the metric does not define buckets or the span is not sampled
+func (m *metricsScope) exemplarTimer(ctx context.Context, id int) tally.Timer {
+	def := m.defs[id]
+	if m.options.ExemplarReporter == nil || def.buckets == nil {
+		return nil
+	}
+	span := tracing.SpanFromContext(ctx)
+	if span == nil || !span.SpanContext().Sampled {
+		return nil
+	}
+	return newExemplarTimer(m.options.ExemplarReporter, def.metricName.String(), m.tags, def.buckets, span.SpanContext().TraceID)
 }
 
 func (m *metricsScope) getBuckets(id int) tally.Buckets {
@@ -143,3 +202,8 @@ func (m *metricsScope) getBuckets(id int) tally.Buckets {
 func isNamespaceTagged(tag Tag) bool {
 	return tag.Key() == namespace && tag.Value() != namespaceAllValue
 }
+
+// Record records the duration in the histogram
+func (h histogramTimer) Record(d time.Duration) {
+	h.RecordDuration(d)
+}
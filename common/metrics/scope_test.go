@@ -0,0 +1,124 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+
+	"github.com/temporalio/temporal/common/tracing"
+)
+
+func TestTagged_TagAllowlist(t *testing.T) {
+	testScope := tally.NewTestScope("", nil)
+	client := NewClientWithOptions(testScope, Frontend, ClientOptions{
+		TagFilter: NewTagAllowlist(func() map[string]interface{} {
+			return map[string]interface{}{
+				namespace:    []interface{}{"StartWorkflowExecution"},
+				workflowType: []interface{}{},
+			}
+		}),
+	})
+
+	client.Scope(FrontendStartWorkflowExecutionScope, NamespaceTag("test-namespace"), WorkflowTypeTag("test-type")).IncCounter(ServiceRequests)
+	client.Scope(FrontendSignalWorkflowExecutionScope, NamespaceTag("test-namespace"), TaskListTag("test-tasklist")).IncCounter(ServiceRequests)
+
+	tags := make(map[string]map[string]string)
+	for _, counter := range testScope.Snapshot().Counters() {
+		tags[counter.Tags()[OperationTagName]] = counter.Tags()
+	}
+	require.Len(t, tags, 2)
+	assert.Equal(t, "test-namespace", tags["StartWorkflowExecution"][namespace])
+	assert.Equal(t, excludedValue, tags["StartWorkflowExecution"][workflowType])
+	assert.Equal(t, excludedValue, tags["SignalWorkflowExecution"][namespace])
+	assert.Equal(t, "test_tasklist", tags["SignalWorkflowExecution"][taskList])
+}
+
+func TestStartTimer_HistogramTimers(t *testing.T) {
+	testScope := tally.NewTestScope("", nil)
+	client := NewClientWithOptions(testScope, Frontend, ClientOptions{HistogramTimers: true})
+	scope := client.Scope(FrontendStartWorkflowExecutionScope, NamespaceTag("test-namespace"))
+
+	scope.RecordTimer(ServiceLatency, 3*time.Millisecond)
+	scope.StartTimer(ServiceLatency).Stop()
+	scope.RecordTimer(ServiceFailures, time.Millisecond)
+
+	// metrics are dual emitted with the namespace all tag
+	snapshot := testScope.Snapshot()
+	assert.Len(t, snapshot.Timers(), 2)
+	histograms := snapshot.Histograms()
+	require.Len(t, histograms, 2)
+	for _, histogram := range histograms {
+		assert.Equal(t, "service_latency", histogram.Name())
+		var count int64
+		for bound, value := range histogram.Durations() {
+			count += value
+			if bound == 5*time.Millisecond {
+				assert.True(t, value >= 1)
+			}
+		}
+		assert.Equal(t, int64(2), count)
+	}
+}
+
+func TestStartTimerWithContext_Exemplars(t *testing.T) {
+	exemplars := NewExemplarStore(10)
+	client := NewClientWithOptions(tally.NoopScope, Frontend, ClientOptions{ExemplarReporter: exemplars})
+	scope := client.Scope(FrontendStartWorkflowExecutionScope, NamespaceTag("test-namespace"))
+
+	ctx, span := tracing.NewTracer(tracing.NewNoopExporter(), 1).Start(context.Background(), "test")
+	scope.StartTimerWithContext(ctx, ServiceLatency).Stop()
+	scope.StartTimerWithContext(ctx, ServiceRequests).Stop()
+	unsampledCtx, _ := tracing.NewTracer(tracing.NewNoopExporter(), 0).Start(context.Background(), "test")
+	scope.StartTimerWithContext(unsampledCtx, ServiceLatency).Stop()
+	scope.StartTimerWithContext(context.Background(), ServiceLatency).Stop()
+
+	result := exemplars.Exemplars()
+	require.Len(t, result, 1)
+	assert.Equal(t, "service_latency", result[0].Metric)
+	assert.Equal(t, span.SpanContext().TraceID, result[0].TraceID)
+	assert.Equal(t, "0.001", result[0].UpperBound)
+	assert.Equal(t, map[string]string{
+		OperationTagName: "StartWorkflowExecution",
+		namespace:        "test-namespace",
+	}, result[0].Tags)
+}
+
+func TestExemplarStore_MaxSize(t *testing.T) {
+	exemplars := NewExemplarStore(1)
+	exemplars.ReportExemplar(&Exemplar{Metric: "first", UpperBound: "1", TraceID: "a"})
+	exemplars.ReportExemplar(&Exemplar{Metric: "first", UpperBound: "1", TraceID: "b"})
+	exemplars.ReportExemplar(&Exemplar{Metric: "second", UpperBound: "1", TraceID: "c"})
+
+	result := exemplars.Exemplars()
+	require.Len(t, result, 1)
+	assert.Equal(t, "first", result[0].Metric)
+	assert.Equal(t, "b", result[0].TraceID)
+}
@@ -38,9 +38,12 @@ const (
 	workflowType  = "workflowType"
 	activityType  = "activityType"
 	decisionType  = "decisionType"
+	encoding      = "encoding"
+	apiClass      = "api_class"
 
 	namespaceAllValue = "all"
 	unknownValue      = "_unknown_"
+	excludedValue     = "_tag_excluded_"
 )
 
 // Tag is an interface to define metrics tags
@@ -81,6 +84,14 @@ type (
 	decisionTypeTag struct {
 		value string
 	}
+
+	encodingTag struct {
+		value string
+	}
+
+	apiClassTag struct {
+		value string
+	}
 )
 
 // NamespaceTag returns a new namespace tag. For timers, this also ensures that we
@@ -237,3 +248,39 @@ func (d decisionTypeTag) Key() string {
 func (d decisionTypeTag) Value() string {
 	return d.value
 }
+
+// EncodingTag returns a new encoding tag.
+func EncodingTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return encodingTag{value}
+}
+
+// Key returns the key of the encoding tag
+func (d encodingTag) Key() string {
+	return encoding
+}
+
+// Value returns the value of the encoding tag
+func (d encodingTag) Value() string {
+	return d.value
+}
+
+// APIClassTag returns a new API class tag.
+func APIClassTag(value string) Tag {
+	if len(value) == 0 {
+		value = unknownValue
+	}
+	return apiClassTag{value}
+}
+
+// Key returns the key of the API class tag
+func (d apiClassTag) Key() string {
+	return apiClass
+}
+
+// Value returns the value of the API class tag
+func (d apiClassTag) Value() string {
+	return d.value
+}
@@ -27,6 +27,7 @@ package mocks
 import (
 	"github.com/stretchr/testify/mock"
 
+	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/service/config"
 )
 
@@ -176,3 +177,29 @@ func (_m *ClusterMetadata) GetReplicationConsumerConfig() *config.ReplicationCon
 
 	return r0
 }
+
+// UpdateRemoteClusters provides a mock function with given fields: remoteClusters
+func (_m *ClusterMetadata) UpdateRemoteClusters(remoteClusters map[string]config.ClusterInformation) error {
+	ret := _m.Called(remoteClusters)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]config.ClusterInformation) error); ok {
+		r0 = rf(remoteClusters)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegisterMetadataChangeCallback provides a mock function with given fields: callbackID, cb
+func (_m *ClusterMetadata) RegisterMetadataChangeCallback(callbackID interface{}, cb cluster.CallbackFn) {
+	_m.Called(callbackID, cb)
+}
+
+// UnRegisterMetadataChangeCallback provides a mock function with given fields: callbackID
+func (_m *ClusterMetadata) UnRegisterMetadataChangeCallback(callbackID interface{}) {
+	_m.Called(callbackID)
+}
+
+var _ cluster.Metadata = (*ClusterMetadata)(nil)
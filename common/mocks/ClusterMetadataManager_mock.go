@@ -148,6 +148,94 @@ func (mr *MockClusterMetadataManagerMockRecorder) GetImmutableClusterMetadata()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImmutableClusterMetadata", reflect.TypeOf((*MockClusterMetadataManager)(nil).GetImmutableClusterMetadata))
 }
 
+// UpsertRemoteCluster mocks base method
+func (m *MockClusterMetadataManager) UpsertRemoteCluster(request *persistence.UpsertRemoteClusterRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRemoteCluster", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertRemoteCluster indicates an expected call of UpsertRemoteCluster
+func (mr *MockClusterMetadataManagerMockRecorder) UpsertRemoteCluster(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRemoteCluster", reflect.TypeOf((*MockClusterMetadataManager)(nil).UpsertRemoteCluster), request)
+}
+
+// ListRemoteClusters mocks base method
+func (m *MockClusterMetadataManager) ListRemoteClusters() (*persistence.ListRemoteClustersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRemoteClusters")
+	ret0, _ := ret[0].(*persistence.ListRemoteClustersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRemoteClusters indicates an expected call of ListRemoteClusters
+func (mr *MockClusterMetadataManagerMockRecorder) ListRemoteClusters() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRemoteClusters", reflect.TypeOf((*MockClusterMetadataManager)(nil).ListRemoteClusters))
+}
+
+// DeleteRemoteCluster mocks base method
+func (m *MockClusterMetadataManager) DeleteRemoteCluster(request *persistence.DeleteRemoteClusterRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRemoteCluster", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRemoteCluster indicates an expected call of DeleteRemoteCluster
+func (mr *MockClusterMetadataManagerMockRecorder) DeleteRemoteCluster(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRemoteCluster", reflect.TypeOf((*MockClusterMetadataManager)(nil).DeleteRemoteCluster), request)
+}
+
+// UpdateDynamicConfig mocks base method
+func (m *MockClusterMetadataManager) UpdateDynamicConfig(request *persistence.UpdateDynamicConfigRequest) (*persistence.UpdateDynamicConfigResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDynamicConfig", request)
+	ret0, _ := ret[0].(*persistence.UpdateDynamicConfigResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDynamicConfig indicates an expected call of UpdateDynamicConfig
+func (mr *MockClusterMetadataManagerMockRecorder) UpdateDynamicConfig(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDynamicConfig", reflect.TypeOf((*MockClusterMetadataManager)(nil).UpdateDynamicConfig), request)
+}
+
+// ListDynamicConfigValues mocks base method
+func (m *MockClusterMetadataManager) ListDynamicConfigValues() (*persistence.ListDynamicConfigValuesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDynamicConfigValues")
+	ret0, _ := ret[0].(*persistence.ListDynamicConfigValuesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDynamicConfigValues indicates an expected call of ListDynamicConfigValues
+func (mr *MockClusterMetadataManagerMockRecorder) ListDynamicConfigValues() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDynamicConfigValues", reflect.TypeOf((*MockClusterMetadataManager)(nil).ListDynamicConfigValues))
+}
+
+// GetDynamicConfigHistory mocks base method
+func (m *MockClusterMetadataManager) GetDynamicConfigHistory(request *persistence.GetDynamicConfigHistoryRequest) (*persistence.GetDynamicConfigHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDynamicConfigHistory", request)
+	ret0, _ := ret[0].(*persistence.GetDynamicConfigHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDynamicConfigHistory indicates an expected call of GetDynamicConfigHistory
+func (mr *MockClusterMetadataManagerMockRecorder) GetDynamicConfigHistory(request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDynamicConfigHistory", reflect.TypeOf((*MockClusterMetadataManager)(nil).GetDynamicConfigHistory), request)
+}
+
 // GetClusterMembers mocks base method
 func (m *MockClusterMetadataManager) GetClusterMembers(request *persistence.GetClusterMembersRequest) (*persistence.GetClusterMembersResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,84 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	namespacepb "go.temporal.io/temporal-proto/namespace"
+
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+const (
+	// DeletionStageKey is the namespace data key which reports the stage of a namespace deletion
+	DeletionStageKey = "temporal.deletion.stage"
+	// DeletionExecutionsDeletedKey is the namespace data key which reports the number of purged workflow executions
+	DeletionExecutionsDeletedKey = "temporal.deletion.executionsDeleted"
+	// DeletionTaskListsKey is the namespace data key which holds the JSON encoded task lists of purged workflow executions
+	DeletionTaskListsKey = "temporal.deletion.taskLists"
+
+	// DeletionStagePending means the namespace is marked as deleting but the purge has not started yet
+	DeletionStagePending = "Pending"
+	// DeletionStageTerminatingExecutions means open workflow executions are being terminated
+	DeletionStageTerminatingExecutions = "TerminatingExecutions"
+	// DeletionStageDeletingExecutions means history, mutable state and visibility records are being deleted
+	DeletionStageDeletingExecutions = "DeletingExecutions"
+	// DeletionStageDeletingTaskLists means task lists of the namespace are being deleted
+	DeletionStageDeletingTaskLists = "DeletingTaskLists"
+	// DeletionStageDeletingMetadata means the namespace metadata is being deleted
+	DeletionStageDeletingMetadata = "DeletingMetadata"
+)
+
+// RecordDeletionProgress merges the given progress into the data of a namespace which is being deleted,
+// so that the progress is visible when describing the namespace
+func RecordDeletionProgress(
+	metadataMgr persistence.MetadataManager,
+	name string,
+	progress map[string]string,
+) error {
+
+	metadata, err := metadataMgr.GetMetadata()
+	if err != nil {
+		return err
+	}
+	getResponse, err := metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: name})
+	if err != nil {
+		return err
+	}
+
+	info := getResponse.Namespace.Info
+	if info.Status != namespacepb.NamespaceStatus_Deleted {
+		return errNamespaceNotDeleting
+	}
+	if info.Data == nil {
+		info.Data = make(map[string]string)
+	}
+	for k, v := range progress {
+		info.Data[k] = v
+	}
+	return metadataMgr.UpdateNamespace(&persistence.UpdateNamespaceRequest{
+		Namespace:           getResponse.Namespace,
+		NotificationVersion: metadata.NotificationVersion,
+	})
+}
@@ -36,4 +36,10 @@ var (
 	errCannotDoNamespaceFailoverAndUpdate = serviceerror.NewInvalidArgument("Cannot set active cluster to current cluster when other parameters are set.")
 	errInvalidRetentionPeriod             = serviceerror.NewInvalidArgument("A valid retention period is not set on request.")
 	errInvalidArchivalConfig              = serviceerror.NewInvalidArgument("Invalid to enable archival without specifying a uri.")
+	errCannotDeleteSystemNamespace        = serviceerror.NewInvalidArgument("Cannot delete the system namespace.")
+	errCannotDeleteReplicatedNamespace    = serviceerror.NewInvalidArgument("Cannot delete a namespace that is replicated to other clusters, remove the other clusters first.")
+	errNamespaceNotDeleting               = serviceerror.NewInvalidArgument("Namespace is not being deleted.")
+	errCannotUpdateDeletingNamespace      = serviceerror.NewInvalidArgument("Cannot update a namespace that is being deleted.")
+	errNamespaceInHandover                = serviceerror.NewInvalidArgument("Cannot fail over a namespace that is in handover.")
+	errCannotReplicateOffloadedNamespace  = serviceerror.NewInvalidArgument("Cannot replicate a namespace with payload offloading enabled, offloaded payloads are not replicated.")
 )
@@ -38,6 +38,7 @@ import (
 	"go.temporal.io/temporal-proto/serviceerror"
 	"go.temporal.io/temporal-proto/workflowservice"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	"github.com/temporalio/temporal/common"
@@ -73,18 +74,34 @@ type (
 			ctx context.Context,
 			updateRequest *workflowservice.UpdateNamespaceRequest,
 		) (*workflowservice.UpdateNamespaceResponse, error)
+		DeleteNamespace(
+			ctx context.Context,
+			deleteRequest *adminservice.DeleteNamespaceRequest,
+		) (*adminservice.DeleteNamespaceResponse, error)
+		CompleteNamespaceHandover(
+			ctx context.Context,
+			name string,
+			handoverVersion int64,
+		) error
+		AbortNamespaceHandover(
+			ctx context.Context,
+			name string,
+			handoverVersion int64,
+		) error
 	}
 
 	// HandlerImpl is the namespace operation handler implementation
 	HandlerImpl struct {
-		maxBadBinaryCount      dynamicconfig.IntPropertyFnWithNamespaceFilter
-		logger                 log.Logger
-		metadataMgr            persistence.MetadataManager
-		clusterMetadata        cluster.Metadata
-		namespaceReplicator    Replicator
-		namespaceAttrValidator *AttrValidatorImpl
-		archivalMetadata       archiver.ArchivalMetadata
-		archiverProvider       provider.ArchiverProvider
+		maxBadBinaryCount           dynamicconfig.IntPropertyFnWithNamespaceFilter
+		gracefulFailoverTimeout     dynamicconfig.DurationPropertyFnWithNamespaceFilter
+		payloadOffloadSizeThreshold dynamicconfig.IntPropertyFnWithNamespaceFilter
+		logger                      log.Logger
+		metadataMgr                 persistence.MetadataManager
+		clusterMetadata             cluster.Metadata
+		namespaceReplicator         Replicator
+		namespaceAttrValidator      *AttrValidatorImpl
+		archivalMetadata            archiver.ArchivalMetadata
+		archiverProvider            provider.ArchiverProvider
 	}
 )
 
@@ -94,6 +111,8 @@ var _ Handler = (*HandlerImpl)(nil)
 func NewHandler(
 	minRetentionDays int,
 	maxBadBinaryCount dynamicconfig.IntPropertyFnWithNamespaceFilter,
+	gracefulFailoverTimeout dynamicconfig.DurationPropertyFnWithNamespaceFilter,
+	payloadOffloadSizeThreshold dynamicconfig.IntPropertyFnWithNamespaceFilter,
 	logger log.Logger,
 	metadataMgr persistence.MetadataManager,
 	clusterMetadata cluster.Metadata,
@@ -102,14 +121,16 @@ func NewHandler(
 	archiverProvider provider.ArchiverProvider,
 ) *HandlerImpl {
 	return &HandlerImpl{
-		maxBadBinaryCount:      maxBadBinaryCount,
-		logger:                 logger,
-		metadataMgr:            metadataMgr,
-		clusterMetadata:        clusterMetadata,
-		namespaceReplicator:    namespaceReplicator,
-		namespaceAttrValidator: newAttrValidator(clusterMetadata, int32(minRetentionDays)),
-		archivalMetadata:       archivalMetadata,
-		archiverProvider:       archiverProvider,
+		maxBadBinaryCount:           maxBadBinaryCount,
+		gracefulFailoverTimeout:     gracefulFailoverTimeout,
+		payloadOffloadSizeThreshold: payloadOffloadSizeThreshold,
+		logger:                      logger,
+		metadataMgr:                 metadataMgr,
+		clusterMetadata:             clusterMetadata,
+		namespaceReplicator:         namespaceReplicator,
+		namespaceAttrValidator:      newAttrValidator(clusterMetadata, int32(minRetentionDays)),
+		archivalMetadata:            archivalMetadata,
+		archiverProvider:            archiverProvider,
 	}
 }
 
@@ -371,12 +392,18 @@ func (d *HandlerImpl) UpdateNamespace(
 	}
 
 	info := getResponse.Namespace.Info
+	if info.Status == namespacepb.NamespaceStatus_Deleted {
+		return nil, errCannotUpdateDeletingNamespace
+	}
 	config := getResponse.Namespace.Config
 	replicationConfig := getResponse.Namespace.ReplicationConfig
 	configVersion := getResponse.Namespace.ConfigVersion
 	failoverVersion := getResponse.Namespace.FailoverVersion
 	failoverNotificationVersion := getResponse.Namespace.FailoverNotificationVersion
+	failoverEndTime := getResponse.Namespace.FailoverEndTime
+	handover := getResponse.Namespace.Handover
 	isGlobalNamespace := getResponse.IsGlobalNamespace
+	previousActiveClusterName := replicationConfig.ActiveClusterName
 
 	currentHistoryArchivalState := &ArchivalState{
 		Status: config.HistoryArchivalStatus,
@@ -493,6 +520,10 @@ func (d *HandlerImpl) UpdateNamespace(
 			); err != nil {
 				return nil, err
 			}
+			// history keeps blob references to the offloaded payloads, which the other clusters cannot resolve
+			if len(clustersNew) > 1 && len(replicationConfig.Clusters) <= 1 && d.payloadOffloadSizeThreshold(info.Name) > 0 {
+				return nil, errCannotReplicateOffloadedNamespace
+			}
 			replicationConfig.Clusters = clustersNew
 		}
 
@@ -519,6 +550,9 @@ func (d *HandlerImpl) UpdateNamespace(
 		}
 	}
 
+	// whether the failover is graceful, i.e. the namespace enters handover instead of switching immediately
+	handoverStarted := false
+
 	if configurationChanged && activeClusterChanged && isGlobalNamespace {
 		return nil, errCannotDoNamespaceFailoverAndUpdate
 	} else if configurationChanged || activeClusterChanged {
@@ -526,11 +560,33 @@ func (d *HandlerImpl) UpdateNamespace(
 			return nil, errNotMasterCluster
 		}
 
+		if activeClusterChanged && isGlobalNamespace {
+			if handover != nil {
+				return nil, errNamespaceInHandover
+			}
+			// a graceful failover can only be driven by the current active cluster, which keeps the namespace
+			// and rejects new requests until all shards are replicated to the new active cluster
+			timeout := d.gracefulFailoverTimeout(info.Name)
+			if timeout > 0 &&
+				previousActiveClusterName == d.clusterMetadata.GetCurrentClusterName() &&
+				replicationConfig.ActiveClusterName != previousActiveClusterName {
+				// the notification version is bumped by every namespace update, so shards can tell
+				// this handover apart from a previous, aborted one
+				handover = &persistenceblobs.NamespaceHandover{
+					TargetClusterName: replicationConfig.ActiveClusterName,
+					HandoverVersion:   notificationVersion,
+				}
+				failoverEndTime = time.Now().Add(timeout).UnixNano()
+				replicationConfig.ActiveClusterName = previousActiveClusterName
+				handoverStarted = true
+			}
+		}
+
 		// set the versions
 		if configurationChanged {
 			configVersion++
 		}
-		if activeClusterChanged && isGlobalNamespace {
+		if activeClusterChanged && isGlobalNamespace && !handoverStarted {
 			failoverVersion = d.clusterMetadata.GetNextFailoverVersion(
 				replicationConfig.ActiveClusterName,
 				failoverVersion,
@@ -546,6 +602,8 @@ func (d *HandlerImpl) UpdateNamespace(
 				ConfigVersion:               configVersion,
 				FailoverVersion:             failoverVersion,
 				FailoverNotificationVersion: failoverNotificationVersion,
+				FailoverEndTime:             failoverEndTime,
+				Handover:                    handover,
 			},
 			NotificationVersion: notificationVersion,
 		}
@@ -559,7 +617,8 @@ func (d *HandlerImpl) UpdateNamespace(
 		return nil, errNotMasterCluster
 	}
 
-	if isGlobalNamespace {
+	// the handover is local to this cluster, the failover is replicated once the handover completes
+	if isGlobalNamespace && !handoverStarted {
 		err = d.namespaceReplicator.HandleTransmissionTask(replicationgenpb.NamespaceOperation_Update,
 			info, config, replicationConfig, configVersion, failoverVersion, isGlobalNamespace)
 		if err != nil {
@@ -573,6 +632,13 @@ func (d *HandlerImpl) UpdateNamespace(
 	}
 	response.NamespaceInfo, response.Configuration, response.ReplicationConfiguration = d.createResponse(ctx, info, config, replicationConfig)
 
+	if handoverStarted {
+		d.logger.Info("Namespace handover started",
+			tag.WorkflowNamespace(info.Name),
+			tag.WorkflowNamespaceID(info.Id),
+			tag.ClusterName(handover.TargetClusterName),
+		)
+	}
 	d.logger.Info("Update namespace succeeded",
 		tag.WorkflowNamespace(info.Name),
 		tag.WorkflowNamespaceID(info.Id),
@@ -580,6 +646,88 @@ func (d *HandlerImpl) UpdateNamespace(
 	return response, nil
 }
 
+// CompleteNamespaceHandover switches the active cluster of a namespace in handover
+// to the handover target cluster, as a regular failover does
+func (d *HandlerImpl) CompleteNamespaceHandover(
+	_ context.Context,
+	name string,
+	handoverVersion int64,
+) error {
+
+	return d.finishNamespaceHandover(name, handoverVersion, true)
+}
+
+// AbortNamespaceHandover takes a namespace out of handover and keeps the current active cluster
+func (d *HandlerImpl) AbortNamespaceHandover(
+	_ context.Context,
+	name string,
+	handoverVersion int64,
+) error {
+
+	return d.finishNamespaceHandover(name, handoverVersion, false)
+}
+
+func (d *HandlerImpl) finishNamespaceHandover(
+	name string,
+	handoverVersion int64,
+	complete bool,
+) error {
+
+	// must get the metadata (notificationVersion) first, it guards against concurrent updates
+	metadata, err := d.metadataMgr.GetMetadata()
+	if err != nil {
+		return err
+	}
+	notificationVersion := metadata.NotificationVersion
+	getResponse, err := d.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: name})
+	if err != nil {
+		return err
+	}
+
+	detail := getResponse.Namespace
+	handover := detail.Handover
+	if handover == nil || handover.HandoverVersion != handoverVersion {
+		// the handover was already completed or aborted
+		return nil
+	}
+
+	if complete {
+		detail.ReplicationConfig.ActiveClusterName = handover.TargetClusterName
+		detail.FailoverVersion = d.clusterMetadata.GetNextFailoverVersion(
+			handover.TargetClusterName,
+			detail.FailoverVersion,
+		)
+		detail.FailoverNotificationVersion = notificationVersion
+	}
+	detail.Handover = nil
+	detail.FailoverEndTime = 0
+
+	if err := d.metadataMgr.UpdateNamespace(&persistence.UpdateNamespaceRequest{
+		Namespace:           detail,
+		NotificationVersion: notificationVersion,
+	}); err != nil {
+		return err
+	}
+
+	if complete {
+		if err := d.namespaceReplicator.HandleTransmissionTask(replicationgenpb.NamespaceOperation_Update,
+			detail.Info, detail.Config, detail.ReplicationConfig, detail.ConfigVersion, detail.FailoverVersion, getResponse.IsGlobalNamespace); err != nil {
+			return err
+		}
+	}
+
+	msg := "Namespace handover aborted"
+	if complete {
+		msg = "Namespace handover completed"
+	}
+	d.logger.Info(msg,
+		tag.WorkflowNamespace(name),
+		tag.WorkflowNamespaceID(detail.Info.Id),
+		tag.ClusterName(detail.ReplicationConfig.ActiveClusterName),
+	)
+	return nil
+}
+
 // DeprecateNamespace deprecates a namespace
 func (d *HandlerImpl) DeprecateNamespace(
 	ctx context.Context,
@@ -626,6 +774,62 @@ func (d *HandlerImpl) DeprecateNamespace(
 	return nil, nil
 }
 
+// DeleteNamespace marks the namespace as deleting so that it stops accepting new workflows,
+// the data of the namespace is purged afterwards by the namespace deletion system workflow
+func (d *HandlerImpl) DeleteNamespace(
+	ctx context.Context,
+	deleteRequest *adminservice.DeleteNamespaceRequest,
+) (*adminservice.DeleteNamespaceResponse, error) {
+
+	clusterMetadata := d.clusterMetadata
+	if clusterMetadata.IsGlobalNamespaceEnabled() && !clusterMetadata.IsMasterCluster() {
+		return nil, errNotMasterCluster
+	}
+	if deleteRequest.GetNamespace() == common.SystemLocalNamespace {
+		return nil, errCannotDeleteSystemNamespace
+	}
+
+	// must get the metadata (notificationVersion) first
+	// this version can be regarded as the lock on the v2 namespace table
+	metadata, err := d.metadataMgr.GetMetadata()
+	if err != nil {
+		return nil, err
+	}
+	notificationVersion := metadata.NotificationVersion
+	getResponse, err := d.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: deleteRequest.GetNamespace()})
+	if err != nil {
+		return nil, err
+	}
+	if getResponse.IsGlobalNamespace && len(getResponse.Namespace.ReplicationConfig.Clusters) > 1 {
+		return nil, errCannotDeleteReplicatedNamespace
+	}
+	if getResponse.Namespace.Info.Status == namespacepb.NamespaceStatus_Deleted {
+		// deletion is already in progress, caller is free to restart the purge
+		return &adminservice.DeleteNamespaceResponse{}, nil
+	}
+
+	info := getResponse.Namespace.Info
+	if info.Data == nil {
+		info.Data = make(map[string]string)
+	}
+	info.Status = namespacepb.NamespaceStatus_Deleted
+	info.Data[DeletionStageKey] = DeletionStagePending
+	getResponse.Namespace.ConfigVersion = getResponse.Namespace.ConfigVersion + 1
+	err = d.metadataMgr.UpdateNamespace(&persistence.UpdateNamespaceRequest{
+		Namespace:           getResponse.Namespace,
+		NotificationVersion: notificationVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.logger.Info("Delete namespace succeeded",
+		tag.WorkflowNamespace(info.Name),
+		tag.WorkflowNamespaceID(info.Id),
+	)
+	return &adminservice.DeleteNamespaceResponse{}, nil
+}
+
 func (d *HandlerImpl) createResponse(
 	ctx context.Context,
 	info *persistenceblobs.NamespaceInfo,
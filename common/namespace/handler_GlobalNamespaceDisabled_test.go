@@ -107,6 +107,8 @@ func (s *namespaceHandlerGlobalNamespaceDisabledSuite) SetupTest() {
 	s.handler = NewHandler(
 		s.minRetentionDays,
 		dc.GetIntPropertyFilteredByNamespace(s.maxBadBinaryCount),
+		dc.GetDurationPropertyFnFilteredByNamespace(0),
+		dc.GetIntPropertyFilteredByNamespace(0),
 		logger,
 		s.metadataMgr,
 		s.ClusterMetadata,
@@ -29,6 +29,7 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/pborman/uuid"
@@ -108,6 +109,8 @@ func (s *namespaceHandlerGlobalNamespaceEnabledMasterClusterSuite) SetupTest() {
 	s.handler = NewHandler(
 		s.minRetentionDays,
 		dc.GetIntPropertyFilteredByNamespace(s.maxBadBinaryCount),
+		dc.GetDurationPropertyFnFilteredByNamespace(0),
+		dc.GetIntPropertyFilteredByNamespace(0),
 		logger,
 		s.metadataMgr,
 		s.ClusterMetadata,
@@ -867,6 +870,114 @@ func (s *namespaceHandlerGlobalNamespaceEnabledMasterClusterSuite) TestUpdateGet
 	)
 }
 
+func (s *namespaceHandlerGlobalNamespaceEnabledMasterClusterSuite) TestUpdateGetNamespace_GlobalNamespace_GracefulFailover() {
+	namespace := s.getRandomNamespace()
+	prevActiveClusterName := s.ClusterMetadata.GetCurrentClusterName()
+	nextActiveClusterName := ""
+	clusters := []*replicationpb.ClusterReplicationConfiguration{}
+	for clusterName := range s.ClusterMetadata.GetAllClusterInfo() {
+		if clusterName != prevActiveClusterName {
+			nextActiveClusterName = clusterName
+		}
+		clusters = append(clusters, &replicationpb.ClusterReplicationConfiguration{
+			ClusterName: clusterName,
+		})
+	}
+	s.True(len(nextActiveClusterName) > 0)
+	s.handler.gracefulFailoverTimeout = dc.GetDurationPropertyFnFilteredByNamespace(time.Minute)
+
+	// one for the registration, one for the completed handover
+	s.mockProducer.On("Publish", mock.Anything).Return(nil).Twice()
+
+	_, err := s.handler.RegisterNamespace(context.Background(), &workflowservice.RegisterNamespaceRequest{
+		Name:                                   namespace,
+		WorkflowExecutionRetentionPeriodInDays: 1,
+		Clusters:                               clusters,
+		ActiveClusterName:                      prevActiveClusterName,
+		IsGlobalNamespace:                      true,
+	})
+	s.NoError(err)
+	initialFailoverVersion := s.ClusterMetadata.GetNextFailoverVersion(prevActiveClusterName, 0)
+
+	failoverRequest := &workflowservice.UpdateNamespaceRequest{
+		Name: namespace,
+		ReplicationConfiguration: &replicationpb.NamespaceReplicationConfiguration{
+			ActiveClusterName: nextActiveClusterName,
+		},
+	}
+	updateResp, err := s.handler.UpdateNamespace(context.Background(), failoverRequest)
+	s.NoError(err)
+	s.Equal(prevActiveClusterName, updateResp.ReplicationConfiguration.GetActiveClusterName())
+	s.Equal(initialFailoverVersion, updateResp.GetFailoverVersion())
+
+	getResp, err := s.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: namespace})
+	s.NoError(err)
+	s.Equal(nextActiveClusterName, getResp.Namespace.Handover.GetTargetClusterName())
+	abortedHandoverVersion := getResp.Namespace.Handover.GetHandoverVersion()
+	s.True(getResp.Namespace.FailoverEndTime > time.Now().UnixNano())
+
+	_, err = s.handler.UpdateNamespace(context.Background(), failoverRequest)
+	s.Equal(errNamespaceInHandover, err)
+
+	// a restarted handover gets a new handover version
+	s.NoError(s.handler.AbortNamespaceHandover(context.Background(), namespace, abortedHandoverVersion))
+	_, err = s.handler.UpdateNamespace(context.Background(), failoverRequest)
+	s.NoError(err)
+	getResp, err = s.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: namespace})
+	s.NoError(err)
+	handoverVersion := getResp.Namespace.Handover.GetHandoverVersion()
+	s.True(handoverVersion > abortedHandoverVersion)
+	s.Equal(initialFailoverVersion, getResp.Namespace.FailoverVersion)
+
+	// a stale handover version is ignored
+	s.NoError(s.handler.CompleteNamespaceHandover(context.Background(), namespace, abortedHandoverVersion))
+	getResp, err = s.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: namespace})
+	s.NoError(err)
+	s.NotNil(getResp.Namespace.Handover)
+	s.NoError(s.handler.CompleteNamespaceHandover(context.Background(), namespace, handoverVersion))
+
+	getResp, err = s.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{Name: namespace})
+	s.NoError(err)
+	s.Nil(getResp.Namespace.Handover)
+	s.Equal(int64(0), getResp.Namespace.FailoverEndTime)
+	s.Equal(nextActiveClusterName, getResp.Namespace.ReplicationConfig.ActiveClusterName)
+	s.Equal(s.ClusterMetadata.GetNextFailoverVersion(nextActiveClusterName, initialFailoverVersion), getResp.Namespace.FailoverVersion)
+}
+
+func (s *namespaceHandlerGlobalNamespaceEnabledMasterClusterSuite) TestUpdateGetNamespace_GlobalNamespace_AddClusterWithPayloadOffload() {
+	namespace := s.getRandomNamespace()
+	activeClusterName := s.ClusterMetadata.GetCurrentClusterName()
+	clusters := []*replicationpb.ClusterReplicationConfiguration{}
+	for clusterName := range s.ClusterMetadata.GetAllClusterInfo() {
+		clusters = append(clusters, &replicationpb.ClusterReplicationConfiguration{
+			ClusterName: clusterName,
+		})
+	}
+	s.True(len(clusters) > 1)
+	s.handler.payloadOffloadSizeThreshold = dc.GetIntPropertyFilteredByNamespace(1024)
+
+	s.mockProducer.On("Publish", mock.Anything).Return(nil).Once()
+
+	_, err := s.handler.RegisterNamespace(context.Background(), &workflowservice.RegisterNamespaceRequest{
+		Name:                                   namespace,
+		WorkflowExecutionRetentionPeriodInDays: 1,
+		Clusters: []*replicationpb.ClusterReplicationConfiguration{
+			{ClusterName: activeClusterName},
+		},
+		ActiveClusterName: activeClusterName,
+		IsGlobalNamespace: true,
+	})
+	s.NoError(err)
+
+	_, err = s.handler.UpdateNamespace(context.Background(), &workflowservice.UpdateNamespaceRequest{
+		Name: namespace,
+		ReplicationConfiguration: &replicationpb.NamespaceReplicationConfiguration{
+			Clusters: clusters,
+		},
+	})
+	s.Equal(errCannotReplicateOffloadedNamespace, err)
+}
+
 func (s *namespaceHandlerGlobalNamespaceEnabledMasterClusterSuite) getRandomNamespace() string {
 	return "namespace" + uuid.New()
 }
@@ -109,6 +109,8 @@ func (s *namespaceHandlerGlobalNamespaceEnabledNotMasterClusterSuite) SetupTest(
 	s.handler = NewHandler(
 		s.minRetentionDays,
 		dc.GetIntPropertyFilteredByNamespace(s.maxBadBinaryCount),
+		dc.GetDurationPropertyFnFilteredByNamespace(0),
+		dc.GetIntPropertyFilteredByNamespace(0),
 		logger,
 		s.metadataMgr,
 		s.ClusterMetadata,
@@ -31,6 +31,7 @@ package namespace
 import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
+	adminservice "github.com/temporalio/temporal/.gen/proto/adminservice"
 	workflowservice "go.temporal.io/temporal-proto/workflowservice"
 	reflect "reflect"
 )
@@ -132,3 +133,46 @@ func (mr *MockHandlerMockRecorder) UpdateNamespace(ctx, updateRequest interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNamespace", reflect.TypeOf((*MockHandler)(nil).UpdateNamespace), ctx, updateRequest)
 }
+
+// DeleteNamespace mocks base method.
+func (m *MockHandler) DeleteNamespace(ctx context.Context, deleteRequest *adminservice.DeleteNamespaceRequest) (*adminservice.DeleteNamespaceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNamespace", ctx, deleteRequest)
+	ret0, _ := ret[0].(*adminservice.DeleteNamespaceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteNamespace indicates an expected call of DeleteNamespace.
+func (mr *MockHandlerMockRecorder) DeleteNamespace(ctx, deleteRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNamespace", reflect.TypeOf((*MockHandler)(nil).DeleteNamespace), ctx, deleteRequest)
+}
+
+// CompleteNamespaceHandover mocks base method.
+func (m *MockHandler) CompleteNamespaceHandover(ctx context.Context, name string, handoverVersion int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteNamespaceHandover", ctx, name, handoverVersion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteNamespaceHandover indicates an expected call of CompleteNamespaceHandover.
+func (mr *MockHandlerMockRecorder) CompleteNamespaceHandover(ctx, name, handoverVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteNamespaceHandover", reflect.TypeOf((*MockHandler)(nil).CompleteNamespaceHandover), ctx, name, handoverVersion)
+}
+
+// AbortNamespaceHandover mocks base method.
+func (m *MockHandler) AbortNamespaceHandover(ctx context.Context, name string, handoverVersion int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortNamespaceHandover", ctx, name, handoverVersion)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortNamespaceHandover indicates an expected call of AbortNamespaceHandover.
+func (mr *MockHandlerMockRecorder) AbortNamespaceHandover(ctx, name, handoverVersion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortNamespaceHandover", reflect.TypeOf((*MockHandler)(nil).AbortNamespaceHandover), ctx, name, handoverVersion)
+}
@@ -112,6 +112,8 @@ func (s *namespaceHandlerCommonSuite) SetupTest() {
 	s.handler = NewHandler(
 		s.minRetentionDays,
 		dc.GetIntPropertyFilteredByNamespace(s.maxBadBinaryCount),
+		dc.GetDurationPropertyFnFilteredByNamespace(0),
+		dc.GetIntPropertyFilteredByNamespace(0),
 		logger,
 		s.metadataMgr,
 		s.ClusterMetadata,
@@ -0,0 +1,239 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/resharding"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	// HandoverCompleter finishes graceful failovers. A namespace in handover is switched to
+	// its target cluster once every history shard has reported that the target cluster acked
+	// the replication tasks written before the handover, or taken out of handover once the
+	// failover timeout passes. Each namespace is checked by the frontend host which owns it
+	// in the membership ring only.
+	HandoverCompleter struct {
+		status            int32
+		handler           Handler
+		metadataMgr       persistence.MetadataManager
+		shardMgr          persistence.ShardManager
+		namespaceCache    cache.NamespaceCache
+		membershipMonitor membership.Monitor
+		hostInfo          *membership.HostInfo
+		shardLayout       resharding.Layout
+		checkInterval     dynamicconfig.DurationPropertyFn
+		logger            log.Logger
+		shutdownChan      chan struct{}
+
+		// progress of the handovers checked by this host, keyed by namespace ID,
+		// only accessed by the check loop
+		progress map[string]*handoverProgress
+	}
+
+	handoverProgress struct {
+		handoverVersion  int64
+		replicatedShards map[int]struct{}
+	}
+)
+
+// NewHandoverCompleter creates a new namespace handover completer
+func NewHandoverCompleter(
+	handler Handler,
+	metadataMgr persistence.MetadataManager,
+	shardMgr persistence.ShardManager,
+	namespaceCache cache.NamespaceCache,
+	membershipMonitor membership.Monitor,
+	hostInfo *membership.HostInfo,
+	shardLayout resharding.Layout,
+	checkInterval dynamicconfig.DurationPropertyFn,
+	logger log.Logger,
+) *HandoverCompleter {
+
+	return &HandoverCompleter{
+		status:            common.DaemonStatusInitialized,
+		handler:           handler,
+		metadataMgr:       metadataMgr,
+		shardMgr:          shardMgr,
+		namespaceCache:    namespaceCache,
+		membershipMonitor: membershipMonitor,
+		hostInfo:          hostInfo,
+		shardLayout:       shardLayout,
+		checkInterval:     checkInterval,
+		logger:            logger,
+		shutdownChan:      make(chan struct{}),
+		progress:          make(map[string]*handoverProgress),
+	}
+}
+
+// Start starts the background check of namespaces in handover
+func (c *HandoverCompleter) Start() {
+	if !atomic.CompareAndSwapInt32(&c.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+
+	go c.checkLoop()
+}
+
+// Stop stops the background check of namespaces in handover
+func (c *HandoverCompleter) Stop() {
+	if !atomic.CompareAndSwapInt32(&c.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+
+	close(c.shutdownChan)
+}
+
+func (c *HandoverCompleter) checkLoop() {
+	timer := time.NewTimer(c.checkInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-c.shutdownChan:
+			return
+		case <-timer.C:
+			if err := c.checkHandovers(); err != nil {
+				c.logger.Error("Error checking namespaces in handover", tag.Error(err))
+			}
+			timer.Reset(c.checkInterval())
+		}
+	}
+}
+
+func (c *HandoverCompleter) checkHandovers() error {
+	// the namespace cache is refreshed in the background, only namespaces it reports
+	// in handover are read from the database
+	inHandover := make(map[string]struct{})
+	for namespaceID, entry := range c.namespaceCache.GetAllNamespace() {
+		handover := entry.GetHandover()
+		if !entry.IsGlobalNamespace() || handover == nil || !entry.IsNamespaceActive() {
+			continue
+		}
+		owner, err := c.membershipMonitor.Lookup(common.FrontendServiceName, namespaceID)
+		if err != nil {
+			return err
+		}
+		if owner.Identity() != c.hostInfo.Identity() {
+			continue
+		}
+
+		inHandover[namespaceID] = struct{}{}
+		if err := c.checkHandover(namespaceID, handover.GetHandoverVersion()); err != nil {
+			c.logger.Error("Error checking namespace handover",
+				tag.WorkflowNamespace(entry.GetInfo().Name),
+				tag.Error(err),
+			)
+		}
+	}
+
+	for namespaceID := range c.progress {
+		if _, ok := inHandover[namespaceID]; !ok {
+			delete(c.progress, namespaceID)
+		}
+	}
+	return nil
+}
+
+func (c *HandoverCompleter) checkHandover(
+	namespaceID string,
+	handoverVersion int64,
+) error {
+
+	record, err := c.metadataMgr.GetNamespace(&persistence.GetNamespaceRequest{ID: namespaceID})
+	if err != nil {
+		return err
+	}
+	handover := record.Namespace.Handover
+	if handover == nil || handover.HandoverVersion != handoverVersion {
+		// the namespace cache is stale, the handover was already completed or aborted
+		return nil
+	}
+
+	name := record.Namespace.Info.Name
+	if time.Now().UnixNano() > record.Namespace.FailoverEndTime {
+		c.logger.Warn("Namespace handover timed out, keeping current active cluster",
+			tag.WorkflowNamespace(name),
+			tag.ClusterName(handover.TargetClusterName),
+		)
+		return c.handler.AbortNamespaceHandover(context.Background(), name, handoverVersion)
+	}
+
+	ready, err := c.isReplicationCaughtUp(namespaceID, handoverVersion)
+	if err != nil || !ready {
+		return err
+	}
+	return c.handler.CompleteNamespaceHandover(context.Background(), name, handoverVersion)
+}
+
+// isReplicationCaughtUp returns whether every history shard has recorded that the target cluster
+// acked the replication tasks written before the handover. Shards which already did are not read again.
+func (c *HandoverCompleter) isReplicationCaughtUp(
+	namespaceID string,
+	handoverVersion int64,
+) (bool, error) {
+
+	progress, ok := c.progress[namespaceID]
+	if !ok || progress.handoverVersion != handoverVersion {
+		progress = &handoverProgress{
+			handoverVersion:  handoverVersion,
+			replicatedShards: make(map[int]struct{}),
+		}
+		c.progress[namespaceID] = progress
+	}
+
+	// the shard count is read on every check, so the target shards of a split are checked once they own workflows
+	numberOfShards := c.shardLayout.GetNumberOfShards()
+	sourceNumberOfShards := c.shardLayout.GetSourceNumberOfShards()
+	for shardID := 0; shardID < numberOfShards; shardID++ {
+		if _, ok := progress.replicatedShards[shardID]; ok {
+			continue
+		}
+		if shardID >= sourceNumberOfShards && !c.shardLayout.IsSplit(shardID%sourceNumberOfShards) {
+			// the workflows of this target shard are still owned by its source shard
+			continue
+		}
+		response, err := c.shardMgr.GetShard(&persistence.GetShardRequest{ShardID: int32(shardID)})
+		if err != nil {
+			return false, err
+		}
+		level, ok := response.ShardInfo.NamespaceHandoverLevels[namespaceID]
+		if !ok || level.GetHandoverVersion() != handoverVersion || !level.GetReplicated() {
+			return false, nil
+		}
+		progress.replicatedShards[shardID] = struct{}{}
+	}
+	return true, nil
+}
@@ -0,0 +1,155 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/resharding"
+	dc "github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	handoverCompleterSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		shardMgr          *mocks.ShardManager
+		handoverCompleter *HandoverCompleter
+	}
+)
+
+const (
+	testHandoverNamespaceID = "test-namespace-id"
+	testHandoverVersion     = int64(12)
+)
+
+func TestHandoverCompleterSuite(t *testing.T) {
+	s := new(handoverCompleterSuite)
+	suite.Run(t, s)
+}
+
+func (s *handoverCompleterSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.shardMgr = &mocks.ShardManager{}
+	s.handoverCompleter = NewHandoverCompleter(
+		nil,
+		nil,
+		s.shardMgr,
+		nil,
+		nil,
+		nil,
+		resharding.NewStaticLayout(2),
+		dc.GetDurationPropertyFn(time.Second),
+		loggerimpl.NewDevelopmentForTest(s.Suite),
+	)
+}
+
+func (s *handoverCompleterSuite) TearDownTest() {
+	s.shardMgr.AssertExpectations(s.T())
+}
+
+func (s *handoverCompleterSuite) TestIsReplicationCaughtUp() {
+	s.expectGetShard(0, &persistenceblobs.NamespaceHandoverLevel{
+		HandoverVersion: testHandoverVersion,
+		Replicated:      true,
+	})
+	s.expectGetShard(1, &persistenceblobs.NamespaceHandoverLevel{
+		HandoverVersion: testHandoverVersion,
+		Replicated:      false,
+	})
+	ready, err := s.handoverCompleter.isReplicationCaughtUp(testHandoverNamespaceID, testHandoverVersion)
+	s.NoError(err)
+	s.False(ready)
+
+	// the replicated shard is not read again
+	s.expectGetShard(1, &persistenceblobs.NamespaceHandoverLevel{
+		HandoverVersion: testHandoverVersion,
+		Replicated:      true,
+	})
+	ready, err = s.handoverCompleter.isReplicationCaughtUp(testHandoverNamespaceID, testHandoverVersion)
+	s.NoError(err)
+	s.True(ready)
+}
+
+func (s *handoverCompleterSuite) TestIsReplicationCaughtUp_StaleHandoverVersion() {
+	s.expectGetShard(0, &persistenceblobs.NamespaceHandoverLevel{
+		HandoverVersion: testHandoverVersion - 1,
+		Replicated:      true,
+	})
+	ready, err := s.handoverCompleter.isReplicationCaughtUp(testHandoverNamespaceID, testHandoverVersion)
+	s.NoError(err)
+	s.False(ready)
+}
+
+func (s *handoverCompleterSuite) TestIsReplicationCaughtUp_SplitShards() {
+	layout, err := resharding.NewLayout(2, 4, s.shardMgr, dc.GetDurationPropertyFn(time.Second), dc.GetDurationPropertyFn(time.Minute), loggerimpl.NewNopLogger())
+	s.NoError(err)
+	s.handoverCompleter.shardLayout = layout
+	layout.MarkSplit(0)
+
+	// shard 3 is not checked, its workflows are still owned by source shard 1
+	for _, shardID := range []int32{0, 1, 2} {
+		s.expectGetShard(shardID, &persistenceblobs.NamespaceHandoverLevel{
+			HandoverVersion: testHandoverVersion,
+			Replicated:      true,
+		})
+	}
+	ready, err := s.handoverCompleter.isReplicationCaughtUp(testHandoverNamespaceID, testHandoverVersion)
+	s.NoError(err)
+	s.True(ready)
+
+	layout.MarkSplit(1)
+	s.expectGetShard(3, &persistenceblobs.NamespaceHandoverLevel{
+		HandoverVersion: testHandoverVersion,
+		Replicated:      false,
+	})
+	ready, err = s.handoverCompleter.isReplicationCaughtUp(testHandoverNamespaceID, testHandoverVersion)
+	s.NoError(err)
+	s.False(ready)
+}
+
+func (s *handoverCompleterSuite) expectGetShard(
+	shardID int32,
+	level *persistenceblobs.NamespaceHandoverLevel,
+) {
+
+	s.shardMgr.On("GetShard", &persistence.GetShardRequest{ShardID: shardID}).Return(&persistence.GetShardResponse{
+		ShardInfo: &persistenceblobs.ShardInfo{
+			ShardId: shardID,
+			NamespaceHandoverLevels: map[string]*persistenceblobs.NamespaceHandoverLevel{
+				testHandoverNamespaceID: level,
+			},
+		},
+	}, nil).Once()
+}
@@ -0,0 +1,91 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package payload
+
+import (
+	"reflect"
+
+	commonpb "go.temporal.io/temporal-proto/common"
+)
+
+var (
+	payloadType          = reflect.TypeOf((*commonpb.Payload)(nil))
+	searchAttributesType = reflect.TypeOf((*commonpb.SearchAttributes)(nil))
+)
+
+// Visit calls fn on every payload reachable from the message. Generated proto messages only hold pointers
+// to payloads, so fn can modify payloads in place. Search attributes are skipped since they have to stay
+// readable by the server for indexing.
+func Visit(message interface{}, fn func(p *commonpb.Payload) error) error {
+	return visitValue(reflect.ValueOf(message), fn)
+}
+
+func visitValue(v reflect.Value, fn func(p *commonpb.Payload) error) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		switch v.Type() {
+		case payloadType:
+			return fn(v.Interface().(*commonpb.Payload))
+		case searchAttributesType:
+			return nil
+		}
+		return visitValue(v.Elem(), fn)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return visitValue(v.Elem(), fn)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				// unexported field
+				continue
+			}
+			if err := visitValue(v.Field(i), fn); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := visitValue(v.Index(i), fn); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := visitValue(iter.Value(), fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package payload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
+)
+
+func TestVisit(t *testing.T) {
+	assert := assert.New(t)
+
+	event := &eventpb.HistoryEvent{
+		EventId:   1,
+		EventType: eventpb.EventType_WorkflowExecutionStarted,
+		Attributes: &eventpb.HistoryEvent_WorkflowExecutionStartedEventAttributes{
+			WorkflowExecutionStartedEventAttributes: &eventpb.WorkflowExecutionStartedEventAttributes{
+				Input: &commonpb.Payloads{
+					Payloads: []*commonpb.Payload{EncodeString("input-1"), EncodeString("input-2")},
+				},
+				Memo: &commonpb.Memo{
+					Fields: map[string]*commonpb.Payload{"memo": EncodeString("memo")},
+				},
+				SearchAttributes: &commonpb.SearchAttributes{
+					IndexedFields: map[string]*commonpb.Payload{"CustomKeywordField": EncodeString("keyword")},
+				},
+			},
+		},
+	}
+
+	var visited []string
+	err := Visit([]*eventpb.HistoryEvent{event}, func(p *commonpb.Payload) error {
+		visited = append(visited, ToString(p))
+		p.Data = []byte(`"visited"`)
+		return nil
+	})
+	assert.NoError(err)
+	assert.ElementsMatch([]string{`"input-1"`, `"input-2"`, `"memo"`}, visited)
+
+	attributes := event.GetWorkflowExecutionStartedEventAttributes()
+	assert.Equal(`"visited"`, ToString(attributes.Input.Payloads[0]))
+	assert.Equal(`"visited"`, ToString(attributes.Memo.Fields["memo"]))
+	assert.Equal(`"keyword"`, ToString(attributes.SearchAttributes.IndexedFields["CustomKeywordField"]))
+}
@@ -0,0 +1,158 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package payloadcodec
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	commonpb "go.temporal.io/temporal-proto/common"
+)
+
+const (
+	// MetadataEncodingEncrypted is the payload encoding of a payload encrypted by the AES-GCM codec
+	MetadataEncodingEncrypted = "binary/encrypted"
+
+	metadataEncoding        = "encoding"
+	metadataEncryptionKeyID = "encryption-key-id"
+)
+
+type (
+	aesGCMCodec struct {
+		keyring *Keyring
+		aeads   map[string]cipher.AEAD
+	}
+)
+
+var (
+	// ErrMalformedEncryptedPayload is the error for encrypted payload which cannot be decrypted
+	ErrMalformedEncryptedPayload = errors.New("malformed encrypted payload")
+	// ErrEncryptedPayload is the error for payload to encode which is already marked as encrypted,
+	// only payloads encrypted by the codec itself are decrypted
+	ErrEncryptedPayload = errors.New("payload is already encrypted")
+)
+
+var _ PayloadCodec = (*aesGCMCodec)(nil)
+
+// NewAESGCMCodec returns a PayloadCodec which encrypts payloads with AES-GCM using the keys of the keyring
+func NewAESGCMCodec(keyring *Keyring) (PayloadCodec, error) {
+	keys, err := keyring.decodeKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for keyID, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		aeads[keyID] = aead
+	}
+
+	return &aesGCMCodec{
+		keyring: keyring,
+		aeads:   aeads,
+	}, nil
+}
+
+// IsEncrypted returns true if the payload is encrypted by the AES-GCM codec
+func IsEncrypted(p *commonpb.Payload) bool {
+	return string(p.GetMetadata()[metadataEncoding]) == MetadataEncodingEncrypted
+}
+
+func (c *aesGCMCodec) Encode(namespace string, p *commonpb.Payload) error {
+	if IsEncrypted(p) {
+		return ErrEncryptedPayload
+	}
+	keyID := c.keyring.GetKeyID(namespace)
+	if len(keyID) == 0 {
+		return nil
+	}
+	aead := c.aeads[keyID]
+
+	plaintext, err := p.Marshal()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	*p = commonpb.Payload{
+		Metadata: map[string][]byte{
+			metadataEncoding:        []byte(MetadataEncodingEncrypted),
+			metadataEncryptionKeyID: []byte(keyID),
+		},
+		Data: aead.Seal(nonce, nonce, plaintext, additionalData(namespace, keyID)),
+	}
+	return nil
+}
+
+func (c *aesGCMCodec) Decode(namespace string, p *commonpb.Payload) error {
+	if !IsEncrypted(p) {
+		return nil
+	}
+	// payloads are only decrypted with the key of their namespace
+	keyID := string(p.GetMetadata()[metadataEncryptionKeyID])
+	if keyID != c.keyring.GetKeyID(namespace) {
+		return fmt.Errorf("encryption key %v is not the key of namespace %v", keyID, namespace)
+	}
+	aead, ok := c.aeads[keyID]
+	if !ok {
+		return fmt.Errorf("unknown encryption key %v", keyID)
+	}
+
+	if len(p.Data) < aead.NonceSize() {
+		return ErrMalformedEncryptedPayload
+	}
+	nonce, ciphertext := p.Data[:aead.NonceSize()], p.Data[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData(namespace, keyID))
+	if err != nil {
+		return ErrMalformedEncryptedPayload
+	}
+
+	decoded := commonpb.Payload{}
+	if err := decoded.Unmarshal(plaintext); err != nil {
+		return ErrMalformedEncryptedPayload
+	}
+	*p = decoded
+	return nil
+}
+
+// additionalData binds the ciphertext to the namespace and the key, so a payload of one namespace
+// cannot be decrypted as a payload of another namespace sharing the key
+func additionalData(namespace string, keyID string) []byte {
+	return []byte(keyID + "\x00" + namespace)
+}
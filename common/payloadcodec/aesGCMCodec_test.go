@@ -0,0 +1,188 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package payloadcodec
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/temporal-proto/common"
+
+	"github.com/temporalio/temporal/common/payloads"
+)
+
+type aesGCMCodecSuite struct {
+	*require.Assertions
+	suite.Suite
+
+	keyring *Keyring
+	codec   PayloadCodec
+}
+
+func TestAESGCMCodecSuite(t *testing.T) {
+	suite.Run(t, new(aesGCMCodecSuite))
+}
+
+func (s *aesGCMCodecSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.keyring = &Keyring{
+		Keys: map[string]string{
+			"key-1": base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")),
+			"key-2": base64.StdEncoding.EncodeToString([]byte("0123456789abcdef")),
+		},
+		Namespaces: map[string]string{
+			"encrypted-namespace": "key-1",
+			"plain-namespace":     "",
+		},
+		DefaultKeyID: "key-2",
+	}
+	var err error
+	s.codec, err = NewAESGCMCodec(s.keyring)
+	s.NoError(err)
+}
+
+func (s *aesGCMCodecSuite) TestEncodeDecode() {
+	input := payloads.EncodeString("secret input")
+	expected := payloads.EncodeString("secret input")
+
+	err := EncodeMessage(s.codec, "encrypted-namespace", input)
+	s.NoError(err)
+	s.True(IsEncrypted(input.Payloads[0]))
+	s.Equal("key-1", string(input.Payloads[0].Metadata[metadataEncryptionKeyID]))
+	s.NotContains(string(input.Payloads[0].Data), "secret input")
+
+	// payloads already marked as encrypted are never encoded
+	encrypted := *input.Payloads[0]
+	err = EncodeMessage(s.codec, "encrypted-namespace", input)
+	s.Equal(ErrEncryptedPayload, err)
+	s.Equal(encrypted, *input.Payloads[0])
+	err = EncodeMessage(s.codec, "plain-namespace", input)
+	s.Equal(ErrEncryptedPayload, err)
+
+	err = DecodeMessage(s.codec, "encrypted-namespace", input)
+	s.NoError(err)
+	s.Equal(expected, input)
+}
+
+func (s *aesGCMCodecSuite) TestDecode_OtherNamespace() {
+	input := payloads.EncodeString("input")
+	err := EncodeMessage(s.codec, "encrypted-namespace", input)
+	s.NoError(err)
+	err = DecodeMessage(s.codec, "other-namespace", input)
+	s.Error(err)
+	s.True(IsEncrypted(input.Payloads[0]))
+
+	// namespaces sharing the default key cannot decrypt the payloads of each other
+	input = payloads.EncodeString("input")
+	err = EncodeMessage(s.codec, "other-namespace", input)
+	s.NoError(err)
+	err = DecodeMessage(s.codec, "another-namespace", input)
+	s.Equal(ErrMalformedEncryptedPayload, err)
+	err = DecodeMessage(s.codec, "other-namespace", input)
+	s.NoError(err)
+	s.Equal(payloads.EncodeString("input"), input)
+}
+
+func (s *aesGCMCodecSuite) TestEncode_NamespaceKeys() {
+	input := payloads.EncodeString("input")
+	err := EncodeMessage(s.codec, "plain-namespace", input)
+	s.NoError(err)
+	s.Equal(payloads.EncodeString("input"), input)
+
+	err = EncodeMessage(s.codec, "other-namespace", input)
+	s.NoError(err)
+	s.True(IsEncrypted(input.Payloads[0]))
+	s.Equal("key-2", string(input.Payloads[0].Metadata[metadataEncryptionKeyID]))
+}
+
+func (s *aesGCMCodecSuite) TestDecode_Tampered() {
+	input := payloads.EncodeString("input")
+	err := EncodeMessage(s.codec, "encrypted-namespace", input)
+	s.NoError(err)
+
+	input.Payloads[0].Metadata[metadataEncryptionKeyID] = []byte("key-2")
+	err = DecodeMessage(s.codec, "encrypted-namespace", input)
+	s.Error(err)
+
+	input.Payloads[0].Metadata[metadataEncryptionKeyID] = []byte("unknown-key")
+	err = DecodeMessage(s.codec, "encrypted-namespace", input)
+	s.Error(err)
+
+	input.Payloads[0].Metadata[metadataEncryptionKeyID] = []byte("key-1")
+	input.Payloads[0].Data[len(input.Payloads[0].Data)-1] ^= 1
+	err = DecodeMessage(s.codec, "encrypted-namespace", input)
+	s.Equal(ErrMalformedEncryptedPayload, err)
+
+	err = s.codec.Decode("encrypted-namespace", &commonpb.Payload{
+		Metadata: map[string][]byte{
+			metadataEncoding:        []byte(MetadataEncodingEncrypted),
+			metadataEncryptionKeyID: []byte("key-1"),
+		},
+		Data: []byte("short"),
+	})
+	s.Equal(ErrMalformedEncryptedPayload, err)
+}
+
+func (s *aesGCMCodecSuite) TestLoadKeyringFile() {
+	dir, err := ioutil.TempDir("", "TestLoadKeyringFile")
+	s.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "keyring.yaml")
+	err = ioutil.WriteFile(path, []byte(`
+keys:
+  key-1: MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=
+namespaces:
+  encrypted-namespace: key-1
+`), 0600)
+	s.NoError(err)
+
+	keyring, err := LoadKeyringFile(path)
+	s.NoError(err)
+	s.Equal("key-1", keyring.GetKeyID("encrypted-namespace"))
+	s.Equal("", keyring.GetKeyID("other-namespace"))
+
+	err = ioutil.WriteFile(path, []byte(`
+keys:
+  key-1: c2hvcnQ=
+`), 0600)
+	s.NoError(err)
+	_, err = LoadKeyringFile(path)
+	s.Error(err)
+
+	err = ioutil.WriteFile(path, []byte(`
+namespaces:
+  encrypted-namespace: key-1
+`), 0600)
+	s.NoError(err)
+	_, err = LoadKeyringFile(path)
+	s.Error(err)
+}
@@ -0,0 +1,56 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package payloadcodec
+
+import (
+	commonpb "go.temporal.io/temporal-proto/common"
+
+	"github.com/temporalio/temporal/common/payload"
+)
+
+type (
+	// PayloadCodec encodes and decodes payloads in place, e.g. to encrypt payloads of a namespace
+	PayloadCodec interface {
+		// Encode encodes the payload with the settings of the namespace,
+		// payload is left as is if there is nothing to encode for the namespace
+		Encode(namespace string, p *commonpb.Payload) error
+		// Decode decodes the payload of the namespace if it is encoded by this codec, otherwise payload is left as is
+		Decode(namespace string, p *commonpb.Payload) error
+	}
+)
+
+// EncodeMessage encodes all payloads of the message
+func EncodeMessage(codec PayloadCodec, namespace string, message interface{}) error {
+	return payload.Visit(message, func(p *commonpb.Payload) error {
+		return codec.Encode(namespace, p)
+	})
+}
+
+// DecodeMessage decodes all payloads of the message
+func DecodeMessage(codec PayloadCodec, namespace string, message interface{}) error {
+	return payload.Visit(message, func(p *commonpb.Payload) error {
+		return codec.Decode(namespace, p)
+	})
+}
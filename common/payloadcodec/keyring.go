@@ -0,0 +1,96 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package payloadcodec
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+type (
+	// Keyring holds the encryption keys and which key is used for which namespace
+	Keyring struct {
+		// Keys is map from key ID to base64 encoded AES key of 16, 24 or 32 bytes
+		Keys map[string]string `yaml:"keys"`
+		// Namespaces is map from namespace to the ID of the key used to encrypt its payloads
+		Namespaces map[string]string `yaml:"namespaces"`
+		// DefaultKeyID is the ID of the key used for namespaces not listed in Namespaces, empty means no encryption
+		DefaultKeyID string `yaml:"defaultKeyID"`
+	}
+)
+
+// LoadKeyringFile loads and validates the keyring from a yaml file
+func LoadKeyringFile(path string) (*Keyring, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	keyring := &Keyring{}
+	if err := yaml.Unmarshal(data, keyring); err != nil {
+		return nil, err
+	}
+	if _, err := keyring.decodeKeys(); err != nil {
+		return nil, err
+	}
+	return keyring, nil
+}
+
+// GetKeyID returns the ID of the key for the namespace, empty if payloads of the namespace should not be encrypted
+func (k *Keyring) GetKeyID(namespace string) string {
+	if keyID, ok := k.Namespaces[namespace]; ok {
+		return keyID
+	}
+	return k.DefaultKeyID
+}
+
+func (k *Keyring) decodeKeys() (map[string][]byte, error) {
+	keys := make(map[string][]byte, len(k.Keys))
+	for keyID, encodedKey := range k.Keys {
+		key, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %v: %v", keyID, err)
+		}
+		switch len(key) {
+		case 16, 24, 32:
+		default:
+			return nil, fmt.Errorf("invalid key %v: key must be 16, 24 or 32 bytes", keyID)
+		}
+		keys[keyID] = key
+	}
+
+	for namespace, keyID := range k.Namespaces {
+		if _, ok := keys[keyID]; !ok && len(keyID) != 0 {
+			return nil, fmt.Errorf("unknown key %v for namespace %v", keyID, namespace)
+		}
+	}
+	if _, ok := keys[k.DefaultKeyID]; !ok && len(k.DefaultKeyID) != 0 {
+		return nil, fmt.Errorf("unknown default key %v", k.DefaultKeyID)
+	}
+	return keys, nil
+}
@@ -0,0 +1,89 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:generate mockgen -copyright_file ../../LICENSE -package $GOPACKAGE -source $GOFILE -destination auditLogQueue_mock.go -self_package github.com/temporalio/temporal/common/persistence
+
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+)
+
+var _ AuditLogQueue = (*auditLogQueueImpl)(nil)
+
+type (
+	auditLogQueueImpl struct {
+		queue Queue
+	}
+
+	// AuditLogQueue is used to append and read audit records
+	AuditLogQueue interface {
+		Append(record *persistenceblobs.AuditRecord) error
+		// Read returns up to maxCount records appended after lastMessageID, oldest first,
+		// together with the message ID of the last returned record
+		Read(lastMessageID int64, maxCount int) ([]*persistenceblobs.AuditRecord, int64, error)
+	}
+)
+
+// NewAuditLogQueue creates a new AuditLogQueue instance
+func NewAuditLogQueue(
+	queue Queue,
+) AuditLogQueue {
+	return &auditLogQueueImpl{
+		queue: queue,
+	}
+}
+
+func (q *auditLogQueueImpl) Append(
+	record *persistenceblobs.AuditRecord,
+) error {
+	bytes, err := record.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %v", err)
+	}
+	return q.queue.EnqueueMessage(bytes)
+}
+
+func (q *auditLogQueueImpl) Read(
+	lastMessageID int64,
+	maxCount int,
+) ([]*persistenceblobs.AuditRecord, int64, error) {
+	messages, err := q.queue.ReadMessages(lastMessageID, maxCount)
+	if err != nil {
+		return nil, lastMessageID, err
+	}
+
+	var records []*persistenceblobs.AuditRecord
+	for _, message := range messages {
+		record := &persistenceblobs.AuditRecord{}
+		if err := record.Unmarshal(message.Payload); err != nil {
+			return nil, lastMessageID, fmt.Errorf("failed to decode audit record: %v", err)
+		}
+		records = append(records, record)
+		lastMessageID = message.ID
+	}
+	return records, lastMessageID, nil
+}
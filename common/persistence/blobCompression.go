@@ -0,0 +1,108 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/persistence/serialization"
+)
+
+var (
+	// zstd encoder / decoder are safe for concurrent use with EncodeAll / DecodeAll
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// IsCompressedEncoding returns true if the encoding type is a compressed encoding
+func IsCompressedEncoding(encodingType common.EncodingType) bool {
+	switch encodingType {
+	case common.EncodingTypeProto3Snappy, common.EncodingTypeProto3Zstd:
+		return true
+	default:
+		return false
+	}
+}
+
+// getCompressionBaseEncoding returns the encoding of the payload before compression
+func getCompressionBaseEncoding(encodingType common.EncodingType) common.EncodingType {
+	switch encodingType {
+	case common.EncodingTypeProto3Snappy, common.EncodingTypeProto3Zstd:
+		return common.EncodingTypeProto3
+	default:
+		return encodingType
+	}
+}
+
+// compressDataBlob compresses the data blob with given compressed encoding type,
+// data blob must be encoded with the base encoding of the compressed encoding type
+func compressDataBlob(data *serialization.DataBlob, encodingType common.EncodingType) (*serialization.DataBlob, error) {
+	if data == nil || !IsCompressedEncoding(encodingType) {
+		return data, nil
+	}
+	if data.Encoding != getCompressionBaseEncoding(encodingType) {
+		return nil, NewSerializationError(fmt.Sprintf("cannot compress %v data blob as %v", data.Encoding, encodingType))
+	}
+
+	var compressed []byte
+	switch encodingType {
+	case common.EncodingTypeProto3Snappy:
+		compressed = snappy.Encode(nil, data.Data)
+	case common.EncodingTypeProto3Zstd:
+		compressed = zstdEncoder.EncodeAll(data.Data, nil)
+	}
+	return &serialization.DataBlob{
+		Data:     compressed,
+		Encoding: encodingType,
+	}, nil
+}
+
+// DecompressDataBlob decompresses the data blob if it is encoded with a compressed encoding type,
+// otherwise the data blob is returned as is
+func DecompressDataBlob(data *serialization.DataBlob) (*serialization.DataBlob, error) {
+	if data == nil || !IsCompressedEncoding(data.Encoding) {
+		return data, nil
+	}
+
+	var decompressed []byte
+	var err error
+	switch data.Encoding {
+	case common.EncodingTypeProto3Snappy:
+		decompressed, err = snappy.Decode(nil, data.Data)
+	case common.EncodingTypeProto3Zstd:
+		decompressed, err = zstdDecoder.DecodeAll(data.Data, nil)
+	}
+	if err != nil {
+		return nil, NewDeserializationError(fmt.Sprintf("decompress encoding: \"%v\", error: %v", data.Encoding, err.Error()))
+	}
+	return &serialization.DataBlob{
+		Data:     decompressed,
+		Encoding: getCompressionBaseEncoding(data.Encoding),
+	}, nil
+}
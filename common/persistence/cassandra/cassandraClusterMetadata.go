@@ -25,6 +25,7 @@
 package cassandra
 
 import (
+	"fmt"
 	"net"
 	"strings"
 	"time"
@@ -37,6 +38,7 @@ import (
 	"github.com/temporalio/temporal/common/cassandra"
 	"github.com/temporalio/temporal/common/log"
 	p "github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/persistence/serialization"
 	"github.com/temporalio/temporal/common/service/config"
 )
 
@@ -55,6 +57,35 @@ VALUES(?, ?, ?) IF NOT EXISTS`
 cluster_metadata 
 WHERE metadata_partition = ?`
 
+	// ****** REMOTE_CLUSTERS TABLE ******
+	templateUpsertRemoteCluster = `INSERT INTO 
+remote_clusters (metadata_partition, cluster_name, data, data_encoding) 
+VALUES(?, ?, ?, ?)`
+
+	templateListRemoteClusters = `SELECT data, data_encoding FROM 
+remote_clusters 
+WHERE metadata_partition = ?`
+
+	templateDeleteRemoteCluster = `DELETE FROM 
+remote_clusters 
+WHERE metadata_partition = ? AND cluster_name = ?`
+
+	// ****** DYNAMIC_CONFIG TABLE ******
+	// Revisions are append only, a concurrent writer of the same revision loses.
+	templateInsertDynamicConfigRevision = `INSERT INTO 
+dynamic_config (metadata_partition, name, revision, data, data_encoding) 
+VALUES(?, ?, ?, ?, ?) IF NOT EXISTS`
+
+	// Rows are clustered by name and then by revision descending,
+	// so the first row seen for every name is its latest revision.
+	templateListDynamicConfigValues = `SELECT name, data, data_encoding FROM 
+dynamic_config 
+WHERE metadata_partition = ?`
+
+	templateGetDynamicConfigHistory = `SELECT data, data_encoding FROM 
+dynamic_config 
+WHERE metadata_partition = ? AND name = ?`
+
 	immutablePayloadFieldName = `immutable_data`
 
 	immutableEncodingFieldName = immutablePayloadFieldName + `_encoding`
@@ -178,6 +209,110 @@ func (m *cassandraClusterMetadata) GetImmutableClusterMetadata() (*p.InternalGet
 	}, nil
 }
 
+func (m *cassandraClusterMetadata) UpsertRemoteCluster(request *p.InternalUpsertRemoteClusterRequest) error {
+	query := m.session.Query(templateUpsertRemoteCluster, constMetadataPartition, request.ClusterName,
+		request.RemoteClusterInfo.Data, request.RemoteClusterInfo.Encoding)
+	if err := query.Exec(); err != nil {
+		return convertCommonErrors("UpsertRemoteCluster", err)
+	}
+	return nil
+}
+
+func (m *cassandraClusterMetadata) ListRemoteClusters() (*p.InternalListRemoteClustersResponse, error) {
+	query := m.session.Query(templateListRemoteClusters, constMetadataPartition)
+	iter := query.Iter()
+	if iter == nil {
+		return nil, serviceerror.NewInternal("ListRemoteClusters operation failed.  Not able to create query iterator.")
+	}
+
+	var remoteClusters []*serialization.DataBlob
+	var data []byte
+	var encoding string
+	for iter.Scan(&data, &encoding) {
+		remoteClusters = append(remoteClusters, p.NewDataBlob(data, common.EncodingType(encoding)))
+		data = nil
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, convertCommonErrors("ListRemoteClusters", err)
+	}
+
+	return &p.InternalListRemoteClustersResponse{RemoteClusters: remoteClusters}, nil
+}
+
+func (m *cassandraClusterMetadata) DeleteRemoteCluster(request *p.DeleteRemoteClusterRequest) error {
+	query := m.session.Query(templateDeleteRemoteCluster, constMetadataPartition, request.ClusterName)
+	if err := query.Exec(); err != nil {
+		return convertCommonErrors("DeleteRemoteCluster", err)
+	}
+	return nil
+}
+
+func (m *cassandraClusterMetadata) InsertDynamicConfigRevision(request *p.InternalInsertDynamicConfigRevisionRequest) error {
+	query := m.session.Query(templateInsertDynamicConfigRevision, constMetadataPartition, request.Name,
+		request.Revision, request.Value.Data, request.Value.Encoding)
+
+	previous := make(map[string]interface{})
+	applied, err := query.MapScanCAS(previous)
+	if err != nil {
+		return convertCommonErrors("InsertDynamicConfigRevision", err)
+	}
+	if !applied {
+		return &p.ConditionFailedError{
+			Msg: fmt.Sprintf("Dynamic config %v was concurrently updated, revision %v already exists.", request.Name, request.Revision),
+		}
+	}
+	return nil
+}
+
+func (m *cassandraClusterMetadata) ListDynamicConfigValues() (*p.InternalListDynamicConfigValuesResponse, error) {
+	query := m.session.Query(templateListDynamicConfigValues, constMetadataPartition)
+	iter := query.Iter()
+	if iter == nil {
+		return nil, serviceerror.NewInternal("ListDynamicConfigValues operation failed.  Not able to create query iterator.")
+	}
+
+	var values []*serialization.DataBlob
+	var name, lastName string
+	var data []byte
+	var encoding string
+	for iter.Scan(&name, &data, &encoding) {
+		if name != lastName {
+			values = append(values, p.NewDataBlob(data, common.EncodingType(encoding)))
+			lastName = name
+		}
+		data = nil
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, convertCommonErrors("ListDynamicConfigValues", err)
+	}
+
+	return &p.InternalListDynamicConfigValuesResponse{Values: values}, nil
+}
+
+func (m *cassandraClusterMetadata) GetDynamicConfigHistory(request *p.GetDynamicConfigHistoryRequest) (*p.InternalGetDynamicConfigHistoryResponse, error) {
+	query := m.session.Query(templateGetDynamicConfigHistory, constMetadataPartition, request.Name)
+	iter := query.Iter()
+	if iter == nil {
+		return nil, serviceerror.NewInternal("GetDynamicConfigHistory operation failed.  Not able to create query iterator.")
+	}
+
+	var revisions []*serialization.DataBlob
+	var data []byte
+	var encoding string
+	for iter.Scan(&data, &encoding) {
+		revisions = append(revisions, p.NewDataBlob(data, common.EncodingType(encoding)))
+		data = nil
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, convertCommonErrors("GetDynamicConfigHistory", err)
+	}
+
+	return &p.InternalGetDynamicConfigHistoryResponse{Revisions: revisions}, nil
+}
+
 func (m *cassandraClusterMetadata) GetClusterMembers(request *p.GetClusterMembersRequest) (*p.GetClusterMembersResponse, error) {
 	var queryString strings.Builder
 	var operands []interface{}
@@ -52,7 +52,7 @@ import (
 // Where x is any hexadecimal value, E represents the entity type valid values are:
 // E = {NamespaceID = 1, WorkflowID = 2, RunID = 3}
 // R represents row type in executions table, valid values are:
-// R = {Shard = 1, Execution = 2, Transfer = 3, Timer = 4, Replication = 5}
+// R = {Shard = 1, Execution = 2, Transfer = 3, Timer = 4, Replication = 5, ReplicationDLQ = 6, TransferDLQ = 7, TimerDLQ = 8}
 const (
 	cassandraProtoVersion = 4
 	defaultSessionTimeout = 10 * time.Second
@@ -80,6 +80,14 @@ const (
 	// Row Constants for Replication Task DLQ Row. Source cluster name will be used as WorkflowID.
 	rowTypeDLQNamespaceID = "10000000-6000-f000-f000-000000000000"
 	rowTypeDLQRunID       = "30000000-6000-f000-f000-000000000000"
+	// Row Constants for Transfer Task DLQ Row
+	rowTypeTransferDLQNamespaceID = "10000000-7000-f000-f000-000000000000"
+	rowTypeTransferDLQWorkflowID  = "20000000-7000-f000-f000-000000000000"
+	rowTypeTransferDLQRunID       = "30000000-7000-f000-f000-000000000000"
+	// Row Constants for Timer Task DLQ Row
+	rowTypeTimerDLQNamespaceID = "10000000-8000-f000-f000-000000000000"
+	rowTypeTimerDLQWorkflowID  = "20000000-8000-f000-f000-000000000000"
+	rowTypeTimerDLQRunID       = "30000000-8000-f000-f000-000000000000"
 	// Special TaskId constants
 	rowTypeExecutionTaskID = int64(-10)
 	rowTypeShardTaskID     = int64(-11)
@@ -96,6 +104,8 @@ const (
 	rowTypeTimerTask
 	rowTypeReplicationTask
 	rowTypeDLQ
+	rowTypeTransferDLQ
+	rowTypeTimerDLQ
 )
 
 const (
@@ -517,6 +527,18 @@ workflow_state = ? ` +
 		`and task_id > ? ` +
 		`and task_id <= ?`
 
+	// Timer tasks in DLQ are written with default visibility timestamp and ordered by task ID
+	templateGetTimerTasksFromDLQQuery = `SELECT timer, timer_encoding ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and namespace_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id > ? ` +
+		`and task_id <= ?`
+
 	templateGetReplicationTasksQuery = `SELECT replication, replication_encoding ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
@@ -2734,6 +2756,190 @@ func (d *cassandraPersistence) RangeDeleteReplicationTaskFromDLQ(
 	return nil
 }
 
+func (d *cassandraPersistence) PutTransferTaskToDLQ(request *p.PutTransferTaskToDLQRequest) error {
+	task := request.TaskInfo
+	datablob, err := serialization.TransferTaskInfoToBlob(task)
+	if err != nil {
+		return convertCommonErrors("PutTransferTaskToDLQ", err)
+	}
+
+	query := d.session.Query(templateCreateTransferTaskQuery,
+		d.shardID,
+		rowTypeTransferDLQ,
+		rowTypeTransferDLQNamespaceID,
+		rowTypeTransferDLQWorkflowID,
+		rowTypeTransferDLQRunID,
+		datablob.Data,
+		datablob.Encoding,
+		defaultVisibilityTimestamp,
+		task.GetTaskId())
+
+	err = query.Exec()
+	if err != nil {
+		return convertCommonErrors("PutTransferTaskToDLQ", err)
+	}
+
+	return nil
+}
+
+func (d *cassandraPersistence) GetTransferTasksFromDLQ(
+	request *p.GetTransferTasksFromDLQRequest,
+) (*p.GetTransferTasksFromDLQResponse, error) {
+
+	query := d.session.Query(templateGetTransferTasksQuery,
+		d.shardID,
+		rowTypeTransferDLQ,
+		rowTypeTransferDLQNamespaceID,
+		rowTypeTransferDLQWorkflowID,
+		rowTypeTransferDLQRunID,
+		defaultVisibilityTimestamp,
+		request.ReadLevel,
+		request.MaxReadLevel,
+	).PageSize(request.BatchSize).PageState(request.NextPageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, serviceerror.NewInternal("GetTransferTasksFromDLQ operation failed.  Not able to create query iterator.")
+	}
+
+	response := &p.GetTransferTasksFromDLQResponse{}
+	var data []byte
+	var encoding string
+
+	for iter.Scan(&data, &encoding) {
+		t, err := serialization.TransferTaskInfoFromBlob(data, encoding)
+		if err != nil {
+			return nil, convertCommonErrors("GetTransferTasksFromDLQ", err)
+		}
+
+		response.Tasks = append(response.Tasks, t)
+	}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		return nil, convertCommonErrors("GetTransferTasksFromDLQ", err)
+	}
+
+	return response, nil
+}
+
+func (d *cassandraPersistence) RangeDeleteTransferTaskFromDLQ(
+	request *p.RangeDeleteTransferTaskFromDLQRequest,
+) error {
+
+	query := d.session.Query(templateRangeCompleteTransferTaskQuery,
+		d.shardID,
+		rowTypeTransferDLQ,
+		rowTypeTransferDLQNamespaceID,
+		rowTypeTransferDLQWorkflowID,
+		rowTypeTransferDLQRunID,
+		defaultVisibilityTimestamp,
+		request.ExclusiveBeginTaskID,
+		request.InclusiveEndTaskID,
+	)
+
+	err := query.Exec()
+	if err != nil {
+		return convertCommonErrors("RangeDeleteTransferTaskFromDLQ", err)
+	}
+	return nil
+}
+
+func (d *cassandraPersistence) PutTimerTaskToDLQ(request *p.PutTimerTaskToDLQRequest) error {
+	task := request.TaskInfo
+	datablob, err := serialization.TimerTaskInfoToBlob(task)
+	if err != nil {
+		return convertCommonErrors("PutTimerTaskToDLQ", err)
+	}
+
+	// Timer tasks in DLQ are no longer fired by visibility timestamp,
+	// so use default visibility timestamp and order them by task ID.
+	query := d.session.Query(templateCreateTimerTaskQuery,
+		d.shardID,
+		rowTypeTimerDLQ,
+		rowTypeTimerDLQNamespaceID,
+		rowTypeTimerDLQWorkflowID,
+		rowTypeTimerDLQRunID,
+		datablob.Data,
+		datablob.Encoding,
+		defaultVisibilityTimestamp,
+		task.GetTaskId())
+
+	err = query.Exec()
+	if err != nil {
+		return convertCommonErrors("PutTimerTaskToDLQ", err)
+	}
+
+	return nil
+}
+
+func (d *cassandraPersistence) GetTimerTasksFromDLQ(
+	request *p.GetTimerTasksFromDLQRequest,
+) (*p.GetTimerTasksFromDLQResponse, error) {
+
+	query := d.session.Query(templateGetTimerTasksFromDLQQuery,
+		d.shardID,
+		rowTypeTimerDLQ,
+		rowTypeTimerDLQNamespaceID,
+		rowTypeTimerDLQWorkflowID,
+		rowTypeTimerDLQRunID,
+		defaultVisibilityTimestamp,
+		request.ReadLevel,
+		request.MaxReadLevel,
+	).PageSize(request.BatchSize).PageState(request.NextPageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, serviceerror.NewInternal("GetTimerTasksFromDLQ operation failed.  Not able to create query iterator.")
+	}
+
+	response := &p.GetTimerTasksFromDLQResponse{}
+	var data []byte
+	var encoding string
+
+	for iter.Scan(&data, &encoding) {
+		t, err := serialization.TimerTaskInfoFromBlob(data, encoding)
+		if err != nil {
+			return nil, convertCommonErrors("GetTimerTasksFromDLQ", err)
+		}
+
+		response.Timers = append(response.Timers, t)
+	}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		return nil, convertCommonErrors("GetTimerTasksFromDLQ", err)
+	}
+
+	return response, nil
+}
+
+func (d *cassandraPersistence) RangeDeleteTimerTaskFromDLQ(
+	request *p.RangeDeleteTimerTaskFromDLQRequest,
+) error {
+
+	query := d.session.Query(templateRangeCompleteTransferTaskQuery,
+		d.shardID,
+		rowTypeTimerDLQ,
+		rowTypeTimerDLQNamespaceID,
+		rowTypeTimerDLQWorkflowID,
+		rowTypeTimerDLQRunID,
+		defaultVisibilityTimestamp,
+		request.ExclusiveBeginTaskID,
+		request.InclusiveEndTaskID,
+	)
+
+	err := query.Exec()
+	if err != nil {
+		return convertCommonErrors("RangeDeleteTimerTaskFromDLQ", err)
+	}
+	return nil
+}
+
 func workflowExecutionFromRow(result map[string]interface{}) (*p.InternalWorkflowExecutionInfo, *p.ReplicationState, error) {
 	eiBytes, ok := result["execution"].([]byte)
 	if !ok {
@@ -52,6 +52,9 @@ type (
 		GetNamespaceReplicationQueue() persistence.NamespaceReplicationQueue
 		SetNamespaceReplicationQueue(persistence.NamespaceReplicationQueue)
 
+		GetAuditLogQueue() persistence.AuditLogQueue
+		SetAuditLogQueue(persistence.AuditLogQueue)
+
 		GetShardManager() persistence.ShardManager
 		SetShardManager(persistence.ShardManager)
 
@@ -69,6 +72,7 @@ type (
 		taskManager               persistence.TaskManager
 		visibilityManager         persistence.VisibilityManager
 		namespaceReplicationQueue persistence.NamespaceReplicationQueue
+		auditLogQueue             persistence.AuditLogQueue
 		shardManager              persistence.ShardManager
 		historyManager            persistence.HistoryManager
 		executionManagerFactory   persistence.ExecutionManagerFactory
@@ -109,6 +113,11 @@ func NewBeanFromFactory(
 		return nil, err
 	}
 
+	auditLogQueue, err := factory.NewAuditLogQueue()
+	if err != nil {
+		return nil, err
+	}
+
 	shardMgr, err := factory.NewShardManager()
 	if err != nil {
 		return nil, err
@@ -125,6 +134,7 @@ func NewBeanFromFactory(
 		taskMgr,
 		visibilityMgr,
 		namespaceReplicationQueue,
+		auditLogQueue,
 		shardMgr,
 		historyMgr,
 		factory,
@@ -138,6 +148,7 @@ func NewBean(
 	taskManager persistence.TaskManager,
 	visibilityManager persistence.VisibilityManager,
 	namespaceReplicationQueue persistence.NamespaceReplicationQueue,
+	auditLogQueue persistence.AuditLogQueue,
 	shardManager persistence.ShardManager,
 	historyManager persistence.HistoryManager,
 	executionManagerFactory persistence.ExecutionManagerFactory,
@@ -148,6 +159,7 @@ func NewBean(
 		taskManager:               taskManager,
 		visibilityManager:         visibilityManager,
 		namespaceReplicationQueue: namespaceReplicationQueue,
+		auditLogQueue:             auditLogQueue,
 		shardManager:              shardManager,
 		historyManager:            historyManager,
 		executionManagerFactory:   executionManagerFactory,
@@ -255,6 +267,26 @@ func (s *BeanImpl) SetNamespaceReplicationQueue(
 	s.namespaceReplicationQueue = namespaceReplicationQueue
 }
 
+// GetAuditLogQueue get AuditLogQueue
+func (s *BeanImpl) GetAuditLogQueue() persistence.AuditLogQueue {
+
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.auditLogQueue
+}
+
+// SetAuditLogQueue set AuditLogQueue
+func (s *BeanImpl) SetAuditLogQueue(
+	auditLogQueue persistence.AuditLogQueue,
+) {
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.auditLogQueue = auditLogQueue
+}
+
 // GetShardManager get ShardManager
 func (s *BeanImpl) GetShardManager() persistence.ShardManager {
 
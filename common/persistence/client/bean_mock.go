@@ -199,6 +199,32 @@ func (mr *MockBeanMockRecorder) SetNamespaceReplicationQueue(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNamespaceReplicationQueue", reflect.TypeOf((*MockBean)(nil).SetNamespaceReplicationQueue), arg0)
 }
 
+// GetAuditLogQueue mocks base method.
+func (m *MockBean) GetAuditLogQueue() persistence.AuditLogQueue {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogQueue")
+	ret0, _ := ret[0].(persistence.AuditLogQueue)
+	return ret0
+}
+
+// GetAuditLogQueue indicates an expected call of GetAuditLogQueue.
+func (mr *MockBeanMockRecorder) GetAuditLogQueue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogQueue", reflect.TypeOf((*MockBean)(nil).GetAuditLogQueue))
+}
+
+// SetAuditLogQueue mocks base method.
+func (m *MockBean) SetAuditLogQueue(arg0 persistence.AuditLogQueue) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAuditLogQueue", arg0)
+}
+
+// SetAuditLogQueue indicates an expected call of SetAuditLogQueue.
+func (mr *MockBeanMockRecorder) SetAuditLogQueue(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAuditLogQueue", reflect.TypeOf((*MockBean)(nil).SetAuditLogQueue), arg0)
+}
+
 // GetShardManager mocks base method.
 func (m *MockBean) GetShardManager() persistence.ShardManager {
 	m.ctrl.T.Helper()
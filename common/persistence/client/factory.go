@@ -32,9 +32,9 @@ import (
 	p "github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/persistence/cassandra"
 	"github.com/temporalio/temporal/common/persistence/sql"
-	"github.com/temporalio/temporal/common/quotas"
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 type (
@@ -58,6 +58,8 @@ type (
 		NewVisibilityManager() (p.VisibilityManager, error)
 		// NewNamespaceReplicationQueue returns a new queue for namespace replication
 		NewNamespaceReplicationQueue() (p.NamespaceReplicationQueue, error)
+		// NewAuditLogQueue returns a new queue for audit records
+		NewAuditLogQueue() (p.AuditLogQueue, error)
 		// NewClusterMetadata returns a new manager for cluster specific metadata
 		NewClusterMetadataManager() (p.ClusterMetadataManager, error)
 	}
@@ -91,13 +93,14 @@ type (
 	// Datastore represents a datastore
 	Datastore struct {
 		factory   DataStoreFactory
-		ratelimit quotas.Limiter
+		ratelimit p.RateLimiter
 	}
 	factoryImpl struct {
 		sync.RWMutex
 		config                   *config.Persistence
 		abstractDataStoreFactory AbstractDataStoreFactory
 		metricsClient            metrics.Client
+		tracer                   tracing.Tracer
 		logger                   log.Logger
 		datastores               map[storeType]Datastore
 		clusterName              string
@@ -135,22 +138,49 @@ var storeTypes = []storeType{
 //
 // The objects returned by this factory enforce ratelimit and maxconns according to
 // given configuration. In addition, all objects will emit metrics automatically
+// and the execution, shard, task and history managers will record spans when a
+// tracer is given
 func NewFactory(
 	cfg *config.Persistence,
 	persistenceMaxQPS dynamicconfig.IntPropertyFn,
 	abstractDataStoreFactory AbstractDataStoreFactory,
 	clusterName string,
 	metricsClient metrics.Client,
+	tracer tracing.Tracer,
+	logger log.Logger,
+) Factory {
+	return NewFactoryWithRateLimiterConfig(
+		cfg,
+		p.RateLimiterConfig{MaxQPS: persistenceMaxQPS},
+		abstractDataStoreFactory,
+		clusterName,
+		metricsClient,
+		tracer,
+		logger,
+	)
+}
+
+// NewFactoryWithRateLimiterConfig returns an implementation of factory like NewFactory,
+// the rate limit of each datastore adapts to the latency and error rate of the datastore
+// as configured
+func NewFactoryWithRateLimiterConfig(
+	cfg *config.Persistence,
+	rateLimiterConfig p.RateLimiterConfig,
+	abstractDataStoreFactory AbstractDataStoreFactory,
+	clusterName string,
+	metricsClient metrics.Client,
+	tracer tracing.Tracer,
 	logger log.Logger,
 ) Factory {
 	factory := &factoryImpl{
 		config:                   cfg,
 		abstractDataStoreFactory: abstractDataStoreFactory,
 		metricsClient:            metricsClient,
+		tracer:                   tracer,
 		logger:                   logger,
 		clusterName:              clusterName,
 	}
-	limiters := buildRatelimiters(cfg, persistenceMaxQPS)
+	limiters := buildRatelimiters(cfg, rateLimiterConfig)
 	factory.init(clusterName, limiters)
 	return factory
 }
@@ -168,6 +198,9 @@ func (f *factoryImpl) NewTaskManager() (p.TaskManager, error) {
 	if f.metricsClient != nil {
 		result = p.NewTaskPersistenceMetricsClient(result, f.metricsClient, f.logger)
 	}
+	if f.tracer != nil {
+		result = p.NewTaskPersistenceTracingClient(result, f.tracer)
+	}
 	return result, nil
 }
 
@@ -184,6 +217,9 @@ func (f *factoryImpl) NewShardManager() (p.ShardManager, error) {
 	if f.metricsClient != nil {
 		result = p.NewShardPersistenceMetricsClient(result, f.metricsClient, f.logger)
 	}
+	if f.tracer != nil {
+		result = p.NewShardPersistenceTracingClient(result, f.tracer)
+	}
 	return result, nil
 }
 
@@ -201,6 +237,9 @@ func (f *factoryImpl) NewHistoryManager() (p.HistoryManager, error) {
 	if f.metricsClient != nil {
 		result = p.NewHistoryV2PersistenceMetricsClient(result, f.metricsClient, f.logger)
 	}
+	if f.tracer != nil {
+		result = p.NewHistoryV2PersistenceTracingClient(result, f.tracer)
+	}
 	return result, nil
 }
 
@@ -258,6 +297,9 @@ func (f *factoryImpl) NewExecutionManager(shardID int) (p.ExecutionManager, erro
 	if f.metricsClient != nil {
 		result = p.NewWorkflowExecutionPersistenceMetricsClient(result, f.metricsClient, f.logger)
 	}
+	if f.tracer != nil {
+		result = p.NewWorkflowExecutionPersistenceTracingClient(result, f.tracer)
+	}
 	return result, nil
 }
 
@@ -303,6 +345,23 @@ func (f *factoryImpl) NewNamespaceReplicationQueue() (p.NamespaceReplicationQueu
 	return p.NewNamespaceReplicationQueue(result, f.clusterName, f.metricsClient, f.logger), nil
 }
 
+// NewAuditLogQueue returns a new queue for audit records
+func (f *factoryImpl) NewAuditLogQueue() (p.AuditLogQueue, error) {
+	ds := f.datastores[storeTypeQueue]
+	result, err := ds.factory.NewQueue(p.AuditLogQueueType)
+	if err != nil {
+		return nil, err
+	}
+	if ds.ratelimit != nil {
+		result = p.NewQueuePersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
+	}
+	if f.metricsClient != nil {
+		result = p.NewQueuePersistenceMetricsClient(result, f.metricsClient, f.logger)
+	}
+
+	return p.NewAuditLogQueue(result), nil
+}
+
 // Close closes this factory
 func (f *factoryImpl) Close() {
 	ds := f.datastores[storeTypeExecution]
@@ -319,7 +378,7 @@ func (f *factoryImpl) getCassandraConfig() *config.Cassandra {
 	return cfg.DataStores[cfg.VisibilityStore].Cassandra
 }
 
-func (f *factoryImpl) init(clusterName string, limiters map[string]quotas.Limiter) {
+func (f *factoryImpl) init(clusterName string, limiters map[string]p.RateLimiter) {
 	f.datastores = make(map[storeType]Datastore, len(storeTypes))
 	defaultCfg := f.config.DataStores[f.config.DefaultStore]
 	defaultDataStore := Datastore{ratelimit: limiters[f.config.DefaultStore]}
@@ -354,11 +413,11 @@ func (f *factoryImpl) init(clusterName string, limiters map[string]quotas.Limite
 	f.datastores[storeTypeVisibility] = visibilityDataStore
 }
 
-func buildRatelimiters(cfg *config.Persistence, maxQPS dynamicconfig.IntPropertyFn) map[string]quotas.Limiter {
-	result := make(map[string]quotas.Limiter, len(cfg.DataStores))
+func buildRatelimiters(cfg *config.Persistence, rateLimiterConfig p.RateLimiterConfig) map[string]p.RateLimiter {
+	result := make(map[string]p.RateLimiter, len(cfg.DataStores))
 	for dsName := range cfg.DataStores {
-		if maxQPS != nil && maxQPS() > 0 {
-			result[dsName] = quotas.NewDynamicRateLimiter(func() float64 { return float64(maxQPS()) })
+		if rateLimiterConfig.MaxQPS != nil && rateLimiterConfig.MaxQPS() > 0 {
+			result[dsName] = p.NewRateLimiter(rateLimiterConfig)
 		}
 	}
 	return result
@@ -27,6 +27,9 @@ package persistence
 import (
 	"errors"
 
+	"github.com/gogo/protobuf/types"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
 )
@@ -41,6 +44,12 @@ var (
 
 	// ErrIncompleteMembershipUpsert is used when upserting new cluster membership with missing fields
 	ErrIncompleteMembershipUpsert = errors.New("membership upserts require all fields")
+
+	// ErrIncompleteRemoteClusterUpsert is used when upserting a remote cluster without a cluster name
+	ErrIncompleteRemoteClusterUpsert = errors.New("remote cluster upserts require a cluster name")
+
+	// ErrIncompleteDynamicConfigUpdate is used when updating dynamic config without a key name
+	ErrIncompleteDynamicConfigUpdate = errors.New("dynamic config updates require a key name")
 )
 
 type (
@@ -111,6 +120,130 @@ func (m *clusterMetadataManagerImpl) GetImmutableClusterMetadata() (*GetImmutabl
 	return &GetImmutableClusterMetadataResponse{*icm}, nil
 }
 
+func (m *clusterMetadataManagerImpl) UpsertRemoteCluster(request *UpsertRemoteClusterRequest) error {
+	if request.ClusterName == "" {
+		return ErrIncompleteRemoteClusterUpsert
+	}
+
+	info, err := m.serializer.SerializeRemoteClusterInfo(&request.RemoteClusterInfo, clusterMetadataEncoding)
+	if err != nil {
+		return err
+	}
+
+	return m.persistence.UpsertRemoteCluster(&InternalUpsertRemoteClusterRequest{
+		ClusterName:       request.ClusterName,
+		RemoteClusterInfo: info,
+	})
+}
+
+func (m *clusterMetadataManagerImpl) ListRemoteClusters() (*ListRemoteClustersResponse, error) {
+	resp, err := m.persistence.ListRemoteClusters()
+	if err != nil {
+		return nil, err
+	}
+
+	remoteClusters := make([]*persistenceblobs.RemoteClusterInfo, 0, len(resp.RemoteClusters))
+	for _, blob := range resp.RemoteClusters {
+		info, err := m.serializer.DeserializeRemoteClusterInfo(blob)
+		if err != nil {
+			return nil, err
+		}
+		if info != nil {
+			remoteClusters = append(remoteClusters, info)
+		}
+	}
+
+	return &ListRemoteClustersResponse{RemoteClusters: remoteClusters}, nil
+}
+
+func (m *clusterMetadataManagerImpl) DeleteRemoteCluster(request *DeleteRemoteClusterRequest) error {
+	return m.persistence.DeleteRemoteCluster(request)
+}
+
+func (m *clusterMetadataManagerImpl) UpdateDynamicConfig(request *UpdateDynamicConfigRequest) (*UpdateDynamicConfigResponse, error) {
+	if request.Name == "" {
+		return nil, ErrIncompleteDynamicConfigUpdate
+	}
+
+	history, err := m.persistence.GetDynamicConfigHistory(&GetDynamicConfigHistoryRequest{Name: request.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	revision := int64(1)
+	if len(history.Revisions) > 0 {
+		latest, err := m.serializer.DeserializeDynamicConfigValue(history.Revisions[0])
+		if err != nil {
+			return nil, err
+		}
+		revision = latest.GetRevision() + 1
+	}
+
+	value, err := m.serializer.SerializeDynamicConfigValue(&persistenceblobs.DynamicConfigValue{
+		Name:       request.Name,
+		Revision:   revision,
+		Values:     request.Values,
+		Deleted:    request.Deleted,
+		UpdatedBy:  request.UpdatedBy,
+		Reason:     request.Reason,
+		UpdateTime: types.TimestampNow(),
+	}, clusterMetadataEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	// A concurrent update of the same key surfaces as ConditionFailedError
+	if err := m.persistence.InsertDynamicConfigRevision(&InternalInsertDynamicConfigRevisionRequest{
+		Name:     request.Name,
+		Revision: revision,
+		Value:    value,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &UpdateDynamicConfigResponse{Revision: revision}, nil
+}
+
+func (m *clusterMetadataManagerImpl) ListDynamicConfigValues() (*ListDynamicConfigValuesResponse, error) {
+	resp, err := m.persistence.ListDynamicConfigValues()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]*persistenceblobs.DynamicConfigValue, 0, len(resp.Values))
+	for _, blob := range resp.Values {
+		value, err := m.serializer.DeserializeDynamicConfigValue(blob)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil && !value.GetDeleted() {
+			values = append(values, value)
+		}
+	}
+
+	return &ListDynamicConfigValuesResponse{Values: values}, nil
+}
+
+func (m *clusterMetadataManagerImpl) GetDynamicConfigHistory(request *GetDynamicConfigHistoryRequest) (*GetDynamicConfigHistoryResponse, error) {
+	resp, err := m.persistence.GetDynamicConfigHistory(request)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*persistenceblobs.DynamicConfigValue, 0, len(resp.Revisions))
+	for _, blob := range resp.Revisions {
+		value, err := m.serializer.DeserializeDynamicConfigValue(blob)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			revisions = append(revisions, value)
+		}
+	}
+
+	return &GetDynamicConfigHistoryResponse{Revisions: revisions}, nil
+}
+
 func (m *clusterMetadataManagerImpl) GetClusterMembers(request *GetClusterMembersRequest) (*GetClusterMembersResponse, error) {
 	return m.persistence.GetClusterMembers(request)
 }
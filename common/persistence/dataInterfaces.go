@@ -47,6 +47,7 @@ import (
 	"github.com/temporalio/temporal/common/checksum"
 	"github.com/temporalio/temporal/common/persistence/serialization"
 	"github.com/temporalio/temporal/common/primitives"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 const (
@@ -68,6 +69,7 @@ type QueueType int
 // Negative numbers are reserved for DLQ
 const (
 	NamespaceReplicationQueueType QueueType = iota + 1
+	AuditLogQueueType
 )
 
 // Create Workflow Execution Mode
@@ -264,6 +266,8 @@ type (
 		BranchToken            []byte
 		// Cron
 		CronSchedule string
+		// Completion callbacks
+		CompletionCallbacks []*executiongenpb.CompletionCallbackInfo
 	}
 
 	// ExecutionStats is the statistics about workflow execution
@@ -346,6 +350,13 @@ type (
 		Version             int64
 	}
 
+	// WorkflowCompletionCallbackTask identifies a transfer task for delivering workflow completion callbacks
+	WorkflowCompletionCallbackTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		Version             int64
+	}
+
 	// DeleteHistoryEventTask identifies a timer task for deletion of history events of completed execution.
 	DeleteHistoryEventTask struct {
 		VisibilityTimestamp time.Time
@@ -592,6 +603,9 @@ type (
 		PreviousLastWriteVersion int64
 
 		NewWorkflowSnapshot WorkflowSnapshot
+
+		CallerType CallerType   // optional, defaults to CallerTypeAPI
+		ParentSpan tracing.Span // optional, the call is only traced under a parent span
 	}
 
 	// CreateWorkflowExecutionResponse is the response to CreateWorkflowExecutionRequest
@@ -602,6 +616,8 @@ type (
 	GetWorkflowExecutionRequest struct {
 		NamespaceID string
 		Execution   commonpb.WorkflowExecution
+		CallerType  CallerType   // optional, defaults to CallerTypeAPI
+		ParentSpan  tracing.Span // optional, the call is only traced under a parent span
 	}
 
 	// GetWorkflowExecutionResponse is the response to GetworkflowExecutionRequest
@@ -648,6 +664,9 @@ type (
 		NewWorkflowSnapshot *WorkflowSnapshot
 
 		Encoding common.EncodingType // optional binary encoding type
+
+		CallerType CallerType   // optional, defaults to CallerTypeAPI
+		ParentSpan tracing.Span // optional, the call is only traced under a parent span
 	}
 
 	// ConflictResolveWorkflowExecutionRequest is used to reset workflow execution state for a single run
@@ -670,6 +689,9 @@ type (
 		CurrentWorkflowCAS *CurrentWorkflowCAS
 
 		Encoding common.EncodingType // optional binary encoding type
+
+		CallerType CallerType   // optional, defaults to CallerTypeAPI
+		ParentSpan tracing.Span // optional, the call is only traced under a parent span
 	}
 
 	// CurrentWorkflowCAS represent a compare and swap on current record
@@ -699,6 +721,9 @@ type (
 		NewWorkflowSnapshot WorkflowSnapshot
 
 		Encoding common.EncodingType // optional binary encoding type
+
+		CallerType CallerType   // optional, defaults to CallerTypeAPI
+		ParentSpan tracing.Span // optional, the call is only traced under a parent span
 	}
 
 	// WorkflowEvents is used as generic workflow history events transaction container
@@ -853,6 +878,46 @@ type (
 	// GetReplicationTasksFromDLQResponse is the response for GetReplicationTasksFromDLQ
 	GetReplicationTasksFromDLQResponse = GetReplicationTasksResponse
 
+	// PutTransferTaskToDLQRequest is used to put a transfer task to dlq
+	PutTransferTaskToDLQRequest struct {
+		TaskInfo *persistenceblobs.TransferTaskInfo
+	}
+
+	// GetTransferTasksFromDLQRequest is used to get transfer tasks from dlq
+	GetTransferTasksFromDLQRequest = GetTransferTasksRequest
+
+	// GetTransferTasksFromDLQResponse is the response for GetTransferTasksFromDLQ
+	GetTransferTasksFromDLQResponse = GetTransferTasksResponse
+
+	// RangeDeleteTransferTaskFromDLQRequest is used to delete transfer tasks from DLQ
+	RangeDeleteTransferTaskFromDLQRequest struct {
+		ExclusiveBeginTaskID int64
+		InclusiveEndTaskID   int64
+	}
+
+	// PutTimerTaskToDLQRequest is used to put a timer task to dlq
+	PutTimerTaskToDLQRequest struct {
+		TaskInfo *persistenceblobs.TimerTaskInfo
+	}
+
+	// GetTimerTasksFromDLQRequest is used to get timer tasks from dlq.
+	// Timer tasks in dlq are ordered by task ID instead of visibility timestamp.
+	GetTimerTasksFromDLQRequest struct {
+		ReadLevel     int64
+		MaxReadLevel  int64
+		BatchSize     int
+		NextPageToken []byte
+	}
+
+	// GetTimerTasksFromDLQResponse is the response for GetTimerTasksFromDLQ
+	GetTimerTasksFromDLQResponse = GetTimerIndexTasksResponse
+
+	// RangeDeleteTimerTaskFromDLQRequest is used to delete timer tasks from DLQ
+	RangeDeleteTimerTaskFromDLQRequest struct {
+		ExclusiveBeginTaskID int64
+		InclusiveEndTaskID   int64
+	}
+
 	// RangeCompleteTimerTaskRequest is used to complete a range of tasks in the timer task queue
 	RangeCompleteTimerTaskRequest struct {
 		InclusiveBeginTimestamp time.Time
@@ -872,6 +937,7 @@ type (
 		TaskType     tasklistpb.TaskListType
 		TaskListKind tasklistpb.TaskListKind
 		RangeID      int64
+		CallerType   CallerType // optional, defaults to CallerTypeAPI
 	}
 
 	// LeaseTaskListResponse is response to LeaseTaskListRequest
@@ -883,6 +949,7 @@ type (
 	UpdateTaskListRequest struct {
 		RangeID      int64
 		TaskListInfo *persistenceblobs.TaskListInfo
+		CallerType   CallerType // optional, defaults to CallerTypeAPI
 	}
 
 	// UpdateTaskListResponse is the response to UpdateTaskList
@@ -911,6 +978,7 @@ type (
 	CreateTasksRequest struct {
 		TaskListInfo *PersistedTaskListInfo
 		Tasks        []*persistenceblobs.AllocatedTaskInfo
+		CallerType   CallerType // optional, defaults to CallerTypeAPI
 	}
 
 	// CreateTasksResponse is the response to CreateTasksRequest
@@ -930,6 +998,7 @@ type (
 		ReadLevel    int64  // range exclusive
 		MaxReadLevel *int64 // optional: range inclusive when specified
 		BatchSize    int
+		CallerType   CallerType // optional, defaults to CallerTypeAPI
 	}
 
 	// GetTasksResponse is the response to GetTasksRequests
@@ -1106,12 +1175,16 @@ type (
 		Encoding common.EncodingType
 		// The shard to get history node data
 		ShardID *int
+		// optional, the call is only traced under a parent span
+		ParentSpan tracing.Span
 	}
 
 	// AppendHistoryNodesResponse is a response to AppendHistoryNodesRequest
 	AppendHistoryNodesResponse struct {
 		// the size of the event data that has been appended
 		Size int
+		// the size of the event data as stored, which is smaller than Size if a compressed encoding is used
+		EncodedSize int
 	}
 
 	// ReadHistoryBranchRequest is used to read a history branch
@@ -1267,6 +1340,53 @@ type (
 		persistenceblobs.ImmutableClusterMetadata
 	}
 
+	// UpsertRemoteClusterRequest is the request to UpsertRemoteCluster
+	UpsertRemoteClusterRequest struct {
+		persistenceblobs.RemoteClusterInfo
+	}
+
+	// ListRemoteClustersResponse is the response to ListRemoteClusters
+	ListRemoteClustersResponse struct {
+		RemoteClusters []*persistenceblobs.RemoteClusterInfo
+	}
+
+	// DeleteRemoteClusterRequest is the request to DeleteRemoteCluster
+	DeleteRemoteClusterRequest struct {
+		ClusterName string
+	}
+
+	// UpdateDynamicConfigRequest is the request to UpdateDynamicConfig
+	UpdateDynamicConfigRequest struct {
+		Name string
+		// Values is the YAML encoded list of constrained values for the key
+		Values    string
+		Deleted   bool
+		UpdatedBy string
+		Reason    string
+	}
+
+	// UpdateDynamicConfigResponse is the response to UpdateDynamicConfig
+	UpdateDynamicConfigResponse struct {
+		Revision int64
+	}
+
+	// ListDynamicConfigValuesResponse is the response to ListDynamicConfigValues
+	ListDynamicConfigValuesResponse struct {
+		// Latest revision of every key that is not deleted
+		Values []*persistenceblobs.DynamicConfigValue
+	}
+
+	// GetDynamicConfigHistoryRequest is the request to GetDynamicConfigHistory
+	GetDynamicConfigHistoryRequest struct {
+		Name string
+	}
+
+	// GetDynamicConfigHistoryResponse is the response to GetDynamicConfigHistory
+	GetDynamicConfigHistoryResponse struct {
+		// All revisions of the key, latest first
+		Revisions []*persistenceblobs.DynamicConfigValue
+	}
+
 	// GetClusterMembersRequest is the response to GetClusterMembers
 	GetClusterMembersRequest struct {
 		LastHeartbeatWithin time.Duration
@@ -1343,6 +1463,9 @@ type (
 		GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
 		CompleteTransferTask(request *CompleteTransferTaskRequest) error
 		RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error
+		PutTransferTaskToDLQ(request *PutTransferTaskToDLQRequest) error
+		GetTransferTasksFromDLQ(request *GetTransferTasksFromDLQRequest) (*GetTransferTasksFromDLQResponse, error)
+		RangeDeleteTransferTaskFromDLQ(request *RangeDeleteTransferTaskFromDLQRequest) error
 
 		// Replication task related methods
 		GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error)
@@ -1358,6 +1481,9 @@ type (
 		GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
 		CompleteTimerTask(request *CompleteTimerTaskRequest) error
 		RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error
+		PutTimerTaskToDLQ(request *PutTimerTaskToDLQRequest) error
+		GetTimerTasksFromDLQ(request *GetTimerTasksFromDLQRequest) (*GetTimerTasksFromDLQResponse, error)
+		RangeDeleteTimerTaskFromDLQ(request *RangeDeleteTimerTaskFromDLQRequest) error
 
 		// Scan operations
 		ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*ListConcreteExecutionsResponse, error)
@@ -1441,6 +1567,12 @@ type (
 		GetName() string
 		InitializeImmutableClusterMetadata(request *InitializeImmutableClusterMetadataRequest) (*InitializeImmutableClusterMetadataResponse, error)
 		GetImmutableClusterMetadata() (*GetImmutableClusterMetadataResponse, error)
+		UpsertRemoteCluster(request *UpsertRemoteClusterRequest) error
+		ListRemoteClusters() (*ListRemoteClustersResponse, error)
+		DeleteRemoteCluster(request *DeleteRemoteClusterRequest) error
+		UpdateDynamicConfig(request *UpdateDynamicConfigRequest) (*UpdateDynamicConfigResponse, error)
+		ListDynamicConfigValues() (*ListDynamicConfigValuesResponse, error)
+		GetDynamicConfigHistory(request *GetDynamicConfigHistoryRequest) (*GetDynamicConfigHistoryResponse, error)
 		GetClusterMembers(request *GetClusterMembersRequest) (*GetClusterMembersResponse, error)
 		UpsertClusterMembership(request *UpsertClusterMembershipRequest) error
 		PruneClusterMembership(request *PruneClusterMembershipRequest) error
@@ -2050,6 +2182,41 @@ func (u *StartChildExecutionTask) SetVisibilityTimestamp(timestamp time.Time) {
 	u.VisibilityTimestamp = timestamp
 }
 
+// GetType returns the type of the workflow completion callback transfer task
+func (u *WorkflowCompletionCallbackTask) GetType() commongenpb.TaskType {
+	return commongenpb.TaskType_TransferWorkflowCompletionCallback
+}
+
+// GetVersion returns the version of the workflow completion callback transfer task
+func (u *WorkflowCompletionCallbackTask) GetVersion() int64 {
+	return u.Version
+}
+
+// SetVersion returns the version of the workflow completion callback transfer task
+func (u *WorkflowCompletionCallbackTask) SetVersion(version int64) {
+	u.Version = version
+}
+
+// GetTaskID returns the sequence ID of the workflow completion callback transfer task
+func (u *WorkflowCompletionCallbackTask) GetTaskID() int64 {
+	return u.TaskID
+}
+
+// SetTaskID sets the sequence ID of the workflow completion callback transfer task
+func (u *WorkflowCompletionCallbackTask) SetTaskID(id int64) {
+	u.TaskID = id
+}
+
+// GetVisibilityTimestamp get the visibility timestamp
+func (u *WorkflowCompletionCallbackTask) GetVisibilityTimestamp() time.Time {
+	return u.VisibilityTimestamp
+}
+
+// SetVisibilityTimestamp set the visibility timestamp
+func (u *WorkflowCompletionCallbackTask) SetVisibilityTimestamp(timestamp time.Time) {
+	u.VisibilityTimestamp = timestamp
+}
+
 // GetType returns the type of the history replication task
 func (a *HistoryReplicationTask) GetType() commongenpb.TaskType {
 	return commongenpb.TaskType_ReplicationHistory
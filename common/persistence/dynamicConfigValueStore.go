@@ -0,0 +1,70 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+const dynamicConfigUpdatedBy = "dynamicconfig"
+
+type (
+	// dynamicConfigValueStore exposes the dynamic config values kept by ClusterMetadataManager
+	// to the persistence based dynamic config client
+	dynamicConfigValueStore struct {
+		clusterMetadataManager ClusterMetadataManager
+	}
+)
+
+var _ dynamicconfig.ValueStore = (*dynamicConfigValueStore)(nil)
+
+// NewDynamicConfigValueStore returns a dynamicconfig.ValueStore backed by ClusterMetadataManager
+func NewDynamicConfigValueStore(clusterMetadataManager ClusterMetadataManager) dynamicconfig.ValueStore {
+	return &dynamicConfigValueStore{
+		clusterMetadataManager: clusterMetadataManager,
+	}
+}
+
+func (s *dynamicConfigValueStore) ListValues() (map[string]string, error) {
+	resp, err := s.clusterMetadataManager.ListDynamicConfigValues()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(resp.Values))
+	for _, value := range resp.Values {
+		values[value.GetName()] = value.GetValues()
+	}
+	return values, nil
+}
+
+func (s *dynamicConfigValueStore) UpdateValues(name string, values string) error {
+	_, err := s.clusterMetadataManager.UpdateDynamicConfig(&UpdateDynamicConfigRequest{
+		Name:      name,
+		Values:    values,
+		UpdatedBy: dynamicConfigUpdatedBy,
+	})
+	return err
+}
@@ -30,7 +30,6 @@ import (
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
 	p "github.com/temporalio/temporal/common/persistence"
-	"github.com/temporalio/temporal/common/quotas"
 	"github.com/temporalio/temporal/common/service/config"
 )
 
@@ -46,11 +45,7 @@ func NewESVisibilityManager(indexName string, esClient es.Client, config *config
 	if config != nil {
 		// wrap with rate limiter
 		if config.MaxQPS != nil && config.MaxQPS() != 0 {
-			esRateLimiter := quotas.NewDynamicRateLimiter(
-				func() float64 {
-					return float64(config.MaxQPS())
-				},
-			)
+			esRateLimiter := p.NewRateLimiter(p.RateLimiterConfig{MaxQPS: config.MaxQPS})
 			visibilityFromES = p.NewVisibilityPersistenceRateLimitedClient(visibilityFromES, esRateLimiter, log)
 		}
 		if config.EnableSampling != nil && config.EnableSampling() {
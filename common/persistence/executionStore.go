@@ -182,6 +182,7 @@ func (m *executionManagerImpl) DeserializeExecutionInfo(
 		AutoResetPoints:                    autoResetPoints,
 		SearchAttributes:                   info.SearchAttributes,
 		Memo:                               info.Memo,
+		CompletionCallbacks:                info.CompletionCallbacks,
 	}
 	newStats := &ExecutionStats{
 		HistorySize: info.HistorySize,
@@ -499,6 +500,7 @@ func (m *executionManagerImpl) SerializeExecutionInfo(
 		CronSchedule:                       info.CronSchedule,
 		Memo:                               info.Memo,
 		SearchAttributes:                   info.SearchAttributes,
+		CompletionCallbacks:                info.CompletionCallbacks,
 
 		// attributes which are not related to mutable state
 		HistorySize: stats.HistorySize,
@@ -863,6 +865,42 @@ func (m *executionManagerImpl) RangeDeleteReplicationTaskFromDLQ(
 	return m.persistence.RangeDeleteReplicationTaskFromDLQ(request)
 }
 
+func (m *executionManagerImpl) PutTransferTaskToDLQ(
+	request *PutTransferTaskToDLQRequest,
+) error {
+	return m.persistence.PutTransferTaskToDLQ(request)
+}
+
+func (m *executionManagerImpl) GetTransferTasksFromDLQ(
+	request *GetTransferTasksFromDLQRequest,
+) (*GetTransferTasksFromDLQResponse, error) {
+	return m.persistence.GetTransferTasksFromDLQ(request)
+}
+
+func (m *executionManagerImpl) RangeDeleteTransferTaskFromDLQ(
+	request *RangeDeleteTransferTaskFromDLQRequest,
+) error {
+	return m.persistence.RangeDeleteTransferTaskFromDLQ(request)
+}
+
+func (m *executionManagerImpl) PutTimerTaskToDLQ(
+	request *PutTimerTaskToDLQRequest,
+) error {
+	return m.persistence.PutTimerTaskToDLQ(request)
+}
+
+func (m *executionManagerImpl) GetTimerTasksFromDLQ(
+	request *GetTimerTasksFromDLQRequest,
+) (*GetTimerTasksFromDLQResponse, error) {
+	return m.persistence.GetTimerTasksFromDLQ(request)
+}
+
+func (m *executionManagerImpl) RangeDeleteTimerTaskFromDLQ(
+	request *RangeDeleteTimerTaskFromDLQRequest,
+) error {
+	return m.persistence.RangeDeleteTimerTaskFromDLQ(request)
+}
+
 // Timer related methods.
 func (m *executionManagerImpl) GetTimerTask(
 	request *GetTimerTaskRequest,
@@ -196,11 +196,16 @@ func (m *historyV2ManagerImpl) AppendHistoryNodes(
 	}
 
 	// nodeID will be the first eventID
-	blob, err := m.historySerializer.SerializeBatchEvents(request.Events, request.Encoding)
+	blob, err := m.historySerializer.SerializeBatchEvents(request.Events, getCompressionBaseEncoding(request.Encoding))
 	if err != nil {
 		return nil, err
 	}
 	size := len(blob.Data)
+	blob, err = compressDataBlob(blob, request.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	encodedSize := len(blob.Data)
 	sizeLimit := m.transactionSizeLimit()
 	if size > sizeLimit {
 		return nil, &TransactionSizeLimitError{
@@ -225,7 +230,8 @@ func (m *historyV2ManagerImpl) AppendHistoryNodes(
 	err = m.persistence.AppendHistoryNodes(req)
 
 	return &AppendHistoryNodesResponse{
-		Size: size,
+		Size:        size,
+		EncodedSize: encodedSize,
 	}, err
 }
 
@@ -387,9 +393,16 @@ func (m *historyV2ManagerImpl) readRawHistoryBranch(
 		return nil, nil, 0, nil, serviceerror.NewNotFound("Workflow execution history not found.")
 	}
 
-	dataBlobs := resp.History
+	// history nodes may be stored with compressed encodings, decompress them here
+	// so callers of raw history always get back blobs with uncompressed encodings
+	dataBlobs := make([]*serialization.DataBlob, 0, len(resp.History))
 	dataSize := 0
 	for _, dataBlob := range resp.History {
+		dataBlob, err := DecompressDataBlob(dataBlob)
+		if err != nil {
+			return nil, nil, 0, nil, err
+		}
+		dataBlobs = append(dataBlobs, dataBlob)
 		dataSize += len(dataBlob.Data)
 	}
 
@@ -75,6 +75,14 @@ type (
 		// Initialize immutable metadata for the cluster. Takes no action if already initialized.
 		InitializeImmutableClusterMetadata(request *InternalInitializeImmutableClusterMetadataRequest) (*InternalInitializeImmutableClusterMetadataResponse, error)
 		GetImmutableClusterMetadata() (*InternalGetImmutableClusterMetadataResponse, error)
+		// Remote cluster APIs
+		UpsertRemoteCluster(request *InternalUpsertRemoteClusterRequest) error
+		ListRemoteClusters() (*InternalListRemoteClustersResponse, error)
+		DeleteRemoteCluster(request *DeleteRemoteClusterRequest) error
+		// Dynamic config APIs
+		InsertDynamicConfigRevision(request *InternalInsertDynamicConfigRevisionRequest) error
+		ListDynamicConfigValues() (*InternalListDynamicConfigValuesResponse, error)
+		GetDynamicConfigHistory(request *GetDynamicConfigHistoryRequest) (*InternalGetDynamicConfigHistoryResponse, error)
 		// Membership APIs
 		GetClusterMembers(request *GetClusterMembersRequest) (*GetClusterMembersResponse, error)
 		UpsertClusterMembership(request *UpsertClusterMembershipRequest) error
@@ -101,6 +109,9 @@ type (
 		GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
 		CompleteTransferTask(request *CompleteTransferTaskRequest) error
 		RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error
+		PutTransferTaskToDLQ(request *PutTransferTaskToDLQRequest) error
+		GetTransferTasksFromDLQ(request *GetTransferTasksFromDLQRequest) (*GetTransferTasksFromDLQResponse, error)
+		RangeDeleteTransferTaskFromDLQ(request *RangeDeleteTransferTaskFromDLQRequest) error
 
 		// Replication task related methods
 		GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error)
@@ -116,6 +127,9 @@ type (
 		GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error)
 		CompleteTimerTask(request *CompleteTimerTaskRequest) error
 		RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error
+		PutTimerTaskToDLQ(request *PutTimerTaskToDLQRequest) error
+		GetTimerTasksFromDLQ(request *GetTimerTasksFromDLQRequest) (*GetTimerTasksFromDLQResponse, error)
+		RangeDeleteTimerTaskFromDLQ(request *RangeDeleteTimerTaskFromDLQRequest) error
 
 		// Scan related methods
 		ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*InternalListConcreteExecutionsResponse, error)
@@ -259,6 +273,7 @@ type (
 		CronSchedule           string
 		Memo                   map[string]*commonpb.Payload
 		SearchAttributes       map[string]*commonpb.Payload
+		CompletionCallbacks    []*executiongenpb.CompletionCallbackInfo
 
 		// attributes which are not related to mutable state at all
 		HistorySize int64
@@ -681,6 +696,39 @@ type (
 		ImmutableClusterMetadata *serialization.DataBlob
 	}
 
+	// InternalUpsertRemoteClusterRequest is the request to UpsertRemoteCluster
+	InternalUpsertRemoteClusterRequest struct {
+		ClusterName string
+		// Serialized RemoteClusterInfo to persist.
+		RemoteClusterInfo *serialization.DataBlob
+	}
+
+	// InternalListRemoteClustersResponse is the response to ListRemoteClusters
+	InternalListRemoteClustersResponse struct {
+		// Serialized RemoteClusterInfo of all remote clusters.
+		RemoteClusters []*serialization.DataBlob
+	}
+
+	// InternalInsertDynamicConfigRevisionRequest is the request to InsertDynamicConfigRevision
+	InternalInsertDynamicConfigRevisionRequest struct {
+		Name     string
+		Revision int64
+		// Serialized DynamicConfigValue to persist.
+		Value *serialization.DataBlob
+	}
+
+	// InternalListDynamicConfigValuesResponse is the response to ListDynamicConfigValues
+	InternalListDynamicConfigValuesResponse struct {
+		// Serialized DynamicConfigValue of the latest revision of every key.
+		Values []*serialization.DataBlob
+	}
+
+	// InternalGetDynamicConfigHistoryResponse is the response to GetDynamicConfigHistory
+	InternalGetDynamicConfigHistoryResponse struct {
+		// Serialized DynamicConfigValue of all revisions of a key, latest first.
+		Revisions []*serialization.DataBlob
+	}
+
 	// InternalUpsertClusterMembershipRequest is the request to UpsertClusterMembership
 	InternalUpsertClusterMembershipRequest struct {
 		ClusterMember
@@ -780,6 +828,7 @@ func InternalWorkflowExecutionInfoToProto(executionInfo *InternalWorkflowExecuti
 		AutoResetPointsEncoding:                 executionInfo.AutoResetPoints.GetEncoding().String(),
 		SearchAttributes:                        executionInfo.SearchAttributes,
 		Memo:                                    executionInfo.Memo,
+		CompletionCallbacks:                     executionInfo.CompletionCallbacks,
 	}
 
 	if !executionInfo.ExpirationTime.IsZero() {
@@ -867,6 +916,7 @@ func ProtoWorkflowExecutionToPartialInternalExecution(info *persistenceblobs.Wor
 		NonRetryableErrorTypes:             info.GetRetryNonRetryableErrorTypes(),
 		SearchAttributes:                   info.GetSearchAttributes(),
 		Memo:                               info.GetMemo(),
+		CompletionCallbacks:                info.GetCompletionCallbacks(),
 	}
 
 	if info.GetRetryExpirationTimeNanos() != 0 {
@@ -25,6 +25,8 @@
 package persistence
 
 import (
+	"time"
+
 	"go.temporal.io/temporal-proto/serviceerror"
 
 	"github.com/temporalio/temporal/common/log"
@@ -513,6 +515,102 @@ func (p *workflowExecutionPersistenceClient) RangeDeleteReplicationTaskFromDLQ(
 	return nil
 }
 
+func (p *workflowExecutionPersistenceClient) PutTransferTaskToDLQ(
+	request *PutTransferTaskToDLQRequest,
+) error {
+	p.metricClient.IncCounter(metrics.PersistencePutTransferTaskToDLQScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistencePutTransferTaskToDLQScope, metrics.PersistenceLatency)
+	err := p.persistence.PutTransferTaskToDLQ(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistencePutTransferTaskToDLQScope, err)
+	}
+
+	return err
+}
+
+func (p *workflowExecutionPersistenceClient) GetTransferTasksFromDLQ(
+	request *GetTransferTasksFromDLQRequest,
+) (*GetTransferTasksFromDLQResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetTransferTasksFromDLQScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetTransferTasksFromDLQScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetTransferTasksFromDLQ(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetTransferTasksFromDLQScope, err)
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) RangeDeleteTransferTaskFromDLQ(
+	request *RangeDeleteTransferTaskFromDLQRequest,
+) error {
+	p.metricClient.IncCounter(metrics.PersistenceRangeDeleteTransferTaskFromDLQScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceRangeDeleteTransferTaskFromDLQScope, metrics.PersistenceLatency)
+	err := p.persistence.RangeDeleteTransferTaskFromDLQ(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceRangeDeleteTransferTaskFromDLQScope, err)
+	}
+
+	return err
+}
+
+func (p *workflowExecutionPersistenceClient) PutTimerTaskToDLQ(
+	request *PutTimerTaskToDLQRequest,
+) error {
+	p.metricClient.IncCounter(metrics.PersistencePutTimerTaskToDLQScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistencePutTimerTaskToDLQScope, metrics.PersistenceLatency)
+	err := p.persistence.PutTimerTaskToDLQ(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistencePutTimerTaskToDLQScope, err)
+	}
+
+	return err
+}
+
+func (p *workflowExecutionPersistenceClient) GetTimerTasksFromDLQ(
+	request *GetTimerTasksFromDLQRequest,
+) (*GetTimerTasksFromDLQResponse, error) {
+	p.metricClient.IncCounter(metrics.PersistenceGetTimerTasksFromDLQScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceGetTimerTasksFromDLQScope, metrics.PersistenceLatency)
+	response, err := p.persistence.GetTimerTasksFromDLQ(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceGetTimerTasksFromDLQScope, err)
+	}
+
+	return response, err
+}
+
+func (p *workflowExecutionPersistenceClient) RangeDeleteTimerTaskFromDLQ(
+	request *RangeDeleteTimerTaskFromDLQRequest,
+) error {
+	p.metricClient.IncCounter(metrics.PersistenceRangeDeleteTimerTaskFromDLQScope, metrics.PersistenceRequests)
+
+	sw := p.metricClient.StartTimer(metrics.PersistenceRangeDeleteTimerTaskFromDLQScope, metrics.PersistenceLatency)
+	err := p.persistence.RangeDeleteTimerTaskFromDLQ(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.PersistenceRangeDeleteTimerTaskFromDLQScope, err)
+	}
+
+	return err
+}
+
 func (p *workflowExecutionPersistenceClient) GetTimerTask(request *GetTimerTaskRequest) (*GetTimerTaskResponse, error) {
 	p.metricClient.IncCounter(metrics.PersistenceGetTimerTaskScope, metrics.PersistenceRequests)
 
@@ -1104,6 +1202,14 @@ func (p *historyV2PersistenceClient) AppendHistoryNodes(request *AppendHistoryNo
 	if err != nil {
 		p.updateErrorMetric(metrics.PersistenceAppendHistoryNodesScope, err)
 	}
+	if resp != nil && resp.EncodedSize > 0 {
+		scope := p.metricClient.Scope(metrics.PersistenceAppendHistoryNodesScope, metrics.EncodingTag(request.Encoding.String()))
+		scope.RecordTimer(metrics.PersistenceHistoryBlobSize, time.Duration(resp.Size))
+		scope.RecordTimer(metrics.PersistenceHistoryBlobEncodedSize, time.Duration(resp.EncodedSize))
+		// the compression ratio is the rate of the uncompressed bytes over the rate of the encoded bytes
+		scope.AddCounter(metrics.PersistenceHistoryBlobBytes, int64(resp.Size))
+		scope.AddCounter(metrics.PersistenceHistoryBlobEncodedBytes, int64(resp.EncodedSize))
+	}
 	return resp, err
 }
 
@@ -1403,6 +1509,90 @@ func (c *clusterMetadataPersistenceClient) InitializeImmutableClusterMetadata(re
 	return res, err
 }
 
+func (c *clusterMetadataPersistenceClient) UpsertRemoteCluster(request *UpsertRemoteClusterRequest) error {
+	c.metricClient.IncCounter(metrics.PersistenceUpsertRemoteClusterScope, metrics.PersistenceRequests)
+
+	sw := c.metricClient.StartTimer(metrics.PersistenceUpsertRemoteClusterScope, metrics.PersistenceLatency)
+	err := c.persistence.UpsertRemoteCluster(request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricClient.IncCounter(metrics.PersistenceUpsertRemoteClusterScope, metrics.PersistenceFailures)
+	}
+
+	return err
+}
+
+func (c *clusterMetadataPersistenceClient) ListRemoteClusters() (*ListRemoteClustersResponse, error) {
+	c.metricClient.IncCounter(metrics.PersistenceListRemoteClustersScope, metrics.PersistenceRequests)
+
+	sw := c.metricClient.StartTimer(metrics.PersistenceListRemoteClustersScope, metrics.PersistenceLatency)
+	res, err := c.persistence.ListRemoteClusters()
+	sw.Stop()
+
+	if err != nil {
+		c.metricClient.IncCounter(metrics.PersistenceListRemoteClustersScope, metrics.PersistenceFailures)
+	}
+
+	return res, err
+}
+
+func (c *clusterMetadataPersistenceClient) DeleteRemoteCluster(request *DeleteRemoteClusterRequest) error {
+	c.metricClient.IncCounter(metrics.PersistenceDeleteRemoteClusterScope, metrics.PersistenceRequests)
+
+	sw := c.metricClient.StartTimer(metrics.PersistenceDeleteRemoteClusterScope, metrics.PersistenceLatency)
+	err := c.persistence.DeleteRemoteCluster(request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricClient.IncCounter(metrics.PersistenceDeleteRemoteClusterScope, metrics.PersistenceFailures)
+	}
+
+	return err
+}
+
+func (c *clusterMetadataPersistenceClient) UpdateDynamicConfig(request *UpdateDynamicConfigRequest) (*UpdateDynamicConfigResponse, error) {
+	c.metricClient.IncCounter(metrics.PersistenceUpdateDynamicConfigScope, metrics.PersistenceRequests)
+
+	sw := c.metricClient.StartTimer(metrics.PersistenceUpdateDynamicConfigScope, metrics.PersistenceLatency)
+	res, err := c.persistence.UpdateDynamicConfig(request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricClient.IncCounter(metrics.PersistenceUpdateDynamicConfigScope, metrics.PersistenceFailures)
+	}
+
+	return res, err
+}
+
+func (c *clusterMetadataPersistenceClient) ListDynamicConfigValues() (*ListDynamicConfigValuesResponse, error) {
+	c.metricClient.IncCounter(metrics.PersistenceListDynamicConfigValuesScope, metrics.PersistenceRequests)
+
+	sw := c.metricClient.StartTimer(metrics.PersistenceListDynamicConfigValuesScope, metrics.PersistenceLatency)
+	res, err := c.persistence.ListDynamicConfigValues()
+	sw.Stop()
+
+	if err != nil {
+		c.metricClient.IncCounter(metrics.PersistenceListDynamicConfigValuesScope, metrics.PersistenceFailures)
+	}
+
+	return res, err
+}
+
+func (c *clusterMetadataPersistenceClient) GetDynamicConfigHistory(request *GetDynamicConfigHistoryRequest) (*GetDynamicConfigHistoryResponse, error) {
+	c.metricClient.IncCounter(metrics.PersistenceGetDynamicConfigHistoryScope, metrics.PersistenceRequests)
+
+	sw := c.metricClient.StartTimer(metrics.PersistenceGetDynamicConfigHistoryScope, metrics.PersistenceLatency)
+	res, err := c.persistence.GetDynamicConfigHistory(request)
+	sw.Stop()
+
+	if err != nil {
+		c.metricClient.IncCounter(metrics.PersistenceGetDynamicConfigHistoryScope, metrics.PersistenceFailures)
+	}
+
+	return res, err
+}
+
 func (c *clusterMetadataPersistenceClient) GetClusterMembers(request *GetClusterMembersRequest) (*GetClusterMembersResponse, error) {
 	c.metricClient.IncCounter(metrics.PersistenceGetClusterMembersScope, metrics.PersistenceRequests)
 
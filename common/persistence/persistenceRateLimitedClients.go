@@ -25,10 +25,11 @@
 package persistence
 
 import (
+	"time"
+
 	"go.temporal.io/temporal-proto/serviceerror"
 
 	"github.com/temporalio/temporal/common/log"
-	"github.com/temporalio/temporal/common/quotas"
 )
 
 var (
@@ -40,49 +41,49 @@ var (
 
 type (
 	shardRateLimitedPersistenceClient struct {
-		rateLimiter quotas.Limiter
+		rateLimiter RateLimiter
 		persistence ShardManager
 		logger      log.Logger
 	}
 
 	workflowExecutionRateLimitedPersistenceClient struct {
-		rateLimiter quotas.Limiter
+		rateLimiter RateLimiter
 		persistence ExecutionManager
 		logger      log.Logger
 	}
 
 	taskRateLimitedPersistenceClient struct {
-		rateLimiter quotas.Limiter
+		rateLimiter RateLimiter
 		persistence TaskManager
 		logger      log.Logger
 	}
 
 	historyV2RateLimitedPersistenceClient struct {
-		rateLimiter quotas.Limiter
+		rateLimiter RateLimiter
 		persistence HistoryManager
 		logger      log.Logger
 	}
 
 	metadataRateLimitedPersistenceClient struct {
-		rateLimiter quotas.Limiter
+		rateLimiter RateLimiter
 		persistence MetadataManager
 		logger      log.Logger
 	}
 
 	clusterMetadataRateLimitedPersistenceClient struct {
-		rateLimiter quotas.Limiter
+		rateLimiter RateLimiter
 		persistence ClusterMetadataManager
 		logger      log.Logger
 	}
 
 	visibilityRateLimitedPersistenceClient struct {
-		rateLimiter quotas.Limiter
+		rateLimiter RateLimiter
 		persistence VisibilityManager
 		logger      log.Logger
 	}
 
 	queueRateLimitedPersistenceClient struct {
-		rateLimiter quotas.Limiter
+		rateLimiter RateLimiter
 		persistence Queue
 		logger      log.Logger
 	}
@@ -98,7 +99,7 @@ var _ VisibilityManager = (*visibilityRateLimitedPersistenceClient)(nil)
 var _ Queue = (*queueRateLimitedPersistenceClient)(nil)
 
 // NewShardPersistenceRateLimitedClient creates a client to manage shards
-func NewShardPersistenceRateLimitedClient(persistence ShardManager, rateLimiter quotas.Limiter, logger log.Logger) ShardManager {
+func NewShardPersistenceRateLimitedClient(persistence ShardManager, rateLimiter RateLimiter, logger log.Logger) ShardManager {
 	return &shardRateLimitedPersistenceClient{
 		persistence: persistence,
 		rateLimiter: rateLimiter,
@@ -107,7 +108,7 @@ func NewShardPersistenceRateLimitedClient(persistence ShardManager, rateLimiter
 }
 
 // NewWorkflowExecutionPersistenceRateLimitedClient creates a client to manage executions
-func NewWorkflowExecutionPersistenceRateLimitedClient(persistence ExecutionManager, rateLimiter quotas.Limiter, logger log.Logger) ExecutionManager {
+func NewWorkflowExecutionPersistenceRateLimitedClient(persistence ExecutionManager, rateLimiter RateLimiter, logger log.Logger) ExecutionManager {
 	return &workflowExecutionRateLimitedPersistenceClient{
 		persistence: persistence,
 		rateLimiter: rateLimiter,
@@ -116,7 +117,7 @@ func NewWorkflowExecutionPersistenceRateLimitedClient(persistence ExecutionManag
 }
 
 // NewTaskPersistenceRateLimitedClient creates a client to manage tasks
-func NewTaskPersistenceRateLimitedClient(persistence TaskManager, rateLimiter quotas.Limiter, logger log.Logger) TaskManager {
+func NewTaskPersistenceRateLimitedClient(persistence TaskManager, rateLimiter RateLimiter, logger log.Logger) TaskManager {
 	return &taskRateLimitedPersistenceClient{
 		persistence: persistence,
 		rateLimiter: rateLimiter,
@@ -125,7 +126,7 @@ func NewTaskPersistenceRateLimitedClient(persistence TaskManager, rateLimiter qu
 }
 
 // NewHistoryV2PersistenceRateLimitedClient creates a HistoryManager client to manage workflow execution history
-func NewHistoryV2PersistenceRateLimitedClient(persistence HistoryManager, rateLimiter quotas.Limiter, logger log.Logger) HistoryManager {
+func NewHistoryV2PersistenceRateLimitedClient(persistence HistoryManager, rateLimiter RateLimiter, logger log.Logger) HistoryManager {
 	return &historyV2RateLimitedPersistenceClient{
 		persistence: persistence,
 		rateLimiter: rateLimiter,
@@ -134,7 +135,7 @@ func NewHistoryV2PersistenceRateLimitedClient(persistence HistoryManager, rateLi
 }
 
 // NewMetadataPersistenceRateLimitedClient creates a MetadataManager client to manage metadata
-func NewMetadataPersistenceRateLimitedClient(persistence MetadataManager, rateLimiter quotas.Limiter, logger log.Logger) MetadataManager {
+func NewMetadataPersistenceRateLimitedClient(persistence MetadataManager, rateLimiter RateLimiter, logger log.Logger) MetadataManager {
 	return &metadataRateLimitedPersistenceClient{
 		persistence: persistence,
 		rateLimiter: rateLimiter,
@@ -143,7 +144,7 @@ func NewMetadataPersistenceRateLimitedClient(persistence MetadataManager, rateLi
 }
 
 // NewClusterMetadataPersistenceRateLimitedClient creates a MetadataManager client to manage metadata
-func NewClusterMetadataPersistenceRateLimitedClient(persistence ClusterMetadataManager, rateLimiter quotas.Limiter, logger log.Logger) ClusterMetadataManager {
+func NewClusterMetadataPersistenceRateLimitedClient(persistence ClusterMetadataManager, rateLimiter RateLimiter, logger log.Logger) ClusterMetadataManager {
 	return &clusterMetadataRateLimitedPersistenceClient{
 		persistence: persistence,
 		rateLimiter: rateLimiter,
@@ -152,7 +153,7 @@ func NewClusterMetadataPersistenceRateLimitedClient(persistence ClusterMetadataM
 }
 
 // NewVisibilityPersistenceRateLimitedClient creates a client to manage visibility
-func NewVisibilityPersistenceRateLimitedClient(persistence VisibilityManager, rateLimiter quotas.Limiter, logger log.Logger) VisibilityManager {
+func NewVisibilityPersistenceRateLimitedClient(persistence VisibilityManager, rateLimiter RateLimiter, logger log.Logger) VisibilityManager {
 	return &visibilityRateLimitedPersistenceClient{
 		persistence: persistence,
 		rateLimiter: rateLimiter,
@@ -161,7 +162,7 @@ func NewVisibilityPersistenceRateLimitedClient(persistence VisibilityManager, ra
 }
 
 // NewQueuePersistenceRateLimitedClient creates a client to manage queue
-func NewQueuePersistenceRateLimitedClient(persistence Queue, rateLimiter quotas.Limiter, logger log.Logger) Queue {
+func NewQueuePersistenceRateLimitedClient(persistence Queue, rateLimiter RateLimiter, logger log.Logger) Queue {
 	return &queueRateLimitedPersistenceClient{
 		persistence: persistence,
 		rateLimiter: rateLimiter,
@@ -174,29 +175,35 @@ func (p *shardRateLimitedPersistenceClient) GetName() string {
 }
 
 func (p *shardRateLimitedPersistenceClient) CreateShard(request *CreateShardRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.CreateShard(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *shardRateLimitedPersistenceClient) GetShard(request *GetShardRequest) (*GetShardResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.GetShard(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *shardRateLimitedPersistenceClient) UpdateShard(request *UpdateShardRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.UpdateShard(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
@@ -213,213 +220,341 @@ func (p *workflowExecutionRateLimitedPersistenceClient) GetShardID() int {
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(executionNamespaceID(request.NewWorkflowSnapshot.ExecutionInfo), callerTypeOrDefault(request.CallerType)); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.CreateWorkflowExecution(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, callerTypeOrDefault(request.CallerType)); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.GetWorkflowExecution(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(executionNamespaceID(request.UpdateWorkflowMutation.ExecutionInfo), callerTypeOrDefault(request.CallerType)); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	resp, err := p.persistence.UpdateWorkflowExecution(request)
-	return resp, err
+	startTime := time.Now()
+	response, err := p.persistence.UpdateWorkflowExecution(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) ConflictResolveWorkflowExecution(request *ConflictResolveWorkflowExecutionRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(executionNamespaceID(request.ResetWorkflowSnapshot.ExecutionInfo), callerTypeOrDefault(request.CallerType)); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.ConflictResolveWorkflowExecution(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(executionNamespaceID(request.NewWorkflowSnapshot.ExecutionInfo), callerTypeOrDefault(request.CallerType)); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.ResetWorkflowExecution(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.DeleteWorkflowExecution(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.DeleteCurrentWorkflowExecution(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.GetCurrentExecution(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*ListConcreteExecutionsResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.ListConcreteExecutions(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.GetTransferTasks(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.GetReplicationTasks(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.CompleteTransferTask(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.RangeCompleteTransferTask(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.CompleteReplicationTask(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.RangeCompleteReplicationTask(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) PutReplicationTaskToDLQ(
 	request *PutReplicationTaskToDLQRequest,
 ) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.PutReplicationTaskToDLQ(request)
+	startTime := time.Now()
+	err := p.persistence.PutReplicationTaskToDLQ(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) GetReplicationTasksFromDLQ(
 	request *GetReplicationTasksFromDLQRequest,
 ) (*GetReplicationTasksFromDLQResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.GetReplicationTasksFromDLQ(request)
+	startTime := time.Now()
+	response, err := p.persistence.GetReplicationTasksFromDLQ(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) DeleteReplicationTaskFromDLQ(
 	request *DeleteReplicationTaskFromDLQRequest,
 ) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.DeleteReplicationTaskFromDLQ(request)
+	startTime := time.Now()
+	err := p.persistence.DeleteReplicationTaskFromDLQ(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) RangeDeleteReplicationTaskFromDLQ(
 	request *RangeDeleteReplicationTaskFromDLQRequest,
 ) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.RangeDeleteReplicationTaskFromDLQ(request)
+	startTime := time.Now()
+	err := p.persistence.RangeDeleteReplicationTaskFromDLQ(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) PutTransferTaskToDLQ(
+	request *PutTransferTaskToDLQRequest,
+) error {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	startTime := time.Now()
+	err := p.persistence.PutTransferTaskToDLQ(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) GetTransferTasksFromDLQ(
+	request *GetTransferTasksFromDLQRequest,
+) (*GetTransferTasksFromDLQResponse, error) {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	startTime := time.Now()
+	response, err := p.persistence.GetTransferTasksFromDLQ(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) RangeDeleteTransferTaskFromDLQ(
+	request *RangeDeleteTransferTaskFromDLQRequest,
+) error {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	startTime := time.Now()
+	err := p.persistence.RangeDeleteTransferTaskFromDLQ(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) PutTimerTaskToDLQ(
+	request *PutTimerTaskToDLQRequest,
+) error {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	startTime := time.Now()
+	err := p.persistence.PutTimerTaskToDLQ(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) GetTimerTasksFromDLQ(
+	request *GetTimerTasksFromDLQRequest,
+) (*GetTimerTasksFromDLQResponse, error) {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	startTime := time.Now()
+	response, err := p.persistence.GetTimerTasksFromDLQ(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) RangeDeleteTimerTaskFromDLQ(
+	request *RangeDeleteTimerTaskFromDLQRequest,
+) error {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	startTime := time.Now()
+	err := p.persistence.RangeDeleteTimerTaskFromDLQ(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) GetTimerTask(request *GetTimerTaskRequest) (*GetTimerTaskResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.GetTimerTask(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	resonse, err := p.persistence.GetTimerIndexTasks(request)
-	return resonse, err
+	startTime := time.Now()
+	response, err := p.persistence.GetTimerIndexTasks(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.CompleteTimerTask(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *workflowExecutionRateLimitedPersistenceClient) RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.RangeCompleteTimerTask(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
@@ -432,69 +567,91 @@ func (p *taskRateLimitedPersistenceClient) GetName() string {
 }
 
 func (p *taskRateLimitedPersistenceClient) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.TaskListInfo.Data.GetNamespaceId(), callerTypeOrDefault(request.CallerType)); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.CreateTasks(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *taskRateLimitedPersistenceClient) GetTasks(request *GetTasksRequest) (*GetTasksResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, callerTypeOrDefault(request.CallerType)); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.GetTasks(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *taskRateLimitedPersistenceClient) CompleteTask(request *CompleteTaskRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.TaskList.NamespaceID, CallerTypeAPI); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.CompleteTask(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *taskRateLimitedPersistenceClient) CompleteTasksLessThan(request *CompleteTasksLessThanRequest) (int, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeBackground); !ok {
 		return 0, ErrPersistenceLimitExceeded
 	}
-	return p.persistence.CompleteTasksLessThan(request)
+
+	startTime := time.Now()
+	response, err := p.persistence.CompleteTasksLessThan(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (p *taskRateLimitedPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, callerTypeOrDefault(request.CallerType)); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.LeaseTaskList(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *taskRateLimitedPersistenceClient) UpdateTaskList(request *UpdateTaskListRequest) (*UpdateTaskListResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.TaskListInfo.GetNamespaceId(), callerTypeOrDefault(request.CallerType)); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.UpdateTaskList(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *taskRateLimitedPersistenceClient) ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
-	return p.persistence.ListTaskList(request)
+
+	startTime := time.Now()
+	response, err := p.persistence.ListTaskList(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (p *taskRateLimitedPersistenceClient) DeleteTaskList(request *DeleteTaskListRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.TaskList.NamespaceID, CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
-	return p.persistence.DeleteTaskList(request)
+
+	startTime := time.Now()
+	err := p.persistence.DeleteTaskList(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
 }
 
 func (p *taskRateLimitedPersistenceClient) Close() {
@@ -506,65 +663,79 @@ func (p *metadataRateLimitedPersistenceClient) GetName() string {
 }
 
 func (p *metadataRateLimitedPersistenceClient) CreateNamespace(request *CreateNamespaceRequest) (*CreateNamespaceResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.CreateNamespace(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *metadataRateLimitedPersistenceClient) GetNamespace(request *GetNamespaceRequest) (*GetNamespaceResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.GetNamespace(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *metadataRateLimitedPersistenceClient) UpdateNamespace(request *UpdateNamespaceRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.UpdateNamespace(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *metadataRateLimitedPersistenceClient) DeleteNamespace(request *DeleteNamespaceRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.DeleteNamespace(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *metadataRateLimitedPersistenceClient) DeleteNamespaceByName(request *DeleteNamespaceByNameRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.DeleteNamespaceByName(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *metadataRateLimitedPersistenceClient) ListNamespaces(request *ListNamespacesRequest) (*ListNamespacesResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.ListNamespaces(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *metadataRateLimitedPersistenceClient) GetMetadata() (*GetMetadataResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.GetMetadata()
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
@@ -577,130 +748,168 @@ func (p *visibilityRateLimitedPersistenceClient) GetName() string {
 }
 
 func (p *visibilityRateLimitedPersistenceClient) RecordWorkflowExecutionStarted(request *RecordWorkflowExecutionStartedRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.RecordWorkflowExecutionStarted(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) RecordWorkflowExecutionClosed(request *RecordWorkflowExecutionClosedRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.RecordWorkflowExecutionClosed(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) UpsertWorkflowExecution(request *UpsertWorkflowExecutionRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	err := p.persistence.UpsertWorkflowExecution(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) ListOpenWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.ListOpenWorkflowExecutions(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutions(request *ListWorkflowExecutionsRequest) (*ListWorkflowExecutionsResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.ListClosedWorkflowExecutions(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) ListOpenWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.ListOpenWorkflowExecutionsByType(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutionsByType(request *ListWorkflowExecutionsByTypeRequest) (*ListWorkflowExecutionsResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.ListClosedWorkflowExecutionsByType(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) ListOpenWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.ListOpenWorkflowExecutionsByWorkflowID(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.ListClosedWorkflowExecutionsByWorkflowID(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.ListClosedWorkflowExecutionsByStatus(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
+	startTime := time.Now()
 	response, err := p.persistence.GetClosedWorkflowExecution(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) DeleteWorkflowExecution(request *VisibilityDeleteWorkflowExecutionRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
-	return p.persistence.DeleteWorkflowExecution(request)
+
+	startTime := time.Now()
+	err := p.persistence.DeleteWorkflowExecution(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) ListWorkflowExecutions(request *ListWorkflowExecutionsRequestV2) (*ListWorkflowExecutionsResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
-	return p.persistence.ListWorkflowExecutions(request)
+
+	startTime := time.Now()
+	response, err := p.persistence.ListWorkflowExecutions(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) ScanWorkflowExecutions(request *ListWorkflowExecutionsRequestV2) (*ListWorkflowExecutionsResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
-	return p.persistence.ScanWorkflowExecutions(request)
+
+	startTime := time.Now()
+	response, err := p.persistence.ScanWorkflowExecutions(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) CountWorkflowExecutions(request *CountWorkflowExecutionsRequest) (*CountWorkflowExecutionsResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow(request.NamespaceID, CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
-	return p.persistence.CountWorkflowExecutions(request)
+
+	startTime := time.Now()
+	response, err := p.persistence.CountWorkflowExecutions(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (p *visibilityRateLimitedPersistenceClient) Close() {
@@ -717,159 +926,217 @@ func (p *historyV2RateLimitedPersistenceClient) Close() {
 
 // AppendHistoryNodes add(or override) a node to a history branch
 func (p *historyV2RateLimitedPersistenceClient) AppendHistoryNodes(request *AppendHistoryNodesRequest) (*AppendHistoryNodesResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
-	return p.persistence.AppendHistoryNodes(request)
+
+	startTime := time.Now()
+	response, err := p.persistence.AppendHistoryNodes(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 // ReadHistoryBranch returns history node data for a branch
 func (p *historyV2RateLimitedPersistenceClient) ReadHistoryBranch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
+
+	startTime := time.Now()
 	response, err := p.persistence.ReadHistoryBranch(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 // ReadHistoryBranchByBatch returns history node data for a branch
 func (p *historyV2RateLimitedPersistenceClient) ReadHistoryBranchByBatch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchByBatchResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
+
+	startTime := time.Now()
 	response, err := p.persistence.ReadHistoryBranchByBatch(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 // ReadHistoryBranchByBatch returns history node data for a branch
 func (p *historyV2RateLimitedPersistenceClient) ReadRawHistoryBranch(request *ReadHistoryBranchRequest) (*ReadRawHistoryBranchResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
+
+	startTime := time.Now()
 	response, err := p.persistence.ReadRawHistoryBranch(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 // ForkHistoryBranch forks a new branch from a old branch
 func (p *historyV2RateLimitedPersistenceClient) ForkHistoryBranch(request *ForkHistoryBranchRequest) (*ForkHistoryBranchResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
+
+	startTime := time.Now()
 	response, err := p.persistence.ForkHistoryBranch(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 // DeleteHistoryBranch removes a branch
 func (p *historyV2RateLimitedPersistenceClient) DeleteHistoryBranch(request *DeleteHistoryBranchRequest) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
+
+	startTime := time.Now()
 	err := p.persistence.DeleteHistoryBranch(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return err
 }
 
 // GetHistoryTree returns all branch information of a tree
 func (p *historyV2RateLimitedPersistenceClient) GetHistoryTree(request *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
+
+	startTime := time.Now()
 	response, err := p.persistence.GetHistoryTree(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *historyV2RateLimitedPersistenceClient) GetAllHistoryTreeBranches(request *GetAllHistoryTreeBranchesRequest) (*GetAllHistoryTreeBranchesResponse, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
+
+	startTime := time.Now()
 	response, err := p.persistence.GetAllHistoryTreeBranches(request)
+	p.rateLimiter.Observe(time.Since(startTime), err)
 	return response, err
 }
 
 func (p *queueRateLimitedPersistenceClient) EnqueueMessage(message []byte) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.EnqueueMessage(message)
+	startTime := time.Now()
+	err := p.persistence.EnqueueMessage(message)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
 }
 
 func (p *queueRateLimitedPersistenceClient) ReadMessages(lastMessageID int64, maxCount int) ([]*QueueMessage, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.ReadMessages(lastMessageID, maxCount)
+	startTime := time.Now()
+	response, err := p.persistence.ReadMessages(lastMessageID, maxCount)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (p *queueRateLimitedPersistenceClient) UpdateAckLevel(messageID int64, clusterName string) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.UpdateAckLevel(messageID, clusterName)
+	startTime := time.Now()
+	err := p.persistence.UpdateAckLevel(messageID, clusterName)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
 }
 
 func (p *queueRateLimitedPersistenceClient) GetAckLevels() (map[string]int64, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.GetAckLevels()
+	startTime := time.Now()
+	response, err := p.persistence.GetAckLevels()
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (p *queueRateLimitedPersistenceClient) DeleteMessagesBefore(messageID int64) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.DeleteMessagesBefore(messageID)
+	startTime := time.Now()
+	err := p.persistence.DeleteMessagesBefore(messageID)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
 }
 
 func (p *queueRateLimitedPersistenceClient) EnqueueMessageToDLQ(message []byte) (int64, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return emptyMessageID, ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.EnqueueMessageToDLQ(message)
+	startTime := time.Now()
+	response, err := p.persistence.EnqueueMessageToDLQ(message)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (p *queueRateLimitedPersistenceClient) ReadMessagesFromDLQ(firstMessageID int64, lastMessageID int64, pageSize int, pageToken []byte) ([]*QueueMessage, []byte, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return nil, nil, ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.ReadMessagesFromDLQ(firstMessageID, lastMessageID, pageSize, pageToken)
+	startTime := time.Now()
+	messages, nextPageToken, err := p.persistence.ReadMessagesFromDLQ(firstMessageID, lastMessageID, pageSize, pageToken)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return messages, nextPageToken, err
 }
 
 func (p *queueRateLimitedPersistenceClient) RangeDeleteMessagesFromDLQ(firstMessageID int64, lastMessageID int64) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.RangeDeleteMessagesFromDLQ(firstMessageID, lastMessageID)
+	startTime := time.Now()
+	err := p.persistence.RangeDeleteMessagesFromDLQ(firstMessageID, lastMessageID)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
 }
 func (p *queueRateLimitedPersistenceClient) UpdateDLQAckLevel(messageID int64, clusterName string) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.UpdateDLQAckLevel(messageID, clusterName)
+	startTime := time.Now()
+	err := p.persistence.UpdateDLQAckLevel(messageID, clusterName)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
 }
 
 func (p *queueRateLimitedPersistenceClient) GetDLQAckLevels() (map[string]int64, error) {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.GetDLQAckLevels()
+	startTime := time.Now()
+	response, err := p.persistence.GetDLQAckLevels()
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (p *queueRateLimitedPersistenceClient) DeleteMessageFromDLQ(messageID int64) error {
-	if ok := p.rateLimiter.Allow(); !ok {
+	if ok := p.rateLimiter.Allow("", CallerTypeBackground); !ok {
 		return ErrPersistenceLimitExceeded
 	}
 
-	return p.persistence.DeleteMessageFromDLQ(messageID)
+	startTime := time.Now()
+	err := p.persistence.DeleteMessageFromDLQ(messageID)
+	p.rateLimiter.Observe(time.Since(startTime), err)
+	return err
 }
 
 func (p *queueRateLimitedPersistenceClient) Close() {
@@ -885,43 +1152,133 @@ func (c *clusterMetadataRateLimitedPersistenceClient) GetName() string {
 }
 
 func (c *clusterMetadataRateLimitedPersistenceClient) InitializeImmutableClusterMetadata(request *InitializeImmutableClusterMetadataRequest) (*InitializeImmutableClusterMetadataResponse, error) {
-	if ok := c.rateLimiter.Allow(); !ok {
+	if ok := c.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
-	return c.persistence.InitializeImmutableClusterMetadata(request)
+
+	startTime := time.Now()
+	response, err := c.persistence.InitializeImmutableClusterMetadata(request)
+	c.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (c *clusterMetadataRateLimitedPersistenceClient) GetImmutableClusterMetadata() (*GetImmutableClusterMetadataResponse, error) {
-	if ok := c.rateLimiter.Allow(); !ok {
+	if ok := c.rateLimiter.Allow("", CallerTypeAPI); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	startTime := time.Now()
+	response, err := c.persistence.GetImmutableClusterMetadata()
+	c.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
+}
+
+func (c *clusterMetadataRateLimitedPersistenceClient) UpsertRemoteCluster(request *UpsertRemoteClusterRequest) error {
+	if ok := c.rateLimiter.Allow("", CallerTypeAPI); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	startTime := time.Now()
+	err := c.persistence.UpsertRemoteCluster(request)
+	c.rateLimiter.Observe(time.Since(startTime), err)
+	return err
+}
+
+func (c *clusterMetadataRateLimitedPersistenceClient) ListRemoteClusters() (*ListRemoteClustersResponse, error) {
+	if ok := c.rateLimiter.Allow("", CallerTypeAPI); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	startTime := time.Now()
+	response, err := c.persistence.ListRemoteClusters()
+	c.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
+}
+
+func (c *clusterMetadataRateLimitedPersistenceClient) DeleteRemoteCluster(request *DeleteRemoteClusterRequest) error {
+	if ok := c.rateLimiter.Allow("", CallerTypeAPI); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	startTime := time.Now()
+	err := c.persistence.DeleteRemoteCluster(request)
+	c.rateLimiter.Observe(time.Since(startTime), err)
+	return err
+}
+
+func (c *clusterMetadataRateLimitedPersistenceClient) UpdateDynamicConfig(request *UpdateDynamicConfigRequest) (*UpdateDynamicConfigResponse, error) {
+	if ok := c.rateLimiter.Allow("", CallerTypeAPI); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	startTime := time.Now()
+	response, err := c.persistence.UpdateDynamicConfig(request)
+	c.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
+}
+
+func (c *clusterMetadataRateLimitedPersistenceClient) ListDynamicConfigValues() (*ListDynamicConfigValuesResponse, error) {
+	if ok := c.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
-	return c.persistence.GetImmutableClusterMetadata()
+
+	startTime := time.Now()
+	response, err := c.persistence.ListDynamicConfigValues()
+	c.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
+}
+
+func (c *clusterMetadataRateLimitedPersistenceClient) GetDynamicConfigHistory(request *GetDynamicConfigHistoryRequest) (*GetDynamicConfigHistoryResponse, error) {
+	if ok := c.rateLimiter.Allow("", CallerTypeAPI); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	startTime := time.Now()
+	response, err := c.persistence.GetDynamicConfigHistory(request)
+	c.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (c *clusterMetadataRateLimitedPersistenceClient) GetClusterMembers(request *GetClusterMembersRequest) (*GetClusterMembersResponse, error) {
-	if ok := c.rateLimiter.Allow(); !ok {
+	if ok := c.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return nil, ErrPersistenceLimitExceeded
 	}
-	return c.persistence.GetClusterMembers(request)
+
+	startTime := time.Now()
+	response, err := c.persistence.GetClusterMembers(request)
+	c.rateLimiter.Observe(time.Since(startTime), err)
+	return response, err
 }
 
 func (c *clusterMetadataRateLimitedPersistenceClient) UpsertClusterMembership(request *UpsertClusterMembershipRequest) error {
-	if ok := c.rateLimiter.Allow(); !ok {
+	if ok := c.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return ErrPersistenceLimitExceeded
 	}
-	return c.persistence.UpsertClusterMembership(request)
+
+	startTime := time.Now()
+	err := c.persistence.UpsertClusterMembership(request)
+	c.rateLimiter.Observe(time.Since(startTime), err)
+	return err
 }
 
 func (c *clusterMetadataRateLimitedPersistenceClient) PruneClusterMembership(request *PruneClusterMembershipRequest) error {
-	if ok := c.rateLimiter.Allow(); !ok {
+	if ok := c.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return ErrPersistenceLimitExceeded
 	}
-	return c.persistence.PruneClusterMembership(request)
+
+	startTime := time.Now()
+	err := c.persistence.PruneClusterMembership(request)
+	c.rateLimiter.Observe(time.Since(startTime), err)
+	return err
 }
 
 func (c *metadataRateLimitedPersistenceClient) InitializeSystemNamespaces(currentClusterName string) error {
-	if ok := c.rateLimiter.Allow(); !ok {
+	if ok := c.rateLimiter.Allow("", CallerTypeAPI); !ok {
 		return ErrPersistenceLimitExceeded
 	}
-	return c.persistence.InitializeSystemNamespaces(currentClusterName)
+
+	startTime := time.Now()
+	err := c.persistence.InitializeSystemNamespaces(currentClusterName)
+	c.rateLimiter.Observe(time.Since(startTime), err)
+	return err
 }
@@ -0,0 +1,232 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/common/quotas"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	// CallerType is the type of the caller of a persistence API, calls of background
+	// callers are shed before calls serving the APIs of the service
+	CallerType string
+
+	// RateLimiter limits the persistence calls per namespace and caller type. The limits
+	// adapt to the latency and error rate observed from the store.
+	RateLimiter interface {
+		// Allow returns whether a call of the namespace and caller type can proceed,
+		// the namespace is empty for calls which are not specific to a namespace
+		Allow(namespaceID string, callerType CallerType) bool
+		// Observe records the latency and the result of a call to the store
+		Observe(latency time.Duration, err error)
+	}
+
+	// RateLimiterConfig is the configuration of the persistence rate limiter
+	RateLimiterConfig struct {
+		// MaxQPS is the max rate of calls to the store while the store is healthy
+		MaxQPS dynamicconfig.IntPropertyFn
+		// TargetLatency is the average store latency above which the rate is decreased,
+		// the rate does not adapt to the latency if it is nil or zero
+		TargetLatency dynamicconfig.DurationPropertyFn
+		// MaxErrorRatio is the ratio of failed calls above which the rate is decreased,
+		// the rate does not adapt to the errors if it is nil or zero
+		MaxErrorRatio dynamicconfig.FloatPropertyFn
+	}
+
+	rateLimiterImpl struct {
+		adaptiveRPS *quotas.AdaptiveRPS
+		limiter     *quotas.PriorityRateLimiter
+		namespaces  *activeNamespaces
+	}
+
+	// activeNamespaces tracks the namespaces which called the store recently
+	activeNamespaces struct {
+		lastSeen sync.Map // namespaceID -> *int64 unix nanos
+
+		sync.Mutex
+		count     int64
+		countedAt int64
+	}
+)
+
+const (
+	// CallerTypeAPI is the caller type of calls serving the APIs of the service
+	CallerTypeAPI CallerType = "api"
+	// CallerTypeBackground is the caller type of calls made by background processing,
+	// like the queue processors, the retention and the scanners
+	CallerTypeBackground CallerType = "background"
+)
+
+type callerTypeContextKey struct{}
+
+const (
+	rateLimiterAdaptInterval     = 10 * time.Second
+	activeNamespaceWindow        = time.Minute
+	activeNamespaceCountInterval = time.Second
+)
+
+var _ RateLimiter = (*rateLimiterImpl)(nil)
+
+// NewRateLimiter returns a new persistence rate limiter. All calls are subject to
+// the adaptive rate, calls of background callers are shed first as it is approached.
+// While the store is degraded, each recently active namespace is limited to its fair
+// share of the adaptive rate.
+func NewRateLimiter(config RateLimiterConfig) RateLimiter {
+	adaptiveConfig := quotas.AdaptiveRPSConfig{
+		MaxRPS: func() float64 {
+			return float64(config.MaxQPS())
+		},
+		Interval: rateLimiterAdaptInterval,
+	}
+	if config.TargetLatency != nil {
+		adaptiveConfig.TargetLatency = func() time.Duration {
+			return config.TargetLatency()
+		}
+	}
+	if config.MaxErrorRatio != nil {
+		adaptiveConfig.MaxErrorRatio = func() float64 {
+			return config.MaxErrorRatio()
+		}
+	}
+
+	rl := &rateLimiterImpl{
+		adaptiveRPS: quotas.NewAdaptiveRPS(adaptiveConfig),
+		namespaces:  &activeNamespaces{},
+	}
+	rl.limiter = quotas.NewPriorityRateLimiter(
+		rl.adaptiveRPS.RPS,
+		rl.namespaceRPS,
+		map[string]quotas.APIClassConfig{
+			string(CallerTypeAPI):        {Priority: 0},
+			string(CallerTypeBackground): {Priority: 1},
+		},
+	)
+	return rl
+}
+
+// WithCallerType returns a copy of the context carrying the caller type
+// of the persistence calls made on behalf of it
+func WithCallerType(ctx context.Context, callerType CallerType) context.Context {
+	return context.WithValue(ctx, callerTypeContextKey{}, callerType)
+}
+
+// GetCallerType returns the caller type carried by the context, it is empty
+// if the context does not carry one
+func GetCallerType(ctx context.Context) CallerType {
+	callerType, _ := ctx.Value(callerTypeContextKey{}).(CallerType)
+	return callerType
+}
+
+// callerTypeOrDefault returns the caller type set on a request, calls
+// without a caller type serve the APIs of the service
+func callerTypeOrDefault(callerType CallerType) CallerType {
+	if len(callerType) == 0 {
+		return CallerTypeAPI
+	}
+	return callerType
+}
+
+func (r *rateLimiterImpl) Allow(namespaceID string, callerType CallerType) bool {
+	if len(namespaceID) != 0 {
+		r.namespaces.touch(namespaceID, time.Now())
+	}
+	return r.limiter.Allow(quotas.Info{Namespace: namespaceID, APIClass: string(callerType)})
+}
+
+func (r *rateLimiterImpl) Observe(latency time.Duration, err error) {
+	r.adaptiveRPS.Observe(latency, isUnhealthyStoreError(err))
+}
+
+// namespaceRPS returns the rate of a namespace, the namespaces are only limited
+// separately while the rate is decreased below the max rate
+func (r *rateLimiterImpl) namespaceRPS(_ string) float64 {
+	rps := r.adaptiveRPS.RPS()
+	if r.adaptiveRPS.Ratio() >= 1 {
+		return rps
+	}
+	return rps / float64(r.namespaces.active(time.Now()))
+}
+
+// touch records a call of the namespace
+func (a *activeNamespaces) touch(namespaceID string, now time.Time) {
+	lastSeen, ok := a.lastSeen.Load(namespaceID)
+	if !ok {
+		lastSeen, _ = a.lastSeen.LoadOrStore(namespaceID, new(int64))
+	}
+	atomic.StoreInt64(lastSeen.(*int64), now.UnixNano())
+}
+
+// active returns the number of namespaces which called the store in the last
+// window, it is at least one
+func (a *activeNamespaces) active(now time.Time) int64 {
+	a.Lock()
+	defer a.Unlock()
+	if now.UnixNano()-a.countedAt < int64(activeNamespaceCountInterval) {
+		return a.count
+	}
+
+	count := int64(0)
+	a.lastSeen.Range(func(key, value interface{}) bool {
+		if now.UnixNano()-atomic.LoadInt64(value.(*int64)) > int64(activeNamespaceWindow) {
+			a.lastSeen.Delete(key)
+		} else {
+			count++
+		}
+		return true
+	})
+	if count == 0 {
+		count = 1
+	}
+	a.count = count
+	a.countedAt = now.UnixNano()
+	return count
+}
+
+// isUnhealthyStoreError returns whether the error indicates that the store is degraded
+func isUnhealthyStoreError(err error) bool {
+	switch err.(type) {
+	case *TimeoutError,
+		*serviceerror.Unavailable,
+		*serviceerror.ResourceExhausted,
+		*serviceerror.Internal:
+		return true
+	}
+	return false
+}
+
+func executionNamespaceID(executionInfo *WorkflowExecutionInfo) string {
+	if executionInfo == nil {
+		return ""
+	}
+	return executionInfo.NamespaceID
+}
@@ -0,0 +1,112 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	rateLimiterSuite struct {
+		suite.Suite
+	}
+)
+
+func TestRateLimiterSuite(t *testing.T) {
+	s := new(rateLimiterSuite)
+	suite.Run(t, s)
+}
+
+func (s *rateLimiterSuite) TestBackgroundShedFirst() {
+	rateLimiter := NewRateLimiter(RateLimiterConfig{
+		MaxQPS: dynamicconfig.GetIntPropertyFn(10),
+	})
+
+	// half of the burst is left to the API callers
+	var numBackgroundAllowed int
+	for n := 0; n < 10; n++ {
+		if rateLimiter.Allow("", CallerTypeBackground) {
+			numBackgroundAllowed++
+		}
+	}
+	var numAPIAllowed int
+	for n := 0; n < 10; n++ {
+		if rateLimiter.Allow("test-namespace-id", CallerTypeAPI) {
+			numAPIAllowed++
+		}
+	}
+
+	s.Equal(5, numBackgroundAllowed)
+	s.Equal(5, numAPIAllowed)
+}
+
+func (s *rateLimiterSuite) TestCallerType() {
+	s.Empty(GetCallerType(context.Background()))
+	s.Equal(CallerTypeAPI, callerTypeOrDefault(GetCallerType(context.Background())))
+
+	ctx := WithCallerType(context.Background(), CallerTypeBackground)
+	s.Equal(CallerTypeBackground, GetCallerType(ctx))
+	s.Equal(CallerTypeBackground, callerTypeOrDefault(GetCallerType(ctx)))
+}
+
+func (s *rateLimiterSuite) TestNamespaceRPS_Healthy() {
+	rateLimiter := NewRateLimiter(RateLimiterConfig{
+		MaxQPS: dynamicconfig.GetIntPropertyFn(10),
+	}).(*rateLimiterImpl)
+	s.True(rateLimiter.Allow("namespace-1", CallerTypeAPI))
+	s.True(rateLimiter.Allow("namespace-2", CallerTypeAPI))
+
+	// namespaces are not limited separately while the store is healthy
+	s.Equal(10.0, rateLimiter.namespaceRPS("namespace-1"))
+}
+
+func (s *rateLimiterSuite) TestActiveNamespaces() {
+	namespaces := &activeNamespaces{}
+	now := time.Now()
+	s.Equal(int64(1), namespaces.active(now))
+
+	namespaces.touch("namespace-1", now.Add(-2*activeNamespaceWindow))
+	namespaces.touch("namespace-2", now)
+	namespaces.touch("namespace-3", now)
+	// the count is cached for the count interval
+	s.Equal(int64(1), namespaces.active(now))
+	s.Equal(int64(2), namespaces.active(now.Add(activeNamespaceCountInterval)))
+}
+
+func (s *rateLimiterSuite) TestIsUnhealthyStoreError() {
+	s.True(isUnhealthyStoreError(&TimeoutError{}))
+	s.True(isUnhealthyStoreError(serviceerror.NewUnavailable("unavailable")))
+	s.True(isUnhealthyStoreError(serviceerror.NewInternal("internal")))
+	s.False(isUnhealthyStoreError(nil))
+	s.False(isUnhealthyStoreError(&ConditionFailedError{}))
+	s.False(isUnhealthyStoreError(serviceerror.NewNotFound("not found")))
+}
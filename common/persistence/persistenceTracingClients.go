@@ -0,0 +1,576 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"github.com/temporalio/temporal/common/tracing"
+)
+
+type (
+	shardTracingPersistenceClient struct {
+		tracer      tracing.Tracer
+		persistence ShardManager
+	}
+
+	workflowExecutionTracingPersistenceClient struct {
+		tracer      tracing.Tracer
+		persistence ExecutionManager
+	}
+
+	taskTracingPersistenceClient struct {
+		tracer      tracing.Tracer
+		persistence TaskManager
+	}
+
+	historyV2TracingPersistenceClient struct {
+		tracer      tracing.Tracer
+		persistence HistoryManager
+	}
+)
+
+var _ ShardManager = (*shardTracingPersistenceClient)(nil)
+var _ ExecutionManager = (*workflowExecutionTracingPersistenceClient)(nil)
+var _ TaskManager = (*taskTracingPersistenceClient)(nil)
+var _ HistoryManager = (*historyV2TracingPersistenceClient)(nil)
+
+// NewShardPersistenceTracingClient creates a client to manage shards which records a span for every call
+func NewShardPersistenceTracingClient(persistence ShardManager, tracer tracing.Tracer) ShardManager {
+	return &shardTracingPersistenceClient{
+		persistence: persistence,
+		tracer:      tracer,
+	}
+}
+
+// NewWorkflowExecutionPersistenceTracingClient creates a client to manage workflow executions which records a span for every call
+func NewWorkflowExecutionPersistenceTracingClient(persistence ExecutionManager, tracer tracing.Tracer) ExecutionManager {
+	return &workflowExecutionTracingPersistenceClient{
+		persistence: persistence,
+		tracer:      tracer,
+	}
+}
+
+// NewTaskPersistenceTracingClient creates a client to manage tasks which records a span for every call
+func NewTaskPersistenceTracingClient(persistence TaskManager, tracer tracing.Tracer) TaskManager {
+	return &taskTracingPersistenceClient{
+		persistence: persistence,
+		tracer:      tracer,
+	}
+}
+
+// NewHistoryV2PersistenceTracingClient creates a client to manage history which records a span for every call
+func NewHistoryV2PersistenceTracingClient(persistence HistoryManager, tracer tracing.Tracer) HistoryManager {
+	return &historyV2TracingPersistenceClient{
+		persistence: persistence,
+		tracer:      tracer,
+	}
+}
+
+func (p *shardTracingPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *shardTracingPersistenceClient) CreateShard(request *CreateShardRequest) error {
+	span := p.startSpan("CreateShard", request)
+	err := p.persistence.CreateShard(request)
+	span.End(err)
+	return err
+}
+
+func (p *shardTracingPersistenceClient) GetShard(request *GetShardRequest) (*GetShardResponse, error) {
+	span := p.startSpan("GetShard", request)
+	response, err := p.persistence.GetShard(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *shardTracingPersistenceClient) UpdateShard(request *UpdateShardRequest) error {
+	span := p.startSpan("UpdateShard", request)
+	err := p.persistence.UpdateShard(request)
+	span.End(err)
+	return err
+}
+
+func (p *shardTracingPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetShardID() int {
+	return p.persistence.GetShardID()
+}
+
+func (p *workflowExecutionTracingPersistenceClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
+	span := p.startSpan("CreateWorkflowExecution", request)
+	response, err := p.persistence.CreateWorkflowExecution(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+	span := p.startSpan("GetWorkflowExecution", request)
+	response, err := p.persistence.GetWorkflowExecution(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+	span := p.startSpan("UpdateWorkflowExecution", request)
+	response, err := p.persistence.UpdateWorkflowExecution(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) ConflictResolveWorkflowExecution(request *ConflictResolveWorkflowExecutionRequest) error {
+	span := p.startSpan("ConflictResolveWorkflowExecution", request)
+	err := p.persistence.ConflictResolveWorkflowExecution(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error {
+	span := p.startSpan("ResetWorkflowExecution", request)
+	err := p.persistence.ResetWorkflowExecution(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
+	span := p.startSpan("DeleteWorkflowExecution", request)
+	err := p.persistence.DeleteWorkflowExecution(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
+	span := p.startSpan("DeleteCurrentWorkflowExecution", request)
+	err := p.persistence.DeleteCurrentWorkflowExecution(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
+	span := p.startSpan("GetCurrentExecution", request)
+	response, err := p.persistence.GetCurrentExecution(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*ListConcreteExecutionsResponse, error) {
+	span := p.startSpan("ListConcreteExecutions", request)
+	response, err := p.persistence.ListConcreteExecutions(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+	span := p.startSpan("GetTransferTasks", request)
+	response, err := p.persistence.GetTransferTasks(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
+	span := p.startSpan("GetReplicationTasks", request)
+	response, err := p.persistence.GetReplicationTasks(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
+	span := p.startSpan("CompleteTransferTask", request)
+	err := p.persistence.CompleteTransferTask(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error {
+	span := p.startSpan("RangeCompleteTransferTask", request)
+	err := p.persistence.RangeCompleteTransferTask(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
+	span := p.startSpan("CompleteReplicationTask", request)
+	err := p.persistence.CompleteReplicationTask(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) RangeCompleteReplicationTask(request *RangeCompleteReplicationTaskRequest) error {
+	span := p.startSpan("RangeCompleteReplicationTask", request)
+	err := p.persistence.RangeCompleteReplicationTask(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) PutReplicationTaskToDLQ(request *PutReplicationTaskToDLQRequest) error {
+	span := p.startSpan("PutReplicationTaskToDLQ", request)
+	err := p.persistence.PutReplicationTaskToDLQ(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetReplicationTasksFromDLQ(request *GetReplicationTasksFromDLQRequest) (*GetReplicationTasksFromDLQResponse, error) {
+	span := p.startSpan("GetReplicationTasksFromDLQ", request)
+	response, err := p.persistence.GetReplicationTasksFromDLQ(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) DeleteReplicationTaskFromDLQ(request *DeleteReplicationTaskFromDLQRequest) error {
+	span := p.startSpan("DeleteReplicationTaskFromDLQ", request)
+	err := p.persistence.DeleteReplicationTaskFromDLQ(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) RangeDeleteReplicationTaskFromDLQ(request *RangeDeleteReplicationTaskFromDLQRequest) error {
+	span := p.startSpan("RangeDeleteReplicationTaskFromDLQ", request)
+	err := p.persistence.RangeDeleteReplicationTaskFromDLQ(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) PutTransferTaskToDLQ(request *PutTransferTaskToDLQRequest) error {
+	span := p.startSpan("PutTransferTaskToDLQ", request)
+	err := p.persistence.PutTransferTaskToDLQ(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetTransferTasksFromDLQ(request *GetTransferTasksFromDLQRequest) (*GetTransferTasksFromDLQResponse, error) {
+	span := p.startSpan("GetTransferTasksFromDLQ", request)
+	response, err := p.persistence.GetTransferTasksFromDLQ(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) RangeDeleteTransferTaskFromDLQ(request *RangeDeleteTransferTaskFromDLQRequest) error {
+	span := p.startSpan("RangeDeleteTransferTaskFromDLQ", request)
+	err := p.persistence.RangeDeleteTransferTaskFromDLQ(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) PutTimerTaskToDLQ(request *PutTimerTaskToDLQRequest) error {
+	span := p.startSpan("PutTimerTaskToDLQ", request)
+	err := p.persistence.PutTimerTaskToDLQ(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetTimerTasksFromDLQ(request *GetTimerTasksFromDLQRequest) (*GetTimerTasksFromDLQResponse, error) {
+	span := p.startSpan("GetTimerTasksFromDLQ", request)
+	response, err := p.persistence.GetTimerTasksFromDLQ(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) RangeDeleteTimerTaskFromDLQ(request *RangeDeleteTimerTaskFromDLQRequest) error {
+	span := p.startSpan("RangeDeleteTimerTaskFromDLQ", request)
+	err := p.persistence.RangeDeleteTimerTaskFromDLQ(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetTimerTask(request *GetTimerTaskRequest) (*GetTimerTaskResponse, error) {
+	span := p.startSpan("GetTimerTask", request)
+	response, err := p.persistence.GetTimerTask(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
+	span := p.startSpan("GetTimerIndexTasks", request)
+	response, err := p.persistence.GetTimerIndexTasks(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
+	span := p.startSpan("CompleteTimerTask", request)
+	err := p.persistence.CompleteTimerTask(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error {
+	span := p.startSpan("RangeCompleteTimerTask", request)
+	err := p.persistence.RangeCompleteTimerTask(request)
+	span.End(err)
+	return err
+}
+
+func (p *workflowExecutionTracingPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *taskTracingPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *taskTracingPersistenceClient) CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error) {
+	span := p.startSpan("CreateTasks", request)
+	response, err := p.persistence.CreateTasks(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *taskTracingPersistenceClient) GetTasks(request *GetTasksRequest) (*GetTasksResponse, error) {
+	span := p.startSpan("GetTasks", request)
+	response, err := p.persistence.GetTasks(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *taskTracingPersistenceClient) CompleteTask(request *CompleteTaskRequest) error {
+	span := p.startSpan("CompleteTask", request)
+	err := p.persistence.CompleteTask(request)
+	span.End(err)
+	return err
+}
+
+func (p *taskTracingPersistenceClient) CompleteTasksLessThan(request *CompleteTasksLessThanRequest) (int, error) {
+	span := p.startSpan("CompleteTasksLessThan", request)
+	response, err := p.persistence.CompleteTasksLessThan(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *taskTracingPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
+	span := p.startSpan("LeaseTaskList", request)
+	response, err := p.persistence.LeaseTaskList(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *taskTracingPersistenceClient) UpdateTaskList(request *UpdateTaskListRequest) (*UpdateTaskListResponse, error) {
+	span := p.startSpan("UpdateTaskList", request)
+	response, err := p.persistence.UpdateTaskList(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *taskTracingPersistenceClient) ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error) {
+	span := p.startSpan("ListTaskList", request)
+	response, err := p.persistence.ListTaskList(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *taskTracingPersistenceClient) DeleteTaskList(request *DeleteTaskListRequest) error {
+	span := p.startSpan("DeleteTaskList", request)
+	err := p.persistence.DeleteTaskList(request)
+	span.End(err)
+	return err
+}
+
+func (p *taskTracingPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *historyV2TracingPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *historyV2TracingPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *historyV2TracingPersistenceClient) AppendHistoryNodes(request *AppendHistoryNodesRequest) (*AppendHistoryNodesResponse, error) {
+	span := p.startSpan("AppendHistoryNodes", request)
+	response, err := p.persistence.AppendHistoryNodes(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *historyV2TracingPersistenceClient) ReadHistoryBranch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchResponse, error) {
+	span := p.startSpan("ReadHistoryBranch", request)
+	response, err := p.persistence.ReadHistoryBranch(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *historyV2TracingPersistenceClient) ReadHistoryBranchByBatch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchByBatchResponse, error) {
+	span := p.startSpan("ReadHistoryBranchByBatch", request)
+	response, err := p.persistence.ReadHistoryBranchByBatch(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *historyV2TracingPersistenceClient) ReadRawHistoryBranch(request *ReadHistoryBranchRequest) (*ReadRawHistoryBranchResponse, error) {
+	span := p.startSpan("ReadRawHistoryBranch", request)
+	response, err := p.persistence.ReadRawHistoryBranch(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *historyV2TracingPersistenceClient) ForkHistoryBranch(request *ForkHistoryBranchRequest) (*ForkHistoryBranchResponse, error) {
+	span := p.startSpan("ForkHistoryBranch", request)
+	response, err := p.persistence.ForkHistoryBranch(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *historyV2TracingPersistenceClient) DeleteHistoryBranch(request *DeleteHistoryBranchRequest) error {
+	span := p.startSpan("DeleteHistoryBranch", request)
+	err := p.persistence.DeleteHistoryBranch(request)
+	span.End(err)
+	return err
+}
+
+func (p *historyV2TracingPersistenceClient) GetHistoryTree(request *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error) {
+	span := p.startSpan("GetHistoryTree", request)
+	response, err := p.persistence.GetHistoryTree(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *historyV2TracingPersistenceClient) GetAllHistoryTreeBranches(request *GetAllHistoryTreeBranchesRequest) (*GetAllHistoryTreeBranchesResponse, error) {
+	span := p.startSpan("GetAllHistoryTreeBranches", request)
+	response, err := p.persistence.GetAllHistoryTreeBranches(request)
+	span.End(err)
+	return response, err
+}
+
+func (p *shardTracingPersistenceClient) startSpan(
+	operation string,
+	request interface{},
+) tracing.Span {
+	var attributes []tracing.Attribute
+	switch request := request.(type) {
+	case *CreateShardRequest:
+		attributes = append(attributes, tracing.ShardID(int(request.ShardInfo.GetShardId())))
+	case *GetShardRequest:
+		attributes = append(attributes, tracing.ShardID(int(request.ShardID)))
+	case *UpdateShardRequest:
+		attributes = append(attributes, tracing.ShardID(int(request.ShardInfo.GetShardId())))
+	}
+	return startPersistenceSpan(p.tracer, nil, operation, attributes)
+}
+
+func (p *workflowExecutionTracingPersistenceClient) startSpan(
+	operation string,
+	request interface{},
+) tracing.Span {
+	var parent tracing.Span
+	attributes := []tracing.Attribute{tracing.ShardID(p.persistence.GetShardID())}
+	switch request := request.(type) {
+	case *CreateWorkflowExecutionRequest:
+		attributes = append(attributes, executionInfoAttributes(request.NewWorkflowSnapshot.ExecutionInfo)...)
+		parent = request.ParentSpan
+	case *GetWorkflowExecutionRequest:
+		attributes = append(attributes, tracing.NamespaceID(request.NamespaceID), tracing.WorkflowID(request.Execution.GetWorkflowId()))
+		parent = request.ParentSpan
+	case *UpdateWorkflowExecutionRequest:
+		attributes = append(attributes, executionInfoAttributes(request.UpdateWorkflowMutation.ExecutionInfo)...)
+		parent = request.ParentSpan
+	case *ConflictResolveWorkflowExecutionRequest:
+		attributes = append(attributes, executionInfoAttributes(request.ResetWorkflowSnapshot.ExecutionInfo)...)
+		parent = request.ParentSpan
+	case *ResetWorkflowExecutionRequest:
+		attributes = append(attributes, executionInfoAttributes(request.NewWorkflowSnapshot.ExecutionInfo)...)
+		parent = request.ParentSpan
+	case *DeleteWorkflowExecutionRequest:
+		attributes = append(attributes, tracing.NamespaceID(request.NamespaceID), tracing.WorkflowID(request.WorkflowID))
+	case *DeleteCurrentWorkflowExecutionRequest:
+		attributes = append(attributes, tracing.NamespaceID(request.NamespaceID), tracing.WorkflowID(request.WorkflowID))
+	case *GetCurrentExecutionRequest:
+		attributes = append(attributes, tracing.NamespaceID(request.NamespaceID), tracing.WorkflowID(request.WorkflowID))
+	}
+	return startPersistenceSpan(p.tracer, parent, operation, attributes)
+}
+
+func (p *taskTracingPersistenceClient) startSpan(
+	operation string,
+	request interface{},
+) tracing.Span {
+	var attributes []tracing.Attribute
+	switch request := request.(type) {
+	case *GetTasksRequest:
+		attributes = append(attributes, tracing.NamespaceID(request.NamespaceID))
+	case *CompleteTaskRequest:
+		attributes = append(attributes, tracing.NamespaceID(request.TaskList.NamespaceID))
+	case *CompleteTasksLessThanRequest:
+		attributes = append(attributes, tracing.NamespaceID(request.NamespaceID))
+	case *LeaseTaskListRequest:
+		attributes = append(attributes, tracing.NamespaceID(request.NamespaceID))
+	case *DeleteTaskListRequest:
+		attributes = append(attributes, tracing.NamespaceID(request.TaskList.NamespaceID))
+	}
+	return startPersistenceSpan(p.tracer, nil, operation, attributes)
+}
+
+func (p *historyV2TracingPersistenceClient) startSpan(
+	operation string,
+	request interface{},
+) tracing.Span {
+	var parent tracing.Span
+	var shardID *int
+	switch request := request.(type) {
+	case *AppendHistoryNodesRequest:
+		shardID = request.ShardID
+		parent = request.ParentSpan
+	case *ReadHistoryBranchRequest:
+		shardID = request.ShardID
+	case *ForkHistoryBranchRequest:
+		shardID = request.ShardID
+	case *DeleteHistoryBranchRequest:
+		shardID = request.ShardID
+	}
+
+	var attributes []tracing.Attribute
+	if shardID != nil {
+		attributes = append(attributes, tracing.ShardID(*shardID))
+	}
+	return startPersistenceSpan(p.tracer, parent, operation, attributes)
+}
+
+// persistence calls do not carry a context, the span of the operation they are
+// made for is passed on the request and calls made without one are not traced
+func startPersistenceSpan(
+	tracer tracing.Tracer,
+	parent tracing.Span,
+	operation string,
+	attributes []tracing.Attribute,
+) tracing.Span {
+	return tracing.StartChildSpan(tracer, parent, "persistence."+operation, attributes...)
+}
+
+func executionInfoAttributes(
+	executionInfo *WorkflowExecutionInfo,
+) []tracing.Attribute {
+	if executionInfo == nil {
+		return nil
+	}
+	return []tracing.Attribute{
+		tracing.NamespaceID(executionInfo.NamespaceID),
+		tracing.WorkflowID(executionInfo.WorkflowID),
+	}
+}
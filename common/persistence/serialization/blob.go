@@ -106,6 +106,10 @@ func ShardInfoFromBlob(b []byte, proto string, clusterName string) (*persistence
 		shardInfo.ReplicationDLQAckLevel = make(map[string]int64)
 	}
 
+	if shardInfo.GetNamespaceHandoverLevels() == nil {
+		shardInfo.NamespaceHandoverLevels = make(map[string]*persistenceblobs.NamespaceHandoverLevel)
+	}
+
 	return shardInfo, nil
 }
 
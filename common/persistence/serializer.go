@@ -73,6 +73,14 @@ type (
 		// serialize/deserialize immutable cluster metadata
 		SerializeImmutableClusterMetadata(icm *persistenceblobs.ImmutableClusterMetadata, encodingType common.EncodingType) (*serialization.DataBlob, error)
 		DeserializeImmutableClusterMetadata(data *serialization.DataBlob) (*persistenceblobs.ImmutableClusterMetadata, error)
+
+		// serialize/deserialize remote cluster info
+		SerializeRemoteClusterInfo(info *persistenceblobs.RemoteClusterInfo, encodingType common.EncodingType) (*serialization.DataBlob, error)
+		DeserializeRemoteClusterInfo(data *serialization.DataBlob) (*persistenceblobs.RemoteClusterInfo, error)
+
+		// serialize/deserialize dynamic config value
+		SerializeDynamicConfigValue(value *persistenceblobs.DynamicConfigValue, encodingType common.EncodingType) (*serialization.DataBlob, error)
+		DeserializeDynamicConfigValue(data *serialization.DataBlob) (*persistenceblobs.DynamicConfigValue, error)
 	}
 
 	// SerializationError is an error type for serialization
@@ -99,6 +107,13 @@ func NewPayloadSerializer() PayloadSerializer {
 }
 
 func (t *serializerImpl) SerializeBatchEvents(events []*eventpb.HistoryEvent, encodingType common.EncodingType) (*serialization.DataBlob, error) {
+	if IsCompressedEncoding(encodingType) {
+		data, err := t.serialize(&eventpb.History{Events: events}, getCompressionBaseEncoding(encodingType))
+		if err != nil {
+			return nil, err
+		}
+		return compressDataBlob(data, encodingType)
+	}
 	return t.serialize(&eventpb.History{Events: events}, encodingType)
 }
 
@@ -110,8 +125,12 @@ func (t *serializerImpl) DeserializeBatchEvents(data *serialization.DataBlob) ([
 		return nil, nil
 	}
 
+	data, err := DecompressDataBlob(data)
+	if err != nil {
+		return nil, err
+	}
+
 	events := &eventpb.History{}
-	var err error
 	switch data.Encoding {
 	case common.EncodingTypeJSON:
 		err = codec.NewJSONPBEncoder().Decode(data.Data, events)
@@ -340,6 +359,72 @@ func (t *serializerImpl) DeserializeImmutableClusterMetadata(data *serialization
 	return event, err
 }
 
+func (t *serializerImpl) SerializeRemoteClusterInfo(info *persistenceblobs.RemoteClusterInfo, encodingType common.EncodingType) (*serialization.DataBlob, error) {
+	if info == nil {
+		info = &persistenceblobs.RemoteClusterInfo{}
+	}
+	return t.serialize(info, encodingType)
+}
+
+func (t *serializerImpl) DeserializeRemoteClusterInfo(data *serialization.DataBlob) (*persistenceblobs.RemoteClusterInfo, error) {
+	if data == nil {
+		return nil, nil
+	}
+	if len(data.Data) == 0 {
+		return nil, nil
+	}
+
+	info := &persistenceblobs.RemoteClusterInfo{}
+	var err error
+	switch data.Encoding {
+	case common.EncodingTypeJSON:
+		err = codec.NewJSONPBEncoder().Decode(data.Data, info)
+	case common.EncodingTypeProto3:
+		err = proto.Unmarshal(data.Data, info)
+	default:
+		return nil, NewDeserializationError("DeserializeRemoteClusterInfo invalid encoding")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return info, err
+}
+
+func (t *serializerImpl) SerializeDynamicConfigValue(value *persistenceblobs.DynamicConfigValue, encodingType common.EncodingType) (*serialization.DataBlob, error) {
+	if value == nil {
+		value = &persistenceblobs.DynamicConfigValue{}
+	}
+	return t.serialize(value, encodingType)
+}
+
+func (t *serializerImpl) DeserializeDynamicConfigValue(data *serialization.DataBlob) (*persistenceblobs.DynamicConfigValue, error) {
+	if data == nil {
+		return nil, nil
+	}
+	if len(data.Data) == 0 {
+		return nil, nil
+	}
+
+	value := &persistenceblobs.DynamicConfigValue{}
+	var err error
+	switch data.Encoding {
+	case common.EncodingTypeJSON:
+		err = codec.NewJSONPBEncoder().Decode(data.Data, value)
+	case common.EncodingTypeProto3:
+		err = proto.Unmarshal(data.Data, value)
+	default:
+		return nil, NewDeserializationError("DeserializeDynamicConfigValue invalid encoding")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return value, err
+}
+
 func (t *serializerImpl) serializeProto(p proto.Marshaler, encodingType common.EncodingType) (*serialization.DataBlob, error) {
 	if p == nil {
 		return nil, nil
@@ -26,6 +26,7 @@ package persistence
 
 import (
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -43,6 +44,7 @@ import (
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/payload"
 	"github.com/temporalio/temporal/common/payloads"
+	"github.com/temporalio/temporal/common/persistence/serialization"
 )
 
 type (
@@ -423,3 +425,47 @@ func (s *temporalSerializerSuite) TestSerializer() {
 	succ := common.AwaitWaitGroup(&doneWG, 10*time.Second)
 	s.True(succ, "test timed out")
 }
+
+func (s *temporalSerializerSuite) TestSerializeBatchEvents_Compressed() {
+	serializer := NewPayloadSerializer()
+
+	event := &eventpb.HistoryEvent{
+		EventId:   999,
+		Timestamp: time.Now().UnixNano(),
+		EventType: eventpb.EventType_ActivityTaskCompleted,
+		Attributes: &eventpb.HistoryEvent_ActivityTaskCompletedEventAttributes{
+			ActivityTaskCompletedEventAttributes: &eventpb.ActivityTaskCompletedEventAttributes{
+				Result:           payloads.EncodeString(strings.Repeat("result-1-event-1", 100)),
+				ScheduledEventId: 4,
+				StartedEventId:   5,
+				Identity:         "event-1",
+			},
+		},
+	}
+	events := []*eventpb.HistoryEvent{event, event}
+
+	dsProto, err := serializer.SerializeBatchEvents(events, common.EncodingTypeProto3)
+	s.Nil(err)
+
+	for _, encodingType := range []common.EncodingType{common.EncodingTypeProto3Snappy, common.EncodingTypeProto3Zstd} {
+		blob, err := serializer.SerializeBatchEvents(events, encodingType)
+		s.Nil(err)
+		s.Equal(encodingType, blob.Encoding)
+		s.True(len(blob.Data) < len(dsProto.Data))
+
+		dEvents, err := serializer.DeserializeBatchEvents(blob)
+		s.Nil(err)
+		s.True(reflect.DeepEqual(events, dEvents))
+
+		decompressed, err := DecompressDataBlob(blob)
+		s.Nil(err)
+		s.Equal(common.EncodingTypeProto3, decompressed.Encoding)
+		s.Equal(dsProto.Data, decompressed.Data)
+	}
+
+	_, err = serializer.DeserializeBatchEvents(&serialization.DataBlob{
+		Data:     []byte("not compressed"),
+		Encoding: common.EncodingTypeProto3Zstd,
+	})
+	s.NotNil(err)
+}
@@ -26,6 +26,7 @@ package sql
 
 import (
 	"encoding/binary"
+	"fmt"
 	"net"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/temporalio/temporal/common/convert"
 	"github.com/temporalio/temporal/common/log"
 	p "github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/persistence/serialization"
 	"github.com/temporalio/temporal/common/persistence/sql/sqlplugin"
 	"go.temporal.io/temporal-proto/serviceerror"
 )
@@ -95,6 +97,83 @@ func (s *sqlClusterMetadataManager) GetImmutableClusterMetadata() (*p.InternalGe
 	}, nil
 }
 
+func (s *sqlClusterMetadataManager) UpsertRemoteCluster(request *p.InternalUpsertRemoteClusterRequest) error {
+	_, err := s.db.UpsertRemoteCluster(&sqlplugin.RemoteClusterRow{
+		ClusterName:  request.ClusterName,
+		Data:         request.RemoteClusterInfo.Data,
+		DataEncoding: string(request.RemoteClusterInfo.Encoding),
+	})
+	if err != nil {
+		return convertCommonErrors("UpsertRemoteCluster", err)
+	}
+	return nil
+}
+
+func (s *sqlClusterMetadataManager) ListRemoteClusters() (*p.InternalListRemoteClustersResponse, error) {
+	rows, err := s.db.SelectFromRemoteClusters()
+	if err != nil {
+		return nil, convertCommonErrors("ListRemoteClusters", err)
+	}
+
+	remoteClusters := make([]*serialization.DataBlob, 0, len(rows))
+	for _, row := range rows {
+		remoteClusters = append(remoteClusters, p.NewDataBlob(row.Data, common.EncodingType(row.DataEncoding)))
+	}
+	return &p.InternalListRemoteClustersResponse{RemoteClusters: remoteClusters}, nil
+}
+
+func (s *sqlClusterMetadataManager) DeleteRemoteCluster(request *p.DeleteRemoteClusterRequest) error {
+	_, err := s.db.DeleteFromRemoteClusters(request.ClusterName)
+	if err != nil {
+		return convertCommonErrors("DeleteRemoteCluster", err)
+	}
+	return nil
+}
+
+func (s *sqlClusterMetadataManager) InsertDynamicConfigRevision(request *p.InternalInsertDynamicConfigRevisionRequest) error {
+	_, err := s.db.InsertIntoDynamicConfig(&sqlplugin.DynamicConfigRow{
+		Name:         request.Name,
+		Revision:     request.Revision,
+		Data:         request.Value.Data,
+		DataEncoding: string(request.Value.Encoding),
+	})
+	if err != nil {
+		if s.db.IsDupEntryError(err) {
+			return &p.ConditionFailedError{
+				Msg: fmt.Sprintf("Dynamic config %v was concurrently updated, revision %v already exists.", request.Name, request.Revision),
+			}
+		}
+		return convertCommonErrors("InsertDynamicConfigRevision", err)
+	}
+	return nil
+}
+
+func (s *sqlClusterMetadataManager) ListDynamicConfigValues() (*p.InternalListDynamicConfigValuesResponse, error) {
+	rows, err := s.db.SelectLatestFromDynamicConfig()
+	if err != nil {
+		return nil, convertCommonErrors("ListDynamicConfigValues", err)
+	}
+
+	values := make([]*serialization.DataBlob, 0, len(rows))
+	for _, row := range rows {
+		values = append(values, p.NewDataBlob(row.Data, common.EncodingType(row.DataEncoding)))
+	}
+	return &p.InternalListDynamicConfigValuesResponse{Values: values}, nil
+}
+
+func (s *sqlClusterMetadataManager) GetDynamicConfigHistory(request *p.GetDynamicConfigHistoryRequest) (*p.InternalGetDynamicConfigHistoryResponse, error) {
+	rows, err := s.db.SelectFromDynamicConfig(request.Name)
+	if err != nil {
+		return nil, convertCommonErrors("GetDynamicConfigHistory", err)
+	}
+
+	revisions := make([]*serialization.DataBlob, 0, len(rows))
+	for _, row := range rows {
+		revisions = append(revisions, p.NewDataBlob(row.Data, common.EncodingType(row.DataEncoding)))
+	}
+	return &p.InternalGetDynamicConfigHistoryResponse{Revisions: revisions}, nil
+}
+
 func (s *sqlClusterMetadataManager) GetClusterMembers(request *p.GetClusterMembersRequest) (*p.GetClusterMembersResponse, error) {
 	pageToken := uint64(0)
 	if len(request.NextPageToken) > 0 {
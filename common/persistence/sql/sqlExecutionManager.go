@@ -946,6 +946,160 @@ func (m *sqlExecutionManager) RangeDeleteReplicationTaskFromDLQ(
 	return nil
 }
 
+func (m *sqlExecutionManager) PutTransferTaskToDLQ(
+	request *p.PutTransferTaskToDLQRequest,
+) error {
+
+	blob, err := serialization.TransferTaskInfoToBlob(request.TaskInfo)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.db.InsertIntoTransferTasksDLQ(&sqlplugin.QueueTaskDLQRow{
+		ShardID:      m.shardID,
+		TaskID:       request.TaskInfo.GetTaskId(),
+		Data:         blob.Data,
+		DataEncoding: string(blob.Encoding),
+	})
+	// Tasks are immutable. So it's fine if we already persisted it before.
+	if err != nil && !m.db.IsDupEntryError(err) {
+		return serviceerror.NewInternal(fmt.Sprintf("PutTransferTaskToDLQ operation failed. Error: %v", err))
+	}
+	return nil
+}
+
+func (m *sqlExecutionManager) GetTransferTasksFromDLQ(
+	request *p.GetTransferTasksFromDLQRequest,
+) (*p.GetTransferTasksFromDLQResponse, error) {
+
+	filter, err := m.getQueueTaskDLQFilter(request.ReadLevel, request.MaxReadLevel, request.BatchSize, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := m.db.SelectFromTransferTasksDLQ(filter)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("GetTransferTasksFromDLQ operation failed. Select failed: %v", err))
+	}
+
+	resp := &p.GetTransferTasksFromDLQResponse{Tasks: make([]*persistenceblobs.TransferTaskInfo, len(rows))}
+	for i, row := range rows {
+		info, err := serialization.TransferTaskInfoFromBlob(row.Data, row.DataEncoding)
+		if err != nil {
+			return nil, err
+		}
+		resp.Tasks[i] = info
+	}
+	resp.NextPageToken = getQueueTaskDLQNextPageToken(rows, request.BatchSize)
+	return resp, nil
+}
+
+func (m *sqlExecutionManager) RangeDeleteTransferTaskFromDLQ(
+	request *p.RangeDeleteTransferTaskFromDLQRequest,
+) error {
+
+	if _, err := m.db.RangeDeleteFromTransferTasksDLQ(&sqlplugin.QueueTaskDLQFilter{
+		ShardID:   m.shardID,
+		MinTaskID: request.ExclusiveBeginTaskID,
+		MaxTaskID: request.InclusiveEndTaskID,
+	}); err != nil {
+		return serviceerror.NewInternal(fmt.Sprintf("RangeDeleteTransferTaskFromDLQ operation failed. Error: %v", err))
+	}
+	return nil
+}
+
+func (m *sqlExecutionManager) PutTimerTaskToDLQ(
+	request *p.PutTimerTaskToDLQRequest,
+) error {
+
+	blob, err := serialization.TimerTaskInfoToBlob(request.TaskInfo)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.db.InsertIntoTimerTasksDLQ(&sqlplugin.QueueTaskDLQRow{
+		ShardID:      m.shardID,
+		TaskID:       request.TaskInfo.GetTaskId(),
+		Data:         blob.Data,
+		DataEncoding: string(blob.Encoding),
+	})
+	// Tasks are immutable. So it's fine if we already persisted it before.
+	if err != nil && !m.db.IsDupEntryError(err) {
+		return serviceerror.NewInternal(fmt.Sprintf("PutTimerTaskToDLQ operation failed. Error: %v", err))
+	}
+	return nil
+}
+
+func (m *sqlExecutionManager) GetTimerTasksFromDLQ(
+	request *p.GetTimerTasksFromDLQRequest,
+) (*p.GetTimerTasksFromDLQResponse, error) {
+
+	filter, err := m.getQueueTaskDLQFilter(request.ReadLevel, request.MaxReadLevel, request.BatchSize, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := m.db.SelectFromTimerTasksDLQ(filter)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, serviceerror.NewInternal(fmt.Sprintf("GetTimerTasksFromDLQ operation failed. Select failed: %v", err))
+	}
+
+	resp := &p.GetTimerTasksFromDLQResponse{Timers: make([]*persistenceblobs.TimerTaskInfo, len(rows))}
+	for i, row := range rows {
+		info, err := serialization.TimerTaskInfoFromBlob(row.Data, row.DataEncoding)
+		if err != nil {
+			return nil, err
+		}
+		resp.Timers[i] = info
+	}
+	resp.NextPageToken = getQueueTaskDLQNextPageToken(rows, request.BatchSize)
+	return resp, nil
+}
+
+func (m *sqlExecutionManager) RangeDeleteTimerTaskFromDLQ(
+	request *p.RangeDeleteTimerTaskFromDLQRequest,
+) error {
+
+	if _, err := m.db.RangeDeleteFromTimerTasksDLQ(&sqlplugin.QueueTaskDLQFilter{
+		ShardID:   m.shardID,
+		MinTaskID: request.ExclusiveBeginTaskID,
+		MaxTaskID: request.InclusiveEndTaskID,
+	}); err != nil {
+		return serviceerror.NewInternal(fmt.Sprintf("RangeDeleteTimerTaskFromDLQ operation failed. Error: %v", err))
+	}
+	return nil
+}
+
+func (m *sqlExecutionManager) getQueueTaskDLQFilter(
+	readLevel int64,
+	maxReadLevel int64,
+	batchSize int,
+	pageToken []byte,
+) (*sqlplugin.QueueTaskDLQFilter, error) {
+
+	if len(pageToken) > 0 {
+		var err error
+		if readLevel, err = deserializePageToken(pageToken); err != nil {
+			return nil, err
+		}
+	}
+	return &sqlplugin.QueueTaskDLQFilter{
+		ShardID:   m.shardID,
+		MinTaskID: readLevel,
+		MaxTaskID: maxReadLevel,
+		PageSize:  batchSize,
+	}, nil
+}
+
+func getQueueTaskDLQNextPageToken(
+	rows []sqlplugin.QueueTaskDLQRow,
+	batchSize int,
+) []byte {
+
+	if len(rows) == 0 || len(rows) < batchSize {
+		return nil
+	}
+	return serializePageToken(rows[len(rows)-1].TaskID)
+}
+
 type timerTaskPageToken struct {
 	TaskID    int64
 	Timestamp time.Time
@@ -741,7 +741,8 @@ func createTransferTasks(
 		case commongenpb.TaskType_TransferCloseExecution,
 			commongenpb.TaskType_TransferRecordWorkflowStarted,
 			commongenpb.TaskType_TransferResetWorkflow,
-			commongenpb.TaskType_TransferUpsertWorkflowSearchAttributes:
+			commongenpb.TaskType_TransferUpsertWorkflowSearchAttributes,
+			commongenpb.TaskType_TransferWorkflowCompletionCallback:
 			// No explicit property needs to be set
 
 		default:
@@ -49,6 +49,21 @@ type (
 		ImmutableDataEncoding string
 	}
 
+	// RemoteClusterRow represents a row in the remote_clusters table
+	RemoteClusterRow struct {
+		ClusterName  string
+		Data         []byte
+		DataEncoding string
+	}
+
+	// DynamicConfigRow represents a row in the dynamic_config table
+	DynamicConfigRow struct {
+		Name         string
+		Revision     int64
+		Data         []byte
+		DataEncoding string
+	}
+
 	// ClusterMembershipRow represents a row in the cluster_membership table
 	ClusterMembershipRow struct {
 		Role           persistence.ServiceType
@@ -287,6 +302,23 @@ type (
 		SourceClusterName string
 	}
 
+	// QueueTaskDLQRow represents a row in transfer_tasks_dlq or timer_tasks_dlq table
+	QueueTaskDLQRow struct {
+		ShardID      int
+		TaskID       int64
+		Data         []byte
+		DataEncoding string
+	}
+
+	// QueueTaskDLQFilter contains the column names within transfer_tasks_dlq and timer_tasks_dlq
+	// tables that can be used to filter results through a WHERE clause
+	QueueTaskDLQFilter struct {
+		ShardID   int
+		MinTaskID int64
+		MaxTaskID int64
+		PageSize  int
+	}
+
 	// TimerTasksRow represents a row in timer_tasks table
 	TimerTasksRow struct {
 		ShardID             int
@@ -543,6 +575,14 @@ type (
 	tableCRUD interface {
 		InsertIfNotExistsIntoClusterMetadata(row *ClusterMetadataRow) (sql.Result, error)
 		GetClusterMetadata() (*ClusterMetadataRow, error)
+		UpsertRemoteCluster(row *RemoteClusterRow) (sql.Result, error)
+		SelectFromRemoteClusters() ([]RemoteClusterRow, error)
+		DeleteFromRemoteClusters(clusterName string) (sql.Result, error)
+		InsertIntoDynamicConfig(row *DynamicConfigRow) (sql.Result, error)
+		// SelectLatestFromDynamicConfig returns the latest revision of every key
+		SelectLatestFromDynamicConfig() ([]DynamicConfigRow, error)
+		// SelectFromDynamicConfig returns all revisions of a key, latest first
+		SelectFromDynamicConfig(name string) ([]DynamicConfigRow, error)
 		GetClusterMembers(filter *ClusterMembershipFilter) ([]ClusterMembershipRow, error)
 		UpsertClusterMembership(row *ClusterMembershipRow) (sql.Result, error)
 		PruneClusterMembership(filter *PruneClusterMembershipFilter) (sql.Result, error)
@@ -664,6 +704,22 @@ type (
 		// RangeDeleteMessageFromReplicationTasksDLQ deletes one or more rows from replication_tasks_dlq table
 		// Required filter params - {sourceClusterName, shardID, taskID, inclusiveTaskID}
 		RangeDeleteMessageFromReplicationTasksDLQ(filter *ReplicationTasksDLQFilter) (sql.Result, error)
+		// InsertIntoTransferTasksDLQ puts the transfer task into DLQ
+		InsertIntoTransferTasksDLQ(row *QueueTaskDLQRow) (sql.Result, error)
+		// SelectFromTransferTasksDLQ returns one or more rows from transfer_tasks_dlq table
+		// Required filter params - {shardID, minTaskID, maxTaskID, pageSize}
+		SelectFromTransferTasksDLQ(filter *QueueTaskDLQFilter) ([]QueueTaskDLQRow, error)
+		// RangeDeleteFromTransferTasksDLQ deletes one or more rows from transfer_tasks_dlq table
+		// Required filter params - {shardID, minTaskID, maxTaskID}
+		RangeDeleteFromTransferTasksDLQ(filter *QueueTaskDLQFilter) (sql.Result, error)
+		// InsertIntoTimerTasksDLQ puts the timer task into DLQ
+		InsertIntoTimerTasksDLQ(row *QueueTaskDLQRow) (sql.Result, error)
+		// SelectFromTimerTasksDLQ returns one or more rows from timer_tasks_dlq table
+		// Required filter params - {shardID, minTaskID, maxTaskID, pageSize}
+		SelectFromTimerTasksDLQ(filter *QueueTaskDLQFilter) ([]QueueTaskDLQRow, error)
+		// RangeDeleteFromTimerTasksDLQ deletes one or more rows from timer_tasks_dlq table
+		// Required filter params - {shardID, minTaskID, maxTaskID}
+		RangeDeleteFromTimerTasksDLQ(filter *QueueTaskDLQFilter) (sql.Result, error)
 
 		ReplaceIntoActivityInfoMaps(rows []ActivityInfoMapsRow) (sql.Result, error)
 		// SelectFromActivityInfoMaps returns one or more rows from activity_info_maps
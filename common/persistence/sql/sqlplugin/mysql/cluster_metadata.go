@@ -44,6 +44,31 @@ VALUES(?, ?, ?)`
 	getImmutableClusterMetadataQry = `SELECT immutable_data, immutable_data_encoding FROM 
 cluster_metadata WHERE metadata_partition = ?`
 
+	// ****** REMOTE_CLUSTERS TABLE ******
+	upsertRemoteClusterQry = `REPLACE INTO
+remote_clusters (metadata_partition, cluster_name, data, data_encoding)
+VALUES(?, ?, ?, ?)`
+
+	getRemoteClustersQry = `SELECT cluster_name, data, data_encoding FROM
+remote_clusters WHERE metadata_partition = ?`
+
+	deleteRemoteClusterQry = `DELETE FROM
+remote_clusters WHERE metadata_partition = ? AND cluster_name = ?`
+
+	// ****** DYNAMIC_CONFIG TABLE ******
+	insertDynamicConfigQry = `INSERT INTO
+dynamic_config (metadata_partition, name, revision, data, data_encoding)
+VALUES(?, ?, ?, ?, ?)`
+
+	getLatestDynamicConfigQry = `SELECT d.name, d.revision, d.data, d.data_encoding FROM
+dynamic_config d INNER JOIN (
+SELECT name, MAX(revision) AS revision FROM dynamic_config WHERE metadata_partition = ? GROUP BY name) l
+ON d.name = l.name AND d.revision = l.revision
+WHERE d.metadata_partition = ? ORDER BY d.name`
+
+	getDynamicConfigHistoryQry = `SELECT name, revision, data, data_encoding FROM
+dynamic_config WHERE metadata_partition = ? AND name = ? ORDER BY revision DESC`
+
 	// ****** CLUSTER_MEMBERSHIP TABLE ******
 	templateUpsertActiveClusterMembership = `REPLACE INTO
 cluster_membership (host_id, rpc_address, rpc_port, role, session_start, last_heartbeat, record_expiry)
@@ -87,6 +112,45 @@ func (mdb *db) GetClusterMetadata() (*sqlplugin.ClusterMetadataRow, error) {
 	return &row, err
 }
 
+func (mdb *db) UpsertRemoteCluster(row *sqlplugin.RemoteClusterRow) (sql.Result, error) {
+	return mdb.conn.Exec(upsertRemoteClusterQry,
+		constMetadataPartition,
+		row.ClusterName,
+		row.Data,
+		row.DataEncoding)
+}
+
+func (mdb *db) SelectFromRemoteClusters() ([]sqlplugin.RemoteClusterRow, error) {
+	var rows []sqlplugin.RemoteClusterRow
+	err := mdb.conn.Select(&rows, getRemoteClustersQry, constMetadataPartition)
+	return rows, err
+}
+
+func (mdb *db) DeleteFromRemoteClusters(clusterName string) (sql.Result, error) {
+	return mdb.conn.Exec(deleteRemoteClusterQry, constMetadataPartition, clusterName)
+}
+
+func (mdb *db) InsertIntoDynamicConfig(row *sqlplugin.DynamicConfigRow) (sql.Result, error) {
+	return mdb.conn.Exec(insertDynamicConfigQry,
+		constMetadataPartition,
+		row.Name,
+		row.Revision,
+		row.Data,
+		row.DataEncoding)
+}
+
+func (mdb *db) SelectLatestFromDynamicConfig() ([]sqlplugin.DynamicConfigRow, error) {
+	var rows []sqlplugin.DynamicConfigRow
+	err := mdb.conn.Select(&rows, getLatestDynamicConfigQry, constMetadataPartition, constMetadataPartition)
+	return rows, err
+}
+
+func (mdb *db) SelectFromDynamicConfig(name string) ([]sqlplugin.DynamicConfigRow, error) {
+	var rows []sqlplugin.DynamicConfigRow
+	err := mdb.conn.Select(&rows, getDynamicConfigHistoryQry, constMetadataPartition, name)
+	return rows, err
+}
+
 func (mdb *db) UpsertClusterMembership(row *sqlplugin.ClusterMembershipRow) (sql.Result, error) {
 	return mdb.conn.Exec(templateUpsertActiveClusterMembership,
 		row.HostID,
@@ -156,6 +156,28 @@ VALUES     (:source_cluster_name,
 		AND shard_id = ? 
 		AND task_id > ?
 		AND task_id <= ?`
+
+	insertTransferTaskDLQQuery = `INSERT INTO transfer_tasks_dlq (shard_id, task_id, data, data_encoding)
+VALUES (:shard_id, :task_id, :data, :data_encoding)`
+
+	getTransferTasksDLQQuery = `SELECT task_id, data, data_encoding FROM transfer_tasks_dlq WHERE
+shard_id = ? AND
+task_id > ? AND
+task_id <= ?
+ORDER BY task_id LIMIT ?`
+
+	rangeDeleteTransferTaskFromDLQQuery = `DELETE FROM transfer_tasks_dlq WHERE shard_id = ? AND task_id > ? AND task_id <= ?`
+
+	insertTimerTaskDLQQuery = `INSERT INTO timer_tasks_dlq (shard_id, task_id, data, data_encoding)
+VALUES (:shard_id, :task_id, :data, :data_encoding)`
+
+	getTimerTasksDLQQuery = `SELECT task_id, data, data_encoding FROM timer_tasks_dlq WHERE
+shard_id = ? AND
+task_id > ? AND
+task_id <= ?
+ORDER BY task_id LIMIT ?`
+
+	rangeDeleteTimerTaskFromDLQQuery = `DELETE FROM timer_tasks_dlq WHERE shard_id = ? AND task_id > ? AND task_id <= ?`
 )
 
 // InsertIntoExecutions inserts a row into executions table
@@ -381,3 +403,57 @@ func (mdb *db) RangeDeleteMessageFromReplicationTasksDLQ(
 		filter.InclusiveEndTaskID,
 	)
 }
+
+// InsertIntoTransferTasksDLQ inserts a row into transfer_tasks_dlq table
+func (mdb *db) InsertIntoTransferTasksDLQ(row *sqlplugin.QueueTaskDLQRow) (sql.Result, error) {
+	return mdb.conn.NamedExec(insertTransferTaskDLQQuery, row)
+}
+
+// SelectFromTransferTasksDLQ reads one or more rows from transfer_tasks_dlq table
+func (mdb *db) SelectFromTransferTasksDLQ(filter *sqlplugin.QueueTaskDLQFilter) ([]sqlplugin.QueueTaskDLQRow, error) {
+	var rows []sqlplugin.QueueTaskDLQRow
+	err := mdb.conn.Select(
+		&rows, getTransferTasksDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+		filter.PageSize)
+	return rows, err
+}
+
+// RangeDeleteFromTransferTasksDLQ deletes one or more rows from transfer_tasks_dlq table
+func (mdb *db) RangeDeleteFromTransferTasksDLQ(filter *sqlplugin.QueueTaskDLQFilter) (sql.Result, error) {
+	return mdb.conn.Exec(
+		rangeDeleteTransferTaskFromDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+	)
+}
+
+// InsertIntoTimerTasksDLQ inserts a row into timer_tasks_dlq table
+func (mdb *db) InsertIntoTimerTasksDLQ(row *sqlplugin.QueueTaskDLQRow) (sql.Result, error) {
+	return mdb.conn.NamedExec(insertTimerTaskDLQQuery, row)
+}
+
+// SelectFromTimerTasksDLQ reads one or more rows from timer_tasks_dlq table
+func (mdb *db) SelectFromTimerTasksDLQ(filter *sqlplugin.QueueTaskDLQFilter) ([]sqlplugin.QueueTaskDLQRow, error) {
+	var rows []sqlplugin.QueueTaskDLQRow
+	err := mdb.conn.Select(
+		&rows, getTimerTasksDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+		filter.PageSize)
+	return rows, err
+}
+
+// RangeDeleteFromTimerTasksDLQ deletes one or more rows from timer_tasks_dlq table
+func (mdb *db) RangeDeleteFromTimerTasksDLQ(filter *sqlplugin.QueueTaskDLQFilter) (sql.Result, error) {
+	return mdb.conn.Exec(
+		rangeDeleteTimerTaskFromDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+	)
+}
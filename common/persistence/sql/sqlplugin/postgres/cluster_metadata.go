@@ -48,6 +48,33 @@ ON CONFLICT DO NOTHING`
 	getImmutableClusterMetadataQry = `SELECT immutable_data, immutable_data_encoding FROM 
 cluster_metadata WHERE metadata_partition = $1`
 
+	// ****** REMOTE_CLUSTERS TABLE ******
+	upsertRemoteClusterQry = `INSERT INTO
+remote_clusters (metadata_partition, cluster_name, data, data_encoding)
+VALUES($1, $2, $3, $4)
+ON CONFLICT(metadata_partition, cluster_name)
+DO UPDATE SET data = $3, data_encoding = $4`
+
+	getRemoteClustersQry = `SELECT cluster_name, data, data_encoding FROM
+remote_clusters WHERE metadata_partition = $1`
+
+	deleteRemoteClusterQry = `DELETE FROM
+remote_clusters WHERE metadata_partition = $1 AND cluster_name = $2`
+
+	// ****** DYNAMIC_CONFIG TABLE ******
+	insertDynamicConfigQry = `INSERT INTO
+dynamic_config (metadata_partition, name, revision, data, data_encoding)
+VALUES($1, $2, $3, $4, $5)`
+
+	getLatestDynamicConfigQry = `SELECT d.name, d.revision, d.data, d.data_encoding FROM
+dynamic_config d INNER JOIN (
+SELECT name, MAX(revision) AS revision FROM dynamic_config WHERE metadata_partition = $1 GROUP BY name) l
+ON d.name = l.name AND d.revision = l.revision
+WHERE d.metadata_partition = $1 ORDER BY d.name`
+
+	getDynamicConfigHistoryQry = `SELECT name, revision, data, data_encoding FROM
+dynamic_config WHERE metadata_partition = $1 AND name = $2 ORDER BY revision DESC`
+
 	// ****** CLUSTER_MEMBERSHIP TABLE ******
 	templateUpsertActiveClusterMembership = `INSERT INTO
 cluster_membership (host_id, rpc_address, rpc_port, role, session_start, last_heartbeat, record_expiry)
@@ -95,6 +122,45 @@ func (pdb *db) GetClusterMetadata() (*sqlplugin.ClusterMetadataRow, error) {
 	return &row, err
 }
 
+func (pdb *db) UpsertRemoteCluster(row *sqlplugin.RemoteClusterRow) (sql.Result, error) {
+	return pdb.conn.Exec(upsertRemoteClusterQry,
+		constMetadataPartition,
+		row.ClusterName,
+		row.Data,
+		row.DataEncoding)
+}
+
+func (pdb *db) SelectFromRemoteClusters() ([]sqlplugin.RemoteClusterRow, error) {
+	var rows []sqlplugin.RemoteClusterRow
+	err := pdb.conn.Select(&rows, getRemoteClustersQry, constMetadataPartition)
+	return rows, err
+}
+
+func (pdb *db) DeleteFromRemoteClusters(clusterName string) (sql.Result, error) {
+	return pdb.conn.Exec(deleteRemoteClusterQry, constMetadataPartition, clusterName)
+}
+
+func (pdb *db) InsertIntoDynamicConfig(row *sqlplugin.DynamicConfigRow) (sql.Result, error) {
+	return pdb.conn.Exec(insertDynamicConfigQry,
+		constMetadataPartition,
+		row.Name,
+		row.Revision,
+		row.Data,
+		row.DataEncoding)
+}
+
+func (pdb *db) SelectLatestFromDynamicConfig() ([]sqlplugin.DynamicConfigRow, error) {
+	var rows []sqlplugin.DynamicConfigRow
+	err := pdb.conn.Select(&rows, getLatestDynamicConfigQry, constMetadataPartition)
+	return rows, err
+}
+
+func (pdb *db) SelectFromDynamicConfig(name string) ([]sqlplugin.DynamicConfigRow, error) {
+	var rows []sqlplugin.DynamicConfigRow
+	err := pdb.conn.Select(&rows, getDynamicConfigHistoryQry, constMetadataPartition, name)
+	return rows, err
+}
+
 func (pdb *db) UpsertClusterMembership(row *sqlplugin.ClusterMembershipRow) (sql.Result, error) {
 	return pdb.conn.Exec(templateUpsertActiveClusterMembership,
 		row.HostID,
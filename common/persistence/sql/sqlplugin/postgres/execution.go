@@ -155,6 +155,28 @@ VALUES     (:source_cluster_name,
 		AND shard_id = $2 
 		AND task_id > $3
 		AND task_id <= $4`
+
+	insertTransferTaskDLQQuery = `INSERT INTO transfer_tasks_dlq (shard_id, task_id, data, data_encoding)
+VALUES (:shard_id, :task_id, :data, :data_encoding)`
+
+	getTransferTasksDLQQuery = `SELECT task_id, data, data_encoding FROM transfer_tasks_dlq WHERE
+shard_id = $1 AND
+task_id > $2 AND
+task_id <= $3
+ORDER BY task_id LIMIT $4`
+
+	rangeDeleteTransferTaskFromDLQQuery = `DELETE FROM transfer_tasks_dlq WHERE shard_id = $1 AND task_id > $2 AND task_id <= $3`
+
+	insertTimerTaskDLQQuery = `INSERT INTO timer_tasks_dlq (shard_id, task_id, data, data_encoding)
+VALUES (:shard_id, :task_id, :data, :data_encoding)`
+
+	getTimerTasksDLQQuery = `SELECT task_id, data, data_encoding FROM timer_tasks_dlq WHERE
+shard_id = $1 AND
+task_id > $2 AND
+task_id <= $3
+ORDER BY task_id LIMIT $4`
+
+	rangeDeleteTimerTaskFromDLQQuery = `DELETE FROM timer_tasks_dlq WHERE shard_id = $1 AND task_id > $2 AND task_id <= $3`
 )
 
 // InsertIntoExecutions inserts a row into executions table
@@ -380,3 +402,57 @@ func (pdb *db) RangeDeleteMessageFromReplicationTasksDLQ(
 		filter.InclusiveEndTaskID,
 	)
 }
+
+// InsertIntoTransferTasksDLQ inserts a row into transfer_tasks_dlq table
+func (pdb *db) InsertIntoTransferTasksDLQ(row *sqlplugin.QueueTaskDLQRow) (sql.Result, error) {
+	return pdb.conn.NamedExec(insertTransferTaskDLQQuery, row)
+}
+
+// SelectFromTransferTasksDLQ reads one or more rows from transfer_tasks_dlq table
+func (pdb *db) SelectFromTransferTasksDLQ(filter *sqlplugin.QueueTaskDLQFilter) ([]sqlplugin.QueueTaskDLQRow, error) {
+	var rows []sqlplugin.QueueTaskDLQRow
+	err := pdb.conn.Select(
+		&rows, getTransferTasksDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+		filter.PageSize)
+	return rows, err
+}
+
+// RangeDeleteFromTransferTasksDLQ deletes one or more rows from transfer_tasks_dlq table
+func (pdb *db) RangeDeleteFromTransferTasksDLQ(filter *sqlplugin.QueueTaskDLQFilter) (sql.Result, error) {
+	return pdb.conn.Exec(
+		rangeDeleteTransferTaskFromDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+	)
+}
+
+// InsertIntoTimerTasksDLQ inserts a row into timer_tasks_dlq table
+func (pdb *db) InsertIntoTimerTasksDLQ(row *sqlplugin.QueueTaskDLQRow) (sql.Result, error) {
+	return pdb.conn.NamedExec(insertTimerTaskDLQQuery, row)
+}
+
+// SelectFromTimerTasksDLQ reads one or more rows from timer_tasks_dlq table
+func (pdb *db) SelectFromTimerTasksDLQ(filter *sqlplugin.QueueTaskDLQFilter) ([]sqlplugin.QueueTaskDLQRow, error) {
+	var rows []sqlplugin.QueueTaskDLQRow
+	err := pdb.conn.Select(
+		&rows, getTimerTasksDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+		filter.PageSize)
+	return rows, err
+}
+
+// RangeDeleteFromTimerTasksDLQ deletes one or more rows from timer_tasks_dlq table
+func (pdb *db) RangeDeleteFromTimerTasksDLQ(filter *sqlplugin.QueueTaskDLQFilter) (sql.Result, error) {
+	return pdb.conn.Exec(
+		rangeDeleteTimerTaskFromDLQQuery,
+		filter.ShardID,
+		filter.MinTaskID,
+		filter.MaxTaskID,
+	)
+}
@@ -0,0 +1,254 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sqlite
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	p "github.com/temporalio/temporal/common/persistence"
+
+	"github.com/temporalio/temporal/common/persistence/sql/sqlplugin"
+)
+
+const constMetadataPartition = 0
+const (
+	// ****** CLUSTER_METADATA TABLE ******
+	// One time only for the immutable data, so just use idempotent insert that does nothing if a record exists
+	insertClusterMetadataOneTimeOnlyQry = `INSERT INTO 
+cluster_metadata (metadata_partition, immutable_data, immutable_data_encoding)
+VALUES(?1, ?2, ?3)
+ON CONFLICT DO NOTHING`
+
+	getImmutableClusterMetadataQry = `SELECT immutable_data, immutable_data_encoding FROM 
+cluster_metadata WHERE metadata_partition = ?1`
+
+	// ****** REMOTE_CLUSTERS TABLE ******
+	upsertRemoteClusterQry = `INSERT INTO
+remote_clusters (metadata_partition, cluster_name, data, data_encoding)
+VALUES(?1, ?2, ?3, ?4)
+ON CONFLICT(metadata_partition, cluster_name)
+DO UPDATE SET data = ?3, data_encoding = ?4`
+
+	getRemoteClustersQry = `SELECT cluster_name, data, data_encoding FROM
+remote_clusters WHERE metadata_partition = ?1`
+
+	deleteRemoteClusterQry = `DELETE FROM
+remote_clusters WHERE metadata_partition = ?1 AND cluster_name = ?2`
+
+	// ****** DYNAMIC_CONFIG TABLE ******
+	insertDynamicConfigQry = `INSERT INTO
+dynamic_config (metadata_partition, name, revision, data, data_encoding)
+VALUES(?1, ?2, ?3, ?4, ?5)`
+
+	getLatestDynamicConfigQry = `SELECT d.name, d.revision, d.data, d.data_encoding FROM
+dynamic_config d INNER JOIN (
+SELECT name, MAX(revision) AS revision FROM dynamic_config WHERE metadata_partition = ?1 GROUP BY name) l
+ON d.name = l.name AND d.revision = l.revision
+WHERE d.metadata_partition = ?1 ORDER BY d.name`
+
+	getDynamicConfigHistoryQry = `SELECT name, revision, data, data_encoding FROM
+dynamic_config WHERE metadata_partition = ?1 AND name = ?2 ORDER BY revision DESC`
+
+	// ****** CLUSTER_MEMBERSHIP TABLE ******
+	// SQLite only allows one auto increment column per table, which is the primary key,
+	// so insertion_order is assigned on insert and kept by updates
+	templateUpsertActiveClusterMembership = `INSERT INTO
+cluster_membership (host_id, rpc_address, rpc_port, role, session_start, last_heartbeat, record_expiry, insertion_order)
+VALUES(?1, ?2, ?3, ?4, ?5, ?6, ?7, (SELECT IFNULL(MAX(insertion_order), 0) + 1 FROM cluster_membership))
+ON CONFLICT(host_id)
+DO UPDATE SET 
+rpc_address = ?2, rpc_port = ?3, role = ?4, session_start = ?5, last_heartbeat = ?6, record_expiry = ?7`
+
+	templatePruneStaleClusterMembership = `DELETE FROM
+cluster_membership 
+WHERE host_id IN (
+SELECT host_id FROM cluster_membership WHERE record_expiry < ?1 LIMIT ?2)`
+
+	templateGetClusterMembership = `SELECT host_id, rpc_address, rpc_port, role, session_start, last_heartbeat, record_expiry, insertion_order FROM
+cluster_membership`
+
+	// ClusterMembership WHERE Suffixes
+	templateWithRoleSuffix           = ` AND role = ?`
+	templateWithHeartbeatSinceSuffix = ` AND last_heartbeat > ?`
+	templateWithRecordExpirySuffix   = ` AND record_expiry > ?`
+	templateWithRPCAddressSuffix     = ` AND rpc_address = ?`
+	templateWithHostIDSuffix         = ` AND host_id = ?`
+	templateWithSessionStartSuffix   = ` AND session_start > ?`
+	templateWithInsertionOrderSuffix = ` AND insertion_order > ?`
+
+	// Generic SELECT Suffixes
+	templateWithLimitSuffix            = ` LIMIT ?`
+	templateWithOrderByInsertionSuffix = ` ORDER BY insertion_order ASC`
+)
+
+// Does not follow traditional lock, select, read, insert as we only expect a single row.
+func (pdb *db) InsertIfNotExistsIntoClusterMetadata(row *sqlplugin.ClusterMetadataRow) (sql.Result, error) {
+	return pdb.conn.Exec(insertClusterMetadataOneTimeOnlyQry,
+		constMetadataPartition,
+		row.ImmutableData,
+		row.ImmutableDataEncoding)
+}
+
+func (pdb *db) GetClusterMetadata() (*sqlplugin.ClusterMetadataRow, error) {
+	var row sqlplugin.ClusterMetadataRow
+	err := pdb.conn.Get(&row, getImmutableClusterMetadataQry, constMetadataPartition)
+	if err != nil {
+		return nil, err
+	}
+	return &row, err
+}
+
+func (pdb *db) UpsertRemoteCluster(row *sqlplugin.RemoteClusterRow) (sql.Result, error) {
+	return pdb.conn.Exec(upsertRemoteClusterQry,
+		constMetadataPartition,
+		row.ClusterName,
+		row.Data,
+		row.DataEncoding)
+}
+
+func (pdb *db) SelectFromRemoteClusters() ([]sqlplugin.RemoteClusterRow, error) {
+	var rows []sqlplugin.RemoteClusterRow
+	err := pdb.conn.Select(&rows, getRemoteClustersQry, constMetadataPartition)
+	return rows, err
+}
+
+func (pdb *db) DeleteFromRemoteClusters(clusterName string) (sql.Result, error) {
+	return pdb.conn.Exec(deleteRemoteClusterQry, constMetadataPartition, clusterName)
+}
+
+func (pdb *db) InsertIntoDynamicConfig(row *sqlplugin.DynamicConfigRow) (sql.Result, error) {
+	return pdb.conn.Exec(insertDynamicConfigQry,
+		constMetadataPartition,
+		row.Name,
+		row.Revision,
+		row.Data,
+		row.DataEncoding)
+}
+
+func (pdb *db) SelectLatestFromDynamicConfig() ([]sqlplugin.DynamicConfigRow, error) {
+	var rows []sqlplugin.DynamicConfigRow
+	err := pdb.conn.Select(&rows, getLatestDynamicConfigQry, constMetadataPartition)
+	return rows, err
+}
+
+func (pdb *db) SelectFromDynamicConfig(name string) ([]sqlplugin.DynamicConfigRow, error) {
+	var rows []sqlplugin.DynamicConfigRow
+	err := pdb.conn.Select(&rows, getDynamicConfigHistoryQry, constMetadataPartition, name)
+	return rows, err
+}
+
+func (pdb *db) UpsertClusterMembership(row *sqlplugin.ClusterMembershipRow) (sql.Result, error) {
+	return pdb.conn.Exec(templateUpsertActiveClusterMembership,
+		row.HostID,
+		row.RPCAddress,
+		row.RPCPort,
+		row.Role,
+		pdb.converter.ToSQLiteDateTime(row.SessionStart),
+		pdb.converter.ToSQLiteDateTime(row.LastHeartbeat),
+		pdb.converter.ToSQLiteDateTime(row.RecordExpiry))
+}
+
+func (pdb *db) GetClusterMembers(filter *sqlplugin.ClusterMembershipFilter) ([]sqlplugin.ClusterMembershipRow, error) {
+	var queryString strings.Builder
+	var operands []interface{}
+	queryString.WriteString(templateGetClusterMembership)
+
+	if filter.HostIDEquals != nil {
+		queryString.WriteString(templateWithHostIDSuffix)
+		operands = append(operands, filter.HostIDEquals)
+		queryString.WriteString(strconv.Itoa(len(operands)))
+	}
+
+	if filter.RPCAddressEquals != "" {
+		queryString.WriteString(templateWithRPCAddressSuffix)
+		operands = append(operands, filter.RPCAddressEquals)
+		queryString.WriteString(strconv.Itoa(len(operands)))
+	}
+
+	if filter.RoleEquals != p.All {
+		queryString.WriteString(templateWithRoleSuffix)
+		operands = append(operands, filter.RoleEquals)
+		queryString.WriteString(strconv.Itoa(len(operands)))
+	}
+
+	if !filter.LastHeartbeatAfter.IsZero() {
+		queryString.WriteString(templateWithHeartbeatSinceSuffix)
+		operands = append(operands, pdb.converter.ToSQLiteDateTime(filter.LastHeartbeatAfter))
+		queryString.WriteString(strconv.Itoa(len(operands)))
+	}
+
+	if !filter.RecordExpiryAfter.IsZero() {
+		queryString.WriteString(templateWithRecordExpirySuffix)
+		operands = append(operands, pdb.converter.ToSQLiteDateTime(filter.RecordExpiryAfter))
+		queryString.WriteString(strconv.Itoa(len(operands)))
+	}
+
+	if !filter.SessionStartedAfter.IsZero() {
+		queryString.WriteString(templateWithSessionStartSuffix)
+		operands = append(operands, pdb.converter.ToSQLiteDateTime(filter.SessionStartedAfter))
+		queryString.WriteString(strconv.Itoa(len(operands)))
+	}
+
+	if filter.InsertionOrderGreaterThan > 0 {
+		queryString.WriteString(templateWithInsertionOrderSuffix)
+		operands = append(operands, filter.InsertionOrderGreaterThan)
+		queryString.WriteString(strconv.Itoa(len(operands)))
+	}
+
+	queryString.WriteString(templateWithOrderByInsertionSuffix)
+
+	if filter.MaxRecordCount > 0 {
+		queryString.WriteString(templateWithLimitSuffix)
+		operands = append(operands, filter.MaxRecordCount)
+		queryString.WriteString(strconv.Itoa(len(operands)))
+	}
+
+	// All suffixes start with AND, replace the first occurrence with WHERE
+	compiledQryString := strings.Replace(queryString.String(), " AND ", " WHERE ", 1)
+
+	var rows []sqlplugin.ClusterMembershipRow
+	err := pdb.conn.Select(&rows,
+		compiledQryString,
+		operands...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	convertedRows := make([]sqlplugin.ClusterMembershipRow, 0, len(rows))
+	for _, r := range rows {
+		r.SessionStart = r.SessionStart.UTC()
+		convertedRows = append(convertedRows, r)
+	}
+	return convertedRows, err
+}
+
+func (pdb *db) PruneClusterMembership(filter *sqlplugin.PruneClusterMembershipFilter) (sql.Result, error) {
+	return pdb.conn.Exec(templatePruneStaleClusterMembership,
+		pdb.converter.ToSQLiteDateTime(filter.PruneRecordsBefore),
+		filter.MaxRecordsAffected)
+}
@@ -0,0 +1,92 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sqlite
+
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/temporalio/temporal/common/persistence/sql/sqlplugin"
+)
+
+// db represents a logical connection to sqlite database
+type db struct {
+	plugin       *plugin
+	databaseName string
+	db           *sqlx.DB
+	tx           *sqlx.Tx
+	conn         sqlplugin.Conn
+	converter    DataConverter
+}
+
+var _ sqlplugin.DB = (*db)(nil)
+var _ sqlplugin.Tx = (*db)(nil)
+
+// IsDupEntryError returns true if the error is a primary key or unique constraint violation
+func (pdb *db) IsDupEntryError(err error) bool {
+	sqlErr, ok := err.(sqlite3.Error)
+	return ok && (sqlErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey || sqlErr.ExtendedCode == sqlite3.ErrConstraintUnique)
+}
+
+// newDB returns an instance of DB, which is a logical
+// connection to the underlying sqlite database
+func newDB(plugin *plugin, databaseName string, xdb *sqlx.DB, tx *sqlx.Tx) *db {
+	mdb := &db{plugin: plugin, databaseName: databaseName, db: xdb, tx: tx}
+	mdb.conn = xdb
+	if tx != nil {
+		mdb.conn = tx
+	}
+	mdb.converter = &converter{}
+	return mdb
+}
+
+// BeginTx starts a new transaction and returns a reference to the Tx object
+func (pdb *db) BeginTx() (sqlplugin.Tx, error) {
+	xtx, err := pdb.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	return newDB(pdb.plugin, pdb.databaseName, pdb.db, xtx), nil
+}
+
+// Commit commits a previously started transaction
+func (pdb *db) Commit() error {
+	return pdb.tx.Commit()
+}
+
+// Rollback triggers rollback of a previously started transaction
+func (pdb *db) Rollback() error {
+	return pdb.tx.Rollback()
+}
+
+// Close releases the connection to the sqlite db
+func (pdb *db) Close() error {
+	return pdb.plugin.releaseConnection(pdb.databaseName)
+}
+
+// PluginName returns the name of the sqlite plugin
+func (pdb *db) PluginName() string {
+	return PluginName
+}
@@ -0,0 +1,157 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sqlite
+
+import (
+	"errors"
+	"net/url"
+	"sync"
+
+	"github.com/iancoleman/strcase"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3" // needed to load the sqlite3 driver
+
+	"github.com/temporalio/temporal/common/persistence/sql"
+	"github.com/temporalio/temporal/common/persistence/sql/sqlplugin"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+const (
+	// PluginName is the name of the plugin
+	PluginName = "sqlite"
+	// InMemoryDatabaseName is the database name which keeps the database in memory
+	// for as long as the process holds a connection to it
+	InMemoryDatabaseName = ":memory:"
+
+	driverName = "sqlite3"
+)
+
+var (
+	errTLSNotSupported = errors.New("tls is not supported by sqlite")
+	errCGODisabled     = errors.New("sqlite requires cgo, the binary must be built with CGO_ENABLED=1")
+)
+
+type (
+	plugin struct {
+		sync.Mutex
+		conns map[string]*sharedConn
+	}
+
+	// sharedConn is a connection to a database file shared by all
+	// persistence stores of the process
+	sharedConn struct {
+		db       *sqlx.DB
+		refCount int
+	}
+)
+
+var _ sqlplugin.Plugin = (*plugin)(nil)
+
+func init() {
+	sql.RegisterPlugin(PluginName, &plugin{
+		conns: make(map[string]*sharedConn),
+	})
+}
+
+// CreateDB initialize the db object
+func (d *plugin) CreateDB(cfg *config.SQL) (sqlplugin.DB, error) {
+	conn, err := d.acquireConnection(cfg)
+	if err != nil {
+		return nil, err
+	}
+	db := newDB(d, cfg.DatabaseName, conn, nil)
+	return db, nil
+}
+
+// CreateAdminDB initialize the adminDB object
+func (d *plugin) CreateAdminDB(cfg *config.SQL) (sqlplugin.AdminDB, error) {
+	conn, err := d.acquireConnection(cfg)
+	if err != nil {
+		return nil, err
+	}
+	db := newDB(d, cfg.DatabaseName, conn, nil)
+	return db, nil
+}
+
+// acquireConnection returns the connection to the database file of the config. SQLite allows
+// a single writer at a time, so every database is accessed over one connection shared by all
+// stores of the process, which also keeps an in memory database alive between stores.
+func (d *plugin) acquireConnection(cfg *config.SQL) (*sqlx.DB, error) {
+	if !cgoEnabled {
+		return nil, errCGODisabled
+	}
+	if cfg.TLS != nil && cfg.TLS.Enabled {
+		return nil, errTLSNotSupported
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	if conn, ok := d.conns[cfg.DatabaseName]; ok {
+		conn.refCount++
+		return conn.db, nil
+	}
+
+	db, err := sqlx.Connect(driverName, buildDSN(cfg))
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+
+	// Maps struct names in CamelCase to snake without need for db struct tags.
+	db.MapperFunc(strcase.ToSnake)
+	d.conns[cfg.DatabaseName] = &sharedConn{db: db, refCount: 1}
+	return db, nil
+}
+
+// releaseConnection closes the connection to the database once no store uses it anymore
+func (d *plugin) releaseConnection(databaseName string) error {
+	d.Lock()
+	defer d.Unlock()
+
+	conn, ok := d.conns[databaseName]
+	if !ok {
+		return nil
+	}
+	conn.refCount--
+	if conn.refCount > 0 {
+		return nil
+	}
+	delete(d.conns, databaseName)
+	return conn.db.Close()
+}
+
+func buildDSN(cfg *config.SQL) string {
+	if len(cfg.ConnectAttributes) == 0 {
+		return cfg.DatabaseName
+	}
+	attrs := url.Values{}
+	for k, v := range cfg.ConnectAttributes {
+		attrs.Set(k, v)
+	}
+	return cfg.DatabaseName + "?" + attrs.Encode()
+}
@@ -0,0 +1,152 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// adaptiveRPSDecreaseFactor is the factor the rate is multiplied by when the
+	// observed latency or error ratio of an interval breaches its target
+	adaptiveRPSDecreaseFactor = 0.7
+	// adaptiveRPSIncreaseStep is the share of the max rate added back after a
+	// healthy interval
+	adaptiveRPSIncreaseStep = 0.1
+	// adaptiveRPSMinRatio is the lowest share of the max rate the rate is decreased to
+	adaptiveRPSMinRatio = 0.05
+)
+
+type (
+	// AdaptiveRPSConfig is the configuration of an adaptive rate
+	AdaptiveRPSConfig struct {
+		// MaxRPS is the rate used while the rate limited resource is healthy
+		MaxRPS RPSFunc
+		// TargetLatency is the average latency above which the rate is decreased,
+		// zero disables latency based adaptation
+		TargetLatency func() time.Duration
+		// MaxErrorRatio is the ratio of failed calls above which the rate is decreased,
+		// zero disables error based adaptation
+		MaxErrorRatio func() float64
+		// Interval is the length of the window the observations are aggregated over
+		Interval time.Duration
+	}
+
+	// AdaptiveRPS is a rate which adapts to the observed health of the rate limited
+	// resource. The rate is decreased multiplicatively after every interval in which
+	// the average latency or the error ratio exceeds its target and increased additively
+	// after every healthy interval, up to the max rate.
+	AdaptiveRPS struct {
+		config AdaptiveRPSConfig
+
+		// observations of the current interval
+		calls        int64
+		failures     int64
+		latencyNanos int64
+		// start of the current interval in unix nanos
+		windowStart int64
+		// bits of the current share of the max rate
+		ratio uint64
+
+		sync.Mutex
+	}
+)
+
+// NewAdaptiveRPS returns a new adaptive rate
+func NewAdaptiveRPS(config AdaptiveRPSConfig) *AdaptiveRPS {
+	return &AdaptiveRPS{
+		config:      config,
+		windowStart: time.Now().UnixNano(),
+		ratio:       math.Float64bits(1),
+	}
+}
+
+// Observe records the outcome of a call to the rate limited resource
+func (a *AdaptiveRPS) Observe(latency time.Duration, failed bool) {
+	atomic.AddInt64(&a.calls, 1)
+	atomic.AddInt64(&a.latencyNanos, int64(latency))
+	if failed {
+		atomic.AddInt64(&a.failures, 1)
+	}
+	if now := time.Now(); a.intervalOver(now) {
+		a.adapt(now)
+	}
+}
+
+// RPS returns the current rate
+func (a *AdaptiveRPS) RPS() float64 {
+	return a.config.MaxRPS() * a.Ratio()
+}
+
+// Ratio returns the current share of the max rate, it is 1 while the resource is healthy
+func (a *AdaptiveRPS) Ratio() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&a.ratio))
+}
+
+func (a *AdaptiveRPS) intervalOver(now time.Time) bool {
+	return now.UnixNano()-atomic.LoadInt64(&a.windowStart) >= int64(a.config.Interval)
+}
+
+// adapt updates the ratio at the end of an interval
+func (a *AdaptiveRPS) adapt(now time.Time) {
+	a.Lock()
+	defer a.Unlock()
+	if !a.intervalOver(now) {
+		return
+	}
+	atomic.StoreInt64(&a.windowStart, now.UnixNano())
+
+	calls := atomic.SwapInt64(&a.calls, 0)
+	failures := atomic.SwapInt64(&a.failures, 0)
+	latencyNanos := atomic.SwapInt64(&a.latencyNanos, 0)
+	if calls == 0 {
+		return
+	}
+
+	ratio := a.Ratio()
+	if a.breached(calls, failures, time.Duration(latencyNanos/calls)) {
+		ratio = math.Max(ratio*adaptiveRPSDecreaseFactor, adaptiveRPSMinRatio)
+	} else {
+		ratio = math.Min(ratio+adaptiveRPSIncreaseStep, 1)
+	}
+	atomic.StoreUint64(&a.ratio, math.Float64bits(ratio))
+}
+
+func (a *AdaptiveRPS) breached(calls int64, failures int64, avgLatency time.Duration) bool {
+	if a.config.TargetLatency != nil {
+		if target := a.config.TargetLatency(); target > 0 && avgLatency > target {
+			return true
+		}
+	}
+	if a.config.MaxErrorRatio != nil {
+		if maxRatio := a.config.MaxErrorRatio(); maxRatio > 0 && float64(failures)/float64(calls) > maxRatio {
+			return true
+		}
+	}
+	return false
+}
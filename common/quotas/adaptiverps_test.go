@@ -0,0 +1,110 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveRPSDecreasesOnLatency(t *testing.T) {
+	rps := newTestAdaptiveRPS()
+	rps.Observe(2*time.Second, false)
+	rps.adapt(time.Now().Add(time.Hour))
+	assert.Equal(t, 70.0, rps.RPS())
+
+	// the rate is not decreased below the min ratio
+	for n := 0; n < 20; n++ {
+		rps.Observe(2*time.Second, false)
+		rps.adapt(time.Now().Add(time.Duration(n+2) * time.Hour))
+	}
+	assert.Equal(t, 5.0, rps.RPS())
+}
+
+func TestAdaptiveRPSDecreasesOnErrors(t *testing.T) {
+	rps := newTestAdaptiveRPS()
+	rps.Observe(time.Millisecond, false)
+	rps.Observe(time.Millisecond, true)
+	rps.adapt(time.Now().Add(time.Hour))
+	assert.Equal(t, 70.0, rps.RPS())
+}
+
+func TestAdaptiveRPSIncreasesWhenHealthy(t *testing.T) {
+	rps := newTestAdaptiveRPS()
+	rps.Observe(2*time.Second, false)
+	rps.adapt(time.Now().Add(time.Hour))
+	assert.Equal(t, 0.7, rps.Ratio())
+
+	rps.Observe(time.Millisecond, false)
+	rps.adapt(time.Now().Add(2 * time.Hour))
+	assert.InDelta(t, 0.8, rps.Ratio(), 0.0001)
+
+	for n := 0; n < 5; n++ {
+		rps.Observe(time.Millisecond, false)
+		rps.adapt(time.Now().Add(time.Duration(n+3) * time.Hour))
+	}
+	assert.Equal(t, 100.0, rps.RPS())
+}
+
+func TestAdaptiveRPSIgnoresIdleIntervals(t *testing.T) {
+	rps := newTestAdaptiveRPS()
+	rps.Observe(2*time.Second, false)
+	rps.adapt(time.Now().Add(time.Hour))
+	rps.adapt(time.Now().Add(2 * time.Hour))
+	assert.Equal(t, 0.7, rps.Ratio())
+}
+
+func TestAdaptiveRPSIntervalNotOver(t *testing.T) {
+	rps := newTestAdaptiveRPS()
+	rps.Observe(2*time.Second, true)
+	rps.adapt(time.Now())
+	assert.Equal(t, 1.0, rps.Ratio())
+}
+
+func BenchmarkAdaptiveRPS(b *testing.B) {
+	rps := NewAdaptiveRPS(AdaptiveRPSConfig{
+		MaxRPS:        func() float64 { return 100 },
+		TargetLatency: func() time.Duration { return time.Second },
+		MaxErrorRatio: func() float64 { return 0.5 },
+		Interval:      time.Millisecond,
+	})
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			rps.Observe(time.Millisecond, false)
+			_ = rps.RPS()
+		}
+	})
+}
+
+func newTestAdaptiveRPS() *AdaptiveRPS {
+	return NewAdaptiveRPS(AdaptiveRPSConfig{
+		MaxRPS:        func() float64 { return 100 },
+		TargetLatency: func() time.Duration { return time.Second },
+		MaxErrorRatio: func() float64 { return 0.25 },
+		Interval:      time.Hour,
+	})
+}
@@ -35,6 +35,9 @@ type RPSKeyFunc func(key string) float64
 // Info corresponds to information required to determine rate limits
 type Info struct {
 	Namespace string
+	// APIClass is the class of the API called, it is only used by policies
+	// which limit classes of APIs separately
+	APIClass string
 }
 
 // Limiter corresponds to basic rate limiting functionality.
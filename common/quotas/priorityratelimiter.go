@@ -0,0 +1,188 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+type (
+	// APIClassConfig is the rate limit configuration of a class of APIs
+	APIClassConfig struct {
+		// Priority of the class, 0 is the highest priority
+		Priority int
+		// NamespaceRPS is the per namespace rate limit of the class, the namespaces
+		// are not limited per class if it is nil
+		NamespaceRPS RPSKeyFunc
+	}
+
+	// PriorityRateLimiter is a namespace and API class specific rate limit policy.
+	// Every API class has its own per namespace rate limit on top of the per namespace
+	// rate limit shared by all classes. All requests are subject to the global rate limit,
+	// as it is approached the requests of lower priority classes are shed first.
+	PriorityRateLimiter struct {
+		classes           map[string]*apiClassLimiter
+		namespaceLimiters *namespaceRateLimiters
+		globalLimiter     *DynamicRateLimiter
+		numPriorities     int
+	}
+
+	apiClassLimiter struct {
+		priority          int
+		namespaceLimiters *namespaceRateLimiters
+	}
+
+	namespaceRateLimiters struct {
+		sync.RWMutex
+		rps      RPSKeyFunc
+		limiters map[string]*DynamicRateLimiter
+	}
+)
+
+// NewPriorityRateLimiter returns a new API class and namespace quota rate limiter
+func NewPriorityRateLimiter(
+	rps RPSFunc,
+	namespaceRPS RPSKeyFunc,
+	apiClasses map[string]APIClassConfig,
+) *PriorityRateLimiter {
+	rl := &PriorityRateLimiter{
+		classes:           make(map[string]*apiClassLimiter, len(apiClasses)),
+		namespaceLimiters: newNamespaceRateLimiters(namespaceRPS),
+		globalLimiter:     NewDynamicRateLimiter(rps),
+		numPriorities:     1,
+	}
+	for name, config := range apiClasses {
+		class := &apiClassLimiter{priority: config.Priority}
+		if config.NamespaceRPS != nil {
+			class.namespaceLimiters = newNamespaceRateLimiters(config.NamespaceRPS)
+		}
+		rl.classes[name] = class
+		if config.Priority >= rl.numPriorities {
+			rl.numPriorities = config.Priority + 1
+		}
+	}
+	return rl
+}
+
+// Allow attempts to allow a request to go through. The method returns
+// immediately with a true or false indicating if the request can make
+// progress. Requests of unknown API classes have the lowest priority.
+func (d *PriorityRateLimiter) Allow(info Info) bool {
+	priority := d.numPriorities - 1
+	var limiters []*DynamicRateLimiter
+	if class, ok := d.classes[info.APIClass]; ok {
+		priority = class.priority
+		if len(info.Namespace) != 0 && class.namespaceLimiters != nil {
+			limiters = append(limiters, class.namespaceLimiters.get(info.Namespace))
+		}
+	}
+	if len(info.Namespace) != 0 {
+		limiters = append(limiters, d.namespaceLimiters.get(info.Namespace))
+	}
+
+	// take a reservation with the namespace limiters first, cancel all
+	// reservations and drop the request if any of them is not valid now
+	now := time.Now()
+	reservations := make([]*rate.Reservation, 0, len(limiters))
+	for _, limiter := range limiters {
+		rsv := limiter.ReserveN(now, 1)
+		reservations = append(reservations, rsv)
+		if !rsv.OK() || rsv.DelayFrom(now) != 0 {
+			cancelReservations(now, reservations)
+			return false
+		}
+	}
+
+	if !d.allowGlobal(now, priority) {
+		cancelReservations(now, reservations)
+		return false
+	}
+	return true
+}
+
+// allowGlobal takes a token from the global limiter. Requests of lower priorities require
+// a larger share of the global burst to be left, so that they are shed before the requests
+// of higher priorities as the global rate limit is approached.
+func (d *PriorityRateLimiter) allowGlobal(now time.Time, priority int) bool {
+	headroom := d.globalLimiter.Burst() * priority / d.numPriorities
+	if headroom == 0 {
+		rsv := d.globalLimiter.ReserveN(now, 1)
+		if !rsv.OK() || rsv.DelayFrom(now) != 0 {
+			rsv.CancelAt(now)
+			return false
+		}
+		return true
+	}
+
+	// check that the headroom is available and give it back right away
+	rsv := d.globalLimiter.ReserveN(now, headroom+1)
+	ok := rsv.OK() && rsv.DelayFrom(now) == 0
+	rsv.CancelAt(now)
+	if !ok {
+		return false
+	}
+	return d.allowGlobal(now, 0)
+}
+
+func cancelReservations(now time.Time, reservations []*rate.Reservation) {
+	for _, rsv := range reservations {
+		rsv.CancelAt(now)
+	}
+}
+
+func newNamespaceRateLimiters(rps RPSKeyFunc) *namespaceRateLimiters {
+	return &namespaceRateLimiters{
+		rps:      rps,
+		limiters: make(map[string]*DynamicRateLimiter),
+	}
+}
+
+// get returns the limiter of the namespace, it is created on first use
+func (n *namespaceRateLimiters) get(namespace string) *DynamicRateLimiter {
+	n.RLock()
+	limiter, ok := n.limiters[namespace]
+	n.RUnlock()
+	if ok {
+		return limiter
+	}
+
+	namespaceLimiter := NewDynamicRateLimiter(
+		func() float64 {
+			return n.rps(namespace)
+		},
+	)
+
+	n.Lock()
+	defer n.Unlock()
+	limiter, ok = n.limiters[namespace]
+	if !ok {
+		n.limiters[namespace] = namespaceLimiter
+		limiter = namespaceLimiter
+	}
+	return limiter
+}
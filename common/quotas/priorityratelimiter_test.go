@@ -0,0 +1,114 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package quotas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testHighPriorityClass = "high"
+	testLowPriorityClass  = "low"
+)
+
+func TestPriorityRateLimiterBlockedByClassNamespaceRps(t *testing.T) {
+	policy := newFixedRpsPriorityRateLimiter(100, 100, 1)
+	assert.True(t, policy.Allow(Info{Namespace: defaultNamespace, APIClass: testHighPriorityClass}))
+	assert.False(t, policy.Allow(Info{Namespace: defaultNamespace, APIClass: testHighPriorityClass}))
+	// every class has its own namespace budget
+	assert.True(t, policy.Allow(Info{Namespace: defaultNamespace, APIClass: testLowPriorityClass}))
+	assert.False(t, policy.Allow(Info{Namespace: defaultNamespace, APIClass: testLowPriorityClass}))
+	assert.True(t, policy.Allow(Info{Namespace: "other", APIClass: testLowPriorityClass}))
+}
+
+func TestPriorityRateLimiterBlockedByNamespaceRps(t *testing.T) {
+	policy := newFixedRpsPriorityRateLimiter(100, 1, 100)
+	assert.True(t, policy.Allow(Info{Namespace: defaultNamespace, APIClass: testHighPriorityClass}))
+	assert.False(t, policy.Allow(Info{Namespace: defaultNamespace, APIClass: testLowPriorityClass}))
+	assert.True(t, policy.Allow(Info{Namespace: "other", APIClass: testLowPriorityClass}))
+}
+
+func TestPriorityRateLimiterShedsLowPriorityFirst(t *testing.T) {
+	policy := newFixedRpsPriorityRateLimiter(10, 100, 100)
+
+	// half of the global burst is left to the high priority class
+	var numLowAllowed int
+	for n := 0; n < 10; n++ {
+		if policy.Allow(Info{Namespace: defaultNamespace, APIClass: testLowPriorityClass}) {
+			numLowAllowed++
+		}
+	}
+	var numHighAllowed int
+	for n := 0; n < 10; n++ {
+		if policy.Allow(Info{Namespace: defaultNamespace, APIClass: testHighPriorityClass}) {
+			numHighAllowed++
+		}
+	}
+
+	assert.Equal(t, 5, numLowAllowed)
+	assert.Equal(t, 5, numHighAllowed)
+}
+
+func TestPriorityRateLimiterUnknownClass(t *testing.T) {
+	policy := newFixedRpsPriorityRateLimiter(2, 100, 100)
+	assert.True(t, policy.Allow(Info{}))
+	// unknown classes have the lowest priority
+	assert.False(t, policy.Allow(Info{}))
+	assert.True(t, policy.Allow(Info{APIClass: testHighPriorityClass}))
+}
+
+func BenchmarkPriorityRateLimiter(b *testing.B) {
+	policy := newFixedRpsPriorityRateLimiter(defaultRps, defaultRps, defaultRps)
+	for n := 0; n < b.N; n++ {
+		policy.Allow(Info{Namespace: defaultNamespace, APIClass: testHighPriorityClass})
+	}
+}
+
+func newFixedRpsPriorityRateLimiter(globalRps, namespaceRps, classNamespaceRps float64) Policy {
+	return NewPriorityRateLimiter(
+		func() float64 {
+			return globalRps
+		},
+		func(namespace string) float64 {
+			return namespaceRps
+		},
+		map[string]APIClassConfig{
+			testHighPriorityClass: {
+				Priority: 0,
+				NamespaceRPS: func(namespace string) float64 {
+					return classNamespaceRps
+				},
+			},
+			testLowPriorityClass: {
+				Priority: 1,
+				NamespaceRPS: func(namespace string) float64 {
+					return classNamespaceRps
+				},
+			},
+		},
+	)
+}
@@ -97,6 +97,18 @@ func (rl *RateLimiter) Reserve() *rate.Reservation {
 	return limiter.Reserve()
 }
 
+// ReserveN reserves n rate limit tokens at the given time
+func (rl *RateLimiter) ReserveN(now time.Time, n int) *rate.Reservation {
+	limiter := rl.goRateLimiter.Load().(*rate.Limiter)
+	return limiter.ReserveN(now, n)
+}
+
+// Burst returns the maximum number of tokens which can be reserved at once
+func (rl *RateLimiter) Burst() int {
+	limiter := rl.goRateLimiter.Load().(*rate.Limiter)
+	return limiter.Burst()
+}
+
 // Allow immediately returns with true or false indicating if a rate limit
 // token is available or not
 func (rl *RateLimiter) Allow() bool {
@@ -173,3 +185,15 @@ func (d *DynamicRateLimiter) Reserve() *rate.Reservation {
 	d.rl.UpdateMaxDispatch(&rps)
 	return d.rl.Reserve()
 }
+
+// ReserveN reserves n rate limit tokens at the given time
+func (d *DynamicRateLimiter) ReserveN(now time.Time, n int) *rate.Reservation {
+	rps := d.rps()
+	d.rl.UpdateMaxDispatch(&rps)
+	return d.rl.ReserveN(now, n)
+}
+
+// Burst returns the maximum number of tokens which can be reserved at once
+func (d *DynamicRateLimiter) Burst() int {
+	return d.rl.Burst()
+}
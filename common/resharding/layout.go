@@ -0,0 +1,373 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resharding
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+type (
+	// Layout maps workflow IDs to history shards.
+	//
+	// The number of history shards can only grow by an integer factor. Shard S of the source
+	// layout is split into the target shards S, S + N, S + 2N, ... where N is the source number
+	// of shards, so workflows hashed to a target shard always come from the same source shard.
+	// Until the split of a source shard is completed, all its workflows keep using the source
+	// shard, after which they are routed using the target number of shards.
+	Layout interface {
+		common.Daemon
+
+		// GetShardID returns the shard currently owning the workflow
+		GetShardID(workflowID string) int
+		// GetNumberOfShards returns the number of shards which can own workflows
+		GetNumberOfShards() int
+		// GetSourceNumberOfShards returns the number of shards before the split
+		GetSourceNumberOfShards() int
+		// IsSplitting returns true if the target number of shards differs from the source one
+		IsSplitting() bool
+		// IsSplit returns true if the source shard has been split into the target shards
+		IsSplit(shardID int) bool
+		// MarkSplit records the completed split of the source shard
+		MarkSplit(shardID int)
+		// GetOwnerOverride returns the owner override of the shard, if any
+		GetOwnerOverride(shardID int) (OwnerOverride, bool)
+		// SetOwnerOverride records the owner override of the shard ahead of the next reload,
+		// an override without host address removes the override of the shard
+		SetOwnerOverride(shardID int, override OwnerOverride)
+	}
+
+	// OwnerOverride assigns a shard to a history host regardless of the membership ring
+	OwnerOverride struct {
+		HostAddress string
+		// Pinned overrides are set by operators and are never moved by the shard rebalancer
+		Pinned bool
+	}
+
+	layoutImpl struct {
+		status               int32
+		sourceNumberOfShards int
+		targetNumberOfShards int
+		splitShards          []int32
+		shardManager         persistence.ShardManager
+		refreshInterval      dynamicconfig.DurationPropertyFn
+		ownerRefreshInterval dynamicconfig.DurationPropertyFn
+		logger               log.Logger
+		shutdownChan         chan struct{}
+
+		sync.RWMutex
+		ownerOverrides map[int]OwnerOverride
+	}
+)
+
+const (
+	shardNotSplit int32 = iota
+	shardSplit
+)
+
+var _ Layout = (*layoutImpl)(nil)
+
+// NewLayout creates a layout splitting the source number of shards into the target number of shards,
+// the split state of source shards and the owner overrides of all shards are periodically reloaded
+// from the shard info in persistence
+func NewLayout(
+	sourceNumberOfShards int,
+	targetNumberOfShards int,
+	shardManager persistence.ShardManager,
+	refreshInterval dynamicconfig.DurationPropertyFn,
+	ownerRefreshInterval dynamicconfig.DurationPropertyFn,
+	logger log.Logger,
+) (Layout, error) {
+
+	if targetNumberOfShards == 0 {
+		targetNumberOfShards = sourceNumberOfShards
+	}
+	if sourceNumberOfShards <= 0 ||
+		targetNumberOfShards < sourceNumberOfShards ||
+		targetNumberOfShards%sourceNumberOfShards != 0 {
+		return nil, fmt.Errorf(
+			"target number of history shards %v is not a multiple of the number of history shards %v",
+			targetNumberOfShards,
+			sourceNumberOfShards,
+		)
+	}
+
+	return &layoutImpl{
+		status:               common.DaemonStatusInitialized,
+		sourceNumberOfShards: sourceNumberOfShards,
+		targetNumberOfShards: targetNumberOfShards,
+		splitShards:          make([]int32, sourceNumberOfShards),
+		shardManager:         shardManager,
+		refreshInterval:      refreshInterval,
+		ownerRefreshInterval: ownerRefreshInterval,
+		logger:               logger,
+		shutdownChan:         make(chan struct{}),
+		ownerOverrides:       make(map[int]OwnerOverride),
+	}, nil
+}
+
+// NewStaticLayout creates a layout with a fixed number of shards
+func NewStaticLayout(
+	numberOfShards int,
+) Layout {
+
+	return &layoutImpl{
+		status:               common.DaemonStatusInitialized,
+		sourceNumberOfShards: numberOfShards,
+		targetNumberOfShards: numberOfShards,
+		splitShards:          make([]int32, numberOfShards),
+		shutdownChan:         make(chan struct{}),
+		ownerOverrides:       make(map[int]OwnerOverride),
+	}
+}
+
+// LookupOwner returns the history host owning the shard, an owner override is ignored
+// if its host is not a member of the history service ring
+func LookupOwner(
+	layout Layout,
+	resolver membership.ServiceResolver,
+	shardID int,
+) (*membership.HostInfo, error) {
+
+	if override, ok := layout.GetOwnerOverride(shardID); ok {
+		for _, host := range resolver.Members() {
+			if host.GetAddress() == override.HostAddress {
+				return host, nil
+			}
+		}
+	}
+	return resolver.Lookup(string(shardID))
+}
+
+func (l *layoutImpl) Start() {
+	if !atomic.CompareAndSwapInt32(&l.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+	if l.shardManager == nil {
+		// static layout
+		return
+	}
+
+	if err := l.refreshOwnerOverrides(); err != nil {
+		l.logger.Error("Error loading history shard owner overrides.", tag.Error(err))
+	}
+	go l.ownerRefreshLoop()
+
+	if !l.IsSplitting() {
+		return
+	}
+	if err := l.refresh(); err != nil {
+		l.logger.Error("Error loading history shard split state.", tag.Error(err))
+	}
+	go l.refreshLoop()
+}
+
+func (l *layoutImpl) Stop() {
+	if !atomic.CompareAndSwapInt32(&l.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+
+	close(l.shutdownChan)
+}
+
+func (l *layoutImpl) GetShardID(
+	workflowID string,
+) int {
+
+	shardID := common.WorkflowIDToHistoryShard(workflowID, l.sourceNumberOfShards)
+	if !l.IsSplit(shardID) {
+		return shardID
+	}
+	return common.WorkflowIDToHistoryShard(workflowID, l.targetNumberOfShards)
+}
+
+func (l *layoutImpl) GetNumberOfShards() int {
+	return l.targetNumberOfShards
+}
+
+func (l *layoutImpl) GetSourceNumberOfShards() int {
+	return l.sourceNumberOfShards
+}
+
+func (l *layoutImpl) IsSplitting() bool {
+	return l.targetNumberOfShards != l.sourceNumberOfShards
+}
+
+func (l *layoutImpl) IsSplit(
+	shardID int,
+) bool {
+
+	if !l.IsSplitting() || shardID >= l.sourceNumberOfShards {
+		return false
+	}
+	return atomic.LoadInt32(&l.splitShards[shardID]) == shardSplit
+}
+
+func (l *layoutImpl) MarkSplit(
+	shardID int,
+) {
+
+	if !l.IsSplitting() || shardID >= l.sourceNumberOfShards {
+		return
+	}
+	atomic.StoreInt32(&l.splitShards[shardID], shardSplit)
+}
+
+func (l *layoutImpl) GetOwnerOverride(
+	shardID int,
+) (OwnerOverride, bool) {
+
+	l.RLock()
+	defer l.RUnlock()
+
+	override, ok := l.ownerOverrides[shardID]
+	return override, ok
+}
+
+func (l *layoutImpl) SetOwnerOverride(
+	shardID int,
+	override OwnerOverride,
+) {
+
+	l.Lock()
+	defer l.Unlock()
+
+	if override.HostAddress == "" {
+		delete(l.ownerOverrides, shardID)
+		return
+	}
+	l.ownerOverrides[shardID] = override
+}
+
+func (l *layoutImpl) refreshLoop() {
+	timer := time.NewTimer(l.refreshInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-l.shutdownChan:
+			return
+		case <-timer.C:
+			if err := l.refresh(); err != nil {
+				l.logger.Error("Error refreshing history shard split state.", tag.Error(err))
+			}
+			if l.isSplitCompleted() {
+				l.logger.Info("History shard split completed.", tag.Number(int64(l.targetNumberOfShards)))
+				return
+			}
+			timer.Reset(l.refreshInterval())
+		}
+	}
+}
+
+func (l *layoutImpl) refresh() error {
+	for shardID := 0; shardID < l.sourceNumberOfShards; shardID++ {
+		if l.IsSplit(shardID) {
+			// a split shard never goes back to the source layout
+			continue
+		}
+
+		resp, err := l.shardManager.GetShard(&persistence.GetShardRequest{
+			ShardID: int32(shardID),
+		})
+		if err != nil {
+			if _, ok := err.(*serviceerror.NotFound); ok {
+				// shard has never been acquired, so it has not been split either
+				continue
+			}
+			return err
+		}
+		if int(resp.ShardInfo.GetNumHistoryShards()) == l.targetNumberOfShards {
+			l.MarkSplit(shardID)
+		}
+	}
+	return nil
+}
+
+func (l *layoutImpl) ownerRefreshLoop() {
+	timer := time.NewTimer(l.ownerRefreshInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-l.shutdownChan:
+			return
+		case <-timer.C:
+			if err := l.refreshOwnerOverrides(); err != nil {
+				l.logger.Error("Error refreshing history shard owner overrides.", tag.Error(err))
+			}
+			timer.Reset(l.ownerRefreshInterval())
+		}
+	}
+}
+
+// refreshOwnerOverrides reloads the owner overrides of all shards, including the target
+// shards of a split
+func (l *layoutImpl) refreshOwnerOverrides() error {
+	ownerOverrides := make(map[int]OwnerOverride)
+	for shardID := 0; shardID < l.targetNumberOfShards; shardID++ {
+		resp, err := l.shardManager.GetShard(&persistence.GetShardRequest{
+			ShardID: int32(shardID),
+		})
+		if err != nil {
+			if _, ok := err.(*serviceerror.NotFound); ok {
+				continue
+			}
+			return err
+		}
+
+		if hostAddress := resp.ShardInfo.GetOwnerOverride(); hostAddress != "" {
+			ownerOverrides[shardID] = OwnerOverride{
+				HostAddress: hostAddress,
+				Pinned:      resp.ShardInfo.GetOwnerOverridePinned(),
+			}
+		}
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	l.ownerOverrides = ownerOverrides
+	return nil
+}
+
+func (l *layoutImpl) isSplitCompleted() bool {
+	for shardID := 0; shardID < l.sourceNumberOfShards; shardID++ {
+		if !l.IsSplit(shardID) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,126 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resharding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+func TestNewLayout_InvalidTarget(t *testing.T) {
+	refreshInterval := dynamicconfig.GetDurationPropertyFn(time.Second)
+	logger := loggerimpl.NewNopLogger()
+
+	_, err := NewLayout(4, 6, nil, refreshInterval, refreshInterval, logger)
+	assert.Error(t, err)
+	_, err = NewLayout(4, 2, nil, refreshInterval, refreshInterval, logger)
+	assert.Error(t, err)
+
+	layout, err := NewLayout(4, 0, nil, refreshInterval, refreshInterval, logger)
+	assert.NoError(t, err)
+	assert.False(t, layout.IsSplitting())
+	assert.Equal(t, 4, layout.GetNumberOfShards())
+}
+
+func TestLayout_GetShardID(t *testing.T) {
+	layout, err := NewLayout(4, 8, nil, dynamicconfig.GetDurationPropertyFn(time.Second), dynamicconfig.GetDurationPropertyFn(time.Second), loggerimpl.NewNopLogger())
+	assert.NoError(t, err)
+	assert.True(t, layout.IsSplitting())
+
+	for i := 0; i < 100; i++ {
+		workflowID := string(rune('a'+i%26)) + string(rune('a'+i/26))
+		sourceShardID := common.WorkflowIDToHistoryShard(workflowID, 4)
+		targetShardID := common.WorkflowIDToHistoryShard(workflowID, 8)
+		assert.Equal(t, sourceShardID, targetShardID%4)
+
+		if !layout.IsSplit(sourceShardID) {
+			assert.Equal(t, sourceShardID, layout.GetShardID(workflowID))
+		}
+		layout.MarkSplit(sourceShardID)
+		assert.True(t, layout.IsSplit(sourceShardID))
+		assert.Equal(t, targetShardID, layout.GetShardID(workflowID))
+	}
+}
+
+func TestStaticLayout(t *testing.T) {
+	layout := NewStaticLayout(4)
+	assert.False(t, layout.IsSplitting())
+
+	layout.MarkSplit(1)
+	assert.False(t, layout.IsSplit(1))
+	assert.Equal(t, common.WorkflowIDToHistoryShard("workflow", 4), layout.GetShardID("workflow"))
+}
+
+func TestLayout_OwnerOverride(t *testing.T) {
+	layout := NewStaticLayout(4)
+
+	_, ok := layout.GetOwnerOverride(1)
+	assert.False(t, ok)
+
+	layout.SetOwnerOverride(1, OwnerOverride{HostAddress: "host1", Pinned: true})
+	override, ok := layout.GetOwnerOverride(1)
+	assert.True(t, ok)
+	assert.Equal(t, OwnerOverride{HostAddress: "host1", Pinned: true}, override)
+
+	layout.SetOwnerOverride(1, OwnerOverride{})
+	_, ok = layout.GetOwnerOverride(1)
+	assert.False(t, ok)
+}
+
+func TestLookupOwner(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	ringHost := membership.NewHostInfo("host0", nil)
+	overrideHost := membership.NewHostInfo("host1", nil)
+	resolver := membership.NewMockServiceResolver(controller)
+	resolver.EXPECT().Members().Return([]*membership.HostInfo{ringHost, overrideHost}).AnyTimes()
+	resolver.EXPECT().Lookup(gomock.Any()).Return(ringHost, nil).AnyTimes()
+
+	// target shard of a split
+	layout := NewStaticLayout(8)
+	host, err := LookupOwner(layout, resolver, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, ringHost, host)
+
+	layout.SetOwnerOverride(5, OwnerOverride{HostAddress: "host1"})
+	host, err = LookupOwner(layout, resolver, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, overrideHost, host)
+
+	// override host left the ring
+	layout.SetOwnerOverride(5, OwnerOverride{HostAddress: "host2"})
+	host, err = LookupOwner(layout, resolver, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, ringHost, host)
+}
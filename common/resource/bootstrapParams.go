@@ -39,8 +39,10 @@ import (
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
+	"github.com/temporalio/temporal/common/rpc/encryption"
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 type (
@@ -69,6 +71,10 @@ type (
 		ArchivalMetadata             archiver.ArchivalMetadata
 		ArchiverProvider             provider.ArchiverProvider
 		Authorizer                   authorization.Authorizer
+		TLSConfigProvider            encryption.TLSConfigProvider
+		Tracer                       tracing.Tracer
+		AuditConfig                  *config.Audit
+		PayloadCodecConfig           *config.PayloadCodec
 	}
 
 	// MembershipMonitorFactory provides a bootstrapped membership monitor
@@ -37,6 +37,7 @@ import (
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/blobstore"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/cluster"
@@ -46,6 +47,8 @@ import (
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
+	"github.com/temporalio/temporal/common/resharding"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 type (
@@ -60,6 +63,7 @@ type (
 		GetHostInfo() *membership.HostInfo
 		GetArchivalMetadata() archiver.ArchivalMetadata
 		GetClusterMetadata() cluster.Metadata
+		GetHistoryShardLayout() resharding.Layout
 
 		// other common resources
 
@@ -67,7 +71,9 @@ type (
 		GetTimeSource() clock.TimeSource
 		GetPayloadSerializer() persistence.PayloadSerializer
 		GetMetricsClient() metrics.Client
+		GetTracer() tracing.Tracer
 		GetArchiverProvider() provider.ArchiverProvider
+		GetPayloadOffloader() blobstore.PayloadOffloader
 		GetMessagingClient() messaging.Client
 
 		// membership infos
@@ -94,9 +100,11 @@ type (
 		// persistence clients
 
 		GetMetadataManager() persistence.MetadataManager
+		GetClusterMetadataManager() persistence.ClusterMetadataManager
 		GetTaskManager() persistence.TaskManager
 		GetVisibilityManager() persistence.VisibilityManager
 		GetNamespaceReplicationQueue() persistence.NamespaceReplicationQueue
+		GetAuditLogQueue() persistence.AuditLogQueue
 		GetShardManager() persistence.ShardManager
 		GetHistoryManager() persistence.HistoryManager
 		GetExecutionManager(int) (persistence.ExecutionManager, error)
@@ -43,6 +43,7 @@ import (
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/blobstore"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/cluster"
@@ -54,7 +55,9 @@ import (
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
+	"github.com/temporalio/temporal/common/resharding"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 type (
@@ -81,13 +84,17 @@ type (
 
 		// other common resources
 
-		namespaceCache    cache.NamespaceCache
-		timeSource        clock.TimeSource
-		payloadSerializer persistence.PayloadSerializer
-		metricsClient     metrics.Client
-		messagingClient   messaging.Client
-		archivalMetadata  archiver.ArchivalMetadata
-		archiverProvider  provider.ArchiverProvider
+		namespaceCache           cache.NamespaceCache
+		clusterMetadataRefresher *cluster.MetadataRefresher
+		historyShardLayout       resharding.Layout
+		timeSource               clock.TimeSource
+		payloadSerializer        persistence.PayloadSerializer
+		metricsClient            metrics.Client
+		tracer                   tracing.Tracer
+		messagingClient          messaging.Client
+		archivalMetadata         archiver.ArchivalMetadata
+		archiverProvider         provider.ArchiverProvider
+		payloadOffloader         blobstore.PayloadOffloader
 
 		// membership infos
 
@@ -155,24 +162,24 @@ func New(
 
 	ringpopChannel := params.RPCFactory.GetRingpopChannel()
 
-	persistenceBean, err := persistenceClient.NewBeanFromFactory(persistenceClient.NewFactory(
+	tracer := params.Tracer
+	if tracer == nil {
+		tracer = tracing.NewNoopTracer()
+	}
+
+	dynamicCollection := dynamicconfig.NewCollection(params.DynamicConfig, logger)
+
+	persistenceBean, err := persistenceClient.NewBeanFromFactory(persistenceClient.NewFactoryWithRateLimiterConfig(
 		&params.PersistenceConfig,
-		func(...dynamicconfig.FilterOption) int {
-			if persistenceGlobalMaxQPS() > 0 {
-				// TODO: We have a bootstrap issue to correctly find memberCount.  Membership relies on
-				// persistence to bootstrap membership ring, so we cannot have persistence rely on membership
-				// as it will cause circular dependency.
-				// ringSize, err := membershipMonitor.GetMemberCount(serviceName)
-				// if err == nil && ringSize > 0 {
-				// 	avgQuota := common.MaxInt(persistenceGlobalMaxQPS()/ringSize, 1)
-				// 	return common.MinInt(avgQuota, persistenceMaxQPS())
-				// }
-			}
-			return persistenceMaxQPS()
+		persistence.RateLimiterConfig{
+			MaxQPS:        persistenceMaxQPSFn(persistenceMaxQPS, persistenceGlobalMaxQPS),
+			TargetLatency: dynamicCollection.GetDurationProperty(dynamicconfig.PersistenceTargetLatency, time.Second),
+			MaxErrorRatio: dynamicCollection.GetFloat64Property(dynamicconfig.PersistenceMaxErrorRatio, 0.2),
 		},
 		params.AbstractDatastoreFactory,
 		params.ClusterMetadata.GetCurrentClusterName(),
 		params.MetricsClient,
+		tracer,
 		logger,
 	))
 	if err != nil {
@@ -189,14 +196,30 @@ func New(
 		return nil, err
 	}
 
-	dynamicCollection := dynamicconfig.NewCollection(params.DynamicConfig, logger)
+	clusterMetadataRefresher := cluster.NewMetadataRefresher(
+		params.ClusterMetadata,
+		persistenceBean.GetClusterMetadataManager(),
+		dynamicCollection.GetDurationProperty(dynamicconfig.ClusterMetadataRefreshInterval, time.Minute),
+		logger,
+	)
+	historyShardLayout, err := resharding.NewLayout(
+		numShards,
+		params.PersistenceConfig.TargetNumHistoryShards,
+		persistenceBean.GetShardManager(),
+		dynamicCollection.GetDurationProperty(dynamicconfig.HistoryShardLayoutRefreshInterval, 10*time.Second),
+		dynamicCollection.GetDurationProperty(dynamicconfig.HistoryShardOwnershipRefreshInterval, time.Minute),
+		logger,
+	)
+	if err != nil {
+		return nil, err
+	}
 	clientBean, err := client.NewClientBean(
 		client.NewRPCClientFactory(
 			params.RPCFactory,
 			membershipMonitor,
 			params.MetricsClient,
 			dynamicCollection,
-			numShards,
+			historyShardLayout,
 			logger,
 		),
 		params.ClusterMetadata,
@@ -264,12 +287,14 @@ func New(
 		common.IsWhitelistServiceTransientError,
 	)
 
+	payloadOffloader := blobstore.NewPayloadOffloader(params.ArchiverProvider)
 	historyArchiverBootstrapContainer := &archiver.HistoryBootstrapContainer{
 		HistoryV2Manager: persistenceBean.GetHistoryManager(),
 		Logger:           logger,
 		MetricsClient:    params.MetricsClient,
 		ClusterMetadata:  params.ClusterMetadata,
 		NamespaceCache:   namespaceCache,
+		PayloadOffloader: payloadOffloader,
 	}
 	visibilityArchiverBootstrapContainer := &archiver.VisibilityBootstrapContainer{
 		Logger:          logger,
@@ -298,13 +323,17 @@ func New(
 
 		// other common resources
 
-		namespaceCache:    namespaceCache,
-		timeSource:        clock.NewRealTimeSource(),
-		payloadSerializer: persistence.NewPayloadSerializer(),
-		metricsClient:     params.MetricsClient,
-		messagingClient:   params.MessagingClient,
-		archivalMetadata:  params.ArchivalMetadata,
-		archiverProvider:  params.ArchiverProvider,
+		namespaceCache:           namespaceCache,
+		clusterMetadataRefresher: clusterMetadataRefresher,
+		historyShardLayout:       historyShardLayout,
+		timeSource:               clock.NewRealTimeSource(),
+		payloadSerializer:        persistence.NewPayloadSerializer(),
+		metricsClient:            params.MetricsClient,
+		tracer:                   tracer,
+		messagingClient:          params.MessagingClient,
+		archivalMetadata:         params.ArchivalMetadata,
+		archiverProvider:         params.ArchiverProvider,
+		payloadOffloader:         payloadOffloader,
 
 		// membership infos
 
@@ -368,6 +397,8 @@ func (h *Impl) Start() {
 	h.runtimeMetricsReporter.Start()
 
 	h.membershipMonitor.Start()
+	h.clusterMetadataRefresher.Start()
+	h.historyShardLayout.Start()
 	h.namespaceCache.Start()
 
 	hostInfo, err := h.membershipMonitor.WhoAmI()
@@ -394,6 +425,8 @@ func (h *Impl) Stop() {
 	}
 
 	h.namespaceCache.Stop()
+	h.historyShardLayout.Stop()
+	h.clusterMetadataRefresher.Stop()
 	h.membershipMonitor.Stop()
 	h.ringpopChannel.Close()
 	if err := h.grpcListener.Close(); err != nil {
@@ -424,6 +457,11 @@ func (h *Impl) GetClusterMetadata() cluster.Metadata {
 	return h.clusterMetadata
 }
 
+// GetHistoryShardLayout return history shard layout
+func (h *Impl) GetHistoryShardLayout() resharding.Layout {
+	return h.historyShardLayout
+}
+
 // other common resources
 
 // GetNamespaceCache return namespace cache
@@ -446,6 +484,11 @@ func (h *Impl) GetMetricsClient() metrics.Client {
 	return h.metricsClient
 }
 
+// GetTracer return tracer
+func (h *Impl) GetTracer() tracing.Tracer {
+	return h.tracer
+}
+
 // GetMessagingClient return messaging client
 func (h *Impl) GetMessagingClient() messaging.Client {
 	return h.messagingClient
@@ -461,6 +504,11 @@ func (h *Impl) GetArchiverProvider() provider.ArchiverProvider {
 	return h.archiverProvider
 }
 
+// GetPayloadOffloader return payload offloader
+func (h *Impl) GetPayloadOffloader() blobstore.PayloadOffloader {
+	return h.payloadOffloader
+}
+
 // membership infos
 
 // GetMembershipMonitor return the membership monitor
@@ -573,6 +621,11 @@ func (h *Impl) GetNamespaceReplicationQueue() persistence.NamespaceReplicationQu
 	return h.persistenceBean.GetNamespaceReplicationQueue()
 }
 
+// GetAuditLogQueue return audit log queue
+func (h *Impl) GetAuditLogQueue() persistence.AuditLogQueue {
+	return h.persistenceBean.GetAuditLogQueue()
+}
+
 // GetShardManager return shard manager
 func (h *Impl) GetShardManager() persistence.ShardManager {
 	return h.persistenceBean.GetShardManager()
@@ -612,3 +665,22 @@ func (h *Impl) GetThrottledLogger() log.Logger {
 func (h *Impl) GetGRPCListener() net.Listener {
 	return h.grpcListener
 }
+
+func persistenceMaxQPSFn(
+	persistenceMaxQPS dynamicconfig.IntPropertyFn,
+	persistenceGlobalMaxQPS dynamicconfig.IntPropertyFn,
+) dynamicconfig.IntPropertyFn {
+	return func(...dynamicconfig.FilterOption) int {
+		if persistenceGlobalMaxQPS() > 0 {
+			// TODO: We have a bootstrap issue to correctly find memberCount.  Membership relies on
+			// persistence to bootstrap membership ring, so we cannot have persistence rely on membership
+			// as it will cause circular dependency.
+			// ringSize, err := membershipMonitor.GetMemberCount(serviceName)
+			// if err == nil && ringSize > 0 {
+			// 	avgQuota := common.MaxInt(persistenceGlobalMaxQPS()/ringSize, 1)
+			// 	return common.MinInt(avgQuota, persistenceMaxQPS())
+			// }
+		}
+		return persistenceMaxQPS()
+	}
+}
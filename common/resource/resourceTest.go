@@ -46,6 +46,7 @@ import (
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/blobstore"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/cluster"
@@ -57,13 +58,16 @@ import (
 	"github.com/temporalio/temporal/common/mocks"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
+	"github.com/temporalio/temporal/common/resharding"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 type (
 	// Test is the test implementation used for testing
 	Test struct {
-		MetricsScope    tally.Scope
-		ClusterMetadata *cluster.MockMetadata
+		MetricsScope       tally.Scope
+		ClusterMetadata    *cluster.MockMetadata
+		HistoryShardLayout resharding.Layout
 
 		// other common resources
 
@@ -71,8 +75,10 @@ type (
 		TimeSource        clock.TimeSource
 		PayloadSerializer persistence.PayloadSerializer
 		MetricsClient     metrics.Client
+		Tracer            tracing.Tracer
 		ArchivalMetadata  *archiver.MockArchivalMetadata
 		ArchiverProvider  *provider.MockArchiverProvider
+		PayloadOffloader  blobstore.PayloadOffloader
 
 		// membership infos
 
@@ -95,9 +101,11 @@ type (
 		// persistence clients
 
 		MetadataMgr               *mocks.MetadataManager
+		ClusterMetadataMgr        *mocks.MockClusterMetadataManager
 		TaskMgr                   *mocks.TaskManager
 		VisibilityMgr             *mocks.VisibilityManager
 		NamespaceReplicationQueue persistence.NamespaceReplicationQueue
+		AuditLogQueue             *persistence.MockAuditLogQueue
 		ShardMgr                  *mocks.ShardManager
 		HistoryMgr                *mocks.HistoryV2Manager
 		ExecutionMgr              *mocks.ExecutionManager
@@ -143,6 +151,7 @@ func NewTest(
 
 	metadataMgr := &mocks.MetadataManager{}
 	taskMgr := &mocks.TaskManager{}
+	clusterMetadataMgr := mocks.NewMockClusterMetadataManager(controller)
 	visibilityMgr := &mocks.VisibilityManager{}
 	shardMgr := &mocks.ShardManager{}
 	historyMgr := &mocks.HistoryV2Manager{}
@@ -150,14 +159,17 @@ func NewTest(
 	namespaceReplicationQueue := persistence.NewMockNamespaceReplicationQueue(controller)
 	namespaceReplicationQueue.EXPECT().Start().AnyTimes()
 	namespaceReplicationQueue.EXPECT().Stop().AnyTimes()
+	auditLogQueue := persistence.NewMockAuditLogQueue(controller)
 	persistenceBean := persistenceClient.NewMockBean(controller)
 	persistenceBean.EXPECT().GetMetadataManager().Return(metadataMgr).AnyTimes()
+	persistenceBean.EXPECT().GetClusterMetadataManager().Return(clusterMetadataMgr).AnyTimes()
 	persistenceBean.EXPECT().GetTaskManager().Return(taskMgr).AnyTimes()
 	persistenceBean.EXPECT().GetVisibilityManager().Return(visibilityMgr).AnyTimes()
 	persistenceBean.EXPECT().GetHistoryManager().Return(historyMgr).AnyTimes()
 	persistenceBean.EXPECT().GetShardManager().Return(shardMgr).AnyTimes()
 	persistenceBean.EXPECT().GetExecutionManager(gomock.Any()).Return(executionMgr, nil).AnyTimes()
 	persistenceBean.EXPECT().GetNamespaceReplicationQueue().Return(namespaceReplicationQueue).AnyTimes()
+	persistenceBean.EXPECT().GetAuditLogQueue().Return(auditLogQueue).AnyTimes()
 
 	membershipMonitor := membership.NewMockMonitor(controller)
 	frontendServiceResolver := membership.NewMockServiceResolver(controller)
@@ -170,10 +182,12 @@ func NewTest(
 	membershipMonitor.EXPECT().GetResolver(common.WorkerServiceName).Return(workerServiceResolver, nil).AnyTimes()
 
 	scope := tally.NewTestScope("test", nil)
+	archiverProvider := &provider.MockArchiverProvider{}
 
 	return &Test{
-		MetricsScope:    scope,
-		ClusterMetadata: cluster.NewMockMetadata(controller),
+		MetricsScope:       scope,
+		ClusterMetadata:    cluster.NewMockMetadata(controller),
+		HistoryShardLayout: resharding.NewStaticLayout(1),
 
 		// other common resources
 
@@ -181,8 +195,10 @@ func NewTest(
 		TimeSource:        clock.NewRealTimeSource(),
 		PayloadSerializer: persistence.NewPayloadSerializer(),
 		MetricsClient:     metrics.NewClient(scope, serviceMetricsIndex),
+		Tracer:            tracing.NewNoopTracer(),
 		ArchivalMetadata:  &archiver.MockArchivalMetadata{},
-		ArchiverProvider:  &provider.MockArchiverProvider{},
+		ArchiverProvider:  archiverProvider,
+		PayloadOffloader:  blobstore.NewPayloadOffloader(archiverProvider),
 
 		// membership infos
 
@@ -205,9 +221,11 @@ func NewTest(
 		// persistence clients
 
 		MetadataMgr:               metadataMgr,
+		ClusterMetadataMgr:        clusterMetadataMgr,
 		TaskMgr:                   taskMgr,
 		VisibilityMgr:             visibilityMgr,
 		NamespaceReplicationQueue: namespaceReplicationQueue,
+		AuditLogQueue:             auditLogQueue,
 		ShardMgr:                  shardMgr,
 		HistoryMgr:                historyMgr,
 		ExecutionMgr:              executionMgr,
@@ -251,6 +269,11 @@ func (s *Test) GetClusterMetadata() cluster.Metadata {
 	return s.ClusterMetadata
 }
 
+// GetHistoryShardLayout for testing
+func (s *Test) GetHistoryShardLayout() resharding.Layout {
+	return s.HistoryShardLayout
+}
+
 // other common resources
 
 // GetNamespaceCache for testing
@@ -273,6 +296,11 @@ func (s *Test) GetMetricsClient() metrics.Client {
 	return s.MetricsClient
 }
 
+// GetTracer for testing
+func (s *Test) GetTracer() tracing.Tracer {
+	return s.Tracer
+}
+
 // GetMessagingClient for testing
 func (s *Test) GetMessagingClient() messaging.Client {
 	panic("user should implement this method for test")
@@ -288,6 +316,11 @@ func (s *Test) GetArchiverProvider() provider.ArchiverProvider {
 	return s.ArchiverProvider
 }
 
+// GetPayloadOffloader for testing
+func (s *Test) GetPayloadOffloader() blobstore.PayloadOffloader {
+	return s.PayloadOffloader
+}
+
 // membership infos
 
 // GetMembershipMonitor for testing
@@ -380,6 +413,11 @@ func (s *Test) GetMetadataManager() persistence.MetadataManager {
 	return s.MetadataMgr
 }
 
+// GetClusterMetadataManager for testing
+func (s *Test) GetClusterMetadataManager() persistence.ClusterMetadataManager {
+	return s.ClusterMetadataMgr
+}
+
 // GetTaskManager for testing
 func (s *Test) GetTaskManager() persistence.TaskManager {
 	return s.TaskMgr
@@ -396,6 +434,11 @@ func (s *Test) GetNamespaceReplicationQueue() persistence.NamespaceReplicationQu
 	return s.NamespaceReplicationQueue
 }
 
+// GetAuditLogQueue for testing
+func (s *Test) GetAuditLogQueue() persistence.AuditLogQueue {
+	return s.AuditLogQueue
+}
+
 // GetShardManager for testing
 func (s *Test) GetShardManager() persistence.ShardManager {
 	return s.ShardMgr
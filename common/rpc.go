@@ -37,6 +37,7 @@ type (
 		GetFrontendGRPCServerOptions() ([]grpc.ServerOption, error)
 		GetInternodeGRPCServerOptions() ([]grpc.ServerOption, error)
 		GetGRPCListener() net.Listener
+		GetHTTPListener() net.Listener
 		GetRingpopChannel() *tchannel.Channel
 		CreateFrontendGRPCConnection(hostName string) *grpc.ClientConn
 		CreateInternodeGRPCConnection(hostName string) *grpc.ClientConn
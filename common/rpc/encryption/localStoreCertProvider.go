@@ -25,26 +25,44 @@
 package encryption
 
 import (
+	"bytes"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"sync"
+	"time"
 
 	"github.com/temporalio/temporal/common/service/config"
 )
 
 var _ CertProvider = (*localStoreCertProvider)(nil)
 
+// localStoreCertProvider loads the certificates from the files of the TLS settings and watches the files,
+// the files are read again at most once per refresh interval and the certificates are reloaded when they changed
 type localStoreCertProvider struct {
 	sync.RWMutex
 
-	tlsSettings *config.GroupTLS
+	tlsSettings     *config.GroupTLS
+	refreshInterval time.Duration
 
-	serverCert *tls.Certificate
-	clientCAs  *x509.CertPool
-	serverCAs  *x509.CertPool
+	loaded      bool
+	lastChecked time.Time
+	files       map[string][]byte
+
+	serverCert         *tls.Certificate
+	clientCAs          *x509.CertPool
+	serverCAs          *x509.CertPool
+	namespaceClientCAs map[string][]*x509.Certificate
+}
+
+func newLocalStoreCertProvider(tlsSettings *config.GroupTLS, refreshInterval time.Duration) *localStoreCertProvider {
+	return &localStoreCertProvider{
+		tlsSettings:     tlsSettings,
+		refreshInterval: refreshInterval,
+	}
 }
 
 func (s *localStoreCertProvider) GetSettings() *config.GroupTLS {
@@ -55,96 +73,210 @@ func (s *localStoreCertProvider) FetchServerCertificate() (*tls.Certificate, err
 	if s.tlsSettings.Server.CertFile == "" {
 		return nil, nil
 	}
+	if err := s.refresh(); err != nil {
+		return nil, err
+	}
 
-	// Check under a read lock first
 	s.RLock()
-	if s.serverCert != nil {
-		defer s.RUnlock()
-		return s.serverCert, nil
-	}
-	// Not found, manually unlock read lock and move to write lock
-	s.RUnlock()
-	s.Lock()
-	defer s.Unlock()
+	defer s.RUnlock()
+	return s.serverCert, nil
+}
 
-	// Get serverCert from disk
-	serverCert, err := tls.LoadX509KeyPair(s.tlsSettings.Server.CertFile, s.tlsSettings.Server.KeyFile)
-	if err != nil {
-		return nil, fmt.Errorf("loading server tls certificate failed: %v", err)
+func (s *localStoreCertProvider) FetchClientCAs() (*x509.CertPool, error) {
+	if err := s.refresh(); err != nil {
+		return nil, err
 	}
 
-	s.serverCert = &serverCert
-	return s.serverCert, nil
+	s.RLock()
+	defer s.RUnlock()
+	return s.clientCAs, nil
 }
 
-func (s *localStoreCertProvider) FetchClientCAs() (*x509.CertPool, error) {
-	if s.tlsSettings.Server.ClientCAFiles == nil {
-		return nil, nil
+func (s *localStoreCertProvider) FetchServerRootCAsForClient() (*x509.CertPool, error) {
+	if err := s.refresh(); err != nil {
+		return nil, err
 	}
 
-	// Check under a read lock first
 	s.RLock()
-	if s.clientCAs != nil {
-		defer s.RUnlock()
-		return s.clientCAs, nil
+	defer s.RUnlock()
+	return s.serverCAs, nil
+}
+
+func (s *localStoreCertProvider) FetchNamespaceClientCAs() (map[string][]*x509.Certificate, error) {
+	if err := s.refresh(); err != nil {
+		return nil, err
 	}
-	// Not found, manually unlock read lock and move to write lock
+
+	s.RLock()
+	defer s.RUnlock()
+	return s.namespaceClientCAs, nil
+}
+
+// refresh reads the files once the refresh interval elapsed and reloads the certificates if any file changed.
+// Only the first load fails on invalid files, afterwards the previous certificates are kept until the files
+// can be loaded again, e.g. while the certificate file is replaced but the key file is not yet.
+func (s *localStoreCertProvider) refresh() error {
+	s.RLock()
+	fresh := s.isFresh()
 	s.RUnlock()
+	if fresh {
+		return nil
+	}
+
 	s.Lock()
 	defer s.Unlock()
+	// Check if someone got here first while waiting for write lock
+	if s.isFresh() {
+		return nil
+	}
 
-	var clientCaPool *x509.CertPool
-	if len(s.tlsSettings.Server.ClientCAFiles) > 0 {
-		var err error
-		clientCaPool, err = buildCAPool(s.tlsSettings.Server.ClientCAFiles)
-		if err != nil {
-			return nil, err
+	files, err := s.readFiles()
+	if err == nil && s.loaded && sameFiles(s.files, files) {
+		s.lastChecked = time.Now()
+		return nil
+	}
+	if err == nil {
+		err = s.load(files)
+	}
+	if err != nil {
+		if s.loaded {
+			s.lastChecked = time.Now()
+			return nil
 		}
+		return err
 	}
 
-	s.clientCAs = clientCaPool
-	return s.clientCAs, nil
+	s.files = files
+	s.loaded = true
+	s.lastChecked = time.Now()
+	return nil
 }
 
-func (s *localStoreCertProvider) FetchServerRootCAsForClient() (*x509.CertPool, error) {
-	if s.tlsSettings.Client.RootCAFiles == nil {
-		return nil, nil
+func (s *localStoreCertProvider) isFresh() bool {
+	return s.loaded && time.Since(s.lastChecked) < s.refreshInterval
+}
+
+func (s *localStoreCertProvider) readFiles() (map[string][]byte, error) {
+	var paths []string
+	if s.tlsSettings.Server.CertFile != "" {
+		paths = append(paths, s.tlsSettings.Server.CertFile, s.tlsSettings.Server.KeyFile)
 	}
-	// Check under a read lock first
-	s.RLock()
-	if s.serverCAs != nil {
-		defer s.RUnlock()
-		return s.clientCAs, nil
+	paths = append(paths, s.tlsSettings.Server.ClientCAFiles...)
+	paths = append(paths, s.tlsSettings.Client.RootCAFiles...)
+	for _, caFiles := range s.tlsSettings.Server.NamespaceClientCAFiles {
+		paths = append(paths, caFiles...)
+	}
+
+	files := make(map[string][]byte, len(paths))
+	for _, path := range paths {
+		if _, ok := files[path]; ok {
+			continue
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading tls file: %v", err)
+		}
+		files[path] = content
+	}
+	return files, nil
+}
+
+func (s *localStoreCertProvider) load(files map[string][]byte) error {
+	var serverCert *tls.Certificate
+	if s.tlsSettings.Server.CertFile != "" {
+		cert, err := tls.X509KeyPair(files[s.tlsSettings.Server.CertFile], files[s.tlsSettings.Server.KeyFile])
+		if err != nil {
+			return fmt.Errorf("loading server tls certificate failed: %v", err)
+		}
+		serverCert = &cert
+	}
+
+	// The client CAs include the namespace CAs, so that the clients of any namespace are verified
+	var clientCAs *x509.CertPool
+	if len(s.tlsSettings.Server.ClientCAFiles) > 0 || len(s.tlsSettings.Server.NamespaceClientCAFiles) > 0 {
+		var err error
+		clientCAs, err = buildCAPool(files, s.tlsSettings.Server.ClientCAFiles)
+		if err != nil {
+			return err
+		}
+	}
+
+	namespaceClientCAs := make(map[string][]*x509.Certificate, len(s.tlsSettings.Server.NamespaceClientCAFiles))
+	for namespace, caFiles := range s.tlsSettings.Server.NamespaceClientCAFiles {
+		for _, caFile := range caFiles {
+			certs, err := parseCertificates(files[caFile])
+			if err != nil {
+				return fmt.Errorf("failed reading client ca cert of namespace %v: %v", namespace, err)
+			}
+			for _, cert := range certs {
+				clientCAs.AddCert(cert)
+			}
+			namespaceClientCAs[namespace] = append(namespaceClientCAs[namespace], certs...)
+		}
 	}
-	// Not found, manually unlock read lock and move to write lock
-	s.RUnlock()
-	s.Lock()
-	defer s.Unlock()
 
-	var serverCAPool *x509.CertPool
+	var serverCAs *x509.CertPool
 	if len(s.tlsSettings.Client.RootCAFiles) > 0 {
 		var err error
-		serverCAPool, err = buildCAPool(s.tlsSettings.Client.RootCAFiles)
+		serverCAs, err = buildCAPool(files, s.tlsSettings.Client.RootCAFiles)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	s.serverCAs = serverCAPool
-	return s.serverCAs, nil
+	s.serverCert = serverCert
+	s.clientCAs = clientCAs
+	s.serverCAs = serverCAs
+	s.namespaceClientCAs = namespaceClientCAs
+	return nil
+}
+
+func sameFiles(previous map[string][]byte, current map[string][]byte) bool {
+	if len(previous) != len(current) {
+		return false
+	}
+	for path, content := range current {
+		if !bytes.Equal(previous[path], content) {
+			return false
+		}
+	}
+	return true
 }
 
-func buildCAPool(caFiles []string) (*x509.CertPool, error) {
+func buildCAPool(files map[string][]byte, caFiles []string) (*x509.CertPool, error) {
 	caPool := x509.NewCertPool()
-	for _, ca := range caFiles {
-		caBytes, err := ioutil.ReadFile(ca)
+	for _, caFile := range caFiles {
+		certs, err := parseCertificates(files[caFile])
 		if err != nil {
 			return nil, fmt.Errorf("failed reading client ca cert: %v", err)
 		}
-
-		if !caPool.AppendCertsFromPEM(caBytes) {
-			return nil, errors.New("unknown failure constructing cert pool for ca")
+		for _, cert := range certs {
+			caPool.AddCert(cert)
 		}
 	}
 	return caPool, nil
 }
+
+func parseCertificates(pemBytes []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for len(pemBytes) > 0 {
+		var block *pem.Block
+		block, pemBytes = pem.Decode(pemBytes)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" || len(block.Headers) != 0 {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificate found in pem")
+	}
+	return certs, nil
+}
@@ -28,11 +28,16 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/temporalio/temporal/common/service/config"
 )
 
+// defaultRefreshInterval is the interval at which the certificate files are checked for changes by default
+const defaultRefreshInterval = time.Minute
+
 type localStoreTlsProvider struct {
 	sync.RWMutex
 
@@ -48,9 +53,14 @@ type localStoreTlsProvider struct {
 }
 
 func NewLocalStoreTlsProvider(tlsConfig *config.RootTLS) (TLSConfigProvider, error) {
+	refreshInterval := tlsConfig.RefreshInterval
+	if refreshInterval <= 0 {
+		refreshInterval = defaultRefreshInterval
+	}
+
 	return &localStoreTlsProvider{
-		internodeCertProvider: &localStoreCertProvider{tlsSettings: &tlsConfig.Internode},
-		frontendCertProvider:  &localStoreCertProvider{tlsSettings: &tlsConfig.Frontend},
+		internodeCertProvider: newLocalStoreCertProvider(&tlsConfig.Internode, refreshInterval),
+		frontendCertProvider:  newLocalStoreCertProvider(&tlsConfig.Frontend, refreshInterval),
 		RWMutex:               sync.RWMutex{},
 		settings:              tlsConfig,
 	}, nil
@@ -72,6 +82,38 @@ func (s *localStoreTlsProvider) GetInternodeServerConfig() (*tls.Config, error)
 	return s.getOrCreateConfig(&s.internodeServerConfig, newServerTLSConfig, s.internodeCertProvider, s.internodeCertProvider)
 }
 
+func (s *localStoreTlsProvider) GetFrontendClientNamespaces(verifiedChains [][]*x509.Certificate) ([]string, error) {
+	namespaceClientCAs, err := s.frontendCertProvider.FetchNamespaceClientCAs()
+	if err != nil {
+		return nil, err
+	}
+
+	var namespaces []string
+	for namespace, caCerts := range namespaceClientCAs {
+		if isVerifiedBy(verifiedChains, caCerts) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
+
+// isVerifiedBy returns whether the root of any of the verified chains is one of the CA certificates
+func isVerifiedBy(verifiedChains [][]*x509.Certificate, caCerts []*x509.Certificate) bool {
+	for _, chain := range verifiedChains {
+		if len(chain) == 0 {
+			continue
+		}
+		root := chain[len(chain)-1]
+		for _, caCert := range caCerts {
+			if root.Equal(caCert) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (s *localStoreTlsProvider) getOrCreateConfig(
 	cachedConfig **tls.Config,
 	configConstructor tlsConfigConstructor,
@@ -108,8 +150,10 @@ func (s *localStoreTlsProvider) getOrCreateConfig(
 	return *cachedConfig, nil
 }
 
+// newServerTLSConfig creates a server config which looks up the certificate and the client CAs for every connection,
+// so that the reloaded certificates are used for the new connections
 func newServerTLSConfig(certProvider CertProvider, settingsProvider CertProvider) (*tls.Config, error) {
-	// Get serverCert from disk
+	// Get serverCert from disk, so that invalid files fail at startup
 	serverCert, err := certProvider.FetchServerCertificate()
 	if err != nil {
 		return nil, fmt.Errorf("loading server tls certificate failed: %v", err)
@@ -120,30 +164,46 @@ func newServerTLSConfig(certProvider CertProvider, settingsProvider CertProvider
 		return nil, nil
 	}
 
-	// Default to NoClientAuth
-	clientAuthType := tls.NoClientCert
-	var clientCaPool *x509.CertPool
-
-	// If mTLS enabled
-	if settingsProvider.GetSettings().Server.RequireClientAuth {
-		// TODO: We could expose tls.ClientAuth enum instead of a bool `RequireClientAuth` for more fine grained control in config
-		clientAuthType = tls.RequireAndVerifyClientCert
+	getCertificate := func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		return certProvider.FetchServerCertificate()
+	}
 
-		ca, err := certProvider.FetchClientCAs()
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch client CAs: %v", err)
-		}
+	// Default to NoClientAuth
+	if !settingsProvider.GetSettings().Server.RequireClientAuth {
+		return &tls.Config{
+			ClientAuth:     tls.NoClientCert,
+			GetCertificate: getCertificate,
+		}, nil
+	}
 
-		clientCaPool = ca
+	// mTLS enabled
+	// TODO: We could expose tls.ClientAuth enum instead of a bool `RequireClientAuth` for more fine grained control in config
+	if _, err := certProvider.FetchClientCAs(); err != nil {
+		return nil, fmt.Errorf("failed to fetch client CAs: %v", err)
 	}
 
 	return &tls.Config{
-		ClientAuth:   clientAuthType,
-		Certificates: []tls.Certificate{*serverCert},
-		ClientCAs:    clientCaPool,
+		ClientAuth:     tls.RequireAndVerifyClientCert,
+		GetCertificate: getCertificate,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			// the pool includes the namespace client CAs, the frontend restricts the callers verified by them
+			// to their namespaces, see GetFrontendClientNamespaces
+			clientCaPool, err := certProvider.FetchClientCAs()
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch client CAs: %v", err)
+			}
+
+			return &tls.Config{
+				ClientAuth:     tls.RequireAndVerifyClientCert,
+				GetCertificate: getCertificate,
+				ClientCAs:      clientCaPool,
+			}, nil
+		},
 	}, nil
 }
 
+// newClientTLSConfig creates a client config which looks up the client certificate for every connection.
+// The root CAs are loaded once, as the config is copied by the connections when they are created.
 func newClientTLSConfig(localProvider CertProvider, remoteProvider CertProvider) (*tls.Config, error) {
 	// Optional ServerCA for client if not already trusted by host
 	serverCa, err := remoteProvider.FetchServerRootCAsForClient()
@@ -152,7 +212,7 @@ func newClientTLSConfig(localProvider CertProvider, remoteProvider CertProvider)
 	}
 
 	// mTLS enabled, present certificate
-	var clientCerts []tls.Certificate
+	var getClientCertificate func(*tls.CertificateRequestInfo) (*tls.Certificate, error)
 	if remoteProvider.GetSettings().Server.RequireClientAuth {
 		cert, err := localProvider.FetchServerCertificate()
 		if err != nil {
@@ -162,12 +222,14 @@ func newClientTLSConfig(localProvider CertProvider, remoteProvider CertProvider)
 		if cert == nil {
 			return nil, fmt.Errorf("client auth required, but no certificate provided")
 		}
-		clientCerts = []tls.Certificate{*cert}
+		getClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return localProvider.FetchServerCertificate()
+		}
 	}
 
 	return &tls.Config{
-		Certificates: clientCerts,
-		RootCAs:      serverCa,
-		ServerName:   remoteProvider.GetSettings().Client.ServerName,
+		GetClientCertificate: getClientCertificate,
+		RootCAs:              serverCa,
+		ServerName:           remoteProvider.GetSettings().Client.ServerName,
 	}, nil
 }
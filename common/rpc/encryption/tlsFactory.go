@@ -38,13 +38,18 @@ type (
 		GetInternodeClientConfig() (*tls.Config, error)
 		GetFrontendServerConfig() (*tls.Config, error)
 		GetFrontendClientConfig() (*tls.Config, error)
+		// GetFrontendClientNamespaces returns the namespaces bound to the client CAs which verified the
+		// certificate chains of a frontend client.
+		GetFrontendClientNamespaces(verifiedChains [][]*x509.Certificate) ([]string, error)
 	}
 
 	// CertProvider is a common interface to load raw TLS/X509 primitives.
 	CertProvider interface {
 		FetchServerCertificate() (*tls.Certificate, error)
+		// FetchClientCAs returns the CAs trusted for client authentication, including the CAs of the namespaces.
 		FetchClientCAs() (*x509.CertPool, error)
 		FetchServerRootCAsForClient() (*x509.CertPool, error)
+		FetchNamespaceClientCAs() (map[string][]*x509.Certificate, error)
 		GetSettings() *config.GroupTLS
 	}
 
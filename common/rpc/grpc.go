@@ -27,6 +27,7 @@ package rpc
 import (
 	"context"
 	"crypto/tls"
+	"io"
 
 	"github.com/gogo/status"
 	"go.temporal.io/temporal-proto/serviceerror"
@@ -34,6 +35,7 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	"github.com/temporalio/temporal/common/headers"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 const (
@@ -58,7 +60,11 @@ func Dial(hostName string, tlsConfig *tls.Config) (*grpc.ClientConn, error) {
 		grpcSecureOpt,
 		grpc.WithChainUnaryInterceptor(
 			versionHeadersInterceptor,
+			tracing.ClientInterceptor,
 			errorInterceptor),
+		grpc.WithChainStreamInterceptor(
+			versionHeadersStreamInterceptor,
+			errorStreamInterceptor),
 		grpc.WithDefaultServiceConfig(DefaultServiceConfig),
 		grpc.WithDisableServiceConfig(),
 	)
@@ -70,7 +76,35 @@ func errorInterceptor(ctx context.Context, method string, req, reply interface{}
 	return err
 }
 
+type errorClientStream struct {
+	grpc.ClientStream
+}
+
+func errorStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, serviceerror.FromStatus(status.Convert(err))
+	}
+	return &errorClientStream{ClientStream: stream}, nil
+}
+
+// RecvMsg converts the stream errors to service errors, io.EOF is kept as is because it marks the end of the stream
+func (s *errorClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil || err == io.EOF {
+		return err
+	}
+	return serviceerror.FromStatus(status.Convert(err))
+}
+
 func versionHeadersInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 	ctx = headers.PropagateVersions(ctx)
+	ctx = headers.PropagateRequestOptions(ctx)
 	return invoker(ctx, method, req, reply, cc, opts...)
 }
+
+func versionHeadersStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx = headers.PropagateVersions(ctx)
+	ctx = headers.PropagateRequestOptions(ctx)
+	return streamer(ctx, desc, cc, method, opts...)
+}
@@ -48,6 +48,7 @@ type RPCFactory struct {
 
 	sync.Mutex
 	grpcListener   net.Listener
+	httpListener   net.Listener
 	ringpopChannel *tchannel.Channel
 	tlsFactory     encryption.TLSConfigProvider
 }
@@ -137,6 +138,43 @@ func (d *RPCFactory) GetGRPCListener() net.Listener {
 	return d.grpcListener
 }
 
+// GetHTTPListener returns cached listener for the HTTP gateway or creates one, it returns nil when the
+// HTTP port is not configured. The listener uses the frontend server TLS config when there is one.
+func (d *RPCFactory) GetHTTPListener() net.Listener {
+	if d.config.HTTPPort == 0 {
+		return nil
+	}
+	if d.httpListener != nil {
+		return d.httpListener
+	}
+
+	d.Lock()
+	defer d.Unlock()
+
+	if d.httpListener == nil {
+		hostAddress := fmt.Sprintf("%v:%v", getListenIP(d.config, d.logger), d.config.HTTPPort)
+		listener, err := net.Listen("tcp", hostAddress)
+		if err != nil {
+			d.logger.Fatal("Failed to start HTTP listener", tag.Error(err), tag.Service(d.serviceName), tag.Address(hostAddress))
+		}
+
+		if d.tlsFactory != nil {
+			serverConfig, err := d.tlsFactory.GetFrontendServerConfig()
+			if err != nil {
+				d.logger.Fatal("Failed to create HTTP listener TLS config", tag.Error(err), tag.Service(d.serviceName))
+			}
+			if serverConfig != nil {
+				listener = tls.NewListener(listener, serverConfig)
+			}
+		}
+		d.httpListener = listener
+
+		d.logger.Info("Created HTTP listener", tag.Service(d.serviceName), tag.Address(hostAddress))
+	}
+
+	return d.httpListener
+}
+
 // GetRingpopChannel return a cached ringpop dispatcher
 func (d *RPCFactory) GetRingpopChannel() *tchannel.Channel {
 	if d.ringpopChannel != nil {
@@ -31,6 +31,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -297,3 +298,124 @@ func (s *localStoreRPCSuite) TestMutualTLSButClientNoCert() {
 func (s *localStoreRPCSuite) TestServerTLSButClientAddsCert() {
 	runHelloWorldTest(s.Suite, s.internodeServerTLSRPCFactory, s.internodeMutualTLSRPCFactory, true)
 }
+
+func (s *localStoreRPCSuite) TestServerTLSCertificateReload() {
+	certDir, err := ioutil.TempDir("", "localStoreRPCSuiteReload")
+	s.NoError(err)
+	defer func() { _ = os.RemoveAll(certDir) }()
+	chain := s.GenerateTestChain(certDir)
+	rotatedCertDir, err := ioutil.TempDir("", "localStoreRPCSuiteRotated")
+	s.NoError(err)
+	defer func() { _ = os.RemoveAll(rotatedCertDir) }()
+	rotatedChain := s.GenerateTestChain(rotatedCertDir)
+
+	refreshInterval := 10 * time.Millisecond
+	provider, err := encryption.NewTLSConfigProviderFromConfig(config.RootTLS{
+		Internode: config.GroupTLS{
+			Server: config.ServerTLS{
+				CertFile: chain.CertPubFile,
+				KeyFile:  chain.CertKeyFile,
+			},
+		},
+		RefreshInterval: refreshInterval,
+	})
+	s.NoError(err)
+	serverFactory := i(NewFactory(rpcTestCfgDefault, "tester", s.logger, provider))
+
+	// the client only trusts the CA of the rotated certificate
+	provider, err = encryption.NewTLSConfigProviderFromConfig(config.RootTLS{
+		Internode: config.GroupTLS{
+			Server: config.ServerTLS{
+				CertFile: rotatedChain.CertPubFile,
+				KeyFile:  rotatedChain.CertKeyFile,
+			},
+			Client: config.ClientTLS{
+				RootCAFiles: []string{rotatedChain.CaPubFile},
+			},
+		},
+	})
+	s.NoError(err)
+	clientFactory := i(NewFactory(rpcTestCfgDefault, "tester", s.logger, provider))
+
+	server, port := startHelloWorldServer(s.Suite, serverFactory)
+	defer server.Stop()
+	s.Error(dialHello(s.Suite, "127.0.0.1:"+port, clientFactory, Internode))
+
+	s.copyFile(rotatedChain.CertPubFile, chain.CertPubFile)
+	s.copyFile(rotatedChain.CertKeyFile, chain.CertKeyFile)
+	time.Sleep(2 * refreshInterval)
+	s.NoError(dialHello(s.Suite, "127.0.0.1:"+port, clientFactory, Internode))
+}
+
+func (s *localStoreRPCSuite) TestMutualTLSNamespaceClientCAs() {
+	certDir, err := ioutil.TempDir("", "localStoreRPCSuiteNamespace")
+	s.NoError(err)
+	defer func() { _ = os.RemoveAll(certDir) }()
+	namespaceChain := s.GenerateTestChain(certDir)
+
+	serverProvider, err := encryption.NewTLSConfigProviderFromConfig(config.RootTLS{
+		Frontend: config.GroupTLS{
+			Server: config.ServerTLS{
+				CertFile:               s.frontendChain.CertPubFile,
+				KeyFile:                s.frontendChain.CertKeyFile,
+				NamespaceClientCAFiles: map[string][]string{"test-namespace": {namespaceChain.CaPubFile}},
+				RequireClientAuth:      true,
+			},
+		},
+	})
+	s.NoError(err)
+	serverFactory := f(NewFactory(rpcTestCfgDefault, "tester", s.logger, serverProvider))
+
+	clientProvider, err := encryption.NewTLSConfigProviderFromConfig(config.RootTLS{
+		Internode: config.GroupTLS{
+			Server: config.ServerTLS{
+				CertFile: namespaceChain.CertPubFile,
+				KeyFile:  namespaceChain.CertKeyFile,
+			},
+		},
+		Frontend: config.GroupTLS{
+			Server: config.ServerTLS{
+				RequireClientAuth: true,
+			},
+			Client: config.ClientTLS{
+				RootCAFiles: []string{s.frontendChain.CaPubFile},
+			},
+		},
+	})
+	s.NoError(err)
+	clientFactory := i(NewFactory(rpcTestCfgDefault, "tester", s.logger, clientProvider))
+
+	server, port := startHelloWorldServer(s.Suite, serverFactory)
+	defer server.Stop()
+	s.NoError(dialHello(s.Suite, "127.0.0.1:"+port, clientFactory, Frontend))
+	s.Error(dialHello(s.Suite, "127.0.0.1:"+port, s.internodeMutualTLSRPCFactory, Frontend))
+
+	namespaces, err := serverProvider.GetFrontendClientNamespaces([][]*x509.Certificate{
+		{s.readCertificate(namespaceChain.CertPubFile), s.readCertificate(namespaceChain.CaPubFile)},
+	})
+	s.NoError(err)
+	s.Equal([]string{"test-namespace"}, namespaces)
+
+	namespaces, err = serverProvider.GetFrontendClientNamespaces([][]*x509.Certificate{
+		{s.readCertificate(s.internodeChain.CertPubFile), s.readCertificate(s.internodeChain.CaPubFile)},
+	})
+	s.NoError(err)
+	s.Empty(namespaces)
+}
+
+func (s *localStoreRPCSuite) copyFile(from string, to string) {
+	content, err := ioutil.ReadFile(from)
+	s.NoError(err)
+	err = ioutil.WriteFile(to, content, os.FileMode(0644))
+	s.NoError(err)
+}
+
+func (s *localStoreRPCSuite) readCertificate(file string) *x509.Certificate {
+	content, err := ioutil.ReadFile(file)
+	s.NoError(err)
+	block, _ := pem.Decode(content)
+	s.NotNil(block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	s.NoError(err)
+	return cert
+}
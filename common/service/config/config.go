@@ -68,8 +68,15 @@ type (
 		// DynamicConfigClient is the config for setting up the file based dynamic config client
 		// Filepath should be relative to the root directory
 		DynamicConfigClient dynamicconfig.FileBasedClientConfig `yaml:"dynamicConfigClient"`
+		// PersistenceDynamicConfigClient is the config for serving dynamic config values changed through
+		// the admin API from the default store. Values which are not stored fall back to DynamicConfigClient.
+		PersistenceDynamicConfigClient *dynamicconfig.PersistenceBasedClientConfig `yaml:"persistenceDynamicConfigClient"`
 		// NamespaceDefaults is the default config for every namespace
 		NamespaceDefaults NamespaceDefaults `yaml:"namespaceDefaults"`
+		// Audit is the config for the audit log of mutating frontend APIs, auditing is disabled when not set
+		Audit *Audit `yaml:"audit"`
+		// PayloadCodec is the config for encrypting payloads at the frontend, payloads are not encrypted when not set
+		PayloadCodec *PayloadCodec `yaml:"payloadCodec"`
 	}
 
 	// Service contains the service specific config items
@@ -78,6 +85,8 @@ type (
 		RPC RPC `yaml:"rpc"`
 		// Metrics is the metrics subsystem configuration
 		Metrics Metrics `yaml:"metrics"`
+		// Tracing is the distributed tracing configuration
+		Tracing Tracing `yaml:"tracing"`
 	}
 
 	// PProf contains the config items for the pprof utility
@@ -90,6 +99,9 @@ type (
 	RPC struct {
 		// GRPCPort is the port  on which gRPC will listen
 		GRPCPort int `yaml:"grpcPort"`
+		// HTTPPort is the port on which the HTTP/JSON gateway of the workflow service will listen,
+		// it is only served by the frontend and it is disabled when the port is not set
+		HTTPPort int `yaml:"httpPort"`
 		// Port used for membership listener
 		MembershipPort int `yaml:"membershipPort"`
 		// BindOnLocalHost is true if localhost is the bind address
@@ -120,6 +132,9 @@ type (
 		Internode GroupTLS `yaml:"internode"`
 		// Frontend controls SDK Client to Frontend communication TLS settings.
 		Frontend  GroupTLS `yaml:"frontend"`
+		// RefreshInterval is the interval at which the certificate files are checked for changes,
+		// changed certificates are used for the new connections without restarting the host. Defaults to a minute.
+		RefreshInterval time.Duration `yaml:"refreshInterval"`
 	}
 
 	// GroupTLS contains an instance client and server TLS settings
@@ -141,6 +156,11 @@ type (
 		ClientCAFiles     []string  `yaml:"clientCaFiles"`
 		// Requires clients to authenticate with a certificate when connecting, otherwise known as mutual TLS.
 		RequireClientAuth bool      `yaml:"requireClientAuth"`
+		// Paths to files containing the PEM-encoded public key of the Certificate Authorities trusted for the client
+		// authentication of a namespace, by namespace name. The clients verified by these CAs are bound to the namespace,
+		// the frontend rejects their calls to other namespaces and surfaces the binding to the authorizer.
+		// This value is only used by the frontend and if `requireClientAuth` is enabled.
+		NamespaceClientCAFiles map[string][]string `yaml:"namespaceClientCaFiles"`
 	}
 
 	// ClientTLS contains TLS configuration for clients.
@@ -180,6 +200,9 @@ type (
 		// NumHistoryShards is the desired number of history shards. This config doesn't
 		// belong here, needs refactoring
 		NumHistoryShards int `yaml:"numHistoryShards" validate:"nonzero"`
+		// TargetNumHistoryShards is the number of history shards to split the existing shards into.
+		// It must be a multiple of NumHistoryShards and cannot be reverted once shards are split
+		TargetNumHistoryShards int `yaml:"targetNumHistoryShards"`
 		// DataStores contains the configuration for all datastores
 		DataStores map[string]DataStore `yaml:"datastores"`
 		// VisibilityConfig is config for visibility sampling
@@ -351,6 +374,50 @@ type (
 		Prefix string `yaml:"prefix"`
 	}
 
+	// Tracing contains the config items for distributed tracing
+	Tracing struct {
+		// Exporter is the destination of ended spans, either "log" or "none" (default)
+		Exporter string `yaml:"exporter"`
+		// SampleRate is the fraction of new traces which are recorded, defaults to 1 when an exporter is set
+		SampleRate float64 `yaml:"sampleRate"`
+	}
+
+	// Audit contains the config for the audit log of mutating frontend APIs
+	Audit struct {
+		// Sinks are the destinations of audit records, any of "log", "file" and "persistence"
+		Sinks []string `yaml:"sinks"`
+		// File is the config for the "file" sink
+		File *AuditFile `yaml:"file"`
+	}
+
+	// PayloadCodec contains the config for the payload encryption codec of the frontend
+	PayloadCodec struct {
+		// KeyringFile is the yaml file with the encryption keys and the key used by each namespace
+		KeyringFile string `yaml:"keyringFile" validate:"nonzero"`
+		// InternalCallers are the client certificates of internal callers, such as the system
+		// workers, which always receive decoded payloads without the DecodePayloads permission
+		InternalCallers []PayloadCodecInternalCaller `yaml:"internalCallers"`
+	}
+
+	// PayloadCodecInternalCaller identifies the client certificate of an internal caller,
+	// both the subject and the issuer of the certificate have to match
+	PayloadCodecInternalCaller struct {
+		// Subject is the subject of the client certificate
+		Subject string `yaml:"subject" validate:"nonzero"`
+		// Issuer is the issuer of the client certificate
+		Issuer string `yaml:"issuer" validate:"nonzero"`
+	}
+
+	// AuditFile contains the config items for the rotating audit file
+	AuditFile struct {
+		// Path is the path of the active audit file, rotated files get a numeric suffix
+		Path string `yaml:"path" validate:"nonzero"`
+		// MaxSizeMB is the size at which the file is rotated, defaults to 100
+		MaxSizeMB int `yaml:"maxSizeMB"`
+		// MaxBackups is the number of rotated files kept, defaults to 10
+		MaxBackups int `yaml:"maxBackups"`
+	}
+
 	// Statsd contains the config items for statsd metrics reporter
 	Statsd struct {
 		// The host and port of the statsd server
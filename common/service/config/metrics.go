@@ -65,6 +65,9 @@ var (
 	}
 )
 
+// prometheusHistogramTimerType is the prometheus timerType which reports timers as histograms
+const prometheusHistogramTimerType = "histogram"
+
 // NewScope builds a new tally scope
 // for this metrics configuration
 //
@@ -86,6 +89,15 @@ func (c *Metrics) NewScope(logger log.Logger) tally.Scope {
 	return tally.NoopScope
 }
 
+// HistogramTimers returns true if timers are
+// reported as prometheus histograms, metrics
+// client emits these timers with the buckets
+// of their definitions
+func (c *Metrics) HistogramTimers() bool {
+	return c.M3 == nil && c.Statsd == nil &&
+		c.Prometheus != nil && c.Prometheus.TimerType == prometheusHistogramTimerType
+}
+
 // newM3Scope returns a new m3 scope with
 // a default reporting interval of a second
 func (c *Metrics) newM3Scope(logger log.Logger) tally.Scope {
@@ -59,6 +59,11 @@ func (c *Persistence) Validate() error {
 			ds.SQL.NumShards = 1
 		}
 	}
+	if c.TargetNumHistoryShards != 0 &&
+		(c.TargetNumHistoryShards < c.NumHistoryShards || c.TargetNumHistoryShards%c.NumHistoryShards != 0) {
+		return fmt.Errorf("persistence config: targetNumHistoryShards %v must be a multiple of numHistoryShards %v",
+			c.TargetNumHistoryShards, c.NumHistoryShards)
+	}
 	return nil
 }
 
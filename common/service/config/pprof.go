@@ -31,6 +31,7 @@ import (
 
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
 
 	// DO NOT REMOVE THE LINE BELOW
 	_ "net/http/pprof"
@@ -70,6 +71,8 @@ func (initializer *PProfInitializerImpl) Start() error {
 	}
 
 	if atomic.CompareAndSwapInt32(&pprofStatus, pprofNotInitialized, pprofInitialized) {
+		// metrics exemplars are served along with the profiles
+		http.Handle(metrics.ExemplarsHandlerPath, metrics.DefaultExemplarStore)
 		go func() {
 			initializer.Logger.Info("PProf listen on ", tag.Port(port))
 			err := http.ListenAndServe(fmt.Sprintf("localhost:%d", port), nil)
@@ -0,0 +1,56 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/tracing"
+)
+
+const (
+	// TracingExporterNone disables tracing
+	TracingExporterNone = "none"
+	// TracingExporterLog writes ended spans to the service log
+	TracingExporterLog = "log"
+)
+
+// NewTracer builds a new tracer for this tracing configuration
+func (c *Tracing) NewTracer(logger log.Logger) (tracing.Tracer, error) {
+	sampleRate := c.SampleRate
+	if sampleRate == 0 {
+		sampleRate = 1
+	}
+
+	switch c.Exporter {
+	case "", TracingExporterNone:
+		return tracing.NewNoopTracer(), nil
+	case TracingExporterLog:
+		return tracing.NewTracer(tracing.NewLogExporter(logger), sampleRate), nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %v", c.Exporter)
+	}
+}
@@ -78,6 +78,12 @@ var keys = map[Key]string{
 	EnableParentClosePolicyWorker:          "system.enableParentClosePolicyWorker",
 	EnableStickyQuery:                      "system.enableStickyQuery",
 	EnablePriorityTaskProcessor:            "system.enablePriorityTaskProcessor",
+	ClusterMetadataRefreshInterval:         "system.clusterMetadataRefreshInterval",
+	HistoryShardLayoutRefreshInterval:      "system.historyShardLayoutRefreshInterval",
+	HistoryShardOwnershipRefreshInterval:   "system.historyShardOwnershipRefreshInterval",
+	MetricsTagAllowlist:                    "system.metricsTagAllowlist",
+	PersistenceTargetLatency:               "system.persistenceTargetLatency",
+	PersistenceMaxErrorRatio:               "system.persistenceMaxErrorRatio",
 
 	// size limit
 	BlobSizeLimitError:     "limit.blobSize.error",
@@ -88,31 +94,47 @@ var keys = map[Key]string{
 	HistoryCountLimitWarn:  "limit.historyCount.warn",
 	MaxIDLengthLimit:       "limit.maxIDLength",
 
+	// payload offload
+	PayloadOffloadSizeThreshold: "history.payloadOffloadSizeThreshold",
+	PayloadOffloadTimeout:       "history.payloadOffloadTimeout",
+
+	// workflow completion callbacks
+	CompletionCallbackAllowedHosts:   "system.completionCallbackAllowedHosts",
+	FrontendMaxCompletionCallbacks:   "frontend.maxCompletionCallbacks",
+	CompletionCallbackRequestTimeout: "history.completionCallbackRequestTimeout",
+	CompletionCallbackMaxAttempts:    "history.completionCallbackMaxAttempts",
+
 	// frontend settings
-	FrontendPersistenceMaxQPS:             "frontend.persistenceMaxQPS",
-	FrontendPersistenceGlobalMaxQPS:       "frontend.persistenceGlobalMaxQPS",
-	FrontendVisibilityMaxPageSize:         "frontend.visibilityMaxPageSize",
-	FrontendVisibilityListMaxQPS:          "frontend.visibilityListMaxQPS",
-	FrontendESVisibilityListMaxQPS:        "frontend.esVisibilityListMaxQPS",
-	FrontendMaxBadBinaries:                "frontend.maxBadBinaries",
-	FrontendESIndexMaxResultWindow:        "frontend.esIndexMaxResultWindow",
-	FrontendHistoryMaxPageSize:            "frontend.historyMaxPageSize",
-	FrontendRPS:                           "frontend.rps",
-	FrontendMaxNamespaceRPSPerInstance:    "frontend.namespacerps",
-	FrontendGlobalNamespaceRPS:            "frontend.globalNamespacerps",
-	FrontendHistoryMgrNumConns:            "frontend.historyMgrNumConns",
-	FrontendShutdownDrainDuration:         "frontend.shutdownDrainDuration",
-	DisableListVisibilityByFilter:         "frontend.disableListVisibilityByFilter",
-	FrontendThrottledLogRPS:               "frontend.throttledLogRPS",
-	EnableClientVersionCheck:              "frontend.enableClientVersionCheck",
-	ValidSearchAttributes:                 "frontend.validSearchAttributes",
-	SendRawWorkflowHistory:                "frontend.sendRawWorkflowHistory",
-	SearchAttributesNumberOfKeysLimit:     "frontend.searchAttributesNumberOfKeysLimit",
-	SearchAttributesSizeOfValueLimit:      "frontend.searchAttributesSizeOfValueLimit",
-	SearchAttributesTotalSizeLimit:        "frontend.searchAttributesTotalSizeLimit",
-	VisibilityArchivalQueryMaxPageSize:    "frontend.visibilityArchivalQueryMaxPageSize",
-	VisibilityArchivalQueryMaxRangeInDays: "frontend.visibilityArchivalQueryMaxRangeInDays",
-	VisibilityArchivalQueryMaxQPS:         "frontend.visibilityArchivalQueryMaxQPS",
+	FrontendPersistenceMaxQPS:                    "frontend.persistenceMaxQPS",
+	FrontendPersistenceGlobalMaxQPS:              "frontend.persistenceGlobalMaxQPS",
+	FrontendVisibilityMaxPageSize:                "frontend.visibilityMaxPageSize",
+	FrontendVisibilityListMaxQPS:                 "frontend.visibilityListMaxQPS",
+	FrontendESVisibilityListMaxQPS:               "frontend.esVisibilityListMaxQPS",
+	FrontendMaxBadBinaries:                       "frontend.maxBadBinaries",
+	FrontendESIndexMaxResultWindow:               "frontend.esIndexMaxResultWindow",
+	FrontendHistoryMaxPageSize:                   "frontend.historyMaxPageSize",
+	FrontendRPS:                                  "frontend.rps",
+	FrontendMaxNamespaceRPSPerInstance:           "frontend.namespacerps",
+	FrontendGlobalNamespaceRPS:                   "frontend.globalNamespacerps",
+	FrontendMaxNamespaceWorkerRPSPerInstance:     "frontend.namespaceWorkerRPS",
+	FrontendMaxNamespaceUserRPSPerInstance:       "frontend.namespaceUserRPS",
+	FrontendMaxNamespaceVisibilityRPSPerInstance: "frontend.namespaceVisibilityRPS",
+	FrontendMaxNamespaceAdminRPSPerInstance:      "frontend.namespaceAdminRPS",
+	FrontendHistoryMgrNumConns:                   "frontend.historyMgrNumConns",
+	FrontendShutdownDrainDuration:                "frontend.shutdownDrainDuration",
+	DisableListVisibilityByFilter:                "frontend.disableListVisibilityByFilter",
+	FrontendThrottledLogRPS:                      "frontend.throttledLogRPS",
+	EnableClientVersionCheck:                     "frontend.enableClientVersionCheck",
+	ValidSearchAttributes:                        "frontend.validSearchAttributes",
+	SendRawWorkflowHistory:                       "frontend.sendRawWorkflowHistory",
+	NamespaceGracefulFailoverTimeout:             "frontend.namespaceGracefulFailoverTimeout",
+	NamespaceHandoverCheckInterval:               "frontend.namespaceHandoverCheckInterval",
+	SearchAttributesNumberOfKeysLimit:            "frontend.searchAttributesNumberOfKeysLimit",
+	SearchAttributesSizeOfValueLimit:             "frontend.searchAttributesSizeOfValueLimit",
+	SearchAttributesTotalSizeLimit:               "frontend.searchAttributesTotalSizeLimit",
+	VisibilityArchivalQueryMaxPageSize:           "frontend.visibilityArchivalQueryMaxPageSize",
+	VisibilityArchivalQueryMaxRangeInDays:        "frontend.visibilityArchivalQueryMaxRangeInDays",
+	VisibilityArchivalQueryMaxQPS:                "frontend.visibilityArchivalQueryMaxQPS",
 
 	// matching settings
 	MatchingRPS:                             "matching.rps",
@@ -154,6 +176,11 @@ var keys = map[Key]string{
 	EventsCacheTTL:                                         "history.eventsCacheTTL",
 	AcquireShardInterval:                                   "history.acquireShardInterval",
 	AcquireShardConcurrency:                                "history.acquireShardConcurrency",
+	EnableShardSplit:                                       "history.enableShardSplit",
+	ShardSplitPageSize:                                     "history.shardSplitPageSize",
+	EnableShardRebalance:                                   "history.enableShardRebalance",
+	ShardRebalanceInterval:                                 "history.shardRebalanceInterval",
+	ShardRebalanceLoadThreshold:                            "history.shardRebalanceLoadThreshold",
 	StandbyClusterDelay:                                    "history.standbyClusterDelay",
 	StandbyTaskMissingEventsResendDelay:                    "history.standbyTaskMissingEventsResendDelay",
 	StandbyTaskMissingEventsDiscardDelay:                   "history.standbyTaskMissingEventsDiscardDelay",
@@ -165,6 +192,7 @@ var keys = map[Key]string{
 	TimerTaskBatchSize:                                     "history.timerTaskBatchSize",
 	TimerTaskWorkerCount:                                   "history.timerTaskWorkerCount",
 	TimerTaskMaxRetryCount:                                 "history.timerTaskMaxRetryCount",
+	TimerTaskMaxAttemptsBeforeDLQ:                          "history.timerTaskMaxAttemptsBeforeDLQ",
 	TimerProcessorGetFailureRetryCount:                     "history.timerProcessorGetFailureRetryCount",
 	TimerProcessorCompleteTimerFailureRetryCount:           "history.timerProcessorCompleteTimerFailureRetryCount",
 	TimerProcessorUpdateShardTaskCount:                     "history.timerProcessorUpdateShardTaskCount",
@@ -187,6 +215,7 @@ var keys = map[Key]string{
 	TransferProcessorMaxPollRPS:                            "history.transferProcessorMaxPollRPS",
 	TransferTaskWorkerCount:                                "history.transferTaskWorkerCount",
 	TransferTaskMaxRetryCount:                              "history.transferTaskMaxRetryCount",
+	TransferTaskMaxAttemptsBeforeDLQ:                       "history.transferTaskMaxAttemptsBeforeDLQ",
 	TransferProcessorCompleteTransferFailureRetryCount:     "history.transferProcessorCompleteTransferFailureRetryCount",
 	TransferProcessorUpdateShardTaskCount:                  "history.transferProcessorUpdateShardTaskCount",
 	TransferProcessorMaxPollInterval:                       "history.transferProcessorMaxPollInterval",
@@ -220,6 +249,7 @@ var keys = map[Key]string{
 	ShardSyncMinInterval:                                   "history.shardSyncMinInterval",
 	ShardSyncTimerJitterCoefficient:                        "history.shardSyncMinInterval",
 	DefaultEventEncoding:                                   "history.defaultEventEncoding",
+	HistoryEventBlobEncoding:                               "history.historyEventBlobEncoding",
 	EnableAdminProtection:                                  "history.enableAdminProtection",
 	AdminOperationToken:                                    "history.adminOperationToken",
 	EnableParentClosePolicy:                                "history.enableParentClosePolicy",
@@ -340,6 +370,23 @@ const (
 	DisallowQuery
 	// EnablePriorityTaskProcessor is the key for enabling priority task processor
 	EnablePriorityTaskProcessor
+	// ClusterMetadataRefreshInterval is the interval at which remote clusters are reloaded from persistence
+	ClusterMetadataRefreshInterval
+	// HistoryShardLayoutRefreshInterval is the interval at which the split state of history shards is reloaded
+	// from persistence while the number of history shards is being increased
+	HistoryShardLayoutRefreshInterval
+	// HistoryShardOwnershipRefreshInterval is the interval at which the history shard owner overrides are reloaded from persistence
+	HistoryShardOwnershipRefreshInterval
+	// MetricsTagAllowlist maps the namespace, tasklist and workflowType metrics tags to the operations
+	// which emit their values, other operations emit a constant value to bound the metrics cardinality.
+	// "*" matches all operations and tags which are not in the map are emitted by all operations.
+	MetricsTagAllowlist
+	// PersistenceTargetLatency is the average persistence latency above which the persistence rate limits
+	// are decreased, zero disables the latency based adaptation of the limits
+	PersistenceTargetLatency
+	// PersistenceMaxErrorRatio is the ratio of failed persistence calls above which the persistence rate limits
+	// are decreased, zero disables the error based adaptation of the limits
+	PersistenceMaxErrorRatio
 
 	// BlobSizeLimitError is the per event blob size limit
 	BlobSizeLimitError
@@ -353,6 +400,22 @@ const (
 	HistoryCountLimitError
 	// HistoryCountLimitWarn is the per workflow execution history event count limit for warning
 	HistoryCountLimitWarn
+	// PayloadOffloadSizeThreshold is the payload size above which payloads in history events are offloaded
+	// to the history archival URI of the namespace, 0 disables offloading
+	PayloadOffloadSizeThreshold
+	// PayloadOffloadTimeout is the timeout of writing the offloaded payloads of one history batch
+	PayloadOffloadTimeout
+
+	// CompletionCallbackAllowedHosts is the comma separated list of hosts which workflow completion callbacks
+	// can be sent to, an entry starting with "*." matches all subdomains. Callbacks are rejected when empty
+	CompletionCallbackAllowedHosts
+	// FrontendMaxCompletionCallbacks is the max number of completion callback URLs a workflow can be started with
+	FrontendMaxCompletionCallbacks
+	// CompletionCallbackRequestTimeout is the timeout of a single workflow completion callback HTTP request
+	CompletionCallbackRequestTimeout
+	// CompletionCallbackMaxAttempts is the max number of delivery attempts of a workflow completion callback
+	// before it is moved to the dead letter state
+	CompletionCallbackMaxAttempts
 
 	// MaxIDLengthLimit is the length limit for various IDs, including: Namespace, TaskList, WorkflowID, ActivityID, TimerID,
 	// WorkflowType, ActivityType, SignalName, MarkerName, ErrorReason/FailureReason/CancelCause, Identity, RequestID
@@ -380,6 +443,14 @@ const (
 	FrontendMaxNamespaceRPSPerInstance
 	// FrontendGlobalNamespaceRPS is workflow namespace rate limit per second for the whole cluster
 	FrontendGlobalNamespaceRPS
+	// FrontendMaxNamespaceWorkerRPSPerInstance is namespace rate limit per second of the worker poll and respond APIs
+	FrontendMaxNamespaceWorkerRPSPerInstance
+	// FrontendMaxNamespaceUserRPSPerInstance is namespace rate limit per second of the APIs starting and updating workflows
+	FrontendMaxNamespaceUserRPSPerInstance
+	// FrontendMaxNamespaceVisibilityRPSPerInstance is namespace rate limit per second of the list and history read APIs
+	FrontendMaxNamespaceVisibilityRPSPerInstance
+	// FrontendMaxNamespaceAdminRPSPerInstance is namespace rate limit per second of the namespace and admin APIs
+	FrontendMaxNamespaceAdminRPSPerInstance
 	// FrontendHistoryMgrNumConns is for persistence cluster.NumConns
 	FrontendHistoryMgrNumConns
 	// FrontendThrottledLogRPS is the rate limit on number of log messages emitted per second for throttled logger
@@ -395,6 +466,10 @@ const (
 	ValidSearchAttributes
 	// SendRawWorkflowHistory is whether to enable raw history retrieving
 	SendRawWorkflowHistory
+	// NamespaceGracefulFailoverTimeout is how long a namespace may stay in handover during a graceful failover, zero fails over immediately
+	NamespaceGracefulFailoverTimeout
+	// NamespaceHandoverCheckInterval is the interval at which namespaces in handover are checked for completion
+	NamespaceHandoverCheckInterval
 	// SearchAttributesNumberOfKeysLimit is the limit of number of keys
 	SearchAttributesNumberOfKeysLimit
 	// SearchAttributesSizeOfValueLimit is the size limit of each value
@@ -485,6 +560,16 @@ const (
 	AcquireShardInterval
 	// AcquireShardConcurrency is number of goroutines that can be used to acquire shards in the shard controller.
 	AcquireShardConcurrency
+	// EnableShardSplit is whether owned history shards are split when a target number of history shards is configured
+	EnableShardSplit
+	// ShardSplitPageSize is the page size used to scan the executions and history events of a history shard being split
+	ShardSplitPageSize
+	// EnableShardRebalance is whether shards are automatically moved from the most loaded to the least loaded history host
+	EnableShardRebalance
+	// ShardRebalanceInterval is the interval between two rounds of the shard rebalancer
+	ShardRebalanceInterval
+	// ShardRebalanceLoadThreshold is the ratio by which the load of a history host must exceed the average load to be rebalanced
+	ShardRebalanceLoadThreshold
 	// StandbyClusterDelay is the artificial delay added to standby cluster's view of active cluster's time
 	StandbyClusterDelay
 	// StandbyTaskMissingEventsResendDelay is the amount of time standby cluster's will wait (if events are missing)
@@ -509,6 +594,8 @@ const (
 	TimerTaskWorkerCount
 	// TimerTaskMaxRetryCount is max retry count for timer processor
 	TimerTaskMaxRetryCount
+	// TimerTaskMaxAttemptsBeforeDLQ is the number of failed attempts after which a timer task is moved to DLQ, 0 means never
+	TimerTaskMaxAttemptsBeforeDLQ
 	// TimerProcessorGetFailureRetryCount is retry count for timer processor get failure operation
 	TimerProcessorGetFailureRetryCount
 	// TimerProcessorCompleteTimerFailureRetryCount is retry count for timer processor complete timer operation
@@ -553,6 +640,8 @@ const (
 	TransferTaskWorkerCount
 	// TransferTaskMaxRetryCount is max times of retry for transferQueueProcessor
 	TransferTaskMaxRetryCount
+	// TransferTaskMaxAttemptsBeforeDLQ is the number of failed attempts after which a transfer task is moved to DLQ, 0 means never
+	TransferTaskMaxAttemptsBeforeDLQ
 	// TransferProcessorCompleteTransferFailureRetryCount is times of retry for failure
 	TransferProcessorCompleteTransferFailureRetryCount
 	// TransferProcessorUpdateShardTaskCount is update shard count for transferQueueProcessor
@@ -619,6 +708,9 @@ const (
 	ShardSyncTimerJitterCoefficient
 	// DefaultEventEncoding is the encoding type for history events
 	DefaultEventEncoding
+	// HistoryEventBlobEncoding is the encoding type for history event batches appended to history nodes,
+	// which can be a compressed encoding like proto3-snappy or proto3-zstd. DefaultEventEncoding is used when unset
+	HistoryEventBlobEncoding
 	// NumArchiveSystemWorkflows is key for number of archive system workflows running in total
 	NumArchiveSystemWorkflows
 	// ArchiveRequestRPS is the rate limit on the number of archive request per second
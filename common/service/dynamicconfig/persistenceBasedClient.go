@@ -0,0 +1,285 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicconfig
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+)
+
+var _ Client = (*persistenceBasedClient)(nil)
+
+// PersistenceBasedClientConfig is the config for the persistence based dynamic config client.
+// It specifies how often values are reloaded from the database.
+type PersistenceBasedClientConfig struct {
+	PollInterval time.Duration `yaml:"pollInterval"`
+}
+
+// ValueStore is the storage used by the persistence based client. Values of a key
+// are the YAML encoded list of constrained values, in the same format as a single
+// key of the file based client config.
+type ValueStore interface {
+	ListValues() (map[string]string, error)
+	UpdateValues(name string, values string) error
+}
+
+type persistenceBasedClient struct {
+	stored   *fileBasedClient
+	fallback Client
+	store    ValueStore
+	config   *PersistenceBasedClientConfig
+	doneCh   chan struct{}
+	logger   log.Logger
+
+	// updateLock serializes the reloads of the poller and of UpdateValue, so that an older
+	// list of values never replaces a newer one
+	updateLock sync.Mutex
+	lastValue  map[string]string
+}
+
+// NewPersistenceBasedClient creates a client which serves values stored in the database
+// and falls back to the given client for keys which are not stored.
+func NewPersistenceBasedClient(
+	config *PersistenceBasedClientConfig,
+	store ValueStore,
+	fallback Client,
+	logger log.Logger,
+	doneCh chan struct{},
+) (Client, error) {
+	if config == nil {
+		return nil, errors.New("no config found for persistence based dynamic config client")
+	}
+	if config.PollInterval < minPollInterval {
+		return nil, fmt.Errorf("poll interval should be at least %v", minPollInterval)
+	}
+	if fallback == nil {
+		fallback = NewNopClient()
+	}
+
+	client := &persistenceBasedClient{
+		stored:   &fileBasedClient{logger: logger},
+		fallback: fallback,
+		store:    store,
+		config:   config,
+		doneCh:   doneCh,
+		logger:   logger,
+	}
+	client.stored.values.Store(make(map[string][]*constrainedValue))
+	if err := client.update(); err != nil {
+		return nil, err
+	}
+	go func() {
+		ticker := time.NewTicker(client.config.PollInterval)
+		for {
+			select {
+			case <-ticker.C:
+				err := client.update()
+				if err != nil {
+					client.logger.Error("Failed to update dynamic config from persistence", tag.Error(err))
+				}
+			case <-client.doneCh:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+	return client, nil
+}
+
+func (pc *persistenceBasedClient) GetValue(name Key, defaultValue interface{}) (interface{}, error) {
+	if val, err := pc.stored.GetValue(name, defaultValue); err == nil {
+		return val, nil
+	}
+	return pc.fallback.GetValue(name, defaultValue)
+}
+
+func (pc *persistenceBasedClient) GetValueWithFilters(name Key, filters map[Filter]interface{}, defaultValue interface{}) (interface{}, error) {
+	if val, err := pc.stored.GetValueWithFilters(name, filters, defaultValue); err == nil {
+		return val, nil
+	}
+	return pc.fallback.GetValueWithFilters(name, filters, defaultValue)
+}
+
+func (pc *persistenceBasedClient) GetIntValue(name Key, filters map[Filter]interface{}, defaultValue int) (int, error) {
+	if val, err := pc.stored.GetIntValue(name, filters, defaultValue); err == nil {
+		return val, nil
+	}
+	return pc.fallback.GetIntValue(name, filters, defaultValue)
+}
+
+func (pc *persistenceBasedClient) GetFloatValue(name Key, filters map[Filter]interface{}, defaultValue float64) (float64, error) {
+	if val, err := pc.stored.GetFloatValue(name, filters, defaultValue); err == nil {
+		return val, nil
+	}
+	return pc.fallback.GetFloatValue(name, filters, defaultValue)
+}
+
+func (pc *persistenceBasedClient) GetBoolValue(name Key, filters map[Filter]interface{}, defaultValue bool) (bool, error) {
+	if val, err := pc.stored.GetBoolValue(name, filters, defaultValue); err == nil {
+		return val, nil
+	}
+	return pc.fallback.GetBoolValue(name, filters, defaultValue)
+}
+
+func (pc *persistenceBasedClient) GetStringValue(name Key, filters map[Filter]interface{}, defaultValue string) (string, error) {
+	if val, err := pc.stored.GetStringValue(name, filters, defaultValue); err == nil {
+		return val, nil
+	}
+	return pc.fallback.GetStringValue(name, filters, defaultValue)
+}
+
+func (pc *persistenceBasedClient) GetMapValue(
+	name Key, filters map[Filter]interface{}, defaultValue map[string]interface{},
+) (map[string]interface{}, error) {
+	if val, err := pc.stored.GetMapValue(name, filters, defaultValue); err == nil {
+		return val, nil
+	}
+	return pc.fallback.GetMapValue(name, filters, defaultValue)
+}
+
+func (pc *persistenceBasedClient) GetDurationValue(
+	name Key, filters map[Filter]interface{}, defaultValue time.Duration,
+) (time.Duration, error) {
+	if val, err := pc.stored.GetDurationValue(name, filters, defaultValue); err == nil {
+		return val, nil
+	}
+	return pc.fallback.GetDurationValue(name, filters, defaultValue)
+}
+
+func (pc *persistenceBasedClient) UpdateValue(name Key, value interface{}) error {
+	keyName, ok := keys[name]
+	if !ok {
+		return fmt.Errorf("unknown dynamic config key %v", name)
+	}
+
+	values, err := yaml.Marshal([]*constrainedValue{{Value: value}})
+	if err != nil {
+		return fmt.Errorf("failed to encode dynamic config %v", err)
+	}
+
+	if err := pc.store.UpdateValues(keyName, string(values)); err != nil {
+		return err
+	}
+	return pc.update()
+}
+
+func (pc *persistenceBasedClient) update() error {
+	pc.updateLock.Lock()
+	defer pc.updateLock.Unlock()
+
+	current, err := pc.store.ListValues()
+	if err != nil {
+		return fmt.Errorf("failed to list dynamic config values: %v", err)
+	}
+	if equalValues(current, pc.lastValue) {
+		return nil
+	}
+
+	newValues := make(map[string][]*constrainedValue, len(current))
+	for name, values := range current {
+		decoded, err := decodeValues(values)
+		if err != nil {
+			// A bad value for one key should not block updates of all other keys
+			pc.logger.Error("Failed to decode dynamic config value", tag.Key(name), tag.Error(err))
+			continue
+		}
+		newValues[name] = decoded
+	}
+
+	if err := pc.stored.storeValues(newValues); err != nil {
+		return err
+	}
+	pc.lastValue = current
+	return nil
+}
+
+// ValidateValues checks that values for the given key can be served by the
+// persistence based client.
+func ValidateValues(name string, values string) error {
+	if !isKnownKeyName(name) {
+		return fmt.Errorf("unknown dynamic config key %v", name)
+	}
+
+	decoded, err := decodeValues(values)
+	if err != nil {
+		return err
+	}
+	for _, cv := range decoded {
+		for constraint := range cv.Constraints {
+			if !isKnownFilterName(constraint) {
+				return fmt.Errorf("unknown dynamic config constraint %v", constraint)
+			}
+		}
+		if _, err := convertKeyTypeToString(cv.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeValues(values string) ([]*constrainedValue, error) {
+	var decoded []*constrainedValue
+	if err := yaml.Unmarshal([]byte(values), &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode dynamic config %v", err)
+	}
+	return decoded, nil
+}
+
+func equalValues(a, b map[string]string) bool {
+	if a == nil || b == nil || len(a) != len(b) {
+		return false
+	}
+	for name, values := range a {
+		if otherValues, ok := b[name]; !ok || otherValues != values {
+			return false
+		}
+	}
+	return true
+}
+
+func isKnownKeyName(name string) bool {
+	for key, keyName := range keys {
+		if key != unknownKey && keyName == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isKnownFilterName(name string) bool {
+	for filter, filterName := range filters {
+		if Filter(filter) != unknownFilter && filterName == name {
+			return true
+		}
+	}
+	return false
+}
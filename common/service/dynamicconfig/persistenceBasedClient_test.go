@@ -0,0 +1,171 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicconfig
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/temporalio/temporal/common/log"
+)
+
+type (
+	persistenceBasedClientSuite struct {
+		suite.Suite
+		*require.Assertions
+		store    *memValueStore
+		fallback Client
+		client   Client
+		doneCh   chan struct{}
+	}
+
+	memValueStore struct {
+		sync.Mutex
+		values map[string]string
+	}
+)
+
+func TestPersistenceBasedClientSuite(t *testing.T) {
+	s := new(persistenceBasedClientSuite)
+	suite.Run(t, s)
+}
+
+func (s *persistenceBasedClientSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	var err error
+	s.doneCh = make(chan struct{})
+	s.fallback, err = NewFileBasedClient(&FileBasedClientConfig{
+		Filepath:     "config/testConfig.yaml",
+		PollInterval: time.Second * 5,
+	}, log.NewNoop(), s.doneCh)
+	s.NoError(err)
+
+	s.store = &memValueStore{values: map[string]string{
+		"testGetIntPropertyKey": `
+- value: 2000
+- value: 3000
+  constraints:
+    namespace: samples-namespace
+`,
+	}}
+	s.client, err = NewPersistenceBasedClient(&PersistenceBasedClientConfig{
+		PollInterval: time.Second * 5,
+	}, s.store, s.fallback, log.NewNoop(), s.doneCh)
+	s.NoError(err)
+}
+
+func (s *persistenceBasedClientSuite) TearDownTest() {
+	close(s.doneCh)
+}
+
+func (s *persistenceBasedClientSuite) TestGetValue_Stored() {
+	v, err := s.client.GetIntValue(testGetIntPropertyKey, nil, 0)
+	s.NoError(err)
+	s.Equal(2000, v)
+
+	v, err = s.client.GetIntValue(testGetIntPropertyKey, map[Filter]interface{}{Namespace: "samples-namespace"}, 0)
+	s.NoError(err)
+	s.Equal(3000, v)
+}
+
+func (s *persistenceBasedClientSuite) TestGetValue_Fallback() {
+	expected, err := s.fallback.GetBoolValue(testGetBoolPropertyKey, nil, false)
+	s.NoError(err)
+
+	v, err := s.client.GetBoolValue(testGetBoolPropertyKey, nil, false)
+	s.NoError(err)
+	s.Equal(expected, v)
+}
+
+func (s *persistenceBasedClientSuite) TestUpdateValue() {
+	err := s.client.UpdateValue(testGetIntPropertyKey, 4000)
+	s.NoError(err)
+
+	v, err := s.client.GetIntValue(testGetIntPropertyKey, nil, 0)
+	s.NoError(err)
+	s.Equal(4000, v)
+}
+
+func (s *persistenceBasedClientSuite) TestUpdate_SkipsBadValue() {
+	s.store.values["testGetStringPropertyKey"] = "- value: [unterminated"
+	s.NoError(s.client.(*persistenceBasedClient).update())
+
+	v, err := s.client.GetIntValue(testGetIntPropertyKey, nil, 0)
+	s.NoError(err)
+	s.Equal(2000, v)
+}
+
+func (s *persistenceBasedClientSuite) TestUpdateValue_Concurrent() {
+	client := s.client.(*persistenceBasedClient)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(value int) {
+			defer wg.Done()
+			s.NoError(client.UpdateValue(testGetIntPropertyKey, value))
+		}(i)
+		go func() {
+			defer wg.Done()
+			s.NoError(client.update())
+		}()
+	}
+	wg.Wait()
+
+	s.NoError(client.UpdateValue(testGetIntPropertyKey, 4000))
+	v, err := s.client.GetIntValue(testGetIntPropertyKey, nil, 0)
+	s.NoError(err)
+	s.Equal(4000, v)
+}
+
+func (s *persistenceBasedClientSuite) TestValidateValues() {
+	s.NoError(ValidateValues("testGetIntPropertyKey", "- value: 1\n  constraints:\n    taskListName: tl\n"))
+	s.Error(ValidateValues("noSuchKey", "- value: 1\n"))
+	s.Error(ValidateValues("testGetIntPropertyKey", "- value: 1\n  constraints:\n    noSuchFilter: x\n"))
+	s.Error(ValidateValues("testGetIntPropertyKey", "value: 1"))
+}
+
+func (m *memValueStore) ListValues() (map[string]string, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	values := make(map[string]string, len(m.values))
+	for name, v := range m.values {
+		values[name] = v
+	}
+	return values, nil
+}
+
+func (m *memValueStore) UpdateValues(name string, values string) error {
+	m.Lock()
+	defer m.Unlock()
+
+	m.values[name] = values
+	return nil
+}
@@ -0,0 +1,164 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicconfig
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var _ Client = (*staticClient)(nil)
+
+// staticClient is a dynamic config client with in memory values which don't depend on filters,
+// it is used by servers which have no dynamic config file.
+type staticClient struct {
+	sync.RWMutex
+	values map[Key]interface{}
+}
+
+// NewStaticClient creates a client which returns the given values regardless of filters
+func NewStaticClient(values map[Key]interface{}) Client {
+	client := &staticClient{
+		values: make(map[Key]interface{}, len(values)),
+	}
+	for name, value := range values {
+		client.values[name] = value
+	}
+	return client
+}
+
+func (sc *staticClient) GetValue(name Key, defaultValue interface{}) (interface{}, error) {
+	return sc.getValue(name, defaultValue)
+}
+
+func (sc *staticClient) GetValueWithFilters(
+	name Key, filters map[Filter]interface{}, defaultValue interface{},
+) (interface{}, error) {
+	return sc.getValue(name, defaultValue)
+}
+
+func (sc *staticClient) GetIntValue(name Key, filters map[Filter]interface{}, defaultValue int) (int, error) {
+	val, err := sc.getValue(name, defaultValue)
+	if err != nil {
+		return defaultValue, err
+	}
+
+	if intVal, ok := val.(int); ok {
+		return intVal, nil
+	}
+	return defaultValue, errors.New("value type is not int")
+}
+
+func (sc *staticClient) GetFloatValue(name Key, filters map[Filter]interface{}, defaultValue float64) (float64, error) {
+	val, err := sc.getValue(name, defaultValue)
+	if err != nil {
+		return defaultValue, err
+	}
+
+	if floatVal, ok := val.(float64); ok {
+		return floatVal, nil
+	} else if intVal, ok := val.(int); ok {
+		return float64(intVal), nil
+	}
+	return defaultValue, errors.New("value type is not float64")
+}
+
+func (sc *staticClient) GetBoolValue(name Key, filters map[Filter]interface{}, defaultValue bool) (bool, error) {
+	val, err := sc.getValue(name, defaultValue)
+	if err != nil {
+		return defaultValue, err
+	}
+
+	if boolVal, ok := val.(bool); ok {
+		return boolVal, nil
+	}
+	return defaultValue, errors.New("value type is not bool")
+}
+
+func (sc *staticClient) GetStringValue(name Key, filters map[Filter]interface{}, defaultValue string) (string, error) {
+	val, err := sc.getValue(name, defaultValue)
+	if err != nil {
+		return defaultValue, err
+	}
+
+	if stringVal, ok := val.(string); ok {
+		return stringVal, nil
+	}
+	return defaultValue, errors.New("value type is not string")
+}
+
+func (sc *staticClient) GetMapValue(
+	name Key, filters map[Filter]interface{}, defaultValue map[string]interface{},
+) (map[string]interface{}, error) {
+	val, err := sc.getValue(name, defaultValue)
+	if err != nil {
+		return defaultValue, err
+	}
+	if mapVal, ok := val.(map[string]interface{}); ok {
+		return mapVal, nil
+	}
+	return defaultValue, errors.New("value type is not map")
+}
+
+func (sc *staticClient) GetDurationValue(
+	name Key, filters map[Filter]interface{}, defaultValue time.Duration,
+) (time.Duration, error) {
+	val, err := sc.getValue(name, defaultValue)
+	if err != nil {
+		return defaultValue, err
+	}
+
+	switch v := val.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		durationVal, err := time.ParseDuration(v)
+		if err != nil {
+			return defaultValue, errors.New("value string encoding cannot be parsed into duration")
+		}
+		return durationVal, nil
+	default:
+		return defaultValue, errors.New("value type is not duration")
+	}
+}
+
+func (sc *staticClient) UpdateValue(name Key, value interface{}) error {
+	sc.Lock()
+	defer sc.Unlock()
+
+	sc.values[name] = value
+	return nil
+}
+
+func (sc *staticClient) getValue(name Key, defaultValue interface{}) (interface{}, error) {
+	sc.RLock()
+	defer sc.RUnlock()
+
+	if val, ok := sc.values[name]; ok {
+		return val, nil
+	}
+	return defaultValue, errors.New("unable to find key")
+}
@@ -0,0 +1,88 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamicconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type staticClientSuite struct {
+	suite.Suite
+	*require.Assertions
+	client Client
+}
+
+func TestStaticClientSuite(t *testing.T) {
+	s := new(staticClientSuite)
+	suite.Run(t, s)
+}
+
+func (s *staticClientSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.client = NewStaticClient(map[Key]interface{}{
+		testGetIntPropertyKey:      10,
+		testGetFloat64PropertyKey:  12,
+		testGetBoolPropertyKey:     false,
+		testGetDurationPropertyKey: "1m",
+	})
+}
+
+func (s *staticClientSuite) TestGetValue() {
+	v, err := s.client.GetValueWithFilters(testGetBoolPropertyKey, map[Filter]interface{}{Namespace: "samples-namespace"}, true)
+	s.NoError(err)
+	s.Equal(false, v)
+
+	v, err = s.client.GetValue(testGetPropertyKey, "default")
+	s.Error(err)
+	s.Equal("default", v)
+}
+
+func (s *staticClientSuite) TestGetTypedValue() {
+	intValue, err := s.client.GetIntValue(testGetIntPropertyKey, nil, 1)
+	s.NoError(err)
+	s.Equal(10, intValue)
+
+	floatValue, err := s.client.GetFloatValue(testGetFloat64PropertyKey, nil, 1)
+	s.NoError(err)
+	s.Equal(float64(12), floatValue)
+
+	durationValue, err := s.client.GetDurationValue(testGetDurationPropertyKey, nil, time.Second)
+	s.NoError(err)
+	s.Equal(time.Minute, durationValue)
+
+	_, err = s.client.GetStringValue(testGetIntPropertyKey, nil, "default")
+	s.Error(err)
+}
+
+func (s *staticClientSuite) TestUpdateValue() {
+	s.NoError(s.client.UpdateValue(testGetBoolPropertyKey, true))
+	v, err := s.client.GetBoolValue(testGetBoolPropertyKey, nil, false)
+	s.NoError(err)
+	s.True(v)
+}
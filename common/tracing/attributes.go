@@ -0,0 +1,69 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"strconv"
+)
+
+// Keys of the attributes attached to spans
+const (
+	NamespaceKey   = "namespace"
+	NamespaceIDKey = "namespace-id"
+	WorkflowIDKey  = "workflow-id"
+	RunIDKey       = "run-id"
+	ShardIDKey     = "shard-id"
+	TaskTypeKey    = "task-type"
+)
+
+// Namespace returns the attribute for a namespace name
+func Namespace(namespace string) Attribute {
+	return Attribute{Key: NamespaceKey, Value: namespace}
+}
+
+// NamespaceID returns the attribute for a namespace ID
+func NamespaceID(namespaceID string) Attribute {
+	return Attribute{Key: NamespaceIDKey, Value: namespaceID}
+}
+
+// WorkflowID returns the attribute for a workflow ID
+func WorkflowID(workflowID string) Attribute {
+	return Attribute{Key: WorkflowIDKey, Value: workflowID}
+}
+
+// RunID returns the attribute for a workflow run ID
+func RunID(runID string) Attribute {
+	return Attribute{Key: RunIDKey, Value: runID}
+}
+
+// ShardID returns the attribute for a history shard ID
+func ShardID(shardID int) Attribute {
+	return Attribute{Key: ShardIDKey, Value: strconv.Itoa(shardID)}
+}
+
+// TaskType returns the attribute for a queue task type
+func TaskType(taskType string) Attribute {
+	return Attribute{Key: TaskTypeKey, Value: taskType}
+}
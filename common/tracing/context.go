@@ -0,0 +1,116 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/temporalio/temporal/common/headers"
+)
+
+const (
+	traceParentVersion = "00"
+	flagSampled        = "01"
+	flagNotSampled     = "00"
+)
+
+type spanContextKey struct{}
+
+// ContextWithSpan returns a copy of ctx carrying the span
+func ContextWithSpan(
+	ctx context.Context,
+	span Span,
+) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// SpanFromContext returns the span carried by ctx or nil
+func SpanFromContext(
+	ctx context.Context,
+) Span {
+	if span, ok := ctx.Value(spanContextKey{}).(Span); ok {
+		return span
+	}
+	return nil
+}
+
+// Inject writes the context of the span carried by ctx to the outgoing gRPC metadata,
+// replacing any span context already written there
+func Inject(
+	ctx context.Context,
+) context.Context {
+	span := SpanFromContext(ctx)
+	if span == nil || !span.SpanContext().IsValid() {
+		return ctx
+	}
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	md.Set(headers.TraceParentHeaderName, encodeTraceParent(span.SpanContext()))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// Extract reads the span context propagated by the caller from the incoming gRPC metadata.
+// The returned span context is not valid if the caller did not propagate one.
+func Extract(
+	ctx context.Context,
+) SpanContext {
+	return decodeTraceParent(headers.GetValues(ctx, headers.TraceParentHeaderName)[0])
+}
+
+func encodeTraceParent(
+	sc SpanContext,
+) string {
+	flags := flagNotSampled
+	if sc.Sampled {
+		flags = flagSampled
+	}
+	return fmt.Sprintf("%v-%v-%v-%v", traceParentVersion, sc.TraceID, sc.SpanID, flags)
+}
+
+func decodeTraceParent(
+	value string,
+) SpanContext {
+	parts := strings.Split(value, "-")
+	if len(parts) != 4 ||
+		parts[0] != traceParentVersion ||
+		len(parts[1]) != 2*traceIDLength ||
+		len(parts[2]) != 2*spanIDLength {
+		return SpanContext{}
+	}
+	return SpanContext{
+		TraceID: parts[1],
+		SpanID:  parts[2],
+		Sampled: parts[3] == flagSampled,
+	}
+}
@@ -0,0 +1,119 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+)
+
+type (
+	noopExporter struct{}
+
+	logExporter struct {
+		logger log.Logger
+	}
+
+	// InMemoryExporter keeps all exported spans in memory, it is meant to be used by tests
+	InMemoryExporter struct {
+		sync.Mutex
+		spans []*SpanData
+	}
+)
+
+var _ Exporter = (*noopExporter)(nil)
+var _ Exporter = (*logExporter)(nil)
+var _ Exporter = (*InMemoryExporter)(nil)
+
+// NewNoopExporter creates an exporter which drops all spans
+func NewNoopExporter() Exporter {
+	return &noopExporter{}
+}
+
+// NewLogExporter creates an exporter which writes every span to the logger
+func NewLogExporter(
+	logger log.Logger,
+) Exporter {
+	return &logExporter{logger: logger}
+}
+
+// NewInMemoryExporter creates an exporter which keeps spans in memory
+func NewInMemoryExporter() *InMemoryExporter {
+	return &InMemoryExporter{}
+}
+
+func (e *noopExporter) Export(
+	_ *SpanData,
+) {
+}
+
+func (e *logExporter) Export(
+	span *SpanData,
+) {
+	tags := []tag.Tag{
+		tag.SpanName(span.Name),
+		tag.TraceID(span.SpanContext.TraceID),
+		tag.SpanID(span.SpanContext.SpanID),
+		tag.ParentSpanID(span.ParentSpanID),
+		tag.Timestamp(span.StartTime),
+		tag.SpanDuration(span.EndTime.Sub(span.StartTime)),
+		tag.SpanAttributes(span.Attributes),
+	}
+	if span.Error != "" {
+		tags = append(tags, tag.Error(errors.New(span.Error)))
+	}
+	e.logger.Info("Span ended", tags...)
+}
+
+// Export keeps the span in memory
+func (e *InMemoryExporter) Export(
+	span *SpanData,
+) {
+	e.Lock()
+	defer e.Unlock()
+
+	e.spans = append(e.spans, span)
+}
+
+// Spans returns all spans exported so far, in the order they ended
+func (e *InMemoryExporter) Spans() []*SpanData {
+	e.Lock()
+	defer e.Unlock()
+
+	spans := make([]*SpanData, len(e.spans))
+	copy(spans, e.spans)
+	return spans
+}
+
+// Reset drops all spans exported so far
+func (e *InMemoryExporter) Reset() {
+	e.Lock()
+	defer e.Unlock()
+
+	e.spans = nil
+}
@@ -0,0 +1,135 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+
+	commonpb "go.temporal.io/temporal-proto/common"
+	"google.golang.org/grpc"
+)
+
+type (
+	namespaceGetter interface {
+		GetNamespace() string
+	}
+
+	namespaceIDGetter interface {
+		GetNamespaceId() string
+	}
+
+	workflowIDGetter interface {
+		GetWorkflowId() string
+	}
+
+	executionGetter interface {
+		GetExecution() *commonpb.WorkflowExecution
+	}
+
+	workflowExecutionGetter interface {
+		GetWorkflowExecution() *commonpb.WorkflowExecution
+	}
+
+	shardIDGetter interface {
+		GetShardId() int32
+	}
+)
+
+// NewServerInterceptor creates a gRPC server interceptor which starts a span for every call,
+// continuing the trace propagated by the caller
+func NewServerInterceptor(
+	tracer Tracer,
+) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+
+		ctx, span := tracer.Start(ctx, info.FullMethod)
+		if span.SpanContext().Sampled {
+			span.SetAttributes(requestAttributes(req)...)
+		}
+		resp, err := handler(ctx, req)
+		span.End(err)
+		return resp, err
+	}
+}
+
+// ClientInterceptor is a gRPC client interceptor which starts a span for calls made within
+// a span and propagates it to the server
+func ClientInterceptor(
+	ctx context.Context,
+	method string,
+	req interface{},
+	reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+
+	parent := SpanFromContext(ctx)
+	if parent == nil {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	ctx, span := parent.tracer().Start(ctx, method)
+	err := invoker(Inject(ctx), method, req, reply, cc, opts...)
+	span.End(err)
+	return err
+}
+
+func requestAttributes(
+	req interface{},
+) []Attribute {
+	var attributes []Attribute
+	if r, ok := req.(namespaceGetter); ok && r.GetNamespace() != "" {
+		attributes = append(attributes, Namespace(r.GetNamespace()))
+	}
+	if r, ok := req.(namespaceIDGetter); ok && r.GetNamespaceId() != "" {
+		attributes = append(attributes, NamespaceID(r.GetNamespaceId()))
+	}
+
+	var execution *commonpb.WorkflowExecution
+	if r, ok := req.(executionGetter); ok {
+		execution = r.GetExecution()
+	} else if r, ok := req.(workflowExecutionGetter); ok {
+		execution = r.GetWorkflowExecution()
+	}
+	if execution.GetWorkflowId() != "" {
+		attributes = append(attributes, WorkflowID(execution.GetWorkflowId()))
+	} else if r, ok := req.(workflowIDGetter); ok && r.GetWorkflowId() != "" {
+		attributes = append(attributes, WorkflowID(r.GetWorkflowId()))
+	}
+	if execution.GetRunId() != "" {
+		attributes = append(attributes, RunID(execution.GetRunId()))
+	}
+
+	if r, ok := req.(shardIDGetter); ok {
+		attributes = append(attributes, ShardID(int(r.GetShardId())))
+	}
+	return attributes
+}
@@ -0,0 +1,87 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// Attribute is a key value pair attached to a span
+	Attribute struct {
+		Key   string
+		Value string
+	}
+
+	// SpanContext identifies a span and the trace it belongs to.
+	// It is the part of a span which is propagated across process boundaries.
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+		Sampled bool
+	}
+
+	// SpanData is the recorded state of an ended span, as handed to an Exporter
+	SpanData struct {
+		Name         string
+		SpanContext  SpanContext
+		ParentSpanID string
+		StartTime    time.Time
+		EndTime      time.Time
+		Attributes   []Attribute
+		Error        string
+	}
+
+	// Span is a single timed operation within a trace
+	Span interface {
+		// SpanContext returns the identity of the span
+		SpanContext() SpanContext
+		// SetAttributes attaches attributes to the span, it has no effect once the span ended
+		SetAttributes(attributes ...Attribute)
+		// End completes the span, recording the error if not nil
+		End(err error)
+
+		tracer() Tracer
+	}
+
+	// Tracer creates spans
+	Tracer interface {
+		// Start creates a span which is a child of the span in ctx. If ctx has no span
+		// the span continues the trace propagated by the caller in incoming gRPC metadata,
+		// otherwise a new trace is started. The returned context carries the new span.
+		Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span)
+	}
+
+	// Exporter receives sampled spans once they end
+	Exporter interface {
+		Export(span *SpanData)
+	}
+)
+
+// IsValid returns true if the span context identifies a span
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != "" && sc.SpanID != ""
+}
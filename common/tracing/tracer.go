@@ -0,0 +1,232 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	traceIDLength = 16
+	spanIDLength  = 8
+)
+
+type (
+	tracerImpl struct {
+		exporter   Exporter
+		sampleRate float64
+	}
+
+	recordingSpan struct {
+		sync.Mutex
+		owner *tracerImpl
+		data  SpanData
+		ended bool
+	}
+
+	nonRecordingSpan struct {
+		owner       Tracer
+		spanContext SpanContext
+	}
+
+	noopTracer struct{}
+)
+
+var _ Tracer = (*tracerImpl)(nil)
+var _ Tracer = (*noopTracer)(nil)
+var _ Span = (*recordingSpan)(nil)
+var _ Span = (*nonRecordingSpan)(nil)
+
+// NewTracer creates a tracer which hands sampled spans to the given exporter.
+// The sample rate is the fraction of new traces which are recorded, the sampling
+// decision of a trace is made once at its root and followed by all its spans.
+func NewTracer(
+	exporter Exporter,
+	sampleRate float64,
+) Tracer {
+	return &tracerImpl{
+		exporter:   exporter,
+		sampleRate: math.Max(0, math.Min(1, sampleRate)),
+	}
+}
+
+// NewNoopTracer creates a tracer which records nothing
+func NewNoopTracer() Tracer {
+	return &noopTracer{}
+}
+
+// StartChildSpan starts a span under the given parent for calls which do not carry
+// a context. Nothing is recorded without a parent, the span would otherwise start
+// a trace of its own which is not connected to the operation it was made for.
+func StartChildSpan(
+	tracer Tracer,
+	parent Span,
+	name string,
+	attributes ...Attribute,
+) Span {
+	if parent == nil {
+		return &nonRecordingSpan{owner: tracer}
+	}
+	_, span := tracer.Start(ContextWithSpan(context.Background(), parent), name, attributes...)
+	return span
+}
+
+func (t *tracerImpl) Start(
+	ctx context.Context,
+	name string,
+	attributes ...Attribute,
+) (context.Context, Span) {
+
+	var parent SpanContext
+	if span := SpanFromContext(ctx); span != nil {
+		parent = span.SpanContext()
+	} else {
+		parent = Extract(ctx)
+	}
+
+	spanContext := SpanContext{SpanID: newID(spanIDLength)}
+	if parent.IsValid() {
+		spanContext.TraceID = parent.TraceID
+		spanContext.Sampled = parent.Sampled
+	} else {
+		spanContext.TraceID = newID(traceIDLength)
+		spanContext.Sampled = t.shouldSample()
+	}
+
+	var span Span
+	if spanContext.Sampled {
+		span = &recordingSpan{
+			owner: t,
+			data: SpanData{
+				Name:         name,
+				SpanContext:  spanContext,
+				ParentSpanID: parent.SpanID,
+				StartTime:    time.Now(),
+				Attributes:   attributes,
+			},
+		}
+	} else {
+		span = &nonRecordingSpan{owner: t, spanContext: spanContext}
+	}
+	return ContextWithSpan(ctx, span), span
+}
+
+func (t *tracerImpl) shouldSample() bool {
+	if t.sampleRate >= 1 {
+		return true
+	}
+	if t.sampleRate <= 0 {
+		return false
+	}
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return false
+	}
+	var n uint64
+	for _, v := range b {
+		n = n<<8 | uint64(v)
+	}
+	return float64(n>>11)/float64(1<<53) < t.sampleRate
+}
+
+func (s *recordingSpan) SpanContext() SpanContext {
+	return s.data.SpanContext
+}
+
+func (s *recordingSpan) SetAttributes(
+	attributes ...Attribute,
+) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.ended {
+		return
+	}
+	s.data.Attributes = append(s.data.Attributes, attributes...)
+}
+
+func (s *recordingSpan) End(
+	err error,
+) {
+	s.Lock()
+	if s.ended {
+		s.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.EndTime = time.Now()
+	if err != nil {
+		s.data.Error = err.Error()
+	}
+	data := s.data
+	s.Unlock()
+
+	s.owner.exporter.Export(&data)
+}
+
+func (s *recordingSpan) tracer() Tracer {
+	return s.owner
+}
+
+func (s *nonRecordingSpan) SpanContext() SpanContext {
+	return s.spanContext
+}
+
+func (s *nonRecordingSpan) SetAttributes(
+	_ ...Attribute,
+) {
+}
+
+func (s *nonRecordingSpan) End(
+	_ error,
+) {
+}
+
+func (s *nonRecordingSpan) tracer() Tracer {
+	return s.owner
+}
+
+func (t *noopTracer) Start(
+	ctx context.Context,
+	_ string,
+	_ ...Attribute,
+) (context.Context, Span) {
+	return ctx, &nonRecordingSpan{owner: t}
+}
+
+func newID(length int) string {
+	b := make([]byte, length)
+	// crypto/rand only fails if the system source of randomness is unavailable,
+	// an empty ID is then reported as invalid by SpanContext.IsValid
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
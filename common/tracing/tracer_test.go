@@ -0,0 +1,179 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/temporalio/temporal/common/headers"
+)
+
+type (
+	tracerSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		exporter *InMemoryExporter
+		tracer   Tracer
+	}
+)
+
+func TestTracerSuite(t *testing.T) {
+	s := new(tracerSuite)
+	suite.Run(t, s)
+}
+
+func (s *tracerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.exporter = NewInMemoryExporter()
+	s.tracer = NewTracer(s.exporter, 1)
+}
+
+func (s *tracerSuite) TestStart_ParentChild() {
+	ctx, parent := s.tracer.Start(context.Background(), "parent", Namespace("samples-namespace"))
+	_, child := s.tracer.Start(ctx, "child")
+	child.SetAttributes(ShardID(3))
+	child.End(errors.New("some random error"))
+	parent.End(nil)
+
+	spans := s.exporter.Spans()
+	s.Len(spans, 2)
+	s.Equal("child", spans[0].Name)
+	s.Equal("parent", spans[1].Name)
+	s.Equal(spans[1].SpanContext.TraceID, spans[0].SpanContext.TraceID)
+	s.Equal(spans[1].SpanContext.SpanID, spans[0].ParentSpanID)
+	s.Empty(spans[1].ParentSpanID)
+	s.Equal([]Attribute{ShardID(3)}, spans[0].Attributes)
+	s.Equal([]Attribute{Namespace("samples-namespace")}, spans[1].Attributes)
+	s.Equal("some random error", spans[0].Error)
+	s.Empty(spans[1].Error)
+}
+
+func (s *tracerSuite) TestEnd_Twice() {
+	_, span := s.tracer.Start(context.Background(), "span")
+	span.End(nil)
+	span.SetAttributes(WorkflowID("some random workflow ID"))
+	span.End(nil)
+
+	spans := s.exporter.Spans()
+	s.Len(spans, 1)
+	s.Empty(spans[0].Attributes)
+}
+
+func (s *tracerSuite) TestSampling() {
+	tracer := NewTracer(s.exporter, 0)
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	child.End(nil)
+	parent.End(nil)
+
+	s.Empty(s.exporter.Spans())
+	s.True(parent.SpanContext().IsValid())
+	s.False(parent.SpanContext().Sampled)
+	s.Equal(parent.SpanContext().TraceID, child.SpanContext().TraceID)
+}
+
+func (s *tracerSuite) TestNoopTracer() {
+	ctx, span := NewNoopTracer().Start(context.Background(), "span")
+	span.End(nil)
+
+	s.Nil(SpanFromContext(ctx))
+	s.False(span.SpanContext().IsValid())
+}
+
+func (s *tracerSuite) TestStartChildSpan() {
+	_, parent := s.tracer.Start(context.Background(), "parent")
+	child := StartChildSpan(s.tracer, parent, "child", ShardID(3))
+	child.End(nil)
+	parent.End(nil)
+
+	orphan := StartChildSpan(s.tracer, nil, "orphan")
+	orphan.End(nil)
+	s.False(orphan.SpanContext().IsValid())
+
+	spans := s.exporter.Spans()
+	s.Len(spans, 2)
+	s.Equal("child", spans[0].Name)
+	s.Equal(parent.SpanContext().SpanID, spans[0].ParentSpanID)
+	s.Equal(parent.SpanContext().TraceID, spans[0].SpanContext.TraceID)
+	s.Equal([]Attribute{ShardID(3)}, spans[0].Attributes)
+}
+
+func (s *tracerSuite) TestInjectExtract() {
+	ctx, span := s.tracer.Start(context.Background(), "span")
+	ctx = Inject(Inject(ctx))
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	s.True(ok)
+	s.Len(md.Get(headers.TraceParentHeaderName), 1)
+
+	incoming := metadata.NewIncomingContext(context.Background(), md)
+	s.Equal(span.SpanContext(), Extract(incoming))
+
+	_, remoteChild := s.tracer.Start(incoming, "remote child")
+	s.Equal(span.SpanContext().TraceID, remoteChild.SpanContext().TraceID)
+}
+
+func (s *tracerSuite) TestExtract_Invalid() {
+	for _, value := range []string{"", "00-abc-def-01", "01-00000000000000000000000000000001-0000000000000001-01"} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(headers.TraceParentHeaderName, value))
+		s.False(Extract(ctx).IsValid())
+	}
+}
+
+func (s *tracerSuite) TestInterceptors() {
+	ctx, parent := s.tracer.Start(context.Background(), "parent")
+
+	var serverCtx context.Context
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		serverCtx = metadata.NewIncomingContext(context.Background(), md)
+		return nil
+	}
+	s.NoError(ClientInterceptor(ctx, "/service/Method", nil, nil, nil, invoker))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/service/Method"}
+	_, err := NewServerInterceptor(s.tracer)(serverCtx, nil, info, handler)
+	s.NoError(err)
+	parent.End(nil)
+
+	spans := s.exporter.Spans()
+	s.Len(spans, 3)
+	client, server := spans[0], spans[1]
+	s.Equal(parent.SpanContext().SpanID, client.ParentSpanID)
+	s.Equal(client.SpanContext.SpanID, server.ParentSpanID)
+	s.Equal(parent.SpanContext().TraceID, server.SpanContext.TraceID)
+}
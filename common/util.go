@@ -28,6 +28,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"net/url"
 	"sort"
 	"strings"
 	"sync"
@@ -40,6 +41,7 @@ import (
 	"go.temporal.io/temporal-proto/serviceerror"
 	"go.temporal.io/temporal-proto/workflowservice"
 
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	"github.com/temporalio/temporal/common/backoff"
@@ -389,6 +391,33 @@ func ValidateRetryPolicy(policy *commonpb.RetryPolicy) error {
 	return nil
 }
 
+// IsCompletionCallbackURLAllowed checks if the host of a workflow completion callback URL is in the
+// comma separated allowed hosts list. An entry starting with "*." matches any subdomain of the rest.
+func IsCompletionCallbackURLAllowed(callbackURL string, allowedHosts string) bool {
+	u, err := url.Parse(callbackURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return false
+	}
+	for _, allowedHost := range strings.Split(allowedHosts, ",") {
+		allowedHost = strings.ToLower(strings.TrimSpace(allowedHost))
+		if allowedHost == "" {
+			continue
+		}
+		if strings.HasPrefix(allowedHost, "*.") {
+			if strings.HasSuffix(host, allowedHost[1:]) {
+				return true
+			}
+		} else if host == allowedHost {
+			return true
+		}
+	}
+	return false
+}
+
 // CreateHistoryStartWorkflowRequest create a start workflow request for history
 func CreateHistoryStartWorkflowRequest(
 	namespaceID string,
@@ -557,6 +586,18 @@ func DeserializeSearchAttributeValue(value *commonpb.Payload, valueType commonpb
 	}
 }
 
+// ParseResetReapplyType returns the reset reapply type of the given name, an empty name reapplies signals
+func ParseResetReapplyType(name string) (executiongenpb.ResetReapplyType, error) {
+	switch name {
+	case "", ResetReapplyTypeSignal:
+		return executiongenpb.ResetReapplyType_ResetReapplyType_Signal, nil
+	case ResetReapplyTypeNone:
+		return executiongenpb.ResetReapplyType_ResetReapplyType_None, nil
+	default:
+		return executiongenpb.ResetReapplyType_ResetReapplyType_Signal, fmt.Errorf("unsupported reset reapply type: %v", name)
+	}
+}
+
 // GetDefaultAdvancedVisibilityWritingMode get default advancedVisibilityWritingMode based on
 // whether related config exists in static config file.
 func GetDefaultAdvancedVisibilityWritingMode(isAdvancedVisConfigExist bool) string {
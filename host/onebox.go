@@ -821,6 +821,10 @@ func (c *rpcFactoryImpl) GetGRPCListener() net.Listener {
 	return c.listener
 }
 
+func (c *rpcFactoryImpl) GetHTTPListener() net.Listener {
+	return nil
+}
+
 func (c *rpcFactoryImpl) GetRingpopChannel() *tchannel.Channel {
 	if c.ringpopChannel != nil {
 		return c.ringpopChannel
@@ -56,6 +56,10 @@ func (s *simpleMonitor) EvictSelf() error {
 	return nil
 }
 
+func (s *simpleMonitor) SetDraining(draining bool) error {
+	return nil
+}
+
 func (s *simpleMonitor) WhoAmI() (*membership.HostInfo, error) {
 	return s.hostInfo, nil
 }
@@ -119,6 +119,24 @@ CREATE TABLE replication_tasks_dlq (
   PRIMARY KEY (source_cluster_name, shard_id, task_id)
 );
 
+CREATE TABLE transfer_tasks_dlq (
+  shard_id INT NOT NULL,
+  task_id BIGINT NOT NULL,
+  --
+  data BLOB NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, task_id)
+);
+
+CREATE TABLE timer_tasks_dlq (
+  shard_id INT NOT NULL,
+  task_id BIGINT NOT NULL,
+  --
+  data BLOB NOT NULL,
+  data_encoding VARCHAR(16) NOT NULL,
+  PRIMARY KEY (shard_id, task_id)
+);
+
 CREATE TABLE timer_tasks (
   shard_id INT NOT NULL,
   visibility_timestamp DATETIME(6) NOT NULL,
@@ -244,6 +262,23 @@ CREATE TABLE cluster_metadata (
   PRIMARY KEY(metadata_partition)
 );
 
+CREATE TABLE remote_clusters (
+  metadata_partition INT NOT NULL,
+  cluster_name       VARCHAR(255) NOT NULL,
+  data               BLOB NOT NULL,
+  data_encoding      VARCHAR(16) NOT NULL,
+  PRIMARY KEY(metadata_partition, cluster_name)
+);
+
+CREATE TABLE dynamic_config (
+  metadata_partition INT NOT NULL,
+  name               VARCHAR(255) NOT NULL,
+  revision           BIGINT NOT NULL,
+  data               BLOB NOT NULL,
+  data_encoding      VARCHAR(16) NOT NULL,
+  PRIMARY KEY(metadata_partition, name, revision)
+);
+
 CREATE TABLE cluster_membership
 (
     host_id              BINARY(16) NOT NULL,
@@ -0,0 +1,7 @@
+CREATE TABLE remote_clusters (
+  metadata_partition INT NOT NULL,
+  cluster_name       VARCHAR(255) NOT NULL,
+  data               BLOB NOT NULL,
+  data_encoding      VARCHAR(16) NOT NULL,
+  PRIMARY KEY(metadata_partition, cluster_name)
+);
@@ -0,0 +1,8 @@
+CREATE TABLE dynamic_config (
+  metadata_partition INTEGER NOT NULL,
+  name               VARCHAR(255) NOT NULL,
+  revision           BIGINT NOT NULL,
+  data               BYTEA NOT NULL,
+  data_encoding      VARCHAR(16) NOT NULL,
+  PRIMARY KEY(metadata_partition, name, revision)
+);
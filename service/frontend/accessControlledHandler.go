@@ -28,11 +28,15 @@ import (
 	"context"
 
 	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc/credentials"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
 
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservice"
 	"github.com/temporalio/temporal/common/authorization"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/resource"
+	"github.com/temporalio/temporal/common/rpc/encryption"
 )
 
 // TODO(vancexu): add metrics
@@ -41,12 +45,18 @@ import (
 type AccessControlledWorkflowHandler struct {
 	frontendHandler Handler
 	authorizer      authorization.Authorizer
+	tlsProvider     encryption.TLSConfigProvider
 }
 
 var _ Handler = (*AccessControlledWorkflowHandler)(nil)
 
 // NewAccessControlledHandlerImpl creates frontend handler with authentication support
-func NewAccessControlledHandlerImpl(wfHandler Handler, authorizer authorization.Authorizer) *AccessControlledWorkflowHandler {
+// the tls provider is optional, it resolves the namespaces bound to the client certificates of the callers
+func NewAccessControlledHandlerImpl(
+	wfHandler Handler,
+	authorizer authorization.Authorizer,
+	tlsProvider encryption.TLSConfigProvider,
+) *AccessControlledWorkflowHandler {
 	if authorizer == nil {
 		authorizer = authorization.NewNopAuthorizer()
 	}
@@ -54,6 +64,7 @@ func NewAccessControlledHandlerImpl(wfHandler Handler, authorizer authorization.
 	return &AccessControlledWorkflowHandler{
 		frontendHandler: wfHandler,
 		authorizer:      authorizer,
+		tlsProvider:     tlsProvider,
 	}
 }
 
@@ -748,6 +759,29 @@ func (a *AccessControlledWorkflowHandler) ListTaskListPartitions(
 	return a.frontendHandler.ListTaskListPartitions(ctx, request)
 }
 
+// StreamWorkflowExecutionHistory API call
+func (a *AccessControlledWorkflowHandler) StreamWorkflowExecutionHistory(
+	request *workflowstreamservice.StreamWorkflowExecutionHistoryRequest,
+	stream workflowstreamservice.WorkflowStreamService_StreamWorkflowExecutionHistoryServer,
+) error {
+
+	scope := a.getMetricsScopeWithNamespace(metrics.FrontendStreamWorkflowExecutionHistoryScope, request.GetNamespace())
+
+	attr := &authorization.Attributes{
+		APIName:   "StreamWorkflowExecutionHistory",
+		Namespace: request.GetNamespace(),
+	}
+	isAuthorized, err := a.isAuthorized(stream.Context(), attr, scope)
+	if err != nil {
+		return err
+	}
+	if !isAuthorized {
+		return errUnauthorized
+	}
+
+	return a.frontendHandler.StreamWorkflowExecutionHistory(request, stream)
+}
+
 // UpdateNamespace API call
 func (a *AccessControlledWorkflowHandler) UpdateNamespace(
 	ctx context.Context,
@@ -779,6 +813,13 @@ func (a *AccessControlledWorkflowHandler) isAuthorized(
 	sw := scope.StartTimer(metrics.ServiceAuthorizationLatency)
 	defer sw.Stop()
 
+	tlsIdentity, err := getTLSIdentity(ctx, a.tlsProvider)
+	if err != nil {
+		scope.IncCounter(metrics.ServiceErrAuthorizeFailedCounter)
+		return false, err
+	}
+	attr.TLSIdentity = tlsIdentity
+
 	result, err := a.authorizer.Authorize(ctx, attr)
 	if err != nil {
 		scope.IncCounter(metrics.ServiceErrAuthorizeFailedCounter)
@@ -791,6 +832,39 @@ func (a *AccessControlledWorkflowHandler) isAuthorized(
 	return isAuth, nil
 }
 
+// getTLSIdentity returns the identity of the verified client certificate of the caller, if any
+func getTLSIdentity(
+	ctx context.Context,
+	tlsProvider encryption.TLSConfigProvider,
+) (*authorization.TLSIdentity, error) {
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil, nil
+	}
+	verifiedChains := tlsInfo.State.VerifiedChains
+	if len(verifiedChains) == 0 || len(verifiedChains[0]) == 0 {
+		return nil, nil
+	}
+
+	tlsIdentity := &authorization.TLSIdentity{
+		Subject: verifiedChains[0][0].Subject.String(),
+		Issuer:  verifiedChains[0][0].Issuer.String(),
+	}
+	if tlsProvider != nil {
+		namespaces, err := tlsProvider.GetFrontendClientNamespaces(verifiedChains)
+		if err != nil {
+			return nil, err
+		}
+		tlsIdentity.Namespaces = namespaces
+	}
+	return tlsIdentity, nil
+}
+
 // getMetricsScopeWithNamespace return metrics scope with namespace tag
 func (a *AccessControlledWorkflowHandler) getMetricsScopeWithNamespace(
 	scope int,
@@ -26,6 +26,9 @@ package frontend
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"testing"
 
@@ -33,11 +36,14 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"go.temporal.io/temporal-proto/workflowservicemock"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 
 	"github.com/temporalio/temporal/common/authorization"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/metrics/mocks"
 	"github.com/temporalio/temporal/common/resource"
+	"github.com/temporalio/temporal/common/rpc/encryption"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
 
@@ -53,6 +59,11 @@ type (
 
 		handler *AccessControlledWorkflowHandler
 	}
+
+	testTLSConfigProvider struct {
+		encryption.TLSConfigProvider
+		namespaces []string
+	}
 )
 
 func TestAccessControlledHandlerSuite(t *testing.T) {
@@ -67,11 +78,11 @@ func (s *accessControlledHandlerSuite) SetupTest() {
 	mockResource := resource.NewTest(s.controller, metrics.Frontend)
 	config := NewConfig(dynamicconfig.NewCollection(dynamicconfig.NewNopClient(), mockResource.GetLogger()), 0, false)
 
-	frontendHandlerGRPC := NewWorkflowHandler(mockResource, config, nil)
+	frontendHandlerGRPC := NewWorkflowHandler(mockResource, config, NewNamespaceHandler(mockResource, config, nil))
 	s.mockFrontendHandler = workflowservicemock.NewMockWorkflowServiceServer(s.controller)
 	s.mockAuthorizer = authorization.NewMockAuthorizer(s.controller)
 	s.mockMetricsScope = &mocks.Scope{}
-	s.handler = NewAccessControlledHandlerImpl(frontendHandlerGRPC, s.mockAuthorizer)
+	s.handler = NewAccessControlledHandlerImpl(frontendHandlerGRPC, s.mockAuthorizer, nil)
 }
 
 func (s *accessControlledHandlerSuite) TearDownTest() {
@@ -124,3 +135,29 @@ func (s *accessControlledHandlerSuite) TestIsAuthorized_Unauthorized() {
 	s.False(res)
 	s.NoError(err)
 }
+
+func (s *accessControlledHandlerSuite) TestIsAuthorized_TLSIdentity() {
+	verifiedChains := [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "test-client"}}}}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: verifiedChains}},
+	})
+	attr := &authorization.Attributes{}
+	s.handler.tlsProvider = &testTLSConfigProvider{namespaces: []string{"test-namespace"}}
+
+	s.mockMetricsScope.On("StartTimer", metrics.ServiceAuthorizationLatency).
+		Return(metrics.Stopwatch{}).Once()
+	s.mockAuthorizer.EXPECT().Authorize(ctx, attr).
+		Return(authorization.Result{Decision: authorization.DecisionAllow}, nil).Times(1)
+
+	res, err := s.handler.isAuthorized(ctx, attr, s.mockMetricsScope)
+	s.True(res)
+	s.NoError(err)
+	s.Equal(&authorization.TLSIdentity{
+		Subject:    "CN=test-client",
+		Namespaces: []string{"test-namespace"},
+	}, attr.TLSIdentity)
+}
+
+func (p *testTLSConfigProvider) GetFrontendClientNamespaces([][]*x509.Certificate) ([]string, error) {
+	return p.namespaces, nil
+}
@@ -40,11 +40,17 @@ import (
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	clustergenpb "github.com/temporalio/temporal/.gen/proto/cluster"
 	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
+	eventgenpb "github.com/temporalio/temporal/.gen/proto/event"
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
+	"github.com/temporalio/temporal/.gen/proto/matchingservice"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	tokengenpb "github.com/temporalio/temporal/.gen/proto/token"
 	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/audit"
 	"github.com/temporalio/temporal/common/backoff"
+	"github.com/temporalio/temporal/common/blobstore"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/headers"
 	"github.com/temporalio/temporal/common/log"
@@ -52,14 +58,20 @@ import (
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/namespace"
 	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/primitives"
 	"github.com/temporalio/temporal/common/resource"
+	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 	"github.com/temporalio/temporal/service/history"
+	"github.com/temporalio/temporal/service/worker/namespacedeletion"
 )
 
 const (
 	getNamespaceReplicationMessageBatchSize = 100
 	defaultLastMessageID                    = -1
+	listNamespacesPageSize                  = 100
+	defaultAuditLogPageSize                 = 100
+	maxAuditLogPageSize                     = 1000
 )
 
 type (
@@ -67,10 +79,11 @@ type (
 	AdminHandler struct {
 		resource.Resource
 
-		numberOfHistoryShards int
-		params                *resource.BootstrapParams
-		config                *Config
-		namespaceDLQHandler   namespace.DLQMessageHandler
+		params              *resource.BootstrapParams
+		config              *Config
+		namespaceDLQHandler namespace.DLQMessageHandler
+		namespaceHandler    namespace.Handler
+		deletionClient      namespacedeletion.Client
 	}
 )
 
@@ -85,6 +98,7 @@ func NewAdminHandler(
 	resource resource.Resource,
 	params *resource.BootstrapParams,
 	config *Config,
+	namespaceHandler namespace.Handler,
 ) *AdminHandler {
 
 	namespaceReplicationTaskExecutor := namespace.NewReplicationTaskExecutor(
@@ -92,15 +106,16 @@ func NewAdminHandler(
 		resource.GetLogger(),
 	)
 	return &AdminHandler{
-		Resource:              resource,
-		numberOfHistoryShards: params.PersistenceConfig.NumHistoryShards,
-		params:                params,
-		config:                config,
+		Resource: resource,
+		params:   params,
+		config:   config,
 		namespaceDLQHandler: namespace.NewDLQMessageHandler(
 			namespaceReplicationTaskExecutor,
 			resource.GetNamespaceReplicationQueue(),
 			resource.GetLogger(),
 		),
+		namespaceHandler: namespaceHandler,
+		deletionClient:   namespacedeletion.NewClient(resource.GetSDKClient()),
 	}
 }
 
@@ -194,7 +209,7 @@ func (adh *AdminHandler) DescribeWorkflowExecution(ctx context.Context, request
 		return nil, adh.error(err, scope)
 	}
 
-	shardID := common.WorkflowIDToHistoryShard(request.Execution.WorkflowId, adh.numberOfHistoryShards)
+	shardID := adh.GetHistoryShardLayout().GetShardID(request.Execution.WorkflowId)
 	shardIDstr := string(shardID)
 	shardIDForOutput := strconv.Itoa(shardID)
 
@@ -255,6 +270,79 @@ func (adh *AdminHandler) CloseShard(ctx context.Context, request *adminservice.C
 	return &adminservice.CloseShardResponse{}, err
 }
 
+// SetShardOwner pins a shard to a history host, or removes the pin
+func (adh *AdminHandler) SetShardOwner(ctx context.Context, request *adminservice.SetShardOwnerRequest) (_ *adminservice.SetShardOwnerResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminSetShardOwnerScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetShardId() < 0 || int(request.GetShardId()) >= adh.GetHistoryShardLayout().GetNumberOfShards() {
+		return nil, adh.error(errInvalidShardID, scope)
+	}
+
+	_, err := adh.GetHistoryClient().SetShardOwner(ctx, &historyservice.SetShardOwnerRequest{
+		ShardId:     request.GetShardId(),
+		HostAddress: request.GetHostAddress(),
+		Pinned:      request.GetHostAddress() != "",
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.SetShardOwnerResponse{}, nil
+}
+
+// DrainHost gracefully moves a history or matching host out of the membership ring without
+// stopping it, or puts it back. The response reports how much the host still owns.
+func (adh *AdminHandler) DrainHost(ctx context.Context, request *adminservice.DrainHostRequest) (_ *adminservice.DrainHostResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminDrainHostScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetHostAddress() == "" {
+		return nil, adh.error(errHostAddressNotSet, scope)
+	}
+
+	switch request.GetServiceName() {
+	case primitives.HistoryService:
+		resp, err := adh.GetHistoryClient().DrainHost(ctx, &historyservice.DrainHostRequest{
+			HostAddress: request.GetHostAddress(),
+			Undrain:     request.GetUndrain(),
+		})
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		return &adminservice.DrainHostResponse{
+			Draining:       resp.GetDraining(),
+			Drained:        resp.GetDrained(),
+			NumberOfShards: resp.GetNumberOfShards(),
+		}, nil
+	case primitives.MatchingService:
+		resp, err := adh.GetMatchingClient().DrainHost(ctx, &matchingservice.DrainHostRequest{
+			HostAddress: request.GetHostAddress(),
+			Undrain:     request.GetUndrain(),
+		})
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		return &adminservice.DrainHostResponse{
+			Draining:          resp.GetDraining(),
+			Drained:           resp.GetDrained(),
+			NumberOfTaskLists: resp.GetNumberOfTaskLists(),
+			NumberOfPollers:   resp.GetNumberOfPollers(),
+		}, nil
+	default:
+		return nil, adh.error(errInvalidDrainServiceName, scope)
+	}
+}
+
 // DescribeHistoryHost returns information about the internal states of a history host
 func (adh *AdminHandler) DescribeHistoryHost(ctx context.Context, request *adminservice.DescribeHistoryHostRequest) (_ *adminservice.DescribeHistoryHostResponse, retError error) {
 	defer log.CapturePanic(adh.GetLogger(), &retError)
@@ -288,6 +376,7 @@ func (adh *AdminHandler) DescribeHistoryHost(ctx context.Context, request *admin
 		NamespaceCache:        resp.GetNamespaceCache(),
 		ShardControllerStatus: resp.GetShardControllerStatus(),
 		Address:               resp.GetAddress(),
+		ShardLoads:            resp.GetShardLoads(),
 	}, err
 }
 
@@ -389,7 +478,7 @@ func (adh *AdminHandler) GetWorkflowExecutionRawHistory(ctx context.Context, req
 
 	// TODO need to deal with transient decision if to be used by client getting history
 	var historyBatches []*eventpb.History
-	shardID := common.WorkflowIDToHistoryShard(execution.GetWorkflowId(), adh.numberOfHistoryShards)
+	shardID := adh.GetHistoryShardLayout().GetShardID(execution.GetWorkflowId())
 	_, historyBatches, continuationToken.PersistenceToken, size, err = history.PaginateHistory(
 		adh.GetHistoryManager(),
 		true, // this means that we are getting history by batch
@@ -522,10 +611,7 @@ func (adh *AdminHandler) GetWorkflowExecutionRawHistoryV2(ctx context.Context, r
 		}, nil
 	}
 	pageSize := int(request.GetMaximumPageSize())
-	shardID := common.WorkflowIDToHistoryShard(
-		execution.GetWorkflowId(),
-		adh.numberOfHistoryShards,
-	)
+	shardID := adh.GetHistoryShardLayout().GetShardID(execution.GetWorkflowId())
 	rawHistoryResponse, err := adh.GetHistoryManager().ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
 		BranchToken: targetVersionHistory.GetBranchToken(),
 		// GetWorkflowExecutionRawHistoryV2 is exclusive exclusive.
@@ -558,8 +644,26 @@ func (adh *AdminHandler) GetWorkflowExecutionRawHistoryV2(ctx context.Context, r
 
 	rawBlobs := rawHistoryResponse.HistoryEventBlobs
 	var blobs []*commonpb.DataBlob
-	for _, blob := range rawBlobs {
-		blobs = append(blobs, blob.ToProto())
+	if len(rawBlobs) > 0 {
+		namespaceEntry, err := adh.GetNamespaceCache().GetNamespaceByID(namespaceID)
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		for _, blob := range rawBlobs {
+			blob, err = blobstore.RehydrateEventBlob(
+				ctx,
+				adh.GetPayloadOffloader(),
+				adh.GetPayloadSerializer(),
+				namespaceEntry.GetConfig().GetHistoryArchivalURI(),
+				namespaceID,
+				execution.GetRunId(),
+				blob,
+			)
+			if err != nil {
+				return nil, err
+			}
+			blobs = append(blobs, blob.ToProto())
+		}
 	}
 
 	result := &adminservice.GetWorkflowExecutionRawHistoryV2Response{
@@ -790,7 +894,7 @@ func (adh *AdminHandler) ReadDLQMessages(
 	var token []byte
 	var op func() error
 	switch request.GetType() {
-	case commongenpb.DLQType_Replication:
+	case commongenpb.DLQType_Replication, commongenpb.DLQType_Transfer, commongenpb.DLQType_Timer:
 		resp, err := adh.GetHistoryClient().ReadDLQMessages(ctx, &historyservice.ReadDLQMessagesRequest{
 			Type:                  request.GetType(),
 			ShardId:               request.GetShardId(),
@@ -807,6 +911,8 @@ func (adh *AdminHandler) ReadDLQMessages(
 		return &adminservice.ReadDLQMessagesResponse{
 			Type:             resp.GetType(),
 			ReplicationTasks: resp.GetReplicationTasks(),
+			TransferTasks:    resp.GetTransferTasks(),
+			TimerTasks:       resp.GetTimerTasks(),
 			NextPageToken:    resp.GetNextPageToken(),
 		}, err
 	case commongenpb.DLQType_Namespace:
@@ -857,7 +963,7 @@ func (adh *AdminHandler) PurgeDLQMessages(
 
 	var op func() error
 	switch request.GetType() {
-	case commongenpb.DLQType_Replication:
+	case commongenpb.DLQType_Replication, commongenpb.DLQType_Transfer, commongenpb.DLQType_Timer:
 		resp, err := adh.GetHistoryClient().PurgeDLQMessages(ctx, &historyservice.PurgeDLQMessagesRequest{
 			Type:                  request.GetType(),
 			ShardId:               request.GetShardId(),
@@ -911,7 +1017,7 @@ func (adh *AdminHandler) MergeDLQMessages(
 	var token []byte
 	var op func() error
 	switch request.GetType() {
-	case commongenpb.DLQType_Replication:
+	case commongenpb.DLQType_Replication, commongenpb.DLQType_Transfer, commongenpb.DLQType_Timer:
 		resp, err := adh.GetHistoryClient().MergeDLQMessages(ctx, &historyservice.MergeDLQMessagesRequest{
 			Type:                  request.GetType(),
 			ShardId:               request.GetShardId(),
@@ -925,7 +1031,7 @@ func (adh *AdminHandler) MergeDLQMessages(
 		}
 
 		return &adminservice.MergeDLQMessagesResponse{
-			NextPageToken: request.GetNextPageToken(),
+			NextPageToken: resp.GetNextPageToken(),
 		}, nil
 	case commongenpb.DLQType_Namespace:
 
@@ -986,6 +1092,602 @@ func (adh *AdminHandler) RefreshWorkflowTasks(
 	return &adminservice.RefreshWorkflowTasksResponse{}, nil
 }
 
+// ResetWorkflowExecution resets a workflow with the reset options which the workflow service request does not carry
+func (adh *AdminHandler) ResetWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ResetWorkflowExecutionRequest,
+) (_ *adminservice.ResetWorkflowExecutionResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminResetWorkflowExecutionScope)
+	defer sw.Stop()
+
+	if request == nil || request.ResetRequest == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	resetRequest := request.GetResetRequest()
+	if resetRequest.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if err := validateExecution(resetRequest.WorkflowExecution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if _, ok := executiongenpb.ResetReapplyType_name[int32(request.GetReapplyType())]; !ok {
+		return nil, adh.error(errInvalidResetReapplyType, scope)
+	}
+	if request.GetReapplyType() == executiongenpb.ResetReapplyType_ResetReapplyType_None && len(request.GetReapplySignalNames()) > 0 {
+		return nil, adh.error(errInvalidResetReapplyType, scope)
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(resetRequest.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err := adh.GetHistoryClient().ResetWorkflowExecution(ctx, &historyservice.ResetWorkflowExecutionRequest{
+		NamespaceId:         namespaceID,
+		ResetRequest:        resetRequest,
+		ReapplyType:         request.GetReapplyType(),
+		ReapplySignalNames:  request.GetReapplySignalNames(),
+		ResetToLastRunStart: request.GetResetToLastRunStart(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.ResetWorkflowExecutionResponse{RunId: resp.GetRunId()}, nil
+}
+
+// ExportWorkflowExecution returns a page of the history of a workflow run, the first page includes the version histories
+func (adh *AdminHandler) ExportWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ExportWorkflowExecutionRequest,
+) (_ *adminservice.ExportWorkflowExecutionResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminExportWorkflowExecutionScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	scope = scope.Tagged(metrics.NamespaceTag(request.GetNamespace()))
+
+	pageSize := int(request.GetMaximumPageSize())
+	if pageSize <= 0 {
+		pageSize = adh.config.HistoryMaxPageSize(request.GetNamespace())
+	}
+	pageSize = common.MinInt(pageSize, common.GetHistoryMaxPageSize)
+
+	execution := request.Execution
+	var versionHistories *eventgenpb.VersionHistories
+	var continuationToken *tokengenpb.HistoryContinuation
+	if request.NextPageToken == nil {
+		response, err := adh.GetHistoryClient().GetMutableState(ctx, &historyservice.GetMutableStateRequest{
+			NamespaceId: namespaceID,
+			Execution:   request.Execution,
+		})
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		execution = response.GetExecution()
+		versionHistories = response.GetVersionHistories()
+		continuationToken = &tokengenpb.HistoryContinuation{
+			RunId:        execution.GetRunId(),
+			FirstEventId: common.FirstEventID,
+			NextEventId:  response.GetNextEventId(),
+			BranchToken:  response.GetCurrentBranchToken(),
+		}
+	} else {
+		continuationToken, err = deserializeHistoryToken(request.NextPageToken)
+		if err != nil {
+			return nil, adh.error(errInvalidNextPageToken, scope)
+		}
+		if execution.GetRunId() != "" && execution.GetRunId() != continuationToken.GetRunId() {
+			return nil, adh.error(errNextPageTokenRunIDMismatch, scope)
+		}
+		execution = &commonpb.WorkflowExecution{
+			WorkflowId: execution.GetWorkflowId(),
+			RunId:      continuationToken.GetRunId(),
+		}
+	}
+
+	shardID := adh.GetHistoryShardLayout().GetShardID(execution.GetWorkflowId())
+	_, historyBatches, persistenceToken, _, err := history.PaginateHistory(
+		adh.GetHistoryManager(),
+		true, // this means that we are getting history by batch
+		continuationToken.GetBranchToken(),
+		continuationToken.GetFirstEventId(),
+		continuationToken.GetNextEventId(),
+		continuationToken.GetPersistenceToken(),
+		pageSize,
+		&shardID,
+	)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	var nextPageToken []byte
+	if len(persistenceToken) != 0 {
+		continuationToken.PersistenceToken = persistenceToken
+		nextPageToken, err = serializeHistoryToken(continuationToken)
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+	}
+
+	return &adminservice.ExportWorkflowExecutionResponse{
+		Export: &eventgenpb.WorkflowExecutionHistoryExport{
+			FormatVersion:    common.WorkflowExecutionHistoryExportFormatVersion,
+			Namespace:        request.GetNamespace(),
+			Execution:        execution,
+			VersionHistories: versionHistories,
+			HistoryBatches:   historyBatches,
+		},
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// ImportWorkflowExecution recreates a workflow run from a previously exported history
+func (adh *AdminHandler) ImportWorkflowExecution(
+	ctx context.Context,
+	request *adminservice.ImportWorkflowExecutionRequest,
+) (_ *adminservice.ImportWorkflowExecutionResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminImportWorkflowExecutionScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	export := request.GetExport()
+	if export == nil || len(export.GetHistoryBatches()) == 0 {
+		return nil, adh.error(errHistoryExportNotSet, scope)
+	}
+	if export.GetFormatVersion() != common.WorkflowExecutionHistoryExportFormatVersion {
+		return nil, adh.error(errUnsupportedHistoryExportFormat.MessageArgs(export.GetFormatVersion()), scope)
+	}
+	if err := validateExecution(export.GetExecution()); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if export.GetExecution().GetRunId() == "" {
+		return nil, adh.error(errInvalidRunID, scope)
+	}
+	namespaceEntry, err := adh.GetNamespaceCache().GetNamespace(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	scope = scope.Tagged(metrics.NamespaceTag(request.GetNamespace()))
+
+	_, err = adh.GetHistoryClient().ImportWorkflowExecution(ctx, &historyservice.ImportWorkflowExecutionRequest{
+		NamespaceId: namespaceEntry.GetInfo().Id,
+		Request:     request,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.ImportWorkflowExecutionResponse{}, nil
+}
+
+// DeleteNamespace marks a namespace as deleting and starts a system workflow that purges its data
+func (adh *AdminHandler) DeleteNamespace(
+	ctx context.Context,
+	request *adminservice.DeleteNamespaceRequest,
+) (_ *adminservice.DeleteNamespaceResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminDeleteNamespaceScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := adh.checkPermission(adh.config, request.SecurityToken); err != nil {
+		return nil, adh.error(errNoPermission, scope)
+	}
+	if request.GetNamespace() == "" {
+		return nil, adh.error(errNamespaceNotSet, scope)
+	}
+	namespaceID, err := adh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	scope = scope.Tagged(metrics.NamespaceTag(request.GetNamespace()))
+
+	if _, err := adh.namespaceHandler.DeleteNamespace(ctx, request); err != nil {
+		return nil, adh.error(err, scope)
+	}
+	workflowID, err := adh.deletionClient.StartDeletion(ctx, namespacedeletion.Request{
+		Namespace:   request.GetNamespace(),
+		NamespaceID: namespaceID,
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.DeleteNamespaceResponse{WorkflowId: workflowID}, nil
+}
+
+// AddOrUpdateRemoteCluster adds a remote cluster to the replication group or updates its connection information.
+// The change is applied on this host immediately and picked up by all other hosts on their next cluster metadata refresh.
+func (adh *AdminHandler) AddOrUpdateRemoteCluster(
+	ctx context.Context,
+	request *adminservice.AddOrUpdateRemoteClusterRequest,
+) (_ *adminservice.AddOrUpdateRemoteClusterResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminAddOrUpdateRemoteClusterScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetClusterName() == "" {
+		return nil, adh.error(errClusterNameNotSet, scope)
+	}
+	if request.GetClusterName() == adh.GetClusterMetadata().GetCurrentClusterName() {
+		return nil, adh.error(errRemoteClusterIsCurrentCluster, scope)
+	}
+	if request.GetEnabled() && request.GetRpcAddress() == "" {
+		return nil, adh.error(errRPCAddressNotSet, scope)
+	}
+
+	remoteClusters, err := adh.listRemoteClusters()
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	previousRemoteClusters := make(map[string]config.ClusterInformation, len(remoteClusters))
+	for clusterName, info := range remoteClusters {
+		previousRemoteClusters[clusterName] = info
+	}
+	remoteClusters[request.GetClusterName()] = config.ClusterInformation{
+		Enabled:                request.GetEnabled(),
+		InitialFailoverVersion: request.GetInitialFailoverVersion(),
+		RPCAddress:             request.GetRpcAddress(),
+	}
+
+	// applying the change locally validates it against the static config and the other remote clusters
+	if err := adh.GetClusterMetadata().UpdateRemoteClusters(remoteClusters); err != nil {
+		return nil, adh.error(errInvalidRemoteClusterInfo.MessageArgs(err.Error()), scope)
+	}
+
+	if err := adh.GetClusterMetadataManager().UpsertRemoteCluster(&persistence.UpsertRemoteClusterRequest{
+		RemoteClusterInfo: persistenceblobs.RemoteClusterInfo{
+			ClusterName:            request.GetClusterName(),
+			Enabled:                request.GetEnabled(),
+			InitialFailoverVersion: request.GetInitialFailoverVersion(),
+			RpcAddress:             request.GetRpcAddress(),
+		},
+	}); err != nil {
+		if revertErr := adh.GetClusterMetadata().UpdateRemoteClusters(previousRemoteClusters); revertErr != nil {
+			adh.GetLogger().Error("Failed to revert remote clusters.", tag.Error(revertErr))
+		}
+		return nil, adh.error(err, scope)
+	}
+
+	adh.GetLogger().Info("Remote cluster added or updated.",
+		tag.ClusterName(request.GetClusterName()),
+		tag.Address(request.GetRpcAddress()),
+	)
+	return &adminservice.AddOrUpdateRemoteClusterResponse{}, nil
+}
+
+// RemoveRemoteCluster removes a remote cluster added by AddOrUpdateRemoteCluster from the replication group.
+// A cluster cannot be removed while it is still in the replication config of a namespace.
+func (adh *AdminHandler) RemoveRemoteCluster(
+	ctx context.Context,
+	request *adminservice.RemoveRemoteClusterRequest,
+) (_ *adminservice.RemoveRemoteClusterResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminRemoveRemoteClusterScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	clusterName := request.GetClusterName()
+	if clusterName == "" {
+		return nil, adh.error(errClusterNameNotSet, scope)
+	}
+	if clusterName == adh.GetClusterMetadata().GetCurrentClusterName() {
+		return nil, adh.error(errRemoteClusterIsCurrentCluster, scope)
+	}
+
+	remoteClusters, err := adh.listRemoteClusters()
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if _, ok := remoteClusters[clusterName]; !ok {
+		return nil, adh.error(errRemoteClusterNotManaged.MessageArgs(clusterName), scope)
+	}
+
+	var pageToken []byte
+	for {
+		resp, err := adh.GetMetadataManager().ListNamespaces(&persistence.ListNamespacesRequest{
+			PageSize:      listNamespacesPageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		for _, ns := range resp.Namespaces {
+			for _, nsCluster := range ns.Namespace.GetReplicationConfig().GetClusters() {
+				if nsCluster == clusterName {
+					return nil, adh.error(errRemoteClusterInUse.MessageArgs(clusterName, ns.Namespace.GetInfo().GetName()), scope)
+				}
+			}
+		}
+		pageToken = resp.NextPageToken
+		if len(pageToken) == 0 {
+			break
+		}
+	}
+
+	if err := adh.GetClusterMetadataManager().DeleteRemoteCluster(&persistence.DeleteRemoteClusterRequest{
+		ClusterName: clusterName,
+	}); err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	delete(remoteClusters, clusterName)
+	if err := adh.GetClusterMetadata().UpdateRemoteClusters(remoteClusters); err != nil {
+		adh.GetLogger().Error("Failed to apply remote clusters.", tag.Error(err))
+	}
+
+	adh.GetLogger().Info("Remote cluster removed.", tag.ClusterName(clusterName))
+	return &adminservice.RemoveRemoteClusterResponse{}, nil
+}
+
+func (adh *AdminHandler) listRemoteClusters() (map[string]config.ClusterInformation, error) {
+	resp, err := adh.GetClusterMetadataManager().ListRemoteClusters()
+	if err != nil {
+		return nil, err
+	}
+
+	remoteClusters := make(map[string]config.ClusterInformation, len(resp.RemoteClusters))
+	for _, info := range resp.RemoteClusters {
+		remoteClusters[info.GetClusterName()] = config.ClusterInformation{
+			Enabled:                info.GetEnabled(),
+			InitialFailoverVersion: info.GetInitialFailoverVersion(),
+			RPCAddress:             info.GetRpcAddress(),
+		}
+	}
+	return remoteClusters, nil
+}
+
+// GetDynamicConfig returns the dynamic config values changed through the admin API
+func (adh *AdminHandler) GetDynamicConfig(
+	ctx context.Context,
+	request *adminservice.GetDynamicConfigRequest,
+) (_ *adminservice.GetDynamicConfigResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminGetDynamicConfigScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+
+	resp, err := adh.GetClusterMetadataManager().ListDynamicConfigValues()
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	if request.GetName() == "" {
+		return &adminservice.GetDynamicConfigResponse{Values: resp.Values}, nil
+	}
+	var values []*persistenceblobs.DynamicConfigValue
+	for _, value := range resp.Values {
+		if value.GetName() == request.GetName() {
+			values = append(values, value)
+		}
+	}
+	return &adminservice.GetDynamicConfigResponse{Values: values}, nil
+}
+
+// UpdateDynamicConfig sets the values of a dynamic config key cluster wide
+func (adh *AdminHandler) UpdateDynamicConfig(
+	ctx context.Context,
+	request *adminservice.UpdateDynamicConfigRequest,
+) (_ *adminservice.UpdateDynamicConfigResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminUpdateDynamicConfigScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetName() == "" {
+		return nil, adh.error(errDynamicConfigNameNotSet, scope)
+	}
+	if err := dynamicconfig.ValidateValues(request.GetName(), request.GetValues()); err != nil {
+		return nil, adh.error(errInvalidDynamicConfigValues.MessageArgs(err), scope)
+	}
+
+	revision, err := adh.updateDynamicConfig(&persistence.UpdateDynamicConfigRequest{
+		Name:      request.GetName(),
+		Values:    request.GetValues(),
+		UpdatedBy: request.GetIdentity(),
+		Reason:    request.GetReason(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.UpdateDynamicConfigResponse{Revision: revision}, nil
+}
+
+// DeleteDynamicConfig removes the values of a dynamic config key so the config file value applies again
+func (adh *AdminHandler) DeleteDynamicConfig(
+	ctx context.Context,
+	request *adminservice.DeleteDynamicConfigRequest,
+) (_ *adminservice.DeleteDynamicConfigResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminDeleteDynamicConfigScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetName() == "" {
+		return nil, adh.error(errDynamicConfigNameNotSet, scope)
+	}
+
+	history, err := adh.GetClusterMetadataManager().GetDynamicConfigHistory(&persistence.GetDynamicConfigHistoryRequest{
+		Name: request.GetName(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	if len(history.Revisions) == 0 || history.Revisions[0].GetDeleted() {
+		return nil, adh.error(errDynamicConfigNotStored.MessageArgs(request.GetName()), scope)
+	}
+
+	revision, err := adh.updateDynamicConfig(&persistence.UpdateDynamicConfigRequest{
+		Name:      request.GetName(),
+		Deleted:   true,
+		UpdatedBy: request.GetIdentity(),
+		Reason:    request.GetReason(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.DeleteDynamicConfigResponse{Revision: revision}, nil
+}
+
+// GetDynamicConfigHistory returns all revisions of a dynamic config key
+func (adh *AdminHandler) GetDynamicConfigHistory(
+	ctx context.Context,
+	request *adminservice.GetDynamicConfigHistoryRequest,
+) (_ *adminservice.GetDynamicConfigHistoryResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminGetDynamicConfigHistoryScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetName() == "" {
+		return nil, adh.error(errDynamicConfigNameNotSet, scope)
+	}
+
+	resp, err := adh.GetClusterMetadataManager().GetDynamicConfigHistory(&persistence.GetDynamicConfigHistoryRequest{
+		Name: request.GetName(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.GetDynamicConfigHistoryResponse{Revisions: resp.Revisions}, nil
+}
+
+// RollbackDynamicConfig restores the values of a dynamic config key from a previous revision
+func (adh *AdminHandler) RollbackDynamicConfig(
+	ctx context.Context,
+	request *adminservice.RollbackDynamicConfigRequest,
+) (_ *adminservice.RollbackDynamicConfigResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminRollbackDynamicConfigScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.GetName() == "" {
+		return nil, adh.error(errDynamicConfigNameNotSet, scope)
+	}
+
+	history, err := adh.GetClusterMetadataManager().GetDynamicConfigHistory(&persistence.GetDynamicConfigHistoryRequest{
+		Name: request.GetName(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	var target *persistenceblobs.DynamicConfigValue
+	for _, value := range history.Revisions {
+		if value.GetRevision() == request.GetRevision() {
+			target = value
+			break
+		}
+	}
+	if target == nil {
+		return nil, adh.error(errDynamicConfigRevisionNotFound.MessageArgs(request.GetRevision(), request.GetName()), scope)
+	}
+
+	// Rollback is recorded as a new revision, so it can be rolled back as well
+	revision, err := adh.updateDynamicConfig(&persistence.UpdateDynamicConfigRequest{
+		Name:      request.GetName(),
+		Values:    target.GetValues(),
+		Deleted:   target.GetDeleted(),
+		UpdatedBy: request.GetIdentity(),
+		Reason:    request.GetReason(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.RollbackDynamicConfigResponse{Revision: revision}, nil
+}
+
+func (adh *AdminHandler) updateDynamicConfig(request *persistence.UpdateDynamicConfigRequest) (int64, error) {
+	resp, err := adh.GetClusterMetadataManager().UpdateDynamicConfig(request)
+	if err != nil {
+		return 0, err
+	}
+
+	adh.GetLogger().Info("Dynamic config updated.",
+		tag.Key(request.Name),
+		tag.Number(resp.Revision),
+		tag.Bool(request.Deleted),
+	)
+	return resp.Revision, nil
+}
+
+// GetReplicationStatus returns the replication status of history shards against remote clusters
+func (adh *AdminHandler) GetReplicationStatus(
+	ctx context.Context,
+	request *adminservice.GetReplicationStatusRequest,
+) (_ *adminservice.GetReplicationStatusResponse, err error) {
+	defer log.CapturePanic(adh.GetLogger(), &err)
+	scope, sw := adh.startRequestProfile(metrics.AdminGetReplicationStatusScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	for _, shardID := range request.GetShardIds() {
+		if shardID < 0 || int(shardID) >= adh.GetHistoryShardLayout().GetNumberOfShards() {
+			return nil, adh.error(errInvalidShardID, scope)
+		}
+	}
+	clusterMetadata := adh.GetClusterMetadata()
+	allClusters := clusterMetadata.GetAllClusterInfo()
+	for _, clusterName := range request.GetRemoteClusters() {
+		if _, ok := allClusters[clusterName]; !ok || clusterName == clusterMetadata.GetCurrentClusterName() {
+			return nil, adh.error(errInvalidRemoteCluster, scope)
+		}
+	}
+
+	resp, err := adh.GetHistoryClient().GetReplicationStatus(ctx, &historyservice.GetReplicationStatusRequest{
+		ShardIds:       request.GetShardIds(),
+		RemoteClusters: request.GetRemoteClusters(),
+	})
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return &adminservice.GetReplicationStatusResponse{Shards: resp.GetShards()}, nil
+}
+
 func (adh *AdminHandler) validateGetWorkflowExecutionRawHistoryV2Request(
 	request *adminservice.GetWorkflowExecutionRawHistoryV2Request,
 ) error {
@@ -1176,3 +1878,82 @@ func (adh *AdminHandler) checkPermission(
 	}
 	return nil
 }
+
+// GetAuditLog returns the audit records of mutating frontend API calls, oldest first
+func (adh *AdminHandler) GetAuditLog(
+	ctx context.Context,
+	request *adminservice.GetAuditLogRequest,
+) (_ *adminservice.GetAuditLogResponse, retError error) {
+	defer log.CapturePanic(adh.GetLogger(), &retError)
+
+	scope, sw := adh.startRequestProfile(metrics.AdminGetAuditLogScope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if !adh.isAuditLogStored() {
+		return nil, adh.error(errAuditLogNotStored, scope)
+	}
+
+	pageSize := int(request.GetMaximumPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultAuditLogPageSize
+	}
+	pageSize = common.MinInt(pageSize, maxAuditLogPageSize)
+
+	lastMessageID := int64(defaultLastMessageID)
+	if len(request.NextPageToken) > 0 {
+		var err error
+		lastMessageID, err = strconv.ParseInt(string(request.NextPageToken), 10, 64)
+		if err != nil {
+			return nil, adh.error(errInvalidNextPageToken, scope)
+		}
+	}
+
+	var records []*persistenceblobs.AuditRecord
+	hasMore := true
+	for hasMore && len(records) < pageSize {
+		// never read more than what is left of the page, so that the page ends exactly at the last read message
+		count := pageSize - len(records)
+		batch, batchLastMessageID, err := adh.GetAuditLogQueue().Read(lastMessageID, count)
+		if err != nil {
+			return nil, adh.error(err, scope)
+		}
+		for _, record := range batch {
+			if auditRecordMatches(record, request) {
+				records = append(records, record)
+			}
+		}
+		lastMessageID = batchLastMessageID
+		hasMore = len(batch) == count
+	}
+
+	resp := &adminservice.GetAuditLogResponse{Records: records}
+	if hasMore {
+		resp.NextPageToken = []byte(strconv.FormatInt(lastMessageID, 10))
+	}
+	return resp, nil
+}
+
+func (adh *AdminHandler) isAuditLogStored() bool {
+	if adh.params.AuditConfig == nil {
+		return false
+	}
+	for _, sink := range adh.params.AuditConfig.Sinks {
+		if sink == audit.SinkPersistence {
+			return true
+		}
+	}
+	return false
+}
+
+func auditRecordMatches(
+	record *persistenceblobs.AuditRecord,
+	request *adminservice.GetAuditLogRequest,
+) bool {
+	return (request.GetNamespace() == "" || request.GetNamespace() == record.GetNamespace()) &&
+		(request.GetWorkflowId() == "" || request.GetWorkflowId() == record.GetWorkflowId()) &&
+		(request.GetApi() == "" || request.GetApi() == record.GetApi()) &&
+		(request.GetActor() == "" || request.GetActor() == record.GetActor())
+}
@@ -39,18 +39,26 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
 	"go.temporal.io/temporal-proto/serviceerror"
+	"go.temporal.io/temporal-proto/workflowservice"
+	sdkmocks "go.temporal.io/temporal/mocks"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	eventgenpb "github.com/temporalio/temporal/.gen/proto/event"
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/elasticsearch"
 	esmock "github.com/temporalio/temporal/common/elasticsearch/mocks"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/namespace"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/resource"
 	"github.com/temporalio/temporal/common/service/config"
@@ -62,10 +70,11 @@ type (
 		suite.Suite
 		*require.Assertions
 
-		controller         *gomock.Controller
-		mockResource       *resource.Test
-		mockHistoryClient  *historyservicemock.MockHistoryServiceClient
-		mockNamespaceCache *cache.MockNamespaceCache
+		controller           *gomock.Controller
+		mockResource         *resource.Test
+		mockHistoryClient    *historyservicemock.MockHistoryServiceClient
+		mockNamespaceCache   *cache.MockNamespaceCache
+		mockNamespaceHandler *namespace.MockHandler
 
 		mockHistoryV2Mgr *mocks.HistoryV2Manager
 
@@ -101,7 +110,8 @@ func (s *adminHandlerSuite) SetupTest() {
 	config := &Config{
 		EnableAdminProtection: dynamicconfig.GetBoolPropertyFn(false),
 	}
-	s.handler = NewAdminHandler(s.mockResource, params, config)
+	s.mockNamespaceHandler = namespace.NewMockHandler(s.controller)
+	s.handler = NewAdminHandler(s.mockResource, params, config, s.mockNamespaceHandler)
 	s.handler.Start()
 }
 
@@ -171,6 +181,28 @@ func (s *adminHandlerSuite) Test_GetWorkflowExecutionRawHistoryV2_FailedOnInvali
 	s.Error(err)
 }
 
+func (s *adminHandlerSuite) Test_GetReplicationStatus_FailedOnInvalidShardID() {
+	ctx := context.Background()
+	_, err := s.handler.GetReplicationStatus(ctx,
+		&adminservice.GetReplicationStatusRequest{
+			ShardIds: []int32{1},
+		})
+	s.Equal(errInvalidShardID, err)
+}
+
+func (s *adminHandlerSuite) Test_AddOrUpdateRemoteCluster_FailedOnCurrentCluster() {
+	ctx := context.Background()
+	s.mockResource.ClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	_, err := s.handler.AddOrUpdateRemoteCluster(ctx,
+		&adminservice.AddOrUpdateRemoteClusterRequest{
+			ClusterName:            cluster.TestCurrentClusterName,
+			RpcAddress:             "127.0.0.1:7933",
+			InitialFailoverVersion: 1,
+			Enabled:                true,
+		})
+	s.Equal(errRemoteClusterIsCurrentCluster, err)
+}
+
 func (s *adminHandlerSuite) Test_GetWorkflowExecutionRawHistoryV2_FailedOnInvalidRunID() {
 	ctx := context.Background()
 	_, err := s.handler.GetWorkflowExecutionRawHistoryV2(ctx,
@@ -603,3 +635,263 @@ func (s *adminHandlerSuite) Test_AddSearchAttribute_Permission() {
 		s.Nil(resp)
 	}
 }
+
+func (s *adminHandlerSuite) Test_ExportWorkflowExecution() {
+	ctx := context.Background()
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil).Times(2)
+	runID := uuid.New()
+	branchToken := []byte{1}
+	versionHistory := persistence.NewVersionHistory(branchToken, []*persistence.VersionHistoryItem{
+		persistence.NewVersionHistoryItem(int64(2), int64(100)),
+	})
+	versionHistories := persistence.NewVersionHistories(versionHistory).ToProto()
+	s.mockHistoryClient.EXPECT().GetMutableState(gomock.Any(), gomock.Any()).Return(&historyservice.GetMutableStateResponse{
+		Execution:          &commonpb.WorkflowExecution{WorkflowId: "workflowID", RunId: runID},
+		NextEventId:        3,
+		CurrentBranchToken: branchToken,
+		VersionHistories:   versionHistories,
+	}, nil).Times(1)
+	historyBatches := []*eventpb.History{
+		{Events: []*eventpb.HistoryEvent{{EventId: 1, Version: 100}}},
+		{Events: []*eventpb.HistoryEvent{{EventId: 2, Version: 100}}},
+	}
+	s.mockHistoryV2Mgr.On("ReadHistoryBranchByBatch", mock.Anything).Return(&persistence.ReadHistoryBranchByBatchResponse{
+		History:       historyBatches[:1],
+		NextPageToken: []byte{2},
+	}, nil).Once()
+	s.mockHistoryV2Mgr.On("ReadHistoryBranchByBatch", mock.Anything).Return(&persistence.ReadHistoryBranchByBatchResponse{
+		History:       historyBatches[1:],
+		NextPageToken: nil,
+	}, nil).Once()
+
+	resp, err := s.handler.ExportWorkflowExecution(ctx, &adminservice.ExportWorkflowExecutionRequest{
+		Namespace: s.namespace,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: "workflowID",
+		},
+		MaximumPageSize: 1,
+	})
+	s.NoError(err)
+	export := resp.GetExport()
+	s.Equal(int32(common.WorkflowExecutionHistoryExportFormatVersion), export.GetFormatVersion())
+	s.Equal(s.namespace, export.GetNamespace())
+	s.Equal(runID, export.GetExecution().GetRunId())
+	s.Equal(versionHistories, export.GetVersionHistories())
+	s.Equal(historyBatches[:1], export.GetHistoryBatches())
+	s.NotNil(resp.GetNextPageToken())
+
+	resp, err = s.handler.ExportWorkflowExecution(ctx, &adminservice.ExportWorkflowExecutionRequest{
+		Namespace: s.namespace,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: "workflowID",
+		},
+		MaximumPageSize: 1,
+		NextPageToken:   resp.GetNextPageToken(),
+	})
+	s.NoError(err)
+	export = resp.GetExport()
+	s.Equal(runID, export.GetExecution().GetRunId())
+	s.Nil(export.GetVersionHistories())
+	s.Equal(historyBatches[1:], export.GetHistoryBatches())
+	s.Nil(resp.GetNextPageToken())
+}
+
+func (s *adminHandlerSuite) Test_ResetWorkflowExecution_InvalidReapplyOptions() {
+	ctx := context.Background()
+	resetRequest := &workflowservice.ResetWorkflowExecutionRequest{
+		Namespace: s.namespace,
+		WorkflowExecution: &commonpb.WorkflowExecution{
+			WorkflowId: "workflowID",
+			RunId:      uuid.New(),
+		},
+		DecisionFinishEventId: 4,
+		RequestId:             uuid.New(),
+	}
+
+	_, err := s.handler.ResetWorkflowExecution(ctx, &adminservice.ResetWorkflowExecutionRequest{
+		ResetRequest:       resetRequest,
+		ReapplyType:        executiongenpb.ResetReapplyType_ResetReapplyType_None,
+		ReapplySignalNames: []string{"signal-name"},
+	})
+	s.Equal(errInvalidResetReapplyType, err)
+
+	_, err = s.handler.ResetWorkflowExecution(ctx, &adminservice.ResetWorkflowExecutionRequest{
+		ResetRequest: resetRequest,
+		ReapplyType:  executiongenpb.ResetReapplyType(100),
+	})
+	s.Equal(errInvalidResetReapplyType, err)
+}
+
+func (s *adminHandlerSuite) Test_ResetWorkflowExecution() {
+	ctx := context.Background()
+	resetRequest := &workflowservice.ResetWorkflowExecutionRequest{
+		Namespace: s.namespace,
+		WorkflowExecution: &commonpb.WorkflowExecution{
+			WorkflowId: "workflowID",
+			RunId:      uuid.New(),
+		},
+		RequestId: uuid.New(),
+	}
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil).Times(1)
+	s.mockHistoryClient.EXPECT().ResetWorkflowExecution(gomock.Any(), &historyservice.ResetWorkflowExecutionRequest{
+		NamespaceId:         s.namespaceID,
+		ResetRequest:        resetRequest,
+		ReapplyType:         executiongenpb.ResetReapplyType_ResetReapplyType_Signal,
+		ReapplySignalNames:  []string{"signal-name"},
+		ResetToLastRunStart: true,
+	}).Return(&historyservice.ResetWorkflowExecutionResponse{RunId: "new-run-id"}, nil).Times(1)
+
+	resp, err := s.handler.ResetWorkflowExecution(ctx, &adminservice.ResetWorkflowExecutionRequest{
+		ResetRequest:        resetRequest,
+		ReapplyType:         executiongenpb.ResetReapplyType_ResetReapplyType_Signal,
+		ReapplySignalNames:  []string{"signal-name"},
+		ResetToLastRunStart: true,
+	})
+	s.NoError(err)
+	s.Equal("new-run-id", resp.GetRunId())
+}
+
+func (s *adminHandlerSuite) Test_ImportWorkflowExecution_Validate() {
+	ctx := context.Background()
+	validExport := func() *eventgenpb.WorkflowExecutionHistoryExport {
+		return &eventgenpb.WorkflowExecutionHistoryExport{
+			FormatVersion: common.WorkflowExecutionHistoryExportFormatVersion,
+			Execution:     &commonpb.WorkflowExecution{WorkflowId: "workflowID", RunId: uuid.New()},
+			HistoryBatches: []*eventpb.History{
+				{Events: []*eventpb.HistoryEvent{{EventId: 1}}},
+			},
+		}
+	}
+
+	type test struct {
+		Name    string
+		Request *adminservice.ImportWorkflowExecutionRequest
+	}
+	unsupportedFormat := validExport()
+	unsupportedFormat.FormatVersion = common.WorkflowExecutionHistoryExportFormatVersion + 1
+	missingRunID := validExport()
+	missingRunID.Execution.RunId = ""
+	emptyHistory := validExport()
+	emptyHistory.HistoryBatches = nil
+	testCases := []test{
+		{
+			Name:    "nil request",
+			Request: nil,
+		},
+		{
+			Name:    "missing namespace",
+			Request: &adminservice.ImportWorkflowExecutionRequest{Export: validExport()},
+		},
+		{
+			Name:    "missing export",
+			Request: &adminservice.ImportWorkflowExecutionRequest{Namespace: s.namespace},
+		},
+		{
+			Name:    "empty history",
+			Request: &adminservice.ImportWorkflowExecutionRequest{Namespace: s.namespace, Export: emptyHistory},
+		},
+		{
+			Name:    "unsupported format",
+			Request: &adminservice.ImportWorkflowExecutionRequest{Namespace: s.namespace, Export: unsupportedFormat},
+		},
+		{
+			Name:    "missing run id",
+			Request: &adminservice.ImportWorkflowExecutionRequest{Namespace: s.namespace, Export: missingRunID},
+		},
+	}
+	for _, testCase := range testCases {
+		s.T().Run(testCase.Name, func(t *testing.T) {
+			resp, err := s.handler.ImportWorkflowExecution(ctx, testCase.Request)
+			s.IsType(&serviceerror.InvalidArgument{}, err)
+			s.Nil(resp)
+		})
+	}
+}
+
+func (s *adminHandlerSuite) Test_DeleteNamespace_Validate() {
+	resp, err := s.handler.DeleteNamespace(context.Background(), &adminservice.DeleteNamespaceRequest{})
+	s.Equal(errNamespaceNotSet, err)
+	s.Nil(resp)
+}
+
+func (s *adminHandlerSuite) Test_DeleteNamespace_HandlerFailure() {
+	request := &adminservice.DeleteNamespaceRequest{Namespace: s.namespace}
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil).Times(1)
+	s.mockNamespaceHandler.EXPECT().DeleteNamespace(gomock.Any(), request).Return(nil, serviceerror.NewInvalidArgument("test")).Times(1)
+
+	resp, err := s.handler.DeleteNamespace(context.Background(), request)
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+	s.Nil(resp)
+}
+
+func (s *adminHandlerSuite) Test_DeleteNamespace_Success() {
+	request := &adminservice.DeleteNamespaceRequest{Namespace: s.namespace}
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.namespace).Return(s.namespaceID, nil).Times(1)
+	s.mockNamespaceHandler.EXPECT().DeleteNamespace(gomock.Any(), request).Return(&adminservice.DeleteNamespaceResponse{}, nil).Times(1)
+
+	workflowRun := &sdkmocks.WorkflowRun{}
+	workflowRun.On("GetID").Return("deletion-workflow-id")
+	sdkClient := s.mockResource.SDKClient.(*sdkmocks.Client)
+	sdkClient.On("ExecuteWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(workflowRun, nil).Once()
+
+	resp, err := s.handler.DeleteNamespace(context.Background(), request)
+	s.NoError(err)
+	s.Equal("deletion-workflow-id", resp.GetWorkflowId())
+	sdkClient.AssertExpectations(s.T())
+}
+
+func (s *adminHandlerSuite) Test_UpdateDynamicConfig_FailedOnUnknownKey() {
+	ctx := context.Background()
+	_, err := s.handler.UpdateDynamicConfig(ctx,
+		&adminservice.UpdateDynamicConfigRequest{
+			Name:   "no.such.key",
+			Values: "- value: 1\n",
+		})
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
+
+func (s *adminHandlerSuite) Test_RollbackDynamicConfig() {
+	ctx := context.Background()
+	name := "frontend.rps"
+	s.mockResource.ClusterMetadataMgr.EXPECT().GetDynamicConfigHistory(&persistence.GetDynamicConfigHistoryRequest{
+		Name: name,
+	}).Return(&persistence.GetDynamicConfigHistoryResponse{
+		Revisions: []*persistenceblobs.DynamicConfigValue{
+			{Name: name, Revision: 2, Values: "- value: 2000\n"},
+			{Name: name, Revision: 1, Values: "- value: 1000\n"},
+		},
+	}, nil)
+	s.mockResource.ClusterMetadataMgr.EXPECT().UpdateDynamicConfig(&persistence.UpdateDynamicConfigRequest{
+		Name:      name,
+		Values:    "- value: 1000\n",
+		UpdatedBy: "tester",
+		Reason:    "revert",
+	}).Return(&persistence.UpdateDynamicConfigResponse{Revision: 3}, nil)
+
+	resp, err := s.handler.RollbackDynamicConfig(ctx,
+		&adminservice.RollbackDynamicConfigRequest{
+			Name:     name,
+			Revision: 1,
+			Reason:   "revert",
+			Identity: "tester",
+		})
+	s.NoError(err)
+	s.Equal(int64(3), resp.GetRevision())
+}
+
+func (s *adminHandlerSuite) Test_RollbackDynamicConfig_FailedOnUnknownRevision() {
+	ctx := context.Background()
+	name := "frontend.rps"
+	s.mockResource.ClusterMetadataMgr.EXPECT().GetDynamicConfigHistory(gomock.Any()).Return(&persistence.GetDynamicConfigHistoryResponse{
+		Revisions: []*persistenceblobs.DynamicConfigValue{
+			{Name: name, Revision: 1, Values: "- value: 1000\n"},
+		},
+	}, nil)
+
+	_, err := s.handler.RollbackDynamicConfig(ctx,
+		&adminservice.RollbackDynamicConfigRequest{
+			Name:     name,
+			Revision: 5,
+		})
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+}
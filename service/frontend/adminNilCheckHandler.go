@@ -197,3 +197,138 @@ func (adh *AdminNilCheckHandler) RefreshWorkflowTasks(ctx context.Context, reque
 	}
 	return resp, err
 }
+
+// ResetWorkflowExecution resets a workflow with the reset options which the workflow service request does not carry
+func (adh *AdminNilCheckHandler) ResetWorkflowExecution(ctx context.Context, request *adminservice.ResetWorkflowExecutionRequest) (*adminservice.ResetWorkflowExecutionResponse, error) {
+	resp, err := adh.parentHandler.ResetWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.ResetWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+// ExportWorkflowExecution returns the full history of a workflow run, including version histories, in a portable format.
+func (adh *AdminNilCheckHandler) ExportWorkflowExecution(ctx context.Context, request *adminservice.ExportWorkflowExecutionRequest) (*adminservice.ExportWorkflowExecutionResponse, error) {
+	resp, err := adh.parentHandler.ExportWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.ExportWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+// ImportWorkflowExecution recreates a workflow run from a previously exported history.
+func (adh *AdminNilCheckHandler) ImportWorkflowExecution(ctx context.Context, request *adminservice.ImportWorkflowExecutionRequest) (*adminservice.ImportWorkflowExecutionResponse, error) {
+	resp, err := adh.parentHandler.ImportWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.ImportWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+// DeleteNamespace marks a namespace as deleting and starts a system workflow that purges its data.
+func (adh *AdminNilCheckHandler) DeleteNamespace(ctx context.Context, request *adminservice.DeleteNamespaceRequest) (*adminservice.DeleteNamespaceResponse, error) {
+	resp, err := adh.parentHandler.DeleteNamespace(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.DeleteNamespaceResponse{}
+	}
+	return resp, err
+}
+
+// GetReplicationStatus returns the replication status of history shards against remote clusters.
+func (adh *AdminNilCheckHandler) GetReplicationStatus(ctx context.Context, request *adminservice.GetReplicationStatusRequest) (*adminservice.GetReplicationStatusResponse, error) {
+	resp, err := adh.parentHandler.GetReplicationStatus(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.GetReplicationStatusResponse{}
+	}
+	return resp, err
+}
+
+// AddOrUpdateRemoteCluster adds a remote cluster to the replication group or updates its connection information.
+func (adh *AdminNilCheckHandler) AddOrUpdateRemoteCluster(ctx context.Context, request *adminservice.AddOrUpdateRemoteClusterRequest) (*adminservice.AddOrUpdateRemoteClusterResponse, error) {
+	resp, err := adh.parentHandler.AddOrUpdateRemoteCluster(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.AddOrUpdateRemoteClusterResponse{}
+	}
+	return resp, err
+}
+
+// RemoveRemoteCluster removes a remote cluster from the replication group.
+func (adh *AdminNilCheckHandler) RemoveRemoteCluster(ctx context.Context, request *adminservice.RemoveRemoteClusterRequest) (*adminservice.RemoveRemoteClusterResponse, error) {
+	resp, err := adh.parentHandler.RemoveRemoteCluster(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.RemoveRemoteClusterResponse{}
+	}
+	return resp, err
+}
+
+// SetShardOwner pins a shard to a history host, or removes the pin.
+func (adh *AdminNilCheckHandler) SetShardOwner(ctx context.Context, request *adminservice.SetShardOwnerRequest) (*adminservice.SetShardOwnerResponse, error) {
+	resp, err := adh.parentHandler.SetShardOwner(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.SetShardOwnerResponse{}
+	}
+	return resp, err
+}
+
+// DrainHost gracefully moves a history or matching host out of the membership ring without stopping it, or puts it back.
+func (adh *AdminNilCheckHandler) DrainHost(ctx context.Context, request *adminservice.DrainHostRequest) (*adminservice.DrainHostResponse, error) {
+	resp, err := adh.parentHandler.DrainHost(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.DrainHostResponse{}
+	}
+	return resp, err
+}
+
+// GetDynamicConfig returns the dynamic config values changed through the admin API.
+func (adh *AdminNilCheckHandler) GetDynamicConfig(ctx context.Context, request *adminservice.GetDynamicConfigRequest) (*adminservice.GetDynamicConfigResponse, error) {
+	resp, err := adh.parentHandler.GetDynamicConfig(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.GetDynamicConfigResponse{}
+	}
+	return resp, err
+}
+
+// UpdateDynamicConfig sets the values of a dynamic config key cluster wide.
+func (adh *AdminNilCheckHandler) UpdateDynamicConfig(ctx context.Context, request *adminservice.UpdateDynamicConfigRequest) (*adminservice.UpdateDynamicConfigResponse, error) {
+	resp, err := adh.parentHandler.UpdateDynamicConfig(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.UpdateDynamicConfigResponse{}
+	}
+	return resp, err
+}
+
+// DeleteDynamicConfig removes the values of a dynamic config key so the config file value applies again.
+func (adh *AdminNilCheckHandler) DeleteDynamicConfig(ctx context.Context, request *adminservice.DeleteDynamicConfigRequest) (*adminservice.DeleteDynamicConfigResponse, error) {
+	resp, err := adh.parentHandler.DeleteDynamicConfig(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.DeleteDynamicConfigResponse{}
+	}
+	return resp, err
+}
+
+// GetDynamicConfigHistory returns all revisions of a dynamic config key.
+func (adh *AdminNilCheckHandler) GetDynamicConfigHistory(ctx context.Context, request *adminservice.GetDynamicConfigHistoryRequest) (*adminservice.GetDynamicConfigHistoryResponse, error) {
+	resp, err := adh.parentHandler.GetDynamicConfigHistory(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.GetDynamicConfigHistoryResponse{}
+	}
+	return resp, err
+}
+
+// RollbackDynamicConfig restores the values of a dynamic config key from a previous revision.
+func (adh *AdminNilCheckHandler) RollbackDynamicConfig(ctx context.Context, request *adminservice.RollbackDynamicConfigRequest) (*adminservice.RollbackDynamicConfigResponse, error) {
+	resp, err := adh.parentHandler.RollbackDynamicConfig(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.RollbackDynamicConfigResponse{}
+	}
+	return resp, err
+}
+
+// GetAuditLog returns the audit records of mutating frontend API calls, oldest first.
+func (adh *AdminNilCheckHandler) GetAuditLog(ctx context.Context, request *adminservice.GetAuditLogRequest) (*adminservice.GetAuditLogResponse, error) {
+	resp, err := adh.parentHandler.GetAuditLog(ctx, request)
+	if resp == nil && err == nil {
+		resp = &adminservice.GetAuditLogResponse{}
+	}
+	return resp, err
+}
@@ -0,0 +1,225 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gogo/protobuf/types"
+	commonpb "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common/audit"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+)
+
+const (
+	workflowServiceAuditPrefix = "WorkflowService."
+	adminServiceAuditPrefix    = "AdminService."
+)
+
+type (
+	// AuditInterceptor writes an audit record for every mutating workflow service and admin service call
+	AuditInterceptor struct {
+		sinks  []audit.Sink
+		logger log.Logger
+	}
+
+	identityGetter interface {
+		GetIdentity() string
+	}
+
+	reasonGetter interface {
+		GetReason() string
+	}
+
+	requestIDGetter interface {
+		GetRequestId() string
+	}
+
+	workflowIDGetter interface {
+		GetWorkflowId() string
+	}
+
+	runIDGetter interface {
+		GetRunId() string
+	}
+
+	executionGetter interface {
+		GetExecution() *commonpb.WorkflowExecution
+	}
+
+	workflowExecutionGetter interface {
+		GetWorkflowExecution() *commonpb.WorkflowExecution
+	}
+)
+
+var (
+	auditedWorkflowServiceAPIs = map[string]struct{}{
+		"RegisterNamespace":                {},
+		"UpdateNamespace":                  {},
+		"DeprecateNamespace":               {},
+		"StartWorkflowExecution":           {},
+		"SignalWorkflowExecution":          {},
+		"SignalWithStartWorkflowExecution": {},
+		"RequestCancelWorkflowExecution":   {},
+		"ResetWorkflowExecution":           {},
+		"TerminateWorkflowExecution":       {},
+	}
+
+	auditedAdminServiceAPIs = map[string]struct{}{
+		"CloseShard":               {},
+		"RemoveTask":               {},
+		"ReapplyEvents":            {},
+		"AddSearchAttribute":       {},
+		"PurgeDLQMessages":         {},
+		"MergeDLQMessages":         {},
+		"RefreshWorkflowTasks":     {},
+		"ResetWorkflowExecution":   {},
+		"ImportWorkflowExecution":  {},
+		"DeleteNamespace":          {},
+		"AddOrUpdateRemoteCluster": {},
+		"RemoveRemoteCluster":      {},
+		"SetShardOwner":            {},
+		"DrainHost":                {},
+		"UpdateDynamicConfig":      {},
+		"DeleteDynamicConfig":      {},
+		"RollbackDynamicConfig":    {},
+	}
+)
+
+// NewAuditInterceptor creates a new AuditInterceptor
+func NewAuditInterceptor(
+	sinks []audit.Sink,
+	logger log.Logger,
+) *AuditInterceptor {
+	return &AuditInterceptor{
+		sinks:  sinks,
+		logger: logger,
+	}
+}
+
+// Intercept is the grpc unary server interceptor
+func (i *AuditInterceptor) Intercept(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	api, ok := auditedAPI(info)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	startTime := types.TimestampNow()
+	resp, err := handler(ctx, req)
+
+	record := newAuditRecord(ctx, api, req, resp, err)
+	record.Time = startTime
+	for _, sink := range i.sinks {
+		if writeErr := sink.Write(record); writeErr != nil {
+			i.logger.Error("Failed to write audit record.", tag.AuditAPI(api), tag.Error(writeErr))
+		}
+	}
+	return resp, err
+}
+
+func auditedAPI(
+	info *grpc.UnaryServerInfo,
+) (string, bool) {
+	method := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+	switch info.Server.(type) {
+	case workflowservice.WorkflowServiceServer:
+		_, ok := auditedWorkflowServiceAPIs[method]
+		return workflowServiceAuditPrefix + method, ok
+	case adminservice.AdminServiceServer:
+		_, ok := auditedAdminServiceAPIs[method]
+		return adminServiceAuditPrefix + method, ok
+	default:
+		return "", false
+	}
+}
+
+func newAuditRecord(
+	ctx context.Context,
+	api string,
+	req interface{},
+	resp interface{},
+	err error,
+) *persistenceblobs.AuditRecord {
+
+	record := &persistenceblobs.AuditRecord{
+		Api:     api,
+		Outcome: audit.OutcomeSuccess,
+	}
+	if err != nil {
+		record.Outcome = audit.OutcomeFailure
+		record.Error = err.Error()
+	}
+
+	// the identity in the request is set by the client, so the actor is only taken from the
+	// verified client certificate of the caller
+	if tlsIdentity, _ := getTLSIdentity(ctx, nil); tlsIdentity != nil {
+		record.Actor = tlsIdentity.Subject
+	} else if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		record.Actor = p.Addr.String()
+	}
+	if r, ok := req.(identityGetter); ok {
+		record.Identity = r.GetIdentity()
+	}
+	if r, ok := req.(namespaceGetter); ok {
+		record.Namespace = r.GetNamespace()
+	}
+	if r, ok := req.(requestIDGetter); ok {
+		record.RequestId = r.GetRequestId()
+	}
+	if r, ok := req.(reasonGetter); ok {
+		record.Reason = audit.SanitizeReason(r.GetReason())
+	}
+
+	var execution *commonpb.WorkflowExecution
+	if r, ok := req.(executionGetter); ok {
+		execution = r.GetExecution()
+	} else if r, ok := req.(workflowExecutionGetter); ok {
+		execution = r.GetWorkflowExecution()
+	}
+	record.WorkflowId = execution.GetWorkflowId()
+	record.RunId = execution.GetRunId()
+	if r, ok := req.(workflowIDGetter); ok && record.WorkflowId == "" {
+		record.WorkflowId = r.GetWorkflowId()
+	}
+	// the run ID of a started workflow is only known from the response
+	if r, ok := resp.(runIDGetter); ok && record.RunId == "" {
+		record.RunId = r.GetRunId()
+	}
+	return record
+}
@@ -0,0 +1,176 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common/audit"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+)
+
+type (
+	auditInterceptorSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		sink        *testAuditSink
+		interceptor *AuditInterceptor
+	}
+
+	testAuditSink struct {
+		records []*persistenceblobs.AuditRecord
+	}
+)
+
+func TestAuditInterceptorSuite(t *testing.T) {
+	s := new(auditInterceptorSuite)
+	suite.Run(t, s)
+}
+
+func (s *auditInterceptorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.sink = &testAuditSink{}
+	s.interceptor = NewAuditInterceptor([]audit.Sink{s.sink}, loggerimpl.NewNopLogger())
+}
+
+func (s *auditInterceptorSuite) TestIntercept_MutatingAPI() {
+	info := &grpc.UnaryServerInfo{
+		Server:     NewWorkflowNilCheckHandler(nil),
+		FullMethod: "/workflowservice.WorkflowService/StartWorkflowExecution",
+	}
+	request := &workflowservice.StartWorkflowExecutionRequest{
+		Namespace:  "test-namespace",
+		WorkflowId: "test-workflow-id",
+		Identity:   "test-identity",
+		RequestId:  "test-request-id",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &workflowservice.StartWorkflowExecutionResponse{RunId: "test-run-id"}, nil
+	}
+
+	_, err := s.interceptor.Intercept(context.Background(), request, info, handler)
+	s.NoError(err)
+	s.Len(s.sink.records, 1)
+	record := s.sink.records[0]
+	s.NotNil(record.Time)
+	record.Time = nil
+	s.Equal(&persistenceblobs.AuditRecord{
+		Identity:   "test-identity",
+		Api:        "WorkflowService.StartWorkflowExecution",
+		Namespace:  "test-namespace",
+		WorkflowId: "test-workflow-id",
+		RunId:      "test-run-id",
+		RequestId:  "test-request-id",
+		Outcome:    audit.OutcomeSuccess,
+	}, record)
+}
+
+func (s *auditInterceptorSuite) TestIntercept_TLSIdentity() {
+	info := &grpc.UnaryServerInfo{
+		Server:     NewWorkflowNilCheckHandler(nil),
+		FullMethod: "/workflowservice.WorkflowService/SignalWorkflowExecution",
+	}
+	request := &workflowservice.SignalWorkflowExecutionRequest{
+		Namespace: "test-namespace",
+		Identity:  "spoofed-identity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+	}
+	verifiedChains := [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: "test-client"}}}}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: verifiedChains}},
+	})
+
+	_, err := s.interceptor.Intercept(ctx, request, info, handler)
+	s.NoError(err)
+	s.Len(s.sink.records, 1)
+	record := s.sink.records[0]
+	s.Equal("CN=test-client", record.Actor)
+	s.Equal("spoofed-identity", record.Identity)
+}
+
+func (s *auditInterceptorSuite) TestIntercept_Failure() {
+	info := &grpc.UnaryServerInfo{
+		Server:     NewWorkflowNilCheckHandler(nil),
+		FullMethod: "/workflowservice.WorkflowService/TerminateWorkflowExecution",
+	}
+	request := &workflowservice.TerminateWorkflowExecutionRequest{
+		Namespace: "test-namespace",
+		WorkflowExecution: &commonpb.WorkflowExecution{
+			WorkflowId: "test-workflow-id",
+			RunId:      "test-run-id",
+		},
+		Reason:   "bad\ninput",
+		Identity: "test-identity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, errors.New("some random error")
+	}
+
+	_, err := s.interceptor.Intercept(context.Background(), request, info, handler)
+	s.Error(err)
+	s.Len(s.sink.records, 1)
+	record := s.sink.records[0]
+	s.Equal(audit.OutcomeFailure, record.Outcome)
+	s.Equal("some random error", record.Error)
+	s.Equal("bad input", record.Reason)
+	s.Equal("test-workflow-id", record.WorkflowId)
+	s.Equal("test-run-id", record.RunId)
+}
+
+func (s *auditInterceptorSuite) TestIntercept_ReadOnlyAPI() {
+	info := &grpc.UnaryServerInfo{
+		Server:     NewWorkflowNilCheckHandler(nil),
+		FullMethod: "/workflowservice.WorkflowService/DescribeWorkflowExecution",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &workflowservice.DescribeWorkflowExecutionResponse{}, nil
+	}
+
+	_, err := s.interceptor.Intercept(context.Background(), &workflowservice.DescribeWorkflowExecutionRequest{}, info, handler)
+	s.NoError(err)
+	s.Empty(s.sink.records)
+}
+
+func (s *testAuditSink) Write(record *persistenceblobs.AuditRecord) error {
+	s.records = append(s.records, record)
+	return nil
+}
@@ -0,0 +1,57 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/temporalio/temporal/common/blobstore"
+)
+
+// BlobReferenceInterceptor rejects the requests with payloads referencing offloaded blobs.
+// Blob references are only created by the history service when payloads are offloaded,
+// the blob URI of a payload sent by a caller cannot be trusted.
+type BlobReferenceInterceptor struct{}
+
+// NewBlobReferenceInterceptor creates a new BlobReferenceInterceptor
+func NewBlobReferenceInterceptor() *BlobReferenceInterceptor {
+	return &BlobReferenceInterceptor{}
+}
+
+// Intercept is the grpc unary server interceptor
+func (i *BlobReferenceInterceptor) Intercept(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	if blobstore.HasBlobReference(req) {
+		return nil, errPayloadBlobReference
+	}
+	return handler(ctx, req)
+}
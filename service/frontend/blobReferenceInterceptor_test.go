@@ -0,0 +1,77 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+
+	"github.com/temporalio/temporal/common/blobstore"
+)
+
+func TestBlobReferenceInterceptor(t *testing.T) {
+	interceptor := NewBlobReferenceInterceptor()
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+	}
+	newRequest := func(metadata map[string][]byte) *workflowservice.SignalWorkflowExecutionRequest {
+		return &workflowservice.SignalWorkflowExecutionRequest{
+			Namespace: "test-namespace",
+			Input: &commonpb.Payloads{Payloads: []*commonpb.Payload{{
+				Metadata: metadata,
+				Data:     []byte("data"),
+			}}},
+		}
+	}
+
+	_, err := interceptor.Intercept(
+		context.Background(),
+		newRequest(map[string][]byte{"encoding": []byte("json/plain")}),
+		&grpc.UnaryServerInfo{},
+		handler,
+	)
+	require.NoError(t, err)
+	require.True(t, called)
+
+	called = false
+	_, err = interceptor.Intercept(
+		context.Background(),
+		newRequest(map[string][]byte{
+			"encoding": []byte(blobstore.MetadataEncodingBlobReference),
+			"blobURI":  []byte("file:///etc/passwd"),
+		}),
+		&grpc.UnaryServerInfo{},
+		handler,
+	)
+	require.Equal(t, errPayloadBlobReference, err)
+	require.False(t, called)
+}
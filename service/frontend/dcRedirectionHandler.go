@@ -32,6 +32,7 @@ import (
 	"go.temporal.io/temporal-proto/workflowservice"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservice"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/metrics"
@@ -1190,6 +1191,23 @@ func (handler *DCRedirectionHandlerImpl) GetClusterInfo(
 	return handler.frontendHandler.GetClusterInfo(ctx, request)
 }
 
+// StreamWorkflowExecutionHistory API call
+func (handler *DCRedirectionHandlerImpl) StreamWorkflowExecutionHistory(
+	request *workflowstreamservice.StreamWorkflowExecutionHistoryRequest,
+	stream workflowstreamservice.WorkflowStreamService_StreamWorkflowExecutionHistoryServer,
+) (retError error) {
+
+	// the history is replicated to the standby clusters, so the stream is always served locally
+	var cluster = handler.currentClusterName
+
+	scope, startTime := handler.beforeCall(metrics.DCRedirectionStreamWorkflowExecutionHistoryScope)
+	defer func() {
+		handler.afterCall(scope, startTime, cluster, &retError)
+	}()
+
+	return handler.frontendHandler.StreamWorkflowExecutionHistory(request, stream)
+}
+
 func (handler *DCRedirectionHandlerImpl) beforeCall(
 	scope int,
 ) (metrics.Scope, time.Time) {
@@ -109,7 +109,7 @@ func (s *dcRedirectionHandlerSuite) SetupTest() {
 
 	s.config = NewConfig(dynamicconfig.NewCollection(dynamicconfig.NewNopClient(), s.mockResource.GetLogger()), 0, false)
 
-	frontendHandlerGRPC := NewWorkflowHandler(s.mockResource, s.config, nil)
+	frontendHandlerGRPC := NewWorkflowHandler(s.mockResource, s.config, NewNamespaceHandler(s.mockResource, s.config, nil))
 
 	s.mockFrontendHandler = workflowservicemock.NewMockWorkflowServiceServer(s.controller)
 	s.handler = NewDCRedirectionHandler(frontendHandlerGRPC, config.DCRedirectionPolicy{})
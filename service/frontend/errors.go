@@ -77,6 +77,29 @@ var (
 	errInvalidEventQueryRange                             = serviceerror.NewInvalidArgument("Invalid event query range.")
 	errUnknownValueType                                   = serviceerror.NewInvalidArgument("Unknown value type, %v.")
 	errDLQTypeIsNotSupported                              = serviceerror.NewInvalidArgument("The DLQ type is not supported.")
+	errHistoryExportNotSet                                = serviceerror.NewInvalidArgument("History export is not set on request.")
+	errUnsupportedHistoryExportFormat                     = serviceerror.NewInvalidArgument("History export format version %v is not supported.")
+	errInvalidCompletionCallbackURL                       = serviceerror.NewInvalidArgument("Invalid completion callback URL, only absolute http and https URLs are supported.")
+	errTooManyCompletionCallbacks                         = serviceerror.NewInvalidArgument("Number of completion callback URLs exceeds limit.")
+	errCompletionCallbackURLNotAllowed                    = serviceerror.NewInvalidArgument("Completion callback URL host is not in the allowed hosts list.")
+	errInvalidResetReapplyType                            = serviceerror.NewInvalidArgument("Invalid reset reapply type, it must be Signal or None, and signal names can only be given with Signal.")
+	errInvalidShardID                                     = serviceerror.NewInvalidArgument("Invalid shard ID.")
+	errInvalidRemoteCluster                               = serviceerror.NewInvalidArgument("Invalid remote cluster, it must be a known cluster other than the current one.")
+	errRemoteClusterIsCurrentCluster                      = serviceerror.NewInvalidArgument("Remote cluster cannot be the current cluster.")
+	errRPCAddressNotSet                                   = serviceerror.NewInvalidArgument("RPC address is not set on request.")
+	errRemoteClusterNotManaged                            = serviceerror.NewInvalidArgument("Remote cluster [%s] was not added by AddOrUpdateRemoteCluster, clusters from static config can only be disabled.")
+	errRemoteClusterInUse                                 = serviceerror.NewInvalidArgument("Remote cluster [%s] is still in the replication config of namespace [%s].")
+	errInvalidRemoteClusterInfo                           = serviceerror.NewInvalidArgument("Invalid remote cluster: %s.")
+	errHostAddressNotSet                                  = serviceerror.NewInvalidArgument("Host address is not set on request.")
+	errInvalidDrainServiceName                            = serviceerror.NewInvalidArgument("Only history and matching hosts can be drained.")
+	errDynamicConfigNameNotSet                            = serviceerror.NewInvalidArgument("Dynamic config key name is not set.")
+	errInvalidDynamicConfigValues                         = serviceerror.NewInvalidArgument("Invalid dynamic config values: %v.")
+	errDynamicConfigNotStored                             = serviceerror.NewInvalidArgument("Dynamic config key [%s] has no values set through the admin API.")
+	errDynamicConfigRevisionNotFound                      = serviceerror.NewInvalidArgument("Revision %v of dynamic config key [%s] is not found.")
+	errAuditLogNotStored                                  = serviceerror.NewInvalidArgument("Audit log is not stored, the persistence audit sink is not configured.")
+	errNamespaceDeleted                                   = serviceerror.NewInvalidArgument("Namespace is being deleted, pollers are not accepted.")
+	errPayloadBlobReference                               = serviceerror.NewInvalidArgument("Payloads must not reference offloaded blobs.")
+	errPayloadEncrypted                                   = serviceerror.NewInvalidArgument("Payloads must not be marked as encrypted by the server codec.")
 	errShuttingDown                                       = serviceerror.NewInternal("Shutting down")
 
 	errFailedUpdateDynamicConfig = serviceerror.NewInternal("Failed to update dynamic config, err: %v.")
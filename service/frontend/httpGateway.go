@@ -0,0 +1,517 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	commonpb "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/serviceerror"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/temporalio/temporal/common/codec"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+)
+
+const (
+	httpGatewayPathPrefix      = "/api/v1"
+	httpGatewayMaxBodySize     = 4 * 1024 * 1024
+	httpGatewayReadTimeout     = time.Minute
+	httpGatewayShutdownTimeout = 5 * time.Second
+	httpGatewayContentType     = "application/json"
+)
+
+type (
+	// HTTPGateway serves the workflow service RPCs as JSON over HTTP. The requests are decoded with jsonpb
+	// and go through the same interceptors and handler as the gRPC requests, so they are authorized,
+	// rate limited and measured in the same way.
+	HTTPGateway struct {
+		handler     workflowservice.WorkflowServiceServer
+		interceptor grpc.UnaryServerInterceptor
+		encoder     *codec.JSONPBEncoder
+		logger      log.Logger
+		server      *http.Server
+	}
+
+	httpRoute struct {
+		httpMethod string
+		// segments of the path after the prefix, the segments in braces are parameters
+		segments []string
+		rpc      string
+		// decode creates the request of the RPC from the path parameters, the query and the JSON body
+		decode func(params httpParams, decodeBody func(proto.Message) error) (interface{}, error)
+		invoke func(ctx context.Context, handler workflowservice.WorkflowServiceServer, req interface{}) (interface{}, error)
+	}
+
+	httpParams struct {
+		path  map[string]string
+		query url.Values
+	}
+)
+
+var (
+	errHTTPNotFound         = serviceerror.NewNotFound("No route matches the request path.")
+	errHTTPMethodNotAllowed = serviceerror.NewInvalidArgument("HTTP method is not allowed for the request path.")
+	errHTTPInvalidBody      = serviceerror.NewInvalidArgument("Request body is not valid JSON for the request.")
+	errHTTPInvalidPageToken = serviceerror.NewInvalidArgument("nextPageToken is not valid base64.")
+	errHTTPInvalidPageSize  = serviceerror.NewInvalidArgument("Page size is not a valid number.")
+
+	httpRoutes = []httpRoute{
+		{
+			httpMethod: http.MethodGet,
+			segments:   []string{"namespaces", "{namespace}"},
+			rpc:        "DescribeNamespace",
+			decode: func(params httpParams, decodeBody func(proto.Message) error) (interface{}, error) {
+				return &workflowservice.DescribeNamespaceRequest{Name: params.path["namespace"]}, nil
+			},
+			invoke: func(ctx context.Context, handler workflowservice.WorkflowServiceServer, req interface{}) (interface{}, error) {
+				return handler.DescribeNamespace(ctx, req.(*workflowservice.DescribeNamespaceRequest))
+			},
+		},
+		{
+			httpMethod: http.MethodGet,
+			segments:   []string{"namespaces", "{namespace}", "workflows"},
+			rpc:        "ListWorkflowExecutions",
+			decode: func(params httpParams, decodeBody func(proto.Message) error) (interface{}, error) {
+				pageSize, err := params.int32Query("pageSize")
+				if err != nil {
+					return nil, err
+				}
+				pageToken, err := params.pageTokenQuery()
+				if err != nil {
+					return nil, err
+				}
+				return &workflowservice.ListWorkflowExecutionsRequest{
+					Namespace:     params.path["namespace"],
+					PageSize:      pageSize,
+					NextPageToken: pageToken,
+					Query:         params.stringQuery("query"),
+				}, nil
+			},
+			invoke: func(ctx context.Context, handler workflowservice.WorkflowServiceServer, req interface{}) (interface{}, error) {
+				return handler.ListWorkflowExecutions(ctx, req.(*workflowservice.ListWorkflowExecutionsRequest))
+			},
+		},
+		{
+			httpMethod: http.MethodPost,
+			segments:   []string{"namespaces", "{namespace}", "workflows", "{workflowId}"},
+			rpc:        "StartWorkflowExecution",
+			decode: func(params httpParams, decodeBody func(proto.Message) error) (interface{}, error) {
+				req := &workflowservice.StartWorkflowExecutionRequest{}
+				if err := decodeBody(req); err != nil {
+					return nil, err
+				}
+				req.Namespace = params.path["namespace"]
+				req.WorkflowId = params.path["workflowId"]
+				return req, nil
+			},
+			invoke: func(ctx context.Context, handler workflowservice.WorkflowServiceServer, req interface{}) (interface{}, error) {
+				return handler.StartWorkflowExecution(ctx, req.(*workflowservice.StartWorkflowExecutionRequest))
+			},
+		},
+		{
+			httpMethod: http.MethodGet,
+			segments:   []string{"namespaces", "{namespace}", "workflows", "{workflowId}"},
+			rpc:        "DescribeWorkflowExecution",
+			decode: func(params httpParams, decodeBody func(proto.Message) error) (interface{}, error) {
+				return &workflowservice.DescribeWorkflowExecutionRequest{
+					Namespace: params.path["namespace"],
+					Execution: params.execution(),
+				}, nil
+			},
+			invoke: func(ctx context.Context, handler workflowservice.WorkflowServiceServer, req interface{}) (interface{}, error) {
+				return handler.DescribeWorkflowExecution(ctx, req.(*workflowservice.DescribeWorkflowExecutionRequest))
+			},
+		},
+		{
+			httpMethod: http.MethodGet,
+			segments:   []string{"namespaces", "{namespace}", "workflows", "{workflowId}", "history"},
+			rpc:        "GetWorkflowExecutionHistory",
+			decode: func(params httpParams, decodeBody func(proto.Message) error) (interface{}, error) {
+				pageSize, err := params.int32Query("maximumPageSize")
+				if err != nil {
+					return nil, err
+				}
+				pageToken, err := params.pageTokenQuery()
+				if err != nil {
+					return nil, err
+				}
+				return &workflowservice.GetWorkflowExecutionHistoryRequest{
+					Namespace:       params.path["namespace"],
+					Execution:       params.execution(),
+					MaximumPageSize: pageSize,
+					NextPageToken:   pageToken,
+				}, nil
+			},
+			invoke: func(ctx context.Context, handler workflowservice.WorkflowServiceServer, req interface{}) (interface{}, error) {
+				return handler.GetWorkflowExecutionHistory(ctx, req.(*workflowservice.GetWorkflowExecutionHistoryRequest))
+			},
+		},
+		{
+			httpMethod: http.MethodPost,
+			segments:   []string{"namespaces", "{namespace}", "workflows", "{workflowId}", "signal"},
+			rpc:        "SignalWorkflowExecution",
+			decode: func(params httpParams, decodeBody func(proto.Message) error) (interface{}, error) {
+				req := &workflowservice.SignalWorkflowExecutionRequest{}
+				if err := decodeBody(req); err != nil {
+					return nil, err
+				}
+				req.Namespace = params.path["namespace"]
+				req.WorkflowExecution = params.execution()
+				return req, nil
+			},
+			invoke: func(ctx context.Context, handler workflowservice.WorkflowServiceServer, req interface{}) (interface{}, error) {
+				return handler.SignalWorkflowExecution(ctx, req.(*workflowservice.SignalWorkflowExecutionRequest))
+			},
+		},
+		{
+			httpMethod: http.MethodPost,
+			segments:   []string{"namespaces", "{namespace}", "workflows", "{workflowId}", "signal-with-start"},
+			rpc:        "SignalWithStartWorkflowExecution",
+			decode: func(params httpParams, decodeBody func(proto.Message) error) (interface{}, error) {
+				req := &workflowservice.SignalWithStartWorkflowExecutionRequest{}
+				if err := decodeBody(req); err != nil {
+					return nil, err
+				}
+				req.Namespace = params.path["namespace"]
+				req.WorkflowId = params.path["workflowId"]
+				return req, nil
+			},
+			invoke: func(ctx context.Context, handler workflowservice.WorkflowServiceServer, req interface{}) (interface{}, error) {
+				return handler.SignalWithStartWorkflowExecution(ctx, req.(*workflowservice.SignalWithStartWorkflowExecutionRequest))
+			},
+		},
+		{
+			httpMethod: http.MethodPost,
+			segments:   []string{"namespaces", "{namespace}", "workflows", "{workflowId}", "query"},
+			rpc:        "QueryWorkflow",
+			decode: func(params httpParams, decodeBody func(proto.Message) error) (interface{}, error) {
+				req := &workflowservice.QueryWorkflowRequest{}
+				if err := decodeBody(req); err != nil {
+					return nil, err
+				}
+				req.Namespace = params.path["namespace"]
+				req.Execution = params.execution()
+				return req, nil
+			},
+			invoke: func(ctx context.Context, handler workflowservice.WorkflowServiceServer, req interface{}) (interface{}, error) {
+				return handler.QueryWorkflow(ctx, req.(*workflowservice.QueryWorkflowRequest))
+			},
+		},
+		{
+			httpMethod: http.MethodPost,
+			segments:   []string{"namespaces", "{namespace}", "workflows", "{workflowId}", "cancel"},
+			rpc:        "RequestCancelWorkflowExecution",
+			decode: func(params httpParams, decodeBody func(proto.Message) error) (interface{}, error) {
+				req := &workflowservice.RequestCancelWorkflowExecutionRequest{}
+				if err := decodeBody(req); err != nil {
+					return nil, err
+				}
+				req.Namespace = params.path["namespace"]
+				req.WorkflowExecution = params.execution()
+				return req, nil
+			},
+			invoke: func(ctx context.Context, handler workflowservice.WorkflowServiceServer, req interface{}) (interface{}, error) {
+				return handler.RequestCancelWorkflowExecution(ctx, req.(*workflowservice.RequestCancelWorkflowExecutionRequest))
+			},
+		},
+		{
+			httpMethod: http.MethodPost,
+			segments:   []string{"namespaces", "{namespace}", "workflows", "{workflowId}", "terminate"},
+			rpc:        "TerminateWorkflowExecution",
+			decode: func(params httpParams, decodeBody func(proto.Message) error) (interface{}, error) {
+				req := &workflowservice.TerminateWorkflowExecutionRequest{}
+				if err := decodeBody(req); err != nil {
+					return nil, err
+				}
+				req.Namespace = params.path["namespace"]
+				req.WorkflowExecution = params.execution()
+				return req, nil
+			},
+			invoke: func(ctx context.Context, handler workflowservice.WorkflowServiceServer, req interface{}) (interface{}, error) {
+				return handler.TerminateWorkflowExecution(ctx, req.(*workflowservice.TerminateWorkflowExecutionRequest))
+			},
+		},
+	}
+)
+
+// NewHTTPGateway creates a new HTTPGateway, the handler is called through the chain of interceptors
+func NewHTTPGateway(
+	handler workflowservice.WorkflowServiceServer,
+	interceptors []grpc.UnaryServerInterceptor,
+	logger log.Logger,
+) *HTTPGateway {
+
+	gateway := &HTTPGateway{
+		handler:     handler,
+		interceptor: chainUnaryServerInterceptors(interceptors),
+		encoder:     codec.NewJSONPBEncoder(),
+		logger:      logger,
+	}
+	gateway.server = &http.Server{
+		Handler:     gateway,
+		ReadTimeout: httpGatewayReadTimeout,
+	}
+	return gateway
+}
+
+// Serve accepts HTTP requests on the listener until the gateway is stopped
+func (g *HTTPGateway) Serve(listener net.Listener) {
+	g.logger.Info("Starting to serve on frontend HTTP listener", tag.Address(listener.Addr().String()))
+	if err := g.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		g.logger.Fatal("Failed to serve on frontend HTTP listener", tag.Error(err))
+	}
+}
+
+// Stop stops accepting new requests and waits for a short while for the in flight requests
+func (g *HTTPGateway) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), httpGatewayShutdownTimeout)
+	defer cancel()
+	if err := g.server.Shutdown(ctx); err != nil {
+		g.logger.Warn("Failed to stop frontend HTTP listener gracefully", tag.Error(err))
+	}
+}
+
+// ServeHTTP decodes the request of the RPC matching the request path and writes its JSON response
+func (g *HTTPGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route, params, err := matchHTTPRoute(r.Method, r.URL.Path)
+	if err != nil {
+		g.writeError(w, err)
+		return
+	}
+	params.query = r.URL.Query()
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, httpGatewayMaxBodySize))
+	if err != nil {
+		g.writeError(w, errHTTPInvalidBody)
+		return
+	}
+	req, err := route.decode(params, func(pb proto.Message) error {
+		if len(body) == 0 {
+			return nil
+		}
+		if err := g.encoder.Decode(body, pb); err != nil {
+			return errHTTPInvalidBody
+		}
+		return nil
+	})
+	if err != nil {
+		g.writeError(w, err)
+		return
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     g.handler,
+		FullMethod: "/workflowservice.WorkflowService/" + route.rpc,
+	}
+	resp, err := g.interceptor(newHTTPGatewayContext(r), req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return route.invoke(ctx, g.handler, req)
+	})
+	if err != nil {
+		g.writeError(w, err)
+		return
+	}
+
+	data, err := g.encoder.Encode(resp.(proto.Message))
+	if err != nil {
+		g.writeError(w, serviceerror.NewInternal(err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", httpGatewayContentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// writeError writes the gRPC status of the error, its code is mapped to the closest HTTP status code
+func (g *HTTPGateway) writeError(w http.ResponseWriter, err error) {
+	st := serviceerror.ToStatus(err)
+	data, encodeErr := g.encoder.Encode(st.Proto())
+	if encodeErr != nil {
+		g.logger.Error("Failed to encode HTTP gateway error", tag.Error(encodeErr))
+		http.Error(w, st.Message(), httpStatusFromCode(st.Code()))
+		return
+	}
+	w.Header().Set("Content-Type", httpGatewayContentType)
+	w.WriteHeader(httpStatusFromCode(st.Code()))
+	_, _ = w.Write(data)
+}
+
+// newHTTPGatewayContext exposes the HTTP headers as gRPC metadata and the client address and certificates
+// as gRPC peer, like they are for the gRPC requests
+func newHTTPGatewayContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for name, values := range r.Header {
+		md.Append(strings.ToLower(name), values...)
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+
+	p := &peer.Peer{}
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		p.Addr = addr
+	}
+	if r.TLS != nil {
+		p.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+	}
+	return peer.NewContext(ctx, p)
+}
+
+func matchHTTPRoute(httpMethod string, path string) (*httpRoute, httpParams, error) {
+	if !strings.HasPrefix(path, httpGatewayPathPrefix+"/") {
+		return nil, httpParams{}, errHTTPNotFound
+	}
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(path, httpGatewayPathPrefix), "/"), "/")
+
+	pathMatched := false
+	for i := range httpRoutes {
+		route := &httpRoutes[i]
+		params, ok := route.match(segments)
+		if !ok {
+			continue
+		}
+		if route.httpMethod != httpMethod {
+			pathMatched = true
+			continue
+		}
+		return route, params, nil
+	}
+	if pathMatched {
+		return nil, httpParams{}, errHTTPMethodNotAllowed
+	}
+	return nil, httpParams{}, errHTTPNotFound
+}
+
+func (r *httpRoute) match(segments []string) (httpParams, bool) {
+	if len(segments) != len(r.segments) {
+		return httpParams{}, false
+	}
+	params := httpParams{path: make(map[string]string)}
+	for i, segment := range r.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if segments[i] == "" {
+				return httpParams{}, false
+			}
+			params.path[segment[1:len(segment)-1]] = segments[i]
+			continue
+		}
+		if segments[i] != segment {
+			return httpParams{}, false
+		}
+	}
+	return params, true
+}
+
+func (p httpParams) execution() *commonpb.WorkflowExecution {
+	return &commonpb.WorkflowExecution{
+		WorkflowId: p.path["workflowId"],
+		RunId:      p.stringQuery("runId"),
+	}
+}
+
+func (p httpParams) stringQuery(name string) string {
+	return p.query.Get(name)
+}
+
+func (p httpParams) int32Query(name string) (int32, error) {
+	value := p.stringQuery(name)
+	if value == "" {
+		return 0, nil
+	}
+	i, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, errHTTPInvalidPageSize
+	}
+	return int32(i), nil
+}
+
+func (p httpParams) pageTokenQuery() ([]byte, error) {
+	value := p.stringQuery("nextPageToken")
+	if value == "" {
+		return nil, nil
+	}
+	// the page tokens are encoded in base64 in the JSON responses
+	token, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errHTTPInvalidPageToken
+	}
+	return token, nil
+}
+
+// chainUnaryServerInterceptors chains the interceptors in the same order as grpc.ChainUnaryInterceptor
+func chainUnaryServerInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chained
+			chained = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+		return chained(ctx, req)
+	}
+}
+
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,273 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
+	"go.temporal.io/temporal-proto/serviceerror"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/temporalio/temporal/common/authorization"
+	"github.com/temporalio/temporal/common/codec"
+	"github.com/temporalio/temporal/common/log/loggerimpl"
+	"github.com/temporalio/temporal/common/payloadcodec"
+	"github.com/temporalio/temporal/common/payloads"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type (
+	httpGatewaySuite struct {
+		suite.Suite
+		*require.Assertions
+
+		handler *testHTTPGatewayHandler
+		methods []string
+		gateway *HTTPGateway
+		encoder *codec.JSONPBEncoder
+	}
+
+	testHTTPGatewayHandler struct {
+		workflowservice.WorkflowServiceServer
+
+		requests []interface{}
+		history  *eventpb.History
+		err      error
+	}
+)
+
+func TestHTTPGatewaySuite(t *testing.T) {
+	s := new(httpGatewaySuite)
+	suite.Run(t, s)
+}
+
+func (s *httpGatewaySuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.handler = &testHTTPGatewayHandler{}
+	s.methods = nil
+	recordMethod := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		s.methods = append(s.methods, info.FullMethod)
+		return handler(ctx, req)
+	}
+	s.gateway = NewHTTPGateway(s.handler, []grpc.UnaryServerInterceptor{recordMethod, interceptor}, loggerimpl.NewNopLogger())
+	s.encoder = codec.NewJSONPBEncoder()
+}
+
+func (s *httpGatewaySuite) TestStartWorkflowExecution() {
+	body, err := s.encoder.Encode(&workflowservice.StartWorkflowExecutionRequest{
+		WorkflowId: "ignored-workflow-id",
+		RequestId:  "request-id",
+	})
+	s.NoError(err)
+
+	recorder := s.serve(http.MethodPost, "/api/v1/namespaces/test-namespace/workflows/test-workflow-id", body)
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal(httpGatewayContentType, recorder.Header().Get("Content-Type"))
+	resp := &workflowservice.StartWorkflowExecutionResponse{}
+	s.NoError(s.encoder.Decode(recorder.Body.Bytes(), resp))
+	s.Equal("test-run-id", resp.GetRunId())
+
+	s.Equal([]string{"/workflowservice.WorkflowService/StartWorkflowExecution"}, s.methods)
+	s.Equal([]interface{}{&workflowservice.StartWorkflowExecutionRequest{
+		Namespace:  "test-namespace",
+		WorkflowId: "test-workflow-id",
+		RequestId:  "request-id",
+	}}, s.handler.requests)
+}
+
+func (s *httpGatewaySuite) TestDescribeWorkflowExecution() {
+	recorder := s.serve(http.MethodGet, "/api/v1/namespaces/test-namespace/workflows/test-workflow-id?runId=test-run-id", nil)
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal([]interface{}{&workflowservice.DescribeWorkflowExecutionRequest{
+		Namespace: "test-namespace",
+		Execution: &commonpb.WorkflowExecution{WorkflowId: "test-workflow-id", RunId: "test-run-id"},
+	}}, s.handler.requests)
+}
+
+func (s *httpGatewaySuite) TestListWorkflowExecutions() {
+	recorder := s.serve(http.MethodGet, "/api/v1/namespaces/test-namespace/workflows?query=WorkflowType%3D%27test%27&pageSize=10&nextPageToken=AQI%3D", nil)
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal([]interface{}{&workflowservice.ListWorkflowExecutionsRequest{
+		Namespace:     "test-namespace",
+		PageSize:      10,
+		NextPageToken: []byte{1, 2},
+		Query:         "WorkflowType='test'",
+	}}, s.handler.requests)
+}
+
+func (s *httpGatewaySuite) TestServiceError() {
+	s.handler.err = serviceerror.NewNotFound("workflow not found")
+
+	recorder := s.serve(http.MethodGet, "/api/v1/namespaces/test-namespace/workflows/test-workflow-id", nil)
+	s.Equal(http.StatusNotFound, recorder.Code)
+	s.Contains(recorder.Body.String(), "workflow not found")
+}
+
+func (s *httpGatewaySuite) TestInvalidRequests() {
+	recorder := s.serve(http.MethodGet, "/api/v1/namespaces/test-namespace/unknown", nil)
+	s.Equal(http.StatusNotFound, recorder.Code)
+
+	recorder = s.serve(http.MethodDelete, "/api/v1/namespaces/test-namespace/workflows/test-workflow-id", nil)
+	s.Equal(http.StatusBadRequest, recorder.Code)
+
+	recorder = s.serve(http.MethodPost, "/api/v1/namespaces/test-namespace/workflows/test-workflow-id/signal", []byte("{"))
+	s.Equal(http.StatusBadRequest, recorder.Code)
+
+	recorder = s.serve(http.MethodGet, "/api/v1/namespaces/test-namespace/workflows?pageSize=ten", nil)
+	s.Equal(http.StatusBadRequest, recorder.Code)
+
+	s.Empty(s.methods)
+	s.Empty(s.handler.requests)
+}
+
+func (s *httpGatewaySuite) TestHeadersAsMetadata() {
+	var md metadata.MD
+	s.gateway.interceptor = func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ = metadata.FromIncomingContext(ctx)
+		return handler(ctx, req)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/test-namespace", nil)
+	request.Header.Set("Client-Name", "curl")
+	recorder := httptest.NewRecorder()
+	s.gateway.ServeHTTP(recorder, request)
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal([]string{"curl"}, md.Get("client-name"))
+}
+
+func (s *httpGatewaySuite) TestPayloadCodec() {
+	payloadCodec, err := payloadcodec.NewAESGCMCodec(&payloadcodec.Keyring{
+		Keys: map[string]string{
+			"key-1": base64.StdEncoding.EncodeToString([]byte("0123456789abcdef")),
+		},
+		DefaultKeyID: "key-1",
+	})
+	s.NoError(err)
+	payloadCodecInterceptor := NewPayloadCodecInterceptor(payloadCodec, authorization.NewNopAuthorizer(), nil, nil, []config.PayloadCodecInternalCaller{{
+		Subject: "CN=temporal-worker",
+		Issuer:  "CN=temporal-internode-ca",
+	}})
+	s.gateway = NewHTTPGateway(s.handler, []grpc.UnaryServerInterceptor{interceptor, payloadCodecInterceptor.Intercept}, loggerimpl.NewNopLogger())
+
+	body, err := s.encoder.Encode(&workflowservice.StartWorkflowExecutionRequest{
+		Input: payloads.EncodeString("workflow input"),
+	})
+	s.NoError(err)
+	recorder := s.serve(http.MethodPost, "/api/v1/namespaces/test-namespace/workflows/test-workflow-id", body)
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Len(s.handler.requests, 1)
+	input := s.handler.requests[0].(*workflowservice.StartWorkflowExecutionRequest).Input
+	s.True(payloadcodec.IsEncrypted(input.Payloads[0]))
+
+	s.handler.history = &eventpb.History{Events: []*eventpb.HistoryEvent{{
+		EventId: 1,
+		Attributes: &eventpb.HistoryEvent_WorkflowExecutionStartedEventAttributes{WorkflowExecutionStartedEventAttributes: &eventpb.WorkflowExecutionStartedEventAttributes{
+			Input: input,
+		}},
+	}}}
+	historyTarget := "/api/v1/namespaces/test-namespace/workflows/test-workflow-id/history"
+
+	// payloads stay encrypted for callers without the DecodePayloads permission
+	recorder = s.serve(http.MethodGet, historyTarget, nil)
+	s.Equal(http.StatusOK, recorder.Code)
+	resp := &workflowservice.GetWorkflowExecutionHistoryResponse{}
+	s.NoError(s.encoder.Decode(recorder.Body.Bytes(), resp))
+	s.True(payloadcodec.IsEncrypted(resp.History.Events[0].GetWorkflowExecutionStartedEventAttributes().Input.Payloads[0]))
+
+	// internal callers are identified by their client certificate
+	request := httptest.NewRequest(http.MethodGet, historyTarget, nil)
+	request.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{
+		{Subject: pkix.Name{CommonName: "temporal-worker"}, Issuer: pkix.Name{CommonName: "temporal-internode-ca"}},
+	}}}
+	recorder = httptest.NewRecorder()
+	s.gateway.ServeHTTP(recorder, request)
+	s.Equal(http.StatusOK, recorder.Code)
+	resp = &workflowservice.GetWorkflowExecutionHistoryResponse{}
+	s.NoError(s.encoder.Decode(recorder.Body.Bytes(), resp))
+	s.Equal(payloads.EncodeString("workflow input"), resp.History.Events[0].GetWorkflowExecutionStartedEventAttributes().Input)
+}
+
+func (s *httpGatewaySuite) serve(httpMethod string, target string, body []byte) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	s.gateway.ServeHTTP(recorder, httptest.NewRequest(httpMethod, target, bytes.NewReader(body)))
+	return recorder
+}
+
+func (h *testHTTPGatewayHandler) StartWorkflowExecution(
+	_ context.Context,
+	request *workflowservice.StartWorkflowExecutionRequest,
+) (*workflowservice.StartWorkflowExecutionResponse, error) {
+	h.requests = append(h.requests, request)
+	return &workflowservice.StartWorkflowExecutionResponse{RunId: "test-run-id"}, h.err
+}
+
+func (h *testHTTPGatewayHandler) DescribeWorkflowExecution(
+	_ context.Context,
+	request *workflowservice.DescribeWorkflowExecutionRequest,
+) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
+	h.requests = append(h.requests, request)
+	if h.err != nil {
+		return nil, h.err
+	}
+	return &workflowservice.DescribeWorkflowExecutionResponse{}, nil
+}
+
+func (h *testHTTPGatewayHandler) ListWorkflowExecutions(
+	_ context.Context,
+	request *workflowservice.ListWorkflowExecutionsRequest,
+) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+	h.requests = append(h.requests, request)
+	return &workflowservice.ListWorkflowExecutionsResponse{}, h.err
+}
+
+func (h *testHTTPGatewayHandler) GetWorkflowExecutionHistory(
+	_ context.Context,
+	request *workflowservice.GetWorkflowExecutionHistoryRequest,
+) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+	h.requests = append(h.requests, request)
+	return &workflowservice.GetWorkflowExecutionHistoryResponse{History: h.history}, h.err
+}
+
+func (h *testHTTPGatewayHandler) DescribeNamespace(
+	_ context.Context,
+	request *workflowservice.DescribeNamespaceRequest,
+) (*workflowservice.DescribeNamespaceResponse, error) {
+	h.requests = append(h.requests, request)
+	return &workflowservice.DescribeNamespaceResponse{}, h.err
+}
@@ -29,6 +29,7 @@ package frontend
 import (
 	"go.temporal.io/temporal-proto/workflowservice"
 
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservice"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/resource"
 
@@ -39,6 +40,7 @@ type (
 	// Handler is interface wrapping frontend handler
 	Handler interface {
 		workflowservice.WorkflowServiceServer
+		workflowstreamservice.WorkflowStreamServiceServer
 		common.Daemon
 
 		// Health is the health check method for this rpc handler
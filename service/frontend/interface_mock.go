@@ -31,6 +31,7 @@ package frontend
 import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
+	workflowstreamservice "github.com/temporalio/temporal/.gen/proto/workflowstreamservice"
 	resource "github.com/temporalio/temporal/common/resource"
 	workflowservice "go.temporal.io/temporal-proto/workflowservice"
 	grpc_health_v1 "google.golang.org/grpc/health/grpc_health_v1"
@@ -630,6 +631,20 @@ func (mr *MockHandlerMockRecorder) ListTaskListPartitions(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskListPartitions", reflect.TypeOf((*MockHandler)(nil).ListTaskListPartitions), arg0, arg1)
 }
 
+// StreamWorkflowExecutionHistory mocks base method.
+func (m *MockHandler) StreamWorkflowExecutionHistory(arg0 *workflowstreamservice.StreamWorkflowExecutionHistoryRequest, arg1 workflowstreamservice.WorkflowStreamService_StreamWorkflowExecutionHistoryServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamWorkflowExecutionHistory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamWorkflowExecutionHistory indicates an expected call of StreamWorkflowExecutionHistory.
+func (mr *MockHandlerMockRecorder) StreamWorkflowExecutionHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamWorkflowExecutionHistory", reflect.TypeOf((*MockHandler)(nil).StreamWorkflowExecutionHistory), arg0, arg1)
+}
+
 // Start mocks base method.
 func (m *MockHandler) Start() {
 	m.ctrl.T.Helper()
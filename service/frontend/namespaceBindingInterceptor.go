@@ -0,0 +1,168 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/common/authorization"
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/rpc/encryption"
+)
+
+type (
+	// NamespaceBindingInterceptor restricts the callers verified by namespace client CAs to their namespaces.
+	// The namespace client CAs are trusted by the frontend server for all the callers, so these callers are
+	// rejected here for the requests of other namespaces and for the admin service, whatever the authorizer decides.
+	NamespaceBindingInterceptor struct {
+		tlsProvider       encryption.TLSConfigProvider
+		namespaceResolver *requestNamespaceResolver
+	}
+
+	namespaceBoundServerStream struct {
+		grpc.ServerStream
+
+		interceptor *NamespaceBindingInterceptor
+		server      interface{}
+		received    bool
+	}
+
+	namespaceNameGetter interface {
+		GetName() string
+	}
+)
+
+// NewNamespaceBindingInterceptor creates a new NamespaceBindingInterceptor
+func NewNamespaceBindingInterceptor(
+	tlsProvider encryption.TLSConfigProvider,
+	namespaceCache cache.NamespaceCache,
+) *NamespaceBindingInterceptor {
+	return &NamespaceBindingInterceptor{
+		tlsProvider:       tlsProvider,
+		namespaceResolver: newRequestNamespaceResolver(namespaceCache),
+	}
+}
+
+// Intercept is the grpc unary server interceptor
+func (i *NamespaceBindingInterceptor) Intercept(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	if err := i.checkNamespaceBinding(ctx, info.Server, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamIntercept is the grpc stream server interceptor, the binding is checked
+// when the request of the stream is received
+func (i *NamespaceBindingInterceptor) StreamIntercept(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+
+	return handler(srv, &namespaceBoundServerStream{
+		ServerStream: ss,
+		interceptor:  i,
+		server:       srv,
+	})
+}
+
+func (i *NamespaceBindingInterceptor) checkNamespaceBinding(
+	ctx context.Context,
+	server interface{},
+	req interface{},
+) error {
+
+	tlsIdentity, err := getTLSIdentity(ctx, i.tlsProvider)
+	if err != nil {
+		return err
+	}
+	if tlsIdentity == nil || len(tlsIdentity.Namespaces) == 0 {
+		return nil
+	}
+
+	if _, ok := server.(adminservice.AdminServiceServer); ok {
+		return errUnauthorized
+	}
+	switch req.(type) {
+	case *healthpb.HealthCheckRequest,
+		*workflowservice.GetClusterInfoRequest,
+		*workflowservice.GetSearchAttributesRequest:
+		// these requests expose no namespace data
+		return nil
+	}
+
+	var namespace string
+	if getter, ok := req.(namespaceNameGetter); ok {
+		namespace = getter.GetName()
+	} else if namespace, _, err = i.namespaceResolver.getNamespace(req); err != nil {
+		return err
+	}
+	if !isNamespaceBound(tlsIdentity, namespace) {
+		return errUnauthorized
+	}
+	return nil
+}
+
+// isNamespaceBound returns whether the caller may access the namespace, the callers verified by namespace client CAs
+// may only access the namespaces bound to these CAs
+func isNamespaceBound(
+	tlsIdentity *authorization.TLSIdentity,
+	namespace string,
+) bool {
+
+	if tlsIdentity == nil || len(tlsIdentity.Namespaces) == 0 {
+		return true
+	}
+	for _, boundNamespace := range tlsIdentity.Namespaces {
+		if boundNamespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// RecvMsg checks the binding on the first message, which is the request of server streams
+func (s *namespaceBoundServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.received {
+		return nil
+	}
+	s.received = true
+	return s.interceptor.checkNamespaceBinding(s.Context(), s.server, m)
+}
@@ -0,0 +1,200 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	tokengenpb "github.com/temporalio/temporal/.gen/proto/token"
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservice"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/cache"
+)
+
+type (
+	namespaceBindingInterceptorSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller         *gomock.Controller
+		mockNamespaceCache *cache.MockNamespaceCache
+
+		interceptor *NamespaceBindingInterceptor
+		boundCtx    context.Context
+	}
+)
+
+func TestNamespaceBindingInterceptorSuite(t *testing.T) {
+	s := new(namespaceBindingInterceptorSuite)
+	suite.Run(t, s)
+}
+
+func (s *namespaceBindingInterceptorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.controller = gomock.NewController(s.T())
+	s.mockNamespaceCache = cache.NewMockNamespaceCache(s.controller)
+
+	s.interceptor = NewNamespaceBindingInterceptor(
+		&testTLSConfigProvider{namespaces: []string{"test-namespace"}},
+		s.mockNamespaceCache,
+	)
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "tenant"}}
+	s.boundCtx = peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{cert}},
+		}},
+	})
+}
+
+func (s *namespaceBindingInterceptorSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *namespaceBindingInterceptorSuite) TestIntercept_NotNamespaceBound() {
+	called := false
+	_, err := s.interceptor.Intercept(
+		context.Background(),
+		&workflowservice.SignalWorkflowExecutionRequest{Namespace: "other-namespace"},
+		&grpc.UnaryServerInfo{Server: NewWorkflowNilCheckHandler(nil)},
+		s.handler(&called),
+	)
+	s.NoError(err)
+	s.True(called)
+}
+
+func (s *namespaceBindingInterceptorSuite) TestIntercept_BoundNamespace() {
+	called := false
+	_, err := s.interceptor.Intercept(
+		s.boundCtx,
+		&workflowservice.SignalWorkflowExecutionRequest{Namespace: "test-namespace"},
+		&grpc.UnaryServerInfo{Server: NewWorkflowNilCheckHandler(nil)},
+		s.handler(&called),
+	)
+	s.NoError(err)
+	s.True(called)
+
+	called = false
+	_, err = s.interceptor.Intercept(
+		s.boundCtx,
+		&workflowservice.DescribeNamespaceRequest{Name: "test-namespace"},
+		&grpc.UnaryServerInfo{Server: NewWorkflowNilCheckHandler(nil)},
+		s.handler(&called),
+	)
+	s.NoError(err)
+	s.True(called)
+}
+
+func (s *namespaceBindingInterceptorSuite) TestIntercept_OtherNamespace() {
+	called := false
+	_, err := s.interceptor.Intercept(
+		s.boundCtx,
+		&workflowservice.SignalWorkflowExecutionRequest{Namespace: "other-namespace"},
+		&grpc.UnaryServerInfo{Server: NewWorkflowNilCheckHandler(nil)},
+		s.handler(&called),
+	)
+	s.Equal(errUnauthorized, err)
+	s.False(called)
+}
+
+func (s *namespaceBindingInterceptorSuite) TestIntercept_TaskToken() {
+	taskToken, err := common.NewProtoTaskTokenSerializer().Serialize(&tokengenpb.Task{NamespaceId: "other-namespace-id"})
+	s.NoError(err)
+	s.mockNamespaceCache.EXPECT().GetNamespaceName("other-namespace-id").Return("other-namespace", nil)
+
+	called := false
+	_, err = s.interceptor.Intercept(
+		s.boundCtx,
+		&workflowservice.RespondActivityTaskCompletedRequest{TaskToken: taskToken},
+		&grpc.UnaryServerInfo{Server: NewWorkflowNilCheckHandler(nil)},
+		s.handler(&called),
+	)
+	s.Equal(errUnauthorized, err)
+	s.False(called)
+}
+
+func (s *namespaceBindingInterceptorSuite) TestIntercept_NotScopedToNamespace() {
+	called := false
+	_, err := s.interceptor.Intercept(
+		s.boundCtx,
+		&workflowservice.ListNamespacesRequest{},
+		&grpc.UnaryServerInfo{Server: NewWorkflowNilCheckHandler(nil)},
+		s.handler(&called),
+	)
+	s.Equal(errUnauthorized, err)
+	s.False(called)
+
+	_, err = s.interceptor.Intercept(
+		s.boundCtx,
+		&workflowservice.GetClusterInfoRequest{},
+		&grpc.UnaryServerInfo{Server: NewWorkflowNilCheckHandler(nil)},
+		s.handler(&called),
+	)
+	s.NoError(err)
+	s.True(called)
+}
+
+func (s *namespaceBindingInterceptorSuite) TestIntercept_AdminService() {
+	called := false
+	_, err := s.interceptor.Intercept(
+		s.boundCtx,
+		&adminservice.CloseShardRequest{},
+		&grpc.UnaryServerInfo{Server: NewAdminNilCheckHandler(nil)},
+		s.handler(&called),
+	)
+	s.Equal(errUnauthorized, err)
+	s.False(called)
+}
+
+func (s *namespaceBindingInterceptorSuite) TestStreamIntercept_OtherNamespace() {
+	err := s.interceptor.StreamIntercept(
+		NewWorkflowNilCheckHandler(nil),
+		&testPayloadCodecServerStream{ctx: s.boundCtx},
+		&grpc.StreamServerInfo{FullMethod: "/workflowstreamservice.WorkflowStreamService/StreamWorkflowExecutionHistory", IsServerStream: true},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			return stream.RecvMsg(&workflowstreamservice.StreamWorkflowExecutionHistoryRequest{Namespace: "other-namespace"})
+		},
+	)
+	s.Equal(errUnauthorized, err)
+}
+
+func (s *namespaceBindingInterceptorSuite) handler(called *bool) grpc.UnaryHandler {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		*called = true
+		return nil, nil
+	}
+}
@@ -0,0 +1,246 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+
+	commonpb "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/serviceerror"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+
+	"github.com/temporalio/temporal/common/authorization"
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/payload"
+	"github.com/temporalio/temporal/common/payloadcodec"
+	"github.com/temporalio/temporal/common/rpc/encryption"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type (
+	// PayloadCodecInterceptor encodes the payloads of workflow service requests and decodes the payloads
+	// of responses for internal callers and for callers with the DecodePayloads permission
+	PayloadCodecInterceptor struct {
+		codec             payloadcodec.PayloadCodec
+		authorizer        authorization.Authorizer
+		tlsProvider       encryption.TLSConfigProvider
+		namespaceResolver *requestNamespaceResolver
+		internalCallers   map[config.PayloadCodecInternalCaller]struct{}
+	}
+
+	payloadCodecServerStream struct {
+		grpc.ServerStream
+
+		interceptor *PayloadCodecInterceptor
+		received    bool
+		namespace   string
+		decode      bool
+	}
+)
+
+// NewPayloadCodecInterceptor creates a new PayloadCodecInterceptor, internalCallers are the client
+// certificates of the callers which get decoded payloads without the DecodePayloads permission
+func NewPayloadCodecInterceptor(
+	codec payloadcodec.PayloadCodec,
+	authorizer authorization.Authorizer,
+	tlsProvider encryption.TLSConfigProvider,
+	namespaceCache cache.NamespaceCache,
+	internalCallers []config.PayloadCodecInternalCaller,
+) *PayloadCodecInterceptor {
+	if authorizer == nil {
+		authorizer = authorization.NewNopAuthorizer()
+	}
+
+	internalCallerSet := make(map[config.PayloadCodecInternalCaller]struct{}, len(internalCallers))
+	for _, caller := range internalCallers {
+		internalCallerSet[caller] = struct{}{}
+	}
+	return &PayloadCodecInterceptor{
+		codec:             codec,
+		authorizer:        authorizer,
+		tlsProvider:       tlsProvider,
+		namespaceResolver: newRequestNamespaceResolver(namespaceCache),
+		internalCallers:   internalCallerSet,
+	}
+}
+
+// Intercept is the grpc unary server interceptor
+func (i *PayloadCodecInterceptor) Intercept(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	if _, ok := info.Server.(workflowservice.WorkflowServiceServer); !ok {
+		return handler(ctx, req)
+	}
+
+	namespace, scoped, err := i.namespaceResolver.getNamespace(req)
+	if err != nil {
+		return nil, err
+	}
+	if !scoped {
+		// requests which are not scoped to a namespace carry no payloads
+		return handler(ctx, req)
+	}
+	if err := i.encodeRequest(ctx, namespace, req); err != nil {
+		return nil, err
+	}
+
+	resp, err := handler(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	if !i.canDecode(ctx, namespace) {
+		// payloads stay encoded for callers which are not trusted
+		return resp, nil
+	}
+	if err := payloadcodec.DecodeMessage(i.codec, namespace, resp); err != nil {
+		return nil, serviceerror.NewInternal(err.Error())
+	}
+	return resp, nil
+}
+
+// StreamIntercept is the grpc stream server interceptor, the payloads of the request are encoded
+// and the payloads of the streamed responses are decoded like they are for unary calls
+func (i *PayloadCodecInterceptor) StreamIntercept(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+
+	return handler(srv, &payloadCodecServerStream{
+		ServerStream: ss,
+		interceptor:  i,
+	})
+}
+
+// canDecode returns true if the caller gets the decoded payloads of the namespace
+func (i *PayloadCodecInterceptor) canDecode(
+	ctx context.Context,
+	namespace string,
+) bool {
+
+	tlsIdentity, err := getTLSIdentity(ctx, i.tlsProvider)
+	if err != nil {
+		return false
+	}
+	if i.isInternalCaller(tlsIdentity) {
+		return true
+	}
+
+	result, err := i.authorizer.Authorize(ctx, &authorization.Attributes{
+		Namespace:   namespace,
+		Permission:  authorization.PermissionDecodePayloads,
+		TLSIdentity: tlsIdentity,
+	})
+	return err == nil && result.Decision == authorization.DecisionAllow
+}
+
+// encodeRequest encodes the payloads of the request. Payloads already marked as encrypted are rejected,
+// the codec would otherwise decrypt payloads forged by the caller, only internal callers such as
+// the frontend of another cluster forwarding the request may send payloads encrypted by the codec.
+func (i *PayloadCodecInterceptor) encodeRequest(
+	ctx context.Context,
+	namespace string,
+	req interface{},
+) error {
+
+	tlsIdentity, err := getTLSIdentity(ctx, i.tlsProvider)
+	if err != nil {
+		return err
+	}
+	internalCaller := i.isInternalCaller(tlsIdentity)
+	err = payload.Visit(req, func(p *commonpb.Payload) error {
+		if internalCaller && payloadcodec.IsEncrypted(p) {
+			return nil
+		}
+		return i.codec.Encode(namespace, p)
+	})
+	switch err {
+	case nil:
+		return nil
+	case payloadcodec.ErrEncryptedPayload:
+		return errPayloadEncrypted
+	default:
+		return serviceerror.NewInternal(err.Error())
+	}
+}
+
+// isInternalCaller returns true if both the subject and the issuer of the client certificate match an internal caller.
+// A namespace client CA can issue certificates with any subject and issuer, so the callers verified by one are never internal.
+func (i *PayloadCodecInterceptor) isInternalCaller(
+	tlsIdentity *authorization.TLSIdentity,
+) bool {
+
+	if tlsIdentity == nil || len(tlsIdentity.Namespaces) != 0 {
+		return false
+	}
+	_, ok := i.internalCallers[config.PayloadCodecInternalCaller{
+		Subject: tlsIdentity.Subject,
+		Issuer:  tlsIdentity.Issuer,
+	}]
+	return ok
+}
+
+// RecvMsg encodes the payloads of the request, which is the first message of server streams,
+// and decides if the payloads of the streamed responses are decoded
+func (s *payloadCodecServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.received {
+		return nil
+	}
+	s.received = true
+
+	namespace, scoped, err := s.interceptor.namespaceResolver.getNamespace(m)
+	if err != nil {
+		return err
+	}
+	if !scoped {
+		return nil
+	}
+	if err := s.interceptor.encodeRequest(s.Context(), namespace, m); err != nil {
+		return err
+	}
+	s.namespace = namespace
+	s.decode = s.interceptor.canDecode(s.Context(), namespace)
+	return nil
+}
+
+// SendMsg decodes the payloads of the response for the callers which get decoded payloads
+func (s *payloadCodecServerStream) SendMsg(m interface{}) error {
+	if s.decode {
+		if err := payloadcodec.DecodeMessage(s.interceptor.codec, s.namespace, m); err != nil {
+			return serviceerror.NewInternal(err.Error())
+		}
+	}
+	return s.ServerStream.SendMsg(m)
+}
@@ -0,0 +1,445 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	tokengenpb "github.com/temporalio/temporal/.gen/proto/token"
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservice"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/authorization"
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/payloadcodec"
+	"github.com/temporalio/temporal/common/payloads"
+	"github.com/temporalio/temporal/common/service/config"
+)
+
+type (
+	payloadCodecInterceptorSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller         *gomock.Controller
+		mockAuthorizer     *authorization.MockAuthorizer
+		mockNamespaceCache *cache.MockNamespaceCache
+
+		codec       payloadcodec.PayloadCodec
+		info        *grpc.UnaryServerInfo
+		interceptor *PayloadCodecInterceptor
+	}
+
+	testPayloadCodecServerStream struct {
+		grpc.ServerStream
+
+		ctx  context.Context
+		sent []interface{}
+	}
+)
+
+var (
+	testInternalCaller = config.PayloadCodecInternalCaller{
+		Subject: "CN=temporal-worker",
+		Issuer:  "CN=temporal-internode-ca",
+	}
+)
+
+func TestPayloadCodecInterceptorSuite(t *testing.T) {
+	s := new(payloadCodecInterceptorSuite)
+	suite.Run(t, s)
+}
+
+func (s *payloadCodecInterceptorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.controller = gomock.NewController(s.T())
+	s.mockAuthorizer = authorization.NewMockAuthorizer(s.controller)
+	s.mockNamespaceCache = cache.NewMockNamespaceCache(s.controller)
+
+	var err error
+	s.codec, err = payloadcodec.NewAESGCMCodec(&payloadcodec.Keyring{
+		Keys: map[string]string{
+			"key-1": base64.StdEncoding.EncodeToString([]byte("0123456789abcdef")),
+		},
+		Namespaces: map[string]string{
+			"test-namespace": "key-1",
+		},
+	})
+	s.NoError(err)
+
+	s.info = &grpc.UnaryServerInfo{Server: NewWorkflowNilCheckHandler(nil)}
+	s.interceptor = NewPayloadCodecInterceptor(s.codec, s.mockAuthorizer, nil, s.mockNamespaceCache, []config.PayloadCodecInternalCaller{testInternalCaller})
+}
+
+func (s *payloadCodecInterceptorSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func (s *payloadCodecInterceptorSuite) TestIntercept_AuthorizedCaller() {
+	request := &workflowservice.SignalWorkflowExecutionRequest{
+		Namespace: "test-namespace",
+		Input:     payloads.EncodeString("signal input"),
+	}
+	var stored *commonpb.Payloads
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		stored = req.(*workflowservice.SignalWorkflowExecutionRequest).Input
+		s.True(payloadcodec.IsEncrypted(stored.Payloads[0]))
+		return &workflowservice.QueryWorkflowResponse{QueryResult: stored}, nil
+	}
+	s.mockAuthorizer.EXPECT().Authorize(gomock.Any(), &authorization.Attributes{
+		Namespace:  "test-namespace",
+		Permission: authorization.PermissionDecodePayloads,
+	}).Return(authorization.Result{Decision: authorization.DecisionAllow}, nil)
+
+	resp, err := s.interceptor.Intercept(context.Background(), request, s.info, handler)
+	s.NoError(err)
+	s.Equal(payloads.EncodeString("signal input"), resp.(*workflowservice.QueryWorkflowResponse).QueryResult)
+}
+
+func (s *payloadCodecInterceptorSuite) TestIntercept_UnauthorizedCaller() {
+	request := &workflowservice.SignalWorkflowExecutionRequest{
+		Namespace: "test-namespace",
+		Input:     payloads.EncodeString("signal input"),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &workflowservice.QueryWorkflowResponse{
+			QueryResult: req.(*workflowservice.SignalWorkflowExecutionRequest).Input,
+		}, nil
+	}
+	s.mockAuthorizer.EXPECT().Authorize(gomock.Any(), gomock.Any()).
+		Return(authorization.Result{Decision: authorization.DecisionDeny}, nil)
+
+	resp, err := s.interceptor.Intercept(context.Background(), request, s.info, handler)
+	s.NoError(err)
+	s.True(payloadcodec.IsEncrypted(resp.(*workflowservice.QueryWorkflowResponse).QueryResult.Payloads[0]))
+}
+
+func (s *payloadCodecInterceptorSuite) TestIntercept_OtherNamespace() {
+	request := &workflowservice.SignalWorkflowExecutionRequest{
+		Namespace: "other-namespace",
+		Input:     payloads.EncodeString("signal input"),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		s.Equal(payloads.EncodeString("signal input"), req.(*workflowservice.SignalWorkflowExecutionRequest).Input)
+		return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+	}
+	s.mockAuthorizer.EXPECT().Authorize(gomock.Any(), gomock.Any()).
+		Return(authorization.Result{Decision: authorization.DecisionAllow}, nil)
+
+	_, err := s.interceptor.Intercept(context.Background(), request, s.info, handler)
+	s.NoError(err)
+}
+
+func (s *payloadCodecInterceptorSuite) TestIntercept_InternalCaller() {
+	request := &workflowservice.SignalWorkflowExecutionRequest{
+		Namespace: "test-namespace",
+		Input:     payloads.EncodeString("signal input"),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &workflowservice.QueryWorkflowResponse{
+			QueryResult: req.(*workflowservice.SignalWorkflowExecutionRequest).Input,
+		}, nil
+	}
+
+	resp, err := s.interceptor.Intercept(s.newCallerContext("temporal-worker", "temporal-internode-ca"), request, s.info, handler)
+	s.NoError(err)
+	s.Equal(payloads.EncodeString("signal input"), resp.(*workflowservice.QueryWorkflowResponse).QueryResult)
+}
+
+func (s *payloadCodecInterceptorSuite) TestIntercept_InternalSubjectOtherIssuer() {
+	request := &workflowservice.SignalWorkflowExecutionRequest{
+		Namespace: "test-namespace",
+		Input:     payloads.EncodeString("signal input"),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &workflowservice.QueryWorkflowResponse{
+			QueryResult: req.(*workflowservice.SignalWorkflowExecutionRequest).Input,
+		}, nil
+	}
+	s.mockAuthorizer.EXPECT().Authorize(gomock.Any(), gomock.Any()).
+		Return(authorization.Result{Decision: authorization.DecisionDeny}, nil)
+
+	resp, err := s.interceptor.Intercept(s.newCallerContext("temporal-worker", "other-ca"), request, s.info, handler)
+	s.NoError(err)
+	s.True(payloadcodec.IsEncrypted(resp.(*workflowservice.QueryWorkflowResponse).QueryResult.Payloads[0]))
+}
+
+func (s *payloadCodecInterceptorSuite) TestIntercept_EncryptedPayload() {
+	input := payloads.EncodeString("signal input")
+	s.NoError(payloadcodec.EncodeMessage(s.codec, "test-namespace", input))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		s.Equal(input, req.(*workflowservice.SignalWorkflowExecutionRequest).Input)
+		return &workflowservice.SignalWorkflowExecutionResponse{}, nil
+	}
+
+	_, err := s.interceptor.Intercept(
+		s.newCallerContext("other", "other-ca"),
+		&workflowservice.SignalWorkflowExecutionRequest{Namespace: "test-namespace", Input: input},
+		s.info,
+		handler,
+	)
+	s.Equal(errPayloadEncrypted, err)
+
+	// payloads encrypted by the codec are forwarded by the frontend of another cluster
+	_, err = s.interceptor.Intercept(
+		s.newCallerContext("temporal-worker", "temporal-internode-ca"),
+		&workflowservice.SignalWorkflowExecutionRequest{Namespace: "test-namespace", Input: input},
+		s.info,
+		handler,
+	)
+	s.NoError(err)
+}
+
+func (s *payloadCodecInterceptorSuite) TestIntercept_NamespaceBoundCallerWithInternalSubject() {
+	interceptor := NewPayloadCodecInterceptor(
+		s.codec,
+		s.mockAuthorizer,
+		&testTLSConfigProvider{namespaces: []string{"test-namespace"}},
+		s.mockNamespaceCache,
+		[]config.PayloadCodecInternalCaller{testInternalCaller},
+	)
+	request := &workflowservice.SignalWorkflowExecutionRequest{
+		Namespace: "test-namespace",
+		Input:     payloads.EncodeString("signal input"),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &workflowservice.QueryWorkflowResponse{
+			QueryResult: req.(*workflowservice.SignalWorkflowExecutionRequest).Input,
+		}, nil
+	}
+	// the subject is the one of an internal caller, but the certificate is verified by a namespace client CA
+	s.mockAuthorizer.EXPECT().Authorize(gomock.Any(), gomock.Any()).
+		Return(authorization.Result{Decision: authorization.DecisionDeny}, nil)
+
+	resp, err := interceptor.Intercept(s.newCallerContext("temporal-worker", "temporal-internode-ca"), request, s.info, handler)
+	s.NoError(err)
+	s.True(payloadcodec.IsEncrypted(resp.(*workflowservice.QueryWorkflowResponse).QueryResult.Payloads[0]))
+}
+
+func (s *payloadCodecInterceptorSuite) TestIntercept_NopAuthorizer() {
+	interceptor := NewPayloadCodecInterceptor(s.codec, authorization.NewNopAuthorizer(), nil, s.mockNamespaceCache, nil)
+	request := &workflowservice.SignalWorkflowExecutionRequest{
+		Namespace: "test-namespace",
+		Input:     payloads.EncodeString("signal input"),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &workflowservice.QueryWorkflowResponse{
+			QueryResult: req.(*workflowservice.SignalWorkflowExecutionRequest).Input,
+		}, nil
+	}
+
+	resp, err := interceptor.Intercept(s.newCallerContext("other", "other-ca"), request, s.info, handler)
+	s.NoError(err)
+	s.True(payloadcodec.IsEncrypted(resp.(*workflowservice.QueryWorkflowResponse).QueryResult.Payloads[0]))
+}
+
+func (s *payloadCodecInterceptorSuite) TestIntercept_TaskToken() {
+	taskToken, err := common.NewProtoTaskTokenSerializer().Serialize(&tokengenpb.Task{NamespaceId: "test-namespace-id"})
+	s.NoError(err)
+	request := &workflowservice.RespondActivityTaskCompletedRequest{
+		TaskToken: taskToken,
+		Result:    payloads.EncodeString("activity result"),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		stored := req.(*workflowservice.RespondActivityTaskCompletedRequest).Result
+		s.True(payloadcodec.IsEncrypted(stored.Payloads[0]))
+		s.NoError(payloadcodec.DecodeMessage(s.codec, "test-namespace", req))
+		s.Equal(payloads.EncodeString("activity result"), req.(*workflowservice.RespondActivityTaskCompletedRequest).Result)
+		return &workflowservice.RespondActivityTaskCompletedResponse{}, nil
+	}
+	s.mockNamespaceCache.EXPECT().GetNamespaceName("test-namespace-id").Return("test-namespace", nil)
+	s.mockAuthorizer.EXPECT().Authorize(gomock.Any(), &authorization.Attributes{
+		Namespace:  "test-namespace",
+		Permission: authorization.PermissionDecodePayloads,
+	}).Return(authorization.Result{Decision: authorization.DecisionAllow}, nil)
+
+	_, err = s.interceptor.Intercept(context.Background(), request, s.info, handler)
+	s.NoError(err)
+}
+
+func (s *payloadCodecInterceptorSuite) TestIntercept_QueryTaskToken() {
+	taskToken, err := common.NewProtoTaskTokenSerializer().SerializeQueryTaskToken(&tokengenpb.QueryTask{NamespaceId: "test-namespace-id"})
+	s.NoError(err)
+	request := &workflowservice.RespondQueryTaskCompletedRequest{
+		TaskToken:   taskToken,
+		QueryResult: payloads.EncodeString("query result"),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		s.True(payloadcodec.IsEncrypted(req.(*workflowservice.RespondQueryTaskCompletedRequest).QueryResult.Payloads[0]))
+		return &workflowservice.RespondQueryTaskCompletedResponse{}, nil
+	}
+	s.mockNamespaceCache.EXPECT().GetNamespaceName("test-namespace-id").Return("test-namespace", nil)
+	s.mockAuthorizer.EXPECT().Authorize(gomock.Any(), gomock.Any()).
+		Return(authorization.Result{Decision: authorization.DecisionDeny}, nil)
+
+	_, err = s.interceptor.Intercept(context.Background(), request, s.info, handler)
+	s.NoError(err)
+}
+
+func (s *payloadCodecInterceptorSuite) TestIntercept_TaskTokenWithoutNamespace() {
+	taskToken, err := common.NewProtoTaskTokenSerializer().Serialize(&tokengenpb.Task{WorkflowId: "test-workflow-id"})
+	s.NoError(err)
+	request := &workflowservice.RespondActivityTaskCompletedRequest{
+		TaskToken: taskToken,
+		Result:    payloads.EncodeString("activity result"),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		s.Fail("request must be rejected")
+		return nil, nil
+	}
+
+	_, err = s.interceptor.Intercept(context.Background(), request, s.info, handler)
+	s.Equal(errNamespaceNotSet, err)
+}
+
+func (s *payloadCodecInterceptorSuite) TestIntercept_InvalidTaskToken() {
+	request := &workflowservice.RecordActivityTaskHeartbeatRequest{
+		TaskToken: []byte("invalid"),
+		Details:   payloads.EncodeString("heartbeat details"),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		s.Fail("request must be rejected")
+		return nil, nil
+	}
+
+	_, err := s.interceptor.Intercept(context.Background(), request, s.info, handler)
+	s.Equal(errInvalidTaskToken, err)
+}
+
+func (s *payloadCodecInterceptorSuite) TestIntercept_NamespaceNotSet() {
+	request := &workflowservice.SignalWorkflowExecutionRequest{
+		Input: payloads.EncodeString("signal input"),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		s.Fail("request must be rejected")
+		return nil, nil
+	}
+
+	_, err := s.interceptor.Intercept(context.Background(), request, s.info, handler)
+	s.Equal(errNamespaceNotSet, err)
+}
+
+func (s *payloadCodecInterceptorSuite) TestStreamIntercept_AuthorizedCaller() {
+	s.mockAuthorizer.EXPECT().Authorize(gomock.Any(), &authorization.Attributes{
+		Namespace:  "test-namespace",
+		Permission: authorization.PermissionDecodePayloads,
+	}).Return(authorization.Result{Decision: authorization.DecisionAllow}, nil)
+
+	stream := &testPayloadCodecServerStream{ctx: context.Background()}
+	err := s.streamHistory(stream)
+	s.NoError(err)
+	s.Len(stream.sent, 1)
+	s.Equal(payloads.EncodeString("signal input"), s.getSignalInput(stream.sent[0]))
+}
+
+func (s *payloadCodecInterceptorSuite) TestStreamIntercept_UnauthorizedCaller() {
+	s.mockAuthorizer.EXPECT().Authorize(gomock.Any(), gomock.Any()).
+		Return(authorization.Result{Decision: authorization.DecisionDeny}, nil)
+
+	stream := &testPayloadCodecServerStream{ctx: context.Background()}
+	err := s.streamHistory(stream)
+	s.NoError(err)
+	s.Len(stream.sent, 1)
+	s.True(payloadcodec.IsEncrypted(s.getSignalInput(stream.sent[0]).Payloads[0]))
+}
+
+func (s *payloadCodecInterceptorSuite) TestStreamIntercept_InternalCaller() {
+	stream := &testPayloadCodecServerStream{ctx: s.newCallerContext("temporal-worker", "temporal-internode-ca")}
+	err := s.streamHistory(stream)
+	s.NoError(err)
+	s.Len(stream.sent, 1)
+	s.Equal(payloads.EncodeString("signal input"), s.getSignalInput(stream.sent[0]))
+}
+
+// streamHistory streams a single response with a signaled event whose input is encrypted at rest
+func (s *payloadCodecInterceptorSuite) streamHistory(stream grpc.ServerStream) error {
+	return s.interceptor.StreamIntercept(
+		NewWorkflowNilCheckHandler(nil),
+		stream,
+		&grpc.StreamServerInfo{FullMethod: "/workflowstreamservice.WorkflowStreamService/StreamWorkflowExecutionHistory", IsServerStream: true},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			request := &workflowstreamservice.StreamWorkflowExecutionHistoryRequest{Namespace: "test-namespace"}
+			if err := stream.RecvMsg(request); err != nil {
+				return err
+			}
+			response := &workflowstreamservice.StreamWorkflowExecutionHistoryResponse{
+				Events: []*eventpb.HistoryEvent{{
+					EventId: 1,
+					Attributes: &eventpb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &eventpb.WorkflowExecutionSignaledEventAttributes{
+						Input: payloads.EncodeString("signal input"),
+					}},
+				}},
+			}
+			s.NoError(payloadcodec.EncodeMessage(s.codec, request.GetNamespace(), response))
+			return stream.SendMsg(response)
+		},
+	)
+}
+
+func (s *payloadCodecInterceptorSuite) getSignalInput(response interface{}) *commonpb.Payloads {
+	events := response.(*workflowstreamservice.StreamWorkflowExecutionHistoryResponse).Events
+	return events[0].GetWorkflowExecutionSignaledEventAttributes().GetInput()
+}
+
+// newCallerContext returns the context of a caller authenticated by a client certificate
+// with the common name, issued by the CA with the issuer common name
+func (s *payloadCodecInterceptorSuite) newCallerContext(commonName string, issuerCommonName string) context.Context {
+	cert := &x509.Certificate{
+		Subject: pkix.Name{CommonName: commonName},
+		Issuer:  pkix.Name{CommonName: issuerCommonName},
+	}
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+			VerifiedChains: [][]*x509.Certificate{{cert}},
+		}},
+	})
+}
+
+func (s *testPayloadCodecServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testPayloadCodecServerStream) RecvMsg(m interface{}) error {
+	return nil
+}
+
+func (s *testPayloadCodecServerStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
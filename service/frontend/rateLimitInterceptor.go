@@ -0,0 +1,249 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"strings"
+
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/quotas"
+	"github.com/temporalio/temporal/common/service/dynamicconfig"
+)
+
+// API classes of the frontend rate limiting, in order of priority
+const (
+	workerAPIClass     = "worker"
+	userAPIClass       = "user"
+	adminAPIClass      = "admin"
+	visibilityAPIClass = "visibility"
+)
+
+type (
+	// RateLimitInterceptor rate limits the workflow service and admin service calls
+	// per namespace and API class
+	RateLimitInterceptor struct {
+		rateLimiter   quotas.Policy
+		metricsClient metrics.Client
+	}
+
+	rateLimitedServerStream struct {
+		grpc.ServerStream
+		interceptor *RateLimitInterceptor
+		server      interface{}
+		fullMethod  string
+		received    bool
+	}
+)
+
+var (
+	workflowServiceAPIClasses = map[string]string{
+		"PollForDecisionTask":              workerAPIClass,
+		"PollForActivityTask":              workerAPIClass,
+		"RespondDecisionTaskCompleted":     workerAPIClass,
+		"RespondDecisionTaskFailed":        workerAPIClass,
+		"RecordActivityTaskHeartbeat":      workerAPIClass,
+		"RecordActivityTaskHeartbeatById":  workerAPIClass,
+		"RespondActivityTaskCompleted":     workerAPIClass,
+		"RespondActivityTaskCompletedById": workerAPIClass,
+		"RespondActivityTaskFailed":        workerAPIClass,
+		"RespondActivityTaskFailedById":    workerAPIClass,
+		"RespondActivityTaskCanceled":      workerAPIClass,
+		"RespondActivityTaskCanceledById":  workerAPIClass,
+		"RespondQueryTaskCompleted":        workerAPIClass,
+		"ResetStickyTaskList":              workerAPIClass,
+
+		"StartWorkflowExecution":           userAPIClass,
+		"SignalWorkflowExecution":          userAPIClass,
+		"SignalWithStartWorkflowExecution": userAPIClass,
+		"RequestCancelWorkflowExecution":   userAPIClass,
+		"ResetWorkflowExecution":           userAPIClass,
+		"TerminateWorkflowExecution":       userAPIClass,
+		"QueryWorkflow":                    userAPIClass,
+		"DescribeWorkflowExecution":        userAPIClass,
+
+		"RegisterNamespace":  adminAPIClass,
+		"DescribeNamespace":  adminAPIClass,
+		"ListNamespaces":     adminAPIClass,
+		"UpdateNamespace":    adminAPIClass,
+		"DeprecateNamespace": adminAPIClass,
+		"GetClusterInfo":     adminAPIClass,
+
+		"GetWorkflowExecutionHistory":    visibilityAPIClass,
+		"ListOpenWorkflowExecutions":     visibilityAPIClass,
+		"ListClosedWorkflowExecutions":   visibilityAPIClass,
+		"ListWorkflowExecutions":         visibilityAPIClass,
+		"ListArchivedWorkflowExecutions": visibilityAPIClass,
+		"ScanWorkflowExecutions":         visibilityAPIClass,
+		"CountWorkflowExecutions":        visibilityAPIClass,
+		"GetSearchAttributes":            visibilityAPIClass,
+		"DescribeTaskList":               visibilityAPIClass,
+		"ListTaskListPartitions":         visibilityAPIClass,
+		"StreamWorkflowExecutionHistory": visibilityAPIClass,
+	}
+
+	// countOnlyAPIs complete work already handed out to workers, they are counted
+	// in the rate limits but are accepted even if the rate limits are exceeded
+	countOnlyAPIs = map[string]struct{}{
+		"RespondDecisionTaskCompleted":     {},
+		"RespondDecisionTaskFailed":        {},
+		"RecordActivityTaskHeartbeat":      {},
+		"RecordActivityTaskHeartbeatById":  {},
+		"RespondActivityTaskCompleted":     {},
+		"RespondActivityTaskCompletedById": {},
+		"RespondActivityTaskFailed":        {},
+		"RespondActivityTaskFailedById":    {},
+		"RespondActivityTaskCanceled":      {},
+		"RespondActivityTaskCanceledById":  {},
+		"RespondQueryTaskCompleted":        {},
+	}
+)
+
+// NewRateLimitInterceptor creates a new RateLimitInterceptor
+func NewRateLimitInterceptor(
+	rateLimiter quotas.Policy,
+	metricsClient metrics.Client,
+) *RateLimitInterceptor {
+	return &RateLimitInterceptor{
+		rateLimiter:   rateLimiter,
+		metricsClient: metricsClient,
+	}
+}
+
+// NewRateLimiter creates the frontend rate limit policy from the config
+func NewRateLimiter(
+	config *Config,
+	memberCount func() (int, error),
+) quotas.Policy {
+	namespaceRPS := func(rps dynamicconfig.IntPropertyFnWithNamespaceFilter) quotas.RPSKeyFunc {
+		return func(namespace string) float64 {
+			return float64(rps(namespace))
+		}
+	}
+	return quotas.NewPriorityRateLimiter(
+		func() float64 {
+			return float64(config.RPS())
+		},
+		func(namespace string) float64 {
+			if config.GlobalNamespaceRPS(namespace) > 0 {
+				ringSize, err := memberCount()
+				if err == nil && ringSize > 0 {
+					avgQuota := common.MaxInt(config.GlobalNamespaceRPS(namespace)/ringSize, 1)
+					return float64(common.MinInt(avgQuota, config.MaxNamespaceRPSPerInstance(namespace)))
+				}
+			}
+			return float64(config.MaxNamespaceRPSPerInstance(namespace))
+		},
+		map[string]quotas.APIClassConfig{
+			workerAPIClass:     {Priority: 0, NamespaceRPS: namespaceRPS(config.MaxNamespaceWorkerRPSPerInstance)},
+			userAPIClass:       {Priority: 1, NamespaceRPS: namespaceRPS(config.MaxNamespaceUserRPSPerInstance)},
+			adminAPIClass:      {Priority: 2, NamespaceRPS: namespaceRPS(config.MaxNamespaceAdminRPSPerInstance)},
+			visibilityAPIClass: {Priority: 3, NamespaceRPS: namespaceRPS(config.MaxNamespaceVisibilityRPSPerInstance)},
+		},
+	)
+}
+
+// Intercept is the grpc unary server interceptor
+func (i *RateLimitInterceptor) Intercept(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	if err := i.allow(info.Server, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamIntercept is the grpc stream server interceptor, streams are rate limited
+// when their request is received
+func (i *RateLimitInterceptor) StreamIntercept(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+
+	return handler(srv, &rateLimitedServerStream{
+		ServerStream: ss,
+		interceptor:  i,
+		server:       srv,
+		fullMethod:   info.FullMethod,
+	})
+}
+
+func (i *RateLimitInterceptor) allow(
+	server interface{},
+	fullMethod string,
+	req interface{},
+) error {
+
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	var apiClass string
+	switch server.(type) {
+	case workflowservice.WorkflowServiceServer:
+		apiClass = workflowServiceAPIClasses[method]
+	case adminservice.AdminServiceServer:
+		apiClass = adminAPIClass
+	}
+	if apiClass == "" {
+		return nil
+	}
+
+	var namespace string
+	if r, ok := req.(namespaceGetter); ok {
+		namespace = r.GetNamespace()
+	}
+	if ok := i.rateLimiter.Allow(quotas.Info{Namespace: namespace, APIClass: apiClass}); !ok {
+		if _, countOnly := countOnlyAPIs[method]; !countOnly {
+			i.metricsClient.Scope(
+				metrics.FrontendRateLimitScope,
+				metrics.NamespaceTag(namespace),
+				metrics.APIClassTag(apiClass),
+			).IncCounter(metrics.ServiceErrResourceExhaustedCounter)
+			return errServiceBusy
+		}
+	}
+	return nil
+}
+
+// RecvMsg rate limits the stream on its first message, which is the request of server streams
+func (s *rateLimitedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.received {
+		return nil
+	}
+	s.received = true
+	return s.interceptor.allow(s.server, s.fullMethod, m)
+}
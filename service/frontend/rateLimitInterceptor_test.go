@@ -0,0 +1,187 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc"
+
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservice"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/quotas"
+)
+
+type (
+	rateLimitInterceptorSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		policy      *testRateLimitPolicy
+		interceptor *RateLimitInterceptor
+	}
+
+	testRateLimitPolicy struct {
+		allow bool
+		infos []quotas.Info
+	}
+
+	testServerStream struct {
+		grpc.ServerStream
+		received int
+	}
+)
+
+func TestRateLimitInterceptorSuite(t *testing.T) {
+	s := new(rateLimitInterceptorSuite)
+	suite.Run(t, s)
+}
+
+func (s *rateLimitInterceptorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.policy = &testRateLimitPolicy{}
+	s.interceptor = NewRateLimitInterceptor(s.policy, metrics.NewClient(tally.NoopScope, metrics.Frontend))
+}
+
+func (s *rateLimitInterceptorSuite) TestIntercept_Allowed() {
+	s.policy.allow = true
+	called := false
+	_, err := s.interceptor.Intercept(
+		context.Background(),
+		&workflowservice.ListWorkflowExecutionsRequest{Namespace: "test-namespace"},
+		s.workflowServiceInfo("ListWorkflowExecutions"),
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return &workflowservice.ListWorkflowExecutionsResponse{}, nil
+		},
+	)
+	s.NoError(err)
+	s.True(called)
+	s.Equal([]quotas.Info{{Namespace: "test-namespace", APIClass: visibilityAPIClass}}, s.policy.infos)
+}
+
+func (s *rateLimitInterceptorSuite) TestIntercept_Throttled() {
+	called := false
+	_, err := s.interceptor.Intercept(
+		context.Background(),
+		&workflowservice.StartWorkflowExecutionRequest{Namespace: "test-namespace"},
+		s.workflowServiceInfo("StartWorkflowExecution"),
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return &workflowservice.StartWorkflowExecutionResponse{}, nil
+		},
+	)
+	s.Equal(errServiceBusy, err)
+	s.False(called)
+	s.Equal([]quotas.Info{{Namespace: "test-namespace", APIClass: userAPIClass}}, s.policy.infos)
+}
+
+func (s *rateLimitInterceptorSuite) TestIntercept_CountOnly() {
+	called := false
+	_, err := s.interceptor.Intercept(
+		context.Background(),
+		&workflowservice.RespondDecisionTaskCompletedRequest{},
+		s.workflowServiceInfo("RespondDecisionTaskCompleted"),
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return &workflowservice.RespondDecisionTaskCompletedResponse{}, nil
+		},
+	)
+	s.NoError(err)
+	s.True(called)
+	s.Equal([]quotas.Info{{APIClass: workerAPIClass}}, s.policy.infos)
+}
+
+func (s *rateLimitInterceptorSuite) TestIntercept_UnknownAPI() {
+	called := false
+	_, err := s.interceptor.Intercept(
+		context.Background(),
+		nil,
+		&grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		},
+	)
+	s.NoError(err)
+	s.True(called)
+	s.Empty(s.policy.infos)
+}
+
+func (s *rateLimitInterceptorSuite) TestStreamIntercept_Throttled() {
+	stream := &testServerStream{}
+	err := s.interceptor.StreamIntercept(
+		NewWorkflowNilCheckHandler(nil),
+		stream,
+		&grpc.StreamServerInfo{FullMethod: "/workflowstreamservice.WorkflowStreamService/StreamWorkflowExecutionHistory", IsServerStream: true},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			return stream.RecvMsg(&workflowstreamservice.StreamWorkflowExecutionHistoryRequest{Namespace: "test-namespace"})
+		},
+	)
+	s.Equal(errServiceBusy, err)
+	s.Equal([]quotas.Info{{Namespace: "test-namespace", APIClass: visibilityAPIClass}}, s.policy.infos)
+}
+
+func (s *rateLimitInterceptorSuite) TestStreamIntercept_Allowed() {
+	s.policy.allow = true
+	stream := &testServerStream{}
+	err := s.interceptor.StreamIntercept(
+		NewWorkflowNilCheckHandler(nil),
+		stream,
+		&grpc.StreamServerInfo{FullMethod: "/workflowstreamservice.WorkflowStreamService/StreamWorkflowExecutionHistory", IsServerStream: true},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			request := &workflowstreamservice.StreamWorkflowExecutionHistoryRequest{Namespace: "test-namespace"}
+			if err := stream.RecvMsg(request); err != nil {
+				return err
+			}
+			return stream.RecvMsg(request)
+		},
+	)
+	s.NoError(err)
+	s.Equal(2, stream.received)
+	s.Equal([]quotas.Info{{Namespace: "test-namespace", APIClass: visibilityAPIClass}}, s.policy.infos)
+}
+
+func (s *rateLimitInterceptorSuite) workflowServiceInfo(method string) *grpc.UnaryServerInfo {
+	return &grpc.UnaryServerInfo{
+		Server:     NewWorkflowNilCheckHandler(nil),
+		FullMethod: "/workflowservice.WorkflowService/" + method,
+	}
+}
+
+func (p *testRateLimitPolicy) Allow(info quotas.Info) bool {
+	p.infos = append(p.infos, info)
+	return p.allow
+}
+
+func (s *testServerStream) RecvMsg(m interface{}) error {
+	s.received++
+	return nil
+}
@@ -0,0 +1,109 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"go.temporal.io/temporal-proto/workflowservice"
+
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/cache"
+)
+
+type (
+	// requestNamespaceResolver resolves the namespace which workflow service requests are scoped to
+	requestNamespaceResolver struct {
+		namespaceCache  cache.NamespaceCache
+		tokenSerializer common.TaskTokenSerializer
+	}
+
+	namespaceGetter interface {
+		GetNamespace() string
+	}
+
+	taskTokenGetter interface {
+		GetTaskToken() []byte
+	}
+)
+
+func newRequestNamespaceResolver(
+	namespaceCache cache.NamespaceCache,
+) *requestNamespaceResolver {
+	return &requestNamespaceResolver{
+		namespaceCache:  namespaceCache,
+		tokenSerializer: common.NewProtoTaskTokenSerializer(),
+	}
+}
+
+// getNamespace returns the namespace of the request, the namespace of requests which only carry a task token
+// is resolved from the namespace ID in the token. Requests which are scoped to a namespace are rejected if
+// no namespace resolves.
+func (r *requestNamespaceResolver) getNamespace(
+	req interface{},
+) (namespace string, scoped bool, retError error) {
+
+	if getter, ok := req.(namespaceGetter); ok {
+		scoped = true
+		namespace = getter.GetNamespace()
+	}
+	if getter, ok := req.(taskTokenGetter); ok && namespace == "" {
+		scoped = true
+		if len(getter.GetTaskToken()) == 0 {
+			return "", scoped, errTaskTokenNotSet
+		}
+		namespaceID, err := r.getTaskTokenNamespaceID(req, getter.GetTaskToken())
+		if err != nil {
+			return "", scoped, errInvalidTaskToken
+		}
+		if namespaceID == "" || r.namespaceCache == nil {
+			return "", scoped, errNamespaceNotSet
+		}
+		if namespace, err = r.namespaceCache.GetNamespaceName(namespaceID); err != nil {
+			return "", scoped, err
+		}
+	}
+	if scoped && namespace == "" {
+		return "", scoped, errNamespaceNotSet
+	}
+	return namespace, scoped, nil
+}
+
+func (r *requestNamespaceResolver) getTaskTokenNamespaceID(
+	req interface{},
+	token []byte,
+) (string, error) {
+
+	if _, ok := req.(*workflowservice.RespondQueryTaskCompletedRequest); ok {
+		queryTaskToken, err := r.tokenSerializer.DeserializeQueryTaskToken(token)
+		if err != nil {
+			return "", err
+		}
+		return queryTaskToken.GetNamespaceId(), nil
+	}
+	taskToken, err := r.tokenSerializer.Deserialize(token)
+	if err != nil {
+		return "", err
+	}
+	return taskToken.GetNamespaceId(), nil
+}
@@ -36,19 +36,23 @@ import (
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservice"
 	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/audit"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/mocks"
 	"github.com/temporalio/temporal/common/namespace"
+	"github.com/temporalio/temporal/common/payloadcodec"
 	"github.com/temporalio/temporal/common/persistence"
 	persistenceClient "github.com/temporalio/temporal/common/persistence/client"
 	espersistence "github.com/temporalio/temporal/common/persistence/elasticsearch"
 	"github.com/temporalio/temporal/common/resource"
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 // Config represents configuration for frontend service
@@ -73,6 +77,12 @@ type Config struct {
 	DisallowQuery                   dynamicconfig.BoolPropertyFnWithNamespaceFilter
 	ShutdownDrainDuration           dynamicconfig.DurationPropertyFn
 
+	// per namespace rate limits of the API classes
+	MaxNamespaceWorkerRPSPerInstance     dynamicconfig.IntPropertyFnWithNamespaceFilter
+	MaxNamespaceUserRPSPerInstance       dynamicconfig.IntPropertyFnWithNamespaceFilter
+	MaxNamespaceVisibilityRPSPerInstance dynamicconfig.IntPropertyFnWithNamespaceFilter
+	MaxNamespaceAdminRPSPerInstance      dynamicconfig.IntPropertyFnWithNamespaceFilter
+
 	// Persistence settings
 	HistoryMgrNumConns dynamicconfig.IntPropertyFn
 
@@ -92,6 +102,14 @@ type Config struct {
 	// Namespace specific config
 	EnableNamespaceNotActiveAutoForwarding dynamicconfig.BoolPropertyFnWithNamespaceFilter
 
+	// graceful namespace failover settings
+	NamespaceGracefulFailoverTimeout dynamicconfig.DurationPropertyFnWithNamespaceFilter
+	NamespaceHandoverCheckInterval   dynamicconfig.DurationPropertyFn
+
+	// PayloadOffloadSizeThreshold is the history payload offload threshold, namespaces which offload
+	// payloads cannot be replicated
+	PayloadOffloadSizeThreshold dynamicconfig.IntPropertyFnWithNamespaceFilter
+
 	// ValidSearchAttributes is legal indexed keys that can be used in list APIs
 	ValidSearchAttributes             dynamicconfig.MapPropertyFn
 	SearchAttributesNumberOfKeysLimit dynamicconfig.IntPropertyFnWithNamespaceFilter
@@ -102,6 +120,11 @@ type Config struct {
 	VisibilityArchivalQueryMaxPageSize dynamicconfig.IntPropertyFn
 
 	SendRawWorkflowHistory dynamicconfig.BoolPropertyFnWithNamespaceFilter
+
+	// MaxCompletionCallbacks is the max number of completion callback URLs per workflow
+	MaxCompletionCallbacks dynamicconfig.IntPropertyFnWithNamespaceFilter
+	// CompletionCallbackAllowedHosts is the list of hosts completion callback URLs can point to
+	CompletionCallbackAllowedHosts dynamicconfig.StringPropertyFnWithNamespaceFilter
 }
 
 // NewConfig returns new service config with default values
@@ -121,6 +144,10 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, enableReadFro
 		RPS:                                    dc.GetIntProperty(dynamicconfig.FrontendRPS, 1200),
 		MaxNamespaceRPSPerInstance:             dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendMaxNamespaceRPSPerInstance, 1200),
 		GlobalNamespaceRPS:                     dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendGlobalNamespaceRPS, 0),
+		MaxNamespaceWorkerRPSPerInstance:       dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendMaxNamespaceWorkerRPSPerInstance, 1200),
+		MaxNamespaceUserRPSPerInstance:         dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendMaxNamespaceUserRPSPerInstance, 1200),
+		MaxNamespaceVisibilityRPSPerInstance:   dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendMaxNamespaceVisibilityRPSPerInstance, 1200),
+		MaxNamespaceAdminRPSPerInstance:        dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendMaxNamespaceAdminRPSPerInstance, 1200),
 		MaxIDLengthLimit:                       dc.GetIntProperty(dynamicconfig.MaxIDLengthLimit, 1000),
 		HistoryMgrNumConns:                     dc.GetIntProperty(dynamicconfig.FrontendHistoryMgrNumConns, 10),
 		MaxBadBinaries:                         dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendMaxBadBinaries, namespace.MaxBadBinaries),
@@ -141,6 +168,11 @@ func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int, enableReadFro
 		VisibilityArchivalQueryMaxPageSize:     dc.GetIntProperty(dynamicconfig.VisibilityArchivalQueryMaxPageSize, 10000),
 		DisallowQuery:                          dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.DisallowQuery, false),
 		SendRawWorkflowHistory:                 dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.SendRawWorkflowHistory, false),
+		MaxCompletionCallbacks:                 dc.GetIntPropertyFilteredByNamespace(dynamicconfig.FrontendMaxCompletionCallbacks, 8),
+		CompletionCallbackAllowedHosts:         dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.CompletionCallbackAllowedHosts, ""),
+		NamespaceGracefulFailoverTimeout:       dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.NamespaceGracefulFailoverTimeout, 0),
+		NamespaceHandoverCheckInterval:         dc.GetDurationProperty(dynamicconfig.NamespaceHandoverCheckInterval, 10*time.Second),
+		PayloadOffloadSizeThreshold:            dc.GetIntPropertyFilteredByNamespace(dynamicconfig.PayloadOffloadSizeThreshold, 0),
 	}
 }
 
@@ -152,9 +184,11 @@ type Service struct {
 	config *Config
 	params *resource.BootstrapParams
 
-	handler      Handler
-	adminHandler *AdminHandler
-	server       *grpc.Server
+	handler           Handler
+	adminHandler      *AdminHandler
+	handoverCompleter *namespace.HandoverCompleter
+	server            *grpc.Server
+	httpGateway       *HTTPGateway
 }
 
 // NewService builds a new frontend service
@@ -250,27 +284,105 @@ func (s *Service) Start() {
 	if err != nil {
 		logger.Fatal("creating grpc server options failed", tag.Error(err))
 	}
-	opts = append(opts, grpc.UnaryInterceptor(interceptor))
+	rateLimiter := NewRateLimiter(s.config, func() (int, error) {
+		monitor := s.GetMembershipMonitor()
+		if monitor == nil {
+			return 0, nil
+		}
+		return monitor.GetMemberCount(common.FrontendServiceName)
+	})
+	rateLimitInterceptor := NewRateLimitInterceptor(rateLimiter, s.GetMetricsClient())
+	interceptors := []grpc.UnaryServerInterceptor{
+		tracing.NewServerInterceptor(s.GetTracer()),
+		interceptor,
+		rateLimitInterceptor.Intercept,
+		NewBlobReferenceInterceptor().Intercept,
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		streamInterceptor,
+		rateLimitInterceptor.StreamIntercept,
+	}
+	if s.params.TLSConfigProvider != nil {
+		namespaceBindingInterceptor := NewNamespaceBindingInterceptor(s.params.TLSConfigProvider, s.GetNamespaceCache())
+		interceptors = append(interceptors, namespaceBindingInterceptor.Intercept)
+		streamInterceptors = append(streamInterceptors, namespaceBindingInterceptor.StreamIntercept)
+	}
+	if codecConfig := s.params.PayloadCodecConfig; codecConfig != nil {
+		keyring, err := payloadcodec.LoadKeyringFile(codecConfig.KeyringFile)
+		if err != nil {
+			logger.Fatal("loading payload codec keyring failed", tag.Error(err))
+		}
+		codec, err := payloadcodec.NewAESGCMCodec(keyring)
+		if err != nil {
+			logger.Fatal("creating payload codec failed", tag.Error(err))
+		}
+		payloadCodecInterceptor := NewPayloadCodecInterceptor(
+			codec,
+			s.params.Authorizer,
+			s.params.TLSConfigProvider,
+			s.GetNamespaceCache(),
+			codecConfig.InternalCallers,
+		)
+		interceptors = append(interceptors, payloadCodecInterceptor.Intercept)
+		streamInterceptors = append(streamInterceptors, payloadCodecInterceptor.StreamIntercept)
+	}
+	auditSinks, err := audit.NewSinks(s.params.AuditConfig, s.GetAuditLogQueue(), logger)
+	if err != nil {
+		logger.Fatal("creating audit sinks failed", tag.Error(err))
+	}
+	if len(auditSinks) > 0 {
+		interceptors = append(interceptors, NewAuditInterceptor(auditSinks, logger).Intercept)
+	}
+	opts = append(opts, grpc.ChainUnaryInterceptor(interceptors...))
+	opts = append(opts, grpc.ChainStreamInterceptor(streamInterceptors...))
 	s.server = grpc.NewServer(opts...)
 
-	wfHandler := NewWorkflowHandler(s, s.config, replicationMessageSink)
+	namespaceHandler := NewNamespaceHandler(s, s.config, replicationMessageSink)
+	wfHandler := NewWorkflowHandler(s, s.config, namespaceHandler)
 	s.handler = NewDCRedirectionHandler(wfHandler, s.params.DCRedirectionPolicy)
 	if s.params.Authorizer != nil {
-		s.handler = NewAccessControlledHandlerImpl(s.handler, s.params.Authorizer)
+		s.handler = NewAccessControlledHandlerImpl(s.handler, s.params.Authorizer, s.params.TLSConfigProvider)
 	}
 	workflowNilCheckHandler := NewWorkflowNilCheckHandler(s.handler)
 
 	workflowservice.RegisterWorkflowServiceServer(s.server, workflowNilCheckHandler)
+	workflowstreamservice.RegisterWorkflowStreamServiceServer(s.server, workflowNilCheckHandler)
 	healthpb.RegisterHealthServer(s.server, s.handler)
 
-	s.adminHandler = NewAdminHandler(s, s.params, s.config)
+	s.adminHandler = NewAdminHandler(s, s.params, s.config, namespaceHandler)
 	adminNilCheckHandler := NewAdminNilCheckHandler(s.adminHandler)
 
 	adminservice.RegisterAdminServiceServer(s.server, adminNilCheckHandler)
 
+	// the HTTP gateway calls the workflow service handler through the same interceptors as the gRPC server,
+	// so its payloads are encoded and decoded by the payload codec interceptor as well
+	httpListener := s.params.RPCFactory.GetHTTPListener()
+	if httpListener != nil {
+		s.httpGateway = NewHTTPGateway(workflowNilCheckHandler, interceptors, logger)
+	}
+
+	s.handoverCompleter = namespace.NewHandoverCompleter(
+		namespaceHandler,
+		s.GetMetadataManager(),
+		s.GetShardManager(),
+		s.GetNamespaceCache(),
+		s.GetMembershipMonitor(),
+		s.GetHostInfo(),
+		s.GetHistoryShardLayout(),
+		s.config.NamespaceHandoverCheckInterval,
+		logger,
+	)
+
 	// must start resource first
 	s.Resource.Start()
 	s.adminHandler.Start()
+	if clusterMetadata.IsGlobalNamespaceEnabled() {
+		s.handoverCompleter.Start()
+	}
+
+	if s.httpGateway != nil {
+		go s.httpGateway.Serve(httpListener)
+	}
 
 	listener := s.GetGRPCListener()
 	logger.Info("Starting to serve on frontend listener")
@@ -302,10 +414,14 @@ func (s *Service) Stop() {
 	time.Sleep(failureDetectionTime)
 
 	s.adminHandler.Stop()
+	s.handoverCompleter.Stop()
 
 	s.GetLogger().Info("ShutdownHandler: Draining traffic")
 	time.Sleep(requestDrainTime)
 
+	if s.httpGateway != nil {
+		s.httpGateway.Stop()
+	}
 	// TODO: Change this to GracefulStop when integration tests are refactored.
 	s.server.Stop()
 	s.Resource.Stop()
@@ -316,3 +432,8 @@ func interceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInf
 	resp, err := handler(ctx, req)
 	return resp, serviceerror.ToStatus(err).Err()
 }
+
+func streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	return serviceerror.ToStatus(err).Err()
+}
@@ -27,6 +27,8 @@ package frontend
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/url"
 	"sync/atomic"
 	"time"
 
@@ -43,13 +45,17 @@ import (
 	"go.temporal.io/temporal-proto/workflowservice"
 
 	eventgenpb "github.com/temporalio/temporal/.gen/proto/event"
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
 	tokengenpb "github.com/temporalio/temporal/.gen/proto/token"
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservice"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/backoff"
+	"github.com/temporalio/temporal/common/blobstore"
 	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/codec"
 	"github.com/temporalio/temporal/common/convert"
 	"github.com/temporalio/temporal/common/elasticsearch/validator"
 	"github.com/temporalio/temporal/common/failure"
@@ -60,10 +66,12 @@ import (
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/namespace"
 	"github.com/temporalio/temporal/common/persistence"
-	"github.com/temporalio/temporal/common/quotas"
 	"github.com/temporalio/temporal/common/resource"
+	"github.com/temporalio/temporal/service/history"
 
+	"google.golang.org/grpc"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
@@ -83,7 +91,6 @@ type (
 		shuttingDown              int32
 		healthStatus              int32
 		tokenSerializer           common.TaskTokenSerializer
-		rateLimiter               quotas.Policy
 		config                    *Config
 		versionChecker            headers.VersionChecker
 		namespaceHandler          namespace.Handler
@@ -103,39 +110,15 @@ var (
 func NewWorkflowHandler(
 	resource resource.Resource,
 	config *Config,
-	replicationMessageSink messaging.Producer,
+	namespaceHandler namespace.Handler,
 ) Handler {
 	handler := &WorkflowHandler{
-		Resource:        resource,
-		config:          config,
-		healthStatus:    int32(HealthStatusOK),
-		tokenSerializer: common.NewProtoTaskTokenSerializer(),
-		rateLimiter: quotas.NewMultiStageRateLimiter(
-			func() float64 {
-				return float64(config.RPS())
-			},
-			func(namespace string) float64 {
-				if monitor := resource.GetMembershipMonitor(); monitor != nil && config.GlobalNamespaceRPS(namespace) > 0 {
-					ringSize, err := monitor.GetMemberCount(common.FrontendServiceName)
-					if err == nil && ringSize > 0 {
-						avgQuota := common.MaxInt(config.GlobalNamespaceRPS(namespace)/ringSize, 1)
-						return float64(common.MinInt(avgQuota, config.MaxNamespaceRPSPerInstance(namespace)))
-					}
-				}
-				return float64(config.MaxNamespaceRPSPerInstance(namespace))
-			},
-		),
-		versionChecker: headers.NewVersionChecker(),
-		namespaceHandler: namespace.NewHandler(
-			config.MinRetentionDays(),
-			config.MaxBadBinaries,
-			resource.GetLogger(),
-			resource.GetMetadataManager(),
-			resource.GetClusterMetadata(),
-			namespace.NewNamespaceReplicator(replicationMessageSink, resource.GetLogger()),
-			resource.GetArchivalMetadata(),
-			resource.GetArchiverProvider(),
-		),
+		Resource:                 resource,
+		config:                   config,
+		healthStatus:             int32(HealthStatusOK),
+		tokenSerializer:          common.NewProtoTaskTokenSerializer(),
+		versionChecker:           headers.NewVersionChecker(),
+		namespaceHandler:         namespaceHandler,
 		visibilityQueryValidator: validator.NewQueryValidator(config.ValidSearchAttributes),
 		searchAttributesValidator: validator.NewSearchAttributesValidator(
 			resource.GetLogger(),
@@ -149,6 +132,27 @@ func NewWorkflowHandler(
 	return handler
 }
 
+// NewNamespaceHandler creates the namespace handler shared by the workflow and admin handlers
+func NewNamespaceHandler(
+	resource resource.Resource,
+	config *Config,
+	replicationMessageSink messaging.Producer,
+) namespace.Handler {
+
+	return namespace.NewHandler(
+		config.MinRetentionDays(),
+		config.MaxBadBinaries,
+		config.NamespaceGracefulFailoverTimeout,
+		config.PayloadOffloadSizeThreshold,
+		resource.GetLogger(),
+		resource.GetMetadataManager(),
+		resource.GetClusterMetadata(),
+		namespace.NewNamespaceReplicator(replicationMessageSink, resource.GetLogger()),
+		resource.GetArchivalMetadata(),
+		resource.GetArchiverProvider(),
+	)
+}
+
 // Start starts the handler
 func (wh *WorkflowHandler) Start() {
 }
@@ -204,7 +208,7 @@ func (wh *WorkflowHandler) Watch(*healthpb.HealthCheckRequest, healthpb.Health_W
 func (wh *WorkflowHandler) RegisterNamespace(ctx context.Context, request *workflowservice.RegisterNamespaceRequest) (_ *workflowservice.RegisterNamespaceResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfile(metrics.FrontendRegisterNamespaceScope)
+	scope, sw := wh.startRequestProfile(ctx, metrics.FrontendRegisterNamespaceScope)
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -243,7 +247,7 @@ func (wh *WorkflowHandler) RegisterNamespace(ctx context.Context, request *workf
 func (wh *WorkflowHandler) DescribeNamespace(ctx context.Context, request *workflowservice.DescribeNamespaceRequest) (_ *workflowservice.DescribeNamespaceResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfile(metrics.FrontendDescribeNamespaceScope)
+	scope, sw := wh.startRequestProfile(ctx, metrics.FrontendDescribeNamespaceScope)
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -273,7 +277,7 @@ func (wh *WorkflowHandler) DescribeNamespace(ctx context.Context, request *workf
 func (wh *WorkflowHandler) ListNamespaces(ctx context.Context, request *workflowservice.ListNamespacesRequest) (_ *workflowservice.ListNamespacesResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfile(metrics.FrontendListNamespacesScope)
+	scope, sw := wh.startRequestProfile(ctx, metrics.FrontendListNamespacesScope)
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -299,7 +303,7 @@ func (wh *WorkflowHandler) ListNamespaces(ctx context.Context, request *workflow
 func (wh *WorkflowHandler) UpdateNamespace(ctx context.Context, request *workflowservice.UpdateNamespaceRequest) (_ *workflowservice.UpdateNamespaceResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfile(metrics.FrontendUpdateNamespaceScope)
+	scope, sw := wh.startRequestProfile(ctx, metrics.FrontendUpdateNamespaceScope)
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -338,7 +342,7 @@ func (wh *WorkflowHandler) UpdateNamespace(ctx context.Context, request *workflo
 func (wh *WorkflowHandler) DeprecateNamespace(ctx context.Context, request *workflowservice.DeprecateNamespaceRequest) (_ *workflowservice.DeprecateNamespaceResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfile(metrics.FrontendDeprecateNamespaceScope)
+	scope, sw := wh.startRequestProfile(ctx, metrics.FrontendDeprecateNamespaceScope)
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -375,7 +379,7 @@ func (wh *WorkflowHandler) DeprecateNamespace(ctx context.Context, request *work
 func (wh *WorkflowHandler) StartWorkflowExecution(ctx context.Context, request *workflowservice.StartWorkflowExecutionRequest) (_ *workflowservice.StartWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendStartWorkflowExecutionScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendStartWorkflowExecutionScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -390,10 +394,6 @@ func (wh *WorkflowHandler) StartWorkflowExecution(ctx context.Context, request *
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	namespace := request.GetNamespace()
 	if namespace == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
@@ -459,6 +459,11 @@ func (wh *WorkflowHandler) StartWorkflowExecution(ctx context.Context, request *
 		return nil, wh.error(err, scope)
 	}
 
+	completionCallbackURLs, err := wh.getCompletionCallbackURLs(ctx, namespace)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	wh.GetLogger().Debug("Start workflow execution request namespace", tag.WorkflowNamespace(namespace))
 	namespaceID, err := wh.GetNamespaceCache().GetNamespaceID(namespace)
 	if err != nil {
@@ -489,7 +494,9 @@ func (wh *WorkflowHandler) StartWorkflowExecution(ctx context.Context, request *
 	}
 
 	wh.GetLogger().Debug("Start workflow execution request namespaceID", tag.WorkflowNamespaceID(namespaceID))
-	resp, err := wh.GetHistoryClient().StartWorkflowExecution(ctx, common.CreateHistoryStartWorkflowRequest(namespaceID, request))
+	histRequest := common.CreateHistoryStartWorkflowRequest(namespaceID, request)
+	histRequest.CompletionCallbackUrls = completionCallbackURLs
+	resp, err := wh.GetHistoryClient().StartWorkflowExecution(ctx, histRequest)
 
 	if err != nil {
 		return nil, wh.error(err, scope)
@@ -502,7 +509,7 @@ func (wh *WorkflowHandler) StartWorkflowExecution(ctx context.Context, request *
 func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, request *workflowservice.GetWorkflowExecutionHistoryRequest) (_ *workflowservice.GetWorkflowExecutionHistoryResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendGetWorkflowExecutionHistoryScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendGetWorkflowExecutionHistoryScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -517,10 +524,6 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	if request.GetNamespace() == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
 	}
@@ -652,6 +655,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 		if !isWorkflowRunning {
 			if rawHistoryQueryEnabled {
 				historyBlob, _, err = wh.getRawHistory(
+					ctx,
 					scope,
 					namespaceID,
 					*execution,
@@ -670,6 +674,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 				historyBlob = historyBlob[len(historyBlob)-1 : len(historyBlob)]
 			} else {
 				history, _, err = wh.getHistory(
+					ctx,
 					scope,
 					namespaceID,
 					*execution,
@@ -704,6 +709,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 		} else {
 			if rawHistoryQueryEnabled {
 				historyBlob, continuationToken.PersistenceToken, err = wh.getRawHistory(
+					ctx,
 					scope,
 					namespaceID,
 					*execution,
@@ -716,6 +722,7 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(ctx context.Context, requ
 				)
 			} else {
 				history, continuationToken.PersistenceToken, err = wh.getHistory(
+					ctx,
 					scope,
 					namespaceID,
 					*execution,
@@ -764,7 +771,7 @@ func (wh *WorkflowHandler) PollForDecisionTask(ctx context.Context, request *wor
 	tagsForErrorLog := []tag.Tag{tag.WorkflowNamespace(request.GetNamespace())}
 	callTime := time.Now()
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendPollForDecisionTaskScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendPollForDecisionTaskScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if err := wh.versionChecker.ClientSupported(ctx, wh.config.EnableClientVersionCheck()); err != nil {
@@ -804,6 +811,9 @@ func (wh *WorkflowHandler) PollForDecisionTask(ctx context.Context, request *wor
 	if err != nil {
 		return nil, wh.error(err, scope, tagsForErrorLog...)
 	}
+	if namespaceEntry.GetInfo().Status == namespacepb.NamespaceStatus_Deleted {
+		return nil, wh.error(errNamespaceDeleted, scope, tagsForErrorLog...)
+	}
 	namespaceID := namespaceEntry.GetInfo().Id
 
 	wh.GetLogger().Debug("Poll for decision.", tag.WorkflowNamespace(namespace), tag.WorkflowNamespaceID(namespaceID))
@@ -872,9 +882,6 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(ctx context.Context, req
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.allow("")
-
 	if request.TaskToken == nil {
 		return nil, wh.error(errTaskTokenNotSet, scope)
 	}
@@ -894,7 +901,7 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(ctx context.Context, req
 	}
 
 	scope, sw := wh.startRequestProfileWithNamespace(
-		metrics.FrontendRespondDecisionTaskCompletedScope, namespaceEntry.GetInfo().Name,
+		ctx, metrics.FrontendRespondDecisionTaskCompletedScope, namespaceEntry.GetInfo().Name,
 	)
 	defer sw.Stop()
 
@@ -956,9 +963,6 @@ func (wh *WorkflowHandler) RespondDecisionTaskFailed(ctx context.Context, reques
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.allow("")
-
 	if request.TaskToken == nil {
 		return nil, wh.error(errTaskTokenNotSet, scope)
 	}
@@ -977,7 +981,7 @@ func (wh *WorkflowHandler) RespondDecisionTaskFailed(ctx context.Context, reques
 	}
 
 	scope, sw := wh.startRequestProfileWithNamespace(
-		metrics.FrontendRespondDecisionTaskFailedScope, namespaceEntry.GetInfo().Name,
+		ctx, metrics.FrontendRespondDecisionTaskFailedScope, namespaceEntry.GetInfo().Name,
 	)
 	defer sw.Stop()
 
@@ -1031,7 +1035,7 @@ func (wh *WorkflowHandler) PollForActivityTask(ctx context.Context, request *wor
 
 	callTime := time.Now()
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendPollForActivityTaskScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendPollForActivityTaskScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if err := wh.versionChecker.ClientSupported(ctx, wh.config.EnableClientVersionCheck()); err != nil {
@@ -1066,10 +1070,14 @@ func (wh *WorkflowHandler) PollForActivityTask(ctx context.Context, request *wor
 		return nil, wh.error(errIdentityTooLong, scope)
 	}
 
-	namespaceID, err := wh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	namespaceEntry, err := wh.GetNamespaceCache().GetNamespace(request.GetNamespace())
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	if namespaceEntry.GetInfo().Status == namespacepb.NamespaceStatus_Deleted {
+		return nil, wh.error(errNamespaceDeleted, scope)
+	}
+	namespaceID := namespaceEntry.GetInfo().Id
 
 	pollerID := uuid.New()
 	var matchingResponse *matchingservice.PollForActivityTaskResponse
@@ -1105,6 +1113,11 @@ func (wh *WorkflowHandler) PollForActivityTask(ctx context.Context, request *wor
 		return nil, nil
 	}
 
+	// the activity input comes from the activity scheduled event, which may contain offloaded payloads
+	if err := wh.rehydratePayloads(ctx, namespaceID, matchingResponse.WorkflowExecution.GetRunId(), matchingResponse); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	return &workflowservice.PollForActivityTaskResponse{
 		TaskToken:                       matchingResponse.TaskToken,
 		WorkflowExecution:               matchingResponse.WorkflowExecution,
@@ -1143,9 +1156,6 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeat(ctx context.Context, requ
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.allow("")
-
 	wh.GetLogger().Debug("Received RecordActivityTaskHeartbeat")
 	if request.TaskToken == nil {
 		return nil, wh.error(errTaskTokenNotSet, scope)
@@ -1165,7 +1175,7 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeat(ctx context.Context, requ
 	}
 
 	scope, sw := wh.startRequestProfileWithNamespace(
-		metrics.FrontendRecordActivityTaskHeartbeatScope, namespaceEntry.GetInfo().Name,
+		ctx, metrics.FrontendRecordActivityTaskHeartbeatScope, namespaceEntry.GetInfo().Name,
 	)
 	defer sw.Stop()
 
@@ -1222,7 +1232,7 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeat(ctx context.Context, requ
 func (wh *WorkflowHandler) RecordActivityTaskHeartbeatById(ctx context.Context, request *workflowservice.RecordActivityTaskHeartbeatByIdRequest) (_ *workflowservice.RecordActivityTaskHeartbeatByIdResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendRecordActivityTaskHeartbeatByIdScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendRecordActivityTaskHeartbeatByIdScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -1237,9 +1247,6 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeatById(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.allow("")
-
 	wh.GetLogger().Debug("Received RecordActivityTaskHeartbeatById")
 	namespaceID, err := wh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
 	if err != nil {
@@ -1342,9 +1349,6 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(ctx context.Context, req
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.allow("")
-
 	if request.TaskToken == nil {
 		return nil, wh.error(errTaskTokenNotSet, scope)
 	}
@@ -1366,7 +1370,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(ctx context.Context, req
 	}
 
 	scope, sw := wh.startRequestProfileWithNamespace(
-		metrics.FrontendRespondActivityTaskCompletedScope,
+		ctx, metrics.FrontendRespondActivityTaskCompletedScope,
 		namespaceEntry.GetInfo().Name,
 	)
 	defer sw.Stop()
@@ -1423,7 +1427,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(ctx context.Context, req
 func (wh *WorkflowHandler) RespondActivityTaskCompletedById(ctx context.Context, request *workflowservice.RespondActivityTaskCompletedByIdRequest) (_ *workflowservice.RespondActivityTaskCompletedByIdResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendRespondActivityTaskCompletedByIdScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendRespondActivityTaskCompletedByIdScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -1438,9 +1442,6 @@ func (wh *WorkflowHandler) RespondActivityTaskCompletedById(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.allow("")
-
 	namespaceID, err := wh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
 	if err != nil {
 		return nil, wh.error(err, scope)
@@ -1546,9 +1547,6 @@ func (wh *WorkflowHandler) RespondActivityTaskFailed(ctx context.Context, reques
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.allow("")
-
 	if request.TaskToken == nil {
 		return nil, wh.error(errTaskTokenNotSet, scope)
 	}
@@ -1567,7 +1565,7 @@ func (wh *WorkflowHandler) RespondActivityTaskFailed(ctx context.Context, reques
 	}
 
 	scope, sw := wh.startRequestProfileWithNamespace(
-		metrics.FrontendRespondActivityTaskFailedScope,
+		ctx, metrics.FrontendRespondActivityTaskFailedScope,
 		namespaceEntry.GetInfo().Name,
 	)
 	defer sw.Stop()
@@ -1617,7 +1615,7 @@ func (wh *WorkflowHandler) RespondActivityTaskFailed(ctx context.Context, reques
 func (wh *WorkflowHandler) RespondActivityTaskFailedById(ctx context.Context, request *workflowservice.RespondActivityTaskFailedByIdRequest) (_ *workflowservice.RespondActivityTaskFailedByIdResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendRespondActivityTaskFailedByIdScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendRespondActivityTaskFailedByIdScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -1632,9 +1630,6 @@ func (wh *WorkflowHandler) RespondActivityTaskFailedById(ctx context.Context, re
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.allow("")
-
 	namespaceID, err := wh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
 	if err != nil {
 		return nil, wh.error(err, scope)
@@ -1728,9 +1723,6 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceled(ctx context.Context, requ
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.allow("")
-
 	if request.TaskToken == nil {
 		return nil, wh.error(errTaskTokenNotSet, scope)
 	}
@@ -1751,7 +1743,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceled(ctx context.Context, requ
 	}
 
 	scope, sw := wh.startRequestProfileWithNamespace(
-		metrics.FrontendRespondActivityTaskCanceledScope,
+		ctx, metrics.FrontendRespondActivityTaskCanceledScope,
 		namespaceEntry.GetInfo().Name,
 	)
 	defer sw.Stop()
@@ -1812,7 +1804,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceled(ctx context.Context, requ
 func (wh *WorkflowHandler) RespondActivityTaskCanceledById(ctx context.Context, request *workflowservice.RespondActivityTaskCanceledByIdRequest) (_ *workflowservice.RespondActivityTaskCanceledByIdResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendRespondActivityTaskCanceledScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendRespondActivityTaskCanceledScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -1827,9 +1819,6 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceledById(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.allow("")
-
 	namespaceID, err := wh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
 	if err != nil {
 		return nil, wh.error(err, scope)
@@ -1924,7 +1913,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceledById(ctx context.Context,
 func (wh *WorkflowHandler) RequestCancelWorkflowExecution(ctx context.Context, request *workflowservice.RequestCancelWorkflowExecutionRequest) (_ *workflowservice.RequestCancelWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendRequestCancelWorkflowExecutionScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendRequestCancelWorkflowExecutionScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -1939,10 +1928,6 @@ func (wh *WorkflowHandler) RequestCancelWorkflowExecution(ctx context.Context, r
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	if request.GetNamespace() == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
 	}
@@ -1972,7 +1957,7 @@ func (wh *WorkflowHandler) RequestCancelWorkflowExecution(ctx context.Context, r
 func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context, request *workflowservice.SignalWorkflowExecutionRequest) (_ *workflowservice.SignalWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendSignalWorkflowExecutionScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendSignalWorkflowExecutionScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -1987,10 +1972,6 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context, request
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	if request.GetNamespace() == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
 	}
@@ -2055,7 +2036,7 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context, request
 func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context, request *workflowservice.SignalWithStartWorkflowExecutionRequest) (_ *workflowservice.SignalWithStartWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendSignalWithStartWorkflowExecutionScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendSignalWithStartWorkflowExecutionScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -2070,10 +2051,6 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	namespace := request.GetNamespace()
 	if namespace == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
@@ -2198,7 +2175,7 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 func (wh *WorkflowHandler) ResetWorkflowExecution(ctx context.Context, request *workflowservice.ResetWorkflowExecutionRequest) (_ *workflowservice.ResetWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendResetWorkflowExecutionScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendResetWorkflowExecutionScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -2213,10 +2190,6 @@ func (wh *WorkflowHandler) ResetWorkflowExecution(ctx context.Context, request *
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	if request.GetNamespace() == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
 	}
@@ -2246,7 +2219,7 @@ func (wh *WorkflowHandler) ResetWorkflowExecution(ctx context.Context, request *
 func (wh *WorkflowHandler) TerminateWorkflowExecution(ctx context.Context, request *workflowservice.TerminateWorkflowExecutionRequest) (_ *workflowservice.TerminateWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendTerminateWorkflowExecutionScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendTerminateWorkflowExecutionScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -2261,10 +2234,6 @@ func (wh *WorkflowHandler) TerminateWorkflowExecution(ctx context.Context, reque
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	if request.GetNamespace() == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
 	}
@@ -2293,7 +2262,7 @@ func (wh *WorkflowHandler) TerminateWorkflowExecution(ctx context.Context, reque
 func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context, request *workflowservice.ListOpenWorkflowExecutionsRequest) (_ *workflowservice.ListOpenWorkflowExecutionsResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendListOpenWorkflowExecutionsScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendListOpenWorkflowExecutionsScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -2308,10 +2277,6 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context, reque
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	if request.GetNamespace() == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
 	}
@@ -2389,7 +2354,7 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context, reque
 func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context, request *workflowservice.ListClosedWorkflowExecutionsRequest) (_ *workflowservice.ListClosedWorkflowExecutionsResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendListClosedWorkflowExecutionsScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendListClosedWorkflowExecutionsScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -2404,10 +2369,6 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context, req
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	if request.GetNamespace() == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
 	}
@@ -2496,7 +2457,7 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context, req
 func (wh *WorkflowHandler) ListWorkflowExecutions(ctx context.Context, request *workflowservice.ListWorkflowExecutionsRequest) (_ *workflowservice.ListWorkflowExecutionsResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendListWorkflowExecutionsScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendListWorkflowExecutionsScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -2511,10 +2472,6 @@ func (wh *WorkflowHandler) ListWorkflowExecutions(ctx context.Context, request *
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	if request.GetNamespace() == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
 	}
@@ -2559,7 +2516,7 @@ func (wh *WorkflowHandler) ListWorkflowExecutions(ctx context.Context, request *
 func (wh *WorkflowHandler) ListArchivedWorkflowExecutions(ctx context.Context, request *workflowservice.ListArchivedWorkflowExecutionsRequest) (_ *workflowservice.ListArchivedWorkflowExecutionsResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendListArchivedWorkflowExecutionsScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendListArchivedWorkflowExecutionsScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -2574,10 +2531,6 @@ func (wh *WorkflowHandler) ListArchivedWorkflowExecutions(ctx context.Context, r
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	if request.GetNamespace() == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
 	}
@@ -2647,7 +2600,7 @@ func (wh *WorkflowHandler) ListArchivedWorkflowExecutions(ctx context.Context, r
 func (wh *WorkflowHandler) ScanWorkflowExecutions(ctx context.Context, request *workflowservice.ScanWorkflowExecutionsRequest) (_ *workflowservice.ScanWorkflowExecutionsResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendScanWorkflowExecutionsScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendScanWorkflowExecutionsScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -2662,10 +2615,6 @@ func (wh *WorkflowHandler) ScanWorkflowExecutions(ctx context.Context, request *
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	if request.GetNamespace() == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
 	}
@@ -2711,7 +2660,7 @@ func (wh *WorkflowHandler) ScanWorkflowExecutions(ctx context.Context, request *
 func (wh *WorkflowHandler) CountWorkflowExecutions(ctx context.Context, request *workflowservice.CountWorkflowExecutionsRequest) (_ *workflowservice.CountWorkflowExecutionsResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendCountWorkflowExecutionsScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendCountWorkflowExecutionsScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -2726,10 +2675,6 @@ func (wh *WorkflowHandler) CountWorkflowExecutions(ctx context.Context, request
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	if request.GetNamespace() == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
 	}
@@ -2764,7 +2709,7 @@ func (wh *WorkflowHandler) CountWorkflowExecutions(ctx context.Context, request
 func (wh *WorkflowHandler) GetSearchAttributes(ctx context.Context, _ *workflowservice.GetSearchAttributesRequest) (_ *workflowservice.GetSearchAttributesResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfile(metrics.FrontendGetSearchAttributesScope)
+	scope, sw := wh.startRequestProfile(ctx, metrics.FrontendGetSearchAttributesScope)
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -2797,9 +2742,6 @@ func (wh *WorkflowHandler) RespondQueryTaskCompleted(ctx context.Context, reques
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.allow("")
-
 	if request.TaskToken == nil {
 		return nil, wh.error(errTaskTokenNotSet, scope)
 	}
@@ -2817,7 +2759,7 @@ func (wh *WorkflowHandler) RespondQueryTaskCompleted(ctx context.Context, reques
 	}
 
 	scope, sw := wh.startRequestProfileWithNamespace(
-		metrics.FrontendRespondQueryTaskCompletedScope,
+		ctx, metrics.FrontendRespondQueryTaskCompletedScope,
 		namespaceEntry.GetInfo().Name,
 	)
 	defer sw.Stop()
@@ -2877,7 +2819,7 @@ func (wh *WorkflowHandler) RespondQueryTaskCompleted(ctx context.Context, reques
 func (wh *WorkflowHandler) ResetStickyTaskList(ctx context.Context, request *workflowservice.ResetStickyTaskListRequest) (_ *workflowservice.ResetStickyTaskListResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendResetStickyTaskListScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendResetStickyTaskListScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -2919,7 +2861,7 @@ func (wh *WorkflowHandler) ResetStickyTaskList(ctx context.Context, request *wor
 func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context, request *workflowservice.QueryWorkflowRequest) (_ *workflowservice.QueryWorkflowResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendQueryWorkflowScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendQueryWorkflowScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -2989,7 +2931,7 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context, request *workflows
 func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx context.Context, request *workflowservice.DescribeWorkflowExecutionRequest) (_ *workflowservice.DescribeWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendDescribeWorkflowExecutionScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendDescribeWorkflowExecutionScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -3004,10 +2946,6 @@ func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx context.Context, reques
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	if request.GetNamespace() == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
 	}
@@ -3029,6 +2967,7 @@ func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx context.Context, reques
 		return nil, wh.error(err, scope)
 	}
 
+	wh.setCompletionCallbackStateHeader(ctx, response.GetCompletionCallbacks())
 	return &workflowservice.DescribeWorkflowExecutionResponse{
 		ExecutionConfiguration: response.GetExecutionConfiguration(),
 		WorkflowExecutionInfo:  response.GetWorkflowExecutionInfo(),
@@ -3042,7 +2981,7 @@ func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx context.Context, reques
 func (wh *WorkflowHandler) DescribeTaskList(ctx context.Context, request *workflowservice.DescribeTaskListRequest) (_ *workflowservice.DescribeTaskListResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendDescribeTaskListScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendDescribeTaskListScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -3057,10 +2996,6 @@ func (wh *WorkflowHandler) DescribeTaskList(ctx context.Context, request *workfl
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	if request.GetNamespace() == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
 	}
@@ -3098,11 +3033,6 @@ func (wh *WorkflowHandler) DescribeTaskList(ctx context.Context, request *workfl
 func (wh *WorkflowHandler) GetClusterInfo(ctx context.Context, _ *workflowservice.GetClusterInfoRequest) (_ *workflowservice.GetClusterInfoResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope := wh.getDefaultScope(metrics.FrontendClientGetClusterInfoScope)
-	if ok := wh.allow(""); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	return &workflowservice.GetClusterInfoResponse{
 		SupportedClientVersions: &versionpb.SupportedClientVersions{
 			GoSdk:   headers.SupportedGoSDKVersion,
@@ -3115,7 +3045,7 @@ func (wh *WorkflowHandler) GetClusterInfo(ctx context.Context, _ *workflowservic
 func (wh *WorkflowHandler) ListTaskListPartitions(ctx context.Context, request *workflowservice.ListTaskListPartitionsRequest) (_ *workflowservice.ListTaskListPartitionsResponse, retError error) {
 	defer log.CapturePanic(wh.GetLogger(), &retError)
 
-	scope, sw := wh.startRequestProfileWithNamespace(metrics.FrontendListTaskListPartitionsScope, request.GetNamespace())
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendListTaskListPartitionsScope, request.GetNamespace())
 	defer sw.Stop()
 
 	if wh.isShuttingDown() {
@@ -3126,10 +3056,6 @@ func (wh *WorkflowHandler) ListTaskListPartitions(ctx context.Context, request *
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok := wh.allow(request.GetNamespace()); !ok {
-		return nil, wh.error(errServiceBusy, scope)
-	}
-
 	if request.GetNamespace() == "" {
 		return nil, wh.error(errNamespaceNotSet, scope)
 	}
@@ -3153,7 +3079,128 @@ func (wh *WorkflowHandler) ListTaskListPartitions(ctx context.Context, request *
 	}, err
 }
 
+// StreamWorkflowExecutionHistory streams the history events of a workflow run starting from the requested event ID,
+// new events are sent as they are committed until the run is closed
+func (wh *WorkflowHandler) StreamWorkflowExecutionHistory(
+	request *workflowstreamservice.StreamWorkflowExecutionHistoryRequest,
+	stream workflowstreamservice.WorkflowStreamService_StreamWorkflowExecutionHistoryServer,
+) (retError error) {
+	defer log.CapturePanic(wh.GetLogger(), &retError)
+
+	ctx := stream.Context()
+	scope, sw := wh.startRequestProfileWithNamespace(ctx, metrics.FrontendStreamWorkflowExecutionHistoryScope, request.GetNamespace())
+	defer sw.Stop()
+
+	if wh.isShuttingDown() {
+		return errShuttingDown
+	}
+
+	if err := wh.versionChecker.ClientSupported(ctx, wh.config.EnableClientVersionCheck()); err != nil {
+		return wh.error(err, scope)
+	}
+
+	if request == nil {
+		return wh.error(errRequestNotSet, scope)
+	}
+
+	if request.GetNamespace() == "" {
+		return wh.error(errNamespaceNotSet, scope)
+	}
+
+	if err := wh.validateExecutionAndEmitMetrics(request.Execution, scope); err != nil {
+		return err
+	}
+
+	namespaceID, err := wh.GetNamespaceCache().GetNamespaceID(request.GetNamespace())
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	pageSize := int(request.GetMaximumPageSize())
+	if pageSize <= 0 {
+		pageSize = wh.config.HistoryMaxPageSize(request.GetNamespace())
+	}
+	pageSize = common.MinInt(pageSize, common.GetHistoryMaxPageSize)
+
+	nextEventID := common.MaxInt64(request.GetFirstEventId(), common.FirstEventID)
+	// updates are coalesced by the history service, so a slow receiver gets all the new events in one update
+	watcher, err := wh.GetHistoryClient().WatchMutableState(ctx, &historyservice.WatchMutableStateRequest{
+		NamespaceId:         namespaceID,
+		Execution:           request.Execution,
+		ExpectedNextEventId: nextEventID,
+	})
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	shardID := wh.GetHistoryShardLayout().GetShardID(request.Execution.GetWorkflowId())
+	for {
+		update, err := watcher.Recv()
+		if err == io.EOF {
+			// the history service closes the stream once the run is closed
+			return nil
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				// the caller went away, this is not a failure of the service
+				return ctx.Err()
+			}
+			return wh.error(err, scope)
+		}
+
+		sent := false
+		var pageToken []byte
+		for nextEventID < update.GetNextEventId() {
+			var events []*eventpb.HistoryEvent
+			events, _, pageToken, _, err = history.PaginateHistory(
+				wh.GetHistoryManager(),
+				false,
+				update.GetCurrentBranchToken(),
+				nextEventID,
+				update.GetNextEventId(),
+				pageToken,
+				pageSize,
+				&shardID,
+			)
+			if err != nil {
+				return wh.error(err, scope)
+			}
+			if len(events) == 0 {
+				break
+			}
+			if err := wh.rehydratePayloads(ctx, namespaceID, update.Execution.GetRunId(), events); err != nil {
+				return wh.error(err, scope)
+			}
+			if err := stream.Send(&workflowstreamservice.StreamWorkflowExecutionHistoryResponse{
+				RunId:          update.Execution.GetRunId(),
+				Events:         events,
+				NextEventId:    update.GetNextEventId(),
+				WorkflowStatus: update.GetWorkflowStatus(),
+			}); err != nil {
+				return err
+			}
+			sent = true
+			nextEventID = events[len(events)-1].GetEventId() + 1
+			if len(pageToken) == 0 {
+				break
+			}
+		}
+
+		// a closed run is always reported, even when all of its events were already sent
+		if !sent && update.GetWorkflowStatus() != executionpb.WorkflowExecutionStatus_Running {
+			if err := stream.Send(&workflowstreamservice.StreamWorkflowExecutionHistoryResponse{
+				RunId:          update.Execution.GetRunId(),
+				NextEventId:    update.GetNextEventId(),
+				WorkflowStatus: update.GetWorkflowStatus(),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 func (wh *WorkflowHandler) getRawHistory(
+	ctx context.Context,
 	scope metrics.Scope,
 	namespaceID string,
 	execution commonpb.WorkflowExecution,
@@ -3165,7 +3212,7 @@ func (wh *WorkflowHandler) getRawHistory(
 	branchToken []byte,
 ) ([]*commonpb.DataBlob, []byte, error) {
 	var rawHistory []*commonpb.DataBlob
-	shardID := common.WorkflowIDToHistoryShard(execution.GetWorkflowId(), wh.config.NumHistoryShards)
+	shardID := wh.GetHistoryShardLayout().GetShardID(execution.GetWorkflowId())
 
 	resp, err := wh.GetHistoryManager().ReadRawHistoryBranch(&persistence.ReadHistoryBranchRequest{
 		BranchToken:   branchToken,
@@ -3180,7 +3227,23 @@ func (wh *WorkflowHandler) getRawHistory(
 	}
 
 	var encoding commonpb.EncodingType
+	namespaceEntry, err := wh.GetNamespaceCache().GetNamespaceByID(namespaceID)
+	if err != nil {
+		return nil, nil, err
+	}
 	for _, data := range resp.HistoryEventBlobs {
+		data, err = blobstore.RehydrateEventBlob(
+			ctx,
+			wh.GetPayloadOffloader(),
+			wh.GetPayloadSerializer(),
+			namespaceEntry.GetConfig().GetHistoryArchivalURI(),
+			namespaceID,
+			execution.GetRunId(),
+			data,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
 		switch data.Encoding {
 		case common.EncodingTypeJSON:
 			encoding = commonpb.EncodingType_JSON
@@ -3227,7 +3290,32 @@ func (wh *WorkflowHandler) getRawHistory(
 	return rawHistory, resp.NextPageToken, nil
 }
 
+// rehydratePayloads replaces the blob references of the message with the payloads offloaded for the execution
+func (wh *WorkflowHandler) rehydratePayloads(
+	ctx context.Context,
+	namespaceID string,
+	runID string,
+	message interface{},
+) error {
+
+	if !blobstore.HasBlobReference(message) {
+		return nil
+	}
+	namespaceEntry, err := wh.GetNamespaceCache().GetNamespaceByID(namespaceID)
+	if err != nil {
+		return err
+	}
+	return wh.GetPayloadOffloader().Rehydrate(
+		ctx,
+		namespaceEntry.GetConfig().GetHistoryArchivalURI(),
+		namespaceID,
+		runID,
+		message,
+	)
+}
+
 func (wh *WorkflowHandler) getHistory(
+	ctx context.Context,
 	scope metrics.Scope,
 	namespaceID string,
 	execution commonpb.WorkflowExecution,
@@ -3241,7 +3329,7 @@ func (wh *WorkflowHandler) getHistory(
 	var size int
 
 	isFirstPage := len(nextPageToken) == 0
-	shardID := common.WorkflowIDToHistoryShard(execution.GetWorkflowId(), wh.config.NumHistoryShards)
+	shardID := wh.GetHistoryShardLayout().GetShardID(execution.GetWorkflowId())
 	var err error
 	var historyEvents []*eventpb.HistoryEvent
 	historyEvents, size, nextPageToken, err = persistence.ReadFullPageV2Events(wh.GetHistoryManager(), &persistence.ReadHistoryBranchRequest{
@@ -3258,6 +3346,10 @@ func (wh *WorkflowHandler) getHistory(
 
 	scope.RecordTimer(metrics.HistorySize, time.Duration(size))
 
+	if err := wh.rehydratePayloads(ctx, namespaceID, execution.GetRunId(), historyEvents); err != nil {
+		return nil, nil, err
+	}
+
 	isLastPage := len(nextPageToken) == 0
 	if err := wh.verifyHistoryIsComplete(
 		historyEvents,
@@ -3311,23 +3403,23 @@ func (wh *WorkflowHandler) validateTransientDecisionEvents(
 }
 
 // startRequestProfile initiates recording of request metrics
-func (wh *WorkflowHandler) startRequestProfile(scope int) (metrics.Scope, metrics.Stopwatch) {
+func (wh *WorkflowHandler) startRequestProfile(ctx context.Context, scope int) (metrics.Scope, metrics.Stopwatch) {
 	metricsScope := wh.GetMetricsClient().Scope(scope).Tagged(metrics.NamespaceUnknownTag())
 	// timer should be emitted with the all tag
-	sw := metricsScope.StartTimer(metrics.ServiceLatency)
+	sw := metricsScope.StartTimerWithContext(ctx, metrics.ServiceLatency)
 	metricsScope.IncCounter(metrics.ServiceRequests)
 	return metricsScope, sw
 }
 
 // startRequestProfileWithNamespace initiates recording of request metrics and returns a namespace tagged scope
-func (wh *WorkflowHandler) startRequestProfileWithNamespace(scope int, namespace string) (metrics.Scope, metrics.Stopwatch) {
+func (wh *WorkflowHandler) startRequestProfileWithNamespace(ctx context.Context, scope int, namespace string) (metrics.Scope, metrics.Stopwatch) {
 	var metricsScope metrics.Scope
 	if namespace != "" {
 		metricsScope = wh.GetMetricsClient().Scope(scope).Tagged(metrics.NamespaceTag(namespace))
 	} else {
 		metricsScope = wh.GetMetricsClient().Scope(scope).Tagged(metrics.NamespaceUnknownTag())
 	}
-	sw := metricsScope.StartTimer(metrics.ServiceLatency)
+	sw := metricsScope.StartTimerWithContext(ctx, metrics.ServiceLatency)
 	metricsScope.IncCounter(metrics.ServiceRequests)
 	return metricsScope, sw
 }
@@ -3399,6 +3491,50 @@ func (wh *WorkflowHandler) validateExecutionAndEmitMetrics(w *commonpb.WorkflowE
 	return nil
 }
 
+// getCompletionCallbackURLs returns the validated completion callback URLs passed as headers,
+// StartWorkflowExecutionRequest has no field to carry them.
+func (wh *WorkflowHandler) getCompletionCallbackURLs(ctx context.Context, namespace string) ([]string, error) {
+	callbackURLs := headers.GetAllValues(ctx, headers.CompletionCallbackURLHeaderName)
+	if len(callbackURLs) > wh.config.MaxCompletionCallbacks(namespace) {
+		return nil, errTooManyCompletionCallbacks
+	}
+	for _, callbackURL := range callbackURLs {
+		u, err := url.Parse(callbackURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, errInvalidCompletionCallbackURL
+		}
+		if !common.IsCompletionCallbackURLAllowed(callbackURL, wh.config.CompletionCallbackAllowedHosts(namespace)) {
+			return nil, errCompletionCallbackURLNotAllowed
+		}
+	}
+	return callbackURLs, nil
+}
+
+// setCompletionCallbackStateHeader returns the state of workflow completion callbacks as response header,
+// DescribeWorkflowExecutionResponse has no field to carry them.
+func (wh *WorkflowHandler) setCompletionCallbackStateHeader(
+	ctx context.Context,
+	callbacks []*executiongenpb.CompletionCallbackInfo,
+) {
+	if len(callbacks) == 0 {
+		return
+	}
+
+	encoder := codec.NewJSONPBEncoder()
+	md := metadata.MD{}
+	for _, callback := range callbacks {
+		state, err := encoder.Encode(callback)
+		if err != nil {
+			wh.GetLogger().Warn("Unable to encode completion callback state.", tag.Error(err))
+			return
+		}
+		md.Append(headers.CompletionCallbackStateHeaderName, string(state))
+	}
+	if err := grpc.SetHeader(ctx, md); err != nil {
+		wh.GetLogger().Debug("Unable to set completion callback state header.", tag.Error(err))
+	}
+}
+
 func (wh *WorkflowHandler) createPollForDecisionTaskResponse(
 	ctx context.Context,
 	scope metrics.Scope,
@@ -3443,6 +3579,7 @@ func (wh *WorkflowHandler) createPollForDecisionTaskResponse(
 		}
 		scope = scope.Tagged(metrics.NamespaceTag(namespace.GetInfo().Name))
 		history, persistenceToken, err = wh.getHistory(
+			ctx,
 			scope,
 			namespaceID,
 			*matchingResp.GetWorkflowExecution(),
@@ -3637,9 +3774,6 @@ func (wh *WorkflowHandler) isListRequestPageSizeTooLarge(pageSize int32, namespa
 		pageSize > int32(wh.config.ESIndexMaxResultWindow())
 }
 
-func (wh *WorkflowHandler) allow(namespace string) bool {
-	return wh.rateLimiter.Allow(quotas.Info{Namespace: namespace})
-}
 func (wh *WorkflowHandler) checkPermission(
 	config *Config,
 	securityToken string,
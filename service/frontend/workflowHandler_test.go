@@ -27,6 +27,7 @@ package frontend
 import (
 	"context"
 	"errors"
+	"io"
 	"testing"
 	"time"
 
@@ -35,14 +36,18 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/historyservicemock"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservice"
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservicemock"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/convert"
+	"github.com/temporalio/temporal/common/headers"
 	"github.com/temporalio/temporal/common/messaging"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/mocks"
@@ -60,6 +65,7 @@ import (
 	"go.temporal.io/temporal-proto/serviceerror"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 	"go.temporal.io/temporal-proto/workflowservice"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
@@ -146,7 +152,7 @@ func (s *workflowHandlerSuite) TearDownTest() {
 }
 
 func (s *workflowHandlerSuite) getWorkflowHandler(config *Config) *WorkflowHandler {
-	return NewWorkflowHandler(s.mockResource, config, s.mockProducer).(*WorkflowHandler)
+	return NewWorkflowHandler(s.mockResource, config, NewNamespaceHandler(s.mockResource, config, s.mockProducer)).(*WorkflowHandler)
 }
 
 func (s *workflowHandlerSuite) TestDisableListVisibilityByFilter() {
@@ -238,6 +244,31 @@ func (s *workflowHandlerSuite) TestPollForTask_Failed_ContextTimeoutTooShort() {
 	s.Equal(common.ErrContextTimeoutTooShort, err)
 }
 
+func (s *workflowHandlerSuite) TestPollForTask_Failed_NamespaceDeleted() {
+	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(
+		&persistenceblobs.NamespaceInfo{Id: s.testNamespaceID, Name: s.testNamespace, Status: namespacepb.NamespaceStatus_Deleted},
+		&persistenceblobs.NamespaceConfig{RetentionDays: 1},
+		"",
+		nil)
+	s.mockNamespaceCache.EXPECT().GetNamespace(s.testNamespace).Return(namespaceEntry, nil).Times(2)
+
+	wh := s.getWorkflowHandler(s.newConfig())
+	ctx, cancel := context.WithTimeout(context.Background(), common.MinLongPollTimeout+time.Second)
+	defer cancel()
+
+	_, err := wh.PollForDecisionTask(ctx, &workflowservice.PollForDecisionTaskRequest{
+		Namespace: s.testNamespace,
+		TaskList:  &tasklistpb.TaskList{Name: "task-list"},
+	})
+	s.Equal(errNamespaceDeleted, err)
+
+	_, err = wh.PollForActivityTask(ctx, &workflowservice.PollForActivityTaskRequest{
+		Namespace: s.testNamespace,
+		TaskList:  &tasklistpb.TaskList{Name: "task-list"},
+	})
+	s.Equal(errNamespaceDeleted, err)
+}
+
 func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_RequestIdNotSet() {
 	config := s.newConfig()
 	config.RPS = dc.GetIntPropertyFn(10)
@@ -478,6 +509,53 @@ func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_InvalidTaskTime
 	s.Equal(errInvalidWorkflowTaskTimeoutSeconds, err)
 }
 
+func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_InvalidCompletionCallbackURL() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	config.CompletionCallbackAllowedHosts = dc.GetStringPropertyFnFilteredByNamespace("localhost")
+	wh := s.getWorkflowHandler(config)
+
+	ctx := contextWithCompletionCallbacks(
+		"http://localhost:8080/callback",
+		"ftp://localhost/callback",
+	)
+	_, err := wh.StartWorkflowExecution(ctx, newStartWorkflowExecutionRequest())
+	s.Error(err)
+	s.Equal(errInvalidCompletionCallbackURL, err)
+}
+
+func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_CompletionCallbackURLNotAllowed() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	config.CompletionCallbackAllowedHosts = dc.GetStringPropertyFnFilteredByNamespace("localhost, *.example.com")
+	wh := s.getWorkflowHandler(config)
+
+	ctx := contextWithCompletionCallbacks(
+		"http://localhost:8080/callback",
+		"https://hooks.example.com/callback",
+		"http://169.254.169.254/latest/meta-data",
+	)
+	_, err := wh.StartWorkflowExecution(ctx, newStartWorkflowExecutionRequest())
+	s.Error(err)
+	s.Equal(errCompletionCallbackURLNotAllowed, err)
+}
+
+func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_TooManyCompletionCallbacks() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	config.MaxCompletionCallbacks = dc.GetIntPropertyFilteredByNamespace(1)
+	config.CompletionCallbackAllowedHosts = dc.GetStringPropertyFnFilteredByNamespace("localhost")
+	wh := s.getWorkflowHandler(config)
+
+	ctx := contextWithCompletionCallbacks(
+		"http://localhost:8080/a",
+		"http://localhost:8080/b",
+	)
+	_, err := wh.StartWorkflowExecution(ctx, newStartWorkflowExecutionRequest())
+	s.Error(err)
+	s.Equal(errTooManyCompletionCallbacks, err)
+}
+
 func (s *workflowHandlerSuite) TestRegisterNamespace_Failure_InvalidArchivalURI() {
 	s.mockClusterMetadata.EXPECT().IsGlobalNamespaceEnabled().Return(false)
 	s.mockArchivalMetadata.On("GetHistoryConfig").Return(archiver.NewArchivalConfig("enabled", dc.GetStringPropertyFn("enabled"), dc.GetBoolPropertyFn(true), "disabled", "random URI"))
@@ -1029,12 +1107,74 @@ func (s *workflowHandlerSuite) TestGetHistory() {
 	wh := s.getWorkflowHandler(s.newConfig())
 
 	scope := metrics.NoopScope(metrics.Frontend)
-	history, token, err := wh.getHistory(scope, namespaceID, we, firstEventID, nextEventID, 0, []byte{}, nil, branchToken)
+	history, token, err := wh.getHistory(context.Background(), scope, namespaceID, we, firstEventID, nextEventID, 0, []byte{}, nil, branchToken)
 	s.NoError(err)
 	s.NotNil(history)
 	s.Equal([]byte{}, token)
 }
 
+func (s *workflowHandlerSuite) TestStreamWorkflowExecutionHistory() {
+	ctx := context.Background()
+	wh := s.getWorkflowHandler(s.newConfig())
+	s.mockNamespaceCache.EXPECT().GetNamespaceID(s.testNamespace).Return(s.testNamespaceID, nil).Times(1)
+	runID := uuid.New()
+	branchToken := []byte{1}
+	execution := &commonpb.WorkflowExecution{WorkflowId: "workflowID", RunId: runID}
+
+	watcher := historyservicemock.NewMockHistoryService_WatchMutableStateClient(s.controller)
+	gomock.InOrder(
+		watcher.EXPECT().Recv().Return(&historyservice.WatchMutableStateResponse{
+			Execution:          execution,
+			NextEventId:        3,
+			CurrentBranchToken: branchToken,
+			WorkflowStatus:     executionpb.WorkflowExecutionStatus_Running,
+		}, nil),
+		watcher.EXPECT().Recv().Return(&historyservice.WatchMutableStateResponse{
+			Execution:          execution,
+			NextEventId:        3,
+			CurrentBranchToken: branchToken,
+			WorkflowStatus:     executionpb.WorkflowExecutionStatus_Completed,
+		}, nil),
+		watcher.EXPECT().Recv().Return(nil, io.EOF),
+	)
+	s.mockHistoryClient.EXPECT().WatchMutableState(gomock.Any(), &historyservice.WatchMutableStateRequest{
+		NamespaceId:         s.testNamespaceID,
+		Execution:           &commonpb.WorkflowExecution{WorkflowId: "workflowID"},
+		ExpectedNextEventId: common.FirstEventID,
+	}).Return(watcher, nil).Times(1)
+	events := []*eventpb.HistoryEvent{{EventId: 1, Version: 100}, {EventId: 2, Version: 100}}
+	s.mockHistoryV2Mgr.On("ReadHistoryBranch", mock.Anything).Return(&persistence.ReadHistoryBranchResponse{
+		HistoryEvents: events,
+		NextPageToken: nil,
+	}, nil).Once()
+
+	stream := workflowstreamservicemock.NewMockWorkflowStreamService_StreamWorkflowExecutionHistoryServer(s.controller)
+	stream.EXPECT().Context().Return(ctx).AnyTimes()
+	gomock.InOrder(
+		stream.EXPECT().Send(&workflowstreamservice.StreamWorkflowExecutionHistoryResponse{
+			RunId:          runID,
+			Events:         events,
+			NextEventId:    3,
+			WorkflowStatus: executionpb.WorkflowExecutionStatus_Running,
+		}).Return(nil),
+		// the events of the closed run were already sent, only its status is
+		stream.EXPECT().Send(&workflowstreamservice.StreamWorkflowExecutionHistoryResponse{
+			RunId:          runID,
+			NextEventId:    3,
+			WorkflowStatus: executionpb.WorkflowExecutionStatus_Completed,
+		}).Return(nil),
+	)
+
+	err := wh.StreamWorkflowExecutionHistory(&workflowstreamservice.StreamWorkflowExecutionHistoryRequest{
+		Namespace: s.testNamespace,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: "workflowID",
+		},
+		MaximumPageSize: 100,
+	}, stream)
+	s.NoError(err)
+}
+
 func (s *workflowHandlerSuite) TestListArchivedVisibility_Failure_InvalidRequest() {
 	wh := s.getWorkflowHandler(s.newConfig())
 
@@ -1323,6 +1463,31 @@ func (s *workflowHandlerSuite) newConfig() *Config {
 	return NewConfig(dc.NewCollection(dc.NewNopClient(), s.mockResource.GetLogger()), numHistoryShards, false)
 }
 
+func contextWithCompletionCallbacks(callbackURLs ...string) context.Context {
+	md := metadata.MD{}
+	for _, callbackURL := range callbackURLs {
+		md.Append(headers.CompletionCallbackURLHeaderName, callbackURL)
+	}
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func newStartWorkflowExecutionRequest() *workflowservice.StartWorkflowExecutionRequest {
+	return &workflowservice.StartWorkflowExecutionRequest{
+		Namespace:  "test-namespace",
+		WorkflowId: "workflow-id",
+		WorkflowType: &commonpb.WorkflowType{
+			Name: "workflow-type",
+		},
+		TaskList: &tasklistpb.TaskList{
+			Name: "task-list",
+		},
+		WorkflowExecutionTimeoutSeconds: 1,
+		WorkflowRunTimeoutSeconds:       1,
+		WorkflowTaskTimeoutSeconds:      1,
+		RequestId:                       uuid.New(),
+	}
+}
+
 func updateRequest(
 	historyArchivalURI string,
 	historyArchivalStatus namespacepb.ArchivalStatus,
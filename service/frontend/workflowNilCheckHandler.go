@@ -28,9 +28,12 @@ import (
 	"context"
 
 	"go.temporal.io/temporal-proto/workflowservice"
+
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservice"
 )
 
 var _ workflowservice.WorkflowServiceServer = (*WorkflowNilCheckHandler)(nil)
+var _ workflowstreamservice.WorkflowStreamServiceServer = (*WorkflowNilCheckHandler)(nil)
 
 type (
 	// WorkflowNilCheckHandler - gRPC handler interface for workflow workflowservice
@@ -473,3 +476,8 @@ func (wh *WorkflowNilCheckHandler) ListTaskListPartitions(ctx context.Context, r
 	}
 	return resp, err
 }
+
+// StreamWorkflowExecutionHistory ...
+func (wh *WorkflowNilCheckHandler) StreamWorkflowExecutionHistory(request *workflowstreamservice.StreamWorkflowExecutionHistoryRequest, stream workflowstreamservice.WorkflowStreamService_StreamWorkflowExecutionHistoryServer) error {
+	return wh.parentHandler.StreamWorkflowExecutionHistory(request, stream)
+}
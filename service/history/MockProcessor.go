@@ -25,6 +25,8 @@
 package history
 
 import (
+	"context"
+
 	"github.com/stretchr/testify/mock"
 )
 
@@ -34,19 +36,19 @@ type MockProcessor struct {
 }
 
 // process is mock implementation for process of Processor
-func (_m *MockProcessor) process(task *taskInfo) (int, error) {
-	ret := _m.Called(task)
+func (_m *MockProcessor) process(ctx context.Context, task *taskInfo) (int, error) {
+	ret := _m.Called(ctx, task)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(*taskInfo) int); ok {
-		r0 = rf(task)
+	if rf, ok := ret.Get(0).(func(context.Context, *taskInfo) int); ok {
+		r0 = rf(ctx, task)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*taskInfo) error); ok {
-		r1 = rf(task)
+	if rf, ok := ret.Get(1).(func(context.Context, *taskInfo) error); ok {
+		r1 = rf(ctx, task)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,220 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	eventpb "go.temporal.io/temporal-proto/event"
+	executionpb "go.temporal.io/temporal-proto/execution"
+
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
+	"github.com/temporalio/temporal/common/backoff"
+	"github.com/temporalio/temporal/common/codec"
+)
+
+const (
+	completionCallbackContentType = "application/json"
+
+	completionCallbackInitialInterval = time.Second
+	completionCallbackMaximumInterval = 10 * time.Minute
+)
+
+var (
+	// errCompletionCallbackNotDelivered is returned while completion callbacks are pending a retry,
+	// the task is rescheduled by the task processor
+	errCompletionCallbackNotDelivered = errors.New("workflow completion callback is not delivered yet")
+	// errCompletionCallbackDeadLetter is returned when completion callbacks exhausted their attempts,
+	// the task is moved to the transfer task DLQ
+	errCompletionCallbackDeadLetter = errors.New("workflow completion callback cannot be delivered")
+	// errCompletionCallbackURLNotAllowed is the delivery failure of a callback whose host is not allowed
+	errCompletionCallbackURLNotAllowed = errors.New("completion callback URL host is not allowed")
+)
+
+type (
+	// completionCallbackRequest is the body posted to the completion callback URLs of a closed workflow
+	completionCallbackRequest struct {
+		Namespace  string          `json:"namespace"`
+		WorkflowID string          `json:"workflowId"`
+		RunID      string          `json:"runId"`
+		Status     string          `json:"status"`
+		CloseEvent json.RawMessage `json:"closeEvent"`
+	}
+
+	completionCallbackStatusError struct {
+		statusCode int
+	}
+)
+
+func (e *completionCallbackStatusError) Error() string {
+	return fmt.Sprintf("completion callback responded with status code %v", e.statusCode)
+}
+
+func newCompletionCallbacks(
+	urls []string,
+) []*executiongenpb.CompletionCallbackInfo {
+
+	if len(urls) == 0 {
+		return nil
+	}
+
+	callbacks := make([]*executiongenpb.CompletionCallbackInfo, 0, len(urls))
+	for _, url := range urls {
+		callbacks = append(callbacks, &executiongenpb.CompletionCallbackInfo{
+			Url:   url,
+			State: executiongenpb.CompletionCallbackState_CompletionCallbackState_Scheduled,
+		})
+	}
+	return callbacks
+}
+
+func getCompletionCallbackURLs(
+	callbacks []*executiongenpb.CompletionCallbackInfo,
+) []string {
+
+	if len(callbacks) == 0 {
+		return nil
+	}
+
+	urls := make([]string, 0, len(callbacks))
+	for _, callback := range callbacks {
+		urls = append(urls, callback.GetUrl())
+	}
+	return urls
+}
+
+// inheritCompletionCallbacks returns the callbacks of a run created from the base run by a reset,
+// the callbacks are delivered once the new run closes
+func inheritCompletionCallbacks(
+	baseCallbacks []*executiongenpb.CompletionCallbackInfo,
+) []*executiongenpb.CompletionCallbackInfo {
+
+	return newCompletionCallbacks(getCompletionCallbackURLs(baseCallbacks))
+}
+
+// copyCompletionCallbacks returns a deep copy of the callbacks, including their delivery state
+func copyCompletionCallbacks(
+	callbacks []*executiongenpb.CompletionCallbackInfo,
+) []*executiongenpb.CompletionCallbackInfo {
+
+	if len(callbacks) == 0 {
+		return nil
+	}
+
+	result := make([]*executiongenpb.CompletionCallbackInfo, 0, len(callbacks))
+	for _, callback := range callbacks {
+		result = append(result, proto.Clone(callback).(*executiongenpb.CompletionCallbackInfo))
+	}
+	return result
+}
+
+// newCompletionCallbackRetryPolicy returns the policy deciding when a failed callback is attempted again,
+// a negative delay means the callback ran out of attempts
+func newCompletionCallbackRetryPolicy(
+	maxAttempts int,
+) backoff.RetryPolicy {
+
+	policy := backoff.NewExponentialRetryPolicy(completionCallbackInitialInterval)
+	policy.SetMaximumInterval(completionCallbackMaximumInterval)
+	policy.SetExpirationInterval(backoff.NoInterval)
+	policy.SetMaximumAttempts(maxAttempts)
+	return policy
+}
+
+func newCompletionCallbackBody(
+	namespace string,
+	workflowID string,
+	runID string,
+	status executionpb.WorkflowExecutionStatus,
+	closeEvent *eventpb.HistoryEvent,
+) ([]byte, error) {
+
+	event, err := codec.NewJSONPBEncoder().Encode(closeEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&completionCallbackRequest{
+		Namespace:  namespace,
+		WorkflowID: workflowID,
+		RunID:      runID,
+		Status:     status.String(),
+		CloseEvent: event,
+	})
+}
+
+func postCompletionCallback(
+	ctx context.Context,
+	client *http.Client,
+	url string,
+	body []byte,
+) error {
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", completionCallbackContentType)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	// drain the body so that the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return &completionCallbackStatusError{statusCode: response.StatusCode}
+	}
+	return nil
+}
+
+func isCompletionCallbackRetryableError(
+	err error,
+) bool {
+
+	if err == errCompletionCallbackURLNotAllowed {
+		return false
+	}
+	if statusErr, ok := err.(*completionCallbackStatusError); ok {
+		// client errors are not going to succeed on retry, except for throttling and timeout
+		return statusErr.statusCode >= http.StatusInternalServerError ||
+			statusErr.statusCode == http.StatusTooManyRequests ||
+			statusErr.statusCode == http.StatusRequestTimeout
+	}
+	// transport level errors
+	return true
+}
@@ -0,0 +1,169 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	eventpb "go.temporal.io/temporal-proto/event"
+	executionpb "go.temporal.io/temporal-proto/execution"
+
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
+	"github.com/temporalio/temporal/common"
+)
+
+type (
+	completionCallbackSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+)
+
+func TestCompletionCallbackSuite(t *testing.T) {
+	s := new(completionCallbackSuite)
+	suite.Run(t, s)
+}
+
+func (s *completionCallbackSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *completionCallbackSuite) TestNewCompletionCallbacks() {
+	s.Nil(newCompletionCallbacks(nil))
+
+	urls := []string{"http://localhost:8080/a", "https://localhost/b"}
+	callbacks := newCompletionCallbacks(urls)
+	s.Len(callbacks, 2)
+	for i, callback := range callbacks {
+		s.Equal(urls[i], callback.GetUrl())
+		s.Equal(executiongenpb.CompletionCallbackState_CompletionCallbackState_Scheduled, callback.GetState())
+		s.Zero(callback.GetAttempt())
+	}
+	s.Equal(urls, getCompletionCallbackURLs(callbacks))
+}
+
+func (s *completionCallbackSuite) TestCopyCompletionCallbacks() {
+	s.Nil(copyCompletionCallbacks(nil))
+
+	callbacks := newCompletionCallbacks([]string{"http://localhost:8080/a"})
+	callbacks[0].Attempt = 2
+	callbacks[0].LastFailure = "some random failure"
+	copied := copyCompletionCallbacks(callbacks)
+	s.Equal(callbacks, copied)
+
+	copied[0].State = executiongenpb.CompletionCallbackState_CompletionCallbackState_Succeeded
+	s.Equal(executiongenpb.CompletionCallbackState_CompletionCallbackState_Scheduled, callbacks[0].GetState())
+}
+
+func (s *completionCallbackSuite) TestCompletionCallbackRetryPolicy() {
+	retryPolicy := newCompletionCallbackRetryPolicy(3)
+	for attempt := 1; attempt < 3; attempt++ {
+		delay := retryPolicy.ComputeNextDelay(0, attempt)
+		s.True(delay >= completionCallbackInitialInterval)
+		s.True(delay <= completionCallbackMaximumInterval)
+	}
+	s.True(retryPolicy.ComputeNextDelay(0, 3) < 0)
+
+	// the attempts of a callback are not bound by time
+	s.True(retryPolicy.ComputeNextDelay(24*time.Hour, 1) >= completionCallbackInitialInterval)
+}
+
+func (s *completionCallbackSuite) TestPostCompletionCallback() {
+	closeEvent := &eventpb.HistoryEvent{
+		EventId:   5,
+		EventType: eventpb.EventType_WorkflowExecutionCompleted,
+	}
+	body, err := newCompletionCallbackBody(
+		"test-namespace",
+		"test-workflow-id",
+		"test-run-id",
+		executionpb.WorkflowExecutionStatus_Completed,
+		closeEvent,
+	)
+	s.NoError(err)
+
+	var received completionCallbackRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPost, r.Method)
+		s.Equal(completionCallbackContentType, r.Header.Get("Content-Type"))
+		data, err := ioutil.ReadAll(r.Body)
+		s.NoError(err)
+		s.NoError(json.Unmarshal(data, &received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	s.NoError(postCompletionCallback(context.Background(), server.Client(), server.URL, body))
+	s.Equal("test-namespace", received.Namespace)
+	s.Equal("test-workflow-id", received.WorkflowID)
+	s.Equal("test-run-id", received.RunID)
+	s.Equal(executionpb.WorkflowExecutionStatus_Completed.String(), received.Status)
+	s.NotEmpty(received.CloseEvent)
+}
+
+func (s *completionCallbackSuite) TestPostCompletionCallback_ErrorStatus() {
+	statusCode := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	err := postCompletionCallback(context.Background(), server.Client(), server.URL, []byte("{}"))
+	s.Error(err)
+	s.True(isCompletionCallbackRetryableError(err))
+
+	statusCode = http.StatusTooManyRequests
+	err = postCompletionCallback(context.Background(), server.Client(), server.URL, []byte("{}"))
+	s.Error(err)
+	s.True(isCompletionCallbackRetryableError(err))
+
+	statusCode = http.StatusBadRequest
+	err = postCompletionCallback(context.Background(), server.Client(), server.URL, []byte("{}"))
+	s.Error(err)
+	s.False(isCompletionCallbackRetryableError(err))
+
+	s.False(isCompletionCallbackRetryableError(errCompletionCallbackURLNotAllowed))
+}
+
+func (s *completionCallbackSuite) TestIsCompletionCallbackURLAllowed() {
+	allowedHosts := "localhost, *.example.com"
+
+	s.True(common.IsCompletionCallbackURLAllowed("http://localhost:8080/callback", allowedHosts))
+	s.True(common.IsCompletionCallbackURLAllowed("https://hooks.example.com/callback", allowedHosts))
+	s.True(common.IsCompletionCallbackURLAllowed("https://A.Hooks.Example.com/callback", allowedHosts))
+	s.False(common.IsCompletionCallbackURLAllowed("https://example.com/callback", allowedHosts))
+	s.False(common.IsCompletionCallbackURLAllowed("https://evilexample.com/callback", allowedHosts))
+	s.False(common.IsCompletionCallbackURLAllowed("http://169.254.169.254/latest/meta-data", allowedHosts))
+	s.False(common.IsCompletionCallbackURLAllowed("http://localhost:8080/callback", ""))
+	s.False(common.IsCompletionCallbackURLAllowed("://localhost", allowedHosts))
+}
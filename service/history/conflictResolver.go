@@ -130,6 +130,8 @@ func (r *conflictResolverImpl) reset(
 				r.logger,
 				namespaceEntry,
 			)
+			// completion callbacks are not part of the history, keep their delivery state
+			resetMutableStateBuilder.executionInfo.CompletionCallbacks = copyCompletionCallbacks(info.CompletionCallbacks)
 
 			sBuilder = newStateBuilder(
 				r.shard,
@@ -182,7 +182,7 @@ func (h *Handler) Start() {
 		h,
 		h.config,
 	)
-	h.historyEventNotifier = newHistoryEventNotifier(h.GetTimeSource(), h.GetMetricsClient(), h.config.GetShardID)
+	h.historyEventNotifier = newHistoryEventNotifier(h.GetTimeSource(), h.GetMetricsClient(), h.GetHistoryShardLayout().GetShardID)
 	// events notifier must starts before controller
 	h.historyEventNotifier.Start()
 	h.controller.Start()
@@ -682,6 +682,7 @@ func (h *Handler) DescribeHistoryHost(_ context.Context, _ *historyservice.Descr
 		},
 		ShardControllerStatus: status,
 		Address:               h.GetHostInfo().GetAddress(),
+		ShardLoads:            h.controller.shardLoads(),
 	}
 	return resp, nil
 }
@@ -721,6 +722,64 @@ func (h *Handler) CloseShard(_ context.Context, request *historyservice.CloseSha
 	return &historyservice.CloseShardResponse{}, nil
 }
 
+// SetShardOwner assigns a shard owned by this host to another history host
+func (h *Handler) SetShardOwner(_ context.Context, request *historyservice.SetShardOwnerRequest) (_ *historyservice.SetShardOwnerResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistorySetShardOwnerScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	if err := h.controller.setShardOwner(
+		int(request.GetShardId()),
+		request.GetHostAddress(),
+		request.GetPinned(),
+	); err != nil {
+		return nil, h.error(err, scope, "", "")
+	}
+	return &historyservice.SetShardOwnerResponse{}, nil
+}
+
+// DrainHost moves this host out of the membership ring so that its shards are taken over by
+// other hosts, without stopping the process, or puts the host back into the ring
+func (h *Handler) DrainHost(_ context.Context, request *historyservice.DrainHostRequest) (_ *historyservice.DrainHostResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryDrainHostScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	var err error
+	if request.GetUndrain() {
+		err = h.controller.undrain()
+	} else {
+		err = h.controller.drain()
+	}
+	if err != nil {
+		return nil, h.error(err, scope, "", "")
+	}
+
+	draining := h.controller.isDraining()
+	numShards := h.controller.numShards()
+	return &historyservice.DrainHostResponse{
+		Draining:       draining,
+		Drained:        draining && numShards == 0,
+		NumberOfShards: int32(numShards),
+	}, nil
+}
+
 // DescribeMutableState - returns the internal analysis of workflow execution state
 func (h *Handler) DescribeMutableState(ctx context.Context, request *historyservice.DescribeMutableStateRequest) (_ *historyservice.DescribeMutableStateResponse, retError error) {
 	defer log.CapturePanic(h.GetLogger(), &retError)
@@ -816,6 +875,37 @@ func (h *Handler) PollMutableState(ctx context.Context, request *historyservice.
 	return resp, nil
 }
 
+// WatchMutableState - streams the id of the next event in the execution's history as new events are committed
+func (h *Handler) WatchMutableState(request *historyservice.WatchMutableStateRequest, stream historyservice.HistoryService_WatchMutableStateServer) (retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryWatchMutableStateScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+
+	namespaceID := request.GetNamespaceId()
+	if namespaceID == "" {
+		return h.error(errNamespaceNotSet, scope, namespaceID, "")
+	}
+
+	if ok := h.rateLimiter.Allow(); !ok {
+		return h.error(errHistoryHostThrottle, scope, namespaceID, "")
+	}
+
+	workflowID := request.Execution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return h.error(err1, scope, namespaceID, workflowID)
+	}
+
+	err2 := engine.WatchMutableState(stream.Context(), request, stream.Send)
+	if err2 != nil && stream.Context().Err() == nil {
+		// the stream is not a failure when the receiver went away
+		return h.error(err2, scope, namespaceID, workflowID)
+	}
+	return err2
+}
+
 // DescribeWorkflowExecution returns information about the specified workflow execution.
 func (h *Handler) DescribeWorkflowExecution(ctx context.Context, request *historyservice.DescribeWorkflowExecutionRequest) (_ *historyservice.DescribeWorkflowExecutionResponse, retError error) {
 	defer log.CapturePanic(h.GetLogger(), &retError)
@@ -1523,6 +1613,36 @@ func (h *Handler) GetReplicationMessages(ctx context.Context, request *historyse
 	return &historyservice.GetReplicationMessagesResponse{MessagesByShard: messagesByShard}, nil
 }
 
+// GetReplicationStatus returns the replication status of the requested shards owned by this host
+func (h *Handler) GetReplicationStatus(ctx context.Context, request *historyservice.GetReplicationStatusRequest) (_ *historyservice.GetReplicationStatusResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryGetReplicationStatusScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	response := &historyservice.GetReplicationStatusResponse{}
+	for _, shardID := range request.GetShardIds() {
+		engine, err := h.controller.getEngineForShard(int(shardID))
+		if err != nil {
+			h.GetLogger().Warn("History engine not found for shard", tag.ShardID(int(shardID)), tag.Error(err))
+			continue
+		}
+		status, err := engine.GetReplicationStatus(ctx, request.GetRemoteClusters())
+		if err != nil {
+			return nil, h.error(err, scope, "", "")
+		}
+		response.Shards = append(response.Shards, status)
+	}
+	return response, nil
+}
+
 // GetDLQReplicationMessages is called by remote peers to get replicated messages for DLQ merging
 func (h *Handler) GetDLQReplicationMessages(ctx context.Context, request *historyservice.GetDLQReplicationMessagesRequest) (_ *historyservice.GetDLQReplicationMessagesResponse, retError error) {
 	defer log.CapturePanic(h.GetLogger(), &retError)
@@ -1765,6 +1885,69 @@ func (h *Handler) RefreshWorkflowTasks(ctx context.Context, request *historyserv
 	return &historyservice.RefreshWorkflowTasksResponse{}, nil
 }
 
+// ImportWorkflowExecution recreates a workflow run from a previously exported history
+func (h *Handler) ImportWorkflowExecution(ctx context.Context, request *historyservice.ImportWorkflowExecutionRequest) (_ *historyservice.ImportWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryImportWorkflowExecutionScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	namespaceID := request.GetNamespaceId()
+	if namespaceID == "" {
+		return nil, h.error(errNamespaceNotSet, scope, namespaceID, "")
+	}
+
+	workflowID := request.GetRequest().GetExport().GetExecution().GetWorkflowId()
+	engine, err := h.controller.GetEngine(workflowID)
+	if err != nil {
+		return nil, h.error(err, scope, namespaceID, workflowID)
+	}
+
+	if err := engine.ImportWorkflowExecution(ctx, request); err != nil {
+		return nil, h.error(err, scope, namespaceID, workflowID)
+	}
+	return &historyservice.ImportWorkflowExecutionResponse{}, nil
+}
+
+// DeleteWorkflowExecution deletes the data of a closed workflow run
+func (h *Handler) DeleteWorkflowExecution(ctx context.Context, request *historyservice.DeleteWorkflowExecutionRequest) (_ *historyservice.DeleteWorkflowExecutionResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	h.startWG.Wait()
+
+	scope := metrics.HistoryDeleteWorkflowExecutionScope
+	h.GetMetricsClient().IncCounter(scope, metrics.ServiceRequests)
+	sw := h.GetMetricsClient().StartTimer(scope, metrics.ServiceLatency)
+	defer sw.Stop()
+
+	if h.isShuttingDown() {
+		return nil, errShuttingDown
+	}
+
+	namespaceID := request.GetNamespaceId()
+	if namespaceID == "" {
+		return nil, h.error(errNamespaceNotSet, scope, namespaceID, "")
+	}
+
+	workflowID := request.GetExecution().GetWorkflowId()
+	engine, err := h.controller.GetEngine(workflowID)
+	if err != nil {
+		return nil, h.error(err, scope, namespaceID, workflowID)
+	}
+
+	resp, err := engine.DeleteWorkflowExecution(ctx, request)
+	if err != nil {
+		return nil, h.error(err, scope, namespaceID, workflowID)
+	}
+	return resp, nil
+}
+
 // convertError is a helper method to convert ShardOwnershipLostError from persistence layer returned by various
 // HistoryEngine API calls to ShardOwnershipLost error return by HistoryService for client to be redirected to the
 // correct shard.
@@ -1772,7 +1955,7 @@ func (h *Handler) convertError(err error) error {
 	switch err.(type) {
 	case *persistence.ShardOwnershipLostError:
 		shardID := err.(*persistence.ShardOwnershipLostError).ShardID
-		info, err := h.GetHistoryServiceResolver().Lookup(string(shardID))
+		info, err := h.controller.ownership.lookup(shardID)
 		if err == nil {
 			return createShardOwnershipLostError(h.GetHostInfo().GetAddress(), info.GetAddress())
 		}
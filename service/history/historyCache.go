@@ -153,7 +153,8 @@ func (c *historyCache) getOrCreateWorkflowExecutionForBackground(
 	execution commonpb.WorkflowExecution,
 ) (workflowExecutionContext, releaseWorkflowExecutionFunc, error) {
 
-	return c.getOrCreateWorkflowExecution(context.Background(), namespaceID, execution)
+	ctx := persistence.WithCallerType(context.Background(), persistence.CallerTypeBackground)
+	return c.getOrCreateWorkflowExecution(ctx, namespaceID, execution)
 }
 
 func (c *historyCache) getOrCreateWorkflowExecution(
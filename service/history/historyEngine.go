@@ -32,6 +32,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/gogo/protobuf/types"
@@ -40,6 +41,7 @@ import (
 	eventpb "go.temporal.io/temporal-proto/event"
 	executionpb "go.temporal.io/temporal-proto/execution"
 	failurepb "go.temporal.io/temporal-proto/failure"
+	namespacepb "go.temporal.io/temporal-proto/namespace"
 	querypb "go.temporal.io/temporal-proto/query"
 	"go.temporal.io/temporal-proto/serviceerror"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
@@ -50,6 +52,7 @@ import (
 	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/.gen/proto/matchingservice"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	"github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/client/matching"
@@ -87,6 +90,7 @@ type (
 		StartWorkflowExecution(ctx context.Context, request *historyservice.StartWorkflowExecutionRequest) (*historyservice.StartWorkflowExecutionResponse, error)
 		GetMutableState(ctx context.Context, request *historyservice.GetMutableStateRequest) (*historyservice.GetMutableStateResponse, error)
 		PollMutableState(ctx context.Context, request *historyservice.PollMutableStateRequest) (*historyservice.PollMutableStateResponse, error)
+		WatchMutableState(ctx context.Context, request *historyservice.WatchMutableStateRequest, send func(*historyservice.WatchMutableStateResponse) error) error
 		DescribeMutableState(ctx context.Context, request *historyservice.DescribeMutableStateRequest) (*historyservice.DescribeMutableStateResponse, error)
 		ResetStickyTaskList(ctx context.Context, resetRequest *historyservice.ResetStickyTaskListRequest) (*historyservice.ResetStickyTaskListResponse, error)
 		DescribeWorkflowExecution(ctx context.Context, request *historyservice.DescribeWorkflowExecutionRequest) (*historyservice.DescribeWorkflowExecutionResponse, error)
@@ -113,12 +117,16 @@ type (
 		SyncActivity(ctx context.Context, request *historyservice.SyncActivityRequest) error
 		GetReplicationMessages(ctx context.Context, pollingCluster string, lastReadMessageID int64) (*replicationgenpb.ReplicationMessages, error)
 		GetDLQReplicationMessages(ctx context.Context, taskInfos []*replicationgenpb.ReplicationTaskInfo) ([]*replicationgenpb.ReplicationTask, error)
+		GetReplicationStatus(ctx context.Context, remoteClusters []string) (*replicationgenpb.ShardReplicationStatus, error)
 		QueryWorkflow(ctx context.Context, request *historyservice.QueryWorkflowRequest) (*historyservice.QueryWorkflowResponse, error)
 		ReapplyEvents(ctx context.Context, namespaceUUID string, workflowID string, runID string, events []*eventpb.HistoryEvent) error
 		ReadDLQMessages(ctx context.Context, messagesRequest *historyservice.ReadDLQMessagesRequest) (*historyservice.ReadDLQMessagesResponse, error)
 		PurgeDLQMessages(ctx context.Context, messagesRequest *historyservice.PurgeDLQMessagesRequest) error
 		MergeDLQMessages(ctx context.Context, messagesRequest *historyservice.MergeDLQMessagesRequest) (*historyservice.MergeDLQMessagesResponse, error)
 		RefreshWorkflowTasks(ctx context.Context, namespaceUUID string, execution commonpb.WorkflowExecution) error
+		ImportWorkflowExecution(ctx context.Context, request *historyservice.ImportWorkflowExecutionRequest) error
+		DeleteWorkflowExecution(ctx context.Context, request *historyservice.DeleteWorkflowExecutionRequest) (*historyservice.DeleteWorkflowExecutionResponse, error)
+		GetMutableStateCacheSize() int
 
 		NotifyNewHistoryEvent(event *historyEventNotification)
 		NotifyNewTransferTasks(tasks []persistence.Task)
@@ -149,16 +157,20 @@ type (
 		throttledLogger           log.Logger
 		config                    *Config
 		archivalClient            archiver.Client
-		resetor                   workflowResetor
 		workflowResetter          workflowResetter
+		workflowImporter          workflowImporter
 		queueTaskProcessor        queueTaskProcessor
-		replicationTaskProcessors []ReplicationTaskProcessor
+		replicationTaskFetchers   ReplicationTaskFetchers
+		replicationTaskExecutor   replicationTaskExecutor
+		replicationProcessorsLock sync.RWMutex
+		replicationTaskProcessors map[string]ReplicationTaskProcessor
 		publicClient              sdkclient.Client
 		eventsReapplier           nDCEventsReapplier
 		matchingClient            matching.Client
 		rawMatchingClient         matching.Client
 		versionChecker            headers.VersionChecker
 		replicationDLQHandler     replicationDLQHandler
+		queueTaskDLQHandler       queueTaskDLQHandler
 	}
 )
 
@@ -201,6 +213,10 @@ var (
 	ErrConsistentQueryNotEnabled = serviceerror.NewInvalidArgument("cluster or namespace does not enable strongly consistent query but strongly consistent query was requested")
 	// ErrConsistentQueryBufferExceeded is error indicating that too many consistent queries have been buffered and until buffered queries are finished new consistent queries cannot be buffered
 	ErrConsistentQueryBufferExceeded = serviceerror.NewInternal("consistent query buffer is full, cannot accept new consistent queries")
+	// ErrNamespaceDeleted is error indicating that the namespace is being deleted and does not accept new workflows or signals
+	ErrNamespaceDeleted = serviceerror.NewInvalidArgument("namespace is being deleted, new workflows and signals are not accepted")
+	// ErrWorkflowNotClosed is error indicating that a running workflow execution cannot be deleted
+	ErrWorkflowNotClosed = serviceerror.NewInvalidArgument("workflow execution is still running, it must be closed before being deleted")
 
 	// FailedWorkflowStatuses is a set of failed workflow close states, used for start workflow policy
 	// for start workflow execution API
@@ -264,6 +280,7 @@ func NewEngineWithShardContext(
 
 	historyEngImpl.txProcessor = newTransferQueueProcessor(shard, historyEngImpl, visibilityMgr, matching, historyClient, queueTaskProcessor, logger)
 	historyEngImpl.timerProcessor = newTimerQueueProcessor(shard, historyEngImpl, matching, queueTaskProcessor, logger)
+	historyEngImpl.queueTaskDLQHandler = newQueueTaskDLQHandler(shard, historyEngImpl.txProcessor, historyEngImpl.timerProcessor)
 	historyEngImpl.eventsReapplier = newNDCEventsReapplier(shard.GetMetricsClient(), logger)
 
 	// Only start the replicator processor if valid publisher is passed in
@@ -297,12 +314,15 @@ func NewEngineWithShardContext(
 			logger,
 		)
 	}
-	historyEngImpl.resetor = newWorkflowResetor(historyEngImpl)
 	historyEngImpl.workflowResetter = newWorkflowResetter(
 		shard,
 		historyCache,
 		logger,
 	)
+	historyEngImpl.workflowImporter = newWorkflowImporter(
+		shard,
+		logger,
+	)
 	historyEngImpl.decisionHandler = newDecisionHandler(historyEngImpl)
 
 	nDCHistoryResender := xdc.NewNDCHistoryResender(
@@ -335,7 +355,7 @@ func NewEngineWithShardContext(
 		shard.GetMetricsClient(),
 		shard.GetLogger(),
 	)
-	var replicationTaskProcessors []ReplicationTaskProcessor
+	replicationTaskProcessors := make(map[string]ReplicationTaskProcessor)
 	for _, replicationTaskFetcher := range replicationTaskFetchers.GetFetchers() {
 		replicationTaskProcessor := NewReplicationTaskProcessor(
 			shard,
@@ -345,8 +365,10 @@ func NewEngineWithShardContext(
 			replicationTaskFetcher,
 			replicationTaskExecutor,
 		)
-		replicationTaskProcessors = append(replicationTaskProcessors, replicationTaskProcessor)
+		replicationTaskProcessors[replicationTaskFetcher.GetSourceCluster()] = replicationTaskProcessor
 	}
+	historyEngImpl.replicationTaskFetchers = replicationTaskFetchers
+	historyEngImpl.replicationTaskExecutor = replicationTaskExecutor
 	historyEngImpl.replicationTaskProcessors = replicationTaskProcessors
 	replicationMessageHandler := newReplicationDLQHandler(shard, replicationTaskExecutor)
 	historyEngImpl.replicationDLQHandler = replicationMessageHandler
@@ -372,9 +394,12 @@ func (e *historyEngineImpl) Start() {
 		e.replicatorProcessor.Start()
 	}
 
+	e.replicationProcessorsLock.RLock()
 	for _, replicationTaskProcessor := range e.replicationTaskProcessors {
 		replicationTaskProcessor.Start()
 	}
+	e.replicationProcessorsLock.RUnlock()
+	clusterMetadata.RegisterMetadataChangeCallback(e, e.clusterMetadataChangeCallback)
 }
 
 // Stop the service.
@@ -388,9 +413,12 @@ func (e *historyEngineImpl) Stop() {
 		e.replicatorProcessor.Stop()
 	}
 
+	e.shard.GetClusterMetadata().UnRegisterMetadataChangeCallback(e)
+	e.replicationProcessorsLock.RLock()
 	for _, replicationTaskProcessor := range e.replicationTaskProcessors {
 		replicationTaskProcessor.Stop()
 	}
+	e.replicationProcessorsLock.RUnlock()
 
 	if e.queueTaskProcessor != nil {
 		e.queueTaskProcessor.StopShardProcessor(e.shard)
@@ -400,6 +428,48 @@ func (e *historyEngineImpl) Stop() {
 	e.shard.GetNamespaceCache().UnregisterNamespaceChangeCallback(e.shard.GetShardID())
 }
 
+// clusterMetadataChangeCallback starts replication task processors for clusters added to the replication group
+// and stops the processors of removed or disabled clusters
+func (e *historyEngineImpl) clusterMetadataChangeCallback(
+	oldClusterMetadata map[string]*config.ClusterInformation,
+	newClusterMetadata map[string]*config.ClusterInformation,
+) {
+	if e.shard.GetClusterMetadata().GetReplicationConsumerConfig().Type != config.ReplicationConsumerTypeRPC {
+		return
+	}
+
+	e.replicationProcessorsLock.Lock()
+	defer e.replicationProcessorsLock.Unlock()
+
+	for clusterName, newInfo := range newClusterMetadata {
+		if clusterName == e.currentClusterName {
+			continue
+		}
+
+		replicationTaskProcessor, ok := e.replicationTaskProcessors[clusterName]
+		if newInfo != nil && newInfo.Enabled {
+			if !ok {
+				replicationTaskProcessor = NewReplicationTaskProcessor(
+					e.shard,
+					e,
+					e.config,
+					e.metricsClient,
+					e.replicationTaskFetchers.GetOrCreateFetcher(clusterName),
+					e.replicationTaskExecutor,
+				)
+				replicationTaskProcessor.Start()
+				e.replicationTaskProcessors[clusterName] = replicationTaskProcessor
+			}
+			continue
+		}
+
+		if ok {
+			replicationTaskProcessor.Stop()
+			delete(e.replicationTaskProcessors, clusterName)
+		}
+	}
+}
+
 func (e *historyEngineImpl) registerNamespaceFailoverCallback() {
 
 	// NOTE: READ BEFORE MODIFICATION
@@ -551,6 +621,9 @@ func (e *historyEngineImpl) StartWorkflowExecution(
 	if err != nil {
 		return nil, err
 	}
+	if namespaceEntry.GetInfo().Status == namespacepb.NamespaceStatus_Deleted {
+		return nil, ErrNamespaceDeleted
+	}
 	namespaceID := namespaceEntry.GetInfo().Id
 
 	request := startRequest.StartRequest
@@ -713,6 +786,78 @@ func (e *historyEngineImpl) PollMutableState(
 	}, nil
 }
 
+// WatchMutableState sends the next event ID of a workflow run every time new history events are
+// committed, until the run is closed. Notifications only wake the watcher up, the mutable state is
+// reloaded after each of them, so notifications dropped while a response is sent are coalesced into
+// the next reload instead of being queued.
+func (e *historyEngineImpl) WatchMutableState(
+	ctx context.Context,
+	request *historyservice.WatchMutableStateRequest,
+	send func(*historyservice.WatchMutableStateResponse) error,
+) error {
+
+	namespaceID, err := validateNamespaceUUID(request.GetNamespaceId())
+	if err != nil {
+		return err
+	}
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: request.Execution.GetWorkflowId(),
+		RunId:      request.Execution.GetRunId(),
+	}
+	// resolve the run ID in case the current run is watched
+	response, err := e.getMutableState(ctx, namespaceID, execution)
+	if err != nil {
+		return e.updateEntityNotExistsErrorOnPassiveCluster(err, namespaceID)
+	}
+	execution.RunId = response.Execution.GetRunId()
+
+	identifier := definition.NewWorkflowIdentifier(namespaceID, execution.GetWorkflowId(), execution.GetRunId())
+	subscriberID, channel, err := e.historyEventNotifier.WatchHistoryEvent(identifier)
+	if err != nil {
+		return err
+	}
+	defer e.historyEventNotifier.UnwatchHistoryEvent(identifier, subscriberID) //nolint:errcheck
+
+	currentBranchToken := request.GetCurrentBranchToken()
+	if currentBranchToken == nil {
+		currentBranchToken = response.GetCurrentBranchToken()
+	}
+	expectedNextEventID := request.GetExpectedNextEventId()
+	for {
+		// events committed before the subscription are only visible in the mutable state
+		response, err = e.getMutableState(ctx, namespaceID, execution)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(currentBranchToken, response.GetCurrentBranchToken()) {
+			return serviceerror.NewCurrentBranchChanged("current branch token and request branch token doesn't match.", response.GetCurrentBranchToken())
+		}
+
+		if expectedNextEventID < response.GetNextEventId() || !response.GetIsWorkflowRunning() {
+			if err := send(&historyservice.WatchMutableStateResponse{
+				Execution:          response.GetExecution(),
+				NextEventId:        response.GetNextEventId(),
+				LastFirstEventId:   response.GetLastFirstEventId(),
+				CurrentBranchToken: response.GetCurrentBranchToken(),
+				WorkflowState:      response.GetWorkflowState(),
+				WorkflowStatus:     response.GetWorkflowStatus(),
+			}); err != nil {
+				return err
+			}
+			expectedNextEventID = response.GetNextEventId()
+		}
+		if !response.GetIsWorkflowRunning() {
+			return nil
+		}
+
+		select {
+		case <-channel:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 func (e *historyEngineImpl) updateEntityNotExistsErrorOnPassiveCluster(err error, namespaceID string) error {
 	switch err.(type) {
 	case *serviceerror.NotFound:
@@ -1271,6 +1416,7 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(
 			SearchAttributes: &commonpb.SearchAttributes{IndexedFields: executionInfo.SearchAttributes},
 			Status:           executionInfo.Status,
 		},
+		CompletionCallbacks: executionInfo.CompletionCallbacks,
 	}
 
 	// TODO: we need to consider adding execution time to mutable state
@@ -1861,6 +2007,9 @@ func (e *historyEngineImpl) SignalWorkflowExecution(
 	if err != nil {
 		return err
 	}
+	if namespaceEntry.GetInfo().Status == namespacepb.NamespaceStatus_Deleted {
+		return ErrNamespaceDeleted
+	}
 	namespaceID := namespaceEntry.GetInfo().Id
 
 	request := signalRequest.SignalRequest
@@ -1936,6 +2085,9 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(
 	if err != nil {
 		return nil, err
 	}
+	if namespaceEntry.GetInfo().Status == namespacepb.NamespaceStatus_Deleted {
+		return nil, ErrNamespaceDeleted
+	}
 	namespaceID := namespaceEntry.GetInfo().Id
 
 	sRequest := signalWithStartRequest.SignalWithStartRequest
@@ -2316,6 +2468,19 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 	namespaceID := resetRequest.GetNamespaceId()
 	workflowID := request.WorkflowExecution.GetWorkflowId()
 	baseRunID := request.WorkflowExecution.GetRunId()
+	decisionFinishEventID := request.GetDecisionFinishEventId()
+
+	if _, err := e.getActiveNamespaceEntry(namespaceID); err != nil {
+		return nil, err
+	}
+
+	if resetRequest.GetResetToLastRunStart() {
+		lastRunID, err := e.getLastRunOfContinueAsNewChain(ctx, namespaceID, workflowID, baseRunID)
+		if err != nil {
+			return nil, err
+		}
+		baseRunID = lastRunID
+	}
 
 	baseContext, baseReleaseFn, err := e.historyCache.getOrCreateWorkflowExecution(
 		ctx,
@@ -2334,8 +2499,13 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 	if err != nil {
 		return nil, err
 	}
-	if request.GetDecisionFinishEventId() <= common.FirstEventID ||
-		request.GetDecisionFinishEventId() >= baseMutableState.GetNextEventID() {
+	if resetRequest.GetResetToLastRunStart() {
+		if decisionFinishEventID, err = e.getFirstDecisionCompletedEventID(baseMutableState); err != nil {
+			return nil, err
+		}
+	}
+	if decisionFinishEventID <= common.FirstEventID ||
+		decisionFinishEventID >= baseMutableState.GetNextEventID() {
 		return nil, serviceerror.NewInvalidArgument("Decision finish ID must be > 1 && <= workflow next event ID.")
 	}
 
@@ -2386,21 +2556,16 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 		}, nil
 	}
 
-	// TODO when NDC is rolled out, remove this block
-	if baseMutableState.GetVersionHistories() == nil {
-		return e.resetor.ResetWorkflowExecution(
-			ctx,
-			request,
-			baseContext,
-			baseMutableState,
-			currentContext,
-			currentMutableState,
-		)
-	}
-
 	resetRunID := uuid.New()
-	baseRebuildLastEventID := request.GetDecisionFinishEventId() - 1
-	baseVersionHistories := baseMutableState.GetVersionHistories()
+	baseRebuildLastEventID := decisionFinishEventID - 1
+	baseVersionHistories, err := e.workflowResetter.backfillVersionHistories(
+		baseContext,
+		baseMutableState,
+		currentRunID == baseRunID,
+	)
+	if err != nil {
+		return nil, err
+	}
 	baseCurrentVersionHistory, err := baseVersionHistories.GetCurrentVersionHistory()
 	if err != nil {
 		return nil, err
@@ -2421,6 +2586,8 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 		baseRebuildLastEventID,
 		baseRebuildLastEventVersion,
 		baseNextEventID,
+		baseMutableState.GetReplicationState(),
+		baseMutableState.GetExecutionInfo().CompletionCallbacks,
 		resetRunID,
 		request.GetRequestId(),
 		newNDCWorkflow(
@@ -2433,6 +2600,7 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 		),
 		request.GetReason(),
 		nil,
+		newResetReapplyOptions(resetRequest.GetReapplyType(), resetRequest.GetReapplySignalNames()),
 	); err != nil {
 		return nil, err
 	}
@@ -2441,6 +2609,111 @@ func (e *historyEngineImpl) ResetWorkflowExecution(
 	}, nil
 }
 
+// getLastRunOfContinueAsNewChain returns the current run of the workflow,
+// after checking that it is reached from the given run through continue as new
+func (e *historyEngineImpl) getLastRunOfContinueAsNewChain(
+	ctx context.Context,
+	namespaceID string,
+	workflowID string,
+	runID string,
+) (string, error) {
+
+	resp, err := e.executionManager.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+		NamespaceID: namespaceID,
+		WorkflowID:  workflowID,
+	})
+	if err != nil {
+		return "", err
+	}
+	lastRunID := resp.RunID
+	if runID == "" {
+		return lastRunID, nil
+	}
+
+	for chainRunID := lastRunID; chainRunID != runID; {
+		prevRunID, err := e.getContinuedExecutionRunID(ctx, namespaceID, workflowID, chainRunID)
+		if err != nil {
+			return "", err
+		}
+		if prevRunID == "" {
+			return "", serviceerror.NewInvalidArgument(fmt.Sprintf(
+				"Run %v is not part of the continue as new chain of the current run %v.", runID, lastRunID,
+			))
+		}
+		chainRunID = prevRunID
+	}
+	return lastRunID, nil
+}
+
+func (e *historyEngineImpl) getContinuedExecutionRunID(
+	ctx context.Context,
+	namespaceID string,
+	workflowID string,
+	runID string,
+) (_ string, retError error) {
+
+	context, release, err := e.historyCache.getOrCreateWorkflowExecution(
+		ctx,
+		namespaceID,
+		commonpb.WorkflowExecution{
+			WorkflowId: workflowID,
+			RunId:      runID,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := context.loadWorkflowExecution()
+	if err != nil {
+		return "", err
+	}
+	startEvent, err := mutableState.GetStartEvent()
+	if err != nil {
+		return "", err
+	}
+	return startEvent.GetWorkflowExecutionStartedEventAttributes().GetContinuedExecutionRunId(), nil
+}
+
+// getFirstDecisionCompletedEventID pages through the history of the run until its first completed decision,
+// which can be preceded by many signals or failed decisions
+func (e *historyEngineImpl) getFirstDecisionCompletedEventID(
+	mutableState mutableState,
+) (int64, error) {
+
+	branchToken, err := mutableState.GetCurrentBranchToken()
+	if err != nil {
+		return 0, err
+	}
+
+	var nextPageToken []byte
+	for {
+		historyEvents, _, token, _, err := PaginateHistory(
+			e.historyV2Mgr,
+			false,
+			branchToken,
+			common.FirstEventID,
+			mutableState.GetNextEventID(),
+			nextPageToken,
+			nDCDefaultPageSize,
+			convert.IntPtr(e.shard.GetShardID()),
+		)
+		if err != nil {
+			return 0, err
+		}
+		for _, event := range historyEvents {
+			if event.GetEventType() == eventpb.EventType_DecisionTaskCompleted {
+				return event.GetEventId(), nil
+			}
+		}
+		if len(token) == 0 {
+			return 0, serviceerror.NewInvalidArgument("The last run of the workflow has no completed decision to reset to.")
+		}
+		nextPageToken = token
+	}
+}
+
 func (e *historyEngineImpl) updateWorkflow(
 	ctx context.Context,
 	namespaceID string,
@@ -2593,6 +2866,11 @@ func (e *historyEngineImpl) failDecision(
 	return mutableState, nil
 }
 
+// GetMutableStateCacheSize returns the number of workflow executions in the mutable state cache
+func (e *historyEngineImpl) GetMutableStateCacheSize() int {
+	return e.historyCache.Size()
+}
+
 func (e *historyEngineImpl) NotifyNewHistoryEvent(
 	event *historyEventNotification,
 ) {
@@ -2759,6 +3037,9 @@ func getActiveNamespaceEntryFromShard(
 	if err = namespaceEntry.GetNamespaceNotActiveErr(); err != nil {
 		return nil, err
 	}
+	if err = namespaceEntry.GetNamespaceHandoverErr(); err != nil {
+		return nil, err
+	}
 	return namespaceEntry, nil
 }
 
@@ -2945,6 +3226,49 @@ func (e *historyEngineImpl) GetDLQReplicationMessages(
 	return tasks, nil
 }
 
+func (e *historyEngineImpl) GetReplicationStatus(
+	ctx context.Context,
+	remoteClusters []string,
+) (*replicationgenpb.ShardReplicationStatus, error) {
+
+	if len(remoteClusters) == 0 {
+		for clusterName, clusterInfo := range e.clusterMetadata.GetAllClusterInfo() {
+			if clusterInfo.Enabled && clusterName != e.currentClusterName {
+				remoteClusters = append(remoteClusters, clusterName)
+			}
+		}
+	}
+
+	status := &replicationgenpb.ShardReplicationStatus{
+		ShardId:              int32(e.shard.GetShardID()),
+		ShardLocalTime:       e.timeSource.Now().UnixNano(),
+		MaxReplicationTaskId: e.shard.GetTransferMaxReadLevel(),
+		RemoteClusters:       make(map[string]*replicationgenpb.ClusterReplicationStatus, len(remoteClusters)),
+	}
+	for _, clusterName := range remoteClusters {
+		clusterStatus := &replicationgenpb.ClusterReplicationStatus{
+			RemoteAckLevel: e.shard.GetClusterReplicationLevel(clusterName),
+		}
+		if e.replicatorProcessor != nil {
+			clusterStatus = e.replicatorProcessor.getReplicationStatus(clusterName)
+		}
+		clusterStatus.LastReplicatedTaskId = emptyMessageID
+		clusterStatus.DlqAckLevel = e.shard.GetReplicatorDLQAckLevel(clusterName)
+		e.replicationProcessorsLock.RLock()
+		replicationTaskProcessor, ok := e.replicationTaskProcessors[clusterName]
+		e.replicationProcessorsLock.RUnlock()
+		if ok {
+			inbound := replicationTaskProcessor.getReplicationStatus()
+			clusterStatus.LastReplicatedTaskId = inbound.GetLastReplicatedTaskId()
+			clusterStatus.LastReplicatedTime = inbound.GetLastReplicatedTime()
+			clusterStatus.DlqAckLevel = inbound.GetDlqAckLevel()
+			clusterStatus.DlqSize = inbound.GetDlqSize()
+		}
+		status.RemoteClusters[clusterName] = clusterStatus
+	}
+	return status, nil
+}
+
 func (e *historyEngineImpl) ReapplyEvents(
 	ctx context.Context,
 	namespaceUUID string,
@@ -3031,6 +3355,8 @@ func (e *historyEngineImpl) ReapplyEvents(
 					baseRebuildLastEventID,
 					baseRebuildLastEventVersion,
 					baseNextEventID,
+					mutableState.GetReplicationState(),
+					mutableState.GetExecutionInfo().CompletionCallbacks,
 					resetRunID,
 					uuid.New(),
 					newNDCWorkflow(
@@ -3043,6 +3369,7 @@ func (e *historyEngineImpl) ReapplyEvents(
 					),
 					eventsReapplicationResetWorkflowReason,
 					toReapplyEvents,
+					nil,
 				); err != nil {
 					return nil, err
 				}
@@ -3082,9 +3409,30 @@ func (e *historyEngineImpl) ReadDLQMessages(
 	request *historyservice.ReadDLQMessagesRequest,
 ) (*historyservice.ReadDLQMessagesResponse, error) {
 
-	tasks, token, err := e.replicationDLQHandler.readMessages(
-		ctx,
-		request.GetSourceCluster(),
+	if request.GetType() == commongenpb.DLQType_Replication {
+		tasks, token, err := e.replicationDLQHandler.readMessages(
+			ctx,
+			request.GetSourceCluster(),
+			request.GetInclusiveEndMessageId(),
+			int(request.GetMaximumPageSize()),
+			request.GetNextPageToken(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &historyservice.ReadDLQMessagesResponse{
+			Type:             request.GetType(),
+			ReplicationTasks: tasks,
+			NextPageToken:    token,
+		}, nil
+	}
+
+	queueType, err := toQueueType(request.GetType())
+	if err != nil {
+		return nil, err
+	}
+	tasks, token, err := e.queueTaskDLQHandler.readMessages(
+		queueType,
 		request.GetInclusiveEndMessageId(),
 		int(request.GetMaximumPageSize()),
 		request.GetNextPageToken(),
@@ -3092,11 +3440,20 @@ func (e *historyEngineImpl) ReadDLQMessages(
 	if err != nil {
 		return nil, err
 	}
-	return &historyservice.ReadDLQMessagesResponse{
-		Type:             request.GetType(),
-		ReplicationTasks: tasks,
-		NextPageToken:    token,
-	}, nil
+
+	response := &historyservice.ReadDLQMessagesResponse{
+		Type:          request.GetType(),
+		NextPageToken: token,
+	}
+	for _, task := range tasks {
+		switch task := task.(type) {
+		case *persistenceblobs.TransferTaskInfo:
+			response.TransferTasks = append(response.TransferTasks, task)
+		case *persistenceblobs.TimerTaskInfo:
+			response.TimerTasks = append(response.TimerTasks, task)
+		}
+	}
+	return response, nil
 }
 
 func (e *historyEngineImpl) PurgeDLQMessages(
@@ -3104,8 +3461,19 @@ func (e *historyEngineImpl) PurgeDLQMessages(
 	request *historyservice.PurgeDLQMessagesRequest,
 ) error {
 
-	return e.replicationDLQHandler.purgeMessages(
-		request.GetSourceCluster(),
+	if request.GetType() == commongenpb.DLQType_Replication {
+		return e.replicationDLQHandler.purgeMessages(
+			request.GetSourceCluster(),
+			request.GetInclusiveEndMessageId(),
+		)
+	}
+
+	queueType, err := toQueueType(request.GetType())
+	if err != nil {
+		return err
+	}
+	return e.queueTaskDLQHandler.purgeMessages(
+		queueType,
 		request.GetInclusiveEndMessageId(),
 	)
 }
@@ -3115,13 +3483,29 @@ func (e *historyEngineImpl) MergeDLQMessages(
 	request *historyservice.MergeDLQMessagesRequest,
 ) (*historyservice.MergeDLQMessagesResponse, error) {
 
-	token, err := e.replicationDLQHandler.mergeMessages(
-		ctx,
-		request.GetSourceCluster(),
-		request.GetInclusiveEndMessageId(),
-		int(request.GetMaximumPageSize()),
-		request.GetNextPageToken(),
-	)
+	var token []byte
+	var err error
+	if request.GetType() == commongenpb.DLQType_Replication {
+		token, err = e.replicationDLQHandler.mergeMessages(
+			ctx,
+			request.GetSourceCluster(),
+			request.GetInclusiveEndMessageId(),
+			int(request.GetMaximumPageSize()),
+			request.GetNextPageToken(),
+		)
+	} else {
+		var queueType queueType
+		if queueType, err = toQueueType(request.GetType()); err != nil {
+			return nil, err
+		}
+		token, err = e.queueTaskDLQHandler.mergeMessages(
+			ctx,
+			queueType,
+			request.GetInclusiveEndMessageId(),
+			int(request.GetMaximumPageSize()),
+			request.GetNextPageToken(),
+		)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -3178,6 +3562,163 @@ func (e *historyEngineImpl) RefreshWorkflowTasks(
 	return nil
 }
 
+func (e *historyEngineImpl) ImportWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.ImportWorkflowExecutionRequest,
+) error {
+
+	namespaceEntry, err := e.getActiveNamespaceEntry(request.GetNamespaceId())
+	if err != nil {
+		return err
+	}
+
+	return e.workflowImporter.importWorkflow(
+		ctx,
+		namespaceEntry.GetInfo().Id,
+		request.GetRequest().GetExport(),
+	)
+}
+
+// DeleteWorkflowExecution deletes history, mutable state, visibility and offloaded payloads of a closed workflow run,
+// it returns the task lists used by the run. The visibility record is still deleted if mutable state is already gone.
+func (e *historyEngineImpl) DeleteWorkflowExecution(
+	ctx context.Context,
+	request *historyservice.DeleteWorkflowExecutionRequest,
+) (retResp *historyservice.DeleteWorkflowExecutionResponse, retError error) {
+
+	namespaceEntry, err := e.getActiveNamespaceEntry(request.GetNamespaceId())
+	if err != nil {
+		return nil, err
+	}
+	namespaceID := namespaceEntry.GetInfo().Id
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: request.GetExecution().GetWorkflowId(),
+		RunId:      request.GetExecution().GetRunId(),
+	}
+
+	context, release, err := e.historyCache.getOrCreateWorkflowExecution(ctx, namespaceID, execution)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { release(retError) }()
+
+	var taskLists []string
+	deleted := false
+	mutableState, err := context.loadWorkflowExecution()
+	switch err.(type) {
+	case nil:
+		if mutableState.IsWorkflowExecutionRunning() {
+			return nil, ErrWorkflowNotClosed
+		}
+		branchToken, err := mutableState.GetCurrentBranchToken()
+		if err != nil {
+			return nil, err
+		}
+		taskLists, err = e.getExecutionTaskLists(mutableState, branchToken)
+		if err != nil {
+			return nil, err
+		}
+		// history goes first, so that a retry can still find the branch token in mutable state
+		if err := e.historyV2Mgr.DeleteHistoryBranch(&persistence.DeleteHistoryBranchRequest{
+			BranchToken: branchToken,
+			ShardID:     convert.IntPtr(e.shard.GetShardID()),
+		}); err != nil {
+			return nil, err
+		}
+		if err := e.executionManager.DeleteCurrentWorkflowExecution(&persistence.DeleteCurrentWorkflowExecutionRequest{
+			NamespaceID: namespaceID,
+			WorkflowID:  execution.GetWorkflowId(),
+			RunID:       execution.GetRunId(),
+		}); err != nil {
+			return nil, err
+		}
+		if err := e.executionManager.DeleteWorkflowExecution(&persistence.DeleteWorkflowExecutionRequest{
+			NamespaceID: namespaceID,
+			WorkflowID:  execution.GetWorkflowId(),
+			RunID:       execution.GetRunId(),
+		}); err != nil {
+			return nil, err
+		}
+		// force the next access to read the database instead of the deleted mutable state
+		context.clear()
+		deleted = true
+	case *serviceerror.NotFound:
+		// mutable state is already deleted, e.g. by retention
+	default:
+		return nil, err
+	}
+
+	if err := e.shard.GetService().GetVisibilityManager().DeleteWorkflowExecution(&persistence.VisibilityDeleteWorkflowExecutionRequest{
+		NamespaceID: namespaceID,
+		WorkflowID:  execution.GetWorkflowId(),
+		RunID:       execution.GetRunId(),
+	}); err != nil {
+		return nil, err
+	}
+	if err := e.shard.GetService().GetPayloadOffloader().DeleteExecutionBlobs(
+		ctx,
+		namespaceEntry.GetConfig().HistoryArchivalURI,
+		namespaceID,
+		execution.GetRunId(),
+	); err != nil {
+		return nil, err
+	}
+	return &historyservice.DeleteWorkflowExecutionResponse{TaskLists: taskLists, Deleted: deleted}, nil
+}
+
+// getExecutionTaskLists returns the task lists used by the run, including its sticky task list
+// and the task lists of activities which are already completed
+func (e *historyEngineImpl) getExecutionTaskLists(
+	mutableState mutableState,
+	branchToken []byte,
+) ([]string, error) {
+
+	var taskLists []string
+	seen := make(map[string]struct{})
+	addTaskList := func(name string) {
+		if _, ok := seen[name]; name != "" && !ok {
+			seen[name] = struct{}{}
+			taskLists = append(taskLists, name)
+		}
+	}
+
+	executionInfo := mutableState.GetExecutionInfo()
+	addTaskList(executionInfo.TaskList)
+	addTaskList(executionInfo.StickyTaskList)
+	for _, ai := range mutableState.GetPendingActivityInfos() {
+		addTaskList(ai.TaskList)
+	}
+
+	var token []byte
+	for {
+		historyEvents, _, nextToken, _, err := PaginateHistory(
+			e.historyV2Mgr,
+			false,
+			branchToken,
+			common.FirstEventID,
+			mutableState.GetNextEventID(),
+			token,
+			nDCDefaultPageSize,
+			convert.IntPtr(e.shard.GetShardID()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range historyEvents {
+			switch event.GetEventType() {
+			case eventpb.EventType_DecisionTaskScheduled:
+				addTaskList(event.GetDecisionTaskScheduledEventAttributes().GetTaskList().GetName())
+			case eventpb.EventType_ActivityTaskScheduled:
+				addTaskList(event.GetActivityTaskScheduledEventAttributes().GetTaskList().GetName())
+			}
+		}
+		if len(nextToken) == 0 {
+			return taskLists, nil
+		}
+		token = nextToken
+	}
+}
+
 func (e *historyEngineImpl) loadWorkflowOnce(
 	ctx context.Context,
 	namespaceID string,
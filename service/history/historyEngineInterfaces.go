@@ -61,6 +61,9 @@ type (
 			ctx context.Context,
 			taskInfo *replicationgenpb.ReplicationTaskInfo,
 		) (*replicationgenpb.ReplicationTask, error)
+		getReplicationStatus(
+			remoteCluster string,
+		) *replicationgenpb.ClusterReplicationStatus
 	}
 
 	queueAckMgr interface {
@@ -90,7 +93,7 @@ type (
 	}
 
 	queueTaskExecutor interface {
-		execute(taskInfo queueTaskInfo, shouldProcessTask bool) error
+		execute(ctx context.Context, taskInfo queueTaskInfo, shouldProcessTask bool) error
 	}
 
 	queueTaskProcessor interface {
@@ -103,7 +106,7 @@ type (
 	// TODO: deprecate this interface in favor of the task interface
 	// defined in common/task package
 	taskExecutor interface {
-		process(taskInfo *taskInfo) (int, error)
+		process(ctx context.Context, taskInfo *taskInfo) (int, error)
 		complete(taskInfo *taskInfo)
 		getTaskFilter() taskFilter
 	}
@@ -29,6 +29,7 @@
 package history
 
 import (
+	context "context"
 	reflect "reflect"
 
 	"github.com/gogo/protobuf/types"
@@ -438,17 +439,17 @@ func (m *MockqueueTaskExecutor) EXPECT() *MockqueueTaskExecutorMockRecorder {
 }
 
 // execute mocks base method
-func (m *MockqueueTaskExecutor) execute(taskInfo queueTaskInfo, shouldProcessTask bool) error {
+func (m *MockqueueTaskExecutor) execute(ctx context.Context, taskInfo queueTaskInfo, shouldProcessTask bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "execute", taskInfo, shouldProcessTask)
+	ret := m.ctrl.Call(m, "execute", ctx, taskInfo, shouldProcessTask)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // execute indicates an expected call of execute
-func (mr *MockqueueTaskExecutorMockRecorder) execute(taskInfo, shouldProcessTask interface{}) *gomock.Call {
+func (mr *MockqueueTaskExecutorMockRecorder) execute(ctx, taskInfo, shouldProcessTask interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "execute", reflect.TypeOf((*MockqueueTaskExecutor)(nil).execute), taskInfo, shouldProcessTask)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "execute", reflect.TypeOf((*MockqueueTaskExecutor)(nil).execute), ctx, taskInfo, shouldProcessTask)
 }
 
 // MockqueueTaskProcessor is a mock of queueTaskProcessor interface
@@ -1686,6 +1686,140 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedBadBinary() {
 	s.True(executionBuilder.HasPendingDecision())
 }
 
+func (s *engineSuite) TestStartWorkflowExecution_NamespaceDeleted() {
+	namespaceID := uuid.New()
+	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(
+		&persistenceblobs.NamespaceInfo{Id: namespaceID, Name: "deleted-namespace", Status: namespacepb.NamespaceStatus_Deleted},
+		&persistenceblobs.NamespaceConfig{RetentionDays: 1},
+		cluster.TestCurrentClusterName,
+		nil,
+	)
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(namespaceID).Return(namespaceEntry, nil).AnyTimes()
+
+	_, err := s.mockHistoryEngine.StartWorkflowExecution(context.Background(), &historyservice.StartWorkflowExecutionRequest{
+		NamespaceId: namespaceID,
+		StartRequest: &workflowservice.StartWorkflowExecutionRequest{
+			Namespace:    "deleted-namespace",
+			WorkflowId:   "wId",
+			WorkflowType: &commonpb.WorkflowType{Name: "wType"},
+			TaskList:     &tasklistpb.TaskList{Name: "testTaskList"},
+			RequestId:    uuid.New(),
+		},
+	})
+	s.Equal(ErrNamespaceDeleted, err)
+
+	_, err = s.mockHistoryEngine.SignalWithStartWorkflowExecution(context.Background(), &historyservice.SignalWithStartWorkflowExecutionRequest{
+		NamespaceId: namespaceID,
+		SignalWithStartRequest: &workflowservice.SignalWithStartWorkflowExecutionRequest{
+			Namespace:    "deleted-namespace",
+			WorkflowId:   "wId",
+			WorkflowType: &commonpb.WorkflowType{Name: "wType"},
+			TaskList:     &tasklistpb.TaskList{Name: "testTaskList"},
+			SignalName:   "signal",
+			RequestId:    uuid.New(),
+		},
+	})
+	s.Equal(ErrNamespaceDeleted, err)
+
+	err = s.mockHistoryEngine.SignalWorkflowExecution(context.Background(), &historyservice.SignalWorkflowExecutionRequest{
+		NamespaceId: namespaceID,
+		SignalRequest: &workflowservice.SignalWorkflowExecutionRequest{
+			Namespace:         "deleted-namespace",
+			WorkflowExecution: &commonpb.WorkflowExecution{WorkflowId: "wId", RunId: testRunID},
+			SignalName:        "signal",
+		},
+	})
+	s.Equal(ErrNamespaceDeleted, err)
+}
+
+func (s *engineSuite) TestDeleteWorkflowExecution_Closed() {
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+	tasklist := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), execution.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, execution, "wType", tasklist, payloads.EncodeString("input"), 100, 50, 200, identity)
+	ms := createMutableState(msBuilder)
+	ms.ExecutionInfo.State = executiongenpb.WorkflowExecutionState_WorkflowExecutionState_Completed
+	ms.ExecutionInfo.Status = executionpb.WorkflowExecutionStatus_Terminated
+	ms.ExecutionInfo.StickyTaskList = "testStickyTaskList"
+	gweResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	// the task lists of completed activities are only found in history
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gweResponse, nil).Once()
+	s.mockHistoryV2Mgr.On("ReadHistoryBranch", mock.Anything).Return(&persistence.ReadHistoryBranchResponse{
+		HistoryEvents: []*eventpb.HistoryEvent{
+			{
+				EventId:   5,
+				EventType: eventpb.EventType_ActivityTaskScheduled,
+				Attributes: &eventpb.HistoryEvent_ActivityTaskScheduledEventAttributes{ActivityTaskScheduledEventAttributes: &eventpb.ActivityTaskScheduledEventAttributes{
+					TaskList: &tasklistpb.TaskList{Name: "testActivityTaskList"},
+				}},
+			},
+			{
+				EventId:   6,
+				EventType: eventpb.EventType_ActivityTaskScheduled,
+				Attributes: &eventpb.HistoryEvent_ActivityTaskScheduledEventAttributes{ActivityTaskScheduledEventAttributes: &eventpb.ActivityTaskScheduledEventAttributes{
+					TaskList: &tasklistpb.TaskList{Name: tasklist},
+				}},
+			},
+		},
+	}, nil).Once()
+	s.mockHistoryV2Mgr.On("DeleteHistoryBranch", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("DeleteCurrentWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionMgr.On("DeleteWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockShard.resource.VisibilityMgr.On("DeleteWorkflowExecution", mock.Anything).Return(nil).Once()
+
+	resp, err := s.mockHistoryEngine.DeleteWorkflowExecution(context.Background(), &historyservice.DeleteWorkflowExecutionRequest{
+		NamespaceId: testNamespaceID,
+		Execution:   &execution,
+	})
+	s.NoError(err)
+	s.True(resp.GetDeleted())
+	s.Equal([]string{tasklist, "testStickyTaskList", "testActivityTaskList"}, resp.GetTaskLists())
+}
+
+func (s *engineSuite) TestDeleteWorkflowExecution_Running() {
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+
+	msBuilder := newMutableStateBuilderWithEventV2(s.mockHistoryEngine.shard, s.eventsCache,
+		loggerimpl.NewDevelopmentForTest(s.Suite), execution.GetRunId())
+	addWorkflowExecutionStartedEvent(msBuilder, execution, "wType", "testTaskList", payloads.EncodeString("input"), 100, 50, 200, "testIdentity")
+	gweResponse := &persistence.GetWorkflowExecutionResponse{State: createMutableState(msBuilder)}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gweResponse, nil).Once()
+
+	_, err := s.mockHistoryEngine.DeleteWorkflowExecution(context.Background(), &historyservice.DeleteWorkflowExecutionRequest{
+		NamespaceId: testNamespaceID,
+		Execution:   &execution,
+	})
+	s.Equal(ErrWorkflowNotClosed, err)
+}
+
+func (s *engineSuite) TestDeleteWorkflowExecution_MutableStateNotFound() {
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: "wId",
+		RunId:      testRunID,
+	}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, serviceerror.NewNotFound("")).Once()
+	s.mockShard.resource.VisibilityMgr.On("DeleteWorkflowExecution", mock.Anything).Return(nil).Once()
+
+	resp, err := s.mockHistoryEngine.DeleteWorkflowExecution(context.Background(), &historyservice.DeleteWorkflowExecutionRequest{
+		NamespaceId: testNamespaceID,
+		Execution:   &execution,
+	})
+	s.NoError(err)
+	s.False(resp.GetDeleted())
+	s.Empty(resp.GetTaskLists())
+}
+
 func (s *engineSuite) TestRespondDecisionTaskCompletedSingleActivityScheduledDecision() {
 
 	we := commonpb.WorkflowExecution{
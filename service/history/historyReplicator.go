@@ -34,7 +34,6 @@ import (
 	eventpb "go.temporal.io/temporal-proto/event"
 	executionpb "go.temporal.io/temporal-proto/execution"
 	"go.temporal.io/temporal-proto/serviceerror"
-	"go.temporal.io/temporal-proto/workflowservice"
 
 	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
@@ -64,7 +63,7 @@ type (
 		clusterMetadata   cluster.Metadata
 		metricsClient     metrics.Client
 		logger            log.Logger
-		resetor           workflowResetor
+		resetter          workflowResetter
 
 		getNewConflictResolver conflictResolverProvider
 		getNewStateBuilder     stateBuilderProvider
@@ -159,7 +158,7 @@ func newHistoryReplicator(
 			)
 		},
 	}
-	replicator.resetor = newWorkflowResetor(historyEngine)
+	replicator.resetter = newWorkflowResetter(shard, historyCache, replicator.logger)
 
 	return replicator
 }
@@ -335,6 +334,8 @@ func (r *historyReplicator) ApplyStartEvent(
 		return err
 	}
 	msBuilder := r.getNewMutableState(namespaceEntry, logger)
+	// completion callbacks are not part of the history, they are set before the events are applied
+	msBuilder.GetExecutionInfo().CompletionCallbacks = newCompletionCallbacks(request.GetCompletionCallbackUrls())
 	return r.ApplyReplicationTask(ctx, context, msBuilder, request, logger)
 }
 
@@ -387,7 +388,7 @@ func (r *historyReplicator) ApplyOtherEventsMissingMutableState(
 
 		}
 		if request.GetResetWorkflow() {
-			return r.resetor.ApplyResetEvent(ctx, request, namespaceID, workflowID, currentRunID)
+			return r.resetter.replicateResetWorkflow(ctx, request, namespaceID, workflowID, currentRunID)
 		}
 		return newRetryTaskErrorWithHint(ErrWorkflowNotFoundMsg, namespaceID, workflowID, runID, common.FirstEventID)
 	}
@@ -403,7 +404,7 @@ func (r *historyReplicator) ApplyOtherEventsMissingMutableState(
 
 	if request.GetResetWorkflow() {
 		//Note that at this point, current run is already closed and currentLastWriteVersion <= incomingVersion
-		return r.resetor.ApplyResetEvent(ctx, request, namespaceID, workflowID, currentRunID)
+		return r.resetter.replicateResetWorkflow(ctx, request, namespaceID, workflowID, currentRunID)
 	}
 	return newRetryTaskErrorWithHint(ErrWorkflowNotFoundMsg, namespaceID, workflowID, runID, common.FirstEventID)
 }
@@ -1227,36 +1228,57 @@ func (r *historyReplicator) reapplyEventsToCurrentClosedWorkflow(
 
 	}
 
-	resetDecisionFinishID := lastDecisionTaskStartEventID + 1
+	if !namespaceEntry.IsNamespaceActive() {
+		return nil
+	}
 
-	baseContext := context
-	baseMutableState := msBuilder
-	currContext := context
-	currMutableState := msBuilder
-	resp, err := r.resetor.ResetWorkflowExecution(
-		ctx,
-		&workflowservice.ResetWorkflowExecutionRequest{
-			Namespace:             namespaceEntry.GetInfo().Name,
-			WorkflowExecution:     context.getExecution(),
-			Reason:                workflowResetReason,
-			DecisionFinishEventId: resetDecisionFinishID,
-			RequestId:             resetRequestID,
-		},
-		baseContext,
-		baseMutableState,
-		currContext,
-		currMutableState,
-	)
+	resetRunID := uuid.New()
+	baseRunID := msBuilder.GetExecutionInfo().RunID
+	baseRebuildLastEventID := lastDecisionTaskStartEventID
+	baseVersionHistories, err := r.resetter.backfillVersionHistories(context, msBuilder, true)
 	if err != nil {
-		if _, ok := err.(*serviceerror.NamespaceNotActive); ok {
-			return nil
-		}
+		return err
+	}
+	baseCurrentVersionHistory, err := baseVersionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		return err
+	}
+	baseRebuildLastEventVersion, err := baseCurrentVersionHistory.GetEventVersion(baseRebuildLastEventID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.resetter.resetWorkflow(
+		ctx,
+		namespaceID,
+		workflowID,
+		baseRunID,
+		baseCurrentVersionHistory.GetBranchToken(),
+		baseRebuildLastEventID,
+		baseRebuildLastEventVersion,
+		msBuilder.GetNextEventID(),
+		msBuilder.GetReplicationState(),
+		msBuilder.GetExecutionInfo().CompletionCallbacks,
+		resetRunID,
+		resetRequestID,
+		newNDCWorkflow(
+			ctx,
+			r.namespaceCache,
+			r.clusterMetadata,
+			context,
+			msBuilder,
+			noopReleaseFn, // this is fine since caller will defer on release
+		),
+		workflowResetReason,
+		nil,
+		nil,
+	); err != nil {
 		return err
 	}
 
 	resetNewExecution := commonpb.WorkflowExecution{
 		WorkflowId: workflowID,
-		RunId:      resp.GetRunId(),
+		RunId:      resetRunID,
 	}
 	resetNewContext, resetNewRelease, err := r.historyCache.getOrCreateWorkflowExecution(
 		ctx, namespaceID, resetNewExecution,
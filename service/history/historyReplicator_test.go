@@ -68,7 +68,7 @@ type (
 
 		controller               *gomock.Controller
 		mockShard                *shardContextTest
-		mockWorkflowResetor      *MockworkflowResetor
+		mockWorkflowResetter     *MockworkflowResetter
 		mockTxProcessor          *MocktransferQueueProcessor
 		mockReplicationProcessor *MockReplicatorQueueProcessor
 		mockTimerProcessor       *MocktimerQueueProcessor
@@ -102,7 +102,7 @@ func (s *historyReplicatorSuite) SetupTest() {
 	s.Assertions = require.New(s.T())
 
 	s.controller = gomock.NewController(s.T())
-	s.mockWorkflowResetor = NewMockworkflowResetor(s.controller)
+	s.mockWorkflowResetter = NewMockworkflowResetter(s.controller)
 	s.mockTxProcessor = NewMocktransferQueueProcessor(s.controller)
 	s.mockReplicationProcessor = NewMockReplicatorQueueProcessor(s.controller)
 	s.mockTimerProcessor = NewMocktimerQueueProcessor(s.controller)
@@ -152,7 +152,7 @@ func (s *historyReplicatorSuite) SetupTest() {
 	s.mockShard.SetEngine(engine)
 
 	s.historyReplicator = newHistoryReplicator(s.mockShard, clock.NewEventTimeSource(), engine, historyCache, s.mockNamespaceCache, s.mockHistoryV2Mgr, s.logger)
-	s.historyReplicator.resetor = s.mockWorkflowResetor
+	s.historyReplicator.resetter = s.mockWorkflowResetter
 }
 
 func (s *historyReplicatorSuite) TearDownTest() {
@@ -788,7 +788,7 @@ func (s *historyReplicatorSuite) TestWorkflowReset() {
 
 	reqCtx := context.Background()
 
-	s.mockWorkflowResetor.EXPECT().ApplyResetEvent(
+	s.mockWorkflowResetter.EXPECT().replicateResetWorkflow(
 		reqCtx, req, namespaceID, workflowID, currentRunID,
 	).Return(nil).Times(1)
 
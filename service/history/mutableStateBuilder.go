@@ -25,10 +25,12 @@
 package history
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
 	"github.com/pborman/uuid"
 	commonpb "go.temporal.io/temporal-proto/common"
@@ -46,6 +48,7 @@ import (
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
+	"github.com/temporalio/temporal/common/blobstore"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/checksum"
 	"github.com/temporalio/temporal/common/clock"
@@ -377,6 +380,14 @@ func (e *mutableStateBuilder) SetCurrentBranchToken(
 	return currentVersionHistory.SetBranchToken(branchToken)
 }
 
+// TODO nDC deprecate once replication state is deprecated
+func (e *mutableStateBuilder) SetReplicationState(
+	replicationState *persistence.ReplicationState,
+) {
+
+	e.replicationState = replicationState
+}
+
 func (e *mutableStateBuilder) SetVersionHistories(
 	versionHistories *persistence.VersionHistories,
 ) error {
@@ -1717,6 +1728,7 @@ func (e *mutableStateBuilder) addWorkflowExecutionStartedEventForContinueAsNew(
 		ContinuedFailure:                attributes.GetFailure(),
 		ContinueAsNewInitiator:          attributes.Initiator,
 		FirstDecisionTaskBackoffSeconds: attributes.BackoffStartIntervalInSeconds,
+		CompletionCallbackUrls:          getCompletionCallbackURLs(previousExecutionInfo.CompletionCallbacks),
 	}
 	if attributes.GetInitiator() == commonpb.ContinueAsNewInitiator_Retry {
 		req.Attempt = previousExecutionState.GetExecutionInfo().Attempt + 1
@@ -1741,6 +1753,7 @@ func (e *mutableStateBuilder) addWorkflowExecutionStartedEventForContinueAsNew(
 	); err != nil {
 		return nil, err
 	}
+	e.executionInfo.CompletionCallbacks = newCompletionCallbacks(req.GetCompletionCallbackUrls())
 
 	if err := e.SetHistoryTree(e.GetExecutionInfo().RunID); err != nil {
 		return nil, err
@@ -1801,6 +1814,7 @@ func (e *mutableStateBuilder) AddWorkflowExecutionStartedEvent(
 		event); err != nil {
 		return nil, err
 	}
+	e.executionInfo.CompletionCallbacks = newCompletionCallbacks(startRequest.GetCompletionCallbackUrls())
 	// TODO merge active & passive task generation
 	if err := e.taskGenerator.generateWorkflowStartTasks(
 		e.unixNanoToTime(event.GetTimestamp()),
@@ -3370,11 +3384,16 @@ func (e *mutableStateBuilder) AddContinueAsNewEvent(
 		}
 	}
 
+	// retry and cron copy the input of the current run, which may reference payloads offloaded for the current run
+	startAttributes, err := e.rehydrateContinueAsNewAttributes(attributes)
+	if err != nil {
+		return nil, nil, err
+	}
 	if _, err = newStateBuilder.addWorkflowExecutionStartedEventForContinueAsNew(
 		parentInfo,
 		newExecution,
 		e,
-		attributes,
+		startAttributes,
 		firstRunID,
 	); err != nil {
 		return nil, nil, serviceerror.NewInternal("Failed to add workflow execution started event.")
@@ -3397,6 +3416,30 @@ func (e *mutableStateBuilder) AddContinueAsNewEvent(
 	return continueAsNewEvent, newStateBuilder, nil
 }
 
+// rehydrateContinueAsNewAttributes returns a copy of the attributes with the offloaded payloads rehydrated,
+// so the blobs of the current run can be deleted with the current run
+func (e *mutableStateBuilder) rehydrateContinueAsNewAttributes(
+	attributes *decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes,
+) (*decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes, error) {
+
+	if !blobstore.HasBlobReference(attributes) {
+		return attributes, nil
+	}
+	attributes = proto.Clone(attributes).(*decisionpb.ContinueAsNewWorkflowExecutionDecisionAttributes)
+	ctx, cancel := context.WithTimeout(context.Background(), e.config.PayloadOffloadTimeout(e.namespaceEntry.GetInfo().Name))
+	defer cancel()
+	if err := e.shard.GetService().GetPayloadOffloader().Rehydrate(
+		ctx,
+		e.namespaceEntry.GetConfig().GetHistoryArchivalURI(),
+		e.executionInfo.NamespaceID,
+		e.executionInfo.RunID,
+		attributes,
+	); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
 func rolloverAutoResetPointsWithExpiringTime(
 	resetPoints *executionpb.ResetPoints,
 	prevRunID string,
@@ -32,6 +32,7 @@ import (
 
 	commonpb "go.temporal.io/temporal-proto/common"
 	eventpb "go.temporal.io/temporal-proto/event"
+	executionpb "go.temporal.io/temporal-proto/execution"
 	"go.temporal.io/temporal-proto/serviceerror"
 
 	"github.com/temporalio/temporal/common/cache"
@@ -164,6 +165,15 @@ func (r *mutableStateTaskGeneratorImpl) generateWorkflowCloseTasks(
 		VisibilityTimestamp: now,
 		Version:             currentVersion,
 	})
+	if len(executionInfo.CompletionCallbacks) > 0 &&
+		executionInfo.Status != executionpb.WorkflowExecutionStatus_ContinuedAsNew {
+		// callbacks of a continued as new run are carried over to the new run
+		r.mutableState.AddTransferTasks(&persistence.WorkflowCompletionCallbackTask{
+			// TaskID is set by shard
+			VisibilityTimestamp: now,
+			Version:             currentVersion,
+		})
+	}
 
 	retentionInDays := defaultWorkflowRetentionInDays
 	namespaceEntry, err := r.namespaceCache.GetNamespaceByID(executionInfo.NamespaceID)
@@ -2218,6 +2218,18 @@ func (mr *MockmutableStateMockRecorder) SetHistoryTree(treeID interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHistoryTree", reflect.TypeOf((*MockmutableState)(nil).SetHistoryTree), treeID)
 }
 
+// SetReplicationState mocks base method.
+func (m *MockmutableState) SetReplicationState(arg0 *persistence.ReplicationState) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetReplicationState", arg0)
+}
+
+// SetReplicationState indicates an expected call of SetReplicationState.
+func (mr *MockmutableStateMockRecorder) SetReplicationState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReplicationState", reflect.TypeOf((*MockmutableState)(nil).SetReplicationState), arg0)
+}
+
 // SetVersionHistories mocks base method.
 func (m *MockmutableState) SetVersionHistories(arg0 *persistence.VersionHistories) error {
 	m.ctrl.T.Helper()
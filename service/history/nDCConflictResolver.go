@@ -149,6 +149,7 @@ func (r *nDCConflictResolverImpl) rebuild(
 		workflowIdentifier,
 		replayVersionHistory.GetBranchToken(),
 		requestID,
+		copyCompletionCallbacks(executionInfo.CompletionCallbacks),
 	)
 	if err != nil {
 		return nil, err
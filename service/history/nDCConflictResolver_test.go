@@ -128,10 +128,14 @@ func (s *nDCConflictResolverSuite) TestRebuild() {
 
 	s.mockMutableState.EXPECT().GetUpdateCondition().Return(updateCondition).AnyTimes()
 	s.mockMutableState.EXPECT().GetVersionHistories().Return(versionHistories).AnyTimes()
+	// the rebuilt mutable state keeps the delivery state of the completion callbacks
+	completionCallbacks := newCompletionCallbacks([]string{"http://localhost:8080/callback"})
+	completionCallbacks[0].Attempt = 2
 	s.mockMutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{
-		NamespaceID: s.namespaceID,
-		WorkflowID:  s.workflowID,
-		RunID:       s.runID,
+		NamespaceID:         s.namespaceID,
+		WorkflowID:          s.workflowID,
+		RunID:               s.runID,
+		CompletionCallbacks: completionCallbacks,
 	}).AnyTimes()
 
 	workflowIdentifier := definition.NewWorkflowIdentifier(
@@ -161,6 +165,7 @@ func (s *nDCConflictResolverSuite) TestRebuild() {
 		workflowIdentifier,
 		branchToken1,
 		requestID,
+		completionCallbacks,
 	).Return(mockRebuildMutableState, historySize, nil).Times(1)
 
 	s.mockContext.EXPECT().clear().Times(1)
@@ -254,6 +259,7 @@ func (s *nDCConflictResolverSuite) TestPrepareMutableState_Rebuild() {
 		workflowIdentifier,
 		branchToken1,
 		gomock.Any(),
+		nil,
 	).Return(mockRebuildMutableState, historySize, nil).Times(1)
 
 	s.mockContext.EXPECT().clear().Times(1)
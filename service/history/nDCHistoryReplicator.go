@@ -256,7 +256,22 @@ func (r *nDCHistoryReplicatorImpl) applyEvents(
 		case nil:
 			// Sanity check to make only 3DC mutable state here
 			if mutableState.GetVersionHistories() == nil {
-				return serviceerror.NewInternal("The mutable state does not support 3DC.")
+				if mutableState.GetReplicationState() == nil {
+					return serviceerror.NewInternal("The mutable state does not support 3DC.")
+				}
+				// 2DC workflow which version histories were backfilled by the source cluster
+				currentRunID, err := r.transactionMgr.getCurrentWorkflowRunID(ctx, task.getNamespaceID(), task.getWorkflowID())
+				if err != nil {
+					return err
+				}
+				if _, err := backfillVersionHistories(
+					r.shard,
+					context,
+					mutableState,
+					currentRunID == task.getRunID(),
+				); err != nil {
+					return err
+				}
 			}
 
 			doContinue, branchIndex, err := r.applyNonStartEventsPrepareBranch(ctx, context, mutableState, task)
@@ -306,6 +321,9 @@ func (r *nDCHistoryReplicatorImpl) applyStartEvents(
 	}
 	requestID := uuid.New() // requestID used for start workflow execution request.  This is not on the history event.
 	mutableState := r.newMutableState(namespaceEntry, task.getLogger())
+	// completion callbacks are not part of the history, they are set before the events are applied
+	// so that the tasks generated when the workflow closes include them
+	mutableState.GetExecutionInfo().CompletionCallbacks = newCompletionCallbacks(task.getCompletionCallbackURLs())
 	stateBuilder := r.newStateBuilder(mutableState, task.getLogger())
 
 	// use state builder for workflow mutable state mutation
@@ -57,6 +57,7 @@ type (
 		getNewEvents() []*eventpb.HistoryEvent
 		getLogger() log.Logger
 		getVersionHistory() *persistence.VersionHistory
+		getCompletionCallbackURLs() []string
 		isWorkflowReset() bool
 
 		splitTask(taskStartTime time.Time) (nDCReplicationTask, nDCReplicationTask, error)
@@ -73,6 +74,8 @@ type (
 		events         []*eventpb.HistoryEvent
 		newEvents      []*eventpb.HistoryEvent
 		versionHistory *persistence.VersionHistory
+		// completionCallbackURLs are the callbacks of the run started by the events
+		completionCallbackURLs []string
 
 		startTime time.Time
 		logger    log.Logger
@@ -158,6 +161,8 @@ func newNDCReplicationTask(
 		newEvents:      newEvents,
 		versionHistory: persistence.NewVersionHistoryFromProto(versionHistory),
 
+		completionCallbackURLs: request.GetCompletionCallbackUrls(),
+
 		startTime: taskStartTime,
 		logger:    logger,
 	}, nil
@@ -215,6 +220,10 @@ func (t *nDCReplicationTaskImpl) getVersionHistory() *persistence.VersionHistory
 	return t.versionHistory
 }
 
+func (t *nDCReplicationTaskImpl) getCompletionCallbackURLs() []string {
+	return t.completionCallbackURLs
+}
+
 func (t *nDCReplicationTaskImpl) isWorkflowReset() bool {
 	switch t.getFirstEvent().GetEventType() {
 	case eventpb.EventType_DecisionTaskFailed:
@@ -288,6 +297,9 @@ func (t *nDCReplicationTaskImpl) splitTask(
 		newEvents:      []*eventpb.HistoryEvent{},
 		versionHistory: newVersionHistory,
 
+		// the callbacks of a continued as new run are carried over to the new run
+		completionCallbackURLs: t.completionCallbackURLs,
+
 		startTime: taskStartTime,
 		logger:    logger,
 	}
@@ -123,6 +123,10 @@ type (
 		decisionScheduleToStartTimeout int32
 		tasklist                       tasklistpb.TaskList
 	}
+
+	completionCallbackPendingInfo struct {
+		pendingCallbacks int
+	}
 )
 
 func newHistoryResendInfo(
@@ -166,6 +170,15 @@ func newPushDecisionToMatchingInfo(
 	}
 }
 
+func newCompletionCallbackPendingInfo(
+	pendingCallbacks int,
+) *completionCallbackPendingInfo {
+
+	return &completionCallbackPendingInfo{
+		pendingCallbacks: pendingCallbacks,
+	}
+}
+
 func getHistoryResendInfo(
 	mutableState mutableState,
 ) (*historyResendInfo, error) {
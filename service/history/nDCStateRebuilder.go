@@ -31,6 +31,7 @@ import (
 	"fmt"
 	"time"
 
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/cluster"
@@ -57,6 +58,7 @@ type (
 			targetWorkflowIdentifier definition.WorkflowIdentifier,
 			targetBranchToken []byte,
 			requestID string,
+			completionCallbacks []*executiongenpb.CompletionCallbackInfo,
 		) (mutableState, int64, error)
 	}
 
@@ -107,6 +109,7 @@ func (r *nDCStateRebuilderImpl) rebuild(
 	targetWorkflowIdentifier definition.WorkflowIdentifier,
 	targetBranchToken []byte,
 	requestID string,
+	completionCallbacks []*executiongenpb.CompletionCallbackInfo,
 ) (mutableState, int64, error) {
 
 	iter := collection.NewPagingIterator(r.getPaginationFn(
@@ -130,6 +133,9 @@ func (r *nDCStateRebuilderImpl) rebuild(
 	rebuiltMutableState, stateBuilder := r.initializeBuilders(
 		namespaceEntry,
 	)
+	// completion callbacks are not part of the history, they are set before the events are applied
+	// so that the tasks generated for the rebuilt mutable state include them
+	rebuiltMutableState.GetExecutionInfo().CompletionCallbacks = completionCallbacks
 	if err := r.applyEvents(targetWorkflowIdentifier, stateBuilder, firstEventBatch, requestID); err != nil {
 		return nil, 0, err
 	}
@@ -319,6 +319,7 @@ func (s *nDCStateRebuilderSuite) TestRebuild() {
 	), nil).AnyTimes()
 	s.mockTaskRefresher.EXPECT().refreshTasks(now, gomock.Any()).Return(nil).Times(1)
 
+	completionCallbacks := newCompletionCallbacks([]string{"http://localhost:8080/callback"})
 	rebuildMutableState, rebuiltHistorySize, err := s.nDCStateRebuilder.rebuild(
 		context.Background(),
 		now,
@@ -329,10 +330,12 @@ func (s *nDCStateRebuilderSuite) TestRebuild() {
 		definition.NewWorkflowIdentifier(targetNamespaceID, targetWorkflowID, targetRunID),
 		targetBranchToken,
 		requestID,
+		completionCallbacks,
 	)
 	s.NoError(err)
 	s.NotNil(rebuildMutableState)
 	rebuildExecutionInfo := rebuildMutableState.GetExecutionInfo()
+	s.Equal(completionCallbacks, rebuildExecutionInfo.CompletionCallbacks)
 	s.Equal(targetNamespaceID, rebuildExecutionInfo.NamespaceID)
 	s.Equal(targetWorkflowID, rebuildExecutionInfo.WorkflowID)
 	s.Equal(targetRunID, rebuildExecutionInfo.RunID)
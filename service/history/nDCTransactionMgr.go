@@ -349,11 +349,14 @@ func (r *nDCTransactionMgrImpl) backfillWorkflowEventsReapply(
 			baseRebuildLastEventID,
 			baseRebuildLastEventVersion,
 			baseNextEventID,
+			baseMutableState.GetReplicationState(),
+			baseMutableState.GetExecutionInfo().CompletionCallbacks,
 			resetRunID,
 			uuid.New(),
 			targetWorkflow,
 			eventsReapplicationResetWorkflowReason,
 			targetWorkflowEvents.Events,
+			nil,
 		); err != nil {
 			return 0, transactionPolicyActive, err
 		}
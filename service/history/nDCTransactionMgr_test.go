@@ -216,6 +216,7 @@ func (s *nDCTransactionMgrSuite) TestBackfillWorkflow_CurrentWorkflow_Active_Clo
 	mutableState.EXPECT().GetNextEventID().Return(nextEventID).AnyTimes()
 	mutableState.EXPECT().GetPreviousStartedEventID().Return(lastDecisionTaskStartedEventID).Times(1)
 	mutableState.EXPECT().GetVersionHistories().Return(histories).Times(1)
+	mutableState.EXPECT().GetReplicationState().Return(nil).AnyTimes()
 
 	s.mockWorkflowResetter.EXPECT().resetWorkflow(
 		ctx,
@@ -226,11 +227,14 @@ func (s *nDCTransactionMgrSuite) TestBackfillWorkflow_CurrentWorkflow_Active_Clo
 		lastDecisionTaskStartedEventID,
 		lastDecisionTaskStartedVersion,
 		nextEventID,
+		nil,
+		nil,
 		gomock.Any(),
 		gomock.Any(),
 		workflow,
 		eventsReapplicationResetWorkflowReason,
 		workflowEvents.Events,
+		nil,
 	).Return(nil).Times(1)
 
 	s.mockExecutionMgr.On("GetCurrentExecution", &persistence.GetCurrentExecutionRequest{
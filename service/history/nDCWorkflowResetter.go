@@ -31,6 +31,8 @@ import (
 	"time"
 
 	"github.com/pborman/uuid"
+
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/convert"
 	"github.com/temporalio/temporal/common/definition"
@@ -107,7 +109,7 @@ func (r *nDCWorkflowResetterImpl) resetWorkflow(
 	incomingFirstEventVersion int64,
 ) (mutableState, error) {
 
-	baseBranchToken, err := r.getBaseBranchToken(
+	baseBranchToken, baseReplicationState, baseCompletionCallbacks, err := r.getBaseBranchToken(
 		ctx,
 		baseLastEventID,
 		baseLastEventVersion,
@@ -140,10 +142,17 @@ func (r *nDCWorkflowResetterImpl) resetWorkflow(
 		),
 		resetBranchToken,
 		requestID,
+		inheritCompletionCallbacks(baseCompletionCallbacks),
 	)
 	if err != nil {
 		return nil, err
 	}
+	inheritReplicationState(
+		baseReplicationState,
+		rebuildMutableState,
+		baseLastEventVersion,
+		baseLastEventID,
+	)
 
 	r.newContext.clear()
 	r.newContext.setHistorySize(rebuiltHistorySize)
@@ -156,7 +165,12 @@ func (r *nDCWorkflowResetterImpl) getBaseBranchToken(
 	baseLastEventVersion int64,
 	incomingFirstEventID int64,
 	incomingFirstEventVersion int64,
-) (baseBranchToken []byte, retError error) {
+) (
+	baseBranchToken []byte,
+	baseReplicationState *persistence.ReplicationState,
+	baseCompletionCallbacks []*executiongenpb.CompletionCallbackInfo,
+	retError error,
+) {
 
 	baseWorkflow, err := r.transactionMgr.loadNDCWorkflow(
 		ctx,
@@ -165,13 +179,30 @@ func (r *nDCWorkflowResetterImpl) getBaseBranchToken(
 		r.baseRunID,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	defer func() {
 		baseWorkflow.getReleaseFn()(retError)
 	}()
 
-	baseVersionHistories := baseWorkflow.getMutableState().GetVersionHistories()
+	baseMutableState := baseWorkflow.getMutableState()
+	baseVersionHistories := baseMutableState.GetVersionHistories()
+	if baseVersionHistories == nil {
+		// the base workflow is a 2DC workflow which version histories were backfilled by the source cluster
+		currentRunID, err := r.transactionMgr.getCurrentWorkflowRunID(ctx, r.namespaceID, r.workflowID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		baseVersionHistories, err = backfillVersionHistories(
+			r.shard,
+			baseWorkflow.getContext(),
+			baseMutableState,
+			currentRunID == r.baseRunID,
+		)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
 	index, err := baseVersionHistories.FindFirstVersionHistoryIndexByItem(
 		persistence.NewVersionHistoryItem(baseLastEventID, baseLastEventVersion),
 	)
@@ -179,7 +210,7 @@ func (r *nDCWorkflowResetterImpl) getBaseBranchToken(
 		// the base event and incoming event are from different branch
 		// only re-replicate the gap on the incoming branch
 		// the base branch event will eventually arrived
-		return nil, newNDCRetryTaskErrorWithHint(
+		return nil, nil, nil, newNDCRetryTaskErrorWithHint(
 			resendOnResetWorkflowMessage,
 			r.namespaceID,
 			r.workflowID,
@@ -193,9 +224,12 @@ func (r *nDCWorkflowResetterImpl) getBaseBranchToken(
 
 	baseVersionHistory, err := baseVersionHistories.GetVersionHistory(index)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
-	return baseVersionHistory.GetBranchToken(), nil
+	return baseVersionHistory.GetBranchToken(),
+		baseMutableState.GetReplicationState(),
+		baseMutableState.GetExecutionInfo().CompletionCallbacks,
+		nil
 }
 
 func (r *nDCWorkflowResetterImpl) getResetBranchToken(
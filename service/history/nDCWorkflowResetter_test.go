@@ -34,9 +34,12 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
 	"go.temporal.io/temporal-proto/serviceerror"
 
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/log"
@@ -148,6 +151,14 @@ func (s *nDCWorkflowResetterSuite) TestResetWorkflow_NoError() {
 	newBranchToken := []byte("other random branch token")
 
 	s.mockBaseMutableState.EXPECT().GetVersionHistories().Return(versionHistories).AnyTimes()
+	s.mockBaseMutableState.EXPECT().GetReplicationState().Return(nil).AnyTimes()
+	// the callbacks of the base workflow are delivered again once the reset workflow closes
+	baseCompletionCallbacks := newCompletionCallbacks([]string{"http://localhost:8080/callback"})
+	baseCompletionCallbacks[0].State = executiongenpb.CompletionCallbackState_CompletionCallbackState_Succeeded
+	baseCompletionCallbacks[0].Attempt = 1
+	s.mockBaseMutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{
+		CompletionCallbacks: baseCompletionCallbacks,
+	}).AnyTimes()
 
 	mockBaseWorkflowReleaseFnCalled := false
 	mockBaseWorkflowReleaseFn := func(err error) {
@@ -182,6 +193,7 @@ func (s *nDCWorkflowResetterSuite) TestResetWorkflow_NoError() {
 		),
 		newBranchToken,
 		gomock.Any(),
+		newCompletionCallbacks([]string{"http://localhost:8080/callback"}),
 	).Return(s.mockRebuiltMutableState, rebuiltHistorySize, nil).Times(1)
 
 	shardId := s.mockShard.GetShardID()
@@ -264,3 +276,122 @@ func (s *nDCWorkflowResetterSuite) TestResetWorkflow_Error() {
 	)
 	s.Equal(retryErr, expectedErr)
 }
+
+func (s *nDCWorkflowResetterSuite) TestResetWorkflow_ReplicationState() {
+	ctx := context.Background()
+	now := time.Now()
+
+	branchToken := []byte("some random branch token")
+	baseEventID := int64(2)
+	baseNextEventID := int64(4)
+	baseVersion := int64(123)
+	incomingFirstEventID := baseEventID + 1
+	incomingVersion := baseVersion + 3
+	rebuiltHistorySize := int64(9999)
+	newBranchToken := []byte("other random branch token")
+
+	shardId := s.mockShard.GetShardID()
+	s.mockHistoryV2Mgr.On("ReadHistoryBranchByBatch", &persistence.ReadHistoryBranchRequest{
+		BranchToken:   branchToken,
+		MinEventID:    common.FirstEventID,
+		MaxEventID:    baseNextEventID,
+		PageSize:      nDCDefaultPageSize,
+		NextPageToken: nil,
+		ShardID:       &shardId,
+	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
+		History: []*eventpb.History{{Events: []*eventpb.HistoryEvent{
+			{EventId: 1, Version: baseVersion, EventType: eventpb.EventType_WorkflowExecutionStarted},
+			{EventId: 2, Version: baseVersion, EventType: eventpb.EventType_DecisionTaskScheduled},
+			{EventId: 3, Version: baseVersion, EventType: eventpb.EventType_DecisionTaskStarted},
+		}}},
+		NextPageToken: nil,
+	}, nil).Once()
+
+	// the version histories of the 2DC base workflow are backfilled and persisted
+	backfilledVersionHistories := persistence.NewVersionHistories(persistence.NewVersionHistory(
+		branchToken,
+		[]*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(3, baseVersion)},
+	))
+	s.mockBaseMutableState.EXPECT().GetVersionHistories().Return(nil).AnyTimes()
+	s.mockBaseMutableState.EXPECT().GetCurrentBranchToken().Return(branchToken, nil).AnyTimes()
+	s.mockBaseMutableState.EXPECT().GetNextEventID().Return(baseNextEventID).AnyTimes()
+	s.mockBaseMutableState.EXPECT().SetVersionHistories(backfilledVersionHistories).Return(nil).Times(1)
+	s.mockBaseMutableState.EXPECT().GetReplicationState().Return(&persistence.ReplicationState{
+		StartVersion:     baseVersion,
+		CurrentVersion:   baseVersion,
+		LastWriteVersion: baseVersion,
+		LastWriteEventID: 3,
+	}).AnyTimes()
+	s.mockBaseMutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{}).AnyTimes()
+	mockBaseContext := NewMockworkflowExecutionContext(s.controller)
+	mockBaseContext.EXPECT().updateWorkflowExecutionWithNew(
+		gomock.Any(),
+		persistence.UpdateWorkflowModeBypassCurrent,
+		nil,
+		nil,
+		transactionPolicyPassive,
+		nil,
+	).Return(nil).Times(1)
+
+	mockBaseWorkflow := NewMocknDCWorkflow(s.controller)
+	mockBaseWorkflow.EXPECT().getContext().Return(mockBaseContext).AnyTimes()
+	mockBaseWorkflow.EXPECT().getMutableState().Return(s.mockBaseMutableState).AnyTimes()
+	mockBaseWorkflow.EXPECT().getReleaseFn().Return(func(err error) {}).Times(1)
+	s.mockTransactionMgr.EXPECT().loadNDCWorkflow(
+		ctx,
+		s.namespaceID,
+		s.workflowID,
+		s.baseRunID,
+	).Return(mockBaseWorkflow, nil).Times(1)
+	s.mockTransactionMgr.EXPECT().getCurrentWorkflowRunID(
+		ctx,
+		s.namespaceID,
+		s.workflowID,
+	).Return(s.newRunID, nil).Times(1)
+
+	s.mockHistoryV2Mgr.On("ForkHistoryBranch", &persistence.ForkHistoryBranchRequest{
+		ForkBranchToken: branchToken,
+		ForkNodeID:      baseEventID + 1,
+		Info:            persistence.BuildHistoryGarbageCleanupInfo(s.namespaceID, s.workflowID, s.newRunID),
+		ShardID:         &shardId,
+	}).Return(&persistence.ForkHistoryBranchResponse{NewBranchToken: newBranchToken}, nil).Times(1)
+	s.mockStateBuilder.EXPECT().rebuild(
+		ctx,
+		now,
+		definition.NewWorkflowIdentifier(
+			s.namespaceID,
+			s.workflowID,
+			s.baseRunID,
+		),
+		branchToken,
+		baseEventID,
+		baseVersion,
+		definition.NewWorkflowIdentifier(
+			s.namespaceID,
+			s.workflowID,
+			s.newRunID,
+		),
+		newBranchToken,
+		gomock.Any(),
+		nil,
+	).Return(s.mockRebuiltMutableState, rebuiltHistorySize, nil).Times(1)
+
+	// the reset workflow keeps the replication state of the base workflow, like on the source cluster
+	s.mockRebuiltMutableState.EXPECT().SetReplicationState(&persistence.ReplicationState{
+		StartVersion:        baseVersion,
+		CurrentVersion:      baseVersion,
+		LastReplicationInfo: make(map[string]*replicationgenpb.ReplicationInfo),
+	}).Times(1)
+	s.mockRebuiltMutableState.EXPECT().UpdateReplicationStateLastEventID(baseVersion, baseEventID).Times(1)
+
+	rebuiltMutableState, err := s.nDCWorkflowResetter.resetWorkflow(
+		ctx,
+		now,
+		baseEventID,
+		baseVersion,
+		incomingFirstEventID,
+		incomingVersion,
+	)
+	s.NoError(err)
+	s.Equal(s.mockRebuiltMutableState, rebuiltMutableState)
+}
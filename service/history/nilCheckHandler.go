@@ -77,6 +77,10 @@ func (h *NilCheckHandler) PollMutableState(ctx context.Context, request *history
 	return resp, err
 }
 
+func (h *NilCheckHandler) WatchMutableState(request *historyservice.WatchMutableStateRequest, stream historyservice.HistoryService_WatchMutableStateServer) error {
+	return h.parentHandler.WatchMutableState(request, stream)
+}
+
 func (h *NilCheckHandler) ResetStickyTaskList(ctx context.Context, request *historyservice.ResetStickyTaskListRequest) (_ *historyservice.ResetStickyTaskListResponse, retError error) {
 	resp, err := h.parentHandler.ResetStickyTaskList(ctx, request)
 	if resp == nil && err == nil {
@@ -356,3 +360,43 @@ func (h *NilCheckHandler) RefreshWorkflowTasks(ctx context.Context, request *his
 	}
 	return resp, err
 }
+
+func (h *NilCheckHandler) ImportWorkflowExecution(ctx context.Context, request *historyservice.ImportWorkflowExecutionRequest) (*historyservice.ImportWorkflowExecutionResponse, error) {
+	resp, err := h.parentHandler.ImportWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.ImportWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) DeleteWorkflowExecution(ctx context.Context, request *historyservice.DeleteWorkflowExecutionRequest) (*historyservice.DeleteWorkflowExecutionResponse, error) {
+	resp, err := h.parentHandler.DeleteWorkflowExecution(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.DeleteWorkflowExecutionResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) GetReplicationStatus(ctx context.Context, request *historyservice.GetReplicationStatusRequest) (*historyservice.GetReplicationStatusResponse, error) {
+	resp, err := h.parentHandler.GetReplicationStatus(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.GetReplicationStatusResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) SetShardOwner(ctx context.Context, request *historyservice.SetShardOwnerRequest) (*historyservice.SetShardOwnerResponse, error) {
+	resp, err := h.parentHandler.SetShardOwner(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.SetShardOwnerResponse{}
+	}
+	return resp, err
+}
+
+func (h *NilCheckHandler) DrainHost(ctx context.Context, request *historyservice.DrainHostRequest) (*historyservice.DrainHostResponse, error) {
+	resp, err := h.parentHandler.DrainHost(ctx, request)
+	if resp == nil && err == nil {
+		resp = &historyservice.DrainHostResponse{}
+	}
+	return resp, err
+}
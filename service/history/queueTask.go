@@ -25,6 +25,7 @@
 package history
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -41,6 +42,7 @@ import (
 	"github.com/temporalio/temporal/common/primitives/timestamp"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 	"github.com/temporalio/temporal/common/task"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 type (
@@ -59,6 +61,9 @@ type (
 		taskExecutor  queueTaskExecutor
 		maxRetryCount dynamicconfig.IntPropertyFn
 
+		// failedAttempt counts the attempts failed with non-transient errors, the task is moved to DLQ based on it
+		failedAttempt int
+
 		// TODO: following two fields should be removed after new task lifecycle is implemented
 		taskFilter        taskFilter
 		shouldProcessTask bool
@@ -224,7 +229,10 @@ func (t *queueTaskBase) Execute() error {
 		}
 	}()
 
-	return t.taskExecutor.execute(t.queueTaskInfo, t.shouldProcessTask)
+	ctx, span := startQueueTaskSpan(t.shard, t.queueTaskInfo)
+	err = t.taskExecutor.execute(ctx, t.queueTaskInfo, t.shouldProcessTask)
+	span.End(err)
+	return err
 }
 
 func (t *queueTaskBase) HandleErr(
@@ -279,6 +287,19 @@ func (t *queueTaskBase) HandleErr(
 	}
 
 	t.logger.Error("Fail to process task", tag.Error(err), tag.LifeCycleProcessingFailed)
+
+	if !isTransientQueueTaskError(err) {
+		t.failedAttempt++
+	}
+	if shouldMoveQueueTaskToDLQ(t.shard.GetConfig(), t.queueTaskInfo, t.failedAttempt, err) {
+		if dlqErr := moveQueueTaskToDLQ(t.shard, t.queueTaskInfo); dlqErr != nil {
+			t.logger.Error("Fail to move task to DLQ", tag.Error(dlqErr))
+			return err
+		}
+		t.scope.IncCounter(metrics.TaskMovedToDLQCounter)
+		t.logger.Warn("Task moved to DLQ after max attempts", tag.Error(err), tag.Attempt(int32(t.attempt+1)))
+		return nil
+	}
 	return err
 }
 
@@ -294,6 +315,7 @@ func (t *queueTaskBase) Ack() {
 
 	t.state = task.TaskStateAcked
 	if t.shouldProcessTask {
+		t.shard.GetLoadTracker().recordTask()
 		t.scope.RecordTimer(metrics.TaskAttemptTimer, time.Duration(t.attempt))
 		t.scope.RecordTimer(metrics.TaskLatency, time.Since(t.submitTime))
 		t.scope.RecordTimer(metrics.TaskQueueLatency, time.Since(*timestamp.TimestampFromProto(t.GetVisibilityTimestamp()).ToTime()))
@@ -333,3 +355,23 @@ func (t *queueTaskBase) SetPriority(
 func (t *queueTaskBase) GetShard() ShardContext {
 	return t.shard
 }
+
+// startQueueTaskSpan starts the span of a task processing attempt. Queue tasks are not
+// processed on behalf of a caller, so the task span is the root of the trace and the
+// returned context passes it on to the shard lock and persistence calls of the task.
+func startQueueTaskSpan(
+	shard ShardContext,
+	taskInfo queueTaskInfo,
+) (context.Context, tracing.Span) {
+	ctx := persistence.WithCallerType(context.Background(), persistence.CallerTypeBackground)
+	ctx, span := shard.GetService().GetTracer().Start(ctx, "queue.task")
+	if span.SpanContext().Sampled {
+		span.SetAttributes(
+			tracing.ShardID(shard.GetShardID()),
+			tracing.NamespaceID(taskInfo.GetNamespaceId()),
+			tracing.WorkflowID(taskInfo.GetWorkflowId()),
+			tracing.TaskType(taskInfo.GetTaskType().String()),
+		)
+	}
+	return ctx, span
+}
@@ -0,0 +1,342 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:generate mockgen -copyright_file ../../LICENSE -package $GOPACKAGE -source $GOFILE -destination queueTaskDLQHandler_mock.go -self_package github.com/temporalio/temporal/service/history
+
+package history
+
+import (
+	"context"
+	"fmt"
+
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	// queueTaskDLQHandler is the interface handles transfer and timer task DLQ messages
+	queueTaskDLQHandler interface {
+		readMessages(
+			queueType queueType,
+			lastMessageID int64,
+			pageSize int,
+			pageToken []byte,
+		) ([]queueTaskInfo, []byte, error)
+		purgeMessages(
+			queueType queueType,
+			lastMessageID int64,
+		) error
+		mergeMessages(
+			ctx context.Context,
+			queueType queueType,
+			lastMessageID int64,
+			pageSize int,
+			pageToken []byte,
+		) ([]byte, error)
+	}
+
+	queueTaskDLQHandlerImpl struct {
+		shard         ShardContext
+		transferQueue transferQueueProcessor
+		timerQueue    timerQueueProcessor
+		logger        log.Logger
+	}
+)
+
+const (
+	// queueTaskDLQMinReadLevel is the exclusive lower bound of task IDs in transfer and timer task DLQ
+	queueTaskDLQMinReadLevel = int64(0)
+)
+
+func newQueueTaskDLQHandler(
+	shard ShardContext,
+	transferQueue transferQueueProcessor,
+	timerQueue timerQueueProcessor,
+) queueTaskDLQHandler {
+
+	return &queueTaskDLQHandlerImpl{
+		shard:         shard,
+		transferQueue: transferQueue,
+		timerQueue:    timerQueue,
+		logger:        shard.GetLogger(),
+	}
+}
+
+func (r *queueTaskDLQHandlerImpl) readMessages(
+	queueType queueType,
+	lastMessageID int64,
+	pageSize int,
+	pageToken []byte,
+) ([]queueTaskInfo, []byte, error) {
+
+	switch queueType {
+	case transferQueueType:
+		resp, err := r.shard.GetExecutionManager().GetTransferTasksFromDLQ(&persistence.GetTransferTasksFromDLQRequest{
+			ReadLevel:     queueTaskDLQMinReadLevel,
+			MaxReadLevel:  lastMessageID,
+			BatchSize:     pageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		tasks := make([]queueTaskInfo, 0, len(resp.Tasks))
+		for _, task := range resp.Tasks {
+			tasks = append(tasks, task)
+		}
+		return tasks, resp.NextPageToken, nil
+	case timerQueueType:
+		resp, err := r.shard.GetExecutionManager().GetTimerTasksFromDLQ(&persistence.GetTimerTasksFromDLQRequest{
+			ReadLevel:     queueTaskDLQMinReadLevel,
+			MaxReadLevel:  lastMessageID,
+			BatchSize:     pageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		tasks := make([]queueTaskInfo, 0, len(resp.Timers))
+		for _, task := range resp.Timers {
+			tasks = append(tasks, task)
+		}
+		return tasks, resp.NextPageToken, nil
+	default:
+		return nil, nil, errUnexpectedQueueTask
+	}
+}
+
+func (r *queueTaskDLQHandlerImpl) purgeMessages(
+	queueType queueType,
+	lastMessageID int64,
+) error {
+
+	return r.rangeDeleteMessages(queueType, queueTaskDLQMinReadLevel, lastMessageID)
+}
+
+func (r *queueTaskDLQHandlerImpl) mergeMessages(
+	ctx context.Context,
+	queueType queueType,
+	lastMessageID int64,
+	pageSize int,
+	pageToken []byte,
+) ([]byte, error) {
+
+	tasks, token, err := r.readMessages(
+		queueType,
+		lastMessageID,
+		pageSize,
+		pageToken,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return token, nil
+	}
+
+	firstTaskID := tasks[0].GetTaskId()
+	lastMergedTaskID := firstTaskID - 1
+	var mergeErr error
+MergeLoop:
+	for _, task := range tasks {
+		select {
+		case <-ctx.Done():
+			mergeErr = ctx.Err()
+			break MergeLoop
+		default:
+		}
+
+		if mergeErr = r.reprocessTask(ctx, task); mergeErr != nil {
+			r.logger.Error("Failed to merge task from DLQ.",
+				tag.Error(mergeErr), tag.TaskID(task.GetTaskId()), tag.TaskType(task.GetTaskType()))
+			break
+		}
+		lastMergedTaskID = task.GetTaskId()
+	}
+
+	// tasks are ordered by task ID, so the merged ones form a contiguous range
+	if lastMergedTaskID >= firstTaskID {
+		if err := r.rangeDeleteMessages(queueType, firstTaskID-1, lastMergedTaskID); err != nil {
+			return nil, err
+		}
+	}
+	if mergeErr != nil {
+		return nil, mergeErr
+	}
+	return token, nil
+}
+
+func (r *queueTaskDLQHandlerImpl) reprocessTask(
+	ctx context.Context,
+	taskInfo queueTaskInfo,
+) error {
+
+	switch task := taskInfo.(type) {
+	case *persistenceblobs.TransferTaskInfo:
+		return r.transferQueue.ReprocessTask(ctx, task)
+	case *persistenceblobs.TimerTaskInfo:
+		return r.timerQueue.ReprocessTask(ctx, task)
+	default:
+		return errUnexpectedQueueTask
+	}
+}
+
+func (r *queueTaskDLQHandlerImpl) rangeDeleteMessages(
+	queueType queueType,
+	exclusiveBeginTaskID int64,
+	inclusiveEndTaskID int64,
+) error {
+
+	switch queueType {
+	case transferQueueType:
+		return r.shard.GetExecutionManager().RangeDeleteTransferTaskFromDLQ(&persistence.RangeDeleteTransferTaskFromDLQRequest{
+			ExclusiveBeginTaskID: exclusiveBeginTaskID,
+			InclusiveEndTaskID:   inclusiveEndTaskID,
+		})
+	case timerQueueType:
+		return r.shard.GetExecutionManager().RangeDeleteTimerTaskFromDLQ(&persistence.RangeDeleteTimerTaskFromDLQRequest{
+			ExclusiveBeginTaskID: exclusiveBeginTaskID,
+			InclusiveEndTaskID:   inclusiveEndTaskID,
+		})
+	default:
+		return errUnexpectedQueueTask
+	}
+}
+
+// toQueueType converts the DLQ type of a transfer or timer task DLQ request to the queue type
+func toQueueType(
+	dlqType commongenpb.DLQType,
+) (queueType, error) {
+
+	switch dlqType {
+	case commongenpb.DLQType_Transfer:
+		return transferQueueType, nil
+	case commongenpb.DLQType_Timer:
+		return timerQueueType, nil
+	default:
+		return 0, serviceerror.NewInvalidArgument(fmt.Sprintf("The DLQ type %v is not supported.", dlqType))
+	}
+}
+
+// isTransientQueueTaskError checks if a task failed with an error which is expected to go away when the task is retried,
+// such as throttling, timeouts, shard movement and namespace handover. These failures are not counted toward moving
+// the task to DLQ, otherwise a task would be dead lettered by an outage of a dependency.
+func isTransientQueueTaskError(
+	err error,
+) bool {
+
+	switch err.(type) {
+	case *serviceerror.ResourceExhausted,
+		*serviceerror.Unavailable,
+		*serviceerror.DeadlineExceeded,
+		*serviceerror.ShardOwnershipLost,
+		*persistence.TimeoutError,
+		*persistence.ShardOwnershipLostError:
+		return true
+	}
+	switch err {
+	case context.DeadlineExceeded,
+		ErrConflict,
+		errCompletionCallbackNotDelivered:
+		return true
+	}
+	return false
+}
+
+// shouldMoveQueueTaskToDLQ checks if a task has failed with non-transient errors
+// enough times to be moved out of the queue
+func shouldMoveQueueTaskToDLQ(
+	config *Config,
+	taskInfo queueTaskInfo,
+	failedAttempt int,
+	err error,
+) bool {
+
+	if err == errCompletionCallbackDeadLetter {
+		return true
+	}
+	if isTransientQueueTaskError(err) {
+		return false
+	}
+
+	var maxAttempts int
+	switch taskInfo.(type) {
+	case *persistenceblobs.TransferTaskInfo:
+		maxAttempts = config.TransferTaskMaxAttemptsBeforeDLQ()
+	case *persistenceblobs.TimerTaskInfo:
+		maxAttempts = config.TimerTaskMaxAttemptsBeforeDLQ()
+	}
+	return maxAttempts > 0 && failedAttempt >= maxAttempts
+}
+
+// moveQueueTaskToDLQ persists the task into the transfer or timer task DLQ of the shard
+func moveQueueTaskToDLQ(
+	shard ShardContext,
+	taskInfo queueTaskInfo,
+) error {
+
+	switch task := taskInfo.(type) {
+	case *persistenceblobs.TransferTaskInfo:
+		return shard.GetExecutionManager().PutTransferTaskToDLQ(&persistence.PutTransferTaskToDLQRequest{
+			TaskInfo: task,
+		})
+	case *persistenceblobs.TimerTaskInfo:
+		return shard.GetExecutionManager().PutTimerTaskToDLQ(&persistence.PutTimerTaskToDLQRequest{
+			TaskInfo: task,
+		})
+	default:
+		return errUnexpectedQueueTask
+	}
+}
+
+// reprocessQueueTask executes a task read from DLQ with the first processor whose filter accepts it
+func reprocessQueueTask(
+	ctx context.Context,
+	taskInfo queueTaskInfo,
+	processors []taskExecutor,
+	logger log.Logger,
+) error {
+
+	for _, processor := range processors {
+		shouldProcessTask, err := processor.getTaskFilter()(taskInfo)
+		if err != nil {
+			return err
+		}
+		if !shouldProcessTask {
+			continue
+		}
+
+		_, err = processor.process(ctx, newTaskInfo(processor, taskInfo, logger))
+		if _, ok := err.(*serviceerror.NotFound); ok || err == ErrTaskDiscarded {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -110,7 +110,7 @@ func (s *queueTaskSuite) TestExecute_ExecutionErr() {
 	})
 
 	executionErr := errors.New("some random error")
-	s.mockQueueTaskExecutor.EXPECT().execute(queueTaskBase.queueTaskInfo, true).Return(executionErr).Times(1)
+	s.mockQueueTaskExecutor.EXPECT().execute(gomock.Any(), queueTaskBase.queueTaskInfo, true).Return(executionErr).Times(1)
 
 	err := queueTaskBase.Execute()
 	s.Equal(executionErr, err)
@@ -121,7 +121,7 @@ func (s *queueTaskSuite) TestExecute_Success() {
 		return true, nil
 	})
 
-	s.mockQueueTaskExecutor.EXPECT().execute(queueTaskBase.queueTaskInfo, true).Return(nil).Times(1)
+	s.mockQueueTaskExecutor.EXPECT().execute(gomock.Any(), queueTaskBase.queueTaskInfo, true).Return(nil).Times(1)
 
 	err := queueTaskBase.Execute()
 	s.NoError(err)
@@ -186,6 +186,44 @@ func (s *queueTaskSuite) TestHandleErr_UnknownErr() {
 	s.Equal(err, queueTaskBase.HandleErr(err))
 }
 
+func (s *queueTaskSuite) TestHandleErr_MoveToDLQ() {
+	s.mockShard.config.TransferTaskMaxAttemptsBeforeDLQ = dynamicconfig.GetIntPropertyFn(2)
+	taskInfo := &persistenceblobs.TransferTaskInfo{TaskId: 123}
+	queueTaskBase := newQueueTaskBase(
+		s.mockShard,
+		taskInfo,
+		s.scope,
+		s.logger,
+		func(task queueTaskInfo) (bool, error) {
+			return true, nil
+		},
+		s.mockQueueTaskExecutor,
+		s.timeSource,
+		s.maxRetryCount,
+	)
+
+	err := errors.New("some random error")
+	s.Equal(err, queueTaskBase.HandleErr(err))
+
+	// transient errors are retried without counting toward the DLQ attempts
+	for _, transientErr := range []error{
+		serviceerror.NewResourceExhausted("throttled"),
+		serviceerror.NewUnavailable("namespace is in handover"),
+		serviceerror.NewDeadlineExceeded("deadline exceeded"),
+		&persistence.TimeoutError{Msg: "timeout"},
+		&persistence.ShardOwnershipLostError{ShardID: 1},
+		ErrConflict,
+		errCompletionCallbackNotDelivered,
+	} {
+		s.Equal(transientErr, queueTaskBase.HandleErr(transientErr))
+	}
+
+	s.mockShard.resource.ExecutionMgr.On("PutTransferTaskToDLQ", &persistence.PutTransferTaskToDLQRequest{
+		TaskInfo: taskInfo,
+	}).Return(nil).Once()
+	s.NoError(queueTaskBase.HandleErr(err))
+}
+
 func (s *queueTaskSuite) TestTaskState() {
 	queueTaskBase := s.newTestQueueTaskBase(func(task queueTaskInfo) (bool, error) {
 		return true, nil
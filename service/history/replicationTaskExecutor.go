@@ -38,6 +38,7 @@ import (
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/xdc"
 )
 
@@ -140,7 +141,7 @@ func (e *replicationTaskExecutorImpl) handleActivityTask(
 		LastWorkerIdentity: attr.LastWorkerIdentity,
 		VersionHistory:     attr.GetVersionHistory(),
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), replicationTimeout)
+	ctx, cancel := context.WithTimeout(newReplicationContext(), replicationTimeout)
 	defer cancel()
 	err = e.historyEngine.SyncActivity(ctx, request)
 	// Handle resend error
@@ -214,17 +215,18 @@ func (e *replicationTaskExecutorImpl) handleHistoryReplicationTask(
 			WorkflowId: attr.WorkflowId,
 			RunId:      attr.RunId,
 		},
-		FirstEventId:      attr.FirstEventId,
-		NextEventId:       attr.NextEventId,
-		Version:           attr.Version,
-		ReplicationInfo:   attr.ReplicationInfo,
-		History:           attr.History,
-		NewRunHistory:     attr.NewRunHistory,
-		ForceBufferEvents: false,
-		ResetWorkflow:     attr.ResetWorkflow,
-		NewRunNDC:         attr.NewRunNDC,
+		FirstEventId:           attr.FirstEventId,
+		NextEventId:            attr.NextEventId,
+		Version:                attr.Version,
+		ReplicationInfo:        attr.ReplicationInfo,
+		History:                attr.History,
+		NewRunHistory:          attr.NewRunHistory,
+		ForceBufferEvents:      false,
+		ResetWorkflow:          attr.ResetWorkflow,
+		NewRunNDC:              attr.NewRunNDC,
+		CompletionCallbackUrls: attr.CompletionCallbackUrls,
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), replicationTimeout)
+	ctx, cancel := context.WithTimeout(newReplicationContext(), replicationTimeout)
 	defer cancel()
 
 	err = e.historyEngine.ReplicateEvents(ctx, request)
@@ -274,9 +276,10 @@ func (e *replicationTaskExecutorImpl) handleHistoryReplicationTaskV2(
 		VersionHistoryItems: attr.VersionHistoryItems,
 		Events:              attr.Events,
 		// new run events does not need version history since there is no prior events
-		NewRunEvents: attr.NewRunEvents,
+		NewRunEvents:           attr.NewRunEvents,
+		CompletionCallbackUrls: attr.CompletionCallbackUrls,
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), replicationTimeout)
+	ctx, cancel := context.WithTimeout(newReplicationContext(), replicationTimeout)
 	defer cancel()
 
 	err = e.historyEngine.ReplicateEventsV2(ctx, request)
@@ -346,3 +349,9 @@ func (e *replicationTaskExecutorImpl) convertRetryTaskV2Error(
 	retError, ok := err.(*serviceerror.RetryTaskV2)
 	return retError, ok
 }
+
+// newReplicationContext returns the context of the persistence calls made to apply replication tasks,
+// the replication tasks are applied in the background
+func newReplicationContext() context.Context {
+	return persistence.WithCallerType(context.Background(), persistence.CallerTypeBackground)
+}
@@ -27,13 +27,13 @@
 package history
 
 import (
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	"github.com/temporalio/temporal/client"
-	"github.com/temporalio/temporal/client/admin"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
 	"github.com/temporalio/temporal/common/cluster"
@@ -56,7 +56,7 @@ type (
 		sourceCluster  string
 		config         *Config
 		logger         log.Logger
-		remotePeer     admin.Client
+		clientBean     client.Bean
 		requestChan    chan *request
 		done           chan struct{}
 	}
@@ -73,13 +73,20 @@ type (
 		common.Daemon
 
 		GetFetchers() []ReplicationTaskFetcher
+		GetOrCreateFetcher(sourceCluster string) ReplicationTaskFetcher
 	}
 
 	// ReplicationTaskFetchersImpl is a group of fetchers, one per source DC.
 	ReplicationTaskFetchersImpl struct {
-		status   int32
-		logger   log.Logger
-		fetchers []ReplicationTaskFetcher
+		status          int32
+		logger          log.Logger
+		config          *Config
+		consumerConfig  *serviceConfig.ReplicationConsumerConfig
+		clusterMetadata cluster.Metadata
+		clientBean      client.Bean
+
+		sync.RWMutex
+		fetchers map[string]ReplicationTaskFetcher
 	}
 )
 
@@ -92,7 +99,7 @@ func NewReplicationTaskFetchers(
 	clientBean client.Bean,
 ) *ReplicationTaskFetchersImpl {
 
-	var fetchers []ReplicationTaskFetcher
+	fetchers := make(map[string]ReplicationTaskFetcher)
 	if consumerConfig.Type == serviceConfig.ReplicationConsumerTypeRPC {
 		for clusterName, info := range clusterMetadata.GetAllClusterInfo() {
 			if !info.Enabled {
@@ -101,23 +108,26 @@ func NewReplicationTaskFetchers(
 
 			currentCluster := clusterMetadata.GetCurrentClusterName()
 			if clusterName != currentCluster {
-				remoteFrontendClient := clientBean.GetRemoteAdminClient(clusterName)
 				fetcher := newReplicationTaskFetcher(
 					logger,
 					clusterName,
 					currentCluster,
 					config,
-					remoteFrontendClient,
+					clientBean,
 				)
-				fetchers = append(fetchers, fetcher)
+				fetchers[clusterName] = fetcher
 			}
 		}
 	}
 
 	return &ReplicationTaskFetchersImpl{
-		fetchers: fetchers,
-		status:   common.DaemonStatusInitialized,
-		logger:   logger,
+		fetchers:        fetchers,
+		status:          common.DaemonStatusInitialized,
+		logger:          logger,
+		config:          config,
+		consumerConfig:  consumerConfig,
+		clusterMetadata: clusterMetadata,
+		clientBean:      clientBean,
 	}
 }
 
@@ -127,9 +137,12 @@ func (f *ReplicationTaskFetchersImpl) Start() {
 		return
 	}
 
+	f.RLock()
 	for _, fetcher := range f.fetchers {
 		fetcher.Start()
 	}
+	f.RUnlock()
+	f.clusterMetadata.RegisterMetadataChangeCallback(f, f.clusterMetadataChangeCallback)
 	f.logger.Info("Replication task fetchers started.")
 }
 
@@ -139,15 +152,84 @@ func (f *ReplicationTaskFetchersImpl) Stop() {
 		return
 	}
 
+	f.clusterMetadata.UnRegisterMetadataChangeCallback(f)
+	f.RLock()
 	for _, fetcher := range f.fetchers {
 		fetcher.Stop()
 	}
+	f.RUnlock()
 	f.logger.Info("Replication task fetchers stopped.")
 }
 
 // GetFetchers returns all the fetchers
 func (f *ReplicationTaskFetchersImpl) GetFetchers() []ReplicationTaskFetcher {
-	return f.fetchers
+	f.RLock()
+	defer f.RUnlock()
+
+	fetchers := make([]ReplicationTaskFetcher, 0, len(f.fetchers))
+	for _, fetcher := range f.fetchers {
+		fetchers = append(fetchers, fetcher)
+	}
+	return fetchers
+}
+
+// GetOrCreateFetcher returns the fetcher of the source cluster, the fetcher is created
+// if the source cluster was added to the replication group after the fetchers were created
+func (f *ReplicationTaskFetchersImpl) GetOrCreateFetcher(sourceCluster string) ReplicationTaskFetcher {
+	f.RLock()
+	fetcher, ok := f.fetchers[sourceCluster]
+	f.RUnlock()
+	if ok {
+		return fetcher
+	}
+
+	f.Lock()
+	defer f.Unlock()
+
+	if fetcher, ok := f.fetchers[sourceCluster]; ok {
+		return fetcher
+	}
+	fetcher = newReplicationTaskFetcher(
+		f.logger,
+		sourceCluster,
+		f.clusterMetadata.GetCurrentClusterName(),
+		f.config,
+		f.clientBean,
+	)
+	if atomic.LoadInt32(&f.status) == common.DaemonStatusStarted {
+		fetcher.Start()
+	}
+	f.fetchers[sourceCluster] = fetcher
+	return fetcher
+}
+
+// clusterMetadataChangeCallback creates the fetchers of added clusters and stops the fetchers of removed clusters
+func (f *ReplicationTaskFetchersImpl) clusterMetadataChangeCallback(
+	oldClusterMetadata map[string]*serviceConfig.ClusterInformation,
+	newClusterMetadata map[string]*serviceConfig.ClusterInformation,
+) {
+	if f.consumerConfig.Type != serviceConfig.ReplicationConsumerTypeRPC {
+		return
+	}
+
+	currentCluster := f.clusterMetadata.GetCurrentClusterName()
+	for clusterName, newInfo := range newClusterMetadata {
+		if clusterName == currentCluster {
+			continue
+		}
+
+		if newInfo != nil && newInfo.Enabled {
+			f.GetOrCreateFetcher(clusterName)
+			continue
+		}
+
+		f.Lock()
+		if fetcher, ok := f.fetchers[clusterName]; ok {
+			fetcher.Stop()
+			delete(f.fetchers, clusterName)
+		}
+		f.Unlock()
+	}
 }
 
 // newReplicationTaskFetcher creates a new fetcher.
@@ -156,14 +238,14 @@ func newReplicationTaskFetcher(
 	sourceCluster string,
 	currentCluster string,
 	config *Config,
-	sourceFrontend admin.Client,
+	clientBean client.Bean,
 ) *ReplicationTaskFetcherImpl {
 
 	return &ReplicationTaskFetcherImpl{
 		status:         common.DaemonStatusInitialized,
 		config:         config,
 		logger:         logger.WithTags(tag.ClusterName(sourceCluster)),
-		remotePeer:     sourceFrontend,
+		clientBean:     clientBean,
 		currentCluster: currentCluster,
 		sourceCluster:  sourceCluster,
 		requestChan:    make(chan *request, requestChanBufferSize),
@@ -277,7 +359,9 @@ func (f *ReplicationTaskFetcherImpl) getMessages(
 		Tokens:      tokens,
 		ClusterName: f.currentCluster,
 	}
-	response, err := f.remotePeer.GetReplicationMessages(ctx, request)
+	// the remote client is resolved on every call as the address of the source cluster can change at runtime
+	remotePeer := f.clientBean.GetRemoteAdminClient(f.sourceCluster)
+	response, err := remotePeer.GetReplicationMessages(ctx, request)
 	if err != nil {
 		return nil, err
 	}
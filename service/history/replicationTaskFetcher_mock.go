@@ -168,3 +168,17 @@ func (mr *MockReplicationTaskFetchersMockRecorder) GetFetchers() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFetchers", reflect.TypeOf((*MockReplicationTaskFetchers)(nil).GetFetchers))
 }
+
+// GetOrCreateFetcher mocks base method.
+func (m *MockReplicationTaskFetchers) GetOrCreateFetcher(sourceCluster string) ReplicationTaskFetcher {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrCreateFetcher", sourceCluster)
+	ret0, _ := ret[0].(ReplicationTaskFetcher)
+	return ret0
+}
+
+// GetOrCreateFetcher indicates an expected call of GetOrCreateFetcher.
+func (mr *MockReplicationTaskFetchersMockRecorder) GetOrCreateFetcher(sourceCluster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrCreateFetcher", reflect.TypeOf((*MockReplicationTaskFetchers)(nil).GetOrCreateFetcher), sourceCluster)
+}
@@ -79,7 +79,7 @@ func (s *replicationTaskFetcherSuite) SetupTest() {
 		"standby",
 		"active",
 		s.config,
-		s.frontendClient,
+		s.mockResource.ClientBean,
 	)
 }
 
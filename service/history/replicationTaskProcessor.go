@@ -54,6 +54,8 @@ const (
 	taskErrorRetryBackoffCoefficient = 1.2
 	dlqErrorRetryWait                = time.Second
 	emptyMessageID                   = -1
+	dlqSizeReadBatchSize             = 1000
+	dlqSizeMaxCount                  = 10000
 )
 
 var (
@@ -82,6 +84,11 @@ type (
 		lastProcessedMessageID int64
 		lastRetrievedMessageID int64
 
+		// replication status, accessed atomically
+		lastReplicatedTaskID int64
+		lastReplicatedTime   int64
+		dlqSize              int64
+
 		requestChan   chan<- *request
 		syncShardChan chan *replicationgenpb.SyncShardStatus
 		done          chan struct{}
@@ -90,6 +97,8 @@ type (
 	// ReplicationTaskProcessor is responsible for processing replication tasks for a shard.
 	ReplicationTaskProcessor interface {
 		common.Daemon
+		getSourceCluster() string
+		getReplicationStatus() *replicationgenpb.ClusterReplicationStatus
 	}
 
 	request struct {
@@ -136,6 +145,7 @@ func NewReplicationTaskProcessor(
 		done:                    make(chan struct{}),
 		lastProcessedMessageID:  emptyMessageID,
 		lastRetrievedMessageID:  emptyMessageID,
+		lastReplicatedTaskID:    emptyMessageID,
 	}
 }
 
@@ -217,6 +227,9 @@ func (p *ReplicationTaskProcessorImpl) cleanupReplicationTaskLoop() {
 				p.logger.Error("Failed to clean up replication messages.", tag.Error(err))
 				p.metricsClient.Scope(metrics.ReplicationTaskCleanupScope).IncCounter(metrics.ReplicationTaskCleanupFailure)
 			}
+			if err := p.updateDLQSize(); err != nil {
+				p.logger.Warn("Failed to read replication DLQ size.", tag.Error(err))
+			}
 			timer.Reset(backoff.JitDuration(
 				p.config.ShardSyncMinInterval(),
 				p.config.ShardSyncTimerJitterCoefficient(),
@@ -258,6 +271,55 @@ func (p *ReplicationTaskProcessorImpl) cleanupAckedReplicationTasks() error {
 	)
 }
 
+// updateDLQSize counts the replication tasks from the source cluster in the DLQ, up to dlqSizeMaxCount
+func (p *ReplicationTaskProcessorImpl) updateDLQSize() error {
+	ackLevel := p.shard.GetReplicatorDLQAckLevel(p.sourceCluster)
+	size := 0
+	var pageToken []byte
+	for {
+		resp, err := p.shard.GetExecutionManager().GetReplicationTasksFromDLQ(persistence.NewGetReplicationTasksFromDLQRequest(
+			p.sourceCluster,
+			ackLevel,
+			math.MaxInt64,
+			dlqSizeReadBatchSize,
+			pageToken,
+		))
+		if err != nil {
+			return err
+		}
+		size += len(resp.Tasks)
+		pageToken = resp.NextPageToken
+		if len(pageToken) == 0 || size >= dlqSizeMaxCount {
+			break
+		}
+	}
+
+	atomic.StoreInt64(&p.dlqSize, int64(size))
+	p.metricsClient.Scope(
+		metrics.ReplicationDLQStatsScope,
+		metrics.TargetClusterTag(p.sourceCluster),
+		metrics.InstanceTag(strconv.Itoa(p.shard.GetShardID())),
+	).UpdateGauge(
+		metrics.ReplicationDLQSizeGauge,
+		float64(size),
+	)
+	return nil
+}
+
+func (p *ReplicationTaskProcessorImpl) getSourceCluster() string {
+	return p.sourceCluster
+}
+
+// getReplicationStatus returns the replication status of the source cluster to this shard
+func (p *ReplicationTaskProcessorImpl) getReplicationStatus() *replicationgenpb.ClusterReplicationStatus {
+	return &replicationgenpb.ClusterReplicationStatus{
+		LastReplicatedTaskId: atomic.LoadInt64(&p.lastReplicatedTaskID),
+		LastReplicatedTime:   atomic.LoadInt64(&p.lastReplicatedTime),
+		DlqAckLevel:          p.shard.GetReplicatorDLQAckLevel(p.sourceCluster),
+		DlqSize:              atomic.LoadInt64(&p.dlqSize),
+	}
+}
+
 func (p *ReplicationTaskProcessorImpl) sendFetchMessageRequest() <-chan *replicationgenpb.ReplicationMessages {
 	respChan := make(chan *replicationgenpb.ReplicationMessages, 1)
 	// TODO: when we support prefetching, LastRetrievedMessageId can be different than LastProcessedMessageId
@@ -294,6 +356,8 @@ func (p *ReplicationTaskProcessorImpl) processResponse(response *replicationgenp
 
 	p.lastProcessedMessageID = response.GetLastRetrievedMessageId()
 	p.lastRetrievedMessageID = response.GetLastRetrievedMessageId()
+	atomic.StoreInt64(&p.lastReplicatedTaskID, p.lastProcessedMessageID)
+	atomic.StoreInt64(&p.lastReplicatedTime, time.Now().UnixNano())
 	scope := p.metricsClient.Scope(metrics.ReplicationTaskFetcherScope, metrics.TargetClusterTag(p.sourceCluster))
 	scope.UpdateGauge(metrics.LastRetrievedMessageID, float64(p.lastRetrievedMessageID))
 	p.noTaskRetrier.Reset()
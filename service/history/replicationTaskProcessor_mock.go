@@ -30,6 +30,7 @@ package history
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	replication "github.com/temporalio/temporal/.gen/proto/replication"
 	reflect "reflect"
 )
 
@@ -79,3 +80,31 @@ func (mr *MockReplicationTaskProcessorMockRecorder) Stop() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockReplicationTaskProcessor)(nil).Stop))
 }
+
+// getSourceCluster mocks base method.
+func (m *MockReplicationTaskProcessor) getSourceCluster() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getSourceCluster")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// getSourceCluster indicates an expected call of getSourceCluster.
+func (mr *MockReplicationTaskProcessorMockRecorder) getSourceCluster() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getSourceCluster", reflect.TypeOf((*MockReplicationTaskProcessor)(nil).getSourceCluster))
+}
+
+// getReplicationStatus mocks base method.
+func (m *MockReplicationTaskProcessor) getReplicationStatus() *replication.ClusterReplicationStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getReplicationStatus")
+	ret0, _ := ret[0].(*replication.ClusterReplicationStatus)
+	return ret0
+}
+
+// getReplicationStatus indicates an expected call of getReplicationStatus.
+func (mr *MockReplicationTaskProcessorMockRecorder) getReplicationStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getReplicationStatus", reflect.TypeOf((*MockReplicationTaskProcessor)(nil).getReplicationStatus))
+}
@@ -27,6 +27,8 @@ package history
 import (
 	"context"
 	"errors"
+	"strconv"
+	"sync"
 	"time"
 
 	commonpb "go.temporal.io/temporal-proto/common"
@@ -39,6 +41,7 @@ import (
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
+	"github.com/temporalio/temporal/common/blobstore"
 	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/convert"
 	"github.com/temporalio/temporal/common/log"
@@ -68,6 +71,12 @@ type (
 		queueAckMgr
 
 		lastShardSyncTimestamp time.Time
+
+		statusLock sync.Mutex
+		// remoteAckTime is when each remote cluster last reported its replication level
+		remoteAckTime map[string]time.Time
+		// remoteCaughtUpTime is when each remote cluster last had no pending replication task
+		remoteCaughtUpTime map[string]time.Time
 	}
 )
 
@@ -128,6 +137,8 @@ func newReplicatorQueueProcessor(
 		logger:                logger,
 		retryPolicy:           retryPolicy,
 		fetchTasksBatchSize:   config.ReplicatorProcessorFetchTasksBatchSize(),
+		remoteAckTime:         make(map[string]time.Time),
+		remoteCaughtUpTime:    make(map[string]time.Time),
 	}
 
 	queueAckMgr := newQueueAckMgr(shard, options, processor, shard.GetReplicatorAckLevel(), logger)
@@ -161,6 +172,7 @@ func (p *replicatorQueueProcessorImpl) complete(
 }
 
 func (p *replicatorQueueProcessorImpl) process(
+	ctx context.Context,
 	taskInfo *taskInfo,
 ) (int, error) {
 
@@ -173,13 +185,13 @@ func (p *replicatorQueueProcessorImpl) process(
 
 	switch task.TaskType {
 	case commongenpb.TaskType_ReplicationSyncActivity:
-		err := p.processSyncActivityTask(task.ReplicationTaskInfo)
+		err := p.processSyncActivityTask(ctx, task.ReplicationTaskInfo)
 		if err == nil {
 			err = p.executionMgr.CompleteReplicationTask(&persistence.CompleteReplicationTaskRequest{TaskID: task.GetTaskId()})
 		}
 		return metrics.ReplicatorTaskSyncActivityScope, err
 	case commongenpb.TaskType_ReplicationHistory:
-		err := p.processHistoryReplicationTask(task.ReplicationTaskInfo)
+		err := p.processHistoryReplicationTask(ctx, task.ReplicationTaskInfo)
 		if _, ok := err.(*serviceerror.NotFound); ok {
 			err = errHistoryNotFoundTask
 		}
@@ -198,10 +210,11 @@ func (p *replicatorQueueProcessorImpl) queueShutdown() error {
 }
 
 func (p *replicatorQueueProcessorImpl) processSyncActivityTask(
+	ctx context.Context,
 	task *persistenceblobs.ReplicationTaskInfo,
 ) error {
 
-	replicationTask, err := p.generateSyncActivityTask(context.Background(), task)
+	replicationTask, err := p.generateSyncActivityTask(ctx, task)
 	if err != nil || replicationTask == nil {
 		return err
 	}
@@ -210,9 +223,10 @@ func (p *replicatorQueueProcessorImpl) processSyncActivityTask(
 }
 
 func (p *replicatorQueueProcessorImpl) processHistoryReplicationTask(
+	ctx context.Context,
 	task *persistenceblobs.ReplicationTaskInfo,
 ) error {
-	replicationTask, err := p.toReplicationTask(context.Background(), &persistence.ReplicationTaskInfoWrapper{task})
+	replicationTask, err := p.toReplicationTask(ctx, &persistence.ReplicationTaskInfoWrapper{task})
 	if err != nil || replicationTask == nil {
 		return err
 	}
@@ -516,6 +530,8 @@ func (p *replicatorQueueProcessorImpl) getTasks(
 	); err != nil {
 		p.logger.Error("error updating replication level for shard", tag.Error(err), tag.OperationFailed)
 	}
+	p.updateReplicationStatus(pollingCluster, lastReadTaskID, len(taskInfoList) == 0 && !hasMore)
+	p.updateNamespaceHandoverLevels(pollingCluster, lastReadTaskID)
 
 	return &replicationgenpb.ReplicationMessages{
 		ReplicationTasks:       replicationTasks,
@@ -524,6 +540,97 @@ func (p *replicatorQueueProcessorImpl) getTasks(
 	}, nil
 }
 
+func (p *replicatorQueueProcessorImpl) updateReplicationStatus(
+	pollingCluster string,
+	ackLevel int64,
+	caughtUp bool,
+) {
+
+	now := p.shard.GetTimeSource().Now()
+	p.statusLock.Lock()
+	p.remoteAckTime[pollingCluster] = now
+	caughtUpTime, ok := p.remoteCaughtUpTime[pollingCluster]
+	if caughtUp || !ok {
+		caughtUpTime = now
+		p.remoteCaughtUpTime[pollingCluster] = now
+	}
+	p.statusLock.Unlock()
+
+	scope := p.metricsClient.Scope(
+		metrics.ReplicatorQueueProcessorScope,
+		metrics.TargetClusterTag(pollingCluster),
+		metrics.InstanceTag(strconv.Itoa(p.shard.GetShardID())),
+	)
+	scope.UpdateGauge(metrics.ReplicationAckLevelGauge, float64(ackLevel))
+	scope.UpdateGauge(metrics.ReplicationAckLevelLagGauge, float64(common.MaxInt64(0, p.shard.GetTransferMaxReadLevel()-ackLevel)))
+	scope.UpdateGauge(metrics.ReplicationEstimatedLagGauge, now.Sub(caughtUpTime).Seconds())
+}
+
+// getReplicationStatus returns the replication status of this shard to the remote cluster
+func (p *replicatorQueueProcessorImpl) getReplicationStatus(
+	remoteCluster string,
+) *replicationgenpb.ClusterReplicationStatus {
+
+	status := &replicationgenpb.ClusterReplicationStatus{
+		RemoteAckLevel: p.shard.GetClusterReplicationLevel(remoteCluster),
+	}
+
+	p.statusLock.Lock()
+	defer p.statusLock.Unlock()
+	if ackTime, ok := p.remoteAckTime[remoteCluster]; ok {
+		status.RemoteAckTime = ackTime.UnixNano()
+		status.EstimatedLagSeconds = int64(p.shard.GetTimeSource().Now().Sub(p.remoteCaughtUpTime[remoteCluster]).Seconds())
+	}
+	return status
+}
+
+// updateNamespaceHandoverLevels records, for every namespace handing over to the polling cluster, the max
+// replication task ID of this shard when the handover is first observed, and whether the polling cluster's
+// ack level has passed that task ID. New tasks of the namespace are rejected once the handover is observed.
+func (p *replicatorQueueProcessorImpl) updateNamespaceHandoverLevels(
+	pollingCluster string,
+	ackLevel int64,
+) {
+
+	namespaces := p.shard.GetNamespaceCache().GetAllNamespace()
+	levels := p.shard.GetNamespaceHandoverLevels()
+	changed := false
+	for namespaceID, level := range levels {
+		if entry, ok := namespaces[namespaceID]; !ok ||
+			entry.GetHandover().GetHandoverVersion() != level.GetHandoverVersion() {
+			delete(levels, namespaceID)
+			changed = true
+		}
+	}
+	for namespaceID, entry := range namespaces {
+		handover := entry.GetHandover()
+		if handover == nil || !entry.IsNamespaceActive() || handover.GetTargetClusterName() != pollingCluster {
+			continue
+		}
+
+		level, ok := levels[namespaceID]
+		if !ok {
+			level = &persistenceblobs.NamespaceHandoverLevel{
+				HandoverVersion:      handover.GetHandoverVersion(),
+				MaxReplicationTaskId: p.shard.GetTransferMaxReadLevel(),
+			}
+			levels[namespaceID] = level
+			changed = true
+		}
+		if !level.Replicated && ackLevel >= level.MaxReplicationTaskId {
+			level.Replicated = true
+			changed = true
+		}
+	}
+
+	if !changed {
+		return
+	}
+	if err := p.shard.UpdateNamespaceHandoverLevels(levels); err != nil {
+		p.logger.Error("error updating namespace handover levels for shard", tag.Error(err), tag.OperationFailed)
+	}
+}
+
 func (p *replicatorQueueProcessorImpl) getTask(
 	ctx context.Context,
 	taskInfo *replicationgenpb.ReplicationTaskInfo,
@@ -700,6 +807,11 @@ func (p *replicatorQueueProcessorImpl) generateHistoryReplicationTask(
 					}
 					replicationTask.GetHistoryTaskAttributes().NewRunNDC = isNDCWorkflow
 				}
+				if replicationTask != nil && (task.GetFirstEventId() == common.FirstEventID || newRunID != "") {
+					replicationTask.GetHistoryTaskAttributes().CompletionCallbackUrls = getCompletionCallbackURLs(
+						mutableState.GetExecutionInfo().CompletionCallbacks,
+					)
+				}
 
 				return replicationTask, err
 			}
@@ -720,6 +832,9 @@ func (p *replicatorQueueProcessorImpl) generateHistoryReplicationTask(
 			}
 
 			eventsBlob, err := p.getEventsBlob(
+				ctx,
+				namespaceID,
+				runID,
 				task.BranchToken,
 				task.GetFirstEventId(),
 				task.GetNextEventId(),
@@ -730,8 +845,17 @@ func (p *replicatorQueueProcessorImpl) generateHistoryReplicationTask(
 
 			var newRunEventsBlob *commonpb.DataBlob
 			if len(task.NewRunBranchToken) != 0 {
+				// the new run is created by the last event of the batch, same as the standby side
+				events, err := deserializeBlob(p.shard.GetService().GetPayloadSerializer(), eventsBlob)
+				if err != nil {
+					return nil, err
+				}
+				newRunID := events[len(events)-1].GetWorkflowExecutionContinuedAsNewEventAttributes().GetNewExecutionRunId()
 				// only get the first batch
 				newRunEventsBlob, err = p.getEventsBlob(
+					ctx,
+					namespaceID,
+					newRunID,
 					task.NewRunBranchToken,
 					common.FirstEventID,
 					common.FirstEventID+1,
@@ -755,12 +879,21 @@ func (p *replicatorQueueProcessorImpl) generateHistoryReplicationTask(
 					},
 				},
 			}
+			if task.GetFirstEventId() == common.FirstEventID || newRunEventsBlob != nil {
+				// the callbacks of a continued as new run are carried over to the new run
+				replicationTask.GetHistoryTaskV2Attributes().CompletionCallbackUrls = getCompletionCallbackURLs(
+					mutableState.GetExecutionInfo().CompletionCallbacks,
+				)
+			}
 			return replicationTask, nil
 		},
 	)
 }
 
 func (p *replicatorQueueProcessorImpl) getEventsBlob(
+	ctx context.Context,
+	namespaceID string,
+	runID string,
 	branchToken []byte,
 	firstEventID int64,
 	nextEventID int64,
@@ -795,7 +928,23 @@ func (p *replicatorQueueProcessorImpl) getEventsBlob(
 		return nil, serviceerror.NewInternal("replicatorQueueProcessor encounter more than 1 NDC raw event batch")
 	}
 
-	return eventBatchBlobs[0].ToProto(), nil
+	namespaceEntry, err := p.shard.GetNamespaceCache().GetNamespaceByID(namespaceID)
+	if err != nil {
+		return nil, err
+	}
+	eventsBlob, err := blobstore.RehydrateEventBlob(
+		ctx,
+		p.shard.GetService().GetPayloadOffloader(),
+		p.shard.GetService().GetPayloadSerializer(),
+		namespaceEntry.GetConfig().GetHistoryArchivalURI(),
+		namespaceID,
+		runID,
+		eventBatchBlobs[0],
+	)
+	if err != nil {
+		return nil, err
+	}
+	return eventsBlob.ToProto(), nil
 }
 
 func (p *replicatorQueueProcessorImpl) getVersionHistoryItems(
@@ -108,6 +108,20 @@ func (mr *MockReplicatorQueueProcessorMockRecorder) getTasks(arg0 interface{}, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getTasks", reflect.TypeOf((*MockReplicatorQueueProcessor)(nil).getTasks), arg0, arg1, arg2)
 }
 
+// getReplicationStatus mocks base method
+func (m *MockReplicatorQueueProcessor) getReplicationStatus(arg0 string) *replicationgenpb.ClusterReplicationStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getReplicationStatus", arg0)
+	ret0, _ := ret[0].(*replicationgenpb.ClusterReplicationStatus)
+	return ret0
+}
+
+// getReplicationStatus indicates an expected call of getReplicationStatus
+func (mr *MockReplicatorQueueProcessorMockRecorder) getReplicationStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getReplicationStatus", reflect.TypeOf((*MockReplicatorQueueProcessor)(nil).getReplicationStatus), arg0)
+}
+
 // notifyNewTask mocks base method
 func (m *MockReplicatorQueueProcessor) notifyNewTask() {
 	m.ctrl.T.Helper()
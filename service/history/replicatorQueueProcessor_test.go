@@ -25,6 +25,7 @@
 package history
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -160,7 +161,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_WorkflowMissing() {
 	), nil).AnyTimes()
 
 	wrapper := &persistence.ReplicationTaskInfoWrapper{ReplicationTaskInfo: task}
-	_, err := s.replicatorQueueProcessor.process(newTaskInfo(nil, wrapper, s.logger))
+	_, err := s.replicatorQueueProcessor.process(context.Background(), newTaskInfo(nil, wrapper, s.logger))
 	s.Nil(err)
 }
 
@@ -182,14 +183,14 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_WorkflowCompleted() {
 	}
 	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
 
-	context, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecutionForBackground(
+	weContext, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecutionForBackground(
 		namespaceID,
 		commonpb.WorkflowExecution{
 			WorkflowId: workflowID,
 			RunId:      runID,
 		},
 	)
-	context.(*workflowExecutionContextImpl).mutableState = s.mockMutableState
+	weContext.(*workflowExecutionContextImpl).mutableState = s.mockMutableState
 	release(nil)
 	s.mockMutableState.EXPECT().StartTransaction(gomock.Any()).Return(false, nil).Times(1)
 	s.mockMutableState.EXPECT().IsWorkflowExecutionRunning().Return(false).AnyTimes()
@@ -208,7 +209,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_WorkflowCompleted() {
 	), nil).AnyTimes()
 
 	wrapper := &persistence.ReplicationTaskInfoWrapper{ReplicationTaskInfo: task}
-	_, err := s.replicatorQueueProcessor.process(newTaskInfo(nil, wrapper, s.logger))
+	_, err := s.replicatorQueueProcessor.process(context.Background(), newTaskInfo(nil, wrapper, s.logger))
 	s.Nil(err)
 }
 
@@ -230,7 +231,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityCompleted() {
 	}
 	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
 
-	context, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecutionForBackground(
+	weContext, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecutionForBackground(
 		namespaceID,
 		commonpb.WorkflowExecution{
 			WorkflowId: workflowID,
@@ -238,7 +239,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityCompleted() {
 		},
 	)
 
-	context.(*workflowExecutionContextImpl).mutableState = s.mockMutableState
+	weContext.(*workflowExecutionContextImpl).mutableState = s.mockMutableState
 	release(nil)
 	s.mockMutableState.EXPECT().StartTransaction(gomock.Any()).Return(false, nil).Times(1)
 	s.mockMutableState.EXPECT().IsWorkflowExecutionRunning().Return(true).AnyTimes()
@@ -258,7 +259,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityCompleted() {
 	), nil).AnyTimes()
 
 	wrapper := &persistence.ReplicationTaskInfoWrapper{ReplicationTaskInfo: task}
-	_, err := s.replicatorQueueProcessor.process(newTaskInfo(nil, wrapper, s.logger))
+	_, err := s.replicatorQueueProcessor.process(context.Background(), newTaskInfo(nil, wrapper, s.logger))
 	s.Nil(err)
 }
 
@@ -280,7 +281,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRetry() {
 	}
 	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
 
-	context, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecutionForBackground(
+	weContext, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecutionForBackground(
 		namespaceID,
 		commonpb.WorkflowExecution{
 			WorkflowId: workflowID,
@@ -288,7 +289,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRetry() {
 		},
 	)
 
-	context.(*workflowExecutionContextImpl).mutableState = s.mockMutableState
+	weContext.(*workflowExecutionContextImpl).mutableState = s.mockMutableState
 	release(nil)
 
 	activityVersion := int64(333)
@@ -368,7 +369,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRetry() {
 	}).Return(nil).Once()
 
 	wrapper := &persistence.ReplicationTaskInfoWrapper{ReplicationTaskInfo: task}
-	_, err := s.replicatorQueueProcessor.process(newTaskInfo(nil, wrapper, s.logger))
+	_, err := s.replicatorQueueProcessor.process(context.Background(), newTaskInfo(nil, wrapper, s.logger))
 	s.Nil(err)
 }
 
@@ -390,7 +391,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRunning() {
 	}
 	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: taskID}).Return(nil).Once()
 
-	context, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecutionForBackground(
+	weContext, release, _ := s.replicatorQueueProcessor.historyCache.getOrCreateWorkflowExecutionForBackground(
 		namespaceID,
 		commonpb.WorkflowExecution{
 			WorkflowId: workflowID,
@@ -398,7 +399,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRunning() {
 		},
 	)
 
-	context.(*workflowExecutionContextImpl).mutableState = s.mockMutableState
+	weContext.(*workflowExecutionContextImpl).mutableState = s.mockMutableState
 	release(nil)
 
 	activityVersion := int64(333)
@@ -477,7 +478,7 @@ func (s *replicatorQueueProcessorSuite) TestSyncActivity_ActivityRunning() {
 	}).Return(nil).Once()
 
 	wrapper := &persistence.ReplicationTaskInfoWrapper{ReplicationTaskInfo: task}
-	_, err := s.replicatorQueueProcessor.process(newTaskInfo(nil, wrapper, s.logger))
+	_, err := s.replicatorQueueProcessor.process(context.Background(), newTaskInfo(nil, wrapper, s.logger))
 	s.Nil(err)
 }
 
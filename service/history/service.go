@@ -45,6 +45,7 @@ import (
 	"github.com/temporalio/temporal/common/service/config"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 	"github.com/temporalio/temporal/common/task"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 // Config represents configuration for history service
@@ -83,6 +84,13 @@ type Config struct {
 	RangeSizeBits           uint
 	AcquireShardInterval    dynamicconfig.DurationPropertyFn
 	AcquireShardConcurrency dynamicconfig.IntPropertyFn
+	EnableShardSplit        dynamicconfig.BoolPropertyFn
+	ShardSplitPageSize      dynamicconfig.IntPropertyFn
+
+	// Shard ownership settings
+	EnableShardRebalance        dynamicconfig.BoolPropertyFn
+	ShardRebalanceInterval      dynamicconfig.DurationPropertyFn
+	ShardRebalanceLoadThreshold dynamicconfig.FloatPropertyFn
 
 	// the artificial delay added to standby cluster's view of active cluster's time
 	StandbyClusterDelay                  dynamicconfig.DurationPropertyFn
@@ -101,6 +109,7 @@ type Config struct {
 	TimerTaskBatchSize                                dynamicconfig.IntPropertyFn
 	TimerTaskWorkerCount                              dynamicconfig.IntPropertyFn
 	TimerTaskMaxRetryCount                            dynamicconfig.IntPropertyFn
+	TimerTaskMaxAttemptsBeforeDLQ                     dynamicconfig.IntPropertyFn
 	TimerProcessorGetFailureRetryCount                dynamicconfig.IntPropertyFn
 	TimerProcessorCompleteTimerFailureRetryCount      dynamicconfig.IntPropertyFn
 	TimerProcessorUpdateAckInterval                   dynamicconfig.DurationPropertyFn
@@ -122,6 +131,7 @@ type Config struct {
 	TransferTaskBatchSize                                dynamicconfig.IntPropertyFn
 	TransferTaskWorkerCount                              dynamicconfig.IntPropertyFn
 	TransferTaskMaxRetryCount                            dynamicconfig.IntPropertyFn
+	TransferTaskMaxAttemptsBeforeDLQ                     dynamicconfig.IntPropertyFn
 	TransferProcessorCompleteTransferFailureRetryCount   dynamicconfig.IntPropertyFn
 	TransferProcessorFailoverMaxPollRPS                  dynamicconfig.IntPropertyFn
 	TransferProcessorMaxPollRPS                          dynamicconfig.IntPropertyFn
@@ -171,6 +181,8 @@ type Config struct {
 
 	// encoding the history events
 	EventEncodingType dynamicconfig.StringPropertyFnWithNamespaceFilter
+	// encoding the history event batches stored in history nodes, EventEncodingType is used when unset
+	HistoryEventBlobEncoding dynamicconfig.StringPropertyFnWithNamespaceFilter
 	// whether or not using ParentClosePolicy
 	EnableParentClosePolicy dynamicconfig.BoolPropertyFnWithNamespaceFilter
 	// whether or not enable system workers for processing parent close policy task
@@ -193,6 +205,15 @@ type Config struct {
 	HistoryCountLimitError dynamicconfig.IntPropertyFnWithNamespaceFilter
 	HistoryCountLimitWarn  dynamicconfig.IntPropertyFnWithNamespaceFilter
 
+	// Payload offload settings
+	PayloadOffloadSizeThreshold dynamicconfig.IntPropertyFnWithNamespaceFilter
+	PayloadOffloadTimeout       dynamicconfig.DurationPropertyFnWithNamespaceFilter
+
+	// Workflow completion callback settings
+	CompletionCallbackAllowedHosts   dynamicconfig.StringPropertyFnWithNamespaceFilter
+	CompletionCallbackRequestTimeout dynamicconfig.DurationPropertyFn
+	CompletionCallbackMaxAttempts    dynamicconfig.IntPropertyFn
+
 	// ValidSearchAttributes is legal indexed keys that can be used in list APIs
 	ValidSearchAttributes             dynamicconfig.MapPropertyFn
 	SearchAttributesNumberOfKeysLimit dynamicconfig.IntPropertyFnWithNamespaceFilter
@@ -276,6 +297,11 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		RangeSizeBits:                        20, // 20 bits for sequencer, 2^20 sequence number for any range
 		AcquireShardInterval:                 dc.GetDurationProperty(dynamicconfig.AcquireShardInterval, time.Minute),
 		AcquireShardConcurrency:              dc.GetIntProperty(dynamicconfig.AcquireShardConcurrency, 1),
+		EnableShardSplit:                     dc.GetBoolProperty(dynamicconfig.EnableShardSplit, true),
+		ShardSplitPageSize:                   dc.GetIntProperty(dynamicconfig.ShardSplitPageSize, 100),
+		EnableShardRebalance:                 dc.GetBoolProperty(dynamicconfig.EnableShardRebalance, false),
+		ShardRebalanceInterval:               dc.GetDurationProperty(dynamicconfig.ShardRebalanceInterval, 5*time.Minute),
+		ShardRebalanceLoadThreshold:          dc.GetFloat64Property(dynamicconfig.ShardRebalanceLoadThreshold, 0.2),
 		StandbyClusterDelay:                  dc.GetDurationProperty(dynamicconfig.StandbyClusterDelay, 5*time.Minute),
 		StandbyTaskMissingEventsResendDelay:  dc.GetDurationProperty(dynamicconfig.StandbyTaskMissingEventsResendDelay, 15*time.Minute),
 		StandbyTaskMissingEventsDiscardDelay: dc.GetDurationProperty(dynamicconfig.StandbyTaskMissingEventsDiscardDelay, 25*time.Minute),
@@ -290,6 +316,7 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		TimerTaskBatchSize:                                dc.GetIntProperty(dynamicconfig.TimerTaskBatchSize, 100),
 		TimerTaskWorkerCount:                              dc.GetIntProperty(dynamicconfig.TimerTaskWorkerCount, 10),
 		TimerTaskMaxRetryCount:                            dc.GetIntProperty(dynamicconfig.TimerTaskMaxRetryCount, 100),
+		TimerTaskMaxAttemptsBeforeDLQ:                     dc.GetIntProperty(dynamicconfig.TimerTaskMaxAttemptsBeforeDLQ, 0),
 		TimerProcessorGetFailureRetryCount:                dc.GetIntProperty(dynamicconfig.TimerProcessorGetFailureRetryCount, 5),
 		TimerProcessorCompleteTimerFailureRetryCount:      dc.GetIntProperty(dynamicconfig.TimerProcessorCompleteTimerFailureRetryCount, 10),
 		TimerProcessorUpdateAckInterval:                   dc.GetDurationProperty(dynamicconfig.TimerProcessorUpdateAckInterval, 30*time.Second),
@@ -312,6 +339,7 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		TransferProcessorMaxPollRPS:                          dc.GetIntProperty(dynamicconfig.TransferProcessorMaxPollRPS, 20),
 		TransferTaskWorkerCount:                              dc.GetIntProperty(dynamicconfig.TransferTaskWorkerCount, 10),
 		TransferTaskMaxRetryCount:                            dc.GetIntProperty(dynamicconfig.TransferTaskMaxRetryCount, 100),
+		TransferTaskMaxAttemptsBeforeDLQ:                     dc.GetIntProperty(dynamicconfig.TransferTaskMaxAttemptsBeforeDLQ, 0),
 		TransferProcessorCompleteTransferFailureRetryCount:   dc.GetIntProperty(dynamicconfig.TransferProcessorCompleteTransferFailureRetryCount, 10),
 		TransferProcessorMaxPollInterval:                     dc.GetDurationProperty(dynamicconfig.TransferProcessorMaxPollInterval, 1*time.Minute),
 		TransferProcessorMaxPollIntervalJitterCoefficient:    dc.GetFloat64Property(dynamicconfig.TransferProcessorMaxPollIntervalJitterCoefficient, 0.15),
@@ -350,6 +378,7 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		// TODO: Return this value to the client: github.com/temporalio/temporal/issues/294
 		LongPollExpirationInterval:          dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.HistoryLongPollExpirationInterval, time.Second*20),
 		EventEncodingType:                   dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.DefaultEventEncoding, string(common.EncodingTypeProto3)),
+		HistoryEventBlobEncoding:            dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.HistoryEventBlobEncoding, ""),
 		EnableParentClosePolicy:             dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableParentClosePolicy, true),
 		NumParentClosePolicySystemWorkflows: dc.GetIntProperty(dynamicconfig.NumParentClosePolicySystemWorkflows, 10),
 		EnableParentClosePolicyWorker:       dc.GetBoolProperty(dynamicconfig.EnableParentClosePolicyWorker, true),
@@ -365,6 +394,13 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 		HistoryCountLimitError: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistoryCountLimitError, 200*1024),
 		HistoryCountLimitWarn:  dc.GetIntPropertyFilteredByNamespace(dynamicconfig.HistoryCountLimitWarn, 50*1024),
 
+		PayloadOffloadSizeThreshold: dc.GetIntPropertyFilteredByNamespace(dynamicconfig.PayloadOffloadSizeThreshold, 0),
+		PayloadOffloadTimeout:       dc.GetDurationPropertyFilteredByNamespace(dynamicconfig.PayloadOffloadTimeout, 5*time.Second),
+
+		CompletionCallbackAllowedHosts:   dc.GetStringPropertyFnWithNamespaceFilter(dynamicconfig.CompletionCallbackAllowedHosts, ""),
+		CompletionCallbackRequestTimeout: dc.GetDurationProperty(dynamicconfig.CompletionCallbackRequestTimeout, 10*time.Second),
+		CompletionCallbackMaxAttempts:    dc.GetIntProperty(dynamicconfig.CompletionCallbackMaxAttempts, 5),
+
 		ThrottledLogRPS:   dc.GetIntProperty(dynamicconfig.HistoryThrottledLogRPS, 4),
 		EnableStickyQuery: dc.GetBoolPropertyFnWithNamespaceFilter(dynamicconfig.EnableStickyQuery, true),
 
@@ -401,11 +437,6 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, storeType strin
 	return cfg
 }
 
-// GetShardID return the corresponding shard ID for a given workflow ID
-func (config *Config) GetShardID(workflowID string) int {
-	return common.WorkflowIDToHistoryShard(workflowID, config.NumberOfShards)
-}
-
 // Service represents the history service
 type Service struct {
 	resource.Resource
@@ -422,8 +453,13 @@ type Service struct {
 func NewService(
 	params *resource.BootstrapParams,
 ) (resource.Resource, error) {
+	// shards of the target layout are owned by the history service, even before their source shard is split
+	numberOfShards := params.PersistenceConfig.NumHistoryShards
+	if params.PersistenceConfig.TargetNumHistoryShards != 0 {
+		numberOfShards = params.PersistenceConfig.TargetNumHistoryShards
+	}
 	serviceConfig := NewConfig(dynamicconfig.NewCollection(params.DynamicConfig, params.Logger),
-		params.PersistenceConfig.NumHistoryShards,
+		numberOfShards,
 		params.PersistenceConfig.DefaultStoreType(),
 		params.PersistenceConfig.IsAdvancedVisibilityConfigExist())
 
@@ -498,7 +534,8 @@ func (s *Service) Start() {
 	if err != nil {
 		logger.Fatal("creating grpc server options failed", tag.Error(err))
 	}
-	opts = append(opts, grpc.UnaryInterceptor(interceptor))
+	opts = append(opts, grpc.ChainUnaryInterceptor(tracing.NewServerInterceptor(s.GetTracer()), interceptor))
+	opts = append(opts, grpc.ChainStreamInterceptor(streamInterceptor))
 	s.server = grpc.NewServer(opts...)
 	nilCheckHandler := NewNilCheckHandler(s.handler)
 	historyservice.RegisterHistoryServiceServer(s.server, nilCheckHandler)
@@ -563,6 +600,11 @@ func interceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInf
 	return resp, serviceerror.ToStatus(err).Err()
 }
 
+func streamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	return serviceerror.ToStatus(err).Err()
+}
+
 // sleep sleeps for the minimum of desired and available duration
 // returns the remaining available time duration
 func (s *Service) sleep(desired time.Duration, available time.Duration) time.Duration {
@@ -25,6 +25,7 @@
 package history
 
 import (
+	"context"
 	"errors"
 	"strconv"
 	"sync"
@@ -44,7 +45,9 @@ import (
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/resource"
+	"github.com/temporalio/temporal/common/tracing"
 	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
 	"go.temporal.io/temporal-proto/serviceerror"
 )
 
@@ -59,6 +62,7 @@ type (
 		GetClusterMetadata() cluster.Metadata
 		GetConfig() *Config
 		GetEventsCache() eventsCache
+		GetLoadTracker() *shardLoadTracker
 		GetLogger() log.Logger
 		GetThrottledLogger() log.Logger
 		GetMetricsClient() metrics.Client
@@ -92,6 +96,13 @@ type (
 		GetClusterReplicationLevel(cluster string) int64
 		UpdateClusterReplicationLevel(cluster string, lastTaskID int64) error
 
+		GetNamespaceHandoverLevels() map[string]*persistenceblobs.NamespaceHandoverLevel
+		UpdateNamespaceHandoverLevels(levels map[string]*persistenceblobs.NamespaceHandoverLevel) error
+
+		GetNumHistoryShards() int
+		UpdateNumHistoryShards(numHistoryShards int) error
+		UpdateOwnerOverride(hostAddress string, pinned bool) error
+
 		GetTimerAckLevel() time.Time
 		UpdateTimerAckLevel(ackLevel time.Time) error
 		GetTimerClusterAckLevel(cluster string) time.Time
@@ -112,7 +123,7 @@ type (
 		UpdateWorkflowExecution(request *persistence.UpdateWorkflowExecutionRequest) (*persistence.UpdateWorkflowExecutionResponse, error)
 		ConflictResolveWorkflowExecution(request *persistence.ConflictResolveWorkflowExecutionRequest) error
 		ResetWorkflowExecution(request *persistence.ResetWorkflowExecutionRequest) error
-		AppendHistoryV2Events(request *persistence.AppendHistoryNodesRequest, namespaceID string, execution commonpb.WorkflowExecution) (int, error)
+		AppendHistoryV2Events(ctx context.Context, request *persistence.AppendHistoryNodesRequest, namespaceID string, execution commonpb.WorkflowExecution) (int, error)
 	}
 
 	shardContextImpl struct {
@@ -123,6 +134,7 @@ type (
 		rangeID          int64
 		executionManager persistence.ExecutionManager
 		eventsCache      eventsCache
+		loadTracker      *shardLoadTracker
 		closeCallback    func(int, *historyShardsItem)
 		closed           int32
 		config           *Config
@@ -315,6 +327,52 @@ func (s *shardContextImpl) UpdateClusterReplicationLevel(cluster string, lastTas
 	return s.updateShardInfoLocked()
 }
 
+func (s *shardContextImpl) GetNamespaceHandoverLevels() map[string]*persistenceblobs.NamespaceHandoverLevel {
+	s.RLock()
+	defer s.RUnlock()
+
+	return copyNamespaceHandoverLevels(s.shardInfo.NamespaceHandoverLevels)
+}
+
+func (s *shardContextImpl) UpdateNamespaceHandoverLevels(levels map[string]*persistenceblobs.NamespaceHandoverLevel) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.shardInfo.NamespaceHandoverLevels = levels
+	s.shardInfo.StolenSinceRenew = 0
+	return s.updateShardInfoLocked()
+}
+
+// GetNumHistoryShards returns the number of history shards the shard has been split into,
+// zero means the shard has never been split
+func (s *shardContextImpl) GetNumHistoryShards() int {
+	s.RLock()
+	defer s.RUnlock()
+
+	return int(s.shardInfo.NumHistoryShards)
+}
+
+// UpdateNumHistoryShards records the number of history shards the shard has been split into,
+// the range is renewed so that the split is persisted atomically with a fenced write
+func (s *shardContextImpl) UpdateNumHistoryShards(numHistoryShards int) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.shardInfo.NumHistoryShards = int32(numHistoryShards)
+	return s.renewRangeLocked(false)
+}
+
+// UpdateOwnerOverride assigns the shard to the history host instead of the membership ring owner,
+// an empty host address removes the override
+func (s *shardContextImpl) UpdateOwnerOverride(hostAddress string, pinned bool) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.shardInfo.OwnerOverride = hostAddress
+	s.shardInfo.OwnerOverridePinned = pinned && hostAddress != ""
+	return s.renewRangeLocked(false)
+}
+
 func (s *shardContextImpl) GetTimerAckLevel() time.Time {
 	s.RLock()
 	defer s.RUnlock()
@@ -476,7 +534,7 @@ func (s *shardContextImpl) CreateWorkflowExecution(
 		return nil, err
 	}
 
-	s.Lock()
+	s.lockWithSpan(request.ParentSpan, namespaceID, workflowID)
 	defer s.Unlock()
 
 	transferMaxReadLevel := int64(0)
@@ -545,6 +603,15 @@ func (s *shardContextImpl) getDefaultEncoding(namespaceEntry *cache.NamespaceCac
 	return common.EncodingType(s.config.EventEncodingType(namespaceEntry.GetInfo().Name))
 }
 
+func (s *shardContextImpl) getHistoryEventBlobEncoding(namespaceEntry *cache.NamespaceCacheEntry) common.EncodingType {
+	// fall back to the event encoding, so that the existing history.defaultEventEncoding setting keeps applying
+	encoding := s.config.HistoryEventBlobEncoding(namespaceEntry.GetInfo().Name)
+	if encoding == "" {
+		return s.getDefaultEncoding(namespaceEntry)
+	}
+	return common.EncodingType(encoding)
+}
+
 func (s *shardContextImpl) UpdateWorkflowExecution(
 	request *persistence.UpdateWorkflowExecutionRequest,
 ) (*persistence.UpdateWorkflowExecutionResponse, error) {
@@ -559,7 +626,7 @@ func (s *shardContextImpl) UpdateWorkflowExecution(
 	}
 	request.Encoding = s.getDefaultEncoding(namespaceEntry)
 
-	s.Lock()
+	s.lockWithSpan(request.ParentSpan, namespaceID, workflowID)
 	defer s.Unlock()
 
 	transferMaxReadLevel := int64(0)
@@ -645,7 +712,7 @@ func (s *shardContextImpl) ResetWorkflowExecution(request *persistence.ResetWork
 	}
 	request.Encoding = s.getDefaultEncoding(namespaceEntry)
 
-	s.Lock()
+	s.lockWithSpan(request.ParentSpan, namespaceID, workflowID)
 	defer s.Unlock()
 
 	transferMaxReadLevel := int64(0)
@@ -734,7 +801,7 @@ func (s *shardContextImpl) ConflictResolveWorkflowExecution(
 	}
 	request.Encoding = s.getDefaultEncoding(namespaceEntry)
 
-	s.Lock()
+	s.lockWithSpan(request.ParentSpan, namespaceID, workflowID)
 	defer s.Unlock()
 
 	transferMaxReadLevel := int64(0)
@@ -820,8 +887,41 @@ Reset_Loop:
 	return ErrMaxAttemptsExceeded
 }
 
+// offloadPayloads writes the large payloads of the events to the history archival URI of the namespace.
+// Events are offloaded in place, so mutable state referencing these events will also reference
+// the offloaded payloads when persisted afterwards.
+// Namespaces replicated to other clusters are never offloaded, since the blob references
+// would be replicated to clusters which may not be able to read the blob store.
+func (s *shardContextImpl) offloadPayloads(
+	ctx context.Context,
+	namespaceEntry *cache.NamespaceCacheEntry,
+	execution commonpb.WorkflowExecution,
+	events []*eventpb.HistoryEvent,
+) error {
+
+	namespace := namespaceEntry.GetInfo().Name
+	sizeThreshold := s.config.PayloadOffloadSizeThreshold(namespace)
+	storeURI := namespaceEntry.GetConfig().GetHistoryArchivalURI()
+	if sizeThreshold <= 0 || len(storeURI) == 0 ||
+		namespaceEntry.GetReplicationPolicy() == cache.ReplicationPolicyMultiCluster {
+		return nil
+	}
+
+	// the caller holds the workflow lock, do not wait on the blob store for longer than the timeout
+	ctx, cancel := context.WithTimeout(ctx, s.config.PayloadOffloadTimeout(namespace))
+	defer cancel()
+	return s.GetPayloadOffloader().Offload(
+		ctx,
+		storeURI,
+		namespaceEntry.GetInfo().Id,
+		execution.GetRunId(),
+		sizeThreshold,
+		events,
+	)
+}
+
 func (s *shardContextImpl) AppendHistoryV2Events(
-	request *persistence.AppendHistoryNodesRequest, namespaceID string, execution commonpb.WorkflowExecution) (int, error) {
+	ctx context.Context, request *persistence.AppendHistoryNodesRequest, namespaceID string, execution commonpb.WorkflowExecution) (int, error) {
 
 	namespaceEntry, err := s.GetNamespaceCache().GetNamespaceByID(namespaceID)
 	if err != nil {
@@ -835,9 +935,14 @@ func (s *shardContextImpl) AppendHistoryV2Events(
 		return 0, err
 	}
 
-	request.Encoding = s.getDefaultEncoding(namespaceEntry)
+	request.Encoding = s.getHistoryEventBlobEncoding(namespaceEntry)
 	request.ShardID = convert.IntPtr(s.shardID)
 	request.TransactionID = transactionID
+	request.ParentSpan = tracing.SpanFromContext(ctx)
+
+	if err := s.offloadPayloads(ctx, namespaceEntry, execution, request.Events); err != nil {
+		return 0, err
+	}
 
 	size := 0
 	defer func() {
@@ -874,6 +979,10 @@ func (s *shardContextImpl) GetEventsCache() eventsCache {
 	return s.eventsCache
 }
 
+func (s *shardContextImpl) GetLoadTracker() *shardLoadTracker {
+	return s.loadTracker
+}
+
 func (s *shardContextImpl) GetLogger() log.Logger {
 	return s.logger
 }
@@ -1062,6 +1171,25 @@ func (s *shardContextImpl) emitShardInfoMetricsLogsLocked() {
 	s.GetMetricsClient().RecordTimer(metrics.ShardInfoScope, metrics.ShardInfoTimerFailoverInProgressTimer, time.Duration(timerFailoverInProgress))
 }
 
+// lockWithSpan acquires the shard lock, recording the time spent waiting for it
+// under the span of the operation which needs the lock
+func (s *shardContextImpl) lockWithSpan(
+	parent tracing.Span,
+	namespaceID string,
+	workflowID string,
+) {
+	span := tracing.StartChildSpan(
+		s.GetTracer(),
+		parent,
+		"shard.lock",
+		tracing.ShardID(s.shardID),
+		tracing.NamespaceID(namespaceID),
+		tracing.WorkflowID(workflowID),
+	)
+	s.Lock()
+	span.End(nil)
+}
+
 func (s *shardContextImpl) allocateTaskIDsLocked(
 	namespaceEntry *cache.NamespaceCacheEntry,
 	workflowID string,
@@ -1260,6 +1388,7 @@ func acquireShard(
 		shardItem:                      shardItem,
 		shardID:                        shardItem.shardID,
 		executionManager:               executionMgr,
+		loadTracker:                    shardItem.loadTracker,
 		shardInfo:                      updatedShardInfo,
 		closeCallback:                  closeCallback,
 		config:                         shardItem.config,
@@ -1300,6 +1429,10 @@ func copyShardInfo(shardInfo *persistence.ShardInfoWithFailover) *persistence.Sh
 	for k, v := range shardInfo.ClusterReplicationLevel {
 		clusterReplicationLevel[k] = v
 	}
+	replicationDLQAckLevel := make(map[string]int64)
+	for k, v := range shardInfo.ReplicationDLQAckLevel {
+		replicationDLQAckLevel[k] = v
+	}
 	shardInfoCopy := &persistence.ShardInfoWithFailover{
 		ShardInfo: &persistenceblobs.ShardInfo{
 			ShardId:                      shardInfo.GetShardId(),
@@ -1313,6 +1446,11 @@ func copyShardInfo(shardInfo *persistence.ShardInfoWithFailover) *persistence.Sh
 			ClusterTimerAckLevel:         clusterTimerAckLevel,
 			NamespaceNotificationVersion: shardInfo.NamespaceNotificationVersion,
 			ClusterReplicationLevel:      clusterReplicationLevel,
+			ReplicationDLQAckLevel:       replicationDLQAckLevel,
+			NamespaceHandoverLevels:      copyNamespaceHandoverLevels(shardInfo.NamespaceHandoverLevels),
+			NumHistoryShards:             shardInfo.NumHistoryShards,
+			OwnerOverride:                shardInfo.OwnerOverride,
+			OwnerOverridePinned:          shardInfo.OwnerOverridePinned,
 			UpdatedAt:                    shardInfo.UpdatedAt,
 		},
 		TransferFailoverLevels: transferFailoverLevels,
@@ -1321,3 +1459,18 @@ func copyShardInfo(shardInfo *persistence.ShardInfoWithFailover) *persistence.Sh
 
 	return shardInfoCopy
 }
+
+func copyNamespaceHandoverLevels(
+	levels map[string]*persistenceblobs.NamespaceHandoverLevel,
+) map[string]*persistenceblobs.NamespaceHandoverLevel {
+
+	levelsCopy := make(map[string]*persistenceblobs.NamespaceHandoverLevel, len(levels))
+	for k, v := range levels {
+		levelsCopy[k] = &persistenceblobs.NamespaceHandoverLevel{
+			HandoverVersion:      v.GetHandoverVersion(),
+			MaxReplicationTaskId: v.GetMaxReplicationTaskId(),
+			Replicated:           v.GetReplicated(),
+		}
+	}
+	return levelsCopy
+}
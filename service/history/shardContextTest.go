@@ -66,6 +66,7 @@ func newTestShardContext(
 		timerMaxReadLevelMap:      make(map[string]time.Time),
 		remoteClusterCurrentTime:  make(map[string]time.Time),
 		eventsCache:               eventsCache,
+		loadTracker:               newShardLoadTracker(time.Now()),
 	}
 	return &shardContextTest{
 		shardContextImpl: shard,
@@ -30,17 +30,23 @@ import (
 	"sync/atomic"
 	"time"
 
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	clustergenpb "github.com/temporalio/temporal/.gen/proto/cluster"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/membership"
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/resharding"
 	"github.com/temporalio/temporal/common/resource"
 )
 
 const (
 	shardControllerMembershipUpdateListenerName = "ShardController"
+
+	noSplittingShardID int32 = -1
 )
 
 type (
@@ -57,6 +63,12 @@ type (
 		throttledLogger    log.Logger
 		config             *Config
 		metricsScope       metrics.Scope
+		splitter           *shardSplitter
+		splittingShardID   int32
+		ownership          *shardOwnershipResolver
+		rebalancer         *shardRebalancer
+		drainLock          sync.Mutex
+		draining           int32
 
 		sync.RWMutex
 		historyShards map[int]*historyShardsItem
@@ -72,9 +84,11 @@ type (
 		logger          log.Logger
 		throttledLogger log.Logger
 		engineFactory   EngineFactory
+		loadTracker     *shardLoadTracker
 
 		sync.RWMutex
 		status historyShardsItemStatus
+		shard  ShardContext
 		engine Engine
 	}
 )
@@ -91,18 +105,24 @@ func newShardController(
 	config *Config,
 ) *shardController {
 	hostIdentity := resource.GetHostInfo().Identity()
-	return &shardController{
+	logger := resource.GetLogger().WithTags(tag.ComponentShardController, tag.Address(hostIdentity))
+	controller := &shardController{
 		Resource:           resource,
 		status:             common.DaemonStatusInitialized,
 		membershipUpdateCh: make(chan *membership.ChangedEvent, 10),
 		engineFactory:      factory,
 		historyShards:      make(map[int]*historyShardsItem),
 		shutdownCh:         make(chan struct{}),
-		logger:             resource.GetLogger().WithTags(tag.ComponentShardController, tag.Address(hostIdentity)),
+		logger:             logger,
 		throttledLogger:    resource.GetThrottledLogger().WithTags(tag.ComponentShardController, tag.Address(hostIdentity)),
 		config:             config,
 		metricsScope:       resource.GetMetricsClient().Scope(metrics.HistoryShardControllerScope),
+		splitter:           newShardSplitter(resource, config, logger),
+		splittingShardID:   noSplittingShardID,
+		ownership:          newShardOwnershipResolver(resource),
 	}
+	controller.rebalancer = newShardRebalancer(resource, controller, config, logger)
+	return controller
 }
 
 func newHistoryShardsItem(
@@ -119,6 +139,7 @@ func newHistoryShardsItem(
 		status:          historyShardsItemStatusInitialized,
 		engineFactory:   factory,
 		config:          config,
+		loadTracker:     newShardLoadTracker(resource.GetTimeSource().Now()),
 		logger:          resource.GetLogger().WithTags(tag.ShardID(shardID), tag.Address(hostIdentity)),
 		throttledLogger: resource.GetThrottledLogger().WithTags(tag.ShardID(shardID), tag.Address(hostIdentity)),
 	}, nil
@@ -132,6 +153,7 @@ func (c *shardController) Start() {
 	c.acquireShards()
 	c.shutdownWG.Add(1)
 	go c.shardManagementPump()
+	c.rebalancer.Start()
 
 	err := c.GetHistoryServiceResolver().AddListener(shardControllerMembershipUpdateListenerName, c.membershipUpdateCh)
 	if err != nil {
@@ -151,6 +173,7 @@ func (c *shardController) Stop() {
 	if err := c.GetHistoryServiceResolver().RemoveListener(shardControllerMembershipUpdateListenerName); err != nil {
 		c.logger.Error("Error removing membership update listener", tag.Error(err), tag.OperationFailed)
 	}
+	c.rebalancer.Stop()
 	close(c.shutdownCh)
 
 	if success := common.AwaitWaitGroup(&c.shutdownWG, time.Minute); !success {
@@ -169,19 +192,109 @@ func (c *shardController) isShuttingDown() bool {
 	return atomic.LoadInt32(&c.shuttingDown) != 0
 }
 
+// drain takes this host out of the membership ring, stops acquiring shards and releases
+// the owned shards, so that they are taken over by other hosts
+func (c *shardController) drain() error {
+	c.drainLock.Lock()
+	defer c.drainLock.Unlock()
+
+	if c.isDraining() {
+		return nil
+	}
+	c.logger.Info("DrainHost: Removing self from membership ring")
+	if err := c.GetMembershipMonitor().SetDraining(true); err != nil {
+		return err
+	}
+	atomic.StoreInt32(&c.draining, 1)
+	c.PrepareToStop()
+	c.releaseShards()
+	return nil
+}
+
+// undrain puts a drained host back into the membership ring and resumes acquiring shards
+func (c *shardController) undrain() error {
+	c.drainLock.Lock()
+	defer c.drainLock.Unlock()
+
+	if !c.isDraining() {
+		return nil
+	}
+	if atomic.LoadInt32(&c.status) != common.DaemonStatusStarted {
+		return serviceerror.NewUnavailable("shard controller is stopped")
+	}
+	c.logger.Info("DrainHost: Adding self back to membership ring")
+	if err := c.GetMembershipMonitor().SetDraining(false); err != nil {
+		return err
+	}
+	atomic.StoreInt32(&c.shuttingDown, 0)
+	atomic.StoreInt32(&c.draining, 0)
+	return nil
+}
+
+func (c *shardController) isDraining() bool {
+	return atomic.LoadInt32(&c.draining) != 0
+}
+
 func (c *shardController) GetEngine(workflowID string) (Engine, error) {
-	shardID := c.config.GetShardID(workflowID)
-	return c.getEngineForShard(shardID)
+	shardID := c.GetHistoryShardLayout().GetShardID(workflowID)
+	engine, err := c.getEngineForShard(shardID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.validateShardSplit(shardID, workflowID); err != nil {
+		return nil, err
+	}
+	return engine, nil
+}
+
+// validateShardSplit fences a source shard which has been split by another host before the layout
+// of this host observes the split. The split recorded in the shard info is applied to the layout, and
+// workflows which belong to a target shard are rejected, so they are never served by the source shard.
+func (c *shardController) validateShardSplit(shardID int, workflowID string) error {
+	layout := c.GetHistoryShardLayout()
+	if !layout.IsSplitting() || shardID >= layout.GetSourceNumberOfShards() || layout.IsSplit(shardID) {
+		return nil
+	}
+
+	c.RLock()
+	item, ok := c.historyShards[shardID]
+	c.RUnlock()
+	if !ok {
+		return nil
+	}
+	shard := item.getShard()
+	if shard == nil || shard.GetNumHistoryShards() != layout.GetNumberOfShards() {
+		return nil
+	}
+
+	layout.MarkSplit(shardID)
+	targetShardID := layout.GetShardID(workflowID)
+	if targetShardID == shardID {
+		return nil
+	}
+	info, err := c.ownership.lookup(targetShardID)
+	if err != nil {
+		return err
+	}
+	return createShardOwnershipLostError(c.GetHostInfo().Identity(), info.GetAddress())
 }
 
 func (c *shardController) getEngineForShard(shardID int) (Engine, error) {
 	sw := c.metricsScope.StartTimer(metrics.GetEngineForShardLatency)
 	defer sw.Stop()
+	if !c.isShardAvailable(shardID) {
+		return nil, serviceerror.NewUnavailable(fmt.Sprintf("shard %v is being split", shardID))
+	}
 	item, err := c.getOrCreateHistoryShardItem(shardID)
 	if err != nil {
 		return nil, err
 	}
-	return item.getOrCreateEngine(c.shardClosedCallback)
+	engine, err := item.getOrCreateEngine(c.shardClosedCallback)
+	if err != nil {
+		return nil, err
+	}
+	item.loadTracker.recordRequest()
+	return engine, nil
 }
 
 func (c *shardController) removeEngineForShard(shardID int, shardItem *historyShardsItem) {
@@ -227,7 +340,7 @@ func (c *shardController) getOrCreateHistoryShardItem(shardID int) (*historyShar
 	if c.isShuttingDown() || atomic.LoadInt32(&c.status) == common.DaemonStatusStopped {
 		return nil, fmt.Errorf("shardController for host '%v' shutting down", c.GetHostInfo().Identity())
 	}
-	info, err := c.GetHistoryServiceResolver().Lookup(string(shardID))
+	info, err := c.ownership.lookup(shardID)
 	if err != nil {
 		return nil, err
 	}
@@ -292,6 +405,7 @@ func (c *shardController) shardManagementPump() {
 			return
 		case <-acquireTicker.C:
 			c.acquireShards()
+			c.splitShards()
 		case changedEvent := <-c.membershipUpdateCh:
 			c.metricsScope.IncCounter(metrics.MembershipChangedCounter)
 
@@ -321,11 +435,11 @@ func (c *shardController) acquireShards() {
 				if c.isShuttingDown() {
 					return
 				}
-				info, err := c.GetHistoryServiceResolver().Lookup(string(shardID))
+				info, err := c.ownership.lookup(shardID)
 				if err != nil {
 					c.logger.Error("Error looking up host for shardID", tag.Error(err), tag.OperationFailed, tag.ShardID(shardID))
 				} else {
-					if info.Identity() == c.GetHostInfo().Identity() {
+					if info.Identity() == c.GetHostInfo().Identity() && c.isShardAvailable(shardID) {
 						_, err1 := c.getEngineForShard(shardID)
 						if err1 != nil {
 							c.metricsScope.IncCounter(metrics.GetEngineForShardErrorCounter)
@@ -337,7 +451,7 @@ func (c *shardController) acquireShards() {
 		}()
 	}
 	// Submit tasks to the channel.
-	for shardID := 0; shardID < c.config.NumberOfShards; shardID++ {
+	for shardID := 0; shardID < c.GetHistoryShardLayout().GetNumberOfShards(); shardID++ {
 		shardActionCh <- shardID
 		if c.isShuttingDown() {
 			return
@@ -350,6 +464,140 @@ func (c *shardController) acquireShards() {
 	c.metricsScope.UpdateGauge(metrics.NumShardsGauge, float64(c.numShards()))
 }
 
+// isShardAvailable returns false for a source shard being split by this host, and for the
+// target shards of a source shard which has not been split yet
+func (c *shardController) isShardAvailable(shardID int) bool {
+	if int32(shardID) == atomic.LoadInt32(&c.splittingShardID) {
+		return false
+	}
+
+	layout := c.GetHistoryShardLayout()
+	sourceNumberOfShards := layout.GetSourceNumberOfShards()
+	return !layout.IsSplitting() || shardID < sourceNumberOfShards || layout.IsSplit(shardID%sourceNumberOfShards)
+}
+
+// splitShards starts splitting one of the owned source shards, if the history shards are being split.
+// Shards are split one at a time per host.
+func (c *shardController) splitShards() {
+	layout := c.GetHistoryShardLayout()
+	if !layout.IsSplitting() || !c.config.EnableShardSplit() || c.isShuttingDown() {
+		return
+	}
+
+	for shardID := 0; shardID < layout.GetSourceNumberOfShards(); shardID++ {
+		if layout.IsSplit(shardID) {
+			continue
+		}
+		c.RLock()
+		_, ok := c.historyShards[shardID]
+		c.RUnlock()
+		if !ok {
+			continue
+		}
+
+		if !atomic.CompareAndSwapInt32(&c.splittingShardID, noSplittingShardID, int32(shardID)) {
+			// a split is already in progress
+			return
+		}
+		// drain the shard, new requests are rejected until the split completes
+		c.removeEngineForShard(shardID, nil)
+		c.shutdownWG.Add(1)
+		go c.splitShard(shardID)
+		return
+	}
+}
+
+func (c *shardController) splitShard(shardID int) {
+	defer c.shutdownWG.Done()
+	defer atomic.StoreInt32(&c.splittingShardID, noSplittingShardID)
+
+	c.logger.Info("Splitting history shard.", tag.ShardID(shardID))
+	sw := c.metricsScope.StartTimer(metrics.ShardSplitLatency)
+	defer sw.Stop()
+
+	if err := c.splitter.splitShard(shardID); err != nil {
+		c.metricsScope.IncCounter(metrics.ShardSplitFailedCounter)
+		c.logger.Error("Failed to split history shard.", tag.ShardID(shardID), tag.Error(err))
+		return
+	}
+
+	c.GetHistoryShardLayout().MarkSplit(shardID)
+	c.metricsScope.IncCounter(metrics.ShardSplitCompletedCounter)
+	c.logger.Info("History shard split completed.", tag.ShardID(shardID))
+}
+
+// isShardOwned returns true if the shard engine is running on this host
+func (c *shardController) isShardOwned(shardID int) bool {
+	c.RLock()
+	item, ok := c.historyShards[shardID]
+	c.RUnlock()
+	return ok && item.getShard() != nil
+}
+
+// setShardOwner persists the owner override of a shard owned by this host. The shard keeps
+// being served by this host until the new owner acquires it.
+func (c *shardController) setShardOwner(shardID int, hostAddress string, pinned bool) error {
+	if err := c.ownership.validateHost(hostAddress); err != nil {
+		return err
+	}
+
+	if _, err := c.getEngineForShard(shardID); err != nil {
+		return err
+	}
+	item, err := c.getOrCreateHistoryShardItem(shardID)
+	if err != nil {
+		return err
+	}
+	shard := item.getShard()
+	if shard == nil {
+		return serviceerror.NewUnavailable(fmt.Sprintf("shard %v is not available", shardID))
+	}
+
+	if err := shard.UpdateOwnerOverride(hostAddress, pinned); err != nil {
+		return err
+	}
+	c.ownership.setOverride(shardID, resharding.OwnerOverride{
+		HostAddress: hostAddress,
+		Pinned:      pinned,
+	})
+	c.logger.Info("Shard owner override updated.",
+		tag.ShardID(shardID),
+		tag.Address(hostAddress),
+	)
+	return nil
+}
+
+// shardLoads returns the load of every shard with a running engine on this host
+func (c *shardController) shardLoads() []*clustergenpb.ShardLoad {
+	c.RLock()
+	items := make([]*historyShardsItem, 0, len(c.historyShards))
+	for _, item := range c.historyShards {
+		items = append(items, item)
+	}
+	c.RUnlock()
+
+	now := c.GetTimeSource().Now()
+	var loads []*clustergenpb.ShardLoad
+	for _, item := range items {
+		engine := item.getEngine()
+		if engine == nil {
+			continue
+		}
+
+		requestRate, taskRate := item.loadTracker.sample(now)
+		override, _ := c.ownership.getOverride(item.shardID)
+		loads = append(loads, &clustergenpb.ShardLoad{
+			ShardId:             int32(item.shardID),
+			RequestRate:         requestRate,
+			TaskRate:            taskRate,
+			CacheSize:           int32(engine.GetMutableStateCacheSize()),
+			OwnerOverride:       override.HostAddress,
+			OwnerOverridePinned: override.Pinned,
+		})
+	}
+	return loads
+}
+
 func (c *shardController) doShutdown() {
 	c.logger.Info("", tag.LifeCycleStopping)
 	c.Lock()
@@ -360,6 +608,22 @@ func (c *shardController) doShutdown() {
 	c.historyShards = nil
 }
 
+// releaseShards stops the engines of all shards owned by this host, the new owners
+// acquire them without waiting for this host to lose their ownership
+func (c *shardController) releaseShards() {
+	c.Lock()
+	items := c.historyShards
+	c.historyShards = make(map[int]*historyShardsItem)
+	c.Unlock()
+
+	for shardID, item := range items {
+		c.logger.Info("DrainHost: Releasing shard", tag.ShardID(shardID))
+		item.stopEngine()
+		c.metricsScope.IncCounter(metrics.ShardItemRemovedCounter)
+	}
+	c.metricsScope.UpdateGauge(metrics.NumShardsGauge, 0)
+}
+
 func (c *shardController) numShards() int {
 	nShards := 0
 	c.RLock()
@@ -402,6 +666,7 @@ func (i *historyShardsItem) getOrCreateEngine(
 			i.GetMetricsClient().RecordTimer(metrics.ShardInfoScope, metrics.ShardItemAcquisitionLatency,
 				context.GetCurrentTime(i.GetClusterMetadata().GetCurrentClusterName()).Sub(context.GetLastUpdatedTime()))
 		}
+		i.shard = context
 		i.engine = i.engineFactory.CreateEngine(context)
 		i.engine.Start()
 		i.logger.Info("", tag.LifeCycleStarted, tag.ComponentShardEngine)
@@ -427,6 +692,7 @@ func (i *historyShardsItem) stopEngine() {
 		i.logger.Info("", tag.LifeCycleStopping, tag.ComponentShardEngine)
 		i.engine.Stop()
 		i.engine = nil
+		i.shard = nil
 		i.logger.Info("", tag.LifeCycleStopped, tag.ComponentShardEngine)
 		i.status = historyShardsItemStatusStopped
 	case historyShardsItemStatusStopped:
@@ -436,6 +702,20 @@ func (i *historyShardsItem) stopEngine() {
 	}
 }
 
+func (i *historyShardsItem) getShard() ShardContext {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.shard
+}
+
+func (i *historyShardsItem) getEngine() Engine {
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.engine
+}
+
 func (i *historyShardsItem) isValid() bool {
 	i.RLock()
 	defer i.RUnlock()
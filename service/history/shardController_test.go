@@ -28,10 +28,12 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gogo/protobuf/types"
+	"go.temporal.io/temporal-proto/serviceerror"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 
@@ -40,6 +42,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
@@ -47,6 +50,7 @@ import (
 	"github.com/temporalio/temporal/common/metrics"
 	mmocks "github.com/temporalio/temporal/common/mocks"
 	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/resharding"
 	"github.com/temporalio/temporal/common/resource"
 	"github.com/temporalio/temporal/common/service/dynamicconfig"
 )
@@ -105,6 +109,7 @@ func (s *shardControllerSuite) TearDownTest() {
 func (s *shardControllerSuite) TestAcquireShardSuccess() {
 	numShards := 10
 	s.config.NumberOfShards = numShards
+	s.mockResource.HistoryShardLayout = resharding.NewStaticLayout(numShards)
 
 	replicationAck := int64(201)
 	currentClusterTransferAck := int64(210)
@@ -182,6 +187,7 @@ func (s *shardControllerSuite) TestAcquireShardSuccess() {
 func (s *shardControllerSuite) TestAcquireShardsConcurrently() {
 	numShards := 10
 	s.config.NumberOfShards = numShards
+	s.mockResource.HistoryShardLayout = resharding.NewStaticLayout(numShards)
 	s.config.AcquireShardConcurrency = func(opts ...dynamicconfig.FilterOption) int {
 		return 10
 	}
@@ -262,6 +268,7 @@ func (s *shardControllerSuite) TestAcquireShardsConcurrently() {
 func (s *shardControllerSuite) TestAcquireShardLookupFailure() {
 	numShards := 2
 	s.config.NumberOfShards = numShards
+	s.mockResource.HistoryShardLayout = resharding.NewStaticLayout(numShards)
 	for shardID := 0; shardID < numShards; shardID++ {
 		s.mockServiceResolver.EXPECT().Lookup(string(shardID)).Return(nil, errors.New("ring failure")).Times(1)
 	}
@@ -276,6 +283,7 @@ func (s *shardControllerSuite) TestAcquireShardLookupFailure() {
 func (s *shardControllerSuite) TestAcquireShardRenewSuccess() {
 	numShards := 2
 	s.config.NumberOfShards = numShards
+	s.mockResource.HistoryShardLayout = resharding.NewStaticLayout(numShards)
 
 	replicationAck := int64(201)
 	currentClusterTransferAck := int64(210)
@@ -348,6 +356,7 @@ func (s *shardControllerSuite) TestAcquireShardRenewSuccess() {
 func (s *shardControllerSuite) TestAcquireShardRenewLookupFailed() {
 	numShards := 2
 	s.config.NumberOfShards = numShards
+	s.mockResource.HistoryShardLayout = resharding.NewStaticLayout(numShards)
 
 	replicationAck := int64(201)
 	currentClusterTransferAck := int64(210)
@@ -420,6 +429,7 @@ func (s *shardControllerSuite) TestAcquireShardRenewLookupFailed() {
 func (s *shardControllerSuite) TestHistoryEngineClosed() {
 	numShards := 4
 	s.config.NumberOfShards = numShards
+	s.mockResource.HistoryShardLayout = resharding.NewStaticLayout(numShards)
 	s.shardController = newShardController(s.mockResource, s.mockEngineFactory, s.config)
 	historyEngines := make(map[int]*MockEngine)
 	for shardID := 0; shardID < numShards; shardID++ {
@@ -505,9 +515,47 @@ func (s *shardControllerSuite) TestHistoryEngineClosed() {
 	s.shardController.Stop()
 }
 
+func (s *shardControllerSuite) TestValidateShardSplit() {
+	layout, err := resharding.NewLayout(1, 2, s.mockShardManager, dynamicconfig.GetDurationPropertyFn(time.Minute), dynamicconfig.GetDurationPropertyFn(time.Minute), s.logger)
+	s.NoError(err)
+	s.mockResource.HistoryShardLayout = layout
+
+	var movedWorkflowID, keptWorkflowID string
+	for i := 0; movedWorkflowID == "" || keptWorkflowID == ""; i++ {
+		workflowID := fmt.Sprintf("workflow-%v", i)
+		if common.WorkflowIDToHistoryShard(workflowID, 2) == 1 {
+			movedWorkflowID = workflowID
+		} else {
+			keptWorkflowID = workflowID
+		}
+	}
+
+	// the shard has not been split yet
+	shard := newTestShardContext(s.controller, &persistence.ShardInfoWithFailover{
+		ShardInfo: &persistenceblobs.ShardInfo{ShardId: 0},
+	}, s.config)
+	s.shardController.historyShards[0] = &historyShardsItem{shardID: 0, shard: shard}
+	s.NoError(s.shardController.validateShardSplit(0, movedWorkflowID))
+	s.False(layout.IsSplit(0))
+
+	// the shard has been split by another host
+	shard = newTestShardContext(s.controller, &persistence.ShardInfoWithFailover{
+		ShardInfo: &persistenceblobs.ShardInfo{ShardId: 0, NumHistoryShards: 2},
+	}, s.config)
+	s.shardController.historyShards[0] = &historyShardsItem{shardID: 0, shard: shard}
+	s.mockServiceResolver.EXPECT().Lookup(string(1)).Return(membership.NewHostInfo("target-host", nil), nil).Times(1)
+	err = s.shardController.validateShardSplit(0, movedWorkflowID)
+	s.IsType(&serviceerror.ShardOwnershipLost{}, err)
+	s.Equal("target-host", err.(*serviceerror.ShardOwnershipLost).Owner)
+	s.True(layout.IsSplit(0))
+	s.Equal(1, layout.GetShardID(movedWorkflowID))
+	s.Equal(0, layout.GetShardID(keptWorkflowID))
+}
+
 func (s *shardControllerSuite) TestShardControllerClosed() {
 	numShards := 4
 	s.config.NumberOfShards = numShards
+	s.mockResource.HistoryShardLayout = resharding.NewStaticLayout(numShards)
 	s.shardController = newShardController(s.mockResource, s.mockEngineFactory, s.config)
 	historyEngines := make(map[int]*MockEngine)
 	for shardID := 0; shardID < numShards; shardID++ {
@@ -553,6 +601,70 @@ func (s *shardControllerSuite) TestShardControllerClosed() {
 	workerWG.Wait()
 }
 
+func (s *shardControllerSuite) TestDrain() {
+	numShards := 2
+	s.config.NumberOfShards = numShards
+	s.mockResource.HistoryShardLayout = resharding.NewStaticLayout(numShards)
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestSingleDCClusterInfo).AnyTimes()
+	for shardID := 0; shardID < numShards; shardID++ {
+		mockEngine := NewMockEngine(s.controller)
+		s.setupMocksForAcquireShard(shardID, mockEngine, 5, 6)
+		mockEngine.EXPECT().Stop().Times(1)
+	}
+	s.shardController.acquireShards()
+	s.Equal(numShards, s.shardController.numShards())
+
+	s.mockResource.MembershipMonitor.EXPECT().SetDraining(true).Return(nil).Times(1)
+	s.NoError(s.shardController.drain())
+	s.True(s.shardController.isDraining())
+	s.Equal(0, s.shardController.numShards())
+
+	// a drained host neither acquires shards nor creates engines on requests
+	s.shardController.acquireShards()
+	s.Equal(0, s.shardController.numShards())
+	_, err := s.shardController.getEngineForShard(0)
+	s.Error(err)
+
+	s.NoError(s.shardController.drain())
+}
+
+func (s *shardControllerSuite) TestDrain_SetDrainingFailed() {
+	s.mockResource.MembershipMonitor.EXPECT().SetDraining(true).Return(errors.New("ringpop error")).Times(1)
+	s.Error(s.shardController.drain())
+	s.False(s.shardController.isDraining())
+	s.False(s.shardController.isShuttingDown())
+}
+
+func (s *shardControllerSuite) TestUndrain() {
+	numShards := 1
+	s.config.NumberOfShards = numShards
+	s.mockResource.HistoryShardLayout = resharding.NewStaticLayout(numShards)
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestSingleDCClusterInfo).AnyTimes()
+
+	// undraining a host which is not drained is a no op
+	s.NoError(s.shardController.undrain())
+
+	s.mockResource.MembershipMonitor.EXPECT().SetDraining(true).Return(nil).Times(1)
+	s.NoError(s.shardController.drain())
+
+	// a stopped controller cannot be put back into the ring
+	s.Error(s.shardController.undrain())
+	s.True(s.shardController.isDraining())
+
+	atomic.StoreInt32(&s.shardController.status, common.DaemonStatusStarted)
+	s.mockResource.MembershipMonitor.EXPECT().SetDraining(false).Return(nil).Times(1)
+	s.NoError(s.shardController.undrain())
+	s.False(s.shardController.isDraining())
+	s.False(s.shardController.isShuttingDown())
+
+	mockEngine := NewMockEngine(s.controller)
+	s.setupMocksForAcquireShard(0, mockEngine, 5, 6)
+	s.shardController.acquireShards()
+	s.Equal(numShards, s.shardController.numShards())
+}
+
 func (s *shardControllerSuite) setupMocksForAcquireShard(shardID int, mockEngine *MockEngine, currentRangeID,
 	newRangeID int64) {
 
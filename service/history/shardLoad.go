@@ -0,0 +1,103 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	clustergenpb "github.com/temporalio/temporal/.gen/proto/cluster"
+)
+
+const (
+	// shardLoadCacheSizeWeight is the load of one cached mutable state relative to one request or task per second
+	shardLoadCacheSizeWeight = 0.1
+)
+
+type (
+	// shardLoadTracker tracks the request and task rates of a shard, the rates are
+	// computed over the interval between two samples
+	shardLoadTracker struct {
+		requestCount int64
+		taskCount    int64
+
+		sync.Mutex
+		lastSampleTime   time.Time
+		lastRequestCount int64
+		lastTaskCount    int64
+		requestRate      float64
+		taskRate         float64
+	}
+)
+
+func newShardLoadTracker(
+	now time.Time,
+) *shardLoadTracker {
+
+	return &shardLoadTracker{
+		lastSampleTime: now,
+	}
+}
+
+func (t *shardLoadTracker) recordRequest() {
+	atomic.AddInt64(&t.requestCount, 1)
+}
+
+func (t *shardLoadTracker) recordTask() {
+	atomic.AddInt64(&t.taskCount, 1)
+}
+
+// sample returns the request and task rates per second since the previous sample,
+// the previous rates are returned if the last sample is less than a second old
+func (t *shardLoadTracker) sample(
+	now time.Time,
+) (float64, float64) {
+
+	t.Lock()
+	defer t.Unlock()
+
+	elapsed := now.Sub(t.lastSampleTime)
+	if elapsed < time.Second {
+		return t.requestRate, t.taskRate
+	}
+
+	requestCount := atomic.LoadInt64(&t.requestCount)
+	taskCount := atomic.LoadInt64(&t.taskCount)
+	t.requestRate = float64(requestCount-t.lastRequestCount) / elapsed.Seconds()
+	t.taskRate = float64(taskCount-t.lastTaskCount) / elapsed.Seconds()
+	t.lastRequestCount = requestCount
+	t.lastTaskCount = taskCount
+	t.lastSampleTime = now
+	return t.requestRate, t.taskRate
+}
+
+// shardLoadScore combines the load indicators of a shard into a single value used to balance shards
+func shardLoadScore(
+	load *clustergenpb.ShardLoad,
+) float64 {
+
+	return load.GetRequestRate() + load.GetTaskRate() + float64(load.GetCacheSize())*shardLoadCacheSizeWeight
+}
@@ -0,0 +1,89 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/common/membership"
+	"github.com/temporalio/temporal/common/resharding"
+	"github.com/temporalio/temporal/common/resource"
+)
+
+type (
+	// shardOwnershipResolver resolves the history host owning a shard, taking the owner
+	// overrides of the history shard layout into account
+	shardOwnershipResolver struct {
+		resource.Resource
+	}
+)
+
+func newShardOwnershipResolver(
+	resource resource.Resource,
+) *shardOwnershipResolver {
+
+	return &shardOwnershipResolver{
+		Resource: resource,
+	}
+}
+
+// lookup returns the host owning the shard, an override is ignored if its host is not part of the ring
+func (r *shardOwnershipResolver) lookup(
+	shardID int,
+) (*membership.HostInfo, error) {
+
+	return resharding.LookupOwner(r.GetHistoryShardLayout(), r.GetHistoryServiceResolver(), shardID)
+}
+
+func (r *shardOwnershipResolver) getOverride(
+	shardID int,
+) (resharding.OwnerOverride, bool) {
+
+	return r.GetHistoryShardLayout().GetOwnerOverride(shardID)
+}
+
+func (r *shardOwnershipResolver) setOverride(
+	shardID int,
+	override resharding.OwnerOverride,
+) {
+
+	r.GetHistoryShardLayout().SetOwnerOverride(shardID, override)
+}
+
+// validateHost returns an error if the host is not a member of the history ring
+func (r *shardOwnershipResolver) validateHost(
+	hostAddress string,
+) error {
+
+	if hostAddress == "" {
+		return nil
+	}
+	for _, host := range r.GetHistoryServiceResolver().Members() {
+		if host.GetAddress() == hostAddress {
+			return nil
+		}
+	}
+	return serviceerror.NewInvalidArgument("Host is not a member of the history service ring.")
+}
@@ -0,0 +1,210 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	clustergenpb "github.com/temporalio/temporal/.gen/proto/cluster"
+	"github.com/temporalio/temporal/.gen/proto/historyservice"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/resource"
+)
+
+const (
+	shardRebalancerRPCTimeout = 10 * time.Second
+)
+
+type (
+	// shardRebalancer periodically moves one shard from the most loaded history host to
+	// the least loaded one. Only the host owning shard 0 rebalances, so decisions are
+	// never taken concurrently by several hosts.
+	shardRebalancer struct {
+		resource.Resource
+
+		status       int32
+		controller   *shardController
+		config       *Config
+		logger       log.Logger
+		metricsScope metrics.Scope
+		shutdownCh   chan struct{}
+	}
+
+	hostLoad struct {
+		address string
+		load    float64
+		shards  []*clustergenpb.ShardLoad
+	}
+)
+
+func newShardRebalancer(
+	resource resource.Resource,
+	controller *shardController,
+	config *Config,
+	logger log.Logger,
+) *shardRebalancer {
+
+	return &shardRebalancer{
+		Resource:     resource,
+		status:       common.DaemonStatusInitialized,
+		controller:   controller,
+		config:       config,
+		logger:       logger,
+		metricsScope: resource.GetMetricsClient().Scope(metrics.HistoryShardControllerScope),
+		shutdownCh:   make(chan struct{}),
+	}
+}
+
+func (r *shardRebalancer) Start() {
+	if !atomic.CompareAndSwapInt32(&r.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+
+	go r.rebalanceLoop()
+}
+
+func (r *shardRebalancer) Stop() {
+	if !atomic.CompareAndSwapInt32(&r.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+
+	close(r.shutdownCh)
+}
+
+func (r *shardRebalancer) rebalanceLoop() {
+	timer := time.NewTimer(r.config.ShardRebalanceInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-r.shutdownCh:
+			return
+		case <-timer.C:
+			if r.config.EnableShardRebalance() && r.controller.isShardOwned(0) {
+				if err := r.rebalance(); err != nil {
+					r.metricsScope.IncCounter(metrics.ShardRebalanceFailedCounter)
+					r.logger.Error("Failed to rebalance history shards.", tag.Error(err))
+				}
+			}
+			timer.Reset(r.config.ShardRebalanceInterval())
+		}
+	}
+}
+
+func (r *shardRebalancer) rebalance() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shardRebalancerRPCTimeout)
+	defer cancel()
+
+	var hosts []*hostLoad
+	for _, member := range r.GetHistoryServiceResolver().Members() {
+		resp, err := r.GetHistoryClient().DescribeHistoryHost(ctx, &historyservice.DescribeHistoryHostRequest{
+			HostAddress: member.GetAddress(),
+		})
+		if err != nil {
+			return err
+		}
+
+		host := &hostLoad{
+			address: member.GetAddress(),
+			shards:  resp.GetShardLoads(),
+		}
+		for _, shard := range host.shards {
+			host.load += shardLoadScore(shard)
+		}
+		hosts = append(hosts, host)
+	}
+
+	shard, hostAddress := selectShardToMove(hosts, r.config.ShardRebalanceLoadThreshold())
+	if shard == nil {
+		return nil
+	}
+
+	r.logger.Info("Moving history shard to rebalance load.",
+		tag.ShardID(int(shard.GetShardId())),
+		tag.Address(hostAddress),
+	)
+	if _, err := r.GetHistoryClient().SetShardOwner(ctx, &historyservice.SetShardOwnerRequest{
+		ShardId:     shard.GetShardId(),
+		HostAddress: hostAddress,
+	}); err != nil {
+		return err
+	}
+	r.metricsScope.IncCounter(metrics.ShardRebalanceMovedCounter)
+	return nil
+}
+
+// selectShardToMove returns the shard to move from the most loaded host and the least loaded host
+// to move it to, or nil if the most loaded host is within the threshold of the average load.
+// The largest shard which does not make the least loaded host more loaded than the most loaded one
+// is selected, shards pinned by operators are never moved.
+func selectShardToMove(
+	hosts []*hostLoad,
+	threshold float64,
+) (*clustergenpb.ShardLoad, string) {
+
+	if len(hosts) < 2 {
+		return nil, ""
+	}
+
+	totalLoad := float64(0)
+	for _, host := range hosts {
+		totalLoad += host.load
+	}
+	averageLoad := totalLoad / float64(len(hosts))
+
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].load > hosts[j].load
+	})
+	mostLoaded := hosts[0]
+	leastLoaded := hosts[len(hosts)-1]
+	if mostLoaded.load <= averageLoad*(1+threshold) {
+		return nil, ""
+	}
+
+	var selected *clustergenpb.ShardLoad
+	maxLoad := (mostLoaded.load - leastLoaded.load) / 2
+	for _, shard := range mostLoaded.shards {
+		if shard.GetOwnerOverridePinned() {
+			continue
+		}
+		load := shardLoadScore(shard)
+		if load > maxLoad {
+			continue
+		}
+		if selected == nil || load > shardLoadScore(selected) {
+			selected = shard
+		}
+	}
+	if selected == nil {
+		return nil, ""
+	}
+	return selected, leastLoaded.address
+}
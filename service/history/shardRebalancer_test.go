@@ -0,0 +1,116 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	clustergenpb "github.com/temporalio/temporal/.gen/proto/cluster"
+)
+
+type (
+	shardRebalancerSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+)
+
+func TestShardRebalancerSuite(t *testing.T) {
+	s := new(shardRebalancerSuite)
+	suite.Run(t, s)
+}
+
+func (s *shardRebalancerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *shardRebalancerSuite) TestSelectShardToMove_Balanced() {
+	hosts := []*hostLoad{
+		s.newHostLoad("host-1", &clustergenpb.ShardLoad{ShardId: 1, RequestRate: 10}),
+		s.newHostLoad("host-2", &clustergenpb.ShardLoad{ShardId: 2, RequestRate: 11}),
+	}
+
+	shard, hostAddress := selectShardToMove(hosts, 0.2)
+	s.Nil(shard)
+	s.Empty(hostAddress)
+}
+
+func (s *shardRebalancerSuite) TestSelectShardToMove_SingleHost() {
+	hosts := []*hostLoad{
+		s.newHostLoad("host-1", &clustergenpb.ShardLoad{ShardId: 1, RequestRate: 100}),
+	}
+
+	shard, _ := selectShardToMove(hosts, 0.2)
+	s.Nil(shard)
+}
+
+func (s *shardRebalancerSuite) TestSelectShardToMove_Unbalanced() {
+	hosts := []*hostLoad{
+		s.newHostLoad("host-1", &clustergenpb.ShardLoad{ShardId: 1, RequestRate: 10}),
+		s.newHostLoad("host-2",
+			&clustergenpb.ShardLoad{ShardId: 2, RequestRate: 60},
+			&clustergenpb.ShardLoad{ShardId: 3, TaskRate: 20},
+			&clustergenpb.ShardLoad{ShardId: 4, RequestRate: 5},
+		),
+		s.newHostLoad("host-3", &clustergenpb.ShardLoad{ShardId: 5, RequestRate: 30}),
+	}
+
+	// shard 2 would make host-1 more loaded than host-2, shard 3 is the largest one left
+	shard, hostAddress := selectShardToMove(hosts, 0.2)
+	s.Equal(int32(3), shard.GetShardId())
+	s.Equal("host-1", hostAddress)
+}
+
+func (s *shardRebalancerSuite) TestSelectShardToMove_SkipPinned() {
+	hosts := []*hostLoad{
+		s.newHostLoad("host-1"),
+		s.newHostLoad("host-2",
+			&clustergenpb.ShardLoad{ShardId: 1, RequestRate: 20, OwnerOverride: "host-2", OwnerOverridePinned: true},
+			&clustergenpb.ShardLoad{ShardId: 2, RequestRate: 10},
+		),
+	}
+
+	shard, hostAddress := selectShardToMove(hosts, 0.2)
+	s.Equal(int32(2), shard.GetShardId())
+	s.Equal("host-1", hostAddress)
+}
+
+func (s *shardRebalancerSuite) newHostLoad(
+	address string,
+	shards ...*clustergenpb.ShardLoad,
+) *hostLoad {
+
+	host := &hostLoad{
+		address: address,
+		shards:  shards,
+	}
+	for _, shard := range shards {
+		host.load += shardLoadScore(shard)
+	}
+	return host
+}
@@ -0,0 +1,619 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"math"
+
+	"github.com/pborman/uuid"
+	commonpb "go.temporal.io/temporal-proto/common"
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/convert"
+	"github.com/temporalio/temporal/common/definition"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/persistence"
+	"github.com/temporalio/temporal/common/resource"
+)
+
+type (
+	// shardSplitter moves the workflow executions of a source shard to the
+	// shards it is split into, see resharding.Layout for the shard layout
+	shardSplitter struct {
+		resource.Resource
+
+		config       *Config
+		logger       log.Logger
+		metricsScope metrics.Scope
+	}
+
+	// shardSplit is the state of a single split attempt of a source shard
+	shardSplit struct {
+		sourceShard  ShardContext
+		targetShards map[int]ShardContext
+		// replicationTasks are the replication tasks of the source shard which are not
+		// acknowledged by all remote clusters yet, they are moved with their executions
+		replicationTasks map[definition.WorkflowIdentifier][]*persistenceblobs.ReplicationTaskInfo
+		// branchTokens maps the history branches of the source shard to their copies, a branch
+		// may be referenced by a replication task of another run before the run is moved
+		branchTokens map[string][]byte
+	}
+)
+
+func newShardSplitter(
+	resource resource.Resource,
+	config *Config,
+	logger log.Logger,
+) *shardSplitter {
+
+	return &shardSplitter{
+		Resource:     resource,
+		config:       config,
+		logger:       logger,
+		metricsScope: resource.GetMetricsClient().Scope(metrics.HistoryShardControllerScope),
+	}
+}
+
+// splitShard moves every workflow execution of the given source shard which
+// belongs to another shard in the target layout, and then marks the source
+// shard as split. Caller must make sure the source shard has no running engine.
+func (s *shardSplitter) splitShard(
+	shardID int,
+) error {
+
+	layout := s.GetHistoryShardLayout()
+	sourceNumberOfShards := layout.GetSourceNumberOfShards()
+	targetNumberOfShards := layout.GetNumberOfShards()
+
+	// acquiring the shards steals their range, which fences off any other host
+	// still working on them
+	sourceShard, err := s.acquireShard(shardID)
+	if err != nil {
+		return err
+	}
+	split := &shardSplit{
+		sourceShard:  sourceShard,
+		targetShards: make(map[int]ShardContext),
+		branchTokens: make(map[string][]byte),
+	}
+	for targetShardID := shardID + sourceNumberOfShards; targetShardID < targetNumberOfShards; targetShardID += sourceNumberOfShards {
+		targetShard, err := s.acquireShard(targetShardID)
+		if err != nil {
+			return err
+		}
+		split.targetShards[targetShardID] = targetShard
+	}
+	// no replication task is added to the source shard while it is being split,
+	// since its range is stolen from any other host
+	if split.replicationTasks, err = s.getPendingReplicationTasks(sourceShard); err != nil {
+		return err
+	}
+
+	var pageToken []byte
+	for {
+		resp, err := sourceShard.GetExecutionManager().ListConcreteExecutions(&persistence.ListConcreteExecutionsRequest{
+			PageSize:  s.config.ShardSplitPageSize(),
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, executionInfo := range resp.ExecutionInfos {
+			targetShardID := common.WorkflowIDToHistoryShard(executionInfo.WorkflowID, targetNumberOfShards)
+			if targetShardID == shardID {
+				continue
+			}
+			if err := s.moveExecution(split, split.targetShards[targetShardID], executionInfo); err != nil {
+				s.logger.Error("Failed to move workflow execution during shard split",
+					tag.ShardID(shardID),
+					tag.WorkflowNamespaceID(executionInfo.NamespaceID),
+					tag.WorkflowID(executionInfo.WorkflowID),
+					tag.WorkflowRunID(executionInfo.RunID),
+					tag.Error(err),
+				)
+				return err
+			}
+			s.metricsScope.IncCounter(metrics.ShardSplitExecutionsMovedCounter)
+		}
+
+		pageToken = resp.PageToken
+		if len(pageToken) == 0 {
+			break
+		}
+	}
+
+	return sourceShard.UpdateNumHistoryShards(targetNumberOfShards)
+}
+
+func (s *shardSplitter) acquireShard(
+	shardID int,
+) (ShardContext, error) {
+
+	shardItem, err := newHistoryShardsItem(s.Resource, shardID, nil, s.config)
+	if err != nil {
+		return nil, err
+	}
+	// no engine is created for the shard, so there is nothing to do on close
+	return acquireShard(shardItem, func(int, *historyShardsItem) {})
+}
+
+// getPendingReplicationTasks returns the replication tasks of the shard which are
+// not acknowledged by all remote clusters, grouped by workflow execution
+func (s *shardSplitter) getPendingReplicationTasks(
+	shard ShardContext,
+) (map[definition.WorkflowIdentifier][]*persistenceblobs.ReplicationTaskInfo, error) {
+
+	tasks := make(map[definition.WorkflowIdentifier][]*persistenceblobs.ReplicationTaskInfo)
+	readLevel := int64(math.MaxInt64)
+	currentClusterName := shard.GetClusterMetadata().GetCurrentClusterName()
+	for clusterName, info := range shard.GetClusterMetadata().GetAllClusterInfo() {
+		if !info.Enabled || clusterName == currentClusterName {
+			continue
+		}
+		if level := shard.GetClusterReplicationLevel(clusterName); level < readLevel {
+			readLevel = level
+		}
+	}
+	if readLevel == math.MaxInt64 {
+		// no remote cluster
+		return tasks, nil
+	}
+
+	request := &persistence.GetReplicationTasksRequest{
+		ReadLevel:    readLevel,
+		MaxReadLevel: shard.GetTransferMaxReadLevel(),
+		BatchSize:    s.config.ShardSplitPageSize(),
+	}
+	for {
+		resp, err := shard.GetExecutionManager().GetReplicationTasks(request)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range resp.Tasks {
+			key := definition.NewWorkflowIdentifier(task.GetNamespaceId(), task.GetWorkflowId(), task.GetRunId())
+			tasks[key] = append(tasks[key], task)
+		}
+		if len(resp.NextPageToken) == 0 {
+			return tasks, nil
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+}
+
+// moveExecution copies one workflow execution, including its history and its pending
+// replication tasks, to the target shard and then removes it from the source shard.
+// Data left behind in the target shard by a previous attempt is overwritten.
+func (s *shardSplitter) moveExecution(
+	split *shardSplit,
+	targetShard ShardContext,
+	executionInfo *persistence.WorkflowExecutionInfo,
+) error {
+
+	sourceShard := split.sourceShard
+	namespaceID := executionInfo.NamespaceID
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: executionInfo.WorkflowID,
+		RunId:      executionInfo.RunID,
+	}
+
+	resp, err := sourceShard.GetExecutionManager().GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
+		NamespaceID: namespaceID,
+		Execution:   execution,
+	})
+	if err != nil {
+		if _, ok := err.(*serviceerror.NotFound); ok {
+			// deleted after being listed
+			return nil
+		}
+		return err
+	}
+	state := resp.State
+
+	isCurrent, err := s.isCurrentExecution(sourceShard, namespaceID, execution)
+	if err != nil {
+		return err
+	}
+
+	if err := s.clearTargetExecution(targetShard, namespaceID, execution); err != nil {
+		return err
+	}
+
+	sourceBranchTokens, err := s.copyHistory(split, targetShard, namespaceID, execution, state)
+	if err != nil {
+		return err
+	}
+
+	sourceReplicationTasks := split.replicationTasks[definition.NewWorkflowIdentifier(
+		namespaceID,
+		execution.GetWorkflowId(),
+		execution.GetRunId(),
+	)]
+	replicationTasks, err := s.toTargetReplicationTasks(split, sourceReplicationTasks)
+	if err != nil {
+		return err
+	}
+
+	if err := s.createTargetExecution(targetShard, namespaceID, state, isCurrent, replicationTasks); err != nil {
+		return err
+	}
+
+	return s.deleteSourceExecution(sourceShard, namespaceID, execution, isCurrent, sourceBranchTokens, sourceReplicationTasks)
+}
+
+// toTargetReplicationTasks converts the replication tasks of the source shard into tasks of the
+// target shard, which reference the copies of the history branches
+func (s *shardSplitter) toTargetReplicationTasks(
+	split *shardSplit,
+	sourceTasks []*persistenceblobs.ReplicationTaskInfo,
+) ([]persistence.Task, error) {
+
+	var tasks []persistence.Task
+	now := split.sourceShard.GetTimeSource().Now()
+	for _, task := range sourceTasks {
+		switch task.GetTaskType() {
+		case commongenpb.TaskType_ReplicationHistory:
+			branchToken, err := split.getTargetBranchToken(task.GetBranchToken())
+			if err != nil {
+				return nil, err
+			}
+			newRunBranchToken, err := split.getTargetBranchToken(task.GetNewRunBranchToken())
+			if err != nil {
+				return nil, err
+			}
+			tasks = append(tasks, &persistence.HistoryReplicationTask{
+				VisibilityTimestamp: now,
+				FirstEventID:        task.GetFirstEventId(),
+				NextEventID:         task.GetNextEventId(),
+				Version:             task.GetVersion(),
+				BranchToken:         branchToken,
+				NewRunBranchToken:   newRunBranchToken,
+				ResetWorkflow:       task.GetResetWorkflow(),
+				LastReplicationInfo: task.GetLastReplicationInfo(),
+			})
+		case commongenpb.TaskType_ReplicationSyncActivity:
+			tasks = append(tasks, &persistence.SyncActivityTask{
+				VisibilityTimestamp: now,
+				Version:             task.GetVersion(),
+				ScheduledID:         task.GetScheduledId(),
+			})
+		default:
+			return nil, serviceerror.NewInternal("unknown replication task type")
+		}
+	}
+	return tasks, nil
+}
+
+// getTargetBranchToken returns the branch token of the copy of the source history branch,
+// the token is allocated on first use so that the copy of the branch can be made later
+func (s *shardSplit) getTargetBranchToken(
+	sourceBranchToken []byte,
+) ([]byte, error) {
+
+	if len(sourceBranchToken) == 0 {
+		return nil, nil
+	}
+	if branchToken, ok := s.branchTokens[string(sourceBranchToken)]; ok {
+		return branchToken, nil
+	}
+	branchToken, err := persistence.NewHistoryBranchToken(uuid.New())
+	if err != nil {
+		return nil, err
+	}
+	s.branchTokens[string(sourceBranchToken)] = branchToken
+	return branchToken, nil
+}
+
+func (s *shardSplitter) isCurrentExecution(
+	shard ShardContext,
+	namespaceID string,
+	execution commonpb.WorkflowExecution,
+) (bool, error) {
+
+	resp, err := shard.GetExecutionManager().GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+		NamespaceID: namespaceID,
+		WorkflowID:  execution.GetWorkflowId(),
+	})
+	if err != nil {
+		if _, ok := err.(*serviceerror.NotFound); ok {
+			return false, nil
+		}
+		return false, err
+	}
+	return resp.RunID == execution.GetRunId(), nil
+}
+
+func (s *shardSplitter) clearTargetExecution(
+	targetShard ShardContext,
+	namespaceID string,
+	execution commonpb.WorkflowExecution,
+) error {
+
+	isCurrent, err := s.isCurrentExecution(targetShard, namespaceID, execution)
+	if err != nil {
+		return err
+	}
+	if isCurrent {
+		if err := targetShard.GetExecutionManager().DeleteCurrentWorkflowExecution(&persistence.DeleteCurrentWorkflowExecutionRequest{
+			NamespaceID: namespaceID,
+			WorkflowID:  execution.GetWorkflowId(),
+			RunID:       execution.GetRunId(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return targetShard.GetExecutionManager().DeleteWorkflowExecution(&persistence.DeleteWorkflowExecutionRequest{
+		NamespaceID: namespaceID,
+		WorkflowID:  execution.GetWorkflowId(),
+		RunID:       execution.GetRunId(),
+	})
+}
+
+// copyHistory copies all history branches of the workflow execution to the
+// target shard and points the mutable state at the copies. The branch tokens
+// in the source shard are returned.
+func (s *shardSplitter) copyHistory(
+	split *shardSplit,
+	targetShard ShardContext,
+	namespaceID string,
+	execution commonpb.WorkflowExecution,
+	state *persistence.WorkflowMutableState,
+) ([][]byte, error) {
+
+	var sourceBranchTokens [][]byte
+
+	if state.VersionHistories == nil {
+		branchToken := state.ExecutionInfo.BranchToken
+		newBranchToken, err := s.copyHistoryBranch(
+			split,
+			targetShard,
+			namespaceID,
+			execution,
+			branchToken,
+			state.ExecutionInfo.NextEventID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		state.ExecutionInfo.BranchToken = newBranchToken
+		return append(sourceBranchTokens, branchToken), nil
+	}
+
+	for _, versionHistory := range state.VersionHistories.Histories {
+		if versionHistory.IsEmpty() {
+			continue
+		}
+		lastItem, err := versionHistory.GetLastItem()
+		if err != nil {
+			return nil, err
+		}
+		branchToken := versionHistory.GetBranchToken()
+		newBranchToken, err := s.copyHistoryBranch(
+			split,
+			targetShard,
+			namespaceID,
+			execution,
+			branchToken,
+			lastItem.GetEventID()+1,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := versionHistory.SetBranchToken(newBranchToken); err != nil {
+			return nil, err
+		}
+		sourceBranchTokens = append(sourceBranchTokens, branchToken)
+	}
+
+	currentVersionHistory, err := state.VersionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		return nil, err
+	}
+	state.ExecutionInfo.BranchToken = currentVersionHistory.GetBranchToken()
+	return sourceBranchTokens, nil
+}
+
+func (s *shardSplitter) copyHistoryBranch(
+	split *shardSplit,
+	targetShard ShardContext,
+	namespaceID string,
+	execution commonpb.WorkflowExecution,
+	branchToken []byte,
+	nextEventID int64,
+) ([]byte, error) {
+
+	sourceShard := split.sourceShard
+	newBranchToken, err := split.getTargetBranchToken(branchToken)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &persistence.ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+		MinEventID:  common.FirstEventID,
+		MaxEventID:  nextEventID,
+		PageSize:    s.config.ShardSplitPageSize(),
+		ShardID:     convert.IntPtr(sourceShard.GetShardID()),
+	}
+	isNewBranch := true
+	for {
+		resp, err := sourceShard.GetHistoryManager().ReadHistoryBranchByBatch(request)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, batch := range resp.History {
+			if _, err := targetShard.AppendHistoryV2Events(context.Background(), &persistence.AppendHistoryNodesRequest{
+				IsNewBranch: isNewBranch,
+				Info:        persistence.BuildHistoryGarbageCleanupInfo(namespaceID, execution.GetWorkflowId(), execution.GetRunId()),
+				BranchToken: newBranchToken,
+				Events:      batch.Events,
+			}, namespaceID, execution); err != nil {
+				return nil, err
+			}
+			isNewBranch = false
+		}
+
+		if len(resp.NextPageToken) == 0 {
+			return newBranchToken, nil
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+}
+
+// createTargetExecution writes the mutable state into the target shard and
+// regenerates its transfer and timer tasks there
+func (s *shardSplitter) createTargetExecution(
+	targetShard ShardContext,
+	namespaceID string,
+	state *persistence.WorkflowMutableState,
+	isCurrent bool,
+	replicationTasks []persistence.Task,
+) error {
+
+	namespaceEntry, err := targetShard.GetNamespaceCache().GetNamespaceByID(namespaceID)
+	if err != nil {
+		return err
+	}
+
+	// buffered events cannot be part of a snapshot, they are written with a
+	// follow up update instead
+	bufferedEvents := state.BufferedEvents
+	state.BufferedEvents = nil
+
+	mutableState := newMutableStateBuilder(targetShard, targetShard.GetEventsCache(), s.logger, namespaceEntry)
+	mutableState.Load(state)
+	if err := mutableState.StartTransactionSkipDecisionFail(namespaceEntry); err != nil {
+		return err
+	}
+
+	now := targetShard.GetTimeSource().Now()
+	taskRefresher := newMutableStateTaskRefresher(
+		targetShard.GetConfig(),
+		targetShard.GetNamespaceCache(),
+		targetShard.GetEventsCache(),
+		s.logger,
+	)
+	if err := taskRefresher.refreshTasks(now, mutableState); err != nil {
+		return err
+	}
+
+	snapshot, _, err := mutableState.CloseTransactionAsSnapshot(now, transactionPolicyPassive)
+	if err != nil {
+		return err
+	}
+	snapshot.ReplicationTasks = append(snapshot.ReplicationTasks, replicationTasks...)
+	if state.ExecutionStats != nil {
+		snapshot.ExecutionStats = &persistence.ExecutionStats{
+			HistorySize: state.ExecutionStats.HistorySize,
+		}
+	}
+
+	createMode := persistence.CreateWorkflowModeZombie
+	if isCurrent {
+		createMode = persistence.CreateWorkflowModeBrandNew
+	}
+	if _, err := targetShard.CreateWorkflowExecution(&persistence.CreateWorkflowExecutionRequest{
+		Mode:                createMode,
+		NewWorkflowSnapshot: *snapshot,
+	}); err != nil {
+		return err
+	}
+
+	if len(bufferedEvents) == 0 {
+		return nil
+	}
+
+	updateMode := persistence.UpdateWorkflowModeBypassCurrent
+	if isCurrent {
+		updateMode = persistence.UpdateWorkflowModeUpdateCurrent
+	}
+	_, err = targetShard.UpdateWorkflowExecution(&persistence.UpdateWorkflowExecutionRequest{
+		Mode: updateMode,
+		UpdateWorkflowMutation: persistence.WorkflowMutation{
+			ExecutionInfo:     snapshot.ExecutionInfo,
+			ExecutionStats:    snapshot.ExecutionStats,
+			ReplicationState:  snapshot.ReplicationState,
+			VersionHistories:  snapshot.VersionHistories,
+			NewBufferedEvents: bufferedEvents,
+			Condition:         snapshot.ExecutionInfo.NextEventID,
+			Checksum:          snapshot.Checksum,
+		},
+	})
+	return err
+}
+
+func (s *shardSplitter) deleteSourceExecution(
+	sourceShard ShardContext,
+	namespaceID string,
+	execution commonpb.WorkflowExecution,
+	isCurrent bool,
+	branchTokens [][]byte,
+	replicationTasks []*persistenceblobs.ReplicationTaskInfo,
+) error {
+
+	if isCurrent {
+		if err := sourceShard.GetExecutionManager().DeleteCurrentWorkflowExecution(&persistence.DeleteCurrentWorkflowExecutionRequest{
+			NamespaceID: namespaceID,
+			WorkflowID:  execution.GetWorkflowId(),
+			RunID:       execution.GetRunId(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := sourceShard.GetExecutionManager().DeleteWorkflowExecution(&persistence.DeleteWorkflowExecutionRequest{
+		NamespaceID: namespaceID,
+		WorkflowID:  execution.GetWorkflowId(),
+		RunID:       execution.GetRunId(),
+	}); err != nil {
+		return err
+	}
+
+	for _, task := range replicationTasks {
+		if err := sourceShard.GetExecutionManager().CompleteReplicationTask(&persistence.CompleteReplicationTaskRequest{
+			TaskID: task.GetTaskId(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, branchToken := range branchTokens {
+		if err := sourceShard.GetHistoryManager().DeleteHistoryBranch(&persistence.DeleteHistoryBranchRequest{
+			BranchToken: branchToken,
+			ShardID:     convert.IntPtr(sourceShard.GetShardID()),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
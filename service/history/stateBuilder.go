@@ -117,7 +117,9 @@ func (b *stateBuilderImpl) applyEvents(
 			// history event version changed during for loop
 			b.mutableState.UpdateReplicationStateVersion(event.GetVersion(), true)
 			b.mutableState.UpdateReplicationStateLastEventID(lastEvent.GetVersion(), lastEvent.GetEventId())
-		} else if b.mutableState.GetVersionHistories() != nil {
+		}
+		// workflows with backfilled version histories keep both up to date
+		if b.mutableState.GetVersionHistories() != nil {
 			if err := b.mutableState.UpdateCurrentVersion(event.GetVersion(), true); err != nil {
 				return nil, err
 			}
@@ -623,6 +625,10 @@ func (b *stateBuilderImpl) applyEvents(
 						b.mutableState.GetNamespaceEntry(),
 					)
 				}
+				// the callbacks of a continued as new run are carried over to the new run
+				newRunMutableStateBuilder.GetExecutionInfo().CompletionCallbacks = inheritCompletionCallbacks(
+					b.mutableState.GetExecutionInfo().CompletionCallbacks,
+				)
 				newRunStateBuilder := newStateBuilder(b.shard, b.logger, newRunMutableStateBuilder, b.taskGeneratorProvider)
 
 				newRunID := event.GetWorkflowExecutionContinuedAsNewEventAttributes().GetNewExecutionRunId()
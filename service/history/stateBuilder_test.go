@@ -37,6 +37,7 @@ import (
 	eventpb "go.temporal.io/temporal-proto/event"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
@@ -111,6 +112,7 @@ func (s *stateBuilderSuite) SetupTest() {
 	s.logger = s.mockShard.GetLogger()
 
 	s.mockMutableState.EXPECT().GetReplicationState().Return(&persistence.ReplicationState{}).AnyTimes()
+	s.mockMutableState.EXPECT().GetVersionHistories().Return(nil).AnyTimes()
 	s.stateBuilder = newStateBuilder(
 		s.mockShard,
 		s.logger,
@@ -483,7 +485,11 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeWorkflowExecutionContinuedA
 	).Return(nil).Times(1)
 	s.mockMutableState.EXPECT().GetNamespaceEntry().Return(testGlobalNamespaceEntry).AnyTimes()
 	s.mockUpdateVersion(continueAsNewEvent)
-	s.mockMutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{}).AnyTimes()
+	completionCallbacks := newCompletionCallbacks([]string{"http://localhost:8080/callback"})
+	completionCallbacks[0].State = executiongenpb.CompletionCallbackState_CompletionCallbackState_Failed
+	s.mockMutableState.EXPECT().GetExecutionInfo().Return(&persistence.WorkflowExecutionInfo{
+		CompletionCallbacks: completionCallbacks,
+	}).AnyTimes()
 	s.mockTaskGenerator.EXPECT().generateWorkflowCloseTasks(
 		s.stateBuilder.unixNanoToTime(continueAsNewEvent.GetTimestamp()),
 	).Return(nil).Times(1)
@@ -516,6 +522,11 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeWorkflowExecutionContinuedA
 	)
 	s.Nil(err)
 	s.NotNil(newRunStateBuilder)
+	// the callbacks of the continued as new run are delivered once the new run closes
+	s.Equal(
+		newCompletionCallbacks([]string{"http://localhost:8080/callback"}),
+		newRunStateBuilder.GetExecutionInfo().CompletionCallbacks,
+	)
 }
 
 func (s *stateBuilderSuite) TestApplyEvents_EventTypeWorkflowExecutionContinuedAsNew_EmptyNewRunHistory() {
@@ -56,6 +56,9 @@ type (
 		startTime time.Time
 		logger    log.Logger
 
+		// failedAttempt counts the attempts failed with non-transient errors, the task is moved to DLQ based on it
+		failedAttempt int
+
 		// used by 2DC task life cycle
 		// TODO remove when NDC task life cycle is implemented
 		shouldProcessTask bool
@@ -251,7 +254,9 @@ func (t *taskProcessor) processTaskOnce(
 	}
 
 	startTime := t.timeSource.Now()
-	scopeIdx, err := task.processor.process(task)
+	ctx, span := startQueueTaskSpan(t.shard, task.task)
+	scopeIdx, err := task.processor.process(ctx, task)
+	span.End(err)
 	scope := t.metricsClient.Scope(scopeIdx).Tagged(t.getNamespaceTagByID(task.task.GetNamespaceId()))
 	if task.shouldProcessTask {
 		scope.IncCounter(metrics.TaskRequests)
@@ -308,6 +313,19 @@ func (t *taskProcessor) handleTaskError(
 	}
 
 	task.logger.Error("Fail to process task", tag.Error(err), tag.LifeCycleProcessingFailed)
+
+	if !isTransientQueueTaskError(err) {
+		task.failedAttempt++
+	}
+	if shouldMoveQueueTaskToDLQ(t.config, task.task, task.failedAttempt, err) {
+		if dlqErr := moveQueueTaskToDLQ(t.shard, task.task); dlqErr != nil {
+			task.logger.Error("Fail to move task to DLQ", tag.Error(dlqErr))
+			return err
+		}
+		scope.IncCounter(metrics.TaskMovedToDLQCounter)
+		task.logger.Warn("Task moved to DLQ after max attempts", tag.Error(err), tag.Attempt(int32(task.attempt+1)))
+		return nil
+	}
 	return err
 }
 
@@ -318,6 +336,7 @@ func (t *taskProcessor) ackTaskOnce(
 
 	task.processor.complete(task)
 	if task.shouldProcessTask {
+		t.shard.GetLoadTracker().recordTask()
 		goVisibilityTime, _ := types.TimestampFromProto(task.task.GetVisibilityTimestamp())
 		scope.RecordTimer(metrics.TaskAttemptTimer, time.Duration(task.attempt))
 		scope.RecordTimer(metrics.TaskLatency, time.Since(task.startTime))
@@ -31,6 +31,7 @@ import (
 
 	"github.com/gogo/protobuf/types"
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally"
@@ -136,7 +137,7 @@ func (s *taskProcessorSuite) TestProcessTaskAndAck_NamespaceErrRetry_ProcessNoEr
 	}
 	s.mockProcessor.On("getTaskFilter").Return(taskFilterErr).Once()
 	s.mockProcessor.On("getTaskFilter").Return(taskFilter).Once()
-	s.mockProcessor.On("process", task).Return(s.scopeIdx, nil).Once()
+	s.mockProcessor.On("process", mock.Anything, task).Return(s.scopeIdx, nil).Once()
 	s.mockProcessor.On("complete", task).Once()
 	s.mockShard.resource.NamespaceCache.EXPECT().GetNamespaceName(gomock.Any()).Return(testNamespace, nil).Times(1)
 	s.taskProcessor.processTaskAndAck(
@@ -152,7 +153,7 @@ func (s *taskProcessorSuite) TestProcessTaskAndAck_NamespaceFalse_ProcessNoErr()
 		return false, nil
 	}
 	s.mockProcessor.On("getTaskFilter").Return(taskFilter).Once()
-	s.mockProcessor.On("process", task).Return(s.scopeIdx, nil).Once()
+	s.mockProcessor.On("process", mock.Anything, task).Return(s.scopeIdx, nil).Once()
 	s.mockProcessor.On("complete", task).Once()
 	s.mockShard.resource.NamespaceCache.EXPECT().GetNamespaceName(gomock.Any()).Return(testNamespace, nil).Times(1)
 	s.taskProcessor.processTaskAndAck(
@@ -167,7 +168,7 @@ func (s *taskProcessorSuite) TestProcessTaskAndAck_NamespaceTrue_ProcessNoErr()
 		return true, nil
 	}
 	s.mockProcessor.On("getTaskFilter").Return(taskFilter).Once()
-	s.mockProcessor.On("process", task).Return(s.scopeIdx, nil).Once()
+	s.mockProcessor.On("process", mock.Anything, task).Return(s.scopeIdx, nil).Once()
 	s.mockProcessor.On("complete", task).Once()
 	s.mockShard.resource.NamespaceCache.EXPECT().GetNamespaceName(gomock.Any()).Return(testNamespace, nil).Times(1)
 	s.taskProcessor.processTaskAndAck(
@@ -183,8 +184,8 @@ func (s *taskProcessorSuite) TestProcessTaskAndAck_NamespaceTrue_ProcessErrNoErr
 		return true, nil
 	}
 	s.mockProcessor.On("getTaskFilter").Return(taskFilter).Once()
-	s.mockProcessor.On("process", task).Return(s.scopeIdx, err).Once()
-	s.mockProcessor.On("process", task).Return(s.scopeIdx, nil).Once()
+	s.mockProcessor.On("process", mock.Anything, task).Return(s.scopeIdx, err).Once()
+	s.mockProcessor.On("process", mock.Anything, task).Return(s.scopeIdx, nil).Once()
 	s.mockProcessor.On("complete", task).Once()
 	s.mockShard.resource.NamespaceCache.EXPECT().GetNamespaceName(gomock.Any()).Return(testNamespace, nil).Times(2)
 	s.taskProcessor.processTaskAndAck(
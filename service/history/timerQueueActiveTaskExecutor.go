@@ -75,6 +75,7 @@ func newTimerQueueActiveTaskExecutor(
 }
 
 func (t *timerQueueActiveTaskExecutor) execute(
+	ctx context.Context,
 	taskInfo queueTaskInfo,
 	shouldProcessTask bool,
 ) error {
@@ -89,31 +90,30 @@ func (t *timerQueueActiveTaskExecutor) execute(
 
 	switch timerTask.TaskType {
 	case commongenpb.TaskType_UserTimer:
-		return t.executeUserTimerTimeoutTask(timerTask)
+		return t.executeUserTimerTimeoutTask(ctx, timerTask)
 	case commongenpb.TaskType_ActivityTimeout:
-		return t.executeActivityTimeoutTask(timerTask)
+		return t.executeActivityTimeoutTask(ctx, timerTask)
 	case commongenpb.TaskType_DecisionTimeout:
-		return t.executeDecisionTimeoutTask(timerTask)
+		return t.executeDecisionTimeoutTask(ctx, timerTask)
 	case commongenpb.TaskType_WorkflowRunTimeout:
-		return t.executeWorkflowTimeoutTask(timerTask)
+		return t.executeWorkflowTimeoutTask(ctx, timerTask)
 	case commongenpb.TaskType_ActivityRetryTimer:
-		return t.executeActivityRetryTimerTask(timerTask)
+		return t.executeActivityRetryTimerTask(ctx, timerTask)
 	case commongenpb.TaskType_WorkflowBackoffTimer:
-		return t.executeWorkflowBackoffTimerTask(timerTask)
+		return t.executeWorkflowBackoffTimerTask(ctx, timerTask)
 	case commongenpb.TaskType_DeleteHistoryEvent:
-		return t.executeDeleteHistoryEventTask(timerTask)
+		return t.executeDeleteHistoryEventTask(ctx, timerTask)
 	default:
 		return errUnknownTimerTask
 	}
 }
 
 func (t *timerQueueActiveTaskExecutor) executeUserTimerTimeoutTask(
+	ctx context.Context,
 	task *persistenceblobs.TimerTaskInfo,
 ) (retError error) {
 
-	weContext, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(task),
-	)
+	weContext, release, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
@@ -160,12 +160,11 @@ Loop:
 }
 
 func (t *timerQueueActiveTaskExecutor) executeActivityTimeoutTask(
+	ctx context.Context,
 	task *persistenceblobs.TimerTaskInfo,
 ) (retError error) {
 
-	weContext, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(task),
-	)
+	weContext, release, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
@@ -257,12 +256,11 @@ Loop:
 }
 
 func (t *timerQueueActiveTaskExecutor) executeDecisionTimeoutTask(
+	ctx context.Context,
 	task *persistenceblobs.TimerTaskInfo,
 ) (retError error) {
 
-	weContext, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(task),
-	)
+	weContext, release, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
@@ -329,12 +327,11 @@ func (t *timerQueueActiveTaskExecutor) executeDecisionTimeoutTask(
 }
 
 func (t *timerQueueActiveTaskExecutor) executeWorkflowBackoffTimerTask(
+	ctx context.Context,
 	task *persistenceblobs.TimerTaskInfo,
 ) (retError error) {
 
-	weContext, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(task),
-	)
+	weContext, release, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
@@ -364,12 +361,11 @@ func (t *timerQueueActiveTaskExecutor) executeWorkflowBackoffTimerTask(
 }
 
 func (t *timerQueueActiveTaskExecutor) executeActivityRetryTimerTask(
+	ctx context.Context,
 	task *persistenceblobs.TimerTaskInfo,
 ) (retError error) {
 
-	weContext, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(task),
-	)
+	weContext, release, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
@@ -437,7 +433,7 @@ func (t *timerQueueActiveTaskExecutor) executeActivityRetryTimerTask(
 
 	release(nil) // release earlier as we don't need the lock anymore
 
-	_, retError = t.shard.GetService().GetMatchingClient().AddActivityTask(context.Background(), &matchingservice.AddActivityTaskRequest{
+	_, retError = t.shard.GetService().GetMatchingClient().AddActivityTask(ctx, &matchingservice.AddActivityTaskRequest{
 		NamespaceId:                   targetNamespaceID,
 		SourceNamespaceId:             namespaceID,
 		Execution:                     execution,
@@ -450,12 +446,11 @@ func (t *timerQueueActiveTaskExecutor) executeActivityRetryTimerTask(
 }
 
 func (t *timerQueueActiveTaskExecutor) executeWorkflowTimeoutTask(
+	ctx context.Context,
 	task *persistenceblobs.TimerTaskInfo,
 ) (retError error) {
 
-	weContext, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(task),
-	)
+	weContext, release, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
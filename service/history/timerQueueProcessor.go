@@ -34,6 +34,7 @@ import (
 	"time"
 
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/client/matching"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
@@ -54,6 +55,7 @@ type (
 		NotifyNewTimers(clusterName string, timerTask []persistence.Task)
 		LockTaskProcessing()
 		UnlockTaskProcessing()
+		ReprocessTask(ctx context.Context, task *persistenceblobs.TimerTaskInfo) error
 	}
 
 	timeNow                 func() time.Time
@@ -262,6 +264,18 @@ func (t *timerQueueProcessorImpl) UnlockTaskProcessing() {
 	t.taskAllocator.unlock()
 }
 
+func (t *timerQueueProcessorImpl) ReprocessTask(
+	ctx context.Context,
+	task *persistenceblobs.TimerTaskInfo,
+) error {
+
+	processors := []taskExecutor{t.activeTimerProcessor}
+	for _, standbyTimerProcessor := range t.standbyTimerProcessors {
+		processors = append(processors, standbyTimerProcessor)
+	}
+	return reprocessQueueTask(ctx, task, processors, t.logger)
+}
+
 func (t *timerQueueProcessorImpl) completeTimersLoop() {
 	timer := time.NewTimer(t.config.TimerProcessorCompleteTimerInterval())
 	defer timer.Stop()
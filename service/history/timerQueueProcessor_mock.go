@@ -29,7 +29,9 @@
 package history
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
+	persistenceblobs "github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	persistence "github.com/temporalio/temporal/common/persistence"
 	reflect "reflect"
 )
@@ -128,3 +130,17 @@ func (mr *MocktimerQueueProcessorMockRecorder) UnlockTaskProcessing() *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockTaskProcessing", reflect.TypeOf((*MocktimerQueueProcessor)(nil).UnlockTaskProcessing))
 }
+
+// ReprocessTask mocks base method.
+func (m *MocktimerQueueProcessor) ReprocessTask(ctx context.Context, task *persistenceblobs.TimerTaskInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReprocessTask", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReprocessTask indicates an expected call of ReprocessTask.
+func (mr *MocktimerQueueProcessorMockRecorder) ReprocessTask(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReprocessTask", reflect.TypeOf((*MocktimerQueueProcessor)(nil).ReprocessTask), ctx, task)
+}
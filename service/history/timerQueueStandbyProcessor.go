@@ -25,6 +25,7 @@
 package history
 
 import (
+	"context"
 	"time"
 
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
@@ -203,9 +204,10 @@ func (t *timerQueueStandbyProcessorImpl) complete(
 }
 
 func (t *timerQueueStandbyProcessorImpl) process(
+	ctx context.Context,
 	taskInfo *taskInfo,
 ) (int, error) {
 	// TODO: task metricScope should be determined when creating taskInfo
 	metricScope := getTimerTaskMetricScope(taskInfo.task.GetTaskType(), false)
-	return metricScope, t.taskExecutor.execute(taskInfo.task, taskInfo.shouldProcessTask)
+	return metricScope, t.taskExecutor.execute(ctx, taskInfo.task, taskInfo.shouldProcessTask)
 }
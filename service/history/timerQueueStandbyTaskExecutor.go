@@ -25,6 +25,7 @@
 package history
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -77,6 +78,7 @@ func newTimerQueueStandbyTaskExecutor(
 }
 
 func (t *timerQueueStandbyTaskExecutor) execute(
+	ctx context.Context,
 	taskInfo queueTaskInfo,
 	shouldProcessTask bool,
 ) error {
@@ -95,27 +97,28 @@ func (t *timerQueueStandbyTaskExecutor) execute(
 
 	switch timerTask.TaskType {
 	case commongenpb.TaskType_UserTimer:
-		return t.executeUserTimerTimeoutTask(timerTask)
+		return t.executeUserTimerTimeoutTask(ctx, timerTask)
 	case commongenpb.TaskType_ActivityTimeout:
-		return t.executeActivityTimeoutTask(timerTask)
+		return t.executeActivityTimeoutTask(ctx, timerTask)
 	case commongenpb.TaskType_DecisionTimeout:
-		return t.executeDecisionTimeoutTask(timerTask)
+		return t.executeDecisionTimeoutTask(ctx, timerTask)
 	case commongenpb.TaskType_WorkflowRunTimeout:
-		return t.executeWorkflowTimeoutTask(timerTask)
+		return t.executeWorkflowTimeoutTask(ctx, timerTask)
 	case commongenpb.TaskType_ActivityRetryTimer:
 		// retry backoff timer should not get created on passive cluster
 		// TODO: add error logs
 		return nil
 	case commongenpb.TaskType_WorkflowBackoffTimer:
-		return t.executeWorkflowBackoffTimerTask(timerTask)
+		return t.executeWorkflowBackoffTimerTask(ctx, timerTask)
 	case commongenpb.TaskType_DeleteHistoryEvent:
-		return t.executeDeleteHistoryEventTask(timerTask)
+		return t.executeDeleteHistoryEventTask(ctx, timerTask)
 	default:
 		return errUnknownTimerTask
 	}
 }
 
 func (t *timerQueueStandbyTaskExecutor) executeUserTimerTimeoutTask(
+	ctx context.Context,
 	timerTask *persistenceblobs.TimerTaskInfo,
 ) error {
 
@@ -148,6 +151,7 @@ func (t *timerQueueStandbyTaskExecutor) executeUserTimerTimeoutTask(
 	}
 
 	return t.processTimer(
+		ctx,
 		timerTask,
 		actionFn,
 		getStandbyPostActionFn(
@@ -162,6 +166,7 @@ func (t *timerQueueStandbyTaskExecutor) executeUserTimerTimeoutTask(
 }
 
 func (t *timerQueueStandbyTaskExecutor) executeActivityTimeoutTask(
+	ctx context.Context,
 	timerTask *persistenceblobs.TimerTaskInfo,
 ) error {
 
@@ -252,6 +257,7 @@ func (t *timerQueueStandbyTaskExecutor) executeActivityTimeoutTask(
 	}
 
 	return t.processTimer(
+		ctx,
 		timerTask,
 		actionFn,
 		getStandbyPostActionFn(
@@ -266,6 +272,7 @@ func (t *timerQueueStandbyTaskExecutor) executeActivityTimeoutTask(
 }
 
 func (t *timerQueueStandbyTaskExecutor) executeDecisionTimeoutTask(
+	ctx context.Context,
 	timerTask *persistenceblobs.TimerTaskInfo,
 ) error {
 
@@ -292,6 +299,7 @@ func (t *timerQueueStandbyTaskExecutor) executeDecisionTimeoutTask(
 	}
 
 	return t.processTimer(
+		ctx,
 		timerTask,
 		actionFn,
 		getStandbyPostActionFn(
@@ -306,6 +314,7 @@ func (t *timerQueueStandbyTaskExecutor) executeDecisionTimeoutTask(
 }
 
 func (t *timerQueueStandbyTaskExecutor) executeWorkflowBackoffTimerTask(
+	ctx context.Context,
 	timerTask *persistenceblobs.TimerTaskInfo,
 ) error {
 
@@ -331,6 +340,7 @@ func (t *timerQueueStandbyTaskExecutor) executeWorkflowBackoffTimerTask(
 	}
 
 	return t.processTimer(
+		ctx,
 		timerTask,
 		actionFn,
 		getStandbyPostActionFn(
@@ -345,6 +355,7 @@ func (t *timerQueueStandbyTaskExecutor) executeWorkflowBackoffTimerTask(
 }
 
 func (t *timerQueueStandbyTaskExecutor) executeWorkflowTimeoutTask(
+	ctx context.Context,
 	timerTask *persistenceblobs.TimerTaskInfo,
 ) error {
 
@@ -366,6 +377,7 @@ func (t *timerQueueStandbyTaskExecutor) executeWorkflowTimeoutTask(
 	}
 
 	return t.processTimer(
+		ctx,
 		timerTask,
 		actionFn,
 		getStandbyPostActionFn(
@@ -396,14 +408,13 @@ func (t *timerQueueStandbyTaskExecutor) getTimerSequence(
 }
 
 func (t *timerQueueStandbyTaskExecutor) processTimer(
+	ctx context.Context,
 	timerTask *persistenceblobs.TimerTaskInfo,
 	actionFn standbyActionFn,
 	postActionFn standbyPostActionFn,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(timerTask),
-	)
+	context, release, err := t.getOrCreateWorkflowExecution(ctx, timerTask)
 	if err != nil {
 		return err
 	}
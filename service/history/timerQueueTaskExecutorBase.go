@@ -70,10 +70,11 @@ func newTimerQueueTaskExecutorBase(
 }
 
 func (t *timerQueueTaskExecutorBase) executeDeleteHistoryEventTask(
+	ctx context.Context,
 	task *persistenceblobs.TimerTaskInfo,
 ) (retError error) {
 
-	weContext, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(t.getNamespaceIDAndWorkflowExecution(task))
+	weContext, release, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
@@ -111,13 +112,14 @@ func (t *timerQueueTaskExecutorBase) executeDeleteHistoryEventTask(
 	}
 
 	t.metricsClient.IncCounter(metrics.HistoryProcessDeleteHistoryEventScope, metrics.WorkflowCleanupDeleteCount)
-	return t.deleteWorkflow(task, weContext, mutableState)
+	return t.deleteWorkflow(task, weContext, mutableState, namespaceCacheEntry)
 }
 
 func (t *timerQueueTaskExecutorBase) deleteWorkflow(
 	task *persistenceblobs.TimerTaskInfo,
 	context workflowExecutionContext,
 	msBuilder mutableState,
+	namespaceCacheEntry *cache.NamespaceCacheEntry,
 ) error {
 
 	if err := t.deleteCurrentWorkflowExecution(task); err != nil {
@@ -135,6 +137,10 @@ func (t *timerQueueTaskExecutorBase) deleteWorkflow(
 	if err := t.deleteWorkflowVisibility(task); err != nil {
 		return err
 	}
+
+	if err := t.deleteWorkflowOffloadedPayloads(task, namespaceCacheEntry); err != nil {
+		return err
+	}
 	// calling clear here to force accesses of mutable state to read database
 	// if this is not called then callers will get mutable state even though its been removed from database
 	context.clear()
@@ -196,6 +202,9 @@ func (t *timerQueueTaskExecutorBase) archiveWorkflow(
 		if err := t.deleteWorkflowHistory(task, msBuilder); err != nil {
 			return err
 		}
+		if err := t.deleteWorkflowOffloadedPayloads(task, namespaceCacheEntry); err != nil {
+			return err
+		}
 	}
 	// delete visibility record here regardless if it's been archived inline or not
 	// since the entire record is included as part of the archive request.
@@ -272,6 +281,34 @@ func (t *timerQueueTaskExecutorBase) deleteWorkflowVisibility(
 	return backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError)
 }
 
+// deleteWorkflowOffloadedPayloads removes the payloads offloaded under the namespace history archival URI,
+// archived history contains the rehydrated payloads so the blobs are not needed once history is deleted
+func (t *timerQueueTaskExecutorBase) deleteWorkflowOffloadedPayloads(
+	task *persistenceblobs.TimerTaskInfo,
+	namespaceCacheEntry *cache.NamespaceCacheEntry,
+) error {
+
+	ctx, cancel := context.WithTimeout(context.Background(), t.config.TimerProcessorArchivalTimeLimit())
+	defer cancel()
+	return t.shard.GetService().GetPayloadOffloader().DeleteExecutionBlobs(
+		ctx,
+		namespaceCacheEntry.GetConfig().HistoryArchivalURI,
+		task.GetNamespaceId(),
+		task.GetRunId(),
+	)
+}
+
+// getOrCreateWorkflowExecution locks the workflow of the task with the context of the
+// task, so the persistence calls made for it are traced under the span of the task
+func (t *timerQueueTaskExecutorBase) getOrCreateWorkflowExecution(
+	ctx context.Context,
+	task *persistenceblobs.TimerTaskInfo,
+) (workflowExecutionContext, releaseWorkflowExecutionFunc, error) {
+
+	namespaceID, execution := t.getNamespaceIDAndWorkflowExecution(task)
+	return t.cache.getOrCreateWorkflowExecution(ctx, namespaceID, execution)
+}
+
 func (t *timerQueueTaskExecutorBase) getNamespaceIDAndWorkflowExecution(
 	task *persistenceblobs.TimerTaskInfo,
 ) (string, commonpb.WorkflowExecution) {
@@ -144,7 +144,7 @@ func (s *timerQueueTaskExecutorBaseSuite) TestDeleteWorkflow_NoErr() {
 	s.mockMutableState.EXPECT().GetCurrentBranchToken().Return([]byte{1, 2, 3}, nil).Times(1)
 	s.mockMutableState.EXPECT().GetLastWriteVersion().Return(int64(1234), nil).AnyTimes()
 
-	err := s.timerQueueTaskExecutorBase.deleteWorkflow(task, ctx, s.mockMutableState)
+	err := s.timerQueueTaskExecutorBase.deleteWorkflow(task, ctx, s.mockMutableState, testGlobalNamespaceEntry)
 	s.NoError(err)
 }
 
@@ -173,6 +173,38 @@ func (s *timerQueueTaskExecutorBaseSuite) TestArchiveHistory_NoErr_InlineArchiva
 	s.NoError(err)
 }
 
+func (s *timerQueueTaskExecutorBaseSuite) TestArchiveHistory_InlineArchivalSucceeded_DeletePayloadsErr() {
+	s.mockWorkflowExecutionContext.EXPECT().loadExecutionStats().Return(&persistence.ExecutionStats{
+		HistorySize: 1024,
+	}, nil).Times(1)
+
+	s.mockMutableState.EXPECT().GetCurrentBranchToken().Return([]byte{1, 2, 3}, nil).Times(2)
+	s.mockMutableState.EXPECT().GetLastWriteVersion().Return(int64(1234), nil).Times(1)
+	s.mockMutableState.EXPECT().GetNextEventID().Return(int64(101)).Times(1)
+
+	s.mockExecutionManager.On("DeleteCurrentWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockExecutionManager.On("DeleteWorkflowExecution", mock.Anything).Return(nil).Once()
+	s.mockHistoryV2Manager.On("DeleteHistoryBranch", mock.Anything).Return(nil).Once()
+	s.mockShard.resource.ArchiverProvider.On("GetBlobStore", "testscheme").Return(nil, errors.New("failed to get blob store")).Once()
+
+	s.mockArchivalClient.On("Archive", mock.Anything, mock.MatchedBy(func(req *archiver.ClientRequest) bool {
+		return req.CallerService == common.HistoryServiceName && req.AttemptArchiveInline && req.ArchiveRequest.Targets[0] == archiver.ArchiveTargetHistory
+	})).Return(&archiver.ClientResponse{
+		HistoryArchivedInline: true,
+	}, nil)
+
+	namespaceCacheEntry := cache.NewNamespaceCacheEntryForTest(
+		&persistenceblobs.NamespaceInfo{},
+		&persistenceblobs.NamespaceConfig{HistoryArchivalURI: "testScheme://history/archival"},
+		false,
+		nil,
+		0,
+		nil,
+	)
+	err := s.timerQueueTaskExecutorBase.archiveWorkflow(&persistenceblobs.TimerTaskInfo{}, s.mockWorkflowExecutionContext, s.mockMutableState, namespaceCacheEntry)
+	s.Error(err)
+}
+
 func (s *timerQueueTaskExecutorBaseSuite) TestArchiveHistory_SendSignalErr() {
 	s.mockWorkflowExecutionContext.EXPECT().loadExecutionStats().Return(&persistence.ExecutionStats{
 		HistorySize: 1024 * 1024 * 1024,
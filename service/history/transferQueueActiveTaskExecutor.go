@@ -27,7 +27,10 @@ package history
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sync"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/pborman/uuid"
 	"go.temporal.io/temporal-proto/serviceerror"
 	"go.temporal.io/temporal-proto/workflowservice"
@@ -44,6 +47,7 @@ import (
 	"github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/backoff"
+	"github.com/temporalio/temporal/common/blobstore"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
 	"github.com/temporalio/temporal/common/metrics"
@@ -57,6 +61,7 @@ type (
 
 		historyClient           history.Client
 		parentClosePolicyClient parentclosepolicy.Client
+		callbackClient          *http.Client
 	}
 )
 
@@ -82,10 +87,17 @@ func newTransferQueueActiveTaskExecutor(
 			historyService.publicClient,
 			config.NumParentClosePolicySystemWorkflows(),
 		),
+		callbackClient: &http.Client{
+			// callbacks are only delivered to allowed hosts, do not follow redirects elsewhere
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
 	}
 }
 
 func (t *transferQueueActiveTaskExecutor) execute(
+	ctx context.Context,
 	taskInfo queueTaskInfo,
 	shouldProcessTask bool,
 ) error {
@@ -101,35 +113,36 @@ func (t *transferQueueActiveTaskExecutor) execute(
 
 	switch task.TaskType {
 	case commongenpb.TaskType_TransferActivityTask:
-		return t.processActivityTask(task)
+		return t.processActivityTask(ctx, task)
 	case commongenpb.TaskType_TransferDecisionTask:
-		return t.processDecisionTask(task)
+		return t.processDecisionTask(ctx, task)
 	case commongenpb.TaskType_TransferCloseExecution:
-		return t.processCloseExecution(task)
+		return t.processCloseExecution(ctx, task)
 	case commongenpb.TaskType_TransferCancelExecution:
-		return t.processCancelExecution(task)
+		return t.processCancelExecution(ctx, task)
 	case commongenpb.TaskType_TransferSignalExecution:
-		return t.processSignalExecution(task)
+		return t.processSignalExecution(ctx, task)
 	case commongenpb.TaskType_TransferStartChildExecution:
-		return t.processStartChildExecution(task)
+		return t.processStartChildExecution(ctx, task)
 	case commongenpb.TaskType_TransferRecordWorkflowStarted:
-		return t.processRecordWorkflowStarted(task)
+		return t.processRecordWorkflowStarted(ctx, task)
 	case commongenpb.TaskType_TransferResetWorkflow:
-		return t.processResetWorkflow(task)
+		return t.processResetWorkflow(ctx, task)
 	case commongenpb.TaskType_TransferUpsertWorkflowSearchAttributes:
-		return t.processUpsertWorkflowSearchAttributes(task)
+		return t.processUpsertWorkflowSearchAttributes(ctx, task)
+	case commongenpb.TaskType_TransferWorkflowCompletionCallback:
+		return t.processWorkflowCompletionCallback(ctx, task)
 	default:
 		return errUnknownTransferTask
 	}
 }
 
 func (t *transferQueueActiveTaskExecutor) processActivityTask(
+	ctx context.Context,
 	task *persistenceblobs.TransferTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(task),
-	)
+	context, release, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
@@ -161,12 +174,11 @@ func (t *transferQueueActiveTaskExecutor) processActivityTask(
 }
 
 func (t *transferQueueActiveTaskExecutor) processDecisionTask(
+	ctx context.Context,
 	task *persistenceblobs.TransferTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(task),
-	)
+	context, release, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
@@ -216,12 +228,11 @@ func (t *transferQueueActiveTaskExecutor) processDecisionTask(
 }
 
 func (t *transferQueueActiveTaskExecutor) processCloseExecution(
+	ctx context.Context,
 	task *persistenceblobs.TransferTaskInfo,
 ) (retError error) {
 
-	weContext, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(task),
-	)
+	weContext, release, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
@@ -297,7 +308,7 @@ func (t *transferQueueActiveTaskExecutor) processCloseExecution(
 
 	// Communicate the result to parent execution if this is Child Workflow execution
 	if replyToParentWorkflow {
-		ctx, cancel := context.WithTimeout(context.Background(), transferActiveTaskDefaultTimeout)
+		ctx, cancel := context.WithTimeout(ctx, transferActiveTaskDefaultTimeout)
 		defer cancel()
 		_, err = t.historyClient.RecordChildExecutionCompleted(ctx, &historyservice.RecordChildExecutionCompletedRequest{
 			NamespaceId: parentNamespaceID,
@@ -326,13 +337,236 @@ func (t *transferQueueActiveTaskExecutor) processCloseExecution(
 	return t.processParentClosePolicy(task.GetNamespaceId(), namespace, children)
 }
 
-func (t *transferQueueActiveTaskExecutor) processCancelExecution(
+func (t *transferQueueActiveTaskExecutor) processWorkflowCompletionCallback(
+	ctx context.Context,
+	task *persistenceblobs.TransferTaskInfo,
+) (retError error) {
+
+	weContext, release, err := t.getOrCreateWorkflowExecution(ctx, task)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := loadMutableStateForTransferTask(weContext, task, t.metricsClient, t.logger)
+	if err != nil {
+		return err
+	}
+	if mutableState == nil || mutableState.IsWorkflowExecutionRunning() {
+		return nil
+	}
+
+	lastWriteVersion, err := mutableState.GetLastWriteVersion()
+	if err != nil {
+		return err
+	}
+	ok, err := verifyTaskVersion(t.shard, t.logger, task.GetNamespaceId(), lastWriteVersion, task.Version, task)
+	if err != nil || !ok {
+		return err
+	}
+
+	executionInfo := mutableState.GetExecutionInfo()
+	// callbacks are keyed by their index, the same URL can be registered more than once.
+	// Dead lettered callbacks are attempted again when the task is merged back from DLQ.
+	// Callbacks backing off from a failed attempt are left for a later run of the task.
+	now := t.shard.GetTimeSource().Now().UnixNano()
+	backingOff := false
+	callbacks := make(map[int]*executiongenpb.CompletionCallbackInfo)
+	for i, callback := range executionInfo.CompletionCallbacks {
+		switch {
+		case callback.GetState() == executiongenpb.CompletionCallbackState_CompletionCallbackState_Succeeded:
+			// already delivered
+		case callback.GetState() == executiongenpb.CompletionCallbackState_CompletionCallbackState_Scheduled &&
+			callback.GetNextAttemptTimestamp() > now:
+			backingOff = true
+		default:
+			callbacks[i] = proto.Clone(callback).(*executiongenpb.CompletionCallbackInfo)
+		}
+	}
+	if len(callbacks) == 0 {
+		if backingOff {
+			return errCompletionCallbackNotDelivered
+		}
+		return nil
+	}
+
+	completionEvent, err := mutableState.GetCompletionEvent()
+	if err != nil {
+		return err
+	}
+	// the completion event is cached, rehydrate a copy of it
+	completionEvent = proto.Clone(completionEvent).(*eventpb.HistoryEvent)
+	namespace := mutableState.GetNamespaceEntry().GetInfo().Name
+	workflowStatus := executionInfo.Status
+
+	// release the context lock since we no longer need mutable state builder and
+	// the rest of logic is making HTTP calls, which takes time.
+	release(nil)
+
+	rehydrateCtx, cancel := context.WithTimeout(ctx, transferActiveTaskDefaultTimeout)
+	defer cancel()
+	if err := t.rehydratePayloads(rehydrateCtx, task, completionEvent); err != nil {
+		return err
+	}
+	body, err := newCompletionCallbackBody(
+		namespace,
+		task.GetWorkflowId(),
+		task.GetRunId(),
+		workflowStatus,
+		completionEvent,
+	)
+	if err != nil {
+		return err
+	}
+
+	allowedHosts := t.config.CompletionCallbackAllowedHosts(namespace)
+	var wg sync.WaitGroup
+	for _, callback := range callbacks {
+		wg.Add(1)
+		go func(callback *executiongenpb.CompletionCallbackInfo) {
+			defer wg.Done()
+			t.deliverCompletionCallback(task, callback, allowedHosts, body)
+		}(callback)
+	}
+	wg.Wait()
+
+	if err := t.recordCompletionCallbacks(ctx, task, callbacks); err != nil {
+		return err
+	}
+
+	if backingOff {
+		return errCompletionCallbackNotDelivered
+	}
+	deadLetter := false
+	for _, callback := range callbacks {
+		switch callback.GetState() {
+		case executiongenpb.CompletionCallbackState_CompletionCallbackState_Scheduled:
+			// the task is retried until the callback is due for its next attempt
+			return errCompletionCallbackNotDelivered
+		case executiongenpb.CompletionCallbackState_CompletionCallbackState_Failed:
+			deadLetter = true
+		}
+	}
+	if deadLetter {
+		return errCompletionCallbackDeadLetter
+	}
+	return nil
+}
+
+// deliverCompletionCallback makes a single attempt to post the body to the callback URL, the callback is
+// updated with the result of the attempt and the time of its next attempt. Callbacks which cannot be
+// delivered are moved to the failed state.
+func (t *transferQueueActiveTaskExecutor) deliverCompletionCallback(
+	task *persistenceblobs.TransferTaskInfo,
+	callback *executiongenpb.CompletionCallbackInfo,
+	allowedHosts string,
+	body []byte,
+) {
+
+	scope := t.metricsClient.Scope(metrics.TransferActiveTaskWorkflowCompletionCallbackScope)
+
+	now := t.shard.GetTimeSource().Now()
+	callback.Attempt++
+	callback.LastAttemptTimestamp = now.UnixNano()
+	callback.NextAttemptTimestamp = 0
+	var err error
+	if common.IsCompletionCallbackURLAllowed(callback.GetUrl(), allowedHosts) {
+		ctx, cancel := context.WithTimeout(context.Background(), t.config.CompletionCallbackRequestTimeout())
+		err = postCompletionCallback(ctx, t.callbackClient, callback.GetUrl(), body)
+		cancel()
+	} else {
+		err = errCompletionCallbackURLNotAllowed
+	}
+
+	if err == nil {
+		callback.State = executiongenpb.CompletionCallbackState_CompletionCallbackState_Succeeded
+		callback.LastFailure = ""
+		scope.IncCounter(metrics.CompletionCallbackDeliverySuccessCount)
+		return
+	}
+
+	callback.LastFailure = err.Error()
+	scope.IncCounter(metrics.CompletionCallbackDeliveryFailureCount)
+	if callback.GetState() != executiongenpb.CompletionCallbackState_CompletionCallbackState_Scheduled {
+		return
+	}
+	if isCompletionCallbackRetryableError(err) {
+		retryPolicy := newCompletionCallbackRetryPolicy(t.config.CompletionCallbackMaxAttempts())
+		if delay := retryPolicy.ComputeNextDelay(0, int(callback.GetAttempt())); delay >= 0 {
+			callback.NextAttemptTimestamp = now.Add(delay).UnixNano()
+			return
+		}
+	}
+
+	callback.State = executiongenpb.CompletionCallbackState_CompletionCallbackState_Failed
+	scope.IncCounter(metrics.CompletionCallbackDeadLetterCount)
+	t.logger.Warn("Failed to deliver workflow completion callback, moving task to DLQ.",
+		tag.WorkflowNamespaceID(task.GetNamespaceId()),
+		tag.WorkflowID(task.GetWorkflowId()),
+		tag.WorkflowRunID(task.GetRunId()),
+		tag.Attempt(callback.GetAttempt()),
+		tag.Error(err),
+	)
+}
+
+func (t *transferQueueActiveTaskExecutor) recordCompletionCallbacks(
+	ctx context.Context,
 	task *persistenceblobs.TransferTaskInfo,
+	callbacks map[int]*executiongenpb.CompletionCallbackInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(task),
+	weContext, release, err := t.getOrCreateWorkflowExecution(ctx, task)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	mutableState, err := loadMutableStateForTransferTask(weContext, task, t.metricsClient, t.logger)
+	if err != nil {
+		return err
+	}
+	if mutableState == nil || mutableState.IsWorkflowExecutionRunning() {
+		return nil
+	}
+
+	executionInfo := mutableState.GetExecutionInfo()
+	for i, result := range callbacks {
+		if i < len(executionInfo.CompletionCallbacks) && executionInfo.CompletionCallbacks[i].GetUrl() == result.GetUrl() {
+			executionInfo.CompletionCallbacks[i] = result
+		}
+	}
+
+	// the workflow is closed, a newer run may have become the current one
+	updateMode := persistence.UpdateWorkflowModeUpdateCurrent
+	if !mutableState.IsCurrentWorkflowGuaranteed() {
+		resp, err := t.shard.GetExecutionManager().GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+			NamespaceID: executionInfo.NamespaceID,
+			WorkflowID:  executionInfo.WorkflowID,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.RunID != executionInfo.RunID {
+			updateMode = persistence.UpdateWorkflowModeBypassCurrent
+		}
+	}
+
+	return weContext.updateWorkflowExecutionWithNew(
+		t.shard.GetTimeSource().Now(),
+		updateMode,
+		nil,
+		nil,
+		transactionPolicyActive,
+		nil,
 	)
+}
+
+func (t *transferQueueActiveTaskExecutor) processCancelExecution(
+	ctx context.Context,
+	task *persistenceblobs.TransferTaskInfo,
+) (retError error) {
+
+	context, release, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
@@ -411,12 +645,11 @@ func (t *transferQueueActiveTaskExecutor) processCancelExecution(
 }
 
 func (t *transferQueueActiveTaskExecutor) processSignalExecution(
+	ctx context.Context,
 	task *persistenceblobs.TransferTaskInfo,
 ) (retError error) {
 
-	weContext, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(task),
-	)
+	weContext, release, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
@@ -506,7 +739,7 @@ func (t *transferQueueActiveTaskExecutor) processSignalExecution(
 	// the rest of logic is making RPC call, which takes time.
 	release(retError)
 	// remove signalRequestedID from target workflow, after Signal detail is removed from source workflow
-	ctx, cancel := context.WithTimeout(context.Background(), transferActiveTaskDefaultTimeout)
+	ctx, cancel := context.WithTimeout(ctx, transferActiveTaskDefaultTimeout)
 	defer cancel()
 	_, err = t.historyClient.RemoveSignalMutableState(ctx, &historyservice.RemoveSignalMutableStateRequest{
 		NamespaceId: task.GetTargetNamespaceId(),
@@ -520,12 +753,11 @@ func (t *transferQueueActiveTaskExecutor) processSignalExecution(
 }
 
 func (t *transferQueueActiveTaskExecutor) processStartChildExecution(
+	ctx context.Context,
 	task *persistenceblobs.TransferTaskInfo,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(task),
-	)
+	context, release, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
@@ -624,27 +856,28 @@ func (t *transferQueueActiveTaskExecutor) processStartChildExecution(
 }
 
 func (t *transferQueueActiveTaskExecutor) processRecordWorkflowStarted(
+	ctx context.Context,
 	task *persistenceblobs.TransferTaskInfo,
 ) (retError error) {
 
-	return t.processRecordWorkflowStartedOrUpsertHelper(task, true)
+	return t.processRecordWorkflowStartedOrUpsertHelper(ctx, task, true)
 }
 
 func (t *transferQueueActiveTaskExecutor) processUpsertWorkflowSearchAttributes(
+	ctx context.Context,
 	task *persistenceblobs.TransferTaskInfo,
 ) (retError error) {
 
-	return t.processRecordWorkflowStartedOrUpsertHelper(task, false)
+	return t.processRecordWorkflowStartedOrUpsertHelper(ctx, task, false)
 }
 
 func (t *transferQueueActiveTaskExecutor) processRecordWorkflowStartedOrUpsertHelper(
+	ctx context.Context,
 	task *persistenceblobs.TransferTaskInfo,
 	recordStart bool,
 ) (retError error) {
 
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(task),
-	)
+	context, release, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
@@ -718,12 +951,11 @@ func (t *transferQueueActiveTaskExecutor) processRecordWorkflowStartedOrUpsertHe
 }
 
 func (t *transferQueueActiveTaskExecutor) processResetWorkflow(
+	ctx context.Context,
 	task *persistenceblobs.TransferTaskInfo,
 ) (retError error) {
 
-	currentContext, currentRelease, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(task),
-	)
+	currentContext, currentRelease, err := t.getOrCreateWorkflowExecution(ctx, task)
 	if err != nil {
 		return err
 	}
@@ -803,7 +1035,7 @@ func (t *transferQueueActiveTaskExecutor) processResetWorkflow(
 			WorkflowId: task.GetWorkflowId(),
 			RunId:      resetPoint.GetRunId(),
 		}
-		baseContext, baseRelease, err = t.cache.getOrCreateWorkflowExecutionForBackground(task.GetNamespaceId(), *baseExecution)
+		baseContext, baseRelease, err = t.cache.getOrCreateWorkflowExecution(ctx, task.GetNamespaceId(), *baseExecution)
 		if err != nil {
 			return err
 		}
@@ -818,8 +1050,8 @@ func (t *transferQueueActiveTaskExecutor) processResetWorkflow(
 	}
 
 	if err := t.resetWorkflow(
+		ctx,
 		task,
-		namespaceEntry.GetInfo().Name,
 		reason,
 		resetPoint,
 		baseContext,
@@ -1175,6 +1407,13 @@ func (t *transferQueueActiveTaskExecutor) signalExternalExecutionWithRetry(
 
 	ctx, cancel := context.WithTimeout(context.Background(), transferActiveTaskDefaultTimeout)
 	defer cancel()
+	// the signal info is cached in mutable state, rehydrate a copy of the signal request
+	if blobstore.HasBlobReference(request.SignalRequest) {
+		request.SignalRequest = proto.Clone(request.SignalRequest).(*workflowservice.SignalWorkflowExecutionRequest)
+		if err := t.rehydratePayloads(ctx, task, request.SignalRequest); err != nil {
+			return err
+		}
+	}
 	op := func() error {
 		_, err := t.historyClient.SignalWorkflowExecution(ctx, request)
 		return err
@@ -1183,6 +1422,26 @@ func (t *transferQueueActiveTaskExecutor) signalExternalExecutionWithRetry(
 	return backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError)
 }
 
+// rehydratePayloads replaces the blob references of the message with the payloads offloaded for the task execution
+func (t *transferQueueActiveTaskExecutor) rehydratePayloads(
+	ctx context.Context,
+	task *persistenceblobs.TransferTaskInfo,
+	message interface{},
+) error {
+
+	namespaceEntry, err := t.shard.GetNamespaceCache().GetNamespaceByID(task.GetNamespaceId())
+	if err != nil {
+		return err
+	}
+	return t.shard.GetService().GetPayloadOffloader().Rehydrate(
+		ctx,
+		namespaceEntry.GetConfig().GetHistoryArchivalURI(),
+		task.GetNamespaceId(),
+		task.GetRunId(),
+		message,
+	)
+}
+
 func (t *transferQueueActiveTaskExecutor) startWorkflowWithRetry(
 	task *persistenceblobs.TransferTaskInfo,
 	namespace string,
@@ -1230,6 +1489,14 @@ func (t *transferQueueActiveTaskExecutor) startWorkflowWithRetry(
 
 	ctx, cancel := context.WithTimeout(context.Background(), transferActiveTaskDefaultTimeout)
 	defer cancel()
+	// the child is retained independently of the parent, it must not reference the payloads offloaded for the parent,
+	// the initiated event is cached, rehydrate a copy of the start request
+	if blobstore.HasBlobReference(request.StartRequest) {
+		request.StartRequest = proto.Clone(request.StartRequest).(*workflowservice.StartWorkflowExecutionRequest)
+		if err := t.rehydratePayloads(ctx, task, request.StartRequest); err != nil {
+			return "", err
+		}
+	}
 	var response *historyservice.StartWorkflowExecutionResponse
 	var err error
 	op := func() error {
@@ -1245,8 +1512,8 @@ func (t *transferQueueActiveTaskExecutor) startWorkflowWithRetry(
 }
 
 func (t *transferQueueActiveTaskExecutor) resetWorkflow(
+	ctx context.Context,
 	task *persistenceblobs.TransferTaskInfo,
-	namespace string,
 	reason string,
 	resetPoint *executionpb.ResetPointInfo,
 	baseContext workflowExecutionContext,
@@ -1256,72 +1523,59 @@ func (t *transferQueueActiveTaskExecutor) resetWorkflow(
 	logger log.Logger,
 ) error {
 
-	var err error
-	ctx, cancel := context.WithTimeout(context.Background(), transferActiveTaskDefaultTimeout)
+	ctx, cancel := context.WithTimeout(ctx, transferActiveTaskDefaultTimeout)
 	defer cancel()
 
 	namespaceID := task.GetNamespaceId()
 	workflowID := task.GetWorkflowId()
 	baseRunID := baseMutableState.GetExecutionInfo().RunID
 
-	// TODO when NDC is rolled out, remove this block
-	if baseMutableState.GetVersionHistories() == nil {
-		_, err = t.historyService.resetor.ResetWorkflowExecution(
+	resetRunID := uuid.New()
+	baseRebuildLastEventID := resetPoint.GetFirstDecisionCompletedId() - 1
+	baseVersionHistories, err := t.historyService.workflowResetter.backfillVersionHistories(
+		baseContext,
+		baseMutableState,
+		baseRunID == currentMutableState.GetExecutionInfo().RunID,
+	)
+	if err != nil {
+		return err
+	}
+	baseCurrentVersionHistory, err := baseVersionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		return err
+	}
+	baseRebuildLastEventVersion, err := baseCurrentVersionHistory.GetEventVersion(baseRebuildLastEventID)
+	if err != nil {
+		return err
+	}
+	baseCurrentBranchToken := baseCurrentVersionHistory.GetBranchToken()
+	baseNextEventID := baseMutableState.GetNextEventID()
+
+	err = t.historyService.workflowResetter.resetWorkflow(
+		ctx,
+		namespaceID,
+		workflowID,
+		baseRunID,
+		baseCurrentBranchToken,
+		baseRebuildLastEventID,
+		baseRebuildLastEventVersion,
+		baseNextEventID,
+		baseMutableState.GetReplicationState(),
+		baseMutableState.GetExecutionInfo().CompletionCallbacks,
+		resetRunID,
+		uuid.New(),
+		newNDCWorkflow(
 			ctx,
-			&workflowservice.ResetWorkflowExecutionRequest{
-				Namespace: namespace,
-				WorkflowExecution: &commonpb.WorkflowExecution{
-					WorkflowId: workflowID,
-					RunId:      baseRunID,
-				},
-				Reason:                fmt.Sprintf("auto-reset reason:%v, binaryChecksum:%v ", reason, resetPoint.GetBinaryChecksum()),
-				DecisionFinishEventId: resetPoint.GetFirstDecisionCompletedId(),
-				RequestId:             uuid.New(),
-			},
-			baseContext,
-			baseMutableState,
+			t.shard.GetNamespaceCache(),
+			t.shard.GetClusterMetadata(),
 			currentContext,
 			currentMutableState,
-		)
-	} else {
-		resetRunID := uuid.New()
-		baseRunID := baseMutableState.GetExecutionInfo().RunID
-		baseRebuildLastEventID := resetPoint.GetFirstDecisionCompletedId() - 1
-		baseVersionHistories := baseMutableState.GetVersionHistories()
-		baseCurrentVersionHistory, err := baseVersionHistories.GetCurrentVersionHistory()
-		if err != nil {
-			return err
-		}
-		baseRebuildLastEventVersion, err := baseCurrentVersionHistory.GetEventVersion(baseRebuildLastEventID)
-		if err != nil {
-			return err
-		}
-		baseCurrentBranchToken := baseCurrentVersionHistory.GetBranchToken()
-		baseNextEventID := baseMutableState.GetNextEventID()
-
-		err = t.historyService.workflowResetter.resetWorkflow(
-			ctx,
-			namespaceID,
-			workflowID,
-			baseRunID,
-			baseCurrentBranchToken,
-			baseRebuildLastEventID,
-			baseRebuildLastEventVersion,
-			baseNextEventID,
-			resetRunID,
-			uuid.New(),
-			newNDCWorkflow(
-				ctx,
-				t.shard.GetNamespaceCache(),
-				t.shard.GetClusterMetadata(),
-				currentContext,
-				currentMutableState,
-				noopReleaseFn, // this is fine since caller will defer on release
-			),
-			reason,
-			nil,
-		)
-	}
+			noopReleaseFn, // this is fine since caller will defer on release
+		),
+		reason,
+		nil,
+		nil,
+	)
 
 	switch err.(type) {
 	case nil:
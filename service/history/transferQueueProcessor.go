@@ -34,6 +34,7 @@ import (
 	"time"
 
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/client/history"
 	"github.com/temporalio/temporal/client/matching"
 	"github.com/temporalio/temporal/common"
@@ -55,6 +56,7 @@ type (
 		NotifyNewTask(clusterName string, transferTasks []persistence.Task)
 		LockTaskProcessing()
 		UnlockTaskPrrocessing()
+		ReprocessTask(ctx context.Context, task *persistenceblobs.TransferTaskInfo) error
 	}
 
 	taskFilter func(task queueTaskInfo) (bool, error)
@@ -277,6 +279,18 @@ func (t *transferQueueProcessorImpl) UnlockTaskPrrocessing() {
 	t.taskAllocator.unlock()
 }
 
+func (t *transferQueueProcessorImpl) ReprocessTask(
+	ctx context.Context,
+	task *persistenceblobs.TransferTaskInfo,
+) error {
+
+	processors := []taskExecutor{t.activeTaskProcessor}
+	for _, standbyTaskProcessor := range t.standbyTaskProcessors {
+		processors = append(processors, standbyTaskProcessor)
+	}
+	return reprocessQueueTask(ctx, task, processors, t.logger)
+}
+
 func (t *transferQueueProcessorImpl) completeTransferLoop() {
 	timer := time.NewTimer(t.config.TransferProcessorCompleteTransferInterval())
 	defer timer.Stop()
@@ -159,6 +159,11 @@ func getTransferTaskMetricsScope(
 			return metrics.TransferActiveTaskUpsertWorkflowSearchAttributesScope
 		}
 		return metrics.TransferStandbyTaskUpsertWorkflowSearchAttributesScope
+	case commongenpb.TaskType_TransferWorkflowCompletionCallback:
+		if isActive {
+			return metrics.TransferActiveTaskWorkflowCompletionCallbackScope
+		}
+		return metrics.TransferStandbyTaskWorkflowCompletionCallbackScope
 	default:
 		if isActive {
 			return metrics.TransferActiveQueueProcessorScope
@@ -29,7 +29,9 @@
 package history
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
+	persistenceblobs "github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	persistence "github.com/temporalio/temporal/common/persistence"
 	reflect "reflect"
 )
@@ -128,3 +130,17 @@ func (mr *MocktransferQueueProcessorMockRecorder) UnlockTaskPrrocessing() *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockTaskPrrocessing", reflect.TypeOf((*MocktransferQueueProcessor)(nil).UnlockTaskPrrocessing))
 }
+
+// ReprocessTask mocks base method.
+func (m *MocktransferQueueProcessor) ReprocessTask(ctx context.Context, task *persistenceblobs.TransferTaskInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReprocessTask", ctx, task)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReprocessTask indicates an expected call of ReprocessTask.
+func (mr *MocktransferQueueProcessorMockRecorder) ReprocessTask(ctx, task interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReprocessTask", reflect.TypeOf((*MocktransferQueueProcessor)(nil).ReprocessTask), ctx, task)
+}
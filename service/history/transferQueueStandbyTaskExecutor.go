@@ -25,12 +25,14 @@
 package history
 
 import (
+	"context"
 	"time"
 
 	"go.temporal.io/temporal-proto/serviceerror"
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 
 	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/log"
@@ -74,6 +76,7 @@ func newTransferQueueStandbyTaskExecutor(
 }
 
 func (t *transferQueueStandbyTaskExecutor) execute(
+	ctx context.Context,
 	taskInfo queueTaskInfo,
 	shouldProcessTask bool,
 ) error {
@@ -91,31 +94,34 @@ func (t *transferQueueStandbyTaskExecutor) execute(
 
 	switch transferTask.TaskType {
 	case commongenpb.TaskType_TransferActivityTask:
-		return t.processActivityTask(transferTask)
+		return t.processActivityTask(ctx, transferTask)
 	case commongenpb.TaskType_TransferDecisionTask:
-		return t.processDecisionTask(transferTask)
+		return t.processDecisionTask(ctx, transferTask)
 	case commongenpb.TaskType_TransferCloseExecution:
-		return t.processCloseExecution(transferTask)
+		return t.processCloseExecution(ctx, transferTask)
 	case commongenpb.TaskType_TransferCancelExecution:
-		return t.processCancelExecution(transferTask)
+		return t.processCancelExecution(ctx, transferTask)
 	case commongenpb.TaskType_TransferSignalExecution:
-		return t.processSignalExecution(transferTask)
+		return t.processSignalExecution(ctx, transferTask)
 	case commongenpb.TaskType_TransferStartChildExecution:
-		return t.processStartChildExecution(transferTask)
+		return t.processStartChildExecution(ctx, transferTask)
 	case commongenpb.TaskType_TransferRecordWorkflowStarted:
-		return t.processRecordWorkflowStarted(transferTask)
+		return t.processRecordWorkflowStarted(ctx, transferTask)
 	case commongenpb.TaskType_TransferResetWorkflow:
 		// no reset needed for standby
 		// TODO: add error logs
 		return nil
 	case commongenpb.TaskType_TransferUpsertWorkflowSearchAttributes:
-		return t.processUpsertWorkflowSearchAttributes(transferTask)
+		return t.processUpsertWorkflowSearchAttributes(ctx, transferTask)
+	case commongenpb.TaskType_TransferWorkflowCompletionCallback:
+		return t.processWorkflowCompletionCallback(ctx, transferTask)
 	default:
 		return errUnknownTransferTask
 	}
 }
 
 func (t *transferQueueStandbyTaskExecutor) processActivityTask(
+	ctx context.Context,
 	transferTask *persistenceblobs.TransferTaskInfo,
 ) error {
 
@@ -142,6 +148,7 @@ func (t *transferQueueStandbyTaskExecutor) processActivityTask(
 	}
 
 	return t.processTransfer(
+		ctx,
 		processTaskIfClosed,
 		transferTask,
 		actionFn,
@@ -157,6 +164,7 @@ func (t *transferQueueStandbyTaskExecutor) processActivityTask(
 }
 
 func (t *transferQueueStandbyTaskExecutor) processDecisionTask(
+	ctx context.Context,
 	transferTask *persistenceblobs.TransferTaskInfo,
 ) error {
 
@@ -188,6 +196,7 @@ func (t *transferQueueStandbyTaskExecutor) processDecisionTask(
 	}
 
 	return t.processTransfer(
+		ctx,
 		processTaskIfClosed,
 		transferTask,
 		actionFn,
@@ -203,6 +212,7 @@ func (t *transferQueueStandbyTaskExecutor) processDecisionTask(
 }
 
 func (t *transferQueueStandbyTaskExecutor) processCloseExecution(
+	ctx context.Context,
 	transferTask *persistenceblobs.TransferTaskInfo,
 ) error {
 
@@ -263,6 +273,7 @@ func (t *transferQueueStandbyTaskExecutor) processCloseExecution(
 	}
 
 	return t.processTransfer(
+		ctx,
 		processTaskIfClosed,
 		transferTask,
 		actionFn,
@@ -270,7 +281,59 @@ func (t *transferQueueStandbyTaskExecutor) processCloseExecution(
 	) // no op post action, since the entire workflow is finished
 }
 
+func (t *transferQueueStandbyTaskExecutor) processWorkflowCompletionCallback(
+	ctx context.Context,
+	transferTask *persistenceblobs.TransferTaskInfo,
+) error {
+
+	processTaskIfClosed := true
+	actionFn := func(context workflowExecutionContext, mutableState mutableState) (interface{}, error) {
+
+		if mutableState.IsWorkflowExecutionRunning() {
+			// this can happen if workflow is reset.
+			return nil, nil
+		}
+
+		lastWriteVersion, err := mutableState.GetLastWriteVersion()
+		if err != nil {
+			return nil, err
+		}
+		ok, err := verifyTaskVersion(t.shard, t.logger, transferTask.GetNamespaceId(), lastWriteVersion, transferTask.Version, transferTask)
+		if err != nil || !ok {
+			return nil, err
+		}
+
+		// callbacks are delivered by the active cluster, wait until the delivery is recorded
+		pendingCallbacks := 0
+		for _, callback := range mutableState.GetExecutionInfo().CompletionCallbacks {
+			if callback.GetState() == executiongenpb.CompletionCallbackState_CompletionCallbackState_Scheduled {
+				pendingCallbacks++
+			}
+		}
+		if pendingCallbacks == 0 {
+			return nil, nil
+		}
+		return newCompletionCallbackPendingInfo(pendingCallbacks), nil
+	}
+
+	return t.processTransfer(
+		ctx,
+		processTaskIfClosed,
+		transferTask,
+		actionFn,
+		getStandbyPostActionFn(
+			transferTask,
+			t.getCurrentTime,
+			t.config.StandbyTaskMissingEventsResendDelay(),
+			t.config.StandbyTaskMissingEventsDiscardDelay(),
+			standbyTaskPostActionNoOp,
+			standbyTransferTaskPostActionTaskDiscarded,
+		),
+	)
+}
+
 func (t *transferQueueStandbyTaskExecutor) processCancelExecution(
+	ctx context.Context,
 	transferTask *persistenceblobs.TransferTaskInfo,
 ) error {
 
@@ -291,6 +354,7 @@ func (t *transferQueueStandbyTaskExecutor) processCancelExecution(
 	}
 
 	return t.processTransfer(
+		ctx,
 		processTaskIfClosed,
 		transferTask,
 		actionFn,
@@ -306,6 +370,7 @@ func (t *transferQueueStandbyTaskExecutor) processCancelExecution(
 }
 
 func (t *transferQueueStandbyTaskExecutor) processSignalExecution(
+	ctx context.Context,
 	transferTask *persistenceblobs.TransferTaskInfo,
 ) error {
 
@@ -326,6 +391,7 @@ func (t *transferQueueStandbyTaskExecutor) processSignalExecution(
 	}
 
 	return t.processTransfer(
+		ctx,
 		processTaskIfClosed,
 		transferTask,
 		actionFn,
@@ -341,6 +407,7 @@ func (t *transferQueueStandbyTaskExecutor) processSignalExecution(
 }
 
 func (t *transferQueueStandbyTaskExecutor) processStartChildExecution(
+	ctx context.Context,
 	transferTask *persistenceblobs.TransferTaskInfo,
 ) error {
 
@@ -365,6 +432,7 @@ func (t *transferQueueStandbyTaskExecutor) processStartChildExecution(
 	}
 
 	return t.processTransfer(
+		ctx,
 		processTaskIfClosed,
 		transferTask,
 		actionFn,
@@ -380,11 +448,13 @@ func (t *transferQueueStandbyTaskExecutor) processStartChildExecution(
 }
 
 func (t *transferQueueStandbyTaskExecutor) processRecordWorkflowStarted(
+	ctx context.Context,
 	transferTask *persistenceblobs.TransferTaskInfo,
 ) error {
 
 	processTaskIfClosed := false
 	return t.processTransfer(
+		ctx,
 		processTaskIfClosed,
 		transferTask,
 		func(context workflowExecutionContext, mutableState mutableState) (interface{}, error) {
@@ -395,11 +465,13 @@ func (t *transferQueueStandbyTaskExecutor) processRecordWorkflowStarted(
 }
 
 func (t *transferQueueStandbyTaskExecutor) processUpsertWorkflowSearchAttributes(
+	ctx context.Context,
 	transferTask *persistenceblobs.TransferTaskInfo,
 ) error {
 
 	processTaskIfClosed := false
 	return t.processTransfer(
+		ctx,
 		processTaskIfClosed,
 		transferTask,
 		func(context workflowExecutionContext, mutableState mutableState) (interface{}, error) {
@@ -472,6 +544,7 @@ func (t *transferQueueStandbyTaskExecutor) processRecordWorkflowStartedOrUpsertH
 }
 
 func (t *transferQueueStandbyTaskExecutor) processTransfer(
+	ctx context.Context,
 	processTaskIfClosed bool,
 	taskInfo queueTaskInfo,
 	actionFn standbyActionFn,
@@ -479,9 +552,7 @@ func (t *transferQueueStandbyTaskExecutor) processTransfer(
 ) (retError error) {
 
 	transferTask := taskInfo.(*persistenceblobs.TransferTaskInfo)
-	context, release, err := t.cache.getOrCreateWorkflowExecutionForBackground(
-		t.getNamespaceIDAndWorkflowExecution(transferTask),
-	)
+	context, release, err := t.getOrCreateWorkflowExecution(ctx, transferTask)
 	if err != nil {
 		return err
 	}
@@ -25,6 +25,7 @@
 package history
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -229,7 +230,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessActivityTask_Pending(
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Equal(ErrTaskRetry, err)
 }
 
@@ -286,7 +287,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessActivityTask_Pending_
 	s.mockMatchingClient.EXPECT().AddActivityTask(gomock.Any(), gomock.Any()).Return(&matchingservice.AddActivityTaskResponse{}, nil).Times(1)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Nil(err)
 }
 
@@ -343,7 +344,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessActivityTask_Success(
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Nil(err)
 }
 
@@ -391,7 +392,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessDecisionTask_Pending(
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Equal(ErrTaskRetry, err)
 }
 
@@ -441,7 +442,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessDecisionTask_Pending_
 	s.mockMatchingClient.EXPECT().AddDecisionTask(gomock.Any(), gomock.Any()).Return(&matchingservice.AddDecisionTaskResponse{}, nil).Times(1)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Nil(err)
 }
 
@@ -492,7 +493,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessDecisionTask_Success_
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Nil(err)
 }
 
@@ -548,7 +549,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessDecisionTask_Success_
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Nil(err)
 }
 
@@ -603,7 +604,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessCloseExecution() {
 	s.mockArchivalMetadata.On("GetVisibilityConfig").Return(archiver.NewDisabledArchvialConfig())
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Nil(err)
 }
 
@@ -665,7 +666,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessCancelExecution_Pendi
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Equal(ErrTaskRetry, err)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC().Add(s.fetchHistoryDuration))
@@ -674,11 +675,11 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessCancelExecution_Pendi
 		transferTask.GetRunId(), nextEventID,
 		transferTask.GetRunId(), common.EndEventID,
 	).Return(nil).Once()
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Equal(ErrTaskRetry, err)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC().Add(s.discardDuration))
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Equal(ErrTaskDiscarded, err)
 }
 
@@ -741,7 +742,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessCancelExecution_Succe
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Nil(err)
 }
 
@@ -805,7 +806,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessSignalExecution_Pendi
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Equal(ErrTaskRetry, err)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC().Add(s.fetchHistoryDuration))
@@ -814,11 +815,11 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessSignalExecution_Pendi
 		transferTask.GetRunId(), nextEventID,
 		transferTask.GetRunId(), common.EndEventID,
 	).Return(nil).Once()
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Equal(ErrTaskRetry, err)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC().Add(s.discardDuration))
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Equal(ErrTaskDiscarded, err)
 }
 
@@ -883,7 +884,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessSignalExecution_Succe
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Nil(err)
 }
 
@@ -945,7 +946,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessStartChildExecution_P
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Equal(ErrTaskRetry, err)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC().Add(s.fetchHistoryDuration))
@@ -954,11 +955,11 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessStartChildExecution_P
 		transferTask.GetRunId(), nextEventID,
 		transferTask.GetRunId(), common.EndEventID,
 	).Return(nil).Once()
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Equal(ErrTaskRetry, err)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC().Add(s.discardDuration))
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Equal(ErrTaskDiscarded, err)
 }
 
@@ -1022,7 +1023,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessStartChildExecution_S
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Nil(err)
 }
 
@@ -1085,7 +1086,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessRecordWorkflowStarted
 	}).Return(nil).Once()
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Nil(err)
 }
 
@@ -1148,7 +1149,7 @@ func (s *transferQueueStandbyTaskExecutorSuite) TestProcessUpsertWorkflowSearchA
 	}).Return(nil).Once()
 
 	s.mockShard.SetCurrentTime(s.clusterName, time.Unix(now.Seconds, int64(now.Nanos)).UTC())
-	err = s.transferQueueStandbyTaskExecutor.execute(transferTask, true)
+	err = s.transferQueueStandbyTaskExecutor.execute(context.Background(), transferTask, true)
 	s.Nil(err)
 }
 
@@ -95,6 +95,17 @@ func (t *transferQueueTaskExecutorBase) getNamespaceIDAndWorkflowExecution(
 	}
 }
 
+// getOrCreateWorkflowExecution locks the workflow of the task with the context of the
+// task, so the persistence calls made for it are traced under the span of the task
+func (t *transferQueueTaskExecutorBase) getOrCreateWorkflowExecution(
+	ctx context.Context,
+	task *persistenceblobs.TransferTaskInfo,
+) (workflowExecutionContext, releaseWorkflowExecutionFunc, error) {
+
+	namespaceID, execution := t.getNamespaceIDAndWorkflowExecution(task)
+	return t.cache.getOrCreateWorkflowExecution(ctx, namespaceID, execution)
+}
+
 func (t *transferQueueTaskExecutorBase) pushActivity(
 	task *persistenceblobs.TransferTaskInfo,
 	activityScheduleToStartTimeout int32,
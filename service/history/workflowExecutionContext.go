@@ -49,6 +49,7 @@ import (
 	"github.com/temporalio/temporal/common/metrics"
 	"github.com/temporalio/temporal/common/persistence"
 	"github.com/temporalio/temporal/common/rpc"
+	"github.com/temporalio/temporal/common/tracing"
 )
 
 const (
@@ -126,21 +127,6 @@ type (
 			currentWorkflowTransactionPolicy transactionPolicy,
 			newWorkflowTransactionPolicy *transactionPolicy,
 		) error
-
-		resetWorkflowExecution(
-			currMutableState mutableState,
-			updateCurr bool,
-			closeTask persistence.Task,
-			cleanupTask persistence.Task,
-			newMutableState mutableState,
-			newHistorySize int64,
-			newTransferTasks []persistence.Task,
-			newTimerTasks []persistence.Task,
-			currentReplicationTasks []persistence.Task,
-			newReplicationTasks []persistence.Task,
-			baseRunID string,
-			baseRunNextEventID int64,
-		) (retError error)
 	}
 )
 
@@ -155,7 +141,11 @@ type (
 		metricsClient     metrics.Client
 		timeSource        clock.TimeSource
 
-		mutex           locks.Mutex
+		mutex locks.Mutex
+		// lockCtx is the context of the current lock holder, it bounds the calls
+		// to external stores made while persisting on behalf of the lock holder
+		lockCtx context.Context
+
 		mutableState    mutableState
 		stats           *persistence.ExecutionStats
 		updateCondition int64
@@ -192,13 +182,26 @@ func newWorkflowExecutionContext(
 }
 
 func (c *workflowExecutionContextImpl) lock(ctx context.Context) error {
-	return c.mutex.Lock(ctx)
+	if err := c.mutex.Lock(ctx); err != nil {
+		return err
+	}
+	c.lockCtx = ctx
+	return nil
 }
 
 func (c *workflowExecutionContextImpl) unlock() {
+	c.lockCtx = nil
 	c.mutex.Unlock()
 }
 
+func (c *workflowExecutionContextImpl) getLockContext() context.Context {
+	if c.lockCtx == nil {
+		// the context is not locked when a new workflow is persisted for the first time
+		return context.Background()
+	}
+	return c.lockCtx
+}
+
 func (c *workflowExecutionContextImpl) clear() {
 	c.metricsClient.IncCounter(metrics.WorkflowContextScope, metrics.WorkflowContextCleared)
 	c.mutableState = nil
@@ -252,6 +255,8 @@ func (c *workflowExecutionContextImpl) loadWorkflowExecutionForReplication(
 		response, err := c.getWorkflowExecutionWithRetry(&persistence.GetWorkflowExecutionRequest{
 			NamespaceID: c.namespaceID,
 			Execution:   c.workflowExecution,
+			CallerType:  persistence.GetCallerType(c.getLockContext()),
+			ParentSpan:  tracing.SpanFromContext(c.getLockContext()),
 		})
 		if err != nil {
 			return nil, err
@@ -325,6 +330,8 @@ func (c *workflowExecutionContextImpl) loadWorkflowExecution() (mutableState, er
 		response, err := c.getWorkflowExecutionWithRetry(&persistence.GetWorkflowExecutionRequest{
 			NamespaceID: c.namespaceID,
 			Execution:   c.workflowExecution,
+			CallerType:  persistence.GetCallerType(c.getLockContext()),
+			ParentSpan:  tracing.SpanFromContext(c.getLockContext()),
 		})
 		if err != nil {
 			return nil, err
@@ -398,6 +405,9 @@ func (c *workflowExecutionContextImpl) createWorkflowExecution(
 		PreviousLastWriteVersion: prevLastWriteVersion,
 
 		NewWorkflowSnapshot: *newWorkflow,
+
+		CallerType: persistence.GetCallerType(c.getLockContext()),
+		ParentSpan: tracing.SpanFromContext(c.getLockContext()),
 	}
 
 	historySize += c.getHistorySize()
@@ -539,6 +549,9 @@ func (c *workflowExecutionContextImpl) conflictResolveWorkflowExecution(
 
 		CurrentWorkflowCAS: workflowCAS,
 		// Encoding, this is set by shard context
+
+		CallerType: persistence.GetCallerType(c.getLockContext()),
+		ParentSpan: tracing.SpanFromContext(c.getLockContext()),
 	}); err != nil {
 		return err
 	}
@@ -733,6 +746,9 @@ func (c *workflowExecutionContextImpl) updateWorkflowExecutionWithNew(
 		UpdateWorkflowMutation: *currentWorkflow,
 		NewWorkflowSnapshot:    newWorkflow,
 		// Encoding, this is set by shard context
+
+		CallerType: persistence.GetCallerType(c.getLockContext()),
+		ParentSpan: tracing.SpanFromContext(c.getLockContext()),
 	})
 	if err != nil {
 		return err
@@ -922,7 +938,7 @@ func (c *workflowExecutionContextImpl) appendHistoryV2EventsWithRetry(
 	resp := 0
 	op := func() error {
 		var err error
-		resp, err = c.shard.AppendHistoryV2Events(request, namespaceID, execution)
+		resp, err = c.shard.AppendHistoryV2Events(c.getLockContext(), request, namespaceID, execution)
 		return err
 	}
 
@@ -1041,179 +1057,6 @@ func (c *workflowExecutionContextImpl) updateWorkflowExecutionWithRetry(
 	}
 }
 
-// TODO deprecate this API in favor of create / update workflow execution
-// this reset is more complex than "resetMutableState", it involes currentMutableState and newMutableState:
-// 1. append history to new run
-// 2. append history to current run if current run is not closed
-// 3. update mutableState(terminate current run if not closed) and create new run
-func (c *workflowExecutionContextImpl) resetWorkflowExecution(
-	currMutableState mutableState,
-	updateCurr bool,
-	closeTask persistence.Task,
-	cleanupTask persistence.Task,
-	newMutableState mutableState,
-	newHistorySize int64,
-	newTransferTasks []persistence.Task,
-	newTimerTasks []persistence.Task,
-	currReplicationTasks []persistence.Task,
-	newReplicationTasks []persistence.Task,
-	baseRunID string,
-	baseRunNextEventID int64,
-) (retError error) {
-
-	now := c.timeSource.Now()
-	currTransferTasks := []persistence.Task{}
-	currTimerTasks := []persistence.Task{}
-	if closeTask != nil {
-		currTransferTasks = append(currTransferTasks, closeTask)
-	}
-	if cleanupTask != nil {
-		currTimerTasks = append(currTimerTasks, cleanupTask)
-	}
-	setTaskInfo(currMutableState.GetCurrentVersion(), now, currTransferTasks, currTimerTasks)
-	setTaskInfo(newMutableState.GetCurrentVersion(), now, newTransferTasks, newTimerTasks)
-
-	// Since we always reset to decision task, there shouldn't be any buffered events.
-	// Therefore currently ResetWorkflowExecution persistence API doesn't implement setting buffered events.
-	if newMutableState.HasBufferedEvents() {
-		retError = serviceerror.NewInternal(fmt.Sprintf("reset workflow execution shouldn't have buffered events"))
-		return
-	}
-
-	// call FlushBufferedEvents to assign task id to event
-	// as well as update last event task id in ms state builder
-	retError = currMutableState.FlushBufferedEvents()
-	if retError != nil {
-		return retError
-	}
-	retError = newMutableState.FlushBufferedEvents()
-	if retError != nil {
-		return retError
-	}
-
-	if updateCurr {
-		hBuilder := currMutableState.GetHistoryBuilder()
-		var size int64
-		// TODO workflow execution reset logic generates replication tasks in its own business logic
-		currentExecutionInfo := currMutableState.GetExecutionInfo()
-		currentBranchToken, err := currMutableState.GetCurrentBranchToken()
-		if err != nil {
-			return err
-		}
-		size, retError = c.persistNonFirstWorkflowEvents(&persistence.WorkflowEvents{
-			NamespaceID: currentExecutionInfo.NamespaceID,
-			WorkflowID:  currentExecutionInfo.WorkflowID,
-			RunID:       currentExecutionInfo.RunID,
-			BranchToken: currentBranchToken,
-			Events:      hBuilder.GetHistory().GetEvents(),
-		})
-		if retError != nil {
-			return
-		}
-		c.stats.HistorySize += int64(size)
-	}
-
-	// TODO refactor resetWorkflowExecution to use CloseTransactionAsSnapshot
-	//  and CloseTransactionAsMutation correctly
-	resetWorkflow, workflowEventsSeq, err := newMutableState.CloseTransactionAsSnapshot(
-		c.shard.GetTimeSource().Now(),
-		// the reason to use passive policy is because this resetWorkflowExecution function
-		// mostly handcraft all parameters to be persisted
-		transactionPolicyPassive,
-	)
-	if err != nil {
-		return err
-	}
-	if len(workflowEventsSeq) != 1 {
-		return serviceerror.NewInternal("reset workflow execution should generate exactly 1 event batch")
-	}
-	for _, workflowEvents := range workflowEventsSeq {
-		eventsSize, err := c.persistNonFirstWorkflowEvents(workflowEvents)
-		if err != nil {
-			return err
-		}
-		newHistorySize += eventsSize
-	}
-	resetWorkflow.ExecutionStats = &persistence.ExecutionStats{
-		HistorySize: newHistorySize,
-	}
-	resetWorkflow.TransferTasks = newTransferTasks
-	resetWorkflow.ReplicationTasks = newReplicationTasks
-	resetWorkflow.TimerTasks = newTimerTasks
-
-	if len(resetWorkflow.ChildExecutionInfos) > 0 ||
-		len(resetWorkflow.SignalInfos) > 0 ||
-		len(resetWorkflow.SignalRequestedIDs) > 0 {
-		return serviceerror.NewInternal(fmt.Sprintf("something went wrong, we shouldn't see any pending childWF, sending Signal or signal requested"))
-	}
-
-	resetWFReq := &persistence.ResetWorkflowExecutionRequest{
-		BaseRunID:          baseRunID,
-		BaseRunNextEventID: baseRunNextEventID,
-
-		CurrentRunID:          currMutableState.GetExecutionInfo().RunID,
-		CurrentRunNextEventID: currMutableState.GetExecutionInfo().NextEventID,
-
-		CurrentWorkflowMutation: nil,
-
-		NewWorkflowSnapshot: *resetWorkflow,
-	}
-
-	if updateCurr {
-		resetWFReq.CurrentWorkflowMutation = &persistence.WorkflowMutation{
-			ExecutionInfo: currMutableState.GetExecutionInfo(),
-			ExecutionStats: &persistence.ExecutionStats{
-				HistorySize: c.stats.HistorySize,
-			},
-			ReplicationState: currMutableState.GetReplicationState(),
-			VersionHistories: currMutableState.GetVersionHistories(),
-
-			UpsertActivityInfos:       []*persistence.ActivityInfo{},
-			DeleteActivityInfos:       []int64{},
-			UpsertTimerInfos:          []*persistenceblobs.TimerInfo{},
-			DeleteTimerInfos:          []string{},
-			UpsertChildExecutionInfos: []*persistence.ChildExecutionInfo{},
-			DeleteChildExecutionInfo:  nil,
-			UpsertRequestCancelInfos:  []*persistenceblobs.RequestCancelInfo{},
-			DeleteRequestCancelInfo:   nil,
-			UpsertSignalInfos:         []*persistenceblobs.SignalInfo{},
-			DeleteSignalInfo:          nil,
-			UpsertSignalRequestedIDs:  []string{},
-			DeleteSignalRequestedID:   "",
-			NewBufferedEvents:         []*eventpb.HistoryEvent{},
-			ClearBufferedEvents:       false,
-
-			TransferTasks:    currTransferTasks,
-			ReplicationTasks: currReplicationTasks,
-			TimerTasks:       currTimerTasks,
-
-			Condition: c.updateCondition,
-		}
-	}
-
-	err = c.shard.ResetWorkflowExecution(resetWFReq)
-	if err != nil {
-		return err
-	}
-
-	// notify reset workflow tasks
-	c.notifyTasks(
-		resetWorkflow.TransferTasks,
-		resetWorkflow.ReplicationTasks,
-		resetWorkflow.TimerTasks,
-	)
-
-	// notify current workflow tasks
-	if resetWFReq.CurrentWorkflowMutation != nil {
-		c.notifyTasks(
-			resetWFReq.CurrentWorkflowMutation.TransferTasks,
-			resetWFReq.CurrentWorkflowMutation.ReplicationTasks,
-			resetWFReq.CurrentWorkflowMutation.TimerTasks,
-		)
-	}
-	return nil
-}
-
 func (c *workflowExecutionContextImpl) updateWorkflowExecutionEventReapply(
 	updateMode persistence.UpdateWorkflowMode,
 	eventBatch1 []*persistence.WorkflowEvents,
@@ -0,0 +1,295 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+
+	"github.com/pborman/uuid"
+	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	eventgenpb "github.com/temporalio/temporal/.gen/proto/event"
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/definition"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	workflowImporter interface {
+		// importWorkflow writes the exported history to a new history branch and recreates
+		// the workflow run on top of it, rebuilding mutable state from the events
+		importWorkflow(
+			ctx context.Context,
+			namespaceID string,
+			export *eventgenpb.WorkflowExecutionHistoryExport,
+		) error
+	}
+
+	workflowImporterImpl struct {
+		shard             ShardContext
+		executionMgr      persistence.ExecutionManager
+		newStateRebuilder nDCStateRebuilderProvider
+		newContext        func(namespaceID string, execution commonpb.WorkflowExecution) workflowExecutionContext
+		logger            log.Logger
+	}
+)
+
+var _ workflowImporter = (*workflowImporterImpl)(nil)
+
+func newWorkflowImporter(
+	shard ShardContext,
+	logger log.Logger,
+) *workflowImporterImpl {
+	return &workflowImporterImpl{
+		shard:        shard,
+		executionMgr: shard.GetExecutionManager(),
+		newStateRebuilder: func() nDCStateRebuilder {
+			return newNDCStateRebuilder(shard, logger)
+		},
+		newContext: func(namespaceID string, execution commonpb.WorkflowExecution) workflowExecutionContext {
+			return newWorkflowExecutionContext(namespaceID, execution, shard, shard.GetExecutionManager(), logger)
+		},
+		logger: logger,
+	}
+}
+
+func (r *workflowImporterImpl) importWorkflow(
+	ctx context.Context,
+	namespaceID string,
+	export *eventgenpb.WorkflowExecutionHistoryExport,
+) error {
+
+	if export.GetFormatVersion() != common.WorkflowExecutionHistoryExportFormatVersion {
+		return serviceerror.NewInvalidArgument("workflowImporter encounter unsupported history export format.")
+	}
+	workflowID := export.GetExecution().GetWorkflowId()
+	runID := export.GetExecution().GetRunId()
+	if workflowID == "" || uuid.Parse(runID) == nil {
+		return serviceerror.NewInvalidArgument("workflowImporter encounter invalid workflow execution.")
+	}
+	execution := commonpb.WorkflowExecution{
+		WorkflowId: workflowID,
+		RunId:      runID,
+	}
+	// validate all the batches before anything is written, so that a truncated export is rejected as a whole
+	lastEventID, lastEventVersion, err := r.validateHistory(export)
+	if err != nil {
+		return err
+	}
+
+	createMode, prevRunID, prevLastWriteVersion, err := r.getCreateMode(namespaceID, execution)
+	if err != nil {
+		return err
+	}
+
+	// the exported branch token belongs to the source cluster, always write to a new branch
+	branchToken, err := persistence.NewHistoryBranchToken(runID)
+	if err != nil {
+		return err
+	}
+	if err := r.appendHistory(ctx, namespaceID, execution, branchToken, export); err != nil {
+		return err
+	}
+
+	// NOTE: if anything below fails, the history branch written above is left behind
+	// and will be deleted by the history scavenger
+	workflowIdentifier := definition.NewWorkflowIdentifier(namespaceID, workflowID, runID)
+	now := r.shard.GetTimeSource().Now()
+	importedMutableState, historySize, err := r.newStateRebuilder().rebuild(
+		ctx,
+		now,
+		workflowIdentifier,
+		branchToken,
+		lastEventID,
+		lastEventVersion,
+		workflowIdentifier,
+		branchToken,
+		uuid.New(),
+		nil, // the export does not carry the completion callbacks of the run
+	)
+	if err != nil {
+		return err
+	}
+
+	importedContext := r.newContext(namespaceID, execution)
+	importedSnapshot, _, err := importedMutableState.CloseTransactionAsSnapshot(
+		now,
+		transactionPolicyPassive,
+	)
+	if err != nil {
+		return err
+	}
+	return importedContext.createWorkflowExecution(
+		importedSnapshot,
+		historySize,
+		now,
+		createMode,
+		prevRunID,
+		prevLastWriteVersion,
+	)
+}
+
+// validateHistory checks that the batches form a complete history starting from the first event,
+// with the event versions recorded by the current version history of the export if any,
+// and returns the ID and version of the last event
+func (r *workflowImporterImpl) validateHistory(
+	export *eventgenpb.WorkflowExecutionHistoryExport,
+) (int64, int64, error) {
+
+	historyBatches := export.GetHistoryBatches()
+	if len(historyBatches) == 0 {
+		return 0, 0, serviceerror.NewInvalidArgument("workflowImporter encounter empty history.")
+	}
+	lastEventID := common.FirstEventID - 1
+	lastEventVersion := common.EmptyVersion
+	for _, batch := range historyBatches {
+		events := batch.GetEvents()
+		if len(events) == 0 {
+			return 0, 0, serviceerror.NewInvalidArgument("workflowImporter encounter empty history batch.")
+		}
+		for _, event := range events {
+			if event.GetEventId() != lastEventID+1 {
+				return 0, 0, serviceerror.NewInvalidArgument("workflowImporter encounter non contiguous history events.")
+			}
+			lastEventID = event.GetEventId()
+			lastEventVersion = event.GetVersion()
+		}
+	}
+	if historyBatches[0].GetEvents()[0].GetEventType() != eventpb.EventType_WorkflowExecutionStarted {
+		return 0, 0, serviceerror.NewInvalidArgument("workflowImporter encounter history not starting with workflow execution started event.")
+	}
+
+	if export.GetVersionHistories() != nil {
+		if err := r.validateVersionHistory(export.GetVersionHistories(), historyBatches, lastEventID); err != nil {
+			return 0, 0, err
+		}
+	}
+	return lastEventID, lastEventVersion, nil
+}
+
+// validateVersionHistory checks that the events are the ones of the current version history.
+// Only the events of the current branch are exported, so other branches cannot be imported.
+func (r *workflowImporterImpl) validateVersionHistory(
+	versionHistories *eventgenpb.VersionHistories,
+	historyBatches []*eventpb.History,
+	lastEventID int64,
+) error {
+
+	index := int(versionHistories.GetCurrentVersionHistoryIndex())
+	if index < 0 || index >= len(versionHistories.GetHistories()) {
+		return serviceerror.NewInvalidArgument("workflowImporter encounter invalid current version history index.")
+	}
+	items := versionHistories.GetHistories()[index].GetItems()
+	if len(items) == 0 {
+		return serviceerror.NewInvalidArgument("workflowImporter encounter empty version history.")
+	}
+	for i := 1; i < len(items); i++ {
+		if items[i].GetEventId() <= items[i-1].GetEventId() || items[i].GetVersion() <= items[i-1].GetVersion() {
+			return serviceerror.NewInvalidArgument("workflowImporter encounter unordered version history items.")
+		}
+	}
+	if items[len(items)-1].GetEventId() != lastEventID {
+		return serviceerror.NewInvalidArgument("workflowImporter encounter version history not matching the last event.")
+	}
+
+	itemIndex := 0
+	for _, batch := range historyBatches {
+		for _, event := range batch.GetEvents() {
+			for event.GetEventId() > items[itemIndex].GetEventId() {
+				itemIndex++
+			}
+			if event.GetVersion() != items[itemIndex].GetVersion() {
+				return serviceerror.NewInvalidArgument("workflowImporter encounter event version not matching version history.")
+			}
+		}
+	}
+	return nil
+}
+
+// getCreateMode rejects imports which conflict with an existing run of the workflow, and returns
+// the create mode replacing the current closed run if any
+func (r *workflowImporterImpl) getCreateMode(
+	namespaceID string,
+	execution commonpb.WorkflowExecution,
+) (persistence.CreateWorkflowMode, string, int64, error) {
+
+	_, err := r.executionMgr.GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
+		NamespaceID: namespaceID,
+		Execution:   execution,
+	})
+	switch err.(type) {
+	case nil:
+		return 0, "", 0, serviceerror.NewWorkflowExecutionAlreadyStarted(
+			"workflowImporter encounter existing workflow run.", "", execution.GetRunId(),
+		)
+	case *serviceerror.NotFound:
+		// noop
+	default:
+		return 0, "", 0, err
+	}
+
+	resp, err := r.executionMgr.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+		NamespaceID: namespaceID,
+		WorkflowID:  execution.GetWorkflowId(),
+	})
+	switch err.(type) {
+	case nil:
+		if resp.State != executiongenpb.WorkflowExecutionState_WorkflowExecutionState_Completed {
+			return 0, "", 0, serviceerror.NewWorkflowExecutionAlreadyStarted(
+				"workflowImporter encounter running workflow run.", resp.StartRequestID, resp.RunID,
+			)
+		}
+		return persistence.CreateWorkflowModeWorkflowIDReuse, resp.RunID, resp.LastWriteVersion, nil
+	case *serviceerror.NotFound:
+		return persistence.CreateWorkflowModeBrandNew, "", 0, nil
+	default:
+		return 0, "", 0, err
+	}
+}
+
+func (r *workflowImporterImpl) appendHistory(
+	ctx context.Context,
+	namespaceID string,
+	execution commonpb.WorkflowExecution,
+	branchToken []byte,
+	export *eventgenpb.WorkflowExecutionHistoryExport,
+) error {
+
+	for i, batch := range export.GetHistoryBatches() {
+		if _, err := r.shard.AppendHistoryV2Events(ctx, &persistence.AppendHistoryNodesRequest{
+			IsNewBranch: i == 0,
+			Info:        persistence.BuildHistoryGarbageCleanupInfo(namespaceID, execution.GetWorkflowId(), execution.GetRunId()),
+			BranchToken: branchToken,
+			Events:      batch.GetEvents(),
+		}, namespaceID, execution); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,377 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	eventgenpb "github.com/temporalio/temporal/.gen/proto/event"
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/definition"
+	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+const (
+	testSourceVersion = int64(1234)
+)
+
+type (
+	workflowImporterSuite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller         *gomock.Controller
+		mockShard          *shardContextTest
+		mockStateRebuilder *MocknDCStateRebuilder
+		mockContext        *MockworkflowExecutionContext
+		mockMutableState   *MockmutableState
+
+		mockExecutionMgr *mocks.ExecutionManager
+		mockHistoryV2Mgr *mocks.HistoryV2Manager
+
+		namespaceID string
+		workflowID  string
+		runID       string
+
+		workflowImporter *workflowImporterImpl
+	}
+)
+
+func TestWorkflowImporterSuite(t *testing.T) {
+	s := new(workflowImporterSuite)
+	suite.Run(t, s)
+}
+
+func (s *workflowImporterSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.controller = gomock.NewController(s.T())
+	s.mockStateRebuilder = NewMocknDCStateRebuilder(s.controller)
+	s.mockContext = NewMockworkflowExecutionContext(s.controller)
+	s.mockMutableState = NewMockmutableState(s.controller)
+
+	s.mockShard = newTestShardContext(
+		s.controller,
+		&persistence.ShardInfoWithFailover{
+			ShardInfo: &persistenceblobs.ShardInfo{
+				ShardId:          10,
+				RangeId:          1,
+				TransferAckLevel: 0,
+			}},
+		NewDynamicConfigForTest(),
+	)
+	s.mockExecutionMgr = s.mockShard.resource.ExecutionMgr
+	s.mockHistoryV2Mgr = s.mockShard.resource.HistoryMgr
+
+	s.namespaceID = testNamespaceID
+	s.workflowID = "some random workflow ID"
+	s.runID = uuid.New()
+
+	s.workflowImporter = newWorkflowImporter(s.mockShard, s.mockShard.GetLogger())
+	s.workflowImporter.newStateRebuilder = func() nDCStateRebuilder {
+		return s.mockStateRebuilder
+	}
+	s.workflowImporter.newContext = func(namespaceID string, execution commonpb.WorkflowExecution) workflowExecutionContext {
+		return s.mockContext
+	}
+}
+
+func (s *workflowImporterSuite) TearDownTest() {
+	s.controller.Finish()
+	s.mockShard.Finish(s.T())
+}
+
+func (s *workflowImporterSuite) TestImportWorkflow_BrandNew() {
+	export := s.newExport(2, 1)
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, serviceerror.NewNotFound("")).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(nil, serviceerror.NewNotFound("")).Once()
+	s.expectAppendHistory(testSourceVersion, 2)
+
+	workflowIdentifier := definition.NewWorkflowIdentifier(s.namespaceID, s.workflowID, s.runID)
+	snapshot := &persistence.WorkflowSnapshot{}
+	s.mockStateRebuilder.EXPECT().rebuild(
+		gomock.Any(),
+		gomock.Any(),
+		workflowIdentifier,
+		gomock.Any(),
+		int64(3),
+		testSourceVersion,
+		workflowIdentifier,
+		gomock.Any(),
+		gomock.Any(),
+		nil,
+	).Return(s.mockMutableState, int64(123), nil).Times(1)
+	s.mockMutableState.EXPECT().CloseTransactionAsSnapshot(gomock.Any(), transactionPolicyPassive).Return(snapshot, nil, nil).Times(1)
+	s.mockContext.EXPECT().createWorkflowExecution(
+		snapshot, int64(123), gomock.Any(), persistence.CreateWorkflowModeBrandNew, "", int64(0),
+	).Return(nil).Times(1)
+
+	err := s.workflowImporter.importWorkflow(context.Background(), s.namespaceID, export)
+	s.NoError(err)
+}
+
+func (s *workflowImporterSuite) TestImportWorkflow_ReuseClosedRun() {
+	export := s.newExport(1, 2)
+	prevRunID := uuid.New()
+	prevLastWriteVersion := int64(111)
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, serviceerror.NewNotFound("")).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", &persistence.GetCurrentExecutionRequest{
+		NamespaceID: s.namespaceID,
+		WorkflowID:  s.workflowID,
+	}).Return(&persistence.GetCurrentExecutionResponse{
+		RunID:            prevRunID,
+		State:            executiongenpb.WorkflowExecutionState_WorkflowExecutionState_Completed,
+		LastWriteVersion: prevLastWriteVersion,
+	}, nil).Once()
+	s.expectAppendHistory(testSourceVersion, 1)
+
+	snapshot := &persistence.WorkflowSnapshot{}
+	s.mockStateRebuilder.EXPECT().rebuild(
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		int64(3),
+		testSourceVersion,
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		nil,
+	).Return(s.mockMutableState, int64(123), nil).Times(1)
+	s.mockMutableState.EXPECT().CloseTransactionAsSnapshot(gomock.Any(), transactionPolicyPassive).Return(snapshot, nil, nil).Times(1)
+	s.mockContext.EXPECT().createWorkflowExecution(
+		snapshot, int64(123), gomock.Any(), persistence.CreateWorkflowModeWorkflowIDReuse, prevRunID, prevLastWriteVersion,
+	).Return(nil).Times(1)
+
+	err := s.workflowImporter.importWorkflow(context.Background(), s.namespaceID, export)
+	s.NoError(err)
+}
+
+func (s *workflowImporterSuite) TestImportWorkflow_MultipleVersions() {
+	export := s.newExport(3, 1)
+	export.HistoryBatches[2].Events[0].Version = testSourceVersion + 1
+	export.VersionHistories = s.newVersionHistories(
+		&eventgenpb.VersionHistoryItem{EventId: 2, Version: testSourceVersion},
+		&eventgenpb.VersionHistoryItem{EventId: 3, Version: testSourceVersion + 1},
+	)
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, serviceerror.NewNotFound("")).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(nil, serviceerror.NewNotFound("")).Once()
+	// the events are persisted with their exported versions
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.MatchedBy(func(request *persistence.AppendHistoryNodesRequest) bool {
+		return request.Events[0].GetEventId() < 3 && request.Events[0].GetVersion() == testSourceVersion
+	})).Return(&persistence.AppendHistoryNodesResponse{Size: 1}, nil).Times(2)
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.MatchedBy(func(request *persistence.AppendHistoryNodesRequest) bool {
+		return request.Events[0].GetEventId() == 3 && request.Events[0].GetVersion() == testSourceVersion+1
+	})).Return(&persistence.AppendHistoryNodesResponse{Size: 1}, nil).Once()
+
+	snapshot := &persistence.WorkflowSnapshot{}
+	s.mockStateRebuilder.EXPECT().rebuild(
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		int64(3),
+		testSourceVersion+1,
+		gomock.Any(),
+		gomock.Any(),
+		gomock.Any(),
+		nil,
+	).Return(s.mockMutableState, int64(123), nil).Times(1)
+	s.mockMutableState.EXPECT().CloseTransactionAsSnapshot(gomock.Any(), transactionPolicyPassive).Return(snapshot, nil, nil).Times(1)
+	s.mockContext.EXPECT().createWorkflowExecution(
+		snapshot, int64(123), gomock.Any(), persistence.CreateWorkflowModeBrandNew, "", int64(0),
+	).Return(nil).Times(1)
+
+	err := s.workflowImporter.importWorkflow(context.Background(), s.namespaceID, export)
+	s.NoError(err)
+}
+
+func (s *workflowImporterSuite) TestImportWorkflow_VersionHistoryMismatch() {
+	versionMismatch := s.newExport(2, 1)
+	versionMismatch.VersionHistories = s.newVersionHistories(
+		&eventgenpb.VersionHistoryItem{EventId: 1, Version: testSourceVersion},
+		&eventgenpb.VersionHistoryItem{EventId: 2, Version: testSourceVersion + 1},
+	)
+	lastEventMismatch := s.newExport(2, 1)
+	lastEventMismatch.VersionHistories = s.newVersionHistories(
+		&eventgenpb.VersionHistoryItem{EventId: 3, Version: testSourceVersion},
+	)
+	unorderedItems := s.newExport(2, 1)
+	unorderedItems.VersionHistories = s.newVersionHistories(
+		&eventgenpb.VersionHistoryItem{EventId: 1, Version: testSourceVersion},
+		&eventgenpb.VersionHistoryItem{EventId: 2, Version: testSourceVersion - 1},
+	)
+	invalidIndex := s.newExport(2, 1)
+	invalidIndex.VersionHistories = s.newVersionHistories(
+		&eventgenpb.VersionHistoryItem{EventId: 2, Version: testSourceVersion},
+	)
+	invalidIndex.VersionHistories.CurrentVersionHistoryIndex = 1
+
+	testCases := map[string]*eventgenpb.WorkflowExecutionHistoryExport{
+		"event version mismatch": versionMismatch,
+		"last event mismatch":    lastEventMismatch,
+		"unordered items":        unorderedItems,
+		"invalid current index":  invalidIndex,
+	}
+	for name, export := range testCases {
+		s.T().Run(name, func(t *testing.T) {
+			err := s.workflowImporter.importWorkflow(context.Background(), s.namespaceID, export)
+			s.IsType(&serviceerror.InvalidArgument{}, err)
+		})
+	}
+}
+
+func (s *workflowImporterSuite) TestImportWorkflow_ExistingRun() {
+	export := s.newExport(2, 1)
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", &persistence.GetWorkflowExecutionRequest{
+		NamespaceID: s.namespaceID,
+		Execution: commonpb.WorkflowExecution{
+			WorkflowId: s.workflowID,
+			RunId:      s.runID,
+		},
+	}).Return(&persistence.GetWorkflowExecutionResponse{}, nil).Once()
+
+	err := s.workflowImporter.importWorkflow(context.Background(), s.namespaceID, export)
+	s.IsType(&serviceerror.WorkflowExecutionAlreadyStarted{}, err)
+}
+
+func (s *workflowImporterSuite) TestImportWorkflow_RunningCurrentRun() {
+	export := s.newExport(2, 1)
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, serviceerror.NewNotFound("")).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(&persistence.GetCurrentExecutionResponse{
+		RunID: uuid.New(),
+		State: executiongenpb.WorkflowExecutionState_WorkflowExecutionState_Running,
+	}, nil).Once()
+
+	err := s.workflowImporter.importWorkflow(context.Background(), s.namespaceID, export)
+	s.IsType(&serviceerror.WorkflowExecutionAlreadyStarted{}, err)
+}
+
+func (s *workflowImporterSuite) TestImportWorkflow_PartialBatches() {
+	gapInBatch := s.newExport(1, 3)
+	gapInBatch.HistoryBatches[0].Events = append(gapInBatch.HistoryBatches[0].Events[:1], gapInBatch.HistoryBatches[0].Events[2:]...)
+	gapBetweenBatches := s.newExport(3, 1)
+	gapBetweenBatches.HistoryBatches = append(gapBetweenBatches.HistoryBatches[:1], gapBetweenBatches.HistoryBatches[2:]...)
+	emptyBatch := s.newExport(2, 1)
+	emptyBatch.HistoryBatches[1].Events = nil
+	missingFirstBatch := s.newExport(2, 1)
+	missingFirstBatch.HistoryBatches = missingFirstBatch.HistoryBatches[1:]
+	notStarted := s.newExport(2, 1)
+	notStarted.HistoryBatches[0].Events[0].EventType = eventpb.EventType_DecisionTaskScheduled
+
+	testCases := map[string]*eventgenpb.WorkflowExecutionHistoryExport{
+		"gap in batch":          gapInBatch,
+		"gap between batches":   gapBetweenBatches,
+		"empty batch":           emptyBatch,
+		"missing first batch":   missingFirstBatch,
+		"not started by events": notStarted,
+	}
+	for name, export := range testCases {
+		s.T().Run(name, func(t *testing.T) {
+			// nothing is read or written before the whole export is validated
+			err := s.workflowImporter.importWorkflow(context.Background(), s.namespaceID, export)
+			s.IsType(&serviceerror.InvalidArgument{}, err)
+		})
+	}
+}
+
+// newExport creates an export of consecutive batches, with the versions of a source cluster
+func (s *workflowImporterSuite) newExport(
+	batchCount int,
+	eventsPerBatch int,
+) *eventgenpb.WorkflowExecutionHistoryExport {
+
+	eventID := common.FirstEventID
+	var historyBatches []*eventpb.History
+	for i := 0; i < batchCount; i++ {
+		batch := &eventpb.History{}
+		for j := 0; j < eventsPerBatch; j++ {
+			batch.Events = append(batch.Events, &eventpb.HistoryEvent{
+				EventId:   eventID,
+				Version:   testSourceVersion,
+				EventType: eventpb.EventType_WorkflowExecutionSignaled,
+			})
+			eventID++
+		}
+		historyBatches = append(historyBatches, batch)
+	}
+	historyBatches[0].Events[0].EventType = eventpb.EventType_WorkflowExecutionStarted
+
+	return &eventgenpb.WorkflowExecutionHistoryExport{
+		FormatVersion: common.WorkflowExecutionHistoryExportFormatVersion,
+		Namespace:     testNamespace,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: s.workflowID,
+			RunId:      s.runID,
+		},
+		HistoryBatches: historyBatches,
+	}
+}
+
+func (s *workflowImporterSuite) newVersionHistories(
+	items ...*eventgenpb.VersionHistoryItem,
+) *eventgenpb.VersionHistories {
+
+	return &eventgenpb.VersionHistories{
+		CurrentVersionHistoryIndex: 0,
+		Histories: []*eventgenpb.VersionHistory{
+			{BranchToken: []byte("source branch token"), Items: items},
+		},
+	}
+}
+
+func (s *workflowImporterSuite) expectAppendHistory(
+	version int64,
+	times int,
+) {
+
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.MatchedBy(func(request *persistence.AppendHistoryNodesRequest) bool {
+		for _, event := range request.Events {
+			if event.GetVersion() != version {
+				return false
+			}
+		}
+		// only the first batch creates the branch
+		return request.IsNewBranch == (request.Events[0].GetEventId() == common.FirstEventID)
+	})).Return(&persistence.AppendHistoryNodesResponse{Size: 1}, nil).Times(times)
+}
@@ -31,8 +31,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/pborman/uuid"
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
+	"github.com/temporalio/temporal/.gen/proto/historyservice"
+	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/cluster"
 	"github.com/temporalio/temporal/common/collection"
 	"github.com/temporalio/temporal/common/convert"
@@ -42,7 +47,10 @@ import (
 	"github.com/temporalio/temporal/common/persistence"
 	commonpb "go.temporal.io/temporal-proto/common"
 	eventpb "go.temporal.io/temporal-proto/event"
+	executionpb "go.temporal.io/temporal-proto/execution"
+	namespacepb "go.temporal.io/temporal-proto/namespace"
 	"go.temporal.io/temporal-proto/serviceerror"
+	"go.temporal.io/temporal-proto/workflowservice"
 )
 
 type (
@@ -57,14 +65,40 @@ type (
 			baseRebuildLastEventID int64,
 			baseRebuildLastEventVersion int64,
 			baseNextEventID int64,
+			baseReplicationState *persistence.ReplicationState,
+			baseCompletionCallbacks []*executiongenpb.CompletionCallbackInfo,
 			resetRunID string,
 			resetRequestID string,
 			currentWorkflow nDCWorkflow,
 			resetReason string,
 			additionalReapplyEvents []*eventpb.HistoryEvent,
+			reapplyOptions *resetReapplyOptions,
+		) error
+		// backfillVersionHistories returns the version histories of the workflow, building and persisting them
+		// from the history events for executions created before version histories were introduced
+		backfillVersionHistories(
+			context workflowExecutionContext,
+			mutableState mutableState,
+			isCurrentWorkflow bool,
+		) (*persistence.VersionHistories, error)
+		// replicateResetWorkflow applies a workflow reset replicated by a 2DC source cluster,
+		// rebuilding the base workflow up to the reset point and applying the events of the reset run
+		replicateResetWorkflow(
+			ctx context.Context,
+			request *historyservice.ReplicateEventsRequest,
+			namespaceID string,
+			workflowID string,
+			currentRunID string,
 		) error
 	}
 
+	// resetReapplyOptions selects the events after the reset point which are reapplied
+	// to the reset workflow, nil reapplies all signals
+	resetReapplyOptions struct {
+		reapplyType executiongenpb.ResetReapplyType
+		signalNames map[string]struct{}
+	}
+
 	nDCStateRebuilderProvider func() nDCStateRebuilder
 
 	workflowResetterImpl struct {
@@ -107,11 +141,14 @@ func (r *workflowResetterImpl) resetWorkflow(
 	baseRebuildLastEventID int64,
 	baseRebuildLastEventVersion int64,
 	baseNextEventID int64,
+	baseReplicationState *persistence.ReplicationState,
+	baseCompletionCallbacks []*executiongenpb.CompletionCallbackInfo,
 	resetRunID string,
 	resetRequestID string,
 	currentWorkflow nDCWorkflow,
 	resetReason string,
 	additionalReapplyEvents []*eventpb.HistoryEvent,
+	reapplyOptions *resetReapplyOptions,
 ) (retError error) {
 
 	namespaceEntry, err := r.namespaceCache.GetNamespaceByID(namespaceID)
@@ -142,11 +179,14 @@ func (r *workflowResetterImpl) resetWorkflow(
 		baseRebuildLastEventID,
 		baseRebuildLastEventVersion,
 		baseNextEventID,
+		baseReplicationState,
+		baseCompletionCallbacks,
 		resetRunID,
 		resetRequestID,
 		resetWorkflowVersion,
 		resetReason,
 		additionalReapplyEvents,
+		reapplyOptions,
 	)
 	if err != nil {
 		return err
@@ -160,6 +200,283 @@ func (r *workflowResetterImpl) resetWorkflow(
 	)
 }
 
+func (r *workflowResetterImpl) backfillVersionHistories(
+	context workflowExecutionContext,
+	mutableState mutableState,
+	isCurrentWorkflow bool,
+) (*persistence.VersionHistories, error) {
+
+	return backfillVersionHistories(r.shard, context, mutableState, isCurrentWorkflow)
+}
+
+// backfillVersionHistories builds the version histories of a workflow created before version histories
+// were introduced from its history events, and persists them next to the replication state of the workflow
+func backfillVersionHistories(
+	shard ShardContext,
+	context workflowExecutionContext,
+	mutableState mutableState,
+	isCurrentWorkflow bool,
+) (*persistence.VersionHistories, error) {
+
+	if versionHistories := mutableState.GetVersionHistories(); versionHistories != nil {
+		return versionHistories, nil
+	}
+
+	branchToken, err := mutableState.GetCurrentBranchToken()
+	if err != nil {
+		return nil, err
+	}
+	versionHistory := persistence.NewVersionHistory(branchToken, nil)
+
+	var pageToken []byte
+	for {
+		_, historyBatches, nextPageToken, _, err := PaginateHistory(
+			shard.GetHistoryManager(),
+			true,
+			branchToken,
+			common.FirstEventID,
+			mutableState.GetNextEventID(),
+			pageToken,
+			nDCDefaultPageSize,
+			convert.IntPtr(shard.GetShardID()),
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, batch := range historyBatches {
+			for _, event := range batch.Events {
+				if err := versionHistory.AddOrUpdateItem(persistence.NewVersionHistoryItem(
+					event.GetEventId(),
+					event.GetVersion(),
+				)); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if len(nextPageToken) == 0 {
+			break
+		}
+		pageToken = nextPageToken
+	}
+	versionHistories := persistence.NewVersionHistories(versionHistory)
+	if err := mutableState.SetVersionHistories(versionHistories); err != nil {
+		return nil, err
+	}
+
+	// the base workflow is not necessarily updated by the reset, so persist the backfill right away,
+	// the replication state is kept so the workflow can still be replicated to 2DC clusters
+	updateMode := persistence.UpdateWorkflowModeBypassCurrent
+	if isCurrentWorkflow {
+		updateMode = persistence.UpdateWorkflowModeUpdateCurrent
+	}
+	if err := context.updateWorkflowExecutionWithNew(
+		shard.GetTimeSource().Now(),
+		updateMode,
+		nil,
+		nil,
+		transactionPolicyPassive,
+		nil,
+	); err != nil {
+		return nil, err
+	}
+	return versionHistories, nil
+}
+
+// inheritReplicationState keeps the reset workflow of a 2DC workflow in the replication mode of its base
+// workflow, on the active cluster as well as on the clusters the reset is replicated to
+func inheritReplicationState(
+	baseReplicationState *persistence.ReplicationState,
+	resetMutableState mutableState,
+	baseRebuildLastEventVersion int64,
+	baseRebuildLastEventID int64,
+) {
+
+	if baseReplicationState == nil {
+		return
+	}
+	resetMutableState.SetReplicationState(&persistence.ReplicationState{
+		StartVersion:        baseReplicationState.StartVersion,
+		CurrentVersion:      baseRebuildLastEventVersion,
+		LastReplicationInfo: make(map[string]*replicationgenpb.ReplicationInfo),
+	})
+	resetMutableState.UpdateReplicationStateLastEventID(baseRebuildLastEventVersion, baseRebuildLastEventID)
+}
+
+func (r *workflowResetterImpl) replicateResetWorkflow(
+	ctx context.Context,
+	request *historyservice.ReplicateEventsRequest,
+	namespaceID string,
+	workflowID string,
+	currentRunID string,
+) (retError error) {
+
+	resetAttr, err := validateResetReplicationTask(request)
+	if err != nil {
+		return err
+	}
+	resetEvents := request.History.Events
+	firstEvent := resetEvents[0]
+	lastEvent := resetEvents[len(resetEvents)-1]
+	baseRunID := resetAttr.GetBaseRunId()
+	resetRunID := resetAttr.GetNewRunId()
+	baseRebuildLastEventID := firstEvent.GetEventId() - 1
+
+	baseContext, baseRelease, err := r.historyCache.getOrCreateWorkflowExecution(
+		ctx,
+		namespaceID,
+		commonpb.WorkflowExecution{
+			WorkflowId: workflowID,
+			RunId:      baseRunID,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { baseRelease(retError) }()
+
+	baseMutableState, err := baseContext.loadWorkflowExecution()
+	if err != nil {
+		return err
+	}
+	if baseMutableState.GetNextEventID() <= baseRebuildLastEventID {
+		// re-replicate the whole new run
+		return newRetryTaskErrorWithHint(ErrWorkflowNotFoundMsg, namespaceID, workflowID, resetRunID, common.FirstEventID)
+	}
+
+	currentContext := baseContext
+	currentMutableState := baseMutableState
+	if currentRunID != baseRunID {
+		var currentRelease releaseWorkflowExecutionFunc
+		currentContext, currentRelease, err = r.historyCache.getOrCreateWorkflowExecution(
+			ctx,
+			namespaceID,
+			commonpb.WorkflowExecution{
+				WorkflowId: workflowID,
+				RunId:      currentRunID,
+			},
+		)
+		if err != nil {
+			return err
+		}
+		defer func() { currentRelease(retError) }()
+
+		currentMutableState, err = currentContext.loadWorkflowExecution()
+		if err != nil {
+			return err
+		}
+	}
+	if currentMutableState.IsWorkflowExecutionRunning() {
+		return ErrRetryRaceCondition
+	}
+	currentLastWriteVersion, err := currentMutableState.GetLastWriteVersion()
+	if err != nil {
+		return err
+	}
+
+	baseVersionHistories, err := r.backfillVersionHistories(
+		baseContext,
+		baseMutableState,
+		currentRunID == baseRunID,
+	)
+	if err != nil {
+		return err
+	}
+	baseCurrentVersionHistory, err := baseVersionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		return err
+	}
+	baseRebuildLastEventVersion, err := baseCurrentVersionHistory.GetEventVersion(baseRebuildLastEventID)
+	if err != nil {
+		return err
+	}
+	if baseRebuildLastEventVersion != resetAttr.GetForkEventVersion() {
+		// re-replicate the whole new run
+		return newRetryTaskErrorWithHint(ErrWorkflowNotFoundMsg, namespaceID, workflowID, resetRunID, common.FirstEventID)
+	}
+
+	resetWorkflow, err := r.replayResetWorkflow(
+		ctx,
+		namespaceID,
+		workflowID,
+		baseRunID,
+		baseCurrentVersionHistory.GetBranchToken(),
+		baseRebuildLastEventID,
+		baseRebuildLastEventVersion,
+		resetRunID,
+		uuid.New(),
+		inheritCompletionCallbacks(baseMutableState.GetExecutionInfo().CompletionCallbacks),
+	)
+	if err != nil {
+		return err
+	}
+	defer resetWorkflow.getReleaseFn()(retError)
+
+	resetMutableState := resetWorkflow.getMutableState()
+	resetMutableState.ClearStickyness()
+	inheritReplicationState(
+		baseMutableState.GetReplicationState(),
+		resetMutableState,
+		baseRebuildLastEventVersion,
+		baseRebuildLastEventID,
+	)
+	// always enforce the attempt to zero so that the replicated decision task failed event
+	// does not schedule a transient decision, the replicated events carry the next decision
+	decision, ok := resetMutableState.GetInFlightDecision()
+	if !ok || decision.StartedID != baseRebuildLastEventID {
+		return serviceerror.NewInvalidArgument(fmt.Sprintf("Can only reset workflow to DecisionTaskStarted + 1: %v", baseRebuildLastEventID+1))
+	}
+	decision.Attempt = 0
+	resetMutableState.UpdateDecision(decision)
+
+	stateBuilder := newStateBuilder(
+		r.shard,
+		r.logger,
+		resetMutableState,
+		func(mutableState mutableState) mutableStateTaskGenerator {
+			return newMutableStateTaskGenerator(r.namespaceCache, r.logger, mutableState)
+		},
+	)
+	if _, err := stateBuilder.applyEvents(
+		namespaceID,
+		uuid.New(),
+		commonpb.WorkflowExecution{
+			WorkflowId: workflowID,
+			RunId:      resetRunID,
+		},
+		resetEvents,
+		nil,
+		false,
+	); err != nil {
+		return err
+	}
+
+	now := time.Unix(0, lastEvent.GetTimestamp())
+	resetWorkflowSnapshot, resetWorkflowEventsSeq, err := resetMutableState.CloseTransactionAsSnapshot(
+		now,
+		transactionPolicyPassive,
+	)
+	if err != nil {
+		return err
+	}
+	resetHistorySize, err := resetWorkflow.getContext().persistFirstWorkflowEvents(resetWorkflowEventsSeq[0])
+	if err != nil {
+		return err
+	}
+	if err := resetWorkflow.getContext().createWorkflowExecution(
+		resetWorkflowSnapshot,
+		resetHistorySize,
+		now,
+		persistence.CreateWorkflowModeContinueAsNew,
+		currentRunID,
+		currentLastWriteVersion,
+	); err != nil {
+		return err
+	}
+
+	notify(r.shard, request.GetSourceCluster(), now)
+	return nil
+}
+
 func (r *workflowResetterImpl) prepareResetWorkflow(
 	ctx context.Context,
 	namespaceID string,
@@ -169,11 +486,14 @@ func (r *workflowResetterImpl) prepareResetWorkflow(
 	baseRebuildLastEventID int64,
 	baseRebuildLastEventVersion int64,
 	baseNextEventID int64,
+	baseReplicationState *persistence.ReplicationState,
+	baseCompletionCallbacks []*executiongenpb.CompletionCallbackInfo,
 	resetRunID string,
 	resetRequestID string,
 	resetWorkflowVersion int64,
 	resetReason string,
 	additionalReapplyEvents []*eventpb.HistoryEvent,
+	reapplyOptions *resetReapplyOptions,
 ) (nDCWorkflow, error) {
 
 	resetWorkflow, err := r.replayResetWorkflow(
@@ -186,6 +506,7 @@ func (r *workflowResetterImpl) prepareResetWorkflow(
 		baseRebuildLastEventVersion,
 		resetRunID,
 		resetRequestID,
+		inheritCompletionCallbacks(baseCompletionCallbacks),
 	)
 	if err != nil {
 		return nil, err
@@ -193,6 +514,12 @@ func (r *workflowResetterImpl) prepareResetWorkflow(
 
 	// Reset expiration time
 	resetMutableState := resetWorkflow.getMutableState()
+	inheritReplicationState(
+		baseReplicationState,
+		resetMutableState,
+		baseRebuildLastEventVersion,
+		baseRebuildLastEventID,
+	)
 	executionInfo := resetMutableState.GetExecutionInfo()
 	if executionInfo.WorkflowExecutionTimeout > 0 {
 		executionInfo.WorkflowExpirationTime = time.Now().Add(time.Duration(executionInfo.WorkflowExecutionTimeout) * time.Second)
@@ -214,8 +541,8 @@ func (r *workflowResetterImpl) prepareResetWorkflow(
 	if !ok || decision.StartedID+1 != resetMutableState.GetNextEventID() {
 		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("Can only reset workflow to DecisionTaskStarted + 1: %v", baseRebuildLastEventID+1))
 	}
-	if len(resetMutableState.GetPendingChildExecutionInfos()) > 0 {
-		return nil, serviceerror.NewInvalidArgument(fmt.Sprintf("Can only reset workflow with pending child workflows"))
+	if err := resetMutableState.CheckResettable(); err != nil {
+		return nil, err
 	}
 
 	resetFailure := failure.NewResetWorkflowFailure(resetReason, nil)
@@ -246,11 +573,12 @@ func (r *workflowResetterImpl) prepareResetWorkflow(
 		baseBranchToken,
 		baseRebuildLastEventID+1,
 		baseNextEventID,
+		reapplyOptions,
 	); err != nil {
 		return nil, err
 	}
 
-	if err := r.reapplyEvents(resetMutableState, additionalReapplyEvents); err != nil {
+	if err := r.reapplyEvents(resetMutableState, additionalReapplyEvents, nil); err != nil {
 		return nil, err
 	}
 
@@ -315,6 +643,7 @@ func (r *workflowResetterImpl) replayResetWorkflow(
 	baseRebuildLastEventVersion int64,
 	resetRunID string,
 	resetRequestID string,
+	resetCompletionCallbacks []*executiongenpb.CompletionCallbackInfo,
 ) (nDCWorkflow, error) {
 
 	resetBranchToken, err := r.generateBranchToken(
@@ -356,6 +685,7 @@ func (r *workflowResetterImpl) replayResetWorkflow(
 		),
 		resetBranchToken,
 		resetRequestID,
+		resetCompletionCallbacks,
 	)
 	if err != nil {
 		return nil, err
@@ -444,6 +774,7 @@ func (r *workflowResetterImpl) reapplyContinueAsNewWorkflowEvents(
 	baseBranchToken []byte,
 	baseRebuildNextEventID int64,
 	baseNextEventID int64,
+	reapplyOptions *resetReapplyOptions,
 ) error {
 
 	// TODO change this logic to fetching all workflow [baseWorkflow, currentWorkflow]
@@ -458,6 +789,7 @@ func (r *workflowResetterImpl) reapplyContinueAsNewWorkflowEvents(
 		baseRebuildNextEventID,
 		baseNextEventID,
 		baseBranchToken,
+		reapplyOptions,
 	); err != nil {
 		return err
 	}
@@ -502,6 +834,7 @@ func (r *workflowResetterImpl) reapplyContinueAsNewWorkflowEvents(
 			common.FirstEventID,
 			nextWorkflowNextEventID,
 			nextWorkflowBranchToken,
+			reapplyOptions,
 		); err != nil {
 			return err
 		}
@@ -514,6 +847,7 @@ func (r *workflowResetterImpl) reapplyWorkflowEvents(
 	firstEventID int64,
 	nextEventID int64,
 	branchToken []byte,
+	reapplyOptions *resetReapplyOptions,
 ) (string, error) {
 
 	// TODO change this logic to fetching all workflow [baseWorkflow, currentWorkflow]
@@ -535,7 +869,7 @@ func (r *workflowResetterImpl) reapplyWorkflowEvents(
 			return "", err
 		}
 		lastEvents = batch.(*eventpb.History).Events
-		if err := r.reapplyEvents(mutableState, lastEvents); err != nil {
+		if err := r.reapplyEvents(mutableState, lastEvents, reapplyOptions); err != nil {
 			return "", err
 		}
 	}
@@ -552,12 +886,16 @@ func (r *workflowResetterImpl) reapplyWorkflowEvents(
 func (r *workflowResetterImpl) reapplyEvents(
 	mutableState mutableState,
 	events []*eventpb.HistoryEvent,
+	reapplyOptions *resetReapplyOptions,
 ) error {
 
 	for _, event := range events {
 		switch event.GetEventType() {
 		case eventpb.EventType_WorkflowExecutionSignaled:
 			attr := event.GetWorkflowExecutionSignaledEventAttributes()
+			if !reapplyOptions.shouldReapplySignal(attr.GetSignalName()) {
+				continue
+			}
 			if _, err := mutableState.AddWorkflowExecutionSignaled(
 				attr.GetSignalName(),
 				attr.GetInput(),
@@ -601,3 +939,83 @@ func (r *workflowResetterImpl) getPaginationFn(
 		return paginateItems, token, nil
 	}
 }
+
+func newResetReapplyOptions(
+	reapplyType executiongenpb.ResetReapplyType,
+	signalNames []string,
+) *resetReapplyOptions {
+
+	options := &resetReapplyOptions{
+		reapplyType: reapplyType,
+		signalNames: make(map[string]struct{}, len(signalNames)),
+	}
+	for _, signalName := range signalNames {
+		options.signalNames[signalName] = struct{}{}
+	}
+	return options
+}
+
+func (o *resetReapplyOptions) shouldReapplySignal(
+	signalName string,
+) bool {
+
+	if o == nil {
+		return true
+	}
+	if o.reapplyType == executiongenpb.ResetReapplyType_ResetReapplyType_None {
+		return false
+	}
+	if len(o.signalNames) == 0 {
+		return true
+	}
+	_, ok := o.signalNames[signalName]
+	return ok
+}
+
+func getRespondActivityTaskFailedRequestFromActivity(ai *persistence.ActivityInfo, resetReason string) *workflowservice.RespondActivityTaskFailedRequest {
+	return &workflowservice.RespondActivityTaskFailedRequest{
+		Failure:  failure.NewResetWorkflowFailure(resetReason, ai.Details),
+		Identity: ai.StartedIdentity,
+	}
+}
+
+// FindAutoResetPoint returns the auto reset point
+func FindAutoResetPoint(
+	timeSource clock.TimeSource,
+	badBinaries *namespacepb.BadBinaries,
+	autoResetPoints *executionpb.ResetPoints,
+) (string, *executionpb.ResetPointInfo) {
+	if badBinaries == nil || badBinaries.Binaries == nil || autoResetPoints == nil || autoResetPoints.Points == nil {
+		return "", nil
+	}
+	nowNano := timeSource.Now().UnixNano()
+	for _, p := range autoResetPoints.Points {
+		bin, ok := badBinaries.Binaries[p.GetBinaryChecksum()]
+		if ok && p.GetResettable() {
+			if p.GetExpiringTimeNano() > 0 && nowNano > p.GetExpiringTimeNano() {
+				// reset point has expired and we may already deleted the history
+				continue
+			}
+			return bin.GetReason(), p
+		}
+	}
+	return "", nil
+}
+
+func validateResetReplicationTask(
+	request *historyservice.ReplicateEventsRequest,
+) (*eventpb.DecisionTaskFailedEventAttributes, error) {
+
+	historyAfterReset := request.History.Events
+	if len(historyAfterReset) == 0 || historyAfterReset[0].GetEventType() != eventpb.EventType_DecisionTaskFailed {
+		return nil, errUnknownReplicationTask
+	}
+	attr := historyAfterReset[0].GetDecisionTaskFailedEventAttributes()
+	if attr.GetCause() != eventpb.DecisionTaskFailedCause_ResetWorkflow {
+		return nil, errUnknownReplicationTask
+	}
+	if attr.GetNewRunId() != request.GetWorkflowExecution().GetRunId() {
+		return nil, errUnknownReplicationTask
+	}
+	return attr, nil
+}
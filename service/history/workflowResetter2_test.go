@@ -0,0 +1,1150 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
+	executionpb "go.temporal.io/temporal-proto/execution"
+	"go.temporal.io/temporal-proto/serviceerror"
+	tasklistpb "go.temporal.io/temporal-proto/tasklist"
+	"go.temporal.io/temporal-proto/workflowservice"
+
+	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
+	"github.com/temporalio/temporal/.gen/proto/historyservice"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
+	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/cache"
+	"github.com/temporalio/temporal/common/clock"
+	"github.com/temporalio/temporal/common/cluster"
+	"github.com/temporalio/temporal/common/failure"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/mocks"
+	"github.com/temporalio/temporal/common/payloads"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+type (
+	// resetTestBranchType is how the base workflow of the reset tracks its history branch
+	resetTestBranchType int
+
+	workflowResetter2Suite struct {
+		suite.Suite
+		*require.Assertions
+
+		controller               *gomock.Controller
+		mockShard                *shardContextTest
+		mockTxProcessor          *MocktransferQueueProcessor
+		mockReplicationProcessor *MockReplicatorQueueProcessor
+		mockTimerProcessor       *MocktimerQueueProcessor
+		mockEventsCache          *MockeventsCache
+		mockNamespaceCache       *cache.MockNamespaceCache
+		mockClusterMetadata      *cluster.MockMetadata
+
+		mockExecutionMgr *mocks.ExecutionManager
+		mockHistoryV2Mgr *mocks.HistoryV2Manager
+
+		config *Config
+		logger log.Logger
+
+		historyEngine *historyEngineImpl
+
+		namespaceID           string
+		workflowID            string
+		baseRunID             string
+		currentRunID          string
+		baseBranchToken       []byte
+		currentBranchToken    []byte
+		resetBranchToken      []byte
+		beforeResetVersion    int64
+		afterResetVersion     int64
+		decisionFinishEventID int64
+		baseNextEventID       int64
+	}
+)
+
+const (
+	// local namespace, the branch token is only recorded in the execution info
+	resetTestBranchTypeLocal resetTestBranchType = iota
+	// 2DC global namespace, the versions are tracked by the replication state
+	resetTestBranchTypeReplicationState
+	// the versions are tracked by the version histories
+	resetTestBranchTypeVersionHistories
+)
+
+func TestWorkflowResetter2Suite(t *testing.T) {
+	s := new(workflowResetter2Suite)
+	suite.Run(t, s)
+}
+
+func (s *workflowResetter2Suite) SetupSuite() {
+	s.config = NewDynamicConfigForTest()
+}
+
+func (s *workflowResetter2Suite) TearDownSuite() {
+}
+
+func (s *workflowResetter2Suite) SetupTest() {
+	s.Assertions = require.New(s.T())
+
+	s.controller = gomock.NewController(s.T())
+
+	s.mockTxProcessor = NewMocktransferQueueProcessor(s.controller)
+	s.mockReplicationProcessor = NewMockReplicatorQueueProcessor(s.controller)
+	s.mockTimerProcessor = NewMocktimerQueueProcessor(s.controller)
+	s.mockTxProcessor.EXPECT().NotifyNewTask(gomock.Any(), gomock.Any()).AnyTimes()
+	s.mockReplicationProcessor.EXPECT().notifyNewTask().AnyTimes()
+	s.mockTimerProcessor.EXPECT().NotifyNewTimers(gomock.Any(), gomock.Any()).AnyTimes()
+
+	s.mockShard = newTestShardContext(
+		s.controller,
+		&persistence.ShardInfoWithFailover{
+			ShardInfo: &persistenceblobs.ShardInfo{
+				ShardId:          0,
+				RangeId:          1,
+				TransferAckLevel: 0,
+			}},
+		s.config,
+	)
+
+	s.mockNamespaceCache = s.mockShard.resource.NamespaceCache
+	s.mockExecutionMgr = s.mockShard.resource.ExecutionMgr
+	s.mockHistoryV2Mgr = s.mockShard.resource.HistoryMgr
+	s.mockClusterMetadata = s.mockShard.resource.ClusterMetadata
+	s.mockEventsCache = s.mockShard.mockEventsCache
+	s.mockEventsCache.EXPECT().putEvent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	s.mockClusterMetadata.EXPECT().IsGlobalNamespaceEnabled().Return(true).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetCurrentClusterName().Return(cluster.TestCurrentClusterName).AnyTimes()
+	s.mockClusterMetadata.EXPECT().GetAllClusterInfo().Return(cluster.TestAllClusterInfo).AnyTimes()
+
+	s.logger = s.mockShard.GetLogger()
+
+	historyCache := newHistoryCache(s.mockShard)
+	h := &historyEngineImpl{
+		currentClusterName:   s.mockShard.GetClusterMetadata().GetCurrentClusterName(),
+		shard:                s.mockShard,
+		clusterMetadata:      s.mockClusterMetadata,
+		executionManager:     s.mockExecutionMgr,
+		historyV2Mgr:         s.mockHistoryV2Mgr,
+		historyCache:         historyCache,
+		logger:               s.logger,
+		throttledLogger:      s.logger,
+		metricsClient:        metrics.NewClient(tally.NoopScope, metrics.History),
+		tokenSerializer:      common.NewProtoTaskTokenSerializer(),
+		config:               s.config,
+		timeSource:           s.mockShard.GetTimeSource(),
+		historyEventNotifier: newHistoryEventNotifier(clock.NewRealTimeSource(), metrics.NewClient(tally.NoopScope, metrics.History), func(string) int { return 0 }),
+		txProcessor:          s.mockTxProcessor,
+		replicatorProcessor:  s.mockReplicationProcessor,
+		timerProcessor:       s.mockTimerProcessor,
+	}
+	s.mockShard.SetEngine(h)
+	h.workflowResetter = newWorkflowResetter(s.mockShard, historyCache, s.logger)
+	s.historyEngine = h
+
+	s.namespaceID = testNamespaceID
+	s.workflowID = "some random workflow ID"
+	s.baseRunID = uuid.New()
+	s.currentRunID = uuid.New()
+	s.baseBranchToken = []byte("some random base branch token")
+	s.currentBranchToken = []byte("some random current branch token")
+	s.resetBranchToken = []byte("some random reset branch token")
+	s.beforeResetVersion = int64(100)
+	s.afterResetVersion = int64(101)
+	s.decisionFinishEventID = int64(20)
+	s.baseNextEventID = int64(26)
+}
+
+func (s *workflowResetter2Suite) TearDownTest() {
+	s.controller.Finish()
+	s.mockShard.Finish(s.T())
+}
+
+func (s *workflowResetter2Suite) TestResetWorkflowExecution_TerminateCurrent_Local() {
+	s.testResetWorkflowExecutionTerminateCurrent(resetTestBranchTypeLocal)
+}
+
+func (s *workflowResetter2Suite) TestResetWorkflowExecution_TerminateCurrent_ReplicationState() {
+	s.testResetWorkflowExecutionTerminateCurrent(resetTestBranchTypeReplicationState)
+}
+
+func (s *workflowResetter2Suite) TestResetWorkflowExecution_TerminateCurrent_VersionHistories() {
+	s.testResetWorkflowExecutionTerminateCurrent(resetTestBranchTypeVersionHistories)
+}
+
+func (s *workflowResetter2Suite) TestResetWorkflowExecution_CurrentClosed_Local() {
+	s.testResetWorkflowExecutionCurrentClosed(resetTestBranchTypeLocal)
+}
+
+func (s *workflowResetter2Suite) TestResetWorkflowExecution_CurrentClosed_ReplicationState() {
+	s.testResetWorkflowExecutionCurrentClosed(resetTestBranchTypeReplicationState)
+}
+
+func (s *workflowResetter2Suite) TestResetWorkflowExecution_CurrentClosed_VersionHistories() {
+	s.testResetWorkflowExecutionCurrentClosed(resetTestBranchTypeVersionHistories)
+}
+
+func (s *workflowResetter2Suite) TestResetWorkflowExecution_WithRequestCancel_Local() {
+	s.testResetWorkflowExecutionWithRequestCancel(resetTestBranchTypeLocal)
+}
+
+func (s *workflowResetter2Suite) TestResetWorkflowExecution_WithRequestCancel_ReplicationState() {
+	s.testResetWorkflowExecutionWithRequestCancel(resetTestBranchTypeReplicationState)
+}
+
+func (s *workflowResetter2Suite) TestResetWorkflowExecution_WithRequestCancel_VersionHistories() {
+	s.testResetWorkflowExecutionWithRequestCancel(resetTestBranchTypeVersionHistories)
+}
+
+func (s *workflowResetter2Suite) TestResetWorkflowExecution_ReapplySignalNames() {
+	branchType := resetTestBranchTypeVersionHistories
+	s.setupNamespace(branchType, cluster.TestCurrentClusterName)
+	s.mockHistoryBranch(s.newHistory(s.getVersion(branchType), false))
+	s.mockForkHistoryBranch()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&persistence.AppendHistoryNodesResponse{Size: 0}, nil)
+	s.mockExecutionMgr.On("GetWorkflowExecution", s.newGetWorkflowExecutionRequest(s.baseRunID)).Return(s.newBaseWorkflowResponse(branchType), nil).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(&persistence.GetCurrentExecutionResponse{RunID: s.baseRunID}, nil).Once()
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.Anything).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+
+	request := s.newResetRequest()
+	request.ReapplyType = executiongenpb.ResetReapplyType_ResetReapplyType_Signal
+	request.ReapplySignalNames = []string{"sig3"}
+	_, err := s.historyEngine.ResetWorkflowExecution(context.Background(), request)
+	s.NoError(err)
+
+	events := s.getResetEvents()
+	s.Equal([]eventpb.EventType{
+		eventpb.EventType_DecisionTaskFailed,
+		eventpb.EventType_ActivityTaskFailed,
+		eventpb.EventType_WorkflowExecutionSignaled,
+		eventpb.EventType_DecisionTaskScheduled,
+	}, s.getEventTypes(events))
+	s.Equal("sig3", events[2].GetWorkflowExecutionSignaledEventAttributes().GetSignalName())
+}
+
+func (s *workflowResetter2Suite) TestResetWorkflowExecution_ReapplyNone() {
+	branchType := resetTestBranchTypeLocal
+	s.setupNamespace(branchType, cluster.TestCurrentClusterName)
+	s.mockHistoryBranch(s.newHistory(s.getVersion(branchType), false))
+	s.mockForkHistoryBranch()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&persistence.AppendHistoryNodesResponse{Size: 0}, nil)
+	s.mockExecutionMgr.On("GetWorkflowExecution", s.newGetWorkflowExecutionRequest(s.baseRunID)).Return(s.newBaseWorkflowResponse(branchType), nil).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(&persistence.GetCurrentExecutionResponse{RunID: s.baseRunID}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.Anything).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+
+	request := s.newResetRequest()
+	request.ReapplyType = executiongenpb.ResetReapplyType_ResetReapplyType_None
+	_, err := s.historyEngine.ResetWorkflowExecution(context.Background(), request)
+	s.NoError(err)
+
+	s.Equal([]eventpb.EventType{
+		eventpb.EventType_DecisionTaskFailed,
+		eventpb.EventType_ActivityTaskFailed,
+		eventpb.EventType_DecisionTaskScheduled,
+	}, s.getEventTypes(s.getResetEvents()))
+}
+
+func (s *workflowResetter2Suite) TestResetWorkflowExecution_NotActive() {
+	s.setupNamespace(resetTestBranchTypeReplicationState, cluster.TestAlternativeClusterName)
+
+	_, err := s.historyEngine.ResetWorkflowExecution(context.Background(), s.newResetRequest())
+	s.IsType(&serviceerror.NamespaceNotActive{}, err)
+}
+
+func (s *workflowResetter2Suite) TestReplicateResetWorkflow_ReplicationState() {
+	s.testReplicateResetWorkflow(false)
+}
+
+func (s *workflowResetter2Suite) TestReplicateResetWorkflow_BackfilledVersionHistories() {
+	s.testReplicateResetWorkflow(true)
+}
+
+func (s *workflowResetter2Suite) TestReplicateResetWorkflow_ForkVersionMismatch() {
+	branchType := resetTestBranchTypeReplicationState
+	s.mockClusterMetadata.EXPECT().ClusterNameForFailoverVersion(s.afterResetVersion).Return(cluster.TestAlternativeClusterName).AnyTimes()
+	s.setupNamespace(branchType, cluster.TestAlternativeClusterName)
+	s.mockHistoryBranch(s.newHistory(s.getVersion(branchType), false))
+	s.mockExecutionMgr.On("GetWorkflowExecution", s.newGetWorkflowExecutionRequest(s.baseRunID)).Return(s.newBaseWorkflowResponse(branchType), nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	resetRunID := uuid.New()
+	request := s.newReplicateResetRequest(resetRunID, s.afterResetVersion)
+
+	err := s.historyEngine.workflowResetter.replicateResetWorkflow(
+		context.Background(),
+		request,
+		s.namespaceID,
+		s.workflowID,
+		s.baseRunID,
+	)
+	s.IsType(&serviceerror.RetryTask{}, err)
+	s.Equal(resetRunID, err.(*serviceerror.RetryTask).RunId)
+	s.Equal(common.FirstEventID, err.(*serviceerror.RetryTask).NextEventId)
+}
+
+func (s *workflowResetter2Suite) testResetWorkflowExecutionTerminateCurrent(
+	branchType resetTestBranchType,
+) {
+
+	s.setupNamespace(branchType, cluster.TestCurrentClusterName)
+	s.mockHistoryBranch(s.newHistory(s.getVersion(branchType), false))
+	s.mockForkHistoryBranch()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&persistence.AppendHistoryNodesResponse{Size: 0}, nil)
+	s.mockExecutionMgr.On("GetWorkflowExecution", s.newGetWorkflowExecutionRequest(s.baseRunID)).Return(s.newBaseWorkflowResponse(branchType), nil).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(&persistence.GetCurrentExecutionResponse{RunID: s.currentRunID}, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", s.newGetWorkflowExecutionRequest(s.currentRunID)).Return(s.newCurrentWorkflowResponse(branchType), nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil)
+
+	response, err := s.historyEngine.ResetWorkflowExecution(context.Background(), s.newResetRequest())
+	s.NoError(err)
+	s.NotEmpty(response.GetRunId())
+
+	updateRequests := s.getUpdateRequests()
+	if branchType == resetTestBranchTypeVersionHistories {
+		s.Equal(1, len(updateRequests))
+	} else {
+		// the backfilled version histories of the base workflow are persisted first
+		s.Equal(2, len(updateRequests))
+		s.assertBackfilledBaseWorkflow(branchType, persistence.UpdateWorkflowModeBypassCurrent, updateRequests[0])
+	}
+
+	// the current workflow is terminated along with the creation of the reset workflow
+	updateRequest := updateRequests[len(updateRequests)-1]
+	s.Equal(persistence.UpdateWorkflowModeUpdateCurrent, updateRequest.Mode)
+	currentExecutionInfo := updateRequest.UpdateWorkflowMutation.ExecutionInfo
+	s.Equal(s.currentRunID, currentExecutionInfo.RunID)
+	s.Equal(executiongenpb.WorkflowExecutionState_WorkflowExecutionState_Completed, currentExecutionInfo.State)
+	s.Equal(executionpb.WorkflowExecutionStatus_Terminated, currentExecutionInfo.Status)
+	s.Equal(common.FirstEventID+1, currentExecutionInfo.NextEventID)
+
+	s.NotNil(updateRequest.NewWorkflowSnapshot)
+	s.assertResetWorkflow(branchType, response.GetRunId(), updateRequest.NewWorkflowSnapshot)
+}
+
+func (s *workflowResetter2Suite) testResetWorkflowExecutionCurrentClosed(
+	branchType resetTestBranchType,
+) {
+
+	s.setupNamespace(branchType, cluster.TestCurrentClusterName)
+	s.mockHistoryBranch(s.newHistory(s.getVersion(branchType), false))
+	s.mockForkHistoryBranch()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&persistence.AppendHistoryNodesResponse{Size: 0}, nil)
+	s.mockExecutionMgr.On("GetWorkflowExecution", s.newGetWorkflowExecutionRequest(s.baseRunID)).Return(s.newBaseWorkflowResponse(branchType), nil).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(&persistence.GetCurrentExecutionResponse{RunID: s.baseRunID}, nil).Once()
+	if branchType != resetTestBranchTypeVersionHistories {
+		s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+	}
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.Anything).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+
+	response, err := s.historyEngine.ResetWorkflowExecution(context.Background(), s.newResetRequest())
+	s.NoError(err)
+	s.NotEmpty(response.GetRunId())
+
+	updateRequests := s.getUpdateRequests()
+	if branchType == resetTestBranchTypeVersionHistories {
+		s.Empty(updateRequests)
+	} else {
+		s.Equal(1, len(updateRequests))
+		s.assertBackfilledBaseWorkflow(branchType, persistence.UpdateWorkflowModeUpdateCurrent, updateRequests[0])
+	}
+
+	// the reset workflow continues the closed current workflow
+	createRequest := s.getCreateRequest()
+	s.Equal(persistence.CreateWorkflowModeContinueAsNew, createRequest.Mode)
+	s.Equal(s.baseRunID, createRequest.PreviousRunID)
+	s.Equal(s.getVersion(branchType), createRequest.PreviousLastWriteVersion)
+	s.assertResetWorkflow(branchType, response.GetRunId(), &createRequest.NewWorkflowSnapshot)
+}
+
+func (s *workflowResetter2Suite) testResetWorkflowExecutionWithRequestCancel(
+	branchType resetTestBranchType,
+) {
+
+	s.setupNamespace(branchType, cluster.TestCurrentClusterName)
+	s.mockHistoryBranch(s.newHistory(s.getVersion(branchType), true))
+	s.mockForkHistoryBranch()
+	s.mockExecutionMgr.On("GetWorkflowExecution", s.newGetWorkflowExecutionRequest(s.baseRunID)).Return(s.newBaseWorkflowResponse(branchType), nil).Once()
+	s.mockExecutionMgr.On("GetCurrentExecution", mock.Anything).Return(&persistence.GetCurrentExecutionResponse{RunID: s.currentRunID}, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", s.newGetWorkflowExecutionRequest(s.currentRunID)).Return(s.newCurrentWorkflowResponse(branchType), nil).Once()
+	if branchType != resetTestBranchTypeVersionHistories {
+		s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+	}
+
+	_, err := s.historyEngine.ResetWorkflowExecution(context.Background(), s.newResetRequest())
+	s.IsType(&serviceerror.InvalidArgument{}, err)
+	s.EqualError(err, "it is not allowed resetting to a point that workflow has pending request cancel.")
+	for _, call := range s.mockHistoryV2Mgr.Calls {
+		s.NotEqual("AppendHistoryNodes", call.Method)
+	}
+	for _, call := range s.mockExecutionMgr.Calls {
+		s.NotEqual("CreateWorkflowExecution", call.Method)
+	}
+}
+
+func (s *workflowResetter2Suite) testReplicateResetWorkflow(
+	backfilled bool,
+) {
+
+	branchType := resetTestBranchTypeReplicationState
+	s.mockClusterMetadata.EXPECT().ClusterNameForFailoverVersion(s.afterResetVersion).Return(cluster.TestAlternativeClusterName).AnyTimes()
+	s.setupNamespace(branchType, cluster.TestAlternativeClusterName)
+	s.mockHistoryBranch(s.newHistory(s.getVersion(branchType), false))
+	s.mockForkHistoryBranch()
+	s.mockHistoryV2Mgr.On("AppendHistoryNodes", mock.Anything).Return(&persistence.AppendHistoryNodesResponse{Size: 0}, nil).Once()
+	baseResponse := s.newBaseWorkflowResponse(branchType)
+	if backfilled {
+		baseResponse.State.VersionHistories = s.newBackfilledVersionHistories(branchType)
+	} else {
+		s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+	}
+	s.mockExecutionMgr.On("GetWorkflowExecution", s.newGetWorkflowExecutionRequest(s.baseRunID)).Return(baseResponse, nil).Once()
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.Anything).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+
+	resetRunID := uuid.New()
+	request := s.newReplicateResetRequest(resetRunID, s.beforeResetVersion)
+	err := s.historyEngine.workflowResetter.replicateResetWorkflow(
+		context.Background(),
+		request,
+		s.namespaceID,
+		s.workflowID,
+		s.baseRunID,
+	)
+	s.NoError(err)
+
+	updateRequests := s.getUpdateRequests()
+	if backfilled {
+		s.Empty(updateRequests)
+	} else {
+		s.Equal(1, len(updateRequests))
+		s.assertBackfilledBaseWorkflow(branchType, persistence.UpdateWorkflowModeUpdateCurrent, updateRequests[0])
+	}
+
+	// the replicated events are persisted as is on the forked branch
+	s.Equal(request.History.Events, s.getResetEvents())
+
+	createRequest := s.getCreateRequest()
+	s.Equal(persistence.CreateWorkflowModeContinueAsNew, createRequest.Mode)
+	s.Equal(s.baseRunID, createRequest.PreviousRunID)
+	s.Equal(s.beforeResetVersion, createRequest.PreviousLastWriteVersion)
+
+	lastEventID := s.decisionFinishEventID + int64(len(request.History.Events)) - 1
+	snapshot := createRequest.NewWorkflowSnapshot
+	s.Equal(resetRunID, snapshot.ExecutionInfo.RunID)
+	s.Equal(lastEventID+1, snapshot.ExecutionInfo.NextEventID)
+	s.Equal(lastEventID, snapshot.ExecutionInfo.DecisionScheduleID)
+	s.Equal(common.EmptyEventID, snapshot.ExecutionInfo.DecisionStartedID)
+	s.Empty(snapshot.ReplicationTasks)
+	s.assertActivityIDs([]string{"actID2"}, snapshot.ActivityInfos)
+	s.assertTimerIDs([]string{"timerID1"}, snapshot.TimerInfos)
+
+	// the source cluster keeps replicating the reset workflow as a 2DC workflow
+	s.Equal(&persistence.ReplicationState{
+		StartVersion:     s.beforeResetVersion,
+		CurrentVersion:   s.afterResetVersion,
+		LastWriteVersion: s.afterResetVersion,
+		LastWriteEventID: lastEventID,
+		LastReplicationInfo: map[string]*replicationgenpb.ReplicationInfo{
+			cluster.TestAlternativeClusterName: {
+				Version:     s.afterResetVersion,
+				LastEventId: lastEventID,
+			},
+		},
+	}, snapshot.ReplicationState)
+	s.Equal(persistence.NewVersionHistories(persistence.NewVersionHistory(
+		s.resetBranchToken,
+		[]*persistence.VersionHistoryItem{
+			persistence.NewVersionHistoryItem(s.decisionFinishEventID-1, s.beforeResetVersion),
+			persistence.NewVersionHistoryItem(lastEventID, s.afterResetVersion),
+		},
+	)), snapshot.VersionHistories)
+}
+
+func (s *workflowResetter2Suite) assertBackfilledBaseWorkflow(
+	branchType resetTestBranchType,
+	updateMode persistence.UpdateWorkflowMode,
+	updateRequest *persistence.UpdateWorkflowExecutionRequest,
+) {
+
+	s.Equal(updateMode, updateRequest.Mode)
+	s.Nil(updateRequest.NewWorkflowSnapshot)
+	s.Equal(s.baseRunID, updateRequest.UpdateWorkflowMutation.ExecutionInfo.RunID)
+	s.Equal(s.baseNextEventID, updateRequest.UpdateWorkflowMutation.ExecutionInfo.NextEventID)
+	s.Empty(updateRequest.UpdateWorkflowMutation.ReplicationTasks)
+	s.Equal(s.newBackfilledVersionHistories(branchType), updateRequest.UpdateWorkflowMutation.VersionHistories)
+	if branchType == resetTestBranchTypeReplicationState {
+		s.NotNil(updateRequest.UpdateWorkflowMutation.ReplicationState)
+	} else {
+		s.Nil(updateRequest.UpdateWorkflowMutation.ReplicationState)
+	}
+}
+
+func (s *workflowResetter2Suite) assertResetWorkflow(
+	branchType resetTestBranchType,
+	resetRunID string,
+	snapshot *persistence.WorkflowSnapshot,
+) {
+
+	// 1. decision task failed
+	// 2. activity task failed, for the activity started before the reset point
+	// 3. signal, reapplied
+	// 4. signal, reapplied
+	// 5. decision task scheduled
+	events := s.getResetEvents()
+	s.Equal([]eventpb.EventType{
+		eventpb.EventType_DecisionTaskFailed,
+		eventpb.EventType_ActivityTaskFailed,
+		eventpb.EventType_WorkflowExecutionSignaled,
+		eventpb.EventType_WorkflowExecutionSignaled,
+		eventpb.EventType_DecisionTaskScheduled,
+	}, s.getEventTypes(events))
+	resetVersion := s.getResetVersion(branchType)
+	for i, event := range events {
+		s.Equal(s.decisionFinishEventID+int64(i), event.GetEventId())
+		s.Equal(resetVersion, event.GetVersion())
+	}
+	decisionFailedAttr := events[0].GetDecisionTaskFailedEventAttributes()
+	s.Equal(eventpb.DecisionTaskFailedCause_ResetWorkflow, decisionFailedAttr.GetCause())
+	s.Equal(s.baseRunID, decisionFailedAttr.GetBaseRunId())
+	s.Equal(resetRunID, decisionFailedAttr.GetNewRunId())
+	s.Equal(s.getVersion(branchType), decisionFailedAttr.GetForkEventVersion())
+	s.Equal("sig2", events[2].GetWorkflowExecutionSignaledEventAttributes().GetSignalName())
+	s.Equal("sig3", events[3].GetWorkflowExecutionSignaledEventAttributes().GetSignalName())
+
+	lastEventID := events[len(events)-1].GetEventId()
+	s.Equal(resetRunID, snapshot.ExecutionInfo.RunID)
+	s.Equal(lastEventID+1, snapshot.ExecutionInfo.NextEventID)
+	s.Equal(lastEventID, snapshot.ExecutionInfo.DecisionScheduleID)
+	s.Equal(common.EmptyEventID, snapshot.ExecutionInfo.DecisionStartedID)
+	s.assertActivityIDs([]string{"actID2"}, snapshot.ActivityInfos)
+	s.assertTimerIDs([]string{"timerID1"}, snapshot.TimerInfos)
+	s.Empty(snapshot.ChildExecutionInfos)
+	s.Empty(snapshot.RequestCancelInfos)
+	s.Empty(snapshot.SignalInfos)
+
+	hasDecisionTask := false
+	for _, task := range snapshot.TransferTasks {
+		if task.GetType() == commongenpb.TaskType_TransferDecisionTask {
+			hasDecisionTask = true
+		}
+	}
+	s.True(hasDecisionTask)
+
+	// the reset workflow is always created with version histories, and keeps replicating
+	// as a 2DC workflow if the base workflow does, like on the clusters the reset is replicated to
+	if branchType == resetTestBranchTypeReplicationState {
+		s.Equal(&persistence.ReplicationState{
+			StartVersion:        s.beforeResetVersion,
+			CurrentVersion:      resetVersion,
+			LastWriteVersion:    resetVersion,
+			LastWriteEventID:    lastEventID,
+			LastReplicationInfo: map[string]*replicationgenpb.ReplicationInfo{},
+		}, snapshot.ReplicationState)
+	} else {
+		s.Nil(snapshot.ReplicationState)
+	}
+	s.Equal(persistence.NewVersionHistories(persistence.NewVersionHistory(
+		s.resetBranchToken,
+		[]*persistence.VersionHistoryItem{
+			persistence.NewVersionHistoryItem(s.decisionFinishEventID-1, s.getVersion(branchType)),
+			persistence.NewVersionHistoryItem(lastEventID, resetVersion),
+		},
+	)), snapshot.VersionHistories)
+}
+
+func (s *workflowResetter2Suite) assertActivityIDs(
+	activityIDs []string,
+	activityInfos []*persistence.ActivityInfo,
+) {
+
+	var actualActivityIDs []string
+	for _, activityInfo := range activityInfos {
+		actualActivityIDs = append(actualActivityIDs, activityInfo.ActivityID)
+	}
+	s.ElementsMatch(activityIDs, actualActivityIDs)
+}
+
+func (s *workflowResetter2Suite) assertTimerIDs(
+	timerIDs []string,
+	timerInfos []*persistenceblobs.TimerInfo,
+) {
+
+	var actualTimerIDs []string
+	for _, timerInfo := range timerInfos {
+		actualTimerIDs = append(actualTimerIDs, timerInfo.GetTimerId())
+	}
+	s.ElementsMatch(timerIDs, actualTimerIDs)
+}
+
+func (s *workflowResetter2Suite) setupNamespace(
+	branchType resetTestBranchType,
+	activeClusterName string,
+) {
+
+	namespaceEntry := cache.NewLocalNamespaceCacheEntryForTest(
+		&persistenceblobs.NamespaceInfo{Id: s.namespaceID, Name: testNamespace},
+		&persistenceblobs.NamespaceConfig{RetentionDays: 1},
+		cluster.TestCurrentClusterName,
+		nil,
+	)
+	if branchType != resetTestBranchTypeLocal {
+		namespaceEntry = cache.NewGlobalNamespaceCacheEntryForTest(
+			&persistenceblobs.NamespaceInfo{Id: s.namespaceID, Name: testNamespace},
+			&persistenceblobs.NamespaceConfig{RetentionDays: 1},
+			&persistenceblobs.NamespaceReplicationConfig{
+				ActiveClusterName: activeClusterName,
+				Clusters: []string{
+					cluster.TestCurrentClusterName,
+					cluster.TestAlternativeClusterName,
+				},
+			},
+			s.afterResetVersion,
+			cluster.GetTestClusterMetadata(true, true),
+		)
+	}
+	s.mockNamespaceCache.EXPECT().GetNamespaceByID(gomock.Any()).Return(namespaceEntry, nil).AnyTimes()
+	s.mockNamespaceCache.EXPECT().GetNamespace(gomock.Any()).Return(namespaceEntry, nil).AnyTimes()
+	s.mockClusterMetadata.EXPECT().ClusterNameForFailoverVersion(gomock.Any()).Return(cluster.TestCurrentClusterName).AnyTimes()
+}
+
+func (s *workflowResetter2Suite) mockHistoryBranch(
+	history []*eventpb.History,
+) {
+
+	s.mockHistoryV2Mgr.On("ReadHistoryBranchByBatch", mock.MatchedBy(func(request *persistence.ReadHistoryBranchRequest) bool {
+		return bytes.Equal(s.baseBranchToken, request.BranchToken)
+	})).Return(func(request *persistence.ReadHistoryBranchRequest) *persistence.ReadHistoryBranchByBatchResponse {
+		var batches []*eventpb.History
+		for _, batch := range history {
+			if batch.Events[0].GetEventId() >= request.MinEventID &&
+				batch.Events[len(batch.Events)-1].GetEventId() < request.MaxEventID {
+				batches = append(batches, batch)
+			}
+		}
+		return &persistence.ReadHistoryBranchByBatchResponse{
+			History:       batches,
+			NextPageToken: nil,
+		}
+	}, nil)
+
+	s.mockEventsCache.EXPECT().getEvent(
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+	).DoAndReturn(func(namespaceID, workflowID, runID string, firstEventID, eventID int64, branchToken []byte) (*eventpb.HistoryEvent, error) {
+		for _, batch := range history {
+			for _, event := range batch.Events {
+				if event.GetEventId() == eventID {
+					return event, nil
+				}
+			}
+		}
+		return nil, serviceerror.NewNotFound("event not found")
+	}).AnyTimes()
+}
+
+func (s *workflowResetter2Suite) mockForkHistoryBranch() {
+	s.mockHistoryV2Mgr.On("ForkHistoryBranch", mock.MatchedBy(func(request *persistence.ForkHistoryBranchRequest) bool {
+		return bytes.Equal(s.baseBranchToken, request.ForkBranchToken) &&
+			request.ForkNodeID == s.decisionFinishEventID
+	})).Return(&persistence.ForkHistoryBranchResponse{
+		NewBranchToken: s.resetBranchToken,
+	}, nil).Once()
+}
+
+func (s *workflowResetter2Suite) getUpdateRequests() []*persistence.UpdateWorkflowExecutionRequest {
+	var requests []*persistence.UpdateWorkflowExecutionRequest
+	for _, call := range s.mockExecutionMgr.Calls {
+		if call.Method == "UpdateWorkflowExecution" {
+			requests = append(requests, call.Arguments[0].(*persistence.UpdateWorkflowExecutionRequest))
+		}
+	}
+	return requests
+}
+
+func (s *workflowResetter2Suite) getCreateRequest() *persistence.CreateWorkflowExecutionRequest {
+	for _, call := range s.mockExecutionMgr.Calls {
+		if call.Method == "CreateWorkflowExecution" {
+			return call.Arguments[0].(*persistence.CreateWorkflowExecutionRequest)
+		}
+	}
+	s.Fail("CreateWorkflowExecution not called")
+	return nil
+}
+
+func (s *workflowResetter2Suite) getResetEvents() []*eventpb.HistoryEvent {
+	var events []*eventpb.HistoryEvent
+	for _, call := range s.mockHistoryV2Mgr.Calls {
+		if call.Method != "AppendHistoryNodes" {
+			continue
+		}
+		request := call.Arguments[0].(*persistence.AppendHistoryNodesRequest)
+		if bytes.Equal(s.resetBranchToken, request.BranchToken) {
+			events = append(events, request.Events...)
+		}
+	}
+	return events
+}
+
+func (s *workflowResetter2Suite) getEventTypes(
+	events []*eventpb.HistoryEvent,
+) []eventpb.EventType {
+
+	var eventTypes []eventpb.EventType
+	for _, event := range events {
+		eventTypes = append(eventTypes, event.GetEventType())
+	}
+	return eventTypes
+}
+
+func (s *workflowResetter2Suite) getVersion(
+	branchType resetTestBranchType,
+) int64 {
+
+	if branchType == resetTestBranchTypeLocal {
+		return common.EmptyVersion
+	}
+	return s.beforeResetVersion
+}
+
+func (s *workflowResetter2Suite) getResetVersion(
+	branchType resetTestBranchType,
+) int64 {
+
+	if branchType == resetTestBranchTypeLocal {
+		return common.EmptyVersion
+	}
+	return s.afterResetVersion
+}
+
+func (s *workflowResetter2Suite) newResetRequest() *historyservice.ResetWorkflowExecutionRequest {
+	return &historyservice.ResetWorkflowExecutionRequest{
+		NamespaceId: s.namespaceID,
+		ResetRequest: &workflowservice.ResetWorkflowExecutionRequest{
+			Namespace: testNamespace,
+			WorkflowExecution: &commonpb.WorkflowExecution{
+				WorkflowId: s.workflowID,
+				RunId:      s.baseRunID,
+			},
+			Reason:                "some random reset reason",
+			DecisionFinishEventId: s.decisionFinishEventID,
+			RequestId:             uuid.New(),
+		},
+	}
+}
+
+func (s *workflowResetter2Suite) newReplicateResetRequest(
+	resetRunID string,
+	forkEventVersion int64,
+) *historyservice.ReplicateEventsRequest {
+
+	timestamp := time.Now().UnixNano()
+	events := []*eventpb.HistoryEvent{
+		{
+			EventId:   s.decisionFinishEventID,
+			Version:   s.afterResetVersion,
+			Timestamp: timestamp,
+			EventType: eventpb.EventType_DecisionTaskFailed,
+			Attributes: &eventpb.HistoryEvent_DecisionTaskFailedEventAttributes{DecisionTaskFailedEventAttributes: &eventpb.DecisionTaskFailedEventAttributes{
+				ScheduledEventId: s.decisionFinishEventID - 2,
+				StartedEventId:   s.decisionFinishEventID - 1,
+				Cause:            eventpb.DecisionTaskFailedCause_ResetWorkflow,
+				Failure:          failure.NewResetWorkflowFailure("some random reset reason", nil),
+				BaseRunId:        s.baseRunID,
+				NewRunId:         resetRunID,
+				ForkEventVersion: forkEventVersion,
+			}},
+		},
+		{
+			EventId:   s.decisionFinishEventID + 1,
+			Version:   s.afterResetVersion,
+			Timestamp: timestamp,
+			EventType: eventpb.EventType_ActivityTaskFailed,
+			Attributes: &eventpb.HistoryEvent_ActivityTaskFailedEventAttributes{ActivityTaskFailedEventAttributes: &eventpb.ActivityTaskFailedEventAttributes{
+				ScheduledEventId: 13,
+				StartedEventId:   16,
+				Failure:          failure.NewResetWorkflowFailure("some random reset reason", nil),
+			}},
+		},
+		{
+			EventId:   s.decisionFinishEventID + 2,
+			Version:   s.afterResetVersion,
+			Timestamp: timestamp,
+			EventType: eventpb.EventType_WorkflowExecutionSignaled,
+			Attributes: &eventpb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &eventpb.WorkflowExecutionSignaledEventAttributes{
+				SignalName: "sig2",
+			}},
+		},
+		{
+			EventId:   s.decisionFinishEventID + 3,
+			Version:   s.afterResetVersion,
+			Timestamp: timestamp,
+			EventType: eventpb.EventType_DecisionTaskScheduled,
+			Attributes: &eventpb.HistoryEvent_DecisionTaskScheduledEventAttributes{DecisionTaskScheduledEventAttributes: &eventpb.DecisionTaskScheduledEventAttributes{
+				TaskList:                   &tasklistpb.TaskList{Name: "some random task list"},
+				StartToCloseTimeoutSeconds: 100,
+			}},
+		},
+	}
+
+	return &historyservice.ReplicateEventsRequest{
+		SourceCluster: cluster.TestAlternativeClusterName,
+		NamespaceId:   s.namespaceID,
+		WorkflowExecution: &commonpb.WorkflowExecution{
+			WorkflowId: s.workflowID,
+			RunId:      resetRunID,
+		},
+		FirstEventId:  s.decisionFinishEventID,
+		NextEventId:   s.decisionFinishEventID + int64(len(events)),
+		Version:       s.afterResetVersion,
+		History:       &eventpb.History{Events: events},
+		ResetWorkflow: true,
+	}
+}
+
+func (s *workflowResetter2Suite) newGetWorkflowExecutionRequest(
+	runID string,
+) *persistence.GetWorkflowExecutionRequest {
+
+	return &persistence.GetWorkflowExecutionRequest{
+		NamespaceID: s.namespaceID,
+		Execution: commonpb.WorkflowExecution{
+			WorkflowId: s.workflowID,
+			RunId:      runID,
+		},
+	}
+}
+
+func (s *workflowResetter2Suite) newBaseWorkflowResponse(
+	branchType resetTestBranchType,
+) *persistence.GetWorkflowExecutionResponse {
+
+	lastEventID := s.baseNextEventID - 1
+	executionInfo := &persistence.WorkflowExecutionInfo{
+		NamespaceID:            s.namespaceID,
+		WorkflowID:             s.workflowID,
+		RunID:                  s.baseRunID,
+		WorkflowTypeName:       "some random workflow type",
+		TaskList:               "some random task list",
+		NextEventID:            s.baseNextEventID,
+		LastFirstEventID:       lastEventID,
+		CompletionEventBatchID: lastEventID,
+		DecisionVersion:        common.EmptyVersion,
+		DecisionScheduleID:     common.EmptyEventID,
+		DecisionStartedID:      common.EmptyEventID,
+		State:                  executiongenpb.WorkflowExecutionState_WorkflowExecutionState_Completed,
+		Status:                 executionpb.WorkflowExecutionStatus_Terminated,
+	}
+	state := &persistence.WorkflowMutableState{
+		ExecutionInfo:  executionInfo,
+		ExecutionStats: &persistence.ExecutionStats{},
+	}
+
+	switch branchType {
+	case resetTestBranchTypeLocal:
+		executionInfo.BranchToken = s.baseBranchToken
+	case resetTestBranchTypeReplicationState:
+		executionInfo.BranchToken = s.baseBranchToken
+		state.ReplicationState = &persistence.ReplicationState{
+			StartVersion:        s.beforeResetVersion,
+			CurrentVersion:      s.beforeResetVersion,
+			LastWriteVersion:    s.beforeResetVersion,
+			LastWriteEventID:    lastEventID,
+			LastReplicationInfo: map[string]*replicationgenpb.ReplicationInfo{},
+		}
+	case resetTestBranchTypeVersionHistories:
+		state.VersionHistories = s.newBackfilledVersionHistories(branchType)
+	}
+	return &persistence.GetWorkflowExecutionResponse{State: state}
+}
+
+func (s *workflowResetter2Suite) newCurrentWorkflowResponse(
+	branchType resetTestBranchType,
+) *persistence.GetWorkflowExecutionResponse {
+
+	executionInfo := &persistence.WorkflowExecutionInfo{
+		NamespaceID:        s.namespaceID,
+		WorkflowID:         s.workflowID,
+		RunID:              s.currentRunID,
+		WorkflowTypeName:   "some random workflow type",
+		TaskList:           "some random task list",
+		NextEventID:        common.FirstEventID,
+		DecisionVersion:    common.EmptyVersion,
+		DecisionScheduleID: common.EmptyEventID,
+		DecisionStartedID:  common.EmptyEventID,
+		State:              executiongenpb.WorkflowExecutionState_WorkflowExecutionState_Running,
+		Status:             executionpb.WorkflowExecutionStatus_Running,
+	}
+	state := &persistence.WorkflowMutableState{
+		ExecutionInfo:  executionInfo,
+		ExecutionStats: &persistence.ExecutionStats{},
+	}
+
+	switch branchType {
+	case resetTestBranchTypeLocal:
+		executionInfo.BranchToken = s.currentBranchToken
+	case resetTestBranchTypeReplicationState:
+		executionInfo.BranchToken = s.currentBranchToken
+		state.ReplicationState = &persistence.ReplicationState{
+			StartVersion:        s.beforeResetVersion,
+			CurrentVersion:      s.beforeResetVersion,
+			LastWriteVersion:    common.EmptyVersion,
+			LastWriteEventID:    common.EmptyEventID,
+			LastReplicationInfo: map[string]*replicationgenpb.ReplicationInfo{},
+		}
+	case resetTestBranchTypeVersionHistories:
+		state.VersionHistories = persistence.NewVersionHistories(persistence.NewVersionHistory(
+			s.currentBranchToken,
+			nil,
+		))
+	}
+	return &persistence.GetWorkflowExecutionResponse{State: state}
+}
+
+func (s *workflowResetter2Suite) newBackfilledVersionHistories(
+	branchType resetTestBranchType,
+) *persistence.VersionHistories {
+
+	return persistence.NewVersionHistories(persistence.NewVersionHistory(
+		s.baseBranchToken,
+		[]*persistence.VersionHistoryItem{
+			persistence.NewVersionHistoryItem(s.baseNextEventID-1, s.getVersion(branchType)),
+		},
+	))
+}
+
+// newHistory returns the history of the base workflow,
+// the reset point is the decision task completed event 20
+func (s *workflowResetter2Suite) newHistory(
+	version int64,
+	withRequestCancel bool,
+) []*eventpb.History {
+
+	taskList := &tasklistpb.TaskList{Name: "some random task list"}
+	newActivityScheduledEvent := func(eventID int64, activityID string, decisionTaskCompletedEventID int64) *eventpb.HistoryEvent {
+		return &eventpb.HistoryEvent{
+			EventId:   eventID,
+			Version:   version,
+			EventType: eventpb.EventType_ActivityTaskScheduled,
+			Attributes: &eventpb.HistoryEvent_ActivityTaskScheduledEventAttributes{ActivityTaskScheduledEventAttributes: &eventpb.ActivityTaskScheduledEventAttributes{
+				ActivityId:                    activityID,
+				ActivityType:                  &commonpb.ActivityType{Name: "some random activity type"},
+				TaskList:                      taskList,
+				ScheduleToCloseTimeoutSeconds: 1000,
+				ScheduleToStartTimeoutSeconds: 2000,
+				StartToCloseTimeoutSeconds:    3000,
+				HeartbeatTimeoutSeconds:       4000,
+				DecisionTaskCompletedEventId:  decisionTaskCompletedEventID,
+			}},
+		}
+	}
+	newActivityStartedEvent := func(eventID int64, scheduledEventID int64) *eventpb.HistoryEvent {
+		return &eventpb.HistoryEvent{
+			EventId:   eventID,
+			Version:   version,
+			EventType: eventpb.EventType_ActivityTaskStarted,
+			Attributes: &eventpb.HistoryEvent_ActivityTaskStartedEventAttributes{ActivityTaskStartedEventAttributes: &eventpb.ActivityTaskStartedEventAttributes{
+				ScheduledEventId: scheduledEventID,
+			}},
+		}
+	}
+	newTimerStartedEvent := func(eventID int64, timerID string, decisionTaskCompletedEventID int64) *eventpb.HistoryEvent {
+		return &eventpb.HistoryEvent{
+			EventId:   eventID,
+			Version:   version,
+			EventType: eventpb.EventType_TimerStarted,
+			Attributes: &eventpb.HistoryEvent_TimerStartedEventAttributes{TimerStartedEventAttributes: &eventpb.TimerStartedEventAttributes{
+				TimerId:                      timerID,
+				StartToFireTimeoutSeconds:    100,
+				DecisionTaskCompletedEventId: decisionTaskCompletedEventID,
+			}},
+		}
+	}
+	newDecisionScheduledEvent := func(eventID int64) *eventpb.HistoryEvent {
+		return &eventpb.HistoryEvent{
+			EventId:   eventID,
+			Version:   version,
+			EventType: eventpb.EventType_DecisionTaskScheduled,
+			Attributes: &eventpb.HistoryEvent_DecisionTaskScheduledEventAttributes{DecisionTaskScheduledEventAttributes: &eventpb.DecisionTaskScheduledEventAttributes{
+				TaskList:                   taskList,
+				StartToCloseTimeoutSeconds: 100,
+			}},
+		}
+	}
+	newDecisionStartedEvent := func(eventID int64, scheduledEventID int64) *eventpb.HistoryEvent {
+		return &eventpb.HistoryEvent{
+			EventId:   eventID,
+			Version:   version,
+			EventType: eventpb.EventType_DecisionTaskStarted,
+			Attributes: &eventpb.HistoryEvent_DecisionTaskStartedEventAttributes{DecisionTaskStartedEventAttributes: &eventpb.DecisionTaskStartedEventAttributes{
+				ScheduledEventId: scheduledEventID,
+			}},
+		}
+	}
+	newDecisionCompletedEvent := func(eventID int64, scheduledEventID int64, startedEventID int64) *eventpb.HistoryEvent {
+		return &eventpb.HistoryEvent{
+			EventId:   eventID,
+			Version:   version,
+			EventType: eventpb.EventType_DecisionTaskCompleted,
+			Attributes: &eventpb.HistoryEvent_DecisionTaskCompletedEventAttributes{DecisionTaskCompletedEventAttributes: &eventpb.DecisionTaskCompletedEventAttributes{
+				ScheduledEventId: scheduledEventID,
+				StartedEventId:   startedEventID,
+			}},
+		}
+	}
+	newSignaledEvent := func(eventID int64, signalName string) *eventpb.HistoryEvent {
+		return &eventpb.HistoryEvent{
+			EventId:   eventID,
+			Version:   version,
+			EventType: eventpb.EventType_WorkflowExecutionSignaled,
+			Attributes: &eventpb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &eventpb.WorkflowExecutionSignaledEventAttributes{
+				SignalName: signalName,
+			}},
+		}
+	}
+
+	// timerID1 is pending at the reset point, actID1 is started and actID2 is scheduled
+	event15 := newTimerStartedEvent(15, "timerID1", 12)
+	if withRequestCancel {
+		event15 = &eventpb.HistoryEvent{
+			EventId:   15,
+			Version:   version,
+			EventType: eventpb.EventType_RequestCancelExternalWorkflowExecutionInitiated,
+			Attributes: &eventpb.HistoryEvent_RequestCancelExternalWorkflowExecutionInitiatedEventAttributes{RequestCancelExternalWorkflowExecutionInitiatedEventAttributes: &eventpb.RequestCancelExternalWorkflowExecutionInitiatedEventAttributes{
+				DecisionTaskCompletedEventId: 12,
+				Namespace:                    testNamespace,
+				WorkflowExecution: &commonpb.WorkflowExecution{
+					WorkflowId: "some random target workflow ID",
+					RunId:      uuid.New(),
+				},
+			}},
+		}
+	}
+
+	history := []*eventpb.History{
+		{Events: []*eventpb.HistoryEvent{
+			{
+				EventId:   1,
+				Version:   version,
+				EventType: eventpb.EventType_WorkflowExecutionStarted,
+				Attributes: &eventpb.HistoryEvent_WorkflowExecutionStartedEventAttributes{WorkflowExecutionStartedEventAttributes: &eventpb.WorkflowExecutionStartedEventAttributes{
+					WorkflowType:                    &commonpb.WorkflowType{Name: "some random workflow type"},
+					TaskList:                        taskList,
+					Input:                           payloads.EncodeString("some random input"),
+					WorkflowExecutionTimeoutSeconds: 1000,
+					WorkflowRunTimeoutSeconds:       500,
+					WorkflowTaskTimeoutSeconds:      100,
+				}},
+			},
+			newDecisionScheduledEvent(2),
+		}},
+		{Events: []*eventpb.HistoryEvent{newDecisionStartedEvent(3, 2)}},
+		{Events: []*eventpb.HistoryEvent{
+			newDecisionCompletedEvent(4, 2, 3),
+			newActivityScheduledEvent(5, "actID0", 4),
+			newTimerStartedEvent(6, "timerID0", 4),
+		}},
+		{Events: []*eventpb.HistoryEvent{newActivityStartedEvent(7, 5)}},
+		{Events: []*eventpb.HistoryEvent{
+			{
+				EventId:   8,
+				Version:   version,
+				EventType: eventpb.EventType_ActivityTaskCompleted,
+				Attributes: &eventpb.HistoryEvent_ActivityTaskCompletedEventAttributes{ActivityTaskCompletedEventAttributes: &eventpb.ActivityTaskCompletedEventAttributes{
+					ScheduledEventId: 5,
+					StartedEventId:   7,
+				}},
+			},
+		}},
+		{Events: []*eventpb.HistoryEvent{
+			{
+				EventId:   9,
+				Version:   version,
+				EventType: eventpb.EventType_TimerFired,
+				Attributes: &eventpb.HistoryEvent_TimerFiredEventAttributes{TimerFiredEventAttributes: &eventpb.TimerFiredEventAttributes{
+					TimerId:        "timerID0",
+					StartedEventId: 6,
+				}},
+			},
+			newDecisionScheduledEvent(10),
+		}},
+		{Events: []*eventpb.HistoryEvent{newDecisionStartedEvent(11, 10)}},
+		{Events: []*eventpb.HistoryEvent{
+			newDecisionCompletedEvent(12, 10, 11),
+			newActivityScheduledEvent(13, "actID1", 12),
+			newActivityScheduledEvent(14, "actID2", 12),
+			event15,
+		}},
+		{Events: []*eventpb.HistoryEvent{newActivityStartedEvent(16, 13)}},
+		{Events: []*eventpb.HistoryEvent{
+			newSignaledEvent(17, "sig1"),
+			newDecisionScheduledEvent(18),
+		}},
+		{Events: []*eventpb.HistoryEvent{newDecisionStartedEvent(19, 18)}},
+		// reset point
+		{Events: []*eventpb.HistoryEvent{
+			newDecisionCompletedEvent(20, 18, 19),
+			newTimerStartedEvent(21, "timerID2", 20),
+		}},
+		{Events: []*eventpb.HistoryEvent{newActivityStartedEvent(22, 14)}},
+		{Events: []*eventpb.HistoryEvent{newSignaledEvent(23, "sig2")}},
+		{Events: []*eventpb.HistoryEvent{newSignaledEvent(24, "sig3")}},
+		{Events: []*eventpb.HistoryEvent{
+			{
+				EventId:   25,
+				Version:   version,
+				EventType: eventpb.EventType_WorkflowExecutionTerminated,
+				Attributes: &eventpb.HistoryEvent_WorkflowExecutionTerminatedEventAttributes{WorkflowExecutionTerminatedEventAttributes: &eventpb.WorkflowExecutionTerminatedEventAttributes{
+					Reason: "some random terminate reason",
+				}},
+			},
+		}},
+	}
+
+	eventID := int64(0)
+	timestamp := time.Now().UnixNano()
+	for _, batch := range history {
+		for _, event := range batch.Events {
+			eventID++
+			s.Equal(eventID, event.GetEventId())
+			event.Timestamp = timestamp
+		}
+	}
+	s.Equal(s.baseNextEventID-1, eventID)
+	return history
+}
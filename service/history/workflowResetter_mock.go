@@ -31,6 +31,9 @@ package history
 import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
+	execution "github.com/temporalio/temporal/.gen/proto/execution"
+	historyservice "github.com/temporalio/temporal/.gen/proto/historyservice"
+	persistence "github.com/temporalio/temporal/common/persistence"
 	event "go.temporal.io/temporal-proto/event"
 	reflect "reflect"
 )
@@ -59,15 +62,44 @@ func (m *MockworkflowResetter) EXPECT() *MockworkflowResetterMockRecorder {
 }
 
 // resetWorkflow mocks base method.
-func (m *MockworkflowResetter) resetWorkflow(ctx context.Context, namespaceID, workflowID, baseRunID string, baseBranchToken []byte, baseRebuildLastEventID, baseRebuildLastEventVersion, baseNextEventID int64, resetRunID, resetRequestID string, currentWorkflow nDCWorkflow, resetReason string, additionalReapplyEvents []*event.HistoryEvent) error {
+func (m *MockworkflowResetter) resetWorkflow(ctx context.Context, namespaceID, workflowID, baseRunID string, baseBranchToken []byte, baseRebuildLastEventID, baseRebuildLastEventVersion, baseNextEventID int64, baseReplicationState *persistence.ReplicationState, baseCompletionCallbacks []*execution.CompletionCallbackInfo, resetRunID, resetRequestID string, currentWorkflow nDCWorkflow, resetReason string, additionalReapplyEvents []*event.HistoryEvent, reapplyOptions *resetReapplyOptions) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "resetWorkflow", ctx, namespaceID, workflowID, baseRunID, baseBranchToken, baseRebuildLastEventID, baseRebuildLastEventVersion, baseNextEventID, resetRunID, resetRequestID, currentWorkflow, resetReason, additionalReapplyEvents)
+	ret := m.ctrl.Call(m, "resetWorkflow", ctx, namespaceID, workflowID, baseRunID, baseBranchToken, baseRebuildLastEventID, baseRebuildLastEventVersion, baseNextEventID, baseReplicationState, baseCompletionCallbacks, resetRunID, resetRequestID, currentWorkflow, resetReason, additionalReapplyEvents, reapplyOptions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // resetWorkflow indicates an expected call of resetWorkflow.
-func (mr *MockworkflowResetterMockRecorder) resetWorkflow(ctx, namespaceID, workflowID, baseRunID, baseBranchToken, baseRebuildLastEventID, baseRebuildLastEventVersion, baseNextEventID, resetRunID, resetRequestID, currentWorkflow, resetReason, additionalReapplyEvents interface{}) *gomock.Call {
+func (mr *MockworkflowResetterMockRecorder) resetWorkflow(ctx, namespaceID, workflowID, baseRunID, baseBranchToken, baseRebuildLastEventID, baseRebuildLastEventVersion, baseNextEventID, baseReplicationState, baseCompletionCallbacks, resetRunID, resetRequestID, currentWorkflow, resetReason, additionalReapplyEvents, reapplyOptions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "resetWorkflow", reflect.TypeOf((*MockworkflowResetter)(nil).resetWorkflow), ctx, namespaceID, workflowID, baseRunID, baseBranchToken, baseRebuildLastEventID, baseRebuildLastEventVersion, baseNextEventID, resetRunID, resetRequestID, currentWorkflow, resetReason, additionalReapplyEvents)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "resetWorkflow", reflect.TypeOf((*MockworkflowResetter)(nil).resetWorkflow), ctx, namespaceID, workflowID, baseRunID, baseBranchToken, baseRebuildLastEventID, baseRebuildLastEventVersion, baseNextEventID, baseReplicationState, baseCompletionCallbacks, resetRunID, resetRequestID, currentWorkflow, resetReason, additionalReapplyEvents, reapplyOptions)
+}
+
+// backfillVersionHistories mocks base method.
+func (m *MockworkflowResetter) backfillVersionHistories(context workflowExecutionContext, mutableState mutableState, isCurrentWorkflow bool) (*persistence.VersionHistories, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "backfillVersionHistories", context, mutableState, isCurrentWorkflow)
+	ret0, _ := ret[0].(*persistence.VersionHistories)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// backfillVersionHistories indicates an expected call of backfillVersionHistories.
+func (mr *MockworkflowResetterMockRecorder) backfillVersionHistories(context, mutableState, isCurrentWorkflow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "backfillVersionHistories", reflect.TypeOf((*MockworkflowResetter)(nil).backfillVersionHistories), context, mutableState, isCurrentWorkflow)
+}
+
+// replicateResetWorkflow mocks base method.
+func (m *MockworkflowResetter) replicateResetWorkflow(ctx context.Context, request *historyservice.ReplicateEventsRequest, namespaceID, workflowID, currentRunID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "replicateResetWorkflow", ctx, request, namespaceID, workflowID, currentRunID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// replicateResetWorkflow indicates an expected call of replicateResetWorkflow.
+func (mr *MockworkflowResetterMockRecorder) replicateResetWorkflow(ctx, request, namespaceID, workflowID, currentRunID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "replicateResetWorkflow", reflect.TypeOf((*MockworkflowResetter)(nil).replicateResetWorkflow), ctx, request, namespaceID, workflowID, currentRunID)
 }
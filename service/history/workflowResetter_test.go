@@ -27,16 +27,22 @@ package history
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pborman/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	eventpb "go.temporal.io/temporal-proto/event"
+	executionpb "go.temporal.io/temporal-proto/execution"
+	namespacepb "go.temporal.io/temporal-proto/namespace"
 	"go.temporal.io/temporal-proto/workflowservice"
 
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common"
+	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/collection"
 	"github.com/temporalio/temporal/common/definition"
 	"github.com/temporalio/temporal/common/failure"
@@ -221,6 +227,7 @@ func (s *workflowResetterSuite) TestReplayResetWorkflow() {
 	resetRequestID := uuid.New()
 	resetHistorySize := int64(4411)
 	resetMutableState := NewMockmutableState(s.controller)
+	resetCompletionCallbacks := newCompletionCallbacks([]string{"http://localhost:8080/callback"})
 
 	shardId := s.mockShard.GetShardID()
 	s.mockHistoryV2Mgr.On("ForkHistoryBranch", &persistence.ForkHistoryBranchRequest{
@@ -248,6 +255,7 @@ func (s *workflowResetterSuite) TestReplayResetWorkflow() {
 		),
 		resetBranchToken,
 		resetRequestID,
+		resetCompletionCallbacks,
 	).Return(resetMutableState, resetHistorySize, nil).Times(1)
 
 	resetWorkflow, err := s.workflowResetter.replayResetWorkflow(
@@ -260,6 +268,7 @@ func (s *workflowResetterSuite) TestReplayResetWorkflow() {
 		baseRebuildLastEventVersion,
 		s.resetRunID,
 		resetRequestID,
+		resetCompletionCallbacks,
 	)
 	s.NoError(err)
 	s.Equal(resetHistorySize, resetWorkflow.getContext().getHistorySize())
@@ -466,6 +475,7 @@ func (s *workflowResetterSuite) TestReapplyContinueAsNewWorkflowEvents() {
 		baseBranchToken,
 		baseFirstEventID,
 		baseNextEventID,
+		nil,
 	)
 	s.NoError(err)
 }
@@ -524,6 +534,7 @@ func (s *workflowResetterSuite) TestReapplyWorkflowEvents() {
 		firstEventID,
 		nextEventID,
 		branchToken,
+		nil,
 	)
 	s.NoError(err)
 	s.Equal(newRunID, nextRunID)
@@ -569,7 +580,46 @@ func (s *workflowResetterSuite) TestReapplyEvents() {
 		}
 	}
 
-	err := s.workflowResetter.reapplyEvents(mutableState, events)
+	err := s.workflowResetter.reapplyEvents(mutableState, events, nil)
+	s.NoError(err)
+}
+
+func (s *workflowResetterSuite) TestReapplyEvents_Filtered() {
+
+	newSignalEvent := func(eventID int64, signalName string) *eventpb.HistoryEvent {
+		return &eventpb.HistoryEvent{
+			EventId:   eventID,
+			EventType: eventpb.EventType_WorkflowExecutionSignaled,
+			Attributes: &eventpb.HistoryEvent_WorkflowExecutionSignaledEventAttributes{WorkflowExecutionSignaledEventAttributes: &eventpb.WorkflowExecutionSignaledEventAttributes{
+				SignalName: signalName,
+				Input:      payloads.EncodeString("some random signal input"),
+				Identity:   "some random signal identity",
+			}},
+		}
+	}
+	event1 := newSignalEvent(101, "some random signal name")
+	event2 := newSignalEvent(102, "another random signal name")
+	events := []*eventpb.HistoryEvent{event1, event2}
+
+	mutableState := NewMockmutableState(s.controller)
+	err := s.workflowResetter.reapplyEvents(
+		mutableState,
+		events,
+		newResetReapplyOptions(executiongenpb.ResetReapplyType_ResetReapplyType_None, nil),
+	)
+	s.NoError(err)
+
+	attr := event2.GetWorkflowExecutionSignaledEventAttributes()
+	mutableState.EXPECT().AddWorkflowExecutionSignaled(
+		attr.GetSignalName(),
+		attr.GetInput(),
+		attr.GetIdentity(),
+	).Return(&eventpb.HistoryEvent{}, nil).Times(1)
+	err = s.workflowResetter.reapplyEvents(
+		mutableState,
+		events,
+		newResetReapplyOptions(executiongenpb.ResetReapplyType_ResetReapplyType_Signal, []string{attr.GetSignalName()}),
+	)
 	s.NoError(err)
 }
 
@@ -646,3 +696,237 @@ func (s *workflowResetterSuite) TestPagination() {
 
 	s.Equal(history, result)
 }
+
+func (s *workflowResetterSuite) TestBackfillVersionHistories_Existing() {
+	versionHistories := persistence.NewVersionHistories(persistence.NewVersionHistory(
+		[]byte("some random branch token"),
+		[]*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(5, 123)},
+	))
+	mutableState := NewMockmutableState(s.controller)
+	mutableState.EXPECT().GetVersionHistories().Return(versionHistories).AnyTimes()
+
+	context := NewMockworkflowExecutionContext(s.controller)
+
+	backfilled, err := s.workflowResetter.backfillVersionHistories(context, mutableState, true)
+	s.NoError(err)
+	s.Equal(versionHistories, backfilled)
+}
+
+func (s *workflowResetterSuite) TestBackfillVersionHistories_Legacy() {
+	nextEventID := int64(6)
+	branchToken := []byte("some random branch token")
+	version1 := int64(123)
+	version2 := int64(234)
+
+	events := []*eventpb.HistoryEvent{
+		{EventId: 1, Version: version1, EventType: eventpb.EventType_WorkflowExecutionStarted},
+		{EventId: 2, Version: version1, EventType: eventpb.EventType_DecisionTaskScheduled},
+		{EventId: 3, Version: version2, EventType: eventpb.EventType_DecisionTaskStarted},
+		{EventId: 4, Version: version2, EventType: eventpb.EventType_DecisionTaskCompleted},
+		{EventId: 5, Version: version2, EventType: eventpb.EventType_WorkflowExecutionCompleted},
+	}
+	shardId := s.mockShard.GetShardID()
+	s.mockHistoryV2Mgr.On("ReadHistoryBranchByBatch", &persistence.ReadHistoryBranchRequest{
+		BranchToken:   branchToken,
+		MinEventID:    common.FirstEventID,
+		MaxEventID:    nextEventID,
+		PageSize:      nDCDefaultPageSize,
+		NextPageToken: nil,
+		ShardID:       &shardId,
+	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
+		History:       []*eventpb.History{{Events: events[:2]}, {Events: events[2:]}},
+		NextPageToken: nil,
+	}, nil).Once()
+
+	expectedVersionHistories := persistence.NewVersionHistories(persistence.NewVersionHistory(
+		branchToken,
+		[]*persistence.VersionHistoryItem{
+			persistence.NewVersionHistoryItem(2, version1),
+			persistence.NewVersionHistoryItem(5, version2),
+		},
+	))
+	mutableState := NewMockmutableState(s.controller)
+	mutableState.EXPECT().GetVersionHistories().Return(nil).AnyTimes()
+	mutableState.EXPECT().GetCurrentBranchToken().Return(branchToken, nil).AnyTimes()
+	mutableState.EXPECT().GetNextEventID().Return(nextEventID).AnyTimes()
+	mutableState.EXPECT().GetReplicationState().Return(nil).AnyTimes()
+	mutableState.EXPECT().SetVersionHistories(expectedVersionHistories).Return(nil).Times(1)
+	context := NewMockworkflowExecutionContext(s.controller)
+	context.EXPECT().updateWorkflowExecutionWithNew(
+		gomock.Any(),
+		persistence.UpdateWorkflowModeBypassCurrent,
+		nil,
+		nil,
+		transactionPolicyPassive,
+		nil,
+	).Return(nil).Times(1)
+
+	backfilled, err := s.workflowResetter.backfillVersionHistories(context, mutableState, false)
+	s.NoError(err)
+	s.Equal(expectedVersionHistories, backfilled)
+}
+
+func (s *workflowResetterSuite) TestBackfillVersionHistories_ReplicationState() {
+	nextEventID := int64(3)
+	branchToken := []byte("some random branch token")
+	version := int64(123)
+
+	events := []*eventpb.HistoryEvent{
+		{EventId: 1, Version: version, EventType: eventpb.EventType_WorkflowExecutionStarted},
+		{EventId: 2, Version: version, EventType: eventpb.EventType_DecisionTaskScheduled},
+	}
+	shardId := s.mockShard.GetShardID()
+	s.mockHistoryV2Mgr.On("ReadHistoryBranchByBatch", &persistence.ReadHistoryBranchRequest{
+		BranchToken:   branchToken,
+		MinEventID:    common.FirstEventID,
+		MaxEventID:    nextEventID,
+		PageSize:      nDCDefaultPageSize,
+		NextPageToken: nil,
+		ShardID:       &shardId,
+	}).Return(&persistence.ReadHistoryBranchByBatchResponse{
+		History:       []*eventpb.History{{Events: events}},
+		NextPageToken: nil,
+	}, nil).Once()
+
+	mutableState := NewMockmutableState(s.controller)
+	mutableState.EXPECT().GetVersionHistories().Return(nil).AnyTimes()
+	mutableState.EXPECT().GetCurrentBranchToken().Return(branchToken, nil).AnyTimes()
+	mutableState.EXPECT().GetNextEventID().Return(nextEventID).AnyTimes()
+	mutableState.EXPECT().GetReplicationState().Return(&persistence.ReplicationState{}).AnyTimes()
+	expectedVersionHistories := persistence.NewVersionHistories(persistence.NewVersionHistory(
+		branchToken,
+		[]*persistence.VersionHistoryItem{persistence.NewVersionHistoryItem(2, version)},
+	))
+	mutableState.EXPECT().SetVersionHistories(expectedVersionHistories).Return(nil).Times(1)
+	context := NewMockworkflowExecutionContext(s.controller)
+	context.EXPECT().updateWorkflowExecutionWithNew(
+		gomock.Any(),
+		persistence.UpdateWorkflowModeUpdateCurrent,
+		nil,
+		nil,
+		transactionPolicyPassive,
+		nil,
+	).Return(nil).Times(1)
+
+	backfilled, err := s.workflowResetter.backfillVersionHistories(context, mutableState, true)
+	s.NoError(err)
+	s.Equal(expectedVersionHistories, backfilled)
+}
+
+func TestFindAutoResetPoint(t *testing.T) {
+	timeSource := clock.NewRealTimeSource()
+
+	// case 1: nil
+	_, pt := FindAutoResetPoint(timeSource, nil, nil)
+	assert.Nil(t, pt)
+
+	// case 2: empty
+	_, pt = FindAutoResetPoint(timeSource, &namespacepb.BadBinaries{}, &executionpb.ResetPoints{})
+	assert.Nil(t, pt)
+
+	pt0 := &executionpb.ResetPointInfo{
+		BinaryChecksum: "abc",
+		Resettable:     true,
+	}
+	pt1 := &executionpb.ResetPointInfo{
+		BinaryChecksum: "def",
+		Resettable:     true,
+	}
+	pt3 := &executionpb.ResetPointInfo{
+		BinaryChecksum: "ghi",
+		Resettable:     false,
+	}
+
+	expiredNowNano := time.Now().UnixNano() - int64(time.Hour)
+	notExpiredNowNano := time.Now().UnixNano() + int64(time.Hour)
+	pt4 := &executionpb.ResetPointInfo{
+		BinaryChecksum:   "expired",
+		Resettable:       true,
+		ExpiringTimeNano: expiredNowNano,
+	}
+
+	pt5 := &executionpb.ResetPointInfo{
+		BinaryChecksum:   "notExpired",
+		Resettable:       true,
+		ExpiringTimeNano: notExpiredNowNano,
+	}
+
+	// case 3: two intersection
+	_, pt = FindAutoResetPoint(timeSource, &namespacepb.BadBinaries{
+		Binaries: map[string]*namespacepb.BadBinaryInfo{
+			"abc": {},
+			"def": {},
+		},
+	}, &executionpb.ResetPoints{
+		Points: []*executionpb.ResetPointInfo{
+			pt0, pt1, pt3,
+		},
+	})
+	assert.Equal(t, pt.String(), pt0.String())
+
+	// case 4: one intersection
+	_, pt = FindAutoResetPoint(timeSource, &namespacepb.BadBinaries{
+		Binaries: map[string]*namespacepb.BadBinaryInfo{
+			"none":    {},
+			"def":     {},
+			"expired": {},
+		},
+	}, &executionpb.ResetPoints{
+		Points: []*executionpb.ResetPointInfo{
+			pt4, pt0, pt1, pt3,
+		},
+	})
+	assert.Equal(t, pt.String(), pt1.String())
+
+	// case 4: no intersection
+	_, pt = FindAutoResetPoint(timeSource, &namespacepb.BadBinaries{
+		Binaries: map[string]*namespacepb.BadBinaryInfo{
+			"none1": {},
+			"none2": {},
+		},
+	}, &executionpb.ResetPoints{
+		Points: []*executionpb.ResetPointInfo{
+			pt0, pt1, pt3,
+		},
+	})
+	assert.Nil(t, pt)
+
+	// case 5: not resettable
+	_, pt = FindAutoResetPoint(timeSource, &namespacepb.BadBinaries{
+		Binaries: map[string]*namespacepb.BadBinaryInfo{
+			"none1": {},
+			"ghi":   {},
+		},
+	}, &executionpb.ResetPoints{
+		Points: []*executionpb.ResetPointInfo{
+			pt0, pt1, pt3,
+		},
+	})
+	assert.Nil(t, pt)
+
+	// case 6: one intersection of expired
+	_, pt = FindAutoResetPoint(timeSource, &namespacepb.BadBinaries{
+		Binaries: map[string]*namespacepb.BadBinaryInfo{
+			"none":    {},
+			"expired": {},
+		},
+	}, &executionpb.ResetPoints{
+		Points: []*executionpb.ResetPointInfo{
+			pt0, pt1, pt3, pt4, pt5,
+		},
+	})
+	assert.Nil(t, pt)
+
+	// case 7: one intersection of not expired
+	_, pt = FindAutoResetPoint(timeSource, &namespacepb.BadBinaries{
+		Binaries: map[string]*namespacepb.BadBinaryInfo{
+			"none":       {},
+			"notExpired": {},
+		},
+	}, &executionpb.ResetPoints{
+		Points: []*executionpb.ResetPointInfo{
+			pt0, pt1, pt3, pt4, pt5,
+		},
+	})
+	assert.Equal(t, pt.String(), pt5.String())
+}
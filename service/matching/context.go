@@ -115,6 +115,9 @@ func (reqCtx *handlerContext) handleErr(err error) error {
 	case *serviceerror.NamespaceNotActive:
 		scope.IncCounter(metrics.ServiceErrNamespaceNotActivePerTaskListCounter)
 		return err
+	case *serviceerror.Unavailable:
+		scope.IncCounter(metrics.ServiceFailuresPerTaskList)
+		return err
 	default:
 		scope.IncCounter(metrics.ServiceFailuresPerTaskList)
 		return serviceerror.NewInternal(err.Error())
@@ -115,6 +115,8 @@ func (db *taskListDB) UpdateState(ackLevel int64) error {
 			Kind:        db.taskListKind,
 		},
 		RangeID: db.rangeID,
+		// the ack level is persisted periodically by the task reader
+		CallerType: persistence.CallerTypeBackground,
 	})
 	if err == nil {
 		db.ackLevel = ackLevel
@@ -151,6 +153,8 @@ func (db *taskListDB) GetTasks(minTaskID int64, maxTaskID int64, batchSize int)
 		BatchSize:    batchSize,
 		ReadLevel:    minTaskID,  // exclusive
 		MaxReadLevel: &maxTaskID, // inclusive
+		// the tasks are read ahead of the pollers by the task reader
+		CallerType: persistence.CallerTypeBackground,
 	})
 }
 
@@ -27,6 +27,7 @@ package matching
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.temporal.io/temporal-proto/serviceerror"
@@ -51,13 +52,29 @@ type (
 		metricsClient metrics.Client
 		startWG       sync.WaitGroup
 		rateLimiter   quotas.Limiter
+
+		drainLock     sync.Mutex
+		drainState    int32
+		drainCancelCh chan struct{}
+		numPollers    int64
 	}
 )
 
+const (
+	drainStateNone int32 = iota
+	// drainStateDraining means the host has left the membership ring and
+	// is waiting for callers to notice before giving up its task lists
+	drainStateDraining
+	// drainStateDrained means the task lists have been unloaded and new
+	// requests are rejected so that they are not loaded again
+	drainStateDrained
+)
+
 var (
 	_ matchingservice.MatchingServiceServer = (*Handler)(nil)
 
 	errMatchingHostThrottle = serviceerror.NewResourceExhausted("Matching host RPS exceeded.")
+	errMatchingHostDrained  = serviceerror.NewUnavailable("Matching host is drained.")
 )
 
 // NewHandler creates a gRPC handler for the matchingservice
@@ -153,6 +170,10 @@ func (h *Handler) AddActivityTask(
 		return &matchingservice.AddActivityTaskResponse{}, hCtx.handleErr(errMatchingHostThrottle)
 	}
 
+	if h.isDrained() {
+		return &matchingservice.AddActivityTaskResponse{}, hCtx.handleErr(errMatchingHostDrained)
+	}
+
 	syncMatch, err := h.engine.AddActivityTask(hCtx, request)
 	if syncMatch {
 		hCtx.scope.RecordTimer(metrics.SyncMatchLatencyPerTaskList, time.Since(startT))
@@ -186,6 +207,10 @@ func (h *Handler) AddDecisionTask(
 		return &matchingservice.AddDecisionTaskResponse{}, hCtx.handleErr(errMatchingHostThrottle)
 	}
 
+	if h.isDrained() {
+		return &matchingservice.AddDecisionTaskResponse{}, hCtx.handleErr(errMatchingHostDrained)
+	}
+
 	syncMatch, err := h.engine.AddDecisionTask(hCtx, request)
 	if syncMatch {
 		hCtx.scope.RecordTimer(metrics.SyncMatchLatencyPerTaskList, time.Since(startT))
@@ -217,6 +242,13 @@ func (h *Handler) PollForActivityTask(
 		return nil, hCtx.handleErr(errMatchingHostThrottle)
 	}
 
+	if h.isDrained() {
+		return nil, hCtx.handleErr(errMatchingHostDrained)
+	}
+
+	atomic.AddInt64(&h.numPollers, 1)
+	defer atomic.AddInt64(&h.numPollers, -1)
+
 	if _, err := common.ValidateLongPollContextTimeoutIsSet(
 		ctx,
 		"PollForActivityTask",
@@ -253,6 +285,13 @@ func (h *Handler) PollForDecisionTask(
 		return nil, hCtx.handleErr(errMatchingHostThrottle)
 	}
 
+	if h.isDrained() {
+		return nil, hCtx.handleErr(errMatchingHostDrained)
+	}
+
+	atomic.AddInt64(&h.numPollers, 1)
+	defer atomic.AddInt64(&h.numPollers, -1)
+
 	if _, err := common.ValidateLongPollContextTimeoutIsSet(
 		ctx,
 		"PollForDecisionTask",
@@ -388,6 +427,106 @@ func (h *Handler) ListTaskListPartitions(
 	return response, hCtx.handleErr(err)
 }
 
+// DrainHost moves this host out of the membership ring and unloads its task lists once
+// callers had time to notice, or puts the host back into the ring
+func (h *Handler) DrainHost(
+	ctx context.Context,
+	request *matchingservice.DrainHostRequest,
+) (_ *matchingservice.DrainHostResponse, retError error) {
+	defer log.CapturePanic(h.GetLogger(), &retError)
+	hCtx := newHandlerContext(
+		ctx,
+		"",
+		nil,
+		h.metricsClient,
+		metrics.MatchingDrainHostScope,
+	)
+
+	sw := hCtx.startProfiling(&h.startWG)
+	defer sw.Stop()
+
+	var err error
+	if request.GetUndrain() {
+		err = h.undrain()
+	} else {
+		err = h.drain()
+	}
+	if err != nil {
+		return nil, hCtx.handleErr(err)
+	}
+
+	state := atomic.LoadInt32(&h.drainState)
+	numTaskLists := h.engine.NumTaskLists()
+	numPollers := atomic.LoadInt64(&h.numPollers)
+	return &matchingservice.DrainHostResponse{
+		Draining:          state != drainStateNone,
+		Drained:           state == drainStateDrained && numTaskLists == 0 && numPollers == 0,
+		NumberOfTaskLists: int32(numTaskLists),
+		NumberOfPollers:   int32(numPollers),
+	}, nil
+}
+
+func (h *Handler) drain() error {
+	h.drainLock.Lock()
+	defer h.drainLock.Unlock()
+
+	if atomic.LoadInt32(&h.drainState) != drainStateNone {
+		return nil
+	}
+
+	h.GetLogger().Info("DrainHost: Removing self from membership ring")
+	if err := h.GetMembershipMonitor().SetDraining(true); err != nil {
+		return err
+	}
+	cancelCh := make(chan struct{})
+	h.drainCancelCh = cancelCh
+	atomic.StoreInt32(&h.drainState, drainStateDraining)
+	go h.unloadTaskListsAfterDrain(cancelCh)
+	return nil
+}
+
+func (h *Handler) unloadTaskListsAfterDrain(cancelCh <-chan struct{}) {
+	// same wait as during shutdown, so that other hosts find out about the ring change
+	timer := time.NewTimer(h.config.ShutdownDrainDuration())
+	defer timer.Stop()
+	select {
+	case <-cancelCh:
+		return
+	case <-timer.C:
+	}
+
+	h.drainLock.Lock()
+	defer h.drainLock.Unlock()
+	if atomic.LoadInt32(&h.drainState) != drainStateDraining {
+		return
+	}
+	atomic.StoreInt32(&h.drainState, drainStateDrained)
+	h.GetLogger().Info("DrainHost: Unloading all task lists")
+	h.engine.UnloadAllTaskLists()
+}
+
+func (h *Handler) undrain() error {
+	h.drainLock.Lock()
+	defer h.drainLock.Unlock()
+
+	if atomic.LoadInt32(&h.drainState) == drainStateNone {
+		return nil
+	}
+
+	h.GetLogger().Info("DrainHost: Adding self back to membership ring")
+	if err := h.GetMembershipMonitor().SetDraining(false); err != nil {
+		return err
+	}
+	close(h.drainCancelCh)
+	h.drainCancelCh = nil
+	atomic.StoreInt32(&h.drainState, drainStateNone)
+	return nil
+}
+
+func (h *Handler) isDrained() bool {
+	return atomic.LoadInt32(&h.drainState) == drainStateDrained
+}
+
 func (h *Handler) namespaceName(id string) string {
 	entry, err := h.GetNamespaceCache().GetNamespaceByID(id)
 	if err != nil {
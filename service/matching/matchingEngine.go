@@ -146,6 +146,27 @@ func (e *matchingEngineImpl) Stop() {
 	}
 }
 
+// UnloadAllTaskLists stops and removes every task list owned by this host, giving up
+// their leases so that other hosts can take them over
+func (e *matchingEngineImpl) UnloadAllTaskLists() {
+	e.taskListsLock.Lock()
+	lists := e.taskLists
+	e.taskLists = make(map[taskListID]taskListManager)
+	e.taskListsLock.Unlock()
+
+	// Executes Stop() on each task list outside of lock
+	for _, l := range lists {
+		l.Stop()
+	}
+}
+
+// NumTaskLists returns the number of task lists currently loaded by this host
+func (e *matchingEngineImpl) NumTaskLists() int {
+	e.taskListsLock.RLock()
+	defer e.taskListsLock.RUnlock()
+	return len(e.taskLists)
+}
+
 func (e *matchingEngineImpl) getTaskLists(maxCount int) (lists []taskListManager) {
 	e.taskListsLock.RLock()
 	defer e.taskListsLock.RUnlock()
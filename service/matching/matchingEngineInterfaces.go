@@ -41,5 +41,7 @@ type (
 		CancelOutstandingPoll(hCtx *handlerContext, request *matchingservice.CancelOutstandingPollRequest) error
 		DescribeTaskList(hCtx *handlerContext, request *matchingservice.DescribeTaskListRequest) (*matchingservice.DescribeTaskListResponse, error)
 		ListTaskListPartitions(hCtx *handlerContext, request *matchingservice.ListTaskListPartitionsRequest) (*matchingservice.ListTaskListPartitionsResponse, error)
+		UnloadAllTaskLists()
+		NumTaskLists() int
 	}
 )
@@ -124,3 +124,11 @@ func (h *NilCheckHandler) ListTaskListPartitions(ctx context.Context, request *m
 	}
 	return resp, err
 }
+
+func (h *NilCheckHandler) DrainHost(ctx context.Context, request *matchingservice.DrainHostRequest) (*matchingservice.DrainHostResponse, error) {
+	resp, err := h.parentHandler.DrainHost(ctx, request)
+	if resp == nil && err == nil {
+		resp = &matchingservice.DrainHostResponse{}
+	}
+	return resp, err
+}
@@ -109,19 +109,28 @@ func deleteHistoryActivity(ctx context.Context, request ArchiveRequest) (err err
 			err = temporal.NewApplicationError(err.Error(), true)
 		}
 	}()
+	logger := tagLoggerWithHistoryRequest(tagLoggerWithActivityInfo(container.Logger, activity.GetInfo(ctx)), &request)
 	err = container.HistoryV2Manager.DeleteHistoryBranch(&persistence.DeleteHistoryBranchRequest{
 		BranchToken: request.BranchToken,
 		ShardID:     convert.IntPtr(request.ShardID),
 	})
-	if err == nil {
+	if err != nil {
+		logger.Error("failed to delete history events", tag.Error(err))
+		if !common.IsPersistenceTransientError(err) {
+			return errDeleteNonRetryable
+		}
+		return err
+	}
+	if container.PayloadOffloader == nil {
 		return nil
 	}
-	logger := tagLoggerWithHistoryRequest(tagLoggerWithActivityInfo(container.Logger, activity.GetInfo(ctx)), &request)
-	logger.Error("failed to delete history events", tag.Error(err))
-	if !common.IsPersistenceTransientError(err) {
-		return errDeleteNonRetryable
+	// payloads offloaded from the history are stored under the history archival URI,
+	// the archived history already contains the rehydrated payloads
+	if err = container.PayloadOffloader.DeleteExecutionBlobs(ctx, request.URI, request.NamespaceID, request.RunID); err != nil {
+		logger.Error("failed to delete offloaded payloads", tag.Error(err))
+		return err
 	}
-	return err
+	return nil
 }
 
 func archiveVisibilityActivity(ctx context.Context, request ArchiveRequest) (err error) {
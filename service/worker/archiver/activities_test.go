@@ -40,6 +40,7 @@ import (
 	"github.com/temporalio/temporal/common"
 	carchiver "github.com/temporalio/temporal/common/archiver"
 	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/blobstore"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/loggerimpl"
 	"github.com/temporalio/temporal/common/metrics"
@@ -64,6 +65,10 @@ var (
 	errPersistenceNonRetryable = errors.New("persistence non-retryable error")
 )
 
+type testBlobStore struct {
+	deletedURIs []string
+}
+
 type activitiesSuite struct {
 	suite.Suite
 	testsuite.WorkflowTestSuite
@@ -269,6 +274,38 @@ func (s *activitiesSuite) TestDeleteHistoryActivity_Fail_DeleteFromV2NonRetryabl
 	s.Equal(errDeleteNonRetryable.Error(), err.Error())
 }
 
+func (s *activitiesSuite) TestDeleteHistoryActivity_Success() {
+	s.metricsClient.On("Scope", metrics.ArchiverDeleteHistoryActivityScope, []metrics.Tag{metrics.NamespaceTag(testNamespace)}).Return(s.metricsScope).Once()
+	mockHistoryV2Manager := &mocks.HistoryV2Manager{}
+	mockHistoryV2Manager.On("DeleteHistoryBranch", mock.Anything).Return(nil)
+	store := &testBlobStore{}
+	s.archiverProvider.On("GetBlobStore", mock.Anything).Return(store, nil)
+	container := &BootstrapContainer{
+		Logger:           s.logger,
+		MetricsClient:    s.metricsClient,
+		HistoryV2Manager: mockHistoryV2Manager,
+		PayloadOffloader: blobstore.NewPayloadOffloader(s.archiverProvider),
+	}
+	env := s.NewTestActivityEnvironment()
+	s.registerWorkflows(env)
+	env.SetWorkerOptions(worker.Options{
+		BackgroundActivityContext: context.WithValue(context.Background(), bootstrapContainerKey, container),
+	})
+	request := ArchiveRequest{
+		NamespaceID:          testNamespaceID,
+		Namespace:            testNamespace,
+		WorkflowID:           testWorkflowID,
+		RunID:                testRunID,
+		BranchToken:          testBranchToken,
+		NextEventID:          testNextEventID,
+		CloseFailoverVersion: testCloseFailoverVersion,
+		URI:                  testArchivalURI,
+	}
+	_, err := env.ExecuteActivity(deleteHistoryActivity, request)
+	s.NoError(err)
+	s.Equal([]string{testArchivalURI + "/payloads/" + testNamespaceID + "/" + testRunID}, store.deletedURIs)
+}
+
 func (s *activitiesSuite) TestArchiveVisibilityActivity_Fail_InvalidURI() {
 	s.metricsClient.On("Scope", metrics.ArchiverArchiveVisibilityActivityScope, []metrics.Tag{metrics.NamespaceTag(testNamespace)}).Return(s.metricsScope).Once()
 	s.metricsScope.On("IncCounter", metrics.ArchiverNonRetryableErrorCount).Once()
@@ -399,3 +436,16 @@ func (s *activitiesSuite) registerWorkflows(env *testsuite.TestActivityEnvironme
 	env.RegisterActivityWithOptions(deleteHistoryActivity, activity.RegisterOptions{Name: deleteHistoryActivityFnName})
 	env.RegisterActivityWithOptions(archiveVisibilityActivity, activity.RegisterOptions{Name: archiveVisibilityActivityFnName})
 }
+
+func (b *testBlobStore) Put(_ context.Context, _ string, _ []byte) error {
+	return nil
+}
+
+func (b *testBlobStore) Get(_ context.Context, _ string) ([]byte, error) {
+	return nil, blobstore.ErrBlobNotFound
+}
+
+func (b *testBlobStore) Delete(_ context.Context, URI string) error {
+	b.deletedURIs = append(b.deletedURIs, URI)
+	return nil
+}
@@ -35,6 +35,7 @@ import (
 
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/archiver/provider"
+	"github.com/temporalio/temporal/common/blobstore"
 	"github.com/temporalio/temporal/common/cache"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
@@ -64,6 +65,7 @@ type (
 		NamespaceCache   cache.NamespaceCache
 		Config           *Config
 		ArchiverProvider provider.ArchiverProvider
+		PayloadOffloader blobstore.PayloadOffloader
 	}
 
 	// Config for ClientWorker
@@ -25,6 +25,7 @@
 package archiver
 
 import (
+	"context"
 	"time"
 
 	"github.com/uber-go/tally"
@@ -131,6 +132,14 @@ func (r *replayMetricsScope) StartTimer(timer int) metrics.Stopwatch {
 	return r.scope.StartTimer(timer)
 }
 
+// StartTimerWithContext starts a timer for the given metric name. Time will be recorded when stopwatch is stopped.
+func (r *replayMetricsScope) StartTimerWithContext(ctx context.Context, timer int) metrics.Stopwatch {
+	if workflow.IsReplaying(r.ctx) {
+		return metrics.NewTestStopwatch()
+	}
+	return r.scope.StartTimerWithContext(ctx, timer)
+}
+
 // RecordTimer starts a timer for the given metric name
 func (r *replayMetricsScope) RecordTimer(timer int, d time.Duration) {
 	if workflow.IsReplaying(r.ctx) {
@@ -30,7 +30,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/client/frontend"
+	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/convert"
 	"github.com/temporalio/temporal/common/log"
 	"github.com/temporalio/temporal/common/log/tag"
@@ -72,10 +74,12 @@ const (
 	BatchTypeCancel = "cancel"
 	// BatchTypeSignal is batch type for signaling workflows
 	BatchTypeSignal = "signal"
+	// BatchTypeReset is batch type for resetting workflows to the start of their last run
+	BatchTypeReset = "reset"
 )
 
 // AllBatchTypes is the batch types we supported
-var AllBatchTypes = []string{BatchTypeTerminate, BatchTypeCancel, BatchTypeSignal}
+var AllBatchTypes = []string{BatchTypeTerminate, BatchTypeCancel, BatchTypeSignal, BatchTypeReset}
 
 type (
 	// TerminateParams is the parameters for terminating workflow
@@ -100,6 +104,14 @@ type (
 		Input      *commonpb.Payloads
 	}
 
+	// ResetParams is the parameters for resetting workflow
+	ResetParams struct {
+		// events to reapply after the reset point, Signal or None. Default to Signal
+		ReapplyType string
+		// only reapply signals with these names. Default to all signals
+		ReapplySignalNames []string
+	}
+
 	// BatchParams is the parameters for batch operation workflow
 	BatchParams struct {
 		// Target namespace to execute batch operation
@@ -118,6 +130,8 @@ type (
 		CancelParams CancelParams
 		// SignalParams is params only for BatchTypeSignal
 		SignalParams SignalParams
+		// ResetParams is params only for BatchTypeReset
+		ResetParams ResetParams
 		// RPS of processing. Default to DefaultRPS
 		// TODO we will implement smarter way than this static rate limiter: https://github.com/temporalio/temporal/issues/2138
 		RPS int
@@ -194,6 +208,18 @@ func validateParams(params BatchParams) error {
 			return fmt.Errorf("must provide signal name")
 		}
 		return nil
+	case BatchTypeReset:
+		switch params.ResetParams.ReapplyType {
+		case "", common.ResetReapplyTypeSignal:
+			return nil
+		case common.ResetReapplyTypeNone:
+			if len(params.ResetParams.ReapplySignalNames) > 0 {
+				return fmt.Errorf("cannot provide signal names to reapply with reapply type: %v", common.ResetReapplyTypeNone)
+			}
+			return nil
+		default:
+			return fmt.Errorf("not supported reapply type: %v", params.ResetParams.ReapplyType)
+		}
 	case BatchTypeCancel:
 		fallthrough
 	case BatchTypeTerminate:
@@ -232,6 +258,8 @@ func setDefaultParams(params BatchParams) BatchParams {
 func BatchActivity(ctx context.Context, batchParams BatchParams) (HeartBeatDetails, error) {
 	batcher := ctx.Value(batcherContextKey).(*Batcher)
 	client := batcher.clientBean.GetFrontendClient()
+	// resets are made through the admin service, the workflow service request does not carry the reset options
+	adminClient := batcher.clientBean.GetRemoteAdminClient(batcher.cfg.ClusterMetadata.GetCurrentClusterName())
 
 	hbd := HeartBeatDetails{}
 	startOver := true
@@ -390,6 +418,27 @@ func startTaskProcessor(
 						})
 						return err
 					})
+			case BatchTypeReset:
+				err = processTask(ctx, limiter, task, batchParams, client, convert.BoolPtr(false),
+					func(workflowID, runID string) error {
+						// the reapply type is validated when the batch operation starts
+						reapplyType, _ := common.ParseResetReapplyType(batchParams.ResetParams.ReapplyType)
+						_, err := adminClient.ResetWorkflowExecution(ctx, &adminservice.ResetWorkflowExecutionRequest{
+							ResetRequest: &workflowservice.ResetWorkflowExecutionRequest{
+								Namespace: batchParams.Namespace,
+								WorkflowExecution: &commonpb.WorkflowExecution{
+									WorkflowId: workflowID,
+									RunId:      runID,
+								},
+								Reason:    batchParams.Reason,
+								RequestId: requestID,
+							},
+							ReapplyType:         reapplyType,
+							ReapplySignalNames:  batchParams.ResetParams.ReapplySignalNames,
+							ResetToLastRunStart: true,
+						})
+						return err
+					})
 			}
 			if err != nil {
 				batcher.metricsClient.IncCounter(metrics.BatcherScope, metrics.BatcherProcessorFailures)
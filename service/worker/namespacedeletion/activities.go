@@ -0,0 +1,291 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespacedeletion
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+
+	"go.temporal.io/temporal-proto/serviceerror"
+	tasklistpb "go.temporal.io/temporal-proto/tasklist"
+	"go.temporal.io/temporal-proto/workflowservice"
+	"go.temporal.io/temporal/activity"
+
+	"github.com/temporalio/temporal/.gen/proto/historyservice"
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/namespace"
+	"github.com/temporalio/temporal/common/persistence"
+)
+
+const (
+	executionsPageSize = 100
+	taskBatchSize      = 1000
+	terminateReason    = "namespace is deleted"
+)
+
+// TerminateExecutionsActivity terminates one page of open executions of the namespace,
+// it returns the number of executions it terminated. Executions which are already closed
+// but still open in visibility are not counted.
+func TerminateExecutionsActivity(ctx context.Context, params Params) (int, error) {
+	deleter := ctx.Value(deleterContextKey).(*Deleter)
+	if err := deleter.recordProgress(params, namespace.DeletionStageTerminatingExecutions); err != nil {
+		return 0, err
+	}
+
+	resp, err := deleter.GetVisibilityManager().ListOpenWorkflowExecutions(&persistence.ListWorkflowExecutionsRequest{
+		NamespaceID:       params.NamespaceID,
+		Namespace:         params.Namespace,
+		EarliestStartTime: 0,
+		LatestStartTime:   time.Now().UnixNano(),
+		PageSize:          executionsPageSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	terminated := 0
+	for _, execution := range resp.Executions {
+		_, err := deleter.GetHistoryClient().TerminateWorkflowExecution(ctx, &historyservice.TerminateWorkflowExecutionRequest{
+			NamespaceId: params.NamespaceID,
+			TerminateRequest: &workflowservice.TerminateWorkflowExecutionRequest{
+				Namespace:         params.Namespace,
+				WorkflowExecution: execution.GetExecution(),
+				Reason:            terminateReason,
+				Identity:          deletionWFTypeName,
+			},
+		})
+		if _, ok := err.(*serviceerror.NotFound); ok {
+			// already closed, visibility is lagging behind
+			continue
+		}
+		if err != nil {
+			deleter.metricsClient.IncCounter(metrics.NamespaceDeletionScope, metrics.NamespaceDeletionFailures)
+			getActivityLogger(ctx).Error("failed to terminate workflow execution", tag.Error(err),
+				tag.WorkflowID(execution.GetExecution().GetWorkflowId()),
+				tag.WorkflowRunID(execution.GetExecution().GetRunId()),
+			)
+			return 0, err
+		}
+		terminated++
+	}
+	return terminated, nil
+}
+
+// DeleteExecutionsActivity deletes one page of closed executions of the namespace through the history service,
+// deleted executions do not necessarily drop out of visibility (e.g. Cassandra visibility records are only removed
+// by TTL and Elasticsearch deletes are asynchronous), so the pages are walked with the visibility page token
+func DeleteExecutionsActivity(ctx context.Context, params Params) (DeleteExecutionsResult, error) {
+	deleter := ctx.Value(deleterContextKey).(*Deleter)
+
+	resp, err := deleter.GetVisibilityManager().ListClosedWorkflowExecutions(&persistence.ListWorkflowExecutionsRequest{
+		NamespaceID:       params.NamespaceID,
+		Namespace:         params.Namespace,
+		EarliestStartTime: 0,
+		LatestStartTime:   time.Now().UnixNano(),
+		PageSize:          executionsPageSize,
+		NextPageToken:     params.NextPageToken,
+	})
+	if err != nil {
+		return DeleteExecutionsResult{}, err
+	}
+
+	// task lists are kept with the deletion progress, so that the task lists of executions
+	// deleted by a previous attempt or run are not lost
+	taskLists, err := deleter.getDeletionTaskLists(params.Namespace)
+	if err != nil {
+		return DeleteExecutionsResult{}, err
+	}
+
+	var result DeleteExecutionsResult
+	for _, execution := range resp.Executions {
+		deleteResp, err := deleter.GetHistoryClient().DeleteWorkflowExecution(ctx, &historyservice.DeleteWorkflowExecutionRequest{
+			NamespaceId: params.NamespaceID,
+			Execution:   execution.GetExecution(),
+		})
+		if err != nil {
+			deleter.metricsClient.IncCounter(metrics.NamespaceDeletionScope, metrics.NamespaceDeletionFailures)
+			getActivityLogger(ctx).Error("failed to delete workflow execution", tag.Error(err),
+				tag.WorkflowID(execution.GetExecution().GetWorkflowId()),
+				tag.WorkflowRunID(execution.GetExecution().GetRunId()),
+			)
+			return DeleteExecutionsResult{}, err
+		}
+		if !deleteResp.GetDeleted() {
+			// already deleted by a previous attempt or by retention
+			continue
+		}
+		deleter.metricsClient.IncCounter(metrics.NamespaceDeletionScope, metrics.NamespaceDeletionExecutionsDeletedCount)
+		result.ExecutionsDeleted++
+		taskLists = mergeTaskLists(taskLists, deleteResp.GetTaskLists())
+
+		progress := params
+		progress.ExecutionsDeleted += result.ExecutionsDeleted
+		if err := deleter.recordExecutionsProgress(progress, taskLists); err != nil {
+			return DeleteExecutionsResult{}, err
+		}
+	}
+	result.NextPageToken = resp.NextPageToken
+	return result, nil
+}
+
+// DeleteTaskListsActivity deletes the task lists used by the deleted executions
+func DeleteTaskListsActivity(ctx context.Context, params Params) error {
+	deleter := ctx.Value(deleterContextKey).(*Deleter)
+	if err := deleter.recordProgress(params, namespace.DeletionStageDeletingTaskLists); err != nil {
+		return err
+	}
+
+	taskLists, err := deleter.getDeletionTaskLists(params.Namespace)
+	if err != nil {
+		return err
+	}
+	for _, name := range taskLists {
+		for _, taskType := range []tasklistpb.TaskListType{tasklistpb.TaskListType_Decision, tasklistpb.TaskListType_Activity} {
+			if err := deleter.deleteTaskList(params.NamespaceID, name, taskType); err != nil {
+				deleter.metricsClient.IncCounter(metrics.NamespaceDeletionScope, metrics.NamespaceDeletionFailures)
+				getActivityLogger(ctx).Error("failed to delete task list", tag.Error(err), tag.WorkflowTaskListName(name))
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteNamespaceActivity deletes the namespace metadata, this is the last step of the namespace deletion
+func DeleteNamespaceActivity(ctx context.Context, params Params) error {
+	deleter := ctx.Value(deleterContextKey).(*Deleter)
+	if err := deleter.recordProgress(params, namespace.DeletionStageDeletingMetadata); err != nil {
+		if _, ok := err.(*serviceerror.NotFound); ok {
+			// namespace is already deleted by a previous attempt
+			return nil
+		}
+		return err
+	}
+
+	if err := deleter.GetMetadataManager().DeleteNamespace(&persistence.DeleteNamespaceRequest{ID: params.NamespaceID}); err != nil {
+		return err
+	}
+	getActivityLogger(ctx).Info("namespace deleted",
+		tag.WorkflowNamespaceID(params.NamespaceID),
+		tag.Counter(params.ExecutionsDeleted),
+	)
+	return nil
+}
+
+func (d *Deleter) recordProgress(params Params, stage string) error {
+	return namespace.RecordDeletionProgress(d.GetMetadataManager(), params.Namespace, map[string]string{
+		namespace.DeletionStageKey:             stage,
+		namespace.DeletionExecutionsDeletedKey: strconv.Itoa(params.ExecutionsDeleted),
+	})
+}
+
+func (d *Deleter) recordExecutionsProgress(params Params, taskLists []string) error {
+	encodedTaskLists, err := json.Marshal(taskLists)
+	if err != nil {
+		return err
+	}
+	return namespace.RecordDeletionProgress(d.GetMetadataManager(), params.Namespace, map[string]string{
+		namespace.DeletionStageKey:             namespace.DeletionStageDeletingExecutions,
+		namespace.DeletionExecutionsDeletedKey: strconv.Itoa(params.ExecutionsDeleted),
+		namespace.DeletionTaskListsKey:         string(encodedTaskLists),
+	})
+}
+
+// getDeletionTaskLists returns the task lists of the executions purged so far
+func (d *Deleter) getDeletionTaskLists(name string) ([]string, error) {
+	resp, err := d.GetMetadataManager().GetNamespace(&persistence.GetNamespaceRequest{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	encodedTaskLists, ok := resp.Namespace.GetInfo().GetData()[namespace.DeletionTaskListsKey]
+	if !ok {
+		return nil, nil
+	}
+	var taskLists []string
+	if err := json.Unmarshal([]byte(encodedTaskLists), &taskLists); err != nil {
+		return nil, err
+	}
+	return taskLists, nil
+}
+
+// deleteTaskList takes over the ownership of the task list from matching,
+// then deletes all of its tasks and the task list itself
+func (d *Deleter) deleteTaskList(
+	namespaceID string,
+	name string,
+	taskType tasklistpb.TaskListType,
+) error {
+
+	taskMgr := d.GetTaskManager()
+	resp, err := taskMgr.LeaseTaskList(&persistence.LeaseTaskListRequest{
+		NamespaceID:  namespaceID,
+		TaskList:     name,
+		TaskType:     taskType,
+		TaskListKind: tasklistpb.TaskListKind_Normal,
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		n, err := taskMgr.CompleteTasksLessThan(&persistence.CompleteTasksLessThanRequest{
+			NamespaceID:  namespaceID,
+			TaskListName: name,
+			TaskType:     taskType,
+			TaskID:       math.MaxInt64,
+			Limit:        taskBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+		if n == persistence.UnknownNumRowsAffected || n < taskBatchSize {
+			break
+		}
+	}
+
+	return taskMgr.DeleteTaskList(&persistence.DeleteTaskListRequest{
+		TaskList: &persistence.TaskListKey{
+			NamespaceID: namespaceID,
+			Name:        name,
+			TaskType:    taskType,
+		},
+		RangeID: resp.TaskListInfo.RangeID,
+	})
+}
+
+func getActivityLogger(ctx context.Context) log.Logger {
+	deleter := ctx.Value(deleterContextKey).(*Deleter)
+	wfInfo := activity.GetInfo(ctx)
+	return deleter.logger.WithTags(
+		tag.WorkflowID(wfInfo.WorkflowExecution.ID),
+		tag.WorkflowRunID(wfInfo.WorkflowExecution.RunID),
+		tag.WorkflowNamespace(wfInfo.WorkflowNamespace),
+	)
+}
@@ -0,0 +1,79 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespacedeletion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdkclient "go.temporal.io/temporal/client"
+)
+
+type (
+	// Client is used to start the namespace deletion workflow
+	Client interface {
+		// StartDeletion starts purging the data of the namespace and returns the ID of the deletion workflow,
+		// starting the deletion of a namespace which is already being purged is a no-op
+		StartDeletion(ctx context.Context, request Request) (string, error)
+	}
+
+	clientImpl struct {
+		temporalClient sdkclient.Client
+	}
+)
+
+var _ Client = (*clientImpl)(nil)
+
+const (
+	workflowIDPrefix = "temporal-sys-namespace-deletion"
+	startTimeout     = 10 * time.Second
+)
+
+// NewClient creates a new Client
+func NewClient(
+	publicClient sdkclient.Client,
+) Client {
+	return &clientImpl{
+		temporalClient: publicClient,
+	}
+}
+
+func (c *clientImpl) StartDeletion(ctx context.Context, request Request) (string, error) {
+	workflowOptions := sdkclient.StartWorkflowOptions{
+		ID:                       fmt.Sprintf("%v-%v", workflowIDPrefix, request.NamespaceID),
+		TaskList:                 deletionTaskListName,
+		WorkflowExecutionTimeout: infiniteDuration,
+		WorkflowTaskTimeout:      time.Minute,
+		WorkflowIDReusePolicy:    sdkclient.WorkflowIDReusePolicyAllowDuplicate,
+	}
+	startCtx, cancel := context.WithTimeout(ctx, startTimeout)
+	defer cancel()
+	run, err := c.temporalClient.ExecuteWorkflow(startCtx, workflowOptions, deletionWFTypeName, Params{Request: request})
+	if err != nil {
+		return "", err
+	}
+	return run.GetID(), nil
+}
@@ -0,0 +1,79 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespacedeletion
+
+import (
+	"context"
+
+	"go.temporal.io/temporal/activity"
+	sdkclient "go.temporal.io/temporal/client"
+	"go.temporal.io/temporal/worker"
+	"go.temporal.io/temporal/workflow"
+
+	"github.com/temporalio/temporal/common/log"
+	"github.com/temporalio/temporal/common/log/tag"
+	"github.com/temporalio/temporal/common/metrics"
+	"github.com/temporalio/temporal/common/resource"
+)
+
+type (
+	// Deleter is the background sub-system that purges the data of deleted namespaces
+	Deleter struct {
+		resource.Resource
+
+		svcClient     sdkclient.Client
+		metricsClient metrics.Client
+		logger        log.Logger
+	}
+)
+
+// New returns a new instance as daemon
+func New(
+	resource resource.Resource,
+) *Deleter {
+	return &Deleter{
+		Resource:      resource,
+		svcClient:     resource.GetSDKClient(),
+		metricsClient: resource.GetMetricsClient(),
+		logger:        resource.GetLogger().WithTags(tag.ComponentNamespaceDeletion),
+	}
+}
+
+// Start starts the worker which runs the namespace deletion workflow
+func (d *Deleter) Start() error {
+	ctx := context.WithValue(context.Background(), deleterContextKey, d)
+	workerOpts := worker.Options{
+		BackgroundActivityContext: ctx,
+	}
+	deleterWorker := worker.New(d.svcClient, deletionTaskListName, workerOpts)
+
+	deleterWorker.RegisterWorkflowWithOptions(DeletionWorkflow, workflow.RegisterOptions{Name: deletionWFTypeName})
+	deleterWorker.RegisterActivityWithOptions(TerminateExecutionsActivity, activity.RegisterOptions{Name: terminateExecutionsActivityName})
+	deleterWorker.RegisterActivityWithOptions(DeleteExecutionsActivity, activity.RegisterOptions{Name: deleteExecutionsActivityName})
+	deleterWorker.RegisterActivityWithOptions(DeleteTaskListsActivity, activity.RegisterOptions{Name: deleteTaskListsActivityName})
+	deleterWorker.RegisterActivityWithOptions(DeleteNamespaceActivity, activity.RegisterOptions{Name: deleteNamespaceActivityName})
+
+	return deleterWorker.Start()
+}
@@ -0,0 +1,157 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package namespacedeletion
+
+import (
+	"sort"
+	"time"
+
+	"go.temporal.io/temporal"
+	"go.temporal.io/temporal/workflow"
+
+	"github.com/temporalio/temporal/common/namespace"
+)
+
+const (
+	deleterContextKey = "namespaceDeleterContext"
+	// deletionTaskListName is the tasklist name
+	deletionTaskListName = "temporal-sys-namespace-deletion-tasklist"
+	// deletionWFTypeName is the workflow type
+	deletionWFTypeName              = "temporal-sys-namespace-deletion-workflow"
+	terminateExecutionsActivityName = "temporal-sys-namespace-deletion-terminate-executions-activity"
+	deleteExecutionsActivityName    = "temporal-sys-namespace-deletion-delete-executions-activity"
+	deleteTaskListsActivityName     = "temporal-sys-namespace-deletion-delete-tasklists-activity"
+	deleteNamespaceActivityName     = "temporal-sys-namespace-deletion-delete-namespace-activity"
+	infiniteDuration                = 20 * 365 * 24 * time.Hour
+
+	// maxIterationsPerRun bounds the history size of a single run before the workflow continues as new
+	maxIterationsPerRun = 500
+	// terminationPollInterval is how long to wait for terminated executions to be closed in visibility
+	terminationPollInterval = 5 * time.Second
+)
+
+type (
+	// Request defines the request for namespace deletion
+	Request struct {
+		Namespace   string
+		NamespaceID string
+	}
+
+	// Params is the input of the deletion workflow, it carries the progress across continue as new
+	Params struct {
+		Request
+
+		Stage             string
+		ExecutionsDeleted int
+		// NextPageToken is the visibility page of closed executions to be deleted next
+		NextPageToken []byte
+	}
+
+	// DeleteExecutionsResult is the result of deleting one page of closed executions
+	DeleteExecutionsResult struct {
+		ExecutionsDeleted int
+		NextPageToken     []byte
+	}
+)
+
+var (
+	retryPolicy = temporal.RetryPolicy{
+		InitialInterval:    10 * time.Second,
+		BackoffCoefficient: 1.7,
+		MaximumInterval:    5 * time.Minute,
+	}
+
+	activityOptions = workflow.ActivityOptions{
+		ScheduleToStartTimeout: time.Minute,
+		StartToCloseTimeout:    5 * time.Minute,
+		RetryPolicy:            &retryPolicy,
+	}
+)
+
+// DeletionWorkflow purges the data of a namespace which is marked as deleted:
+// 1. terminates all open executions
+// 2. deletes history, mutable state and visibility of all closed executions
+// 3. deletes the task lists used by the deleted executions
+// 4. deletes the namespace metadata
+func DeletionWorkflow(ctx workflow.Context, params Params) error {
+	ctx = workflow.WithActivityOptions(ctx, activityOptions)
+	if params.Stage == "" {
+		params.Stage = namespace.DeletionStageTerminatingExecutions
+	}
+
+	for i := 0; i < maxIterationsPerRun; i++ {
+		switch params.Stage {
+		case namespace.DeletionStageTerminatingExecutions:
+			var terminatedExecutions int
+			if err := workflow.ExecuteActivity(ctx, terminateExecutionsActivityName, params).Get(ctx, &terminatedExecutions); err != nil {
+				return err
+			}
+			if terminatedExecutions == 0 {
+				params.Stage = namespace.DeletionStageDeletingExecutions
+				continue
+			}
+			// terminated executions are closed in visibility asynchronously
+			if err := workflow.Sleep(ctx, terminationPollInterval); err != nil {
+				return err
+			}
+
+		case namespace.DeletionStageDeletingExecutions:
+			var result DeleteExecutionsResult
+			if err := workflow.ExecuteActivity(ctx, deleteExecutionsActivityName, params).Get(ctx, &result); err != nil {
+				return err
+			}
+			params.ExecutionsDeleted += result.ExecutionsDeleted
+			params.NextPageToken = result.NextPageToken
+			if len(params.NextPageToken) == 0 {
+				params.Stage = namespace.DeletionStageDeletingTaskLists
+			}
+
+		case namespace.DeletionStageDeletingTaskLists:
+			if err := workflow.ExecuteActivity(ctx, deleteTaskListsActivityName, params).Get(ctx, nil); err != nil {
+				return err
+			}
+			params.Stage = namespace.DeletionStageDeletingMetadata
+
+		default:
+			return workflow.ExecuteActivity(ctx, deleteNamespaceActivityName, params).Get(ctx, nil)
+		}
+	}
+	return workflow.NewContinueAsNewError(ctx, deletionWFTypeName, params)
+}
+
+func mergeTaskLists(current []string, added []string) []string {
+	seen := make(map[string]struct{}, len(current))
+	for _, name := range current {
+		seen[name] = struct{}{}
+	}
+	for _, name := range added {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			current = append(current, name)
+		}
+	}
+	sort.Strings(current)
+	return current
+}
@@ -198,15 +198,16 @@ func newHistoryReplicationTask(
 				WorkflowId: attr.WorkflowId,
 				RunId:      attr.RunId,
 			},
-			FirstEventId:      attr.FirstEventId,
-			NextEventId:       attr.NextEventId,
-			Version:           attr.Version,
-			ReplicationInfo:   attr.ReplicationInfo,
-			History:           attr.History,
-			NewRunHistory:     attr.NewRunHistory,
-			ForceBufferEvents: false,
-			ResetWorkflow:     attr.ResetWorkflow,
-			NewRunNDC:         attr.NewRunNDC,
+			FirstEventId:           attr.FirstEventId,
+			NextEventId:            attr.NextEventId,
+			Version:                attr.Version,
+			ReplicationInfo:        attr.ReplicationInfo,
+			History:                attr.History,
+			NewRunHistory:          attr.NewRunHistory,
+			ForceBufferEvents:      false,
+			ResetWorkflow:          attr.ResetWorkflow,
+			NewRunNDC:              attr.NewRunNDC,
+			CompletionCallbackUrls: attr.CompletionCallbackUrls,
 		},
 		historyRereplicator: historyRereplicator,
 	}
@@ -293,9 +294,10 @@ func newHistoryReplicationV2Task(
 				WorkflowId: attr.WorkflowId,
 				RunId:      attr.RunId,
 			},
-			VersionHistoryItems: attr.VersionHistoryItems,
-			Events:              attr.Events,
-			NewRunEvents:        attr.NewRunEvents,
+			VersionHistoryItems:    attr.VersionHistoryItems,
+			Events:                 attr.Events,
+			NewRunEvents:           attr.NewRunEvents,
+			CompletionCallbackUrls: attr.CompletionCallbackUrls,
 		},
 		nDCHistoryResender: nDCHistoryResender,
 	}
@@ -27,6 +27,7 @@ package replicator
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/temporalio/temporal/.gen/proto/historyservice"
 	"github.com/temporalio/temporal/client"
@@ -59,7 +60,8 @@ type (
 		config                           *Config
 		client                           messaging.Client
 		processors                       []*replicationTaskProcessor
-		namespaceProcessors              []*namespaceReplicationMessageProcessor
+		namespaceProcessorsLock          sync.Mutex
+		namespaceProcessors              map[string]*namespaceReplicationMessageProcessor
 		logger                           log.Logger
 		metricsClient                    metrics.Client
 		historySerializer                persistence.PayloadSerializer
@@ -114,6 +116,7 @@ func NewReplicator(
 		metricsClient:                    metricsClient,
 		historySerializer:                persistence.NewPayloadSerializer(),
 		namespaceReplicationQueue:        namespaceReplicationQueue,
+		namespaceProcessors:              make(map[string]*namespaceReplicationMessageProcessor),
 	}
 }
 
@@ -128,17 +131,7 @@ func (r *Replicator) Start() error {
 
 		if clusterName != currentClusterName {
 			if replicationConsumerConfig.Type == config.ReplicationConsumerTypeRPC {
-				processor := newNamespaceReplicationMessageProcessor(
-					clusterName,
-					r.logger.WithTags(tag.ComponentReplicationTaskProcessor, tag.SourceCluster(clusterName)),
-					r.clientBean.GetRemoteAdminClient(clusterName),
-					r.metricsClient,
-					r.namespaceReplicationTaskExecutor,
-					r.hostInfo,
-					r.serviceResolver,
-					r.namespaceReplicationQueue,
-				)
-				r.namespaceProcessors = append(r.namespaceProcessors, processor)
+				r.namespaceProcessors[clusterName] = r.createNamespaceProcessor(clusterName)
 			} else {
 				r.createKafkaProcessors(currentClusterName, clusterName)
 			}
@@ -151,13 +144,60 @@ func (r *Replicator) Start() error {
 		}
 	}
 
+	r.namespaceProcessorsLock.Lock()
 	for _, namespaceProcessor := range r.namespaceProcessors {
 		namespaceProcessor.Start()
 	}
+	r.namespaceProcessorsLock.Unlock()
 
+	if replicationConsumerConfig.Type == config.ReplicationConsumerTypeRPC {
+		r.clusterMetadata.RegisterMetadataChangeCallback(r, r.clusterMetadataChangeCallback)
+	}
 	return nil
 }
 
+func (r *Replicator) createNamespaceProcessor(clusterName string) *namespaceReplicationMessageProcessor {
+	return newNamespaceReplicationMessageProcessor(
+		clusterName,
+		r.logger.WithTags(tag.ComponentReplicationTaskProcessor, tag.SourceCluster(clusterName)),
+		r.clientBean.GetRemoteAdminClient(clusterName),
+		r.metricsClient,
+		r.namespaceReplicationTaskExecutor,
+		r.hostInfo,
+		r.serviceResolver,
+		r.namespaceReplicationQueue,
+	)
+}
+
+// clusterMetadataChangeCallback starts namespace replication processors for clusters added to the replication group,
+// restarts the processors of updated clusters and stops the processors of removed or disabled clusters
+func (r *Replicator) clusterMetadataChangeCallback(
+	oldClusterMetadata map[string]*config.ClusterInformation,
+	newClusterMetadata map[string]*config.ClusterInformation,
+) {
+	r.namespaceProcessorsLock.Lock()
+	defer r.namespaceProcessorsLock.Unlock()
+
+	currentClusterName := r.clusterMetadata.GetCurrentClusterName()
+	for clusterName, newInfo := range newClusterMetadata {
+		if clusterName == currentClusterName {
+			continue
+		}
+
+		if namespaceProcessor, ok := r.namespaceProcessors[clusterName]; ok {
+			namespaceProcessor.Stop()
+			delete(r.namespaceProcessors, clusterName)
+		}
+		if newInfo == nil || !newInfo.Enabled {
+			continue
+		}
+
+		namespaceProcessor := r.createNamespaceProcessor(clusterName)
+		namespaceProcessor.Start()
+		r.namespaceProcessors[clusterName] = namespaceProcessor
+	}
+}
+
 func (r *Replicator) createKafkaProcessors(currentClusterName string, clusterName string) {
 	consumerName := getConsumerName(currentClusterName, clusterName)
 	adminClient := admin.NewRetryableClient(
@@ -223,9 +263,12 @@ func (r *Replicator) Stop() {
 		processor.Stop()
 	}
 
+	r.clusterMetadata.UnRegisterMetadataChangeCallback(r)
+	r.namespaceProcessorsLock.Lock()
 	for _, namespaceProcessor := range r.namespaceProcessors {
 		namespaceProcessor.Stop()
 	}
+	r.namespaceProcessorsLock.Unlock()
 
 	r.namespaceCache.Stop()
 }
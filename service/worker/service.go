@@ -42,6 +42,7 @@ import (
 	"github.com/temporalio/temporal/service/worker/archiver"
 	"github.com/temporalio/temporal/service/worker/batcher"
 	"github.com/temporalio/temporal/service/worker/indexer"
+	"github.com/temporalio/temporal/service/worker/namespacedeletion"
 	"github.com/temporalio/temporal/service/worker/parentclosepolicy"
 	"github.com/temporalio/temporal/service/worker/replicator"
 	"github.com/temporalio/temporal/service/worker/scanner"
@@ -52,6 +53,7 @@ type (
 	// 1. Replicator: Handles applying replication tasks generated by remote clusters.
 	// 2. Indexer: Handles uploading of visibility records to elastic search.
 	// 3. Archiver: Handles archival of workflow histories.
+	// 4. Namespace deleter: Purges the data of deleted namespaces.
 	Service struct {
 		resource.Resource
 
@@ -192,6 +194,7 @@ func (s *Service) Start() {
 	if s.config.EnableParentClosePolicyWorker() {
 		s.startParentClosePolicyProcessor()
 	}
+	s.startNamespaceDeleter()
 
 	logger.Info("worker started", tag.ComponentWorker)
 	<-s.stopC
@@ -223,6 +226,12 @@ func (s *Service) startParentClosePolicyProcessor() {
 	}
 }
 
+func (s *Service) startNamespaceDeleter() {
+	if err := namespacedeletion.New(s.Resource).Start(); err != nil {
+		s.GetLogger().Fatal("error starting namespace deleter", tag.Error(err))
+	}
+}
+
 func (s *Service) startBatcher() {
 	params := &batcher.BootstrapParams{
 		Config:        *s.config.BatcherCfg,
@@ -294,6 +303,7 @@ func (s *Service) startArchiver() {
 		NamespaceCache:   s.GetNamespaceCache(),
 		Config:           s.config.ArchiverConfig,
 		ArchiverProvider: s.GetArchiverProvider(),
+		PayloadOffloader: s.GetPayloadOffloader(),
 	}
 	clientWorker := archiver.NewClientWorker(bc)
 	if err := clientWorker.Start(); err != nil {
@@ -91,6 +91,40 @@ func newAdminWorkflowCommands() []cli.Command {
 				AdminRefreshWorkflowTasks(c)
 			},
 		},
+		{
+			Name:  "export",
+			Usage: "Export the full history of a workflow run, including version histories, as JSON",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowId",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunId",
+				},
+				cli.StringFlag{
+					Name:  FlagOutputFilenameWithAlias,
+					Usage: "Output file, print to stdout if not set",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminExportWorkflow(c)
+			},
+		},
+		{
+			Name:  "import",
+			Usage: "Recreate a workflow run in the namespace from a file produced by export",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagInputFileWithAlias,
+					Usage: "Input file produced by export",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminImportWorkflow(c)
+			},
+		},
 		{
 			Name:    "delete",
 			Aliases: []string{"del"},
@@ -211,6 +245,36 @@ func newAdminShardManagementCommands() []cli.Command {
 				AdminRemoveTask(c)
 			},
 		},
+		{
+			Name:  "pin",
+			Usage: "pin a shard to a history host, the shard is moved to the host and never rebalanced",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardID,
+					Usage: "ShardId",
+				},
+				cli.StringFlag{
+					Name:  FlagHistoryAddressWithAlias,
+					Usage: "History Host address(IP:PORT)",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminPinShard(c)
+			},
+		},
+		{
+			Name:  "unpin",
+			Usage: "remove the pin of a shard, the shard goes back to the history host selected by the membership ring",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardID,
+					Usage: "ShardId",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminUnpinShard(c)
+			},
+		},
 	}
 }
 
@@ -263,6 +327,42 @@ func newAdminHistoryHostCommands() []cli.Command {
 	}
 }
 
+func newAdminHostCommands() []cli.Command {
+	hostFlags := []cli.Flag{
+		cli.StringFlag{
+			Name:  FlagAddress,
+			Usage: "Host address(IP:PORT)",
+		},
+		cli.StringFlag{
+			Name:  FlagHostServiceName,
+			Value: "history",
+			Usage: "Service running on the host, history or matching",
+		},
+		cli.BoolFlag{
+			Name:  FlagNoWait,
+			Usage: "Return right away instead of reporting progress until the host is drained",
+		},
+	}
+	return []cli.Command{
+		{
+			Name:  "drain",
+			Usage: "Gracefully move all shards or task lists away from a host without stopping it",
+			Flags: hostFlags,
+			Action: func(c *cli.Context) {
+				AdminDrainHost(c)
+			},
+		},
+		{
+			Name:  "undrain",
+			Usage: "Put a drained host back into the membership ring",
+			Flags: hostFlags[:2],
+			Action: func(c *cli.Context) {
+				AdminUndrainHost(c)
+			},
+		},
+	}
+}
+
 func newAdminNamespaceCommands() []cli.Command {
 	return []cli.Command{
 		{
@@ -309,6 +409,20 @@ func newAdminNamespaceCommands() []cli.Command {
 				AdminGetNamespaceIDOrName(c)
 			},
 		},
+		{
+			Name:    "delete",
+			Aliases: []string{"del"},
+			Usage:   "Delete workflow namespace and purge all of its data, progress is reported by namespace describe",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagSecurityTokenWithAlias,
+					Usage: "Optional token for security check",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDeleteNamespace(c)
+			},
+		},
 	}
 }
 
@@ -678,6 +792,68 @@ func newAdminClusterCommands() []cli.Command {
 				AdminDescribeCluster(c)
 			},
 		},
+		{
+			Name:    "replication-status",
+			Aliases: []string{"rs"},
+			Usage:   "Show replication progress and lag of history shards against remote clusters",
+			Flags: []cli.Flag{
+				cli.IntSliceFlag{
+					Name:  FlagShardIDWithAlias,
+					Usage: "Optional shard ID, can be repeated, all shards by default",
+				},
+				cli.StringSliceFlag{
+					Name:  FlagCluster,
+					Usage: "Optional remote cluster name, can be repeated, all remote clusters by default",
+				},
+				cli.BoolFlag{
+					Name:  FlagPrintJSONWithAlias,
+					Usage: "Print in raw json format",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminGetReplicationStatus(c)
+			},
+		},
+		{
+			Name:    "add-remote",
+			Aliases: []string{"ar"},
+			Usage:   "Add a remote cluster to the replication group or update its connection information",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagCluster,
+					Usage: "Name of the remote cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagRPCAddress,
+					Usage: "Frontend address of the remote cluster, host:port",
+				},
+				cli.Int64Flag{
+					Name:  FlagInitialFailoverVersion,
+					Usage: "Initial failover version of the remote cluster",
+				},
+				cli.BoolFlag{
+					Name:  FlagDisabled,
+					Usage: "Add the remote cluster in disabled state",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminAddOrUpdateRemoteCluster(c)
+			},
+		},
+		{
+			Name:    "remove-remote",
+			Aliases: []string{"rr"},
+			Usage:   "Remove a remote cluster from the replication group",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagCluster,
+					Usage: "Name of the remote cluster",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminRemoveRemoteCluster(c)
+			},
+		},
 	}
 }
 
@@ -690,7 +866,7 @@ func newAdminDLQCommands() []cli.Command {
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:  FlagDLQTypeWithAlias,
-					Usage: "Type of DLQ to manage. (Options: namespace, history)",
+					Usage: "Type of DLQ to manage. (Options: namespace, history, transfer, timer)",
 				},
 				cli.IntFlag{
 					Name:  FlagShardIDWithAlias,
@@ -720,7 +896,7 @@ func newAdminDLQCommands() []cli.Command {
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:  FlagDLQTypeWithAlias,
-					Usage: "Type of DLQ to manage. (Options: namespace, history)",
+					Usage: "Type of DLQ to manage. (Options: namespace, history, transfer, timer)",
 				},
 				cli.IntFlag{
 					Name:  FlagShardIDWithAlias,
@@ -742,7 +918,7 @@ func newAdminDLQCommands() []cli.Command {
 			Flags: []cli.Flag{
 				cli.StringFlag{
 					Name:  FlagDLQTypeWithAlias,
-					Usage: "Type of DLQ to manage. (Options: namespace, history)",
+					Usage: "Type of DLQ to manage. (Options: namespace, history, transfer, timer)",
 				},
 				cli.IntFlag{
 					Name:  FlagShardIDWithAlias,
@@ -760,6 +936,113 @@ func newAdminDLQCommands() []cli.Command {
 	}
 }
 
+func newAdminDynamicConfigCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:    "get",
+			Aliases: []string{"g"},
+			Usage:   "Show dynamic config values set through the admin API",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagNameWithAlias,
+					Usage: "Optional dynamic config key, all keys by default",
+				},
+				cli.BoolFlag{
+					Name:  FlagPrintJSONWithAlias,
+					Usage: "Print in raw json format",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminGetDynamicConfig(c)
+			},
+		},
+		{
+			Name:    "set",
+			Aliases: []string{"s"},
+			Usage:   "Set the values of a dynamic config key cluster wide",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagNameWithAlias,
+					Usage: "Dynamic config key",
+				},
+				cli.StringFlag{
+					Name:  FlagDynamicConfigValue,
+					Usage: "Value in YAML, applies without constraints",
+				},
+				cli.StringFlag{
+					Name:  FlagInputFileWithAlias,
+					Usage: "YAML file with the list of constrained values, in the format of a key of the dynamic config file",
+				},
+				cli.StringFlag{
+					Name:  FlagReasonWithAlias,
+					Usage: "Reason for the change",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminUpdateDynamicConfig(c)
+			},
+		},
+		{
+			Name:    "delete",
+			Aliases: []string{"del"},
+			Usage:   "Delete the values of a dynamic config key so the config file value applies again",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagNameWithAlias,
+					Usage: "Dynamic config key",
+				},
+				cli.StringFlag{
+					Name:  FlagReasonWithAlias,
+					Usage: "Reason for the change",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDeleteDynamicConfig(c)
+			},
+		},
+		{
+			Name:    "history",
+			Aliases: []string{"h"},
+			Usage:   "Show all revisions of a dynamic config key",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagNameWithAlias,
+					Usage: "Dynamic config key",
+				},
+				cli.BoolFlag{
+					Name:  FlagPrintJSONWithAlias,
+					Usage: "Print in raw json format",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminGetDynamicConfigHistory(c)
+			},
+		},
+		{
+			Name:    "rollback",
+			Aliases: []string{"rb"},
+			Usage:   "Restore the values of a dynamic config key from a previous revision",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagNameWithAlias,
+					Usage: "Dynamic config key",
+				},
+				cli.Int64Flag{
+					Name:  FlagDynamicConfigRevision,
+					Usage: "Revision to restore",
+				},
+				cli.StringFlag{
+					Name:  FlagReasonWithAlias,
+					Usage: "Reason for the change",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminRollbackDynamicConfig(c)
+			},
+		},
+	}
+}
+
 func newDBCommands() []cli.Command {
 	return []cli.Command{
 		{
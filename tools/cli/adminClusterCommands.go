@@ -26,12 +26,18 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli"
 	commonpb "go.temporal.io/temporal-proto/common"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/common"
 )
 
 // AdminAddSearchAttribute to whitelist search attribute
@@ -77,6 +83,119 @@ func AdminDescribeCluster(c *cli.Context) {
 	prettyPrintJSONObject(response)
 }
 
+// AdminGetReplicationStatus prints the replication status of history shards against remote clusters
+func AdminGetReplicationStatus(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	var shardIDs []int32
+	for _, shardID := range c.IntSlice(FlagShardID) {
+		shardIDs = append(shardIDs, int32(shardID))
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.GetReplicationStatus(ctx, &adminservice.GetReplicationStatusRequest{
+		ShardIds:       shardIDs,
+		RemoteClusters: c.StringSlice(FlagCluster),
+	})
+	if err != nil {
+		ErrorAndExit("Operation GetReplicationStatus failed.", err)
+	}
+
+	if c.Bool(FlagPrintJSON) {
+		prettyPrintJSONObject(response)
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	header := []string{"Shard", "Remote Cluster", "Max Task ID", "Remote Ack Level", "Lag (Tasks)", "Remote Ack Time", "Estimated Lag",
+		"Last Replicated Task ID", "Last Replicated Time", "DLQ Ack Level", "DLQ Size"}
+	headerColor := make([]tablewriter.Colors, len(header))
+	for i := range headerColor {
+		headerColor[i] = tableHeaderBlue
+	}
+	table.SetHeader(header)
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(headerColor...)
+	for _, shard := range response.GetShards() {
+		remoteClusters := make([]string, 0, len(shard.GetRemoteClusters()))
+		for clusterName := range shard.GetRemoteClusters() {
+			remoteClusters = append(remoteClusters, clusterName)
+		}
+		sort.Strings(remoteClusters)
+
+		for _, clusterName := range remoteClusters {
+			status := shard.GetRemoteClusters()[clusterName]
+			table.Append([]string{
+				strconv.Itoa(int(shard.GetShardId())),
+				clusterName,
+				strconv.FormatInt(shard.GetMaxReplicationTaskId(), 10),
+				strconv.FormatInt(status.GetRemoteAckLevel(), 10),
+				strconv.FormatInt(common.MaxInt64(0, shard.GetMaxReplicationTaskId()-status.GetRemoteAckLevel()), 10),
+				formatReplicationTime(status.GetRemoteAckTime()),
+				(time.Duration(status.GetEstimatedLagSeconds()) * time.Second).String(),
+				strconv.FormatInt(status.GetLastReplicatedTaskId(), 10),
+				formatReplicationTime(status.GetLastReplicatedTime()),
+				strconv.FormatInt(status.GetDlqAckLevel(), 10),
+				strconv.FormatInt(status.GetDlqSize(), 10),
+			})
+		}
+	}
+	table.Render()
+}
+
+// AdminAddOrUpdateRemoteCluster adds a remote cluster to the replication group or updates its connection information
+func AdminAddOrUpdateRemoteCluster(c *cli.Context) {
+	clusterName := getRequiredOption(c, FlagCluster)
+	if !c.IsSet(FlagInitialFailoverVersion) {
+		ErrorAndExit(fmt.Sprintf("Option %s is required", FlagInitialFailoverVersion), nil)
+	}
+	enabled := !c.Bool(FlagDisabled)
+	rpcAddress := c.String(FlagRPCAddress)
+	if enabled && rpcAddress == "" {
+		ErrorAndExit(fmt.Sprintf("Option %s is required", FlagRPCAddress), nil)
+	}
+
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	_, err := adminClient.AddOrUpdateRemoteCluster(ctx, &adminservice.AddOrUpdateRemoteClusterRequest{
+		ClusterName:            clusterName,
+		RpcAddress:             rpcAddress,
+		InitialFailoverVersion: c.Int64(FlagInitialFailoverVersion),
+		Enabled:                enabled,
+	})
+	if err != nil {
+		ErrorAndExit("Operation AddOrUpdateRemoteCluster failed.", err)
+	}
+	fmt.Printf("Remote cluster %v added or updated.\n", clusterName)
+}
+
+// AdminRemoveRemoteCluster removes a remote cluster from the replication group
+func AdminRemoveRemoteCluster(c *cli.Context) {
+	clusterName := getRequiredOption(c, FlagCluster)
+
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	_, err := adminClient.RemoveRemoteCluster(ctx, &adminservice.RemoveRemoteClusterRequest{
+		ClusterName: clusterName,
+	})
+	if err != nil {
+		ErrorAndExit("Operation RemoveRemoteCluster failed.", err)
+	}
+	fmt.Printf("Remote cluster %v removed.\n", clusterName)
+}
+
+func formatReplicationTime(unixNano int64) string {
+	if unixNano == 0 {
+		return "n/a"
+	}
+	return convertTime(unixNano, false)
+}
+
 func intValTypeToString(valType int) string {
 	switch valType {
 	case 0:
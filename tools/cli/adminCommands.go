@@ -31,6 +31,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/gocql/gocql"
 	"github.com/urfave/cli"
 	commonpb "go.temporal.io/temporal-proto/common"
@@ -38,6 +39,7 @@ import (
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
+	eventgenpb "github.com/temporalio/temporal/.gen/proto/event"
 	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/auth"
@@ -50,7 +52,11 @@ import (
 	"github.com/temporalio/temporal/tools/cassandra"
 )
 
-const maxEventID = 9999
+const (
+	maxEventID = 9999
+
+	drainHostProgressInterval = 5 * time.Second
+)
 
 // AdminShowWorkflow shows history
 func AdminShowWorkflow(c *cli.Context) {
@@ -354,6 +360,27 @@ func AdminGetNamespaceIDOrName(c *cli.Context) {
 	}
 }
 
+// AdminDeleteNamespace marks a namespace as deleting and starts purging its data
+func AdminDeleteNamespace(c *cli.Context) {
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+
+	// ask user for confirmation
+	promptMsg := fmt.Sprintf("Are you trying to delete namespace [%s] and all of its workflows? Y/N", color.YellowString(namespace))
+	prompt(promptMsg, c.GlobalBool(FlagAutoConfirm))
+
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := adminClient.DeleteNamespace(ctx, &adminservice.DeleteNamespaceRequest{
+		Namespace:     namespace,
+		SecurityToken: c.String(FlagSecurityToken),
+	})
+	if err != nil {
+		ErrorAndExit("Delete namespace failed.", err)
+	}
+	fmt.Printf("Namespace %s is being deleted by workflow %s.\n", namespace, resp.GetWorkflowId())
+}
+
 // AdminGetShardID get shardID
 func AdminGetShardID(c *cli.Context) {
 	wid := getRequiredOption(c, FlagWorkflowID)
@@ -446,6 +473,34 @@ func AdminShardManagement(c *cli.Context) {
 	}
 }
 
+// AdminPinShard pins a shard to a history host
+func AdminPinShard(c *cli.Context) {
+	sid := getRequiredIntOption(c, FlagShardID)
+	addr := getRequiredOption(c, FlagHistoryAddress)
+	setShardOwner(c, sid, addr)
+}
+
+// AdminUnpinShard removes the pin of a shard
+func AdminUnpinShard(c *cli.Context) {
+	sid := getRequiredIntOption(c, FlagShardID)
+	setShardOwner(c, sid, "")
+}
+
+func setShardOwner(c *cli.Context, shardID int, hostAddress string) {
+	adminClient := cFactory.AdminClient(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	_, err := adminClient.SetShardOwner(ctx, &adminservice.SetShardOwnerRequest{
+		ShardId:     int32(shardID),
+		HostAddress: hostAddress,
+	})
+	if err != nil {
+		ErrorAndExit("Set shard owner has failed", err)
+	}
+}
+
 // AdminDescribeHistoryHost describes history host
 func AdminDescribeHistoryHost(c *cli.Context) {
 	adminClient := cFactory.AdminClient(c)
@@ -481,10 +536,57 @@ func AdminDescribeHistoryHost(c *cli.Context) {
 
 	if !printFully {
 		resp.ShardIds = nil
+		resp.ShardLoads = nil
 	}
 	prettyPrintJSONObject(resp)
 }
 
+// AdminDrainHost moves a history or matching host out of the membership ring and
+// reports progress until the host owns nothing
+func AdminDrainHost(c *cli.Context) {
+	addr := getRequiredOption(c, FlagAddress)
+	serviceName := c.String(FlagHostServiceName)
+
+	for {
+		resp := drainHost(c, addr, serviceName, false)
+		if resp.GetDrained() {
+			fmt.Printf("Host %v is drained.\n", addr)
+			return
+		}
+		if c.Bool(FlagNoWait) {
+			prettyPrintJSONObject(resp)
+			return
+		}
+		fmt.Printf("Draining host %v: %v shards, %v task lists, %v pollers remaining.\n",
+			addr, resp.GetNumberOfShards(), resp.GetNumberOfTaskLists(), resp.GetNumberOfPollers())
+		time.Sleep(drainHostProgressInterval)
+	}
+}
+
+// AdminUndrainHost puts a drained history or matching host back into the membership ring
+func AdminUndrainHost(c *cli.Context) {
+	addr := getRequiredOption(c, FlagAddress)
+	drainHost(c, addr, c.String(FlagHostServiceName), true)
+	fmt.Printf("Host %v is back in the membership ring.\n", addr)
+}
+
+func drainHost(c *cli.Context, hostAddress string, serviceName string, undrain bool) *adminservice.DrainHostResponse {
+	adminClient := cFactory.AdminClient(c)
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	resp, err := adminClient.DrainHost(ctx, &adminservice.DrainHostRequest{
+		HostAddress: hostAddress,
+		ServiceName: serviceName,
+		Undrain:     undrain,
+	})
+	if err != nil {
+		ErrorAndExit("Drain host has failed", err)
+	}
+	return resp
+}
+
 // AdminRefreshWorkflowTasks refreshes all the tasks of a workflow
 func AdminRefreshWorkflowTasks(c *cli.Context) {
 	adminClient := cFactory.AdminClient(c)
@@ -509,3 +611,84 @@ func AdminRefreshWorkflowTasks(c *cli.Context) {
 		fmt.Println("Refresh workflow task succeeded.")
 	}
 }
+
+// AdminExportWorkflow exports the full history of a workflow run
+func AdminExportWorkflow(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	outputFileName := c.String(FlagOutputFilename)
+
+	var export *eventgenpb.WorkflowExecutionHistoryExport
+	var nextPageToken []byte
+	for {
+		ctx, cancel := newContext(c)
+		resp, err := adminClient.ExportWorkflowExecution(ctx, &adminservice.ExportWorkflowExecutionRequest{
+			Namespace: namespace,
+			Execution: &commonpb.WorkflowExecution{
+				WorkflowId: wid,
+				RunId:      rid,
+			},
+			NextPageToken: nextPageToken,
+		})
+		cancel()
+		if err != nil {
+			ErrorAndExit("Export workflow failed", err)
+		}
+
+		if export == nil {
+			export = resp.GetExport()
+			// pin the run resolved by the first page
+			rid = export.GetExecution().GetRunId()
+		} else {
+			export.HistoryBatches = append(export.HistoryBatches, resp.GetExport().GetHistoryBatches()...)
+		}
+		nextPageToken = resp.GetNextPageToken()
+		if len(nextPageToken) == 0 {
+			break
+		}
+	}
+
+	data, err := codec.NewJSONPBIndentEncoder("  ").Encode(export)
+	if err != nil {
+		ErrorAndExit("Failed to serialize history export.", err)
+	}
+	if outputFileName == "" {
+		fmt.Println(string(data))
+		return
+	}
+	if err := ioutil.WriteFile(outputFileName, data, 0666); err != nil {
+		ErrorAndExit("Failed to write history export file.", err)
+	}
+}
+
+// AdminImportWorkflow recreates a workflow run from an exported history
+func AdminImportWorkflow(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	inputFileName := getRequiredOption(c, FlagInputFile)
+
+	data, err := ioutil.ReadFile(inputFileName)
+	if err != nil {
+		ErrorAndExit("Failed to read history export file.", err)
+	}
+	export := &eventgenpb.WorkflowExecutionHistoryExport{}
+	if err := codec.NewJSONPBEncoder().Decode(data, export); err != nil {
+		ErrorAndExit("Failed to deserialize history export.", err)
+	}
+
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	_, err = adminClient.ImportWorkflowExecution(ctx, &adminservice.ImportWorkflowExecutionRequest{
+		Namespace: namespace,
+		Export:    export,
+	})
+	if err != nil {
+		ErrorAndExit("Import workflow failed", err)
+	}
+	fmt.Printf("Imported workflow %v, run %v.\n", export.GetExecution().GetWorkflowId(), export.GetExecution().GetRunId())
+}
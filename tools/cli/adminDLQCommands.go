@@ -29,10 +29,12 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/urfave/cli"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	commongenpb "github.com/temporalio/temporal/.gen/proto/common"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
 	replicationgenpb "github.com/temporalio/temporal/.gen/proto/replication"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/codec"
@@ -65,6 +67,7 @@ func AdminGetDLQMessages(c *cli.Context) {
 	paginationFunc := func(paginationToken []byte) ([]interface{}, []byte, error) {
 		resp, err := adminClient.ReadDLQMessages(ctx, &adminservice.ReadDLQMessagesRequest{
 			Type:                  toQueueType(dlqType),
+			ShardId:               int32(c.Int(FlagShardID)),
 			InclusiveEndMessageId: lastMessageID,
 			MaximumPageSize:       defaultPageSize,
 			NextPageToken:         paginationToken,
@@ -76,6 +79,12 @@ func AdminGetDLQMessages(c *cli.Context) {
 		for _, item := range resp.GetReplicationTasks() {
 			paginateItems = append(paginateItems, item)
 		}
+		for _, item := range resp.GetTransferTasks() {
+			paginateItems = append(paginateItems, item)
+		}
+		for _, item := range resp.GetTimerTasks() {
+			paginateItems = append(paginateItems, item)
+		}
 		return paginateItems, resp.GetNextPageToken(), err
 	}
 
@@ -87,14 +96,21 @@ func AdminGetDLQMessages(c *cli.Context) {
 			ErrorAndExit(fmt.Sprintf("fail to read dlq message. Last read message id: %v", lastReadMessageID), err)
 		}
 
-		task := item.(*replicationgenpb.ReplicationTask)
+		task := item.(proto.Message)
 		encoder := codec.NewJSONPBIndentEncoder(" ")
 		taskStr, err := encoder.Encode(task)
 		if err != nil {
 			ErrorAndExit(fmt.Sprintf("fail to encode dlq message. Last read message id: %v", lastReadMessageID), err)
 		}
 
-		lastReadMessageID = int(task.SourceTaskId)
+		switch task := task.(type) {
+		case *replicationgenpb.ReplicationTask:
+			lastReadMessageID = int(task.SourceTaskId)
+		case *persistenceblobs.TransferTaskInfo:
+			lastReadMessageID = int(task.TaskId)
+		case *persistenceblobs.TimerTaskInfo:
+			lastReadMessageID = int(task.TaskId)
+		}
 		remainingMessageCount--
 		_, err = outputFile.WriteString(fmt.Sprintf("%v\n", string(taskStr)))
 		if err != nil {
@@ -120,6 +136,7 @@ func AdminPurgeDLQMessages(c *cli.Context) {
 	adminClient := cFactory.AdminClient(c)
 	if _, err := adminClient.PurgeDLQMessages(ctx, &adminservice.PurgeDLQMessagesRequest{
 		Type:                  toQueueType(dlqType),
+		ShardId:               int32(c.Int(FlagShardID)),
 		InclusiveEndMessageId: lastMessageID,
 	}); err != nil {
 		ErrorAndExit("Failed to purge dlq", nil)
@@ -144,6 +161,7 @@ func AdminMergeDLQMessages(c *cli.Context) {
 	adminClient := cFactory.AdminClient(c)
 	request := &adminservice.MergeDLQMessagesRequest{
 		Type:                  toQueueType(dlqType),
+		ShardId:               int32(c.Int(FlagShardID)),
 		InclusiveEndMessageId: lastMessageID,
 		MaximumPageSize:       defaultPageSize,
 	}
@@ -168,6 +186,10 @@ func toQueueType(dlqType string) commongenpb.DLQType {
 		return commongenpb.DLQType_Namespace
 	case "history":
 		return commongenpb.DLQType_Replication
+	case "transfer":
+		return commongenpb.DLQType_Transfer
+	case "timer":
+		return commongenpb.DLQType_Timer
 	default:
 		ErrorAndExit("The queue type is not supported.", fmt.Errorf("the queue type is not supported. Type: %v", dlqType))
 	}
@@ -0,0 +1,196 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/.gen/proto/persistenceblobs"
+)
+
+// AdminGetDynamicConfig shows dynamic config values set through the admin API
+func AdminGetDynamicConfig(c *cli.Context) {
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.GetDynamicConfig(ctx, &adminservice.GetDynamicConfigRequest{
+		Name: c.String(FlagName),
+	})
+	if err != nil {
+		ErrorAndExit("Operation GetDynamicConfig failed.", err)
+	}
+
+	if c.Bool(FlagPrintJSON) {
+		prettyPrintJSONObject(response)
+		return
+	}
+	renderDynamicConfigTable(response.GetValues())
+}
+
+// AdminUpdateDynamicConfig sets the values of a dynamic config key cluster wide
+func AdminUpdateDynamicConfig(c *cli.Context) {
+	name := getRequiredOption(c, FlagName)
+	values := getDynamicConfigValues(c)
+
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.UpdateDynamicConfig(ctx, &adminservice.UpdateDynamicConfigRequest{
+		Name:     name,
+		Values:   values,
+		Reason:   c.String(FlagReason),
+		Identity: getCliIdentity(),
+	})
+	if err != nil {
+		ErrorAndExit("Operation UpdateDynamicConfig failed.", err)
+	}
+	fmt.Printf("Dynamic config %v updated to revision %v.\n", name, response.GetRevision())
+}
+
+// AdminDeleteDynamicConfig deletes the values of a dynamic config key
+func AdminDeleteDynamicConfig(c *cli.Context) {
+	name := getRequiredOption(c, FlagName)
+
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.DeleteDynamicConfig(ctx, &adminservice.DeleteDynamicConfigRequest{
+		Name:     name,
+		Reason:   c.String(FlagReason),
+		Identity: getCliIdentity(),
+	})
+	if err != nil {
+		ErrorAndExit("Operation DeleteDynamicConfig failed.", err)
+	}
+	fmt.Printf("Dynamic config %v deleted in revision %v.\n", name, response.GetRevision())
+}
+
+// AdminGetDynamicConfigHistory shows all revisions of a dynamic config key
+func AdminGetDynamicConfigHistory(c *cli.Context) {
+	name := getRequiredOption(c, FlagName)
+
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.GetDynamicConfigHistory(ctx, &adminservice.GetDynamicConfigHistoryRequest{
+		Name: name,
+	})
+	if err != nil {
+		ErrorAndExit("Operation GetDynamicConfigHistory failed.", err)
+	}
+
+	if c.Bool(FlagPrintJSON) {
+		prettyPrintJSONObject(response)
+		return
+	}
+	renderDynamicConfigTable(response.GetRevisions())
+}
+
+// AdminRollbackDynamicConfig restores the values of a dynamic config key from a previous revision
+func AdminRollbackDynamicConfig(c *cli.Context) {
+	name := getRequiredOption(c, FlagName)
+	if !c.IsSet(FlagDynamicConfigRevision) {
+		ErrorAndExit(fmt.Sprintf("Option %s is required", FlagDynamicConfigRevision), nil)
+	}
+
+	adminClient := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	response, err := adminClient.RollbackDynamicConfig(ctx, &adminservice.RollbackDynamicConfigRequest{
+		Name:     name,
+		Revision: c.Int64(FlagDynamicConfigRevision),
+		Reason:   c.String(FlagReason),
+		Identity: getCliIdentity(),
+	})
+	if err != nil {
+		ErrorAndExit("Operation RollbackDynamicConfig failed.", err)
+	}
+	fmt.Printf("Dynamic config %v rolled back to revision %v as revision %v.\n",
+		name, c.Int64(FlagDynamicConfigRevision), response.GetRevision())
+}
+
+func getDynamicConfigValues(c *cli.Context) string {
+	if c.IsSet(FlagDynamicConfigValue) == c.IsSet(FlagInputFile) {
+		ErrorAndExit(fmt.Sprintf("Exactly one of options %s and %s is required", FlagDynamicConfigValue, FlagInputFile), nil)
+	}
+
+	if c.IsSet(FlagInputFile) {
+		data, err := ioutil.ReadFile(c.String(FlagInputFile))
+		if err != nil {
+			ErrorAndExit("Unable to read input file.", err)
+		}
+		return string(data)
+	}
+
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(c.String(FlagDynamicConfigValue)), &value); err != nil {
+		ErrorAndExit("Unable to parse value.", err)
+	}
+	data, err := yaml.Marshal([]map[string]interface{}{{"value": value}})
+	if err != nil {
+		ErrorAndExit("Unable to encode value.", err)
+	}
+	return string(data)
+}
+
+func renderDynamicConfigTable(values []*persistenceblobs.DynamicConfigValue) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetAutoWrapText(false)
+	header := []string{"Name", "Revision", "Values", "Deleted", "Updated By", "Update Time", "Reason"}
+	headerColor := make([]tablewriter.Colors, len(header))
+	for i := range headerColor {
+		headerColor[i] = tableHeaderBlue
+	}
+	table.SetHeader(header)
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(headerColor...)
+	for _, value := range values {
+		updateTime := ""
+		if t, err := types.TimestampFromProto(value.GetUpdateTime()); err == nil {
+			updateTime = convertTime(t.UnixNano(), false)
+		}
+		table.Append([]string{
+			value.GetName(),
+			strconv.FormatInt(value.GetRevision(), 10),
+			value.GetValues(),
+			strconv.FormatBool(value.GetDeleted()),
+			value.GetUpdatedBy(),
+			updateTime,
+			value.GetReason(),
+		})
+	}
+	table.Render()
+}
@@ -69,7 +69,13 @@ func NewCliApp() *cli.App {
 			Usage:  "automatically confirm all prompts",
 			Hidden: true,
 		},
+		cli.StringFlag{
+			Name:   FlagPayloadKeyringFile,
+			Usage:  "optional keyring file to decrypt encrypted payloads when printing them",
+			EnvVar: "TEMPORAL_CLI_PAYLOAD_KEYRING_FILE",
+		},
 	}
+	app.Before = loadPayloadCodec
 	app.Commands = []cli.Command{
 		{
 			Name:        "namespace",
@@ -123,6 +129,11 @@ func NewCliApp() *cli.App {
 					Usage:       "Run admin operation on history host",
 					Subcommands: newAdminHistoryHostCommands(),
 				},
+				{
+					Name:        "host",
+					Usage:       "Run admin operation on history or matching host",
+					Subcommands: newAdminHostCommands(),
+				},
 				{
 					Name:        "kafka",
 					Aliases:     []string{"ka"},
@@ -159,6 +170,12 @@ func NewCliApp() *cli.App {
 					Usage:       "Run admin operation on DLQ",
 					Subcommands: newAdminDLQCommands(),
 				},
+				{
+					Name:        "config",
+					Aliases:     []string{"cfg"},
+					Usage:       "Run admin operation on dynamic config stored in database",
+					Subcommands: newAdminDynamicConfigCommands(),
+				},
 				{
 					Name:        "db",
 					Aliases:     []string{"db"},
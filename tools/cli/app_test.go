@@ -25,6 +25,7 @@
 package cli
 
 import (
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -50,6 +51,8 @@ import (
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	"github.com/temporalio/temporal/.gen/proto/adminservicemock"
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservice"
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservicemock"
 	"github.com/temporalio/temporal/common/payload"
 	"github.com/temporalio/temporal/common/payloads"
 )
@@ -60,12 +63,14 @@ type cliAppSuite struct {
 	mockCtrl          *gomock.Controller
 	frontendClient    *workflowservicemock.MockWorkflowServiceClient
 	serverAdminClient *adminservicemock.MockAdminServiceClient
+	streamClient      *workflowstreamservicemock.MockWorkflowStreamServiceClient
 	sdkClient         *sdkmocks.Client
 }
 
 type clientFactoryMock struct {
 	frontendClient    workflowservice.WorkflowServiceClient
 	serverAdminClient adminservice.AdminServiceClient
+	streamClient      workflowstreamservice.WorkflowStreamServiceClient
 	sdkClient         *sdkmocks.Client
 }
 
@@ -77,6 +82,10 @@ func (m *clientFactoryMock) AdminClient(c *cli.Context) adminservice.AdminServic
 	return m.serverAdminClient
 }
 
+func (m *clientFactoryMock) WorkflowStreamClient(c *cli.Context) workflowstreamservice.WorkflowStreamServiceClient {
+	return m.streamClient
+}
+
 func (m *clientFactoryMock) SDKClient(c *cli.Context, namespace string) sdkclient.Client {
 	return m.sdkClient
 }
@@ -103,10 +112,12 @@ func (s *cliAppSuite) SetupTest() {
 
 	s.frontendClient = workflowservicemock.NewMockWorkflowServiceClient(s.mockCtrl)
 	s.serverAdminClient = adminservicemock.NewMockAdminServiceClient(s.mockCtrl)
+	s.streamClient = workflowstreamservicemock.NewMockWorkflowStreamServiceClient(s.mockCtrl)
 	s.sdkClient = &sdkmocks.Client{}
 	SetFactory(&clientFactoryMock{
 		frontendClient:    s.frontendClient,
 		serverAdminClient: s.serverAdminClient,
+		streamClient:      s.streamClient,
 		sdkClient:         s.sdkClient,
 	})
 }
@@ -566,27 +577,69 @@ func (s *cliAppSuite) TestDescribeTaskList_Activity() {
 }
 
 func (s *cliAppSuite) TestObserveWorkflow() {
-	s.sdkClient.On("GetWorkflowHistory", mock.Anything, "wid", "", mock.Anything, mock.Anything).Return(historyEventIterator()).Once()
+	s.streamClient.EXPECT().StreamWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(s.historyStream(), nil)
 	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "workflow", "observe", "-w", "wid"})
 	s.Nil(err)
-	s.sdkClient.AssertExpectations(s.T())
 
-	s.sdkClient.On("GetWorkflowHistory", mock.Anything, "wid", "", mock.Anything, mock.Anything).Return(historyEventIterator()).Once()
+	s.streamClient.EXPECT().StreamWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(s.historyStream(), nil)
 	err = s.app.Run([]string{"", "--ns", cliTestNamespace, "workflow", "observe", "-w", "wid", "-sd"})
 	s.Nil(err)
-	s.sdkClient.AssertExpectations(s.T())
 }
 
 func (s *cliAppSuite) TestObserveWorkflowWithID() {
-	s.sdkClient.On("GetWorkflowHistory", mock.Anything, "wid", "", mock.Anything, mock.Anything).Return(historyEventIterator()).Once()
+	s.streamClient.EXPECT().StreamWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(s.historyStream(), nil)
 	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "workflow", "observeid", "wid"})
 	s.Nil(err)
-	s.sdkClient.AssertExpectations(s.T())
 
-	s.sdkClient.On("GetWorkflowHistory", mock.Anything, "wid", "", mock.Anything, mock.Anything).Return(historyEventIterator()).Once()
+	s.streamClient.EXPECT().StreamWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(s.historyStream(), nil)
 	err = s.app.Run([]string{"", "--ns", cliTestNamespace, "workflow", "observeid", "wid", "-sd"})
 	s.Nil(err)
-	s.sdkClient.AssertExpectations(s.T())
+}
+
+func (s *cliAppSuite) TestObserveWorkflow_Resumed() {
+	stream := workflowstreamservicemock.NewMockWorkflowStreamService_StreamWorkflowExecutionHistoryClient(s.mockCtrl)
+	stream.EXPECT().Recv().Return(&workflowstreamservice.StreamWorkflowExecutionHistoryResponse{
+		RunId:       "rid",
+		Events:      []*eventpb.HistoryEvent{{EventId: 1, EventType: eventType}},
+		NextEventId: 2,
+	}, nil)
+	stream.EXPECT().Recv().Return(nil, serviceerror.NewUnavailable("faked error"))
+	gomock.InOrder(
+		s.streamClient.EXPECT().StreamWorkflowExecutionHistory(gomock.Any(), &workflowstreamservice.StreamWorkflowExecutionHistoryRequest{
+			Namespace:    cliTestNamespace,
+			Execution:    &commonpb.WorkflowExecution{WorkflowId: "wid"},
+			FirstEventId: 1,
+		}).Return(stream, nil),
+		s.streamClient.EXPECT().StreamWorkflowExecutionHistory(gomock.Any(), &workflowstreamservice.StreamWorkflowExecutionHistoryRequest{
+			Namespace:    cliTestNamespace,
+			Execution:    &commonpb.WorkflowExecution{WorkflowId: "wid", RunId: "rid"},
+			FirstEventId: 2,
+		}).Return(s.historyStream(), nil),
+	)
+	err := s.app.Run([]string{"", "--ns", cliTestNamespace, "workflow", "observe", "-w", "wid"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) historyStream() workflowstreamservice.WorkflowStreamService_StreamWorkflowExecutionHistoryClient {
+	stream := workflowstreamservicemock.NewMockWorkflowStreamService_StreamWorkflowExecutionHistoryClient(s.mockCtrl)
+	gomock.InOrder(
+		stream.EXPECT().Recv().Return(&workflowstreamservice.StreamWorkflowExecutionHistoryResponse{
+			RunId: "rid",
+			Events: []*eventpb.HistoryEvent{{
+				EventId:   2,
+				EventType: eventType,
+				Attributes: &eventpb.HistoryEvent_WorkflowExecutionStartedEventAttributes{WorkflowExecutionStartedEventAttributes: &eventpb.WorkflowExecutionStartedEventAttributes{
+					WorkflowType: &commonpb.WorkflowType{Name: "TestWorkflow"},
+					TaskList:     &tasklistpb.TaskList{Name: "taskList"},
+					Identity:     "tester",
+				}},
+			}},
+			NextEventId:    3,
+			WorkflowStatus: executionpb.WorkflowExecutionStatus_Completed,
+		}, nil),
+		stream.EXPECT().Recv().Return(nil, io.EOF),
+	)
+	return stream
 }
 
 // TestParseTime tests the parsing of date argument in UTC and UnixNano formats
@@ -104,6 +104,14 @@ func newBatchCommands() []cli.Command {
 					Name:  FlagInputWithAlias,
 					Usage: "Optional input of signal",
 				},
+				cli.StringFlag{
+					Name:  FlagResetReapplyType,
+					Usage: "Optional events to reapply for batch reset. Support one of these: Signal,None",
+				},
+				cli.StringSliceFlag{
+					Name:  FlagResetReapplySignalName,
+					Usage: "Optional names of signals to reapply for batch reset, can be passed multiple times",
+				},
 				cli.IntFlag{
 					Name:  FlagRPS,
 					Value: batcher.DefaultRPS,
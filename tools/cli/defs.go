@@ -86,6 +86,7 @@ var resetTypesMap = map[string]string{
 	"LastDecisionCompleted":  "",
 	"LastContinuedAsNew":     "",
 	"BadBinary":              FlagResetBadBinaryChecksum,
+	"LastRunStart":           "",
 }
 
 type jsonType int
@@ -32,6 +32,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/temporalio/temporal/.gen/proto/adminservice"
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservice"
 	"github.com/temporalio/temporal/common/rpc"
 )
 
@@ -39,6 +40,7 @@ import (
 type ClientFactory interface {
 	FrontendClient(c *cli.Context) workflowservice.WorkflowServiceClient
 	AdminClient(c *cli.Context) adminservice.AdminServiceClient
+	WorkflowStreamClient(c *cli.Context) workflowstreamservice.WorkflowStreamServiceClient
 	SDKClient(c *cli.Context, namespace string) sdkclient.Client
 }
 
@@ -72,6 +74,13 @@ func (b *clientFactory) AdminClient(c *cli.Context) adminservice.AdminServiceCli
 	return adminservice.NewAdminServiceClient(connection)
 }
 
+// WorkflowStreamClient builds a client of the streaming APIs of the frontend
+func (b *clientFactory) WorkflowStreamClient(c *cli.Context) workflowstreamservice.WorkflowStreamServiceClient {
+	connection := b.createGRPCConnection(c.GlobalString(FlagAddress))
+
+	return workflowstreamservice.NewWorkflowStreamServiceClient(connection)
+}
+
 // AdminClient builds an admin client (based on server side thrift interface)
 func (b *clientFactory) SDKClient(c *cli.Context, namespace string) sdkclient.Client {
 	hostPort := c.GlobalString(FlagAddress)
@@ -186,6 +186,8 @@ const (
 	FlagResetType                         = "reset_type"
 	FlagResetPointsOnly                   = "reset_points_only"
 	FlagResetBadBinaryChecksum            = "reset_bad_binary_checksum"
+	FlagResetReapplyType                  = "reapply_type"
+	FlagResetReapplySignalName            = "reapply_signal_name"
 	FlagListQuery                         = "query"
 	FlagListQueryWithAlias                = FlagListQuery + ", q"
 	FlagBatchType                         = "batch_type"
@@ -224,6 +226,15 @@ const (
 	FlagUpperShardBound                   = "upper_shard_bound"
 	FlagInputDirectory                    = "input_directory"
 	FlagAutoConfirm                       = "auto_confirm"
+	FlagPayloadKeyringFile                = "payload_keyring_file"
+	FlagCompletionCallbackURL             = "completion_callback_url"
+	FlagRPCAddress                        = "rpc_address"
+	FlagInitialFailoverVersion            = "initial_failover_version"
+	FlagDisabled                          = "disabled"
+	FlagHostServiceName                   = "service"
+	FlagNoWait                            = "no_wait"
+	FlagDynamicConfigValue                = "value"
+	FlagDynamicConfigRevision             = "revision"
 )
 
 var flagsForExecution = []cli.Flag{
@@ -352,6 +363,11 @@ func getFlagsForStart() []cli.Flag {
 				"If value is array, use json array like [\"a\",\"b\"], [1,2], [\"true\",\"false\"], [\"2019-06-07T17:16:34-08:00\",\"2019-06-07T18:16:34-08:00\"]. " +
 				"Use 'cluster get-search-attr' cmd to list legal keys and value types",
 		},
+		cli.StringSliceFlag{
+			Name: FlagCompletionCallbackURL,
+			Usage: "Optional http(s) URL which is notified with a POST request when the workflow completes. " +
+				"Can be specified multiple times",
+		},
 	}
 }
 
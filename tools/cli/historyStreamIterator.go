@@ -0,0 +1,162 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	commonpb "go.temporal.io/temporal-proto/common"
+	eventpb "go.temporal.io/temporal-proto/event"
+	"go.temporal.io/temporal-proto/serviceerror"
+
+	"github.com/temporalio/temporal/.gen/proto/workflowstreamservice"
+	"github.com/temporalio/temporal/common"
+)
+
+const (
+	historyStreamRetryInterval = time.Second
+	historyStreamMaxAttempts   = 10
+)
+
+type (
+	// historyStreamIterator iterates over the history events of a workflow run streamed by the frontend,
+	// the stream is resumed after the last received event when it fails with a transient error
+	historyStreamIterator struct {
+		ctx         context.Context
+		client      workflowstreamservice.WorkflowStreamServiceClient
+		namespace   string
+		execution   *commonpb.WorkflowExecution
+		stream      workflowstreamservice.WorkflowStreamService_StreamWorkflowExecutionHistoryClient
+		nextEventID int64
+		attempts    int
+		events      []*eventpb.HistoryEvent
+		done        bool
+		err         error
+	}
+)
+
+var errNoMoreHistoryEvents = errors.New("no more history events")
+
+func newHistoryStreamIterator(
+	ctx context.Context,
+	client workflowstreamservice.WorkflowStreamServiceClient,
+	namespace string,
+	wid string,
+	rid string,
+) *historyStreamIterator {
+	return &historyStreamIterator{
+		ctx:         ctx,
+		client:      client,
+		namespace:   namespace,
+		execution:   &commonpb.WorkflowExecution{WorkflowId: wid, RunId: rid},
+		nextEventID: common.FirstEventID,
+	}
+}
+
+// HasNext blocks until the next event is received, it returns false once the run is closed
+func (it *historyStreamIterator) HasNext() bool {
+	for len(it.events) == 0 && !it.done && it.err == nil {
+		it.err = it.receive()
+	}
+	return len(it.events) > 0 || it.err != nil
+}
+
+// Next returns the next event, or the error that ended the stream
+func (it *historyStreamIterator) Next() (*eventpb.HistoryEvent, error) {
+	if !it.HasNext() {
+		return nil, errNoMoreHistoryEvents
+	}
+	if len(it.events) == 0 {
+		err := it.err
+		it.err = nil
+		it.done = true
+		return nil, err
+	}
+	event := it.events[0]
+	it.events = it.events[1:]
+	return event, nil
+}
+
+func (it *historyStreamIterator) receive() error {
+	if it.stream == nil {
+		stream, err := it.client.StreamWorkflowExecutionHistory(it.ctx, &workflowstreamservice.StreamWorkflowExecutionHistoryRequest{
+			Namespace:    it.namespace,
+			Execution:    it.execution,
+			FirstEventId: it.nextEventID,
+		})
+		if err != nil {
+			return it.backoff(err)
+		}
+		it.stream = stream
+	}
+
+	response, err := it.stream.Recv()
+	if err == io.EOF {
+		it.done = true
+		return nil
+	}
+	if err != nil {
+		it.stream = nil
+		return it.backoff(err)
+	}
+	it.attempts = 0
+	// a resumed stream must follow the same run even if the workflow was continued as new in the meantime
+	it.execution = &commonpb.WorkflowExecution{WorkflowId: it.execution.GetWorkflowId(), RunId: response.GetRunId()}
+	if events := response.GetEvents(); len(events) > 0 {
+		it.events = events
+		it.nextEventID = events[len(events)-1].GetEventId() + 1
+	}
+	return nil
+}
+
+// backoff waits before the stream is resumed, it returns the error if the stream can not be resumed
+func (it *historyStreamIterator) backoff(err error) error {
+	if !isTransientHistoryStreamError(err) {
+		return err
+	}
+	it.attempts++
+	if it.attempts >= historyStreamMaxAttempts {
+		return err
+	}
+	select {
+	case <-time.After(historyStreamRetryInterval):
+		return nil
+	case <-it.ctx.Done():
+		return err
+	}
+}
+
+func isTransientHistoryStreamError(err error) bool {
+	switch err.(type) {
+	case *serviceerror.Unavailable,
+		*serviceerror.ResourceExhausted,
+		*serviceerror.CurrentBranchChanged:
+		return true
+	}
+	return false
+}
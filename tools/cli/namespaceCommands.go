@@ -336,6 +336,13 @@ func printNamespace(resp *workflowservice.DescribeNamespaceResponse) {
 		formatStr = formatStr + "VisibilityArchivalURI: %v\n"
 		descValues = append(descValues, resp.Configuration.GetVisibilityArchivalURI())
 	}
+	if resp.NamespaceInfo.GetStatus() == namespacepb.NamespaceStatus_Deleted {
+		formatStr = formatStr + "DeletionStage: %v\nDeletedExecutions: %v\n"
+		descValues = append(descValues,
+			resp.NamespaceInfo.Data[namespace.DeletionStageKey],
+			resp.NamespaceInfo.Data[namespace.DeletionExecutionsDeletedKey],
+		)
+	}
 	fmt.Printf(formatStr, descValues...)
 	if resp.Configuration.BadBinaries != nil {
 		fmt.Println("Bad binaries to reset:")
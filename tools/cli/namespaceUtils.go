@@ -265,6 +265,8 @@ func initializeNamespaceHandler(
 	return namespace.NewHandler(
 		namespace.MinRetentionDays,
 		dynamicconfig.GetIntPropertyFilteredByNamespace(namespace.MaxBadBinaries),
+		dynamicconfig.GetDurationPropertyFnFilteredByNamespace(0),
+		dynamicconfig.GetIntPropertyFilteredByNamespace(0),
 		logger,
 		metadataMgr,
 		clusterMetadata,
@@ -299,6 +301,7 @@ func initializeMetadataMgr(
 		nil, // TODO propagate abstract datastore factory from the CLI.
 		clusterMetadata.GetCurrentClusterName(),
 		metricsClient,
+		nil,
 		logger,
 	)
 	metadata, err := pFactory.NewMetadataManager()
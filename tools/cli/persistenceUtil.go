@@ -64,6 +64,7 @@ func CreatePersistenceFactory(c *cli.Context) persistenceClient.Factory {
 		params.AbstractDatastoreFactory,
 		c.String(FlagTargetCluster),
 		nil, // MetricsClient
+		nil, // Tracer
 		loggerimpl.NewNopLogger(),
 	)
 
@@ -53,10 +53,56 @@ import (
 
 	"github.com/temporalio/temporal/common/codec"
 	"github.com/temporalio/temporal/common/payload"
+	"github.com/temporalio/temporal/common/payloadcodec"
 	"github.com/temporalio/temporal/common/payloads"
 	"github.com/temporalio/temporal/common/rpc"
 )
 
+// payloadCodec decodes encrypted payloads for printing, it is only set when a payload keyring file is provided
+var payloadCodec payloadcodec.PayloadCodec
+
+// payloadNamespace is the namespace of the printed payloads, payloads are only decrypted with the key of their namespace
+var payloadNamespace string
+
+func loadPayloadCodec(c *cli.Context) error {
+	keyringFile := c.GlobalString(FlagPayloadKeyringFile)
+	if len(keyringFile) == 0 {
+		return nil
+	}
+
+	payloadNamespace = c.GlobalString(FlagNamespace)
+	keyring, err := payloadcodec.LoadKeyringFile(keyringFile)
+	if err != nil {
+		return fmt.Errorf("unable to load payload keyring file: %v", err)
+	}
+	payloadCodec, err = payloadcodec.NewAESGCMCodec(keyring)
+	return err
+}
+
+// decodePayload returns a decrypted copy of the payload if it is encrypted and the key is known,
+// otherwise the payload itself is returned
+func decodePayload(p *commonpb.Payload) *commonpb.Payload {
+	if payloadCodec == nil || !payloadcodec.IsEncrypted(p) {
+		return p
+	}
+	decoded := proto.Clone(p).(*commonpb.Payload)
+	if err := payloadCodec.Decode(payloadNamespace, decoded); err != nil {
+		return p
+	}
+	return decoded
+}
+
+func decodePayloads(ps *commonpb.Payloads) *commonpb.Payloads {
+	if payloadCodec == nil || ps == nil {
+		return ps
+	}
+	decoded := &commonpb.Payloads{}
+	for _, p := range ps.GetPayloads() {
+		decoded.Payloads = append(decoded.Payloads, decodePayload(p))
+	}
+	return decoded
+}
+
 // GetHistory helper method to iterate over all pages and return complete list of history events
 func GetHistory(ctx context.Context, workflowClient sdkclient.Client, workflowID, runID string) (*eventpb.History, error) {
 	iter := workflowClient.GetWorkflowHistory(ctx, workflowID, runID, false,
@@ -130,7 +176,7 @@ func valueToString(v reflect.Value, printFully bool, maxFieldLength int) string
 	switch v.Kind() {
 	case reflect.Ptr:
 		if ps, isPayloads := v.Interface().(*commonpb.Payloads); isPayloads {
-			return payloads.ToString(ps)
+			return payloads.ToString(decodePayloads(ps))
 		}
 		return valueToString(v.Elem(), printFully, maxFieldLength)
 	case reflect.Struct:
@@ -155,7 +201,7 @@ func valueToString(v reflect.Value, printFully bool, maxFieldLength int) string
 			case []byte:
 				str += string(typedV)
 			case *commonpb.Payload:
-				str += payload.ToString(typedV)
+				str += payload.ToString(decodePayload(typedV))
 			default:
 				str += val.String()
 			}
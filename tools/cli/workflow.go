@@ -254,6 +254,14 @@ func newWorkflowCommands() []cli.Command {
 					Name:  FlagResetBadBinaryChecksum,
 					Usage: "Binary checksum for resetType of BadBinary",
 				},
+				cli.StringFlag{
+					Name:  FlagResetReapplyType,
+					Usage: "events to reapply after the reset point. Support one of these: Signal,None",
+				},
+				cli.StringSliceFlag{
+					Name:  FlagResetReapplySignalName,
+					Usage: "only reapply signals with this name, can be passed multiple times",
+				},
 			},
 			Action: func(c *cli.Context) {
 				ResetWorkflow(c)
@@ -318,6 +326,14 @@ func newWorkflowCommands() []cli.Command {
 					Name:  FlagResetBadBinaryChecksum,
 					Usage: "Binary checksum for resetType of BadBinary",
 				},
+				cli.StringFlag{
+					Name:  FlagResetReapplyType,
+					Usage: "events to reapply after the reset point. Support one of these: Signal,None",
+				},
+				cli.StringSliceFlag{
+					Name:  FlagResetReapplySignalName,
+					Usage: "only reapply signals with this name, can be passed multiple times",
+				},
 			},
 			Action: func(c *cli.Context) {
 				ResetInBatch(c)
@@ -212,6 +212,10 @@ func StartBatchJob(c *cli.Context) {
 			SignalName: sigName,
 			Input:      sigInput,
 		},
+		ResetParams: batcher.ResetParams{
+			ReapplyType:        c.String(FlagResetReapplyType),
+			ReapplySignalNames: c.StringSlice(FlagResetReapplySignalName),
+		},
 		RPS: rps,
 	}
 	wf, err := client.ExecuteWorkflow(tcCtx, options, batcher.BatchWFTypeName, params)
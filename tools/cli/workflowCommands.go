@@ -54,11 +54,16 @@ import (
 	tasklistpb "go.temporal.io/temporal-proto/tasklist"
 	"go.temporal.io/temporal-proto/workflowservice"
 	"go.temporal.io/temporal/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
+	"github.com/temporalio/temporal/.gen/proto/adminservice"
 	cligenpb "github.com/temporalio/temporal/.gen/proto/cli"
+	executiongenpb "github.com/temporalio/temporal/.gen/proto/execution"
 	"github.com/temporalio/temporal/common"
 	"github.com/temporalio/temporal/common/clock"
 	"github.com/temporalio/temporal/common/codec"
+	"github.com/temporalio/temporal/common/headers"
 	"github.com/temporalio/temporal/common/payload"
 	"github.com/temporalio/temporal/common/payloads"
 	"github.com/temporalio/temporal/service/history"
@@ -231,6 +236,7 @@ func startWorkflowHelper(c *cli.Context, shouldPrintProgress bool) {
 	startFn := func() {
 		tcCtx, cancel := newContext(c)
 		defer cancel()
+		tcCtx = withCompletionCallbackURLs(tcCtx, c)
 		resp, err := serviceClient.StartWorkflowExecution(tcCtx, startRequest)
 
 		if err != nil {
@@ -243,6 +249,7 @@ func startWorkflowHelper(c *cli.Context, shouldPrintProgress bool) {
 	runFn := func() {
 		tcCtx, cancel := newContextForLongPoll(c)
 		defer cancel()
+		tcCtx = withCompletionCallbackURLs(tcCtx, c)
 		resp, err := serviceClient.StartWorkflowExecution(tcCtx, startRequest)
 
 		if err != nil {
@@ -258,14 +265,17 @@ func startWorkflowHelper(c *cli.Context, shouldPrintProgress bool) {
 			{"Type", workflowType},
 			{"Namespace", namespace},
 			{"Task List", taskList},
-			{"Args", truncate(payloads.ToString(input))}, // in case of large input
+			{"Args", truncate(payloads.ToString(decodePayloads(input)))}, // in case of large input
 		}
 		table.SetBorder(false)
 		table.SetColumnSeparator(":")
 		table.AppendBulk(executionData) // Add Bulk Data
 		table.Render()
 
-		printWorkflowProgress(c, wid, resp.GetRunId())
+		wfClient := getWorkflowClient(c)
+		printWorkflowProgress(c, func(ctx context.Context) client.HistoryEventIterator {
+			return wfClient.GetWorkflowHistory(ctx, wid, resp.GetRunId(), true, filterpb.HistoryEventFilterType_AllEvent)
+		})
 	}
 
 	if shouldPrintProgress {
@@ -370,10 +380,9 @@ func getPrintableSearchAttr(searchAttr *commonpb.SearchAttributes) string {
 }
 
 // helper function to print workflow progress with time refresh every second
-func printWorkflowProgress(c *cli.Context, wid, rid string) {
+func printWorkflowProgress(c *cli.Context, getHistory func(ctx context.Context) client.HistoryEventIterator) {
 	fmt.Println(colorMagenta("Progress:"))
 
-	wfClient := getWorkflowClient(c)
 	timeElapse := 1
 	isTimeElapseExist := false
 	doneChan := make(chan bool)
@@ -390,7 +399,7 @@ func printWorkflowProgress(c *cli.Context, wid, rid string) {
 	}
 
 	go func() {
-		iter := wfClient.GetWorkflowHistory(tcCtx, wid, rid, true, filterpb.HistoryEventFilterType_AllEvent)
+		iter := getHistory(tcCtx)
 		for iter.HasNext() {
 			event, err := iter.Next()
 			if err != nil {
@@ -847,13 +856,14 @@ func describeWorkflowHelper(c *cli.Context, wid, rid string) {
 	ctx, cancel := newContext(c)
 	defer cancel()
 
+	var header metadata.MD
 	resp, err := frontendClient.DescribeWorkflowExecution(ctx, &workflowservice.DescribeWorkflowExecutionRequest{
 		Namespace: namespace,
 		Execution: &commonpb.WorkflowExecution{
 			WorkflowId: wid,
 			RunId:      rid,
 		},
-	})
+	}, grpc.Header(&header))
 	if err != nil {
 		ErrorAndExit("Describe workflow execution failed", err)
 	}
@@ -866,8 +876,30 @@ func describeWorkflowHelper(c *cli.Context, wid, rid string) {
 	if printRaw {
 		prettyPrintJSONObject(resp)
 	} else {
-		prettyPrintJSONObject(convertDescribeWorkflowExecutionResponse(resp, frontendClient, c))
+		describeResp := convertDescribeWorkflowExecutionResponse(resp, frontendClient, c)
+		describeResp.CompletionCallbacks = getCompletionCallbacks(header)
+		prettyPrintJSONObject(describeResp)
+	}
+}
+
+func withCompletionCallbackURLs(ctx context.Context, c *cli.Context) context.Context {
+	for _, callbackURL := range c.StringSlice(FlagCompletionCallbackURL) {
+		ctx = metadata.AppendToOutgoingContext(ctx, headers.CompletionCallbackURLHeaderName, callbackURL)
+	}
+	return ctx
+}
+
+func getCompletionCallbacks(header metadata.MD) []*executiongenpb.CompletionCallbackInfo {
+	var callbacks []*executiongenpb.CompletionCallbackInfo
+	encoder := codec.NewJSONPBEncoder()
+	for _, state := range header.Get(headers.CompletionCallbackStateHeaderName) {
+		callback := &executiongenpb.CompletionCallbackInfo{}
+		if err := encoder.Decode([]byte(state), callback); err != nil {
+			ErrorAndExit("Unable to decode completion callback state.", err)
+		}
+		callbacks = append(callbacks, callback)
 	}
+	return callbacks
 }
 
 func printAutoResetPoints(resp *workflowservice.DescribeWorkflowExecutionResponse) {
@@ -1366,7 +1398,7 @@ func ObserveHistory(c *cli.Context) {
 	wid := getRequiredOption(c, FlagWorkflowID)
 	rid := c.String(FlagRunID)
 
-	printWorkflowProgress(c, wid, rid)
+	observeHistory(c, wid, rid)
 }
 
 // ResetWorkflow reset workflow
@@ -1402,7 +1434,7 @@ func ResetWorkflow(c *cli.Context) {
 			ErrorAndExit("getResetEventIDByType failed", err)
 		}
 	}
-	resp, err := frontendClient.ResetWorkflowExecution(ctx, &workflowservice.ResetWorkflowExecutionRequest{
+	resp, err := cFactory.AdminClient(c).ResetWorkflowExecution(ctx, newResetRequestWithOptions(c, resetType, &workflowservice.ResetWorkflowExecutionRequest{
 		Namespace: namespace,
 		WorkflowExecution: &commonpb.WorkflowExecution{
 			WorkflowId: wid,
@@ -1411,7 +1443,7 @@ func ResetWorkflow(c *cli.Context) {
 		Reason:                fmt.Sprintf("%v:%v", getCurrentUserFromEnv(), reason),
 		DecisionFinishEventId: decisionFinishID,
 		RequestId:             uuid.New(),
-	})
+	}))
 	if err != nil {
 		ErrorAndExit("reset failed", err)
 	}
@@ -1667,7 +1699,7 @@ func doReset(c *cli.Context, namespace, wid, rid string, params batchResetParams
 	if params.dryRun {
 		fmt.Printf("dry run to reset wid: %v, rid:%v to baseRunId:%v, eventId:%v \n", wid, rid, resetBaseRunID, decisionFinishID)
 	} else {
-		resp2, err := frontendClient.ResetWorkflowExecution(ctx, &workflowservice.ResetWorkflowExecutionRequest{
+		resp2, err := cFactory.AdminClient(c).ResetWorkflowExecution(ctx, newResetRequestWithOptions(c, params.resetType, &workflowservice.ResetWorkflowExecutionRequest{
 			Namespace: namespace,
 			WorkflowExecution: &commonpb.WorkflowExecution{
 				WorkflowId: wid,
@@ -1676,7 +1708,7 @@ func doReset(c *cli.Context, namespace, wid, rid string, params batchResetParams
 			DecisionFinishEventId: decisionFinishID,
 			RequestId:             uuid.New(),
 			Reason:                fmt.Sprintf("%v:%v", getCurrentUserFromEnv(), params.reason),
-		})
+		}))
 
 		if err != nil {
 			return printErrorAndReturn("ResetWorkflowExecution failed", err)
@@ -1758,12 +1790,34 @@ func getResetEventIDByType(ctx context.Context, c *cli.Context, resetType, names
 		if err != nil {
 			return
 		}
+	case "LastRunStart":
+		// server resolves the last run of the continue as new chain and its first completed decision
+		resetBaseRunID = rid
 	default:
 		panic("not supported resetType")
 	}
 	return
 }
 
+// newResetRequestWithOptions wraps the reset request into the admin reset request, which carries
+// the reset options that ResetWorkflowExecutionRequest has no field for
+func newResetRequestWithOptions(
+	c *cli.Context,
+	resetType string,
+	request *workflowservice.ResetWorkflowExecutionRequest,
+) *adminservice.ResetWorkflowExecutionRequest {
+	reapplyType, err := common.ParseResetReapplyType(c.String(FlagResetReapplyType))
+	if err != nil {
+		ErrorAndExit("Invalid reapply type", err)
+	}
+	return &adminservice.ResetWorkflowExecutionRequest{
+		ResetRequest:        request,
+		ReapplyType:         reapplyType,
+		ReapplySignalNames:  c.StringSlice(FlagResetReapplySignalName),
+		ResetToLastRunStart: resetType == "LastRunStart",
+	}
+}
+
 func getLastDecisionCompletedID(ctx context.Context, namespace, wid, rid string, frontendClient workflowservice.WorkflowServiceClient) (resetBaseRunID string, decisionFinishID int64, err error) {
 	resetBaseRunID = rid
 	req := &workflowservice.GetWorkflowExecutionHistoryRequest{
@@ -1992,5 +2046,15 @@ func ObserveHistoryWithID(c *cli.Context) {
 		rid = c.Args().Get(1)
 	}
 
-	printWorkflowProgress(c, wid, rid)
+	observeHistory(c, wid, rid)
+}
+
+// observeHistory prints the history events of the workflow run as they are streamed by the server
+func observeHistory(c *cli.Context, wid, rid string) {
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	streamClient := cFactory.WorkflowStreamClient(c)
+
+	printWorkflowProgress(c, func(ctx context.Context) client.HistoryEventIterator {
+		return newHistoryStreamIterator(ctx, streamClient, namespace, wid, rid)
+	})
 }
@@ -43,8 +43,18 @@ func ParseFile(filePath string) ([]string, error) {
 		return nil, err
 	}
 
-	reader := bufio.NewReader(f)
+	defer f.Close()
 
+	return ParseStatements(f)
+}
+
+// ParseStatements takes a reader of cql / sql
+// statements as input and returns an array of
+// cql / sql statements on success.
+func ParseStatements(r io.Reader) ([]string, error) {
+	reader := bufio.NewReader(r)
+
+	var err error
 	var line string
 	var currStmt string
 	var stmts = make([]string, 0, 4)